@@ -9,6 +9,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"log"
 	"net"
@@ -20,6 +21,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/syncthing/syncthing/lib/build"
 	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/tlsutil"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/thejerf/suture/v4"
@@ -77,6 +79,8 @@ func main() {
 	var certFile string
 	var keyFile string
 	var useHTTP bool
+	var upgradeInterval time.Duration
+	var releasesURL string
 
 	log.SetOutput(os.Stdout)
 	log.SetFlags(0)
@@ -90,6 +94,8 @@ func main() {
 	flag.StringVar(&metricsListen, "metrics-listen", "", "Metrics listen address")
 	flag.StringVar(&replicationPeers, "replicate", "", "Replication peers, id@address, comma separated")
 	flag.StringVar(&replicationListen, "replication-listen", ":19200", "Replication listen address")
+	flag.DurationVar(&upgradeInterval, "upgrade-interval", 0, "How often to check for and install signed upgrades, exiting to be restarted by the service manager after one (zero to disable)")
+	flag.StringVar(&releasesURL, "releases-url", "https://upgrades.syncthing.net/meta.json", "Where to look for upgrades")
 	showVersion := flag.Bool("version", false, "Show version")
 	flag.Parse()
 
@@ -183,6 +189,14 @@ func main() {
 		}()
 	}
 
+	if upgradeInterval > 0 {
+		main.Add(&upgrader{interval: upgradeInterval, releasesURL: releasesURL})
+	}
+
 	// Engage!
-	main.Serve(context.Background())
+	err = main.Serve(context.Background())
+	var fatalErr *svcutil.FatalErr
+	if errors.As(err, &fatalErr) {
+		os.Exit(fatalErr.Status.AsInt())
+	}
 }
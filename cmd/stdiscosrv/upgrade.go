@@ -0,0 +1,57 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/svcutil"
+	"github.com/syncthing/syncthing/lib/upgrade"
+)
+
+var upgradeProgram = upgrade.Program{
+	Name:       "stdiscosrv",
+	SigningKey: upgrade.SigningKey,
+}
+
+// The upgrader periodically upgrades the binary to the latest release. It
+// then terminates the service tree, so that the process exits with
+// svcutil.ExitUpgrade to be restarted by the service manager.
+type upgrader struct {
+	interval    time.Duration
+	releasesURL string
+}
+
+func (u *upgrader) Serve(ctx context.Context) error {
+	timer := time.NewTimer(u.interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		rel, err := upgradeProgram.ToLatest(u.releasesURL, build.Version, false)
+		switch err {
+		case nil:
+			log.Printf("Upgraded to %s, exiting to restart", rel.Tag)
+			return svcutil.AsFatalErr(fmt.Errorf("upgraded to %s", rel.Tag), svcutil.ExitUpgrade)
+		case upgrade.ErrNoUpgradeAvailable:
+		case upgrade.ErrUpgradeUnsupported:
+			log.Println("Automatic upgrade:", err)
+			return svcutil.NoRestartErr(err)
+		default:
+			log.Println("Automatic upgrade:", err)
+		}
+		timer.Reset(u.interval)
+	}
+}
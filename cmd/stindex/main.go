@@ -17,11 +17,12 @@ import (
 )
 
 func main() {
-	var mode string
+	var mode, to string
 	log.SetFlags(0)
 	log.SetOutput(os.Stdout)
 
-	flag.StringVar(&mode, "mode", "dump", "Mode of operation: dump, dumpsize, idxck")
+	flag.StringVar(&mode, "mode", "dump", "Mode of operation: dump, dumpsize, idxck, convert")
+	flag.StringVar(&to, "to", "badger", "Database type to convert to: leveldb, badger")
 
 	flag.Parse()
 
@@ -30,6 +31,13 @@ func main() {
 		path = filepath.Join(defaultConfigDir(), "index-v0.14.0.db")
 	}
 
+	if mode == "convert" {
+		if err := convert(path, to); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var ldb backend.Backend
 	var err error
 	if looksLikeBadger(path) {
@@ -57,6 +65,26 @@ func main() {
 	}
 }
 
+// convert converts the database at the given LevelDB path, or the Badger
+// database next to it, to the given type. Syncthing must not be running and
+// should be configured to use the new type before it is started again.
+func convert(path, to string) error {
+	var typ backend.Type
+	switch to {
+	case "leveldb":
+		typ = backend.TypeLevelDB
+	case "badger":
+		typ = backend.TypeBadger
+	default:
+		return fmt.Errorf("unknown database type %q", to)
+	}
+	ldb, err := backend.Open(path, typ, backend.TuningAuto)
+	if err != nil {
+		return err
+	}
+	return ldb.Close()
+}
+
 func looksLikeBadger(path string) bool {
 	_, err := os.Stat(filepath.Join(path, "KEYREGISTRY"))
 	return err == nil
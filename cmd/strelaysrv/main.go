@@ -24,7 +24,9 @@ import (
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/relay/protocol"
+	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/tlsutil"
+	"github.com/syncthing/syncthing/lib/upgrade"
 	"golang.org/x/time/rate"
 
 	"github.com/syncthing/syncthing/lib/config"
@@ -68,8 +70,16 @@ var (
 	natTimeout int
 
 	pprofEnabled bool
+
+	upgradeInterval time.Duration
+	releasesURL     string
 )
 
+var upgradeProgram = upgrade.Program{
+	Name:       "strelaysrv",
+	SigningKey: upgrade.SigningKey,
+}
+
 // httpClient is the HTTP client we use for outbound requests. It has a
 // timeout and may get further options set during initialization.
 var httpClient = &http.Client{
@@ -100,6 +110,8 @@ func main() {
 	flag.IntVar(&natTimeout, "nat-timeout", 10, "NAT discovery timeout in seconds")
 	flag.BoolVar(&pprofEnabled, "pprof", false, "Enable the built in profiling on the status server")
 	flag.IntVar(&networkBufferSize, "network-buffer", 2048, "Network buffer size (two of these per proxied connection)")
+	flag.DurationVar(&upgradeInterval, "upgrade-interval", 0, "How often to check for and install signed upgrades, exiting to be restarted by the service manager after one (zero to disable)")
+	flag.StringVar(&releasesURL, "releases-url", "https://upgrades.syncthing.net/meta.json", "Where to look for upgrades")
 	showVersion := flag.Bool("version", false, "Show version")
 	flag.Parse()
 
@@ -253,9 +265,19 @@ func main() {
 
 	go listener(proto, listen, tlsCfg)
 
+	upgraded := make(chan struct{})
+	if upgradeInterval > 0 {
+		go autoUpgrade(upgradeInterval, upgraded)
+	}
+
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	<-sigs
+	exitStatus := svcutil.ExitSuccess
+	select {
+	case <-sigs:
+	case <-upgraded:
+		exitStatus = svcutil.ExitUpgrade
+	}
 
 	// Gracefully close all connections, hoping that clients will be faster
 	// to realize that the relay is now gone.
@@ -277,6 +299,30 @@ func main() {
 	outboxesMut.RUnlock()
 
 	time.Sleep(500 * time.Millisecond)
+	if exitStatus != svcutil.ExitSuccess {
+		os.Exit(exitStatus.AsInt())
+	}
+}
+
+// autoUpgrade periodically upgrades the binary to the latest release,
+// closing upgraded once it has done so.
+func autoUpgrade(interval time.Duration, upgraded chan<- struct{}) {
+	for {
+		time.Sleep(interval)
+		rel, err := upgradeProgram.ToLatest(releasesURL, build.Version, false)
+		switch err {
+		case nil:
+			log.Printf("Upgraded to %s, exiting to restart", rel.Tag)
+			close(upgraded)
+			return
+		case upgrade.ErrNoUpgradeAvailable:
+		case upgrade.ErrUpgradeUnsupported:
+			log.Println("Automatic upgrade:", err)
+			return
+		default:
+			log.Println("Automatic upgrade:", err)
+		}
+	}
 }
 
 func monitorLimits() {
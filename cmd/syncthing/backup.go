@@ -0,0 +1,107 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/syncthing/syncthing/lib/backup"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/syncthing"
+)
+
+// backupCmd is the `syncthing backup` command. It exports the device
+// certificate and key, the configuration and optionally the database as
+// one encrypted file, and imports such a file into a fresh installation to
+// migrate or restore the device.
+type backupCmd struct {
+	Export backupExportCmd `cmd:"" help:"Export a backup while Syncthing isn't running (see also syncthing cli operations backup)"`
+	Import backupImportCmd `cmd:"" help:"Import a backup into a fresh installation"`
+}
+
+type backupDirs struct {
+	HomeDir string `name:"home" placeholder:"PATH" help:"Set configuration and data directory"`
+	ConfDir string `name:"conf" placeholder:"PATH" help:"Set configuration directory (config and keys)"`
+	DataDir string `name:"data" placeholder:"PATH" help:"Set data directory (database and logs)"`
+}
+
+type backupExportCmd struct {
+	backupDirs
+	File     string `arg:"" help:"File to write the backup to"`
+	Password string `help:"Password to encrypt the backup with" env:"STBACKUP_PASSWORD"`
+	Database bool   `help:"Include the database, so that folders don't need to be rescanned"`
+}
+
+func (c backupExportCmd) Run() error {
+	if err := setBaseDirs(c.HomeDir, c.ConfDir, c.DataDir); err != nil {
+		return err
+	}
+	if c.Password == "" {
+		return backup.ErrNoPassword
+	}
+
+	var db backend.Reader
+	if c.Database {
+		path := locations.Get(locations.Database)
+		ll, err := syncthing.OpenDBBackend(path, syncthing.DetectDBBackend(path), config.TuningAuto)
+		if err != nil {
+			return fmt.Errorf("opening database (is Syncthing running?): %w", err)
+		}
+		defer ll.Close()
+		db = ll
+	}
+
+	fd, err := os.OpenFile(c.File, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if err := backup.Export(fd, c.Password, locations.Default(), db); err != nil {
+		fd.Close()
+		os.Remove(c.File)
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	fmt.Println("Backup written to", c.File)
+	return nil
+}
+
+type backupImportCmd struct {
+	backupDirs
+	File     string `arg:"" help:"Backup file to import"`
+	Password string `help:"Password the backup was encrypted with" env:"STBACKUP_PASSWORD"`
+}
+
+func (c backupImportCmd) Run() error {
+	if err := setBaseDirs(c.HomeDir, c.ConfDir, c.DataDir); err != nil {
+		return err
+	}
+	if c.Password == "" {
+		return backup.ErrNoPassword
+	}
+
+	fd, err := os.Open(c.File)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	res, err := backup.Import(fd, c.Password, locations.Default())
+	if err != nil {
+		return err
+	}
+	fmt.Println("Imported device", res.DeviceID)
+	if !res.Database {
+		fmt.Println("The backup has no database; folders will be scanned and synced from scratch")
+	}
+	fmt.Println("Check the folder paths in the configuration before starting Syncthing")
+	return nil
+}
@@ -25,11 +25,12 @@ type APIClient struct {
 	apikey string
 }
 
-func getClient(cfg config.GUIConfiguration) *APIClient {
+func getClient(cfg config.GUIConfiguration, certs []tls.Certificate) *APIClient {
 	httpClient := http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: true,
+				Certificates:       certs,
 			},
 			DialContext: func(_ context.Context, _, _ string) (net.Conn, error) {
 				return net.Dial(cfg.Network(), cfg.Address())
@@ -45,6 +46,9 @@ func getClient(cfg config.GUIConfiguration) *APIClient {
 
 func (c *APIClient) Endpoint() string {
 	if c.cfg.Network() == "unix" {
+		if c.cfg.UseTLS() {
+			return "https://unix/"
+		}
 		return "http://unix/"
 	}
 	url := c.cfg.URL()
@@ -79,6 +83,22 @@ func (c *APIClient) Post(url, body string) (*http.Response, error) {
 	return c.Do(request)
 }
 
+func (c *APIClient) Patch(url, body string) (*http.Response, error) {
+	request, err := http.NewRequest("PATCH", c.Endpoint()+"rest/"+url, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(request)
+}
+
+func (c *APIClient) Delete(url string) (*http.Response, error) {
+	request, err := http.NewRequest("DELETE", c.Endpoint()+"rest/"+url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(request)
+}
+
 func checkResponse(response *http.Response) error {
 	if response.StatusCode == 404 {
 		return errors.New("invalid endpoint or API call")
@@ -0,0 +1,137 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/urfave/cli"
+)
+
+var devicesCommand = cli.Command{
+	Name:     "devices",
+	HideHelp: true,
+	Usage:    "Device command group",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List devices and whether they are connected",
+			Action: expects(0, devicesList),
+		},
+		{
+			Name:      "add",
+			Usage:     "Add a device",
+			ArgsUsage: "[device id]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "name",
+					Usage: "Name of the device",
+				},
+				cli.StringSliceFlag{
+					Name:  "address",
+					Usage: "Address of the device, \"dynamic\" if none are given (may be repeated)",
+				},
+			},
+			Action: expects(1, devicesAdd),
+		},
+		{
+			Name:      "remove",
+			Usage:     "Remove a device",
+			ArgsUsage: "[device id]",
+			Action:    expects(1, devicesRemove),
+		},
+		{
+			Name:      "completion",
+			Usage:     "Show how far along syncing the folders shared with a device is on it",
+			ArgsUsage: "[device id]",
+			Action:    expects(1, devicesCompletion),
+		},
+	},
+}
+
+func devicesList(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	var devices []config.DeviceConfiguration
+	if err := getJSON(client, "config/devices", &devices); err != nil {
+		return err
+	}
+	var connections struct {
+		Connections map[string]struct {
+			Connected bool   `json:"connected"`
+			Address   string `json:"address"`
+		} `json:"connections"`
+	}
+	if err := getJSON(client, "system/connections", &connections); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tName\tPaused\tConnected\tAddresses")
+	for _, device := range devices {
+		conn, ok := connections.Connections[device.DeviceID.String()]
+		connected := "-" // this device
+		if ok {
+			connected = fmt.Sprint(conn.Connected)
+			if conn.Connected {
+				connected = conn.Address
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%s\t%s\n", device.DeviceID, device.Name, device.Paused, connected, strings.Join(device.Addresses, ", "))
+	}
+	return tw.Flush()
+}
+
+func devicesAdd(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	id, err := protocol.DeviceIDFromString(c.Args()[0])
+	if err != nil {
+		return err
+	}
+	var devices []config.DeviceConfiguration
+	if err := getJSON(client, "config/devices", &devices); err != nil {
+		return err
+	}
+	if hasDevice(devices, id) {
+		return fmt.Errorf("device %s already exists", id)
+	}
+	addresses := c.StringSlice("address")
+	if len(addresses) == 0 {
+		addresses = []string{"dynamic"}
+	}
+	return sendJSON(client.Post, "config/devices", map[string]interface{}{
+		"deviceID":  id,
+		"name":      c.String("name"),
+		"addresses": addresses,
+	})
+}
+
+func devicesRemove(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	id, err := protocol.DeviceIDFromString(c.Args()[0])
+	if err != nil {
+		return err
+	}
+	_, err = client.Delete("config/devices/" + id.String())
+	return err
+}
+
+func devicesCompletion(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	id, err := protocol.DeviceIDFromString(c.Args()[0])
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	query.Set("device", id.String())
+	return printCompletion(client, query)
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/urfave/cli"
+)
+
+var foldersCommand = cli.Command{
+	Name:     "folders",
+	HideHelp: true,
+	Usage:    "Folder command group",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List folders",
+			Action: expects(0, foldersList),
+		},
+		{
+			Name:      "add",
+			Usage:     "Add a folder",
+			ArgsUsage: "[folder id] [path]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "label",
+					Usage: "Label of the folder",
+				},
+				cli.StringFlag{
+					Name:  "type",
+					Value: config.FolderTypeSendReceive.String(),
+					Usage: "Folder type: sendreceive, sendonly, receiveonly or receiveencrypted",
+				},
+			},
+			Action: expects(2, foldersAdd),
+		},
+		{
+			Name:      "remove",
+			Usage:     "Remove a folder, leaving its files alone",
+			ArgsUsage: "[folder id]",
+			Action:    expects(1, foldersRemove),
+		},
+		{
+			Name:      "share",
+			Usage:     "Share a folder with a device",
+			ArgsUsage: "[folder id] [device id]",
+			Action:    expects(2, foldersShare(true)),
+		},
+		{
+			Name:      "unshare",
+			Usage:     "Stop sharing a folder with a device",
+			ArgsUsage: "[folder id] [device id]",
+			Action:    expects(2, foldersShare(false)),
+		},
+		{
+			Name:      "status",
+			Usage:     "Show the state of a folder",
+			ArgsUsage: "[folder id]",
+			Action:    expects(1, foldersStatus),
+		},
+		{
+			Name:      "completion",
+			Usage:     "Show how far along syncing a folder is, locally or on a device",
+			ArgsUsage: "[folder id]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "device",
+					Usage: "Device to show the completion of, rather than this one",
+				},
+			},
+			Action: expects(1, foldersCompletion),
+		},
+		{
+			Name:      "rescan",
+			Usage:     "Rescan a folder, or all folders without a folder id",
+			ArgsUsage: "[folder id]",
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  "sub",
+					Usage: "Rescan only this path within the folder (may be repeated)",
+				},
+			},
+			Action: foldersRescan,
+		},
+	},
+}
+
+func foldersList(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	var folders []config.FolderConfiguration
+	if err := getJSON(client, "config/folders", &folders); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tLabel\tType\tDevices\tPaused\tPath")
+	for _, folder := range folders {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%v\t%s\n", folder.ID, folder.Label, folder.Type, len(folder.Devices), folder.Paused, folder.Path)
+	}
+	return tw.Flush()
+}
+
+func foldersAdd(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	id, path := c.Args()[0], c.Args()[1]
+	if _, err := getFolder(client, id); err == nil {
+		return fmt.Errorf("folder %s already exists", id)
+	}
+	var folderType config.FolderType
+	if err := folderType.UnmarshalText([]byte(c.String("type"))); err != nil {
+		return err
+	}
+	return sendJSON(client.Post, "config/folders", map[string]interface{}{
+		"id":    id,
+		"label": c.String("label"),
+		"path":  path,
+		"type":  folderType,
+	})
+}
+
+func foldersRemove(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	id := c.Args()[0]
+	if _, err := getFolder(client, id); err != nil {
+		return err
+	}
+	_, err := client.Delete("config/folders/" + url.PathEscape(id))
+	return err
+}
+
+func foldersShare(share bool) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		client := c.App.Metadata["client"].(*APIClient)
+		folder, err := getFolder(client, c.Args()[0])
+		if err != nil {
+			return err
+		}
+		device, err := protocol.DeviceIDFromString(c.Args()[1])
+		if err != nil {
+			return err
+		}
+
+		devices := make([]config.FolderDeviceConfiguration, 0, len(folder.Devices)+1)
+		shared := false
+		for _, dev := range folder.Devices {
+			if dev.DeviceID == device {
+				shared = true
+				if !share {
+					continue
+				}
+			}
+			devices = append(devices, dev)
+		}
+		switch {
+		case share && shared:
+			return nil
+		case !share && !shared:
+			return fmt.Errorf("folder %s isn't shared with %s", folder.ID, device)
+		case share:
+			var known []config.DeviceConfiguration
+			if err := getJSON(client, "config/devices", &known); err != nil {
+				return err
+			}
+			if !hasDevice(known, device) {
+				return fmt.Errorf("device %s isn't configured, add it first", device)
+			}
+			devices = append(devices, config.FolderDeviceConfiguration{DeviceID: device})
+		}
+
+		return sendJSON(client.Patch, "config/folders/"+url.PathEscape(folder.ID), map[string]interface{}{
+			"devices": devices,
+		})
+	}
+}
+
+func foldersStatus(c *cli.Context) error {
+	return dumpOutput("db/status?folder=" + url.QueryEscape(c.Args()[0]))(c)
+}
+
+func foldersCompletion(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	query := url.Values{}
+	query.Set("folder", c.Args()[0])
+	if device := c.String("device"); device != "" {
+		query.Set("device", device)
+	}
+	return printCompletion(client, query)
+}
+
+func foldersRescan(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	switch c.NArg() {
+	case 0:
+		if len(c.StringSlice("sub")) > 0 {
+			return errors.New("rescanning subdirectories needs a folder id")
+		}
+		_, err := client.Post("db/scan", "")
+		return err
+	case 1:
+		query := url.Values{}
+		query.Set("folder", c.Args()[0])
+		for _, sub := range c.StringSlice("sub") {
+			query.Add("sub", sub)
+		}
+		_, err := client.Post("db/scan?"+query.Encode(), "")
+		return err
+	default:
+		return fmt.Errorf("expected at most 1 argument, got %d", c.NArg())
+	}
+}
+
+// getFolder returns the configuration of the folder, or an error if there's
+// no such folder.
+func getFolder(client *APIClient, id string) (config.FolderConfiguration, error) {
+	var folders []config.FolderConfiguration
+	if err := getJSON(client, "config/folders", &folders); err != nil {
+		return config.FolderConfiguration{}, err
+	}
+	for _, folder := range folders {
+		if folder.ID == id {
+			return folder, nil
+		}
+	}
+	return config.FolderConfiguration{}, fmt.Errorf("folder %s not found", id)
+}
+
+func hasDevice(devices []config.DeviceConfiguration, id protocol.DeviceID) bool {
+	for _, dev := range devices {
+		if dev.DeviceID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// printCompletion shows the completion for the folder and/or device in the
+// query.
+func printCompletion(client *APIClient, query url.Values) error {
+	var comp struct {
+		Completion       float64  `json:"completion"`
+		GlobalBytes      int64    `json:"globalBytes"`
+		NeedBytes        int64    `json:"needBytes"`
+		NeedItems        int64    `json:"needItems"`
+		NeedDeletes      int64    `json:"needDeletes"`
+		RemainingSeconds *float64 `json:"remainingSeconds"`
+	}
+	if err := getJSON(client, "db/completion?"+query.Encode(), &comp); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Completion:\t%.2f%%\n", comp.Completion)
+	fmt.Fprintf(tw, "Needed:\t%d items, %d deletes, %d of %d bytes\n", comp.NeedItems, comp.NeedDeletes, comp.NeedBytes, comp.GlobalBytes)
+	if comp.RemainingSeconds != nil {
+		fmt.Fprintf(tw, "Remaining time:\t%v\n", (time.Duration(*comp.RemainingSeconds) * time.Second).Round(time.Second))
+	}
+	return tw.Flush()
+}
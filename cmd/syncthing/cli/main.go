@@ -31,6 +31,8 @@ import (
 type CLI struct {
 	GUIAddress string   `name:"gui-address" placeholder:"URL" help:"Override GUI address (e.g. \"http://192.0.2.42:8443\")"`
 	GUIAPIKey  string   `name:"gui-apikey" placeholder:"API-KEY" help:"Override GUI API key"`
+	GUICert    string   `name:"gui-client-cert" placeholder:"PATH" help:"Client certificate for a GUI that requires one"`
+	GUIKey     string   `name:"gui-client-key" placeholder:"PATH" help:"Key of the client certificate"`
 	HomeDir    string   `name:"home" placeholder:"PATH" help:"Set configuration and data directory"`
 	ConfDir    string   `name:"conf" placeholder:"PATH" help:"Set configuration directory (config and keys)"`
 	Args       []string `arg:"" optional:""`
@@ -79,19 +81,28 @@ func (c *CLI) Run() error {
 		}
 
 		guiCfg = cfg.GUI()
-	} else if guiCfg.Address() == "" || guiCfg.APIKey == "" {
-		return errors.New("Both --gui-address and --gui-apikey should be specified")
+	} else if guiCfg.Address() == "" || (guiCfg.APIKey == "" && c.GUICert == "") {
+		return errors.New("Both --gui-address and --gui-apikey (or --gui-client-cert) should be specified")
 	}
 
 	if guiCfg.Address() == "" {
 		return errors.New("Could not find GUI Address")
 	}
 
-	if guiCfg.APIKey == "" {
+	if guiCfg.APIKey == "" && c.GUICert == "" {
 		return errors.New("Could not find GUI API key")
 	}
 
-	client := getClient(guiCfg)
+	var clientCerts []tls.Certificate
+	if c.GUICert != "" || c.GUIKey != "" {
+		cert, err := tls.LoadX509KeyPair(c.GUICert, c.GUIKey)
+		if err != nil {
+			return errors.Wrap(err, "reading client certificate")
+		}
+		clientCerts = append(clientCerts, cert)
+	}
+
+	client := getClient(guiCfg, clientCerts)
 
 	cfg, err := getConfig(client)
 	original := cfg.Copy()
@@ -122,6 +133,16 @@ func (c *CLI) Run() error {
 			Value: "API-KEY",
 			Usage: "Override GUI API key",
 		},
+		cli.StringFlag{
+			Name:  "gui-client-cert",
+			Value: "PATH",
+			Usage: "Client certificate for a GUI that requires one",
+		},
+		cli.StringFlag{
+			Name:  "gui-client-key",
+			Value: "PATH",
+			Usage: "Key of the client certificate",
+		},
 		cli.StringFlag{
 			Name:  "home",
 			Value: "PATH",
@@ -153,6 +174,8 @@ func (c *CLI) Run() error {
 		},
 		showCommand,
 		operationCommand,
+		foldersCommand,
+		devicesCommand,
 		errorsCommand,
 	}
 
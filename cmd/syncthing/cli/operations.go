@@ -7,7 +7,11 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
 
 	"github.com/urfave/cli"
 )
@@ -37,12 +41,51 @@ var operationCommand = cli.Command{
 			Usage:  "Upgrade syncthing (if a newer version is available)",
 			Action: expects(0, emptyPost("system/upgrade")),
 		},
+		{
+			Name:   "freeze",
+			Usage:  "Stop making changes to folders, for maintenance",
+			Action: expects(0, emptyPost("system/freeze")),
+		},
+		{
+			Name:   "unfreeze",
+			Usage:  "Lift a maintenance freeze",
+			Action: expects(0, emptyPost("system/unfreeze")),
+		},
 		{
 			Name:      "folder-override",
 			Usage:     "Override changes on folder (remote for sendonly, local for receiveonly)",
 			ArgsUsage: "[folder id]",
 			Action:    expects(1, foldersOverride),
 		},
+		{
+			Name:      "folder-verify",
+			Usage:     "Re-hash local files and report those that don't match the index",
+			ArgsUsage: "[folder id]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "repair",
+					Usage: "Pull corrupted files again from devices that have them",
+				},
+			},
+			Action: expects(1, folderVerify),
+		},
+		{
+			Name:      "backup",
+			Usage:     "Export the device identity, configuration and optionally database to an encrypted file",
+			ArgsUsage: "[file]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "password",
+					Usage:  "Password to encrypt the backup with",
+					EnvVar: "STBACKUP_PASSWORD",
+				},
+				cli.BoolFlag{
+					Name:  "database",
+					Usage: "Include the database, so that folders don't need to be rescanned",
+				},
+			},
+			Action: expects(1, backupExport),
+		},
 	},
 }
 
@@ -55,7 +98,7 @@ func foldersOverride(c *cli.Context) error {
 	rid := c.Args()[0]
 	for _, folder := range cfg.Folders {
 		if folder.ID == rid {
-			response, err := client.Post("db/override", "")
+			response, err := client.Post("db/override?folder="+url.QueryEscape(rid), "")
 			if err != nil {
 				return err
 			}
@@ -76,3 +119,43 @@ func foldersOverride(c *cli.Context) error {
 	}
 	return fmt.Errorf("Folder " + rid + " not found")
 }
+
+func backupExport(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	body, err := json.Marshal(map[string]interface{}{
+		"password": c.String("password"),
+		"database": c.Bool("database"),
+	})
+	if err != nil {
+		return err
+	}
+	response, err := client.Post("system/backup", string(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	fd, err := os.OpenFile(c.Args()[0], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fd, response.Body); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+func folderVerify(c *cli.Context) error {
+	client := c.App.Metadata["client"].(*APIClient)
+	query := url.Values{}
+	query.Set("folder", c.Args()[0])
+	if c.Bool("repair") {
+		query.Set("repair", "true")
+	}
+	response, err := client.Post("db/verify?"+query.Encode(), "")
+	if err != nil {
+		return err
+	}
+	return prettyPrintResponse(c, response)
+}
@@ -35,10 +35,20 @@ var showCommand = cli.Command{
 			Usage:  "Report about connections to other devices",
 			Action: expects(0, dumpOutput("system/connections")),
 		},
+		{
+			Name:   "relays",
+			Usage:  "Report the results of probing the relays of relay pools",
+			Action: expects(0, dumpOutput("system/relays")),
+		},
 		{
 			Name:   "usage",
 			Usage:  "Show usage report",
 			Action: expects(0, dumpOutput("svc/report")),
 		},
+		{
+			Name:   "upgrade",
+			Usage:  "Show whether a newer version is available",
+			Action: expects(0, dumpOutput("system/upgrade")),
+		},
 	},
 }
@@ -44,6 +44,32 @@ func dumpOutput(url string) cli.ActionFunc {
 	}
 }
 
+// getJSON gets the url and decodes the JSON response into v.
+func getJSON(c *APIClient, url string, v interface{}) error {
+	response, err := c.Get(url)
+	if err != nil {
+		return err
+	}
+	bytes, err := responseToBArray(response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, v)
+}
+
+// sendJSON sends v as JSON with the given method, e.g. client.Post.
+func sendJSON(send func(url, body string) (*http.Response, error), url string, v interface{}) error {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	response, err := send(url, string(bs))
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
+
 func getConfig(c *APIClient) (config.Configuration, error) {
 	cfg := config.Configuration{}
 	response, err := c.Get("system/config")
@@ -0,0 +1,453 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/syncthing"
+	"github.com/syncthing/syncthing/lib/tlsutil"
+)
+
+const (
+	// Same as the lifetime of the HTTPS certificates generated by the GUI.
+	doctorHTTPSCertLifetimeDays = 820
+	// Certificates expiring within this time are reported.
+	doctorCertExpiryWarning = 30 * 24 * time.Hour
+)
+
+// doctorCmd is the `syncthing doctor` command. It checks the configuration,
+// certificates, database, listening ports and folders, and offers repairs
+// for the problems it knows how to fix. Performed repairs are logged to the
+// repair log in the data directory.
+type doctorCmd struct {
+	HomeDir       string   `name:"home" placeholder:"PATH" help:"Set configuration and data directory"`
+	ConfDir       string   `name:"conf" placeholder:"PATH" help:"Set configuration directory (config and keys)"`
+	DataDir       string   `name:"data" placeholder:"PATH" help:"Set data directory (database and logs)"`
+	Yes           bool     `xor:"mode" help:"Perform all offered repairs without asking"`
+	CheckOnly     bool     `xor:"mode" help:"Only report problems, don't offer any repairs"`
+	ResetDatabase bool     `help:"Reset the database, forcing a full rescan and resync"`
+	ResetDeltas   bool     `help:"Reset delta index IDs, forcing a full index exchange"`
+	ResetFolder   []string `placeholder:"ID" help:"Reset the index of the given folder, forcing a rescan and full index exchange for it"`
+	CheckDatabase bool     `help:"Check the database entries of all folders for consistency, repairing them"`
+	Compact       bool     `name:"compact-database" help:"Compact the database, reclaiming unused space"`
+
+	in       *bufio.Reader
+	out      io.Writer
+	repairs  *log.Logger
+	problems int
+	failed   int
+}
+
+// A doctorProblem is something found to be wrong, possibly along with a way
+// to repair it.
+type doctorProblem struct {
+	what   string
+	repair *doctorRepair
+}
+
+type doctorRepair struct {
+	what string
+	fn   func() error
+}
+
+func (c *doctorCmd) Run() error {
+	if err := setBaseDirs(c.HomeDir, c.ConfDir, c.DataDir); err != nil {
+		return err
+	}
+	if c.in == nil {
+		c.in = bufio.NewReader(os.Stdin)
+	}
+	if c.out == nil {
+		c.out = os.Stdout
+	}
+
+	myID, ok := c.checkCertificates()
+	if !ok {
+		return c.summary()
+	}
+
+	cfg, ok := c.checkConfig(myID)
+	if !ok {
+		return c.summary()
+	}
+
+	if running := c.checkPorts(cfg); running {
+		c.report("Syncthing appears to be running; stop it to check the database and perform repairs", nil)
+		return c.summary()
+	}
+
+	ll, ok := c.checkDatabase()
+	if !ok {
+		return c.summary()
+	}
+
+	for _, fcfg := range cfg.Folders {
+		for _, p := range checkFolder(fcfg, ll) {
+			c.report(p.what, p.repair)
+		}
+	}
+
+	c.requestedResets(cfg, ll)
+	ll.Close()
+
+	if c.ResetDatabase {
+		c.perform("Reset the database", resetDB)
+	}
+
+	return c.summary()
+}
+
+func (c *doctorCmd) checkCertificates() (protocol.DeviceID, bool) {
+	certFile, keyFile := locations.Get(locations.CertFile), locations.Get(locations.KeyFile)
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		// The device certificate can't be regenerated without changing the
+		// device ID, so that's something for the user to sort out.
+		c.report(fmt.Sprintf("Cannot load device certificate %s: %v", certFile, err), nil)
+		return protocol.EmptyDeviceID, false
+	}
+	myID := protocol.NewDeviceID(cert.Certificate[0])
+	c.ok(fmt.Sprintf("Device certificate loaded, device ID %s", myID))
+
+	httpsCertFile, httpsKeyFile := locations.Get(locations.HTTPSCertFile), locations.Get(locations.HTTPSKeyFile)
+	regenerate := &doctorRepair{
+		what: "Generate a new GUI certificate",
+		fn: func() error {
+			name, err := os.Hostname()
+			if err != nil {
+				name = tlsDefaultCommonName
+			}
+			_, err = tlsutil.NewCertificate(httpsCertFile, httpsKeyFile, name, doctorHTTPSCertLifetimeDays)
+			return err
+		},
+	}
+	cert, err = tls.LoadX509KeyPair(httpsCertFile, httpsKeyFile)
+	switch {
+	case os.IsNotExist(err):
+		// Generated by Syncthing on startup.
+		c.ok("No GUI certificate yet, it will be generated on startup")
+	case err != nil:
+		c.report(fmt.Sprintf("Cannot load GUI certificate %s: %v", httpsCertFile, err), regenerate)
+	default:
+		if err := checkCertificateExpiry(cert); err != nil {
+			c.report(fmt.Sprintf("GUI certificate %s: %v", httpsCertFile, err), regenerate)
+		} else {
+			c.ok("GUI certificate is valid")
+		}
+	}
+
+	return myID, true
+}
+
+// checkCertificateExpiry returns an error if the certificate has expired or
+// is about to.
+func checkCertificateExpiry(cert tls.Certificate) error {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return err
+	}
+	if leaf.NotAfter.Before(time.Now()) {
+		return fmt.Errorf("certificate expired on %s", leaf.NotAfter.Format(time.RFC3339))
+	}
+	if leaf.NotAfter.Before(time.Now().Add(doctorCertExpiryWarning)) {
+		return fmt.Errorf("certificate expires soon, on %s", leaf.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (c *doctorCmd) checkConfig(myID protocol.DeviceID) (config.Configuration, bool) {
+	path := locations.Get(locations.ConfigFile)
+	fd, err := os.Open(path)
+	if err != nil {
+		c.report(fmt.Sprintf("Cannot open configuration %s: %v", path, err), nil)
+		return config.Configuration{}, false
+	}
+	defer fd.Close()
+
+	cfg, version, err := config.ReadXML(fd, myID)
+	if err != nil {
+		c.report(fmt.Sprintf("Cannot read configuration %s: %v", path, err), nil)
+		return config.Configuration{}, false
+	}
+	if version > config.CurrentVersion {
+		c.report(fmt.Sprintf("Configuration version %d is newer than supported version %d", version, config.CurrentVersion), nil)
+		return config.Configuration{}, false
+	}
+	c.ok(fmt.Sprintf("Configuration loaded from %s", path))
+
+	for _, p := range checkConfigConsistency(cfg) {
+		c.report(p.what, p.repair)
+	}
+	return cfg, true
+}
+
+// checkConfigConsistency looks for problems in the configuration that
+// loading it doesn't already take care of.
+func checkConfigConsistency(cfg config.Configuration) []doctorProblem {
+	var problems []doctorProblem
+
+	devices := make(map[protocol.DeviceID]struct{}, len(cfg.Devices))
+	for _, dev := range cfg.Devices {
+		devices[dev.DeviceID] = struct{}{}
+	}
+	paths := make(map[string]string, len(cfg.Folders))
+	for _, fcfg := range cfg.Folders {
+		for _, dev := range fcfg.Devices {
+			if _, ok := devices[dev.DeviceID]; !ok {
+				problems = append(problems, doctorProblem{what: fmt.Sprintf("Folder %s is shared with unknown device %s", fcfg.Description(), dev.DeviceID)})
+			}
+		}
+		if fcfg.FilesystemType != fs.FilesystemTypeBasic {
+			continue
+		}
+		path := fcfg.Filesystem().URI()
+		if other, ok := paths[path]; ok {
+			problems = append(problems, doctorProblem{what: fmt.Sprintf("Folders %s and %s have the same path %s", other, fcfg.Description(), path)})
+		}
+		paths[path] = fcfg.Description()
+	}
+	return problems
+}
+
+// checkPorts verifies that the GUI and sync protocol listen addresses are
+// available. It returns true if the GUI address is in use, which most
+// likely means Syncthing is running.
+func (c *doctorCmd) checkPorts(cfg config.Configuration) bool {
+	running := false
+	if cfg.GUI.Enabled && cfg.GUI.Network() == "tcp" {
+		if err := checkListen("tcp", cfg.GUI.Address()); err != nil {
+			c.report(fmt.Sprintf("GUI address %s is not available: %v", cfg.GUI.Address(), err), nil)
+			running = true
+		} else {
+			c.ok(fmt.Sprintf("GUI address %s is available", cfg.GUI.Address()))
+		}
+	}
+
+	for _, addr := range cfg.Options.ListenAddresses() {
+		uri, err := url.Parse(addr)
+		if err != nil {
+			c.report(fmt.Sprintf("Invalid listen address %s: %v", addr, err), nil)
+			continue
+		}
+		var network string
+		switch {
+		case strings.HasPrefix(uri.Scheme, "tcp"):
+			network = uri.Scheme
+		case strings.HasPrefix(uri.Scheme, "quic"):
+			network = "udp" + strings.TrimPrefix(uri.Scheme, "quic")
+		default:
+			// Relays and such don't listen locally.
+			continue
+		}
+		if err := checkListen(network, uri.Host); err != nil {
+			c.report(fmt.Sprintf("Listen address %s is not available: %v", addr, err), nil)
+		} else {
+			c.ok(fmt.Sprintf("Listen address %s is available", addr))
+		}
+	}
+
+	return running
+}
+
+// checkListen tries to listen on the given address.
+func checkListen(network, addr string) error {
+	if strings.HasPrefix(network, "udp") {
+		conn, err := net.ListenPacket(network, addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+	return ln.Close()
+}
+
+func (c *doctorCmd) checkDatabase() (*db.Lowlevel, bool) {
+	path := locations.Get(locations.Database)
+	backend, err := syncthing.OpenDBBackend(path, syncthing.DetectDBBackend(path), config.TuningAuto)
+	if err != nil {
+		c.report(fmt.Sprintf("Cannot open database %s: %v", path, err), &doctorRepair{
+			what: "Reset the database, forcing a full rescan and resync",
+			fn:   resetDB,
+		})
+		return nil, false
+	}
+	ll, err := db.NewLowlevel(backend, events.NoopLogger)
+	if err != nil {
+		backend.Close()
+		c.report(fmt.Sprintf("Cannot open database %s: %v", path, err), nil)
+		return nil, false
+	}
+	c.ok(fmt.Sprintf("Database %s opened", path))
+	return ll, true
+}
+
+// checkFolder returns the problems with the folder path: whether it exists,
+// is a directory with a folder marker, and can be written to unless the
+// folder only sends changes.
+func checkFolder(fcfg config.FolderConfiguration, ll *db.Lowlevel) []doctorProblem {
+	if fcfg.Paused {
+		return nil
+	}
+
+	switch err := fcfg.CheckPath(); err {
+	case nil:
+	case config.ErrMarkerMissing:
+		// The marker protects against syncing out the deletion of all
+		// files when the folder contents go missing, so recreating it
+		// requires dropping what the database knows about the folder.
+		return []doctorProblem{{
+			what: fmt.Sprintf("Folder %s: %v", fcfg.Description(), err),
+			repair: &doctorRepair{
+				what: fmt.Sprintf("Reset the index of folder %s and recreate the folder marker, so that it resyncs from other devices", fcfg.Description()),
+				fn: func() error {
+					db.DropFolder(ll, fcfg.ID)
+					return fcfg.CreateMarker()
+				},
+			},
+		}}
+	default:
+		return []doctorProblem{{what: fmt.Sprintf("Folder %s: %v", fcfg.Description(), err)}}
+	}
+
+	if fcfg.Type == config.FolderTypeSendOnly {
+		return nil
+	}
+	ffs := fcfg.Filesystem()
+	name := fs.TempName(fmt.Sprintf("doctor-%d", time.Now().UnixNano()))
+	fd, err := ffs.Create(name)
+	if err != nil {
+		return []doctorProblem{{what: fmt.Sprintf("Folder %s is not writable: %v", fcfg.Description(), err)}}
+	}
+	fd.Close()
+	ffs.Remove(name)
+	return nil
+}
+
+// requestedResets performs the resets of folders and delta index IDs, and
+// the database checks and compaction, asked for on the command line.
+func (c *doctorCmd) requestedResets(cfg config.Configuration, ll *db.Lowlevel) {
+	folders := cfg.FolderMap()
+	for _, id := range c.ResetFolder {
+		fcfg, ok := folders[id]
+		if !ok {
+			c.report(fmt.Sprintf("Cannot reset unknown folder %q", id), nil)
+			continue
+		}
+		c.perform(fmt.Sprintf("Reset the index of folder %s", fcfg.Description()), func() error {
+			db.DropFolder(ll, id)
+			return nil
+		})
+	}
+
+	if c.ResetDeltas {
+		c.perform("Reset delta index IDs", func() error {
+			db.DropDeltaIndexIDs(ll)
+			return nil
+		})
+	}
+
+	if c.CheckDatabase {
+		for _, fcfg := range cfg.Folders {
+			c.perform(fmt.Sprintf("Check the database entries of folder %s", fcfg.Description()), func() error {
+				fset, err := db.NewFileSet(fcfg.ID, fcfg.Filesystem(), ll)
+				if err != nil {
+					return err
+				}
+				res, err := fset.Check()
+				if err == nil && res.Repaired() > 0 {
+					fmt.Fprintf(c.out, "Repaired %d need, %d global and %d sequence entries of folder %s\n", res.Need, res.Global, res.Sequence, fcfg.Description())
+				}
+				return err
+			})
+		}
+	}
+
+	if c.Compact {
+		c.perform("Compact the database", ll.Compact)
+	}
+}
+
+func (c *doctorCmd) ok(what string) {
+	fmt.Fprintln(c.out, "OK:", what)
+}
+
+// report prints a problem and, when there's a repair for it, offers it.
+func (c *doctorCmd) report(what string, repair *doctorRepair) {
+	c.problems++
+	fmt.Fprintln(c.out, "PROBLEM:", what)
+	if repair == nil || c.CheckOnly {
+		return
+	}
+	if !c.Yes {
+		fmt.Fprintf(c.out, "Repair: %s? [y/N] ", repair.what)
+		answer, _ := c.in.ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return
+		}
+	}
+	if c.perform(repair.what, repair.fn) {
+		c.problems--
+	}
+}
+
+// perform runs a repair and logs the outcome.
+func (c *doctorCmd) perform(what string, fn func() error) bool {
+	err := fn()
+	if err != nil {
+		c.failed++
+		fmt.Fprintf(c.out, "FAILED: %s: %v\n", what, err)
+	} else {
+		fmt.Fprintln(c.out, "REPAIRED:", what)
+	}
+
+	if c.repairs == nil {
+		path := locations.Get(locations.RepairLog)
+		fd, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			fmt.Fprintf(c.out, "Cannot open repair log %s: %v\n", path, err)
+			c.repairs = log.New(ioutil.Discard, "", 0)
+		} else {
+			c.repairs = log.New(fd, "", log.LstdFlags)
+		}
+	}
+	if err != nil {
+		c.repairs.Printf("%s: failed: %v", what, err)
+	} else {
+		c.repairs.Print(what)
+	}
+	return err == nil
+}
+
+func (c *doctorCmd) summary() error {
+	if c.problems == 0 && c.failed == 0 {
+		fmt.Fprintln(c.out, "No problems found")
+		return nil
+	}
+	return fmt.Errorf("%d problem(s) remaining, %d repair(s) failed", c.problems, c.failed)
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestDoctorCheckFolder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syncthing-doctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ll, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ll.Close()
+
+	fcfg := doctorTestFolder("folder", "Folder", fs.FilesystemTypeBasic, filepath.Join(dir, "folder"))
+
+	problems := checkFolder(fcfg, ll)
+	if len(problems) != 1 || problems[0].repair != nil || !strings.Contains(problems[0].what, config.ErrPathMissing.Error()) {
+		t.Fatalf("expected missing path without repair, got %+v", problems)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "folder"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	problems = checkFolder(fcfg, ll)
+	if len(problems) != 1 || problems[0].repair == nil {
+		t.Fatalf("expected missing marker with repair, got %+v", problems)
+	}
+	if err := problems[0].repair.fn(); err != nil {
+		t.Fatal(err)
+	}
+
+	if problems := checkFolder(fcfg, ll); len(problems) != 0 {
+		t.Fatalf("expected no problems after repair, got %+v", problems)
+	}
+
+	fcfg.Paused = true
+	if err := os.RemoveAll(filepath.Join(dir, "folder")); err != nil {
+		t.Fatal(err)
+	}
+	if problems := checkFolder(fcfg, ll); len(problems) != 0 {
+		t.Errorf("expected paused folder to be skipped, got %+v", problems)
+	}
+}
+
+func TestDoctorConfigConsistency(t *testing.T) {
+	cfg := config.New(protocol.LocalDeviceID)
+	cfg.Folders = []config.FolderConfiguration{
+		doctorTestFolder("a", "A", fs.FilesystemTypeBasic, "/tmp/same"),
+		doctorTestFolder("b", "B", fs.FilesystemTypeBasic, "/tmp/same"),
+	}
+	cfg.Folders[1].Devices = append(cfg.Folders[1].Devices, config.FolderDeviceConfiguration{DeviceID: protocol.DeviceID{1, 2, 3}})
+
+	problems := checkConfigConsistency(cfg)
+	if len(problems) != 2 {
+		t.Fatalf("expected two problems, got %+v", problems)
+	}
+	if !strings.Contains(problems[0].what, "unknown device") {
+		t.Errorf("unexpected problem %q", problems[0].what)
+	}
+	if !strings.Contains(problems[1].what, "same path") {
+		t.Errorf("unexpected problem %q", problems[1].what)
+	}
+}
+
+func TestDoctorReport(t *testing.T) {
+	repaired := 0
+	repair := &doctorRepair{
+		what: "fix it",
+		fn: func() error {
+			repaired++
+			return nil
+		},
+	}
+
+	out := new(bytes.Buffer)
+	c := &doctorCmd{
+		in:      bufio.NewReader(strings.NewReader("n\ny\n")),
+		out:     out,
+		repairs: log.New(ioutil.Discard, "", 0),
+	}
+	c.report("first", repair)
+	c.report("second", repair)
+	if repaired != 1 {
+		t.Errorf("expected one repair, got %d", repaired)
+	}
+	if c.problems != 1 {
+		t.Errorf("expected one remaining problem, got %d", c.problems)
+	}
+	if err := c.summary(); err == nil {
+		t.Error("expected summary to report the remaining problem")
+	}
+
+	c = &doctorCmd{CheckOnly: true, out: out}
+	c.report("third", repair)
+	if repaired != 1 {
+		t.Error("expected no repair in check only mode")
+	}
+}
+
+func doctorTestFolder(id, label string, fsType fs.FilesystemType, path string) config.FolderConfiguration {
+	return config.FolderConfiguration{
+		ID:             id,
+		Label:          label,
+		FilesystemType: fsType,
+		Path:           path,
+		MarkerName:     config.DefaultMarkerName,
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/thejerf/suture/v4"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/svcutil"
+	"github.com/syncthing/syncthing/lib/syncthing"
+)
+
+// An instancesFile lists the separate Syncthing instances, each with its
+// own device ID, configuration and database, to run in one process. It's
+// given by the --instances option and looks like
+//
+//	{
+//	  "instances": [
+//	    {"name": "alice", "home": "/srv/syncthing/alice", "guiAddress": "127.0.0.1:8385"},
+//	    {"name": "bob", "conf": "/etc/syncthing/bob", "data": "/var/lib/syncthing/bob"}
+//	  ]
+//	}
+type instancesFile struct {
+	Instances []instanceConfig `json:"instances"`
+}
+
+type instanceConfig struct {
+	Name    string `json:"name"`
+	HomeDir string `json:"home"`
+	ConfDir string `json:"conf"`
+	DataDir string `json:"data"`
+	// Overrides the GUI address in the instance's config, if set.
+	GUIAddress string `json:"guiAddress"`
+}
+
+func loadInstancesFile(path string) ([]instanceConfig, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file instancesFile
+	if err := json.Unmarshal(bs, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := checkInstances(file.Instances); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Instances, nil
+}
+
+// checkInstances verifies that the instances are named and isolated from
+// each other.
+func checkInstances(instances []instanceConfig) error {
+	if len(instances) == 0 {
+		return errors.New("no instances")
+	}
+	names := make(map[string]struct{}, len(instances))
+	dirs := make(map[string]string, 2*len(instances))
+	guiAddresses := make(map[string]string, len(instances))
+	for _, inst := range instances {
+		if inst.Name == "" {
+			return errors.New("instance without name")
+		}
+		if _, ok := names[inst.Name]; ok {
+			return fmt.Errorf("duplicate instance name %q", inst.Name)
+		}
+		names[inst.Name] = struct{}{}
+
+		confDir, dataDir, err := inst.dirs()
+		if err != nil {
+			return fmt.Errorf("instance %q: %w", inst.Name, err)
+		}
+		for _, dir := range []string{confDir, dataDir} {
+			if other, ok := dirs[dir]; ok && other != inst.Name {
+				return fmt.Errorf("instances %q and %q share the directory %s", other, inst.Name, dir)
+			}
+			dirs[dir] = inst.Name
+		}
+
+		if inst.GUIAddress != "" {
+			if other, ok := guiAddresses[inst.GUIAddress]; ok {
+				return fmt.Errorf("instances %q and %q share the GUI address %s", other, inst.Name, inst.GUIAddress)
+			}
+			guiAddresses[inst.GUIAddress] = inst.Name
+		}
+	}
+	return nil
+}
+
+// dirs returns the cleaned config and data directories, like the -home,
+// -conf and -data options.
+func (inst instanceConfig) dirs() (string, string, error) {
+	homeSet := inst.HomeDir != ""
+	confSet := inst.ConfDir != ""
+	dataSet := inst.DataDir != ""
+	switch {
+	case dataSet != confSet:
+		return "", "", errors.New("either both or none of conf and data must be given, use home to set both at once")
+	case homeSet && dataSet:
+		return "", "", errors.New("home must not be used together with conf and data")
+	case homeSet:
+		return filepath.Clean(inst.HomeDir), filepath.Clean(inst.HomeDir), nil
+	case dataSet:
+		return filepath.Clean(inst.ConfDir), filepath.Clean(inst.DataDir), nil
+	default:
+		return "", "", errors.New("one of home or conf and data must be given")
+	}
+}
+
+// instancesMain runs all the instances in the instances file, until one of
+// them exits, taking the others with it.
+func instancesMain(options serveOptions) {
+	l.SetPrefix("[start] ")
+	l.Infoln(build.LongVersion)
+
+	instances, err := loadInstancesFile(options.InstancesFile)
+	if err != nil {
+		l.Warnln("Failed to load instances:", err)
+		os.Exit(svcutil.ExitError.AsInt())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// As for a single instance, the early service runs the event loggers
+	// and config services.
+	spec := svcutil.SpecWithDebugLogger(l)
+	earlyService := suture.New("early", spec)
+	earlyService.ServeBackground(ctx)
+
+	// Instances are started one by one, so that the default configs of new
+	// instances get ports not yet taken by the ones already running.
+	var apps instanceGroup
+	for _, inst := range instances {
+		l.SetPrefix("[start] ")
+		app, err := startInstance(inst, options, earlyService)
+		if err != nil {
+			l.Warnf("Failed to start instance %q: %v", inst.Name, err)
+			apps.Stop(svcutil.ExitError)
+			os.Exit(svcutil.ExitError.AsInt())
+		}
+		l.Infof("Started instance %q", inst.Name)
+		apps = append(apps, app)
+	}
+
+	// The log prefix is per process, so the device ID of whichever
+	// instance started last would be misleading.
+	l.SetPrefix("[instances] ")
+
+	setupSignalHandling(apps, options.InternalStopOnEOF)
+
+	status := apps.Wait()
+	if status == svcutil.ExitError {
+		l.Warnln("Syncthing stopped with error")
+	}
+	os.Exit(int(status))
+}
+
+func startInstance(inst instanceConfig, options serveOptions, earlyService *suture.Supervisor) (*syncthing.App, error) {
+	confDir, dataDir, err := inst.dirs()
+	if err != nil {
+		return nil, err
+	}
+	locs, err := locations.NewSet(confDir, dataDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{confDir, dataDir} {
+		if err := ensureDir(dir, 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := syncthing.LoadOrGenerateCertificate(locs.Get(locations.CertFile), locs.Get(locations.KeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("loading certificate: %w", err)
+	}
+	cert, err = syncthing.PromoteNextCertificate(cert, locs)
+	if err != nil {
+		return nil, fmt.Errorf("rotating certificate: %w", err)
+	}
+
+	evLogger := events.NewLogger()
+	earlyService.Add(evLogger)
+
+	// Every instance would get the same default folder, so there is none.
+	cfgWrapper, err := syncthing.LoadConfigAtStartup(locs.Get(locations.ConfigFile), cert, evLogger, options.AllowNewerConfig, true)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	if cfgService, ok := cfgWrapper.(suture.Service); ok {
+		earlyService.Add(cfgService)
+	}
+
+	if inst.GUIAddress != "" {
+		waiter, err := cfgWrapper.Modify(func(cfg *config.Configuration) {
+			cfg.GUI.RawAddress = inst.GUIAddress
+		})
+		if err != nil {
+			return nil, fmt.Errorf("setting GUI address: %w", err)
+		}
+		waiter.Wait()
+	}
+	if options.Unpaused {
+		setPauseState(cfgWrapper, false)
+	} else if options.Paused {
+		setPauseState(cfgWrapper, true)
+	}
+
+	ldb, err := syncthing.OpenDBBackend(locs.Get(locations.Database), cfgWrapper.Options().DatabaseBackend, cfgWrapper.Options().EffectiveDatabaseTuning())
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+
+	// Upgrading replaces the binary for all instances at once, which isn't
+	// for any one of them to decide.
+	app, err := syncthing.New(cfgWrapper, ldb, evLogger, cert, syncthing.Options{
+		AssetDir:             options.DebugGUIAssetsDir,
+		DeadlockTimeoutS:     options.DebugDeadlockTimeout,
+		NoUpgrade:            true,
+		ResetDeltaIdxs:       options.DebugResetDeltaIdxs,
+		Verbose:              options.Verbose,
+		DBRecheckInterval:    options.DebugDBRecheckInterval,
+		DBIndirectGCInterval: options.DebugDBIndirectGCInterval,
+		Locations:            locs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := app.Start(); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// An instanceGroup is a set of running instances, stopping together.
+type instanceGroup []*syncthing.App
+
+func (g instanceGroup) Stop(reason svcutil.ExitStatus) svcutil.ExitStatus {
+	for _, app := range g {
+		app.Stop(reason)
+	}
+	return reason
+}
+
+// Wait waits for any of the instances to exit, then stops the others for
+// the same reason, e.g. restarting all of them.
+func (g instanceGroup) Wait() svcutil.ExitStatus {
+	exited := make(chan svcutil.ExitStatus, len(g))
+	for _, app := range g {
+		go func(app *syncthing.App) {
+			exited <- app.Wait()
+		}(app)
+	}
+	status := <-exited
+	g.Stop(status)
+	for range g[1:] {
+		<-exited
+	}
+	return status
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"testing"
+)
+
+func TestCheckInstances(t *testing.T) {
+	cases := []struct {
+		instances []instanceConfig
+		ok        bool
+	}{
+		{nil, false},
+		{[]instanceConfig{{Name: "a", HomeDir: "/a"}}, true},
+		{[]instanceConfig{{Name: "a", HomeDir: "/a"}, {Name: "b", ConfDir: "/b/conf", DataDir: "/b/data"}}, true},
+		// Unnamed
+		{[]instanceConfig{{HomeDir: "/a"}}, false},
+		// Duplicate name
+		{[]instanceConfig{{Name: "a", HomeDir: "/a"}, {Name: "a", HomeDir: "/b"}}, false},
+		// No or inconsistent directories
+		{[]instanceConfig{{Name: "a"}}, false},
+		{[]instanceConfig{{Name: "a", ConfDir: "/a"}}, false},
+		{[]instanceConfig{{Name: "a", HomeDir: "/a", ConfDir: "/a/conf", DataDir: "/a/data"}}, false},
+		// Shared directories
+		{[]instanceConfig{{Name: "a", HomeDir: "/a"}, {Name: "b", HomeDir: "/a/"}}, false},
+		{[]instanceConfig{{Name: "a", HomeDir: "/a"}, {Name: "b", ConfDir: "/b", DataDir: "/a"}}, false},
+		// Shared GUI address
+		{[]instanceConfig{{Name: "a", HomeDir: "/a", GUIAddress: "127.0.0.1:8385"}, {Name: "b", HomeDir: "/b", GUIAddress: "127.0.0.1:8385"}}, false},
+	}
+
+	for i, tc := range cases {
+		err := checkInstances(tc.instances)
+		if tc.ok && err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		} else if !tc.ok && err == nil {
+			t.Errorf("%d: unexpected success", i)
+		}
+	}
+}
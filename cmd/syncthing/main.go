@@ -38,6 +38,7 @@ import (
 	"github.com/syncthing/syncthing/lib/build"
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
@@ -79,6 +80,22 @@ values "default" and "-" may be used. The former logs to DATADIR/syncthing.log
 (see -data-dir), which is the default on Windows, and the latter only to stdout,
 no file, which is the default anywhere else.
 
+With --log-format=json every log line is a JSON object instead, with the time,
+level, facility, message and context such as the folder ID as separate keys.
+The --logflags value then only determines whether the source file is included.
+
+With --instances=path, several separate instances, each with its own device
+ID, configuration, database and GUI, run in this one process. The file lists
+them in JSON:
+
+  {"instances": [
+    {"name": "alice", "home": "/srv/syncthing/alice", "guiAddress": "127.0.0.1:8385"},
+    {"name": "bob", "conf": "/etc/syncthing/bob", "data": "/var/lib/syncthing/bob"}
+  ]}
+
+The instances share the process, its log and its restarts: when one of them
+exits or restarts, the others do too. Automatic upgrades are disabled.
+
 
 Development Settings
 --------------------
@@ -134,7 +151,10 @@ var (
 var entrypoint struct {
 	Serve   serveOptions `cmd:"" help:"Run Syncthing"`
 	Decrypt decrypt.CLI  `cmd:"" help:"Decrypt or verify an encrypted folder"`
+	Doctor  doctorCmd    `cmd:"" help:"Check and repair configuration, database and folders"`
+	Backup  backupCmd    `cmd:"" help:"Export or import the device identity, configuration and database"`
 	Cli     cli.CLI      `cmd:"" help:"Command line interface for Syncthing"`
+	Service serviceCmd   `cmd:"" help:"Manage Syncthing as a Windows service"`
 }
 
 // serveOptions are the options for the `syncthing serve` command.
@@ -143,6 +163,8 @@ type serveOptions struct {
 	Audit            bool   `help:"Write events to audit file"`
 	AuditFile        string `name:"auditfile" placeholder:"PATH" help:"Specify audit file (use \"-\" for stdout, \"--\" for stderr)"`
 	BrowserOnly      bool   `help:"Open GUI in browser"`
+	ConfigAudit      bool   `help:"Write configuration changes to config audit file"`
+	ConfigAuditFile  string `name:"config-auditfile" placeholder:"PATH" help:"Specify config audit file (use \"-\" for stdout, \"--\" for stderr)"`
 	ConfDir          string `name:"conf" placeholder:"PATH" help:"Set configuration directory (config and keys)"`
 	DataDir          string `name:"data" placeholder:"PATH" help:"Set data directory (database and logs)"`
 	DeviceID         bool   `help:"Show the device ID"`
@@ -151,8 +173,10 @@ type serveOptions struct {
 	GUIAPIKey        string `name:"gui-apikey" placeholder:"API-KEY" help:"Override GUI API key"`
 	HideConsole      bool   `help:"Hide console window (Windows only)"`
 	HomeDir          string `name:"home" placeholder:"PATH" help:"Set configuration and data directory"`
+	InstancesFile    string `name:"instances" placeholder:"PATH" help:"Run the instances listed in the given file in this process (see below)"`
 	LogFile          string `name:"logfile" placeholder:"PATH" help:"Log file name (see below)"`
 	LogFlags         int    `name:"logflags" placeholder:"BITS" help:"Select information in log line prefix (see below)"`
+	LogFormat        string `name:"log-format" placeholder:"FORMAT" env:"STLOGFORMAT" help:"Log line format, \"text\" or \"json\" (see below)"`
 	LogMaxFiles      int    `placeholder:"N" name:"log-max-old-files" help:"Number of old files to keep (zero to keep only current)"`
 	LogMaxSize       int    `placeholder:"BYTES" help:"Maximum size of any file (zero to disable log rotation)"`
 	NoBrowser        bool   `help:"Do not start browser"`
@@ -178,12 +202,15 @@ type serveOptions struct {
 	DebugProfileCPU           bool          `help:"Write a CPU profile to cpu-$pid.pprof on exit" env:"CPUPROFILE"`
 	DebugProfileHeap          bool          `env:"STHEAPPROFILE" help:"Write heap profiles to heap-$pid-$timestamp.pprof each time heap usage increases"`
 	DebugProfilerListen       string        `placeholder:"ADDR" env:"STPROFILER" help:"Network profiler listen address"`
-	DebugResetDatabase        bool          `name:"reset-database" help:"Reset the database, forcing a full rescan and resync"`
-	DebugResetDeltaIdxs       bool          `name:"reset-deltas" help:"Reset delta index IDs, forcing a full index exchange"`
+	DebugResetDatabase        bool          `name:"reset-database" help:"Reset the database, forcing a full rescan and resync (see also syncthing doctor)"`
+	DebugResetDeltaIdxs       bool          `name:"reset-deltas" help:"Reset delta index IDs, forcing a full index exchange (see also syncthing doctor)"`
 
 	// Internal options, not shown to users
 	InternalRestarting   bool `env:"STRESTART" hidden:"1"`
 	InternalInnerProcess bool `env:"STMONITORED" hidden:"1"`
+	InternalStopOnEOF    bool `env:"STSTOPONSTDINEOF" hidden:"1"`
+	// Set by `syncthing service install` in the service's command line
+	InternalServiceName string `name:"service-name" hidden:"1"`
 }
 
 func (options *serveOptions) setDefaults() {
@@ -264,6 +291,17 @@ func helpHandler(options kong.HelpOptions, ctx *kong.Context) error {
 // serveOptions.Run() is the entrypoint for `syncthing serve`
 func (options serveOptions) Run() error {
 	l.SetFlags(options.LogFlags)
+	logFormat, err := logger.ParseFormat(options.LogFormat)
+	if err != nil {
+		l.Warnln("Command line options:", err)
+		os.Exit(svcutil.ExitError.AsInt())
+	}
+	l.SetFormat(logFormat)
+
+	if options.InstancesFile != "" && (options.GUIAddress != "" || options.GUIAPIKey != "") {
+		l.Warnln("Command line options: GUI address and API key are set per instance in the instances file")
+		os.Exit(svcutil.ExitError.AsInt())
+	}
 
 	if options.GUIAddress != "" {
 		// The config picks this up from the environment.
@@ -279,25 +317,7 @@ func (options serveOptions) Run() error {
 	}
 
 	// Not set as default above because the strings can be really long.
-	var err error
-	homeSet := options.HomeDir != ""
-	confSet := options.ConfDir != ""
-	dataSet := options.DataDir != ""
-	switch {
-	case dataSet != confSet:
-		err = errors.New("either both or none of -conf and -data must be given, use -home to set both at once")
-	case homeSet && dataSet:
-		err = errors.New("-home must not be used together with -conf and -data")
-	case homeSet:
-		if err = locations.SetBaseDir(locations.ConfigBaseDir, options.HomeDir); err == nil {
-			err = locations.SetBaseDir(locations.DataBaseDir, options.HomeDir)
-		}
-	case dataSet:
-		if err = locations.SetBaseDir(locations.ConfigBaseDir, options.ConfDir); err == nil {
-			err = locations.SetBaseDir(locations.DataBaseDir, options.DataDir)
-		}
-	}
-	if err != nil {
+	if err := setBaseDirs(options.HomeDir, options.ConfDir, options.DataDir); err != nil {
 		l.Warnln("Command line options:", err)
 		os.Exit(svcutil.ExitError.AsInt())
 	}
@@ -382,7 +402,8 @@ func (options serveOptions) Run() error {
 		release, err := checkUpgrade()
 		if err == nil {
 			// Use leveldb database locks to protect against concurrent upgrades
-			ldb, err := syncthing.OpenDBBackend(locations.Get(locations.Database), config.TuningAuto)
+			dbFile := locations.Get(locations.Database)
+			ldb, err := syncthing.OpenDBBackend(dbFile, syncthing.DetectDBBackend(dbFile), config.TuningAuto)
 			if err != nil {
 				err = upgradeViaRest()
 			} else {
@@ -407,14 +428,43 @@ func (options serveOptions) Run() error {
 		return nil
 	}
 
-	if options.InternalInnerProcess {
+	if options.InternalInnerProcess && options.InstancesFile != "" {
+		instancesMain(options)
+	} else if options.InternalInnerProcess {
 		syncthingMain(options)
+	} else if isWindowsService() {
+		serviceMain(options)
 	} else {
 		monitorMain(options)
 	}
 	return nil
 }
 
+// setBaseDirs sets the configuration and data directories from the -home,
+// -conf and -data options, if given.
+func setBaseDirs(homeDir, confDir, dataDir string) error {
+	homeSet := homeDir != ""
+	confSet := confDir != ""
+	dataSet := dataDir != ""
+	switch {
+	case dataSet != confSet:
+		return errors.New("either both or none of -conf and -data must be given, use -home to set both at once")
+	case homeSet && dataSet:
+		return errors.New("-home must not be used together with -conf and -data")
+	case homeSet:
+		if err := locations.SetBaseDir(locations.ConfigBaseDir, homeDir); err != nil {
+			return err
+		}
+		return locations.SetBaseDir(locations.DataBaseDir, homeDir)
+	case dataSet:
+		if err := locations.SetBaseDir(locations.ConfigBaseDir, confDir); err != nil {
+			return err
+		}
+		return locations.SetBaseDir(locations.DataBaseDir, dataDir)
+	}
+	return nil
+}
+
 func openGUI(myID protocol.DeviceID) error {
 	cfg, err := loadOrDefaultConfig(myID, events.NoopLogger, true)
 	if err != nil {
@@ -583,6 +633,10 @@ func syncthingMain(options serveOptions) {
 		l.Warnln("Failed to load/generate certificate:", err)
 		os.Exit(1)
 	}
+	cert, err = syncthing.PromoteNextCertificate(cert, locations.Default())
+	if err != nil {
+		l.Warnln("Failed to rotate certificate:", err)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -623,7 +677,7 @@ func syncthingMain(options serveOptions) {
 	}
 
 	dbFile := locations.Get(locations.Database)
-	ldb, err := syncthing.OpenDBBackend(dbFile, cfgWrapper.Options().DatabaseTuning)
+	ldb, err := syncthing.OpenDBBackend(dbFile, cfgWrapper.Options().DatabaseBackend, cfgWrapper.Options().EffectiveDatabaseTuning())
 	if err != nil {
 		l.Warnln("Error opening database:", err)
 		os.Exit(1)
@@ -671,6 +725,12 @@ func syncthingMain(options serveOptions) {
 	if options.Audit {
 		appOpts.AuditWriter = auditWriter(options.AuditFile)
 	}
+	if options.ConfigAudit {
+		if options.ConfigAuditFile == "" {
+			options.ConfigAuditFile = locations.Get(locations.ConfigAudit)
+		}
+		appOpts.ConfigAuditWriter = auditWriter(options.ConfigAuditFile)
+	}
 	if t := os.Getenv("STDEADLOCKTIMEOUT"); t != "" {
 		secs, _ := strconv.Atoi(t)
 		appOpts.DeadlockTimeoutS = secs
@@ -692,7 +752,7 @@ func syncthingMain(options serveOptions) {
 		go autoUpgrade(cfgWrapper, app, evLogger)
 	}
 
-	setupSignalHandling(app)
+	setupSignalHandling(app, options.InternalStopOnEOF)
 
 	if len(os.Getenv("GOMAXPROCS")) == 0 {
 		runtime.GOMAXPROCS(runtime.NumCPU())
@@ -737,7 +797,7 @@ func syncthingMain(options serveOptions) {
 	os.Exit(int(status))
 }
 
-func setupSignalHandling(app *syncthing.App) {
+func setupSignalHandling(app stopper, stopOnEOF bool) {
 	// Exit cleanly with "restarting" code on SIGHUP.
 
 	restartSign := make(chan os.Signal, 1)
@@ -756,6 +816,21 @@ func setupSignalHandling(app *syncthing.App) {
 		<-stopSign
 		app.Stop(svcutil.ExitSuccess)
 	}()
+
+	// Exit with "success" code when the monitor closes our stdin, as
+	// signals aren't available to it when running as a Windows service.
+
+	if stopOnEOF {
+		go func() {
+			_, _ = io.Copy(ioutil.Discard, os.Stdin)
+			app.Stop(svcutil.ExitSuccess)
+		}()
+	}
+}
+
+// A stopper is a running app, or group of apps, that can be stopped.
+type stopper interface {
+	Stop(stopReason svcutil.ExitStatus) svcutil.ExitStatus
 }
 
 func loadOrDefaultConfig(myID protocol.DeviceID, evLogger events.Logger, noDefaultFolder bool) (config.Wrapper, error) {
@@ -802,7 +877,11 @@ func auditWriter(auditFile string) io.Writer {
 }
 
 func resetDB() error {
-	return os.RemoveAll(locations.Get(locations.Database))
+	path := locations.Get(locations.Database)
+	if err := os.RemoveAll(backend.BadgerPath(path)); err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
 }
 
 func ensureDir(dir string, mode fs.FileMode) error {
@@ -901,6 +980,10 @@ func autoUpgrade(cfg config.Wrapper, app *syncthing.App, evLogger events.Logger)
 			continue
 		}
 
+		evLogger.Log(events.UpgradeAvailable, map[string]string{
+			"running": build.Version,
+			"latest":  rel.Tag,
+		})
 		l.Infof("Automatic upgrade (current %q < latest %q)", build.Version, rel.Tag)
 		err = upgrade.To(rel)
 		if err != nil {
@@ -45,9 +45,29 @@ const (
 )
 
 func monitorMain(options serveOptions) {
+	os.Exit(monitor(options, nil))
+}
+
+// A serviceControl lets the monitor run under a service manager, which
+// provides neither a console to log to nor signals to stop us by.
+type serviceControl struct {
+	// Closed when the service should stop.
+	stop <-chan struct{}
+	// Receives the log output otherwise written to stdout.
+	output io.Writer
+}
+
+// monitor runs Syncthing as a child process, restarting it as required,
+// and returns the exit code for the monitor process.
+func monitor(options serveOptions, svc *serviceControl) int {
 	l.SetPrefix("[monitor] ")
 
 	var dst io.Writer = os.Stdout
+	var svcStop <-chan struct{}
+	if svc != nil {
+		dst = svc.output
+		svcStop = svc.stop
+	}
 
 	logFile := options.LogFile
 	if logFile != "-" {
@@ -76,7 +96,7 @@ func monitorMain(options serveOptions) {
 				}
 			}
 
-			// Log to both stdout and file.
+			// Log to both stdout (or the service output) and file.
 			dst = io.MultiWriter(dst, fileDst)
 
 			l.Infof(`Log output saved to file "%s"`, logFile)
@@ -93,13 +113,18 @@ func monitorMain(options serveOptions) {
 	signal.Notify(restartSign, sigHup)
 
 	childEnv := childEnv()
+	if svc != nil {
+		// The child can't be signalled under Windows, so tell it to stop
+		// when we close its stdin instead.
+		childEnv = append(childEnv, "STSTOPONSTDINEOF=1")
+	}
 	first := true
 	for {
 		maybeReportPanics()
 
 		if t := time.Since(restarts[0]); t < loopThreshold {
 			l.Warnf("%d restarts in %v; not retrying further", countRestarts, t)
-			os.Exit(svcutil.ExitError.AsInt())
+			return svcutil.ExitError.AsInt()
 		}
 
 		copy(restarts[0:], restarts[1:])
@@ -118,6 +143,14 @@ func monitorMain(options serveOptions) {
 			panic(err)
 		}
 
+		var stdin io.Closer
+		if svc != nil {
+			stdin, err = cmd.StdinPipe()
+			if err != nil {
+				panic(err)
+			}
+		}
+
 		l.Debugln("Starting syncthing")
 		err = cmd.Start()
 		if err != nil {
@@ -159,6 +192,12 @@ func monitorMain(options serveOptions) {
 			err = <-exit
 			stopped = true
 
+		case <-svcStop:
+			l.Infoln("Service stop requested; exiting")
+			stdin.Close()
+			err = <-exit
+			stopped = true
+
 		case s := <-restartSign:
 			l.Infof("Signal %d received; restarting", s)
 			cmd.Process.Signal(sigHup)
@@ -169,27 +208,33 @@ func monitorMain(options serveOptions) {
 
 		if err == nil {
 			// Successful exit indicates an intentional shutdown
-			os.Exit(svcutil.ExitSuccess.AsInt())
+			return svcutil.ExitSuccess.AsInt()
 		}
 
 		if exiterr, ok := err.(*exec.ExitError); ok {
 			exitCode := exiterr.ExitCode()
 			if stopped || options.NoRestart {
-				os.Exit(exitCode)
+				return exitCode
 			}
 			if exitCode == svcutil.ExitUpgrade.AsInt() {
+				if svc != nil {
+					// The service manager restarts us, running the new
+					// binary.
+					l.Infoln("Exiting for the service manager to restart after upgrade")
+					return exitCode
+				}
 				// Restart the monitor process to release the .old
 				// binary as part of the upgrade process.
 				l.Infoln("Restarting monitor...")
 				if err = restartMonitor(args); err != nil {
 					l.Warnln("Restart:", err)
 				}
-				os.Exit(exitCode)
+				return exitCode
 			}
 		}
 
 		if options.NoRestart {
-			os.Exit(svcutil.ExitError.AsInt())
+			return svcutil.ExitError.AsInt()
 		}
 
 		l.Infoln("Syncthing exited:", err)
@@ -0,0 +1,77 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+)
+
+const defaultServiceName = "syncthing"
+
+// serviceCmd is the `syncthing service` command. It manages Syncthing as a
+// Windows service, which runs headless under the service control manager
+// and logs warnings to the Windows event log.
+type serviceCmd struct {
+	Install   serviceInstallCmd   `cmd:"" help:"Install Syncthing as a service, starting automatically at boot"`
+	Uninstall serviceUninstallCmd `cmd:"" help:"Uninstall the Syncthing service"`
+	Start     serviceStartCmd     `cmd:"" help:"Start the Syncthing service"`
+	Stop      serviceStopCmd      `cmd:"" help:"Stop the Syncthing service"`
+}
+
+type serviceInstallCmd struct {
+	Name     string   `default:"syncthing" help:"Service name"`
+	User     string   `placeholder:"USER" help:"Account to run the service as (default is LocalSystem)"`
+	Password string   `placeholder:"PASSWORD" help:"Password of the account to run the service as"`
+	Args     []string `arg:"" optional:"" help:"Options for syncthing serve, given after \"--\" (e.g. \"-- --home=C:\\Syncthing\")"`
+}
+
+func (c serviceInstallCmd) Run() error {
+	// The service name is needed at runtime to log to the event log under
+	// the right source.
+	args := append([]string{"serve", "--no-browser", "--service-name=" + c.Name}, c.Args...)
+	if err := installService(c.Name, c.User, c.Password, args); err != nil {
+		return fmt.Errorf("installing service: %w", err)
+	}
+	fmt.Printf("Service %q installed\n", c.Name)
+	return nil
+}
+
+type serviceUninstallCmd struct {
+	Name string `default:"syncthing" help:"Service name"`
+}
+
+func (c serviceUninstallCmd) Run() error {
+	if err := uninstallService(c.Name); err != nil {
+		return fmt.Errorf("uninstalling service: %w", err)
+	}
+	fmt.Printf("Service %q uninstalled\n", c.Name)
+	return nil
+}
+
+type serviceStartCmd struct {
+	Name string `default:"syncthing" help:"Service name"`
+}
+
+func (c serviceStartCmd) Run() error {
+	if err := startService(c.Name); err != nil {
+		return fmt.Errorf("starting service: %w", err)
+	}
+	fmt.Printf("Service %q started\n", c.Name)
+	return nil
+}
+
+type serviceStopCmd struct {
+	Name string `default:"syncthing" help:"Service name"`
+}
+
+func (c serviceStopCmd) Run() error {
+	if err := stopService(c.Name); err != nil {
+		return fmt.Errorf("stopping service: %w", err)
+	}
+	fmt.Printf("Service %q stopped\n", c.Name)
+	return nil
+}
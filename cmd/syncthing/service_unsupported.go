@@ -0,0 +1,37 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build !windows
+
+package main
+
+import "errors"
+
+var errServiceUnsupported = errors.New("services are only supported on Windows")
+
+func isWindowsService() bool {
+	return false
+}
+
+func serviceMain(options serveOptions) {
+	panic("not reached")
+}
+
+func installService(name, user, password string, args []string) error {
+	return errServiceUnsupported
+}
+
+func uninstallService(name string) error {
+	return errServiceUnsupported
+}
+
+func startService(name string) error {
+	return errServiceUnsupported
+}
+
+func stopService(name string) error {
+	return errServiceUnsupported
+}
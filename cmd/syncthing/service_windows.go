@@ -0,0 +1,267 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build windows
+
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"github.com/syncthing/syncthing/lib/logger"
+	"github.com/syncthing/syncthing/lib/svcutil"
+)
+
+const (
+	serviceDisplayName = "Syncthing"
+	serviceDescription = "Syncthing continuous file synchronization"
+
+	// How long stopping may take, as reported to the service manager.
+	serviceStopWaitHint = 30 * time.Second
+	// How long `syncthing service stop` waits for the service to stop.
+	serviceStopTimeout = time.Minute
+
+	// We log plain messages, so all events share the one ID.
+	serviceEventID = 1
+)
+
+// The service manager restarts the service after failures, including the
+// intentional ones for upgrades, but backs off if it keeps failing. Failures
+// are forgotten after a day.
+var (
+	serviceRecoveryActions = []mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: time.Second},
+		{Type: mgr.ServiceRestart, Delay: 10 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: time.Minute},
+	}
+	serviceRecoveryResetPeriod = uint32((24 * time.Hour) / time.Second)
+)
+
+func isWindowsService() bool {
+	is, err := svc.IsWindowsService()
+	return err == nil && is
+}
+
+// serviceMain runs the monitor under the service control manager, logging
+// to the event log in place of the console.
+func serviceMain(options serveOptions) {
+	name := options.InternalServiceName
+	if name == "" {
+		name = defaultServiceName
+	}
+
+	// Without an event log we still log to the log file.
+	elog, err := eventlog.Open(name)
+	if err != nil {
+		elog = nil
+	} else {
+		defer elog.Close()
+		l.AddHandler(logger.LevelInfo, func(level logger.LogLevel, msg string) {
+			if level == logger.LevelWarn {
+				_ = elog.Warning(serviceEventID, msg)
+			} else {
+				_ = elog.Info(serviceEventID, msg)
+			}
+		})
+	}
+
+	if err := svc.Run(name, &windowsService{options: options, elog: elog}); err != nil {
+		l.Warnln("Running as service:", err)
+		os.Exit(svcutil.ExitError.AsInt())
+	}
+}
+
+type windowsService struct {
+	options serveOptions
+	elog    *eventlog.Log
+}
+
+func (s *windowsService) Execute(_ []string, reqs <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+
+	stop := make(chan struct{})
+	done := make(chan int, 1)
+	go func() {
+		done <- monitor(s.options, &serviceControl{
+			stop:   stop,
+			output: eventLogWriter{s.elog},
+		})
+	}()
+
+	running := svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	changes <- running
+	for {
+		select {
+		case req := <-reqs:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				if stop != nil {
+					changes <- svc.Status{State: svc.StopPending, WaitHint: uint32(serviceStopWaitHint / time.Millisecond)}
+					close(stop)
+					stop = nil
+				}
+			}
+
+		case code := <-done:
+			changes <- svc.Status{State: svc.StopPending}
+			if code == svcutil.ExitSuccess.AsInt() {
+				return false, 0
+			}
+			// A service specific exit code makes the service manager
+			// consider it failed and apply the recovery actions.
+			return true, uint32(code)
+		}
+	}
+}
+
+// eventLogWriter passes the warnings and panics in Syncthing's log output
+// on to the event log, discarding everything else. The full output goes to
+// the log file.
+type eventLogWriter struct {
+	elog *eventlog.Log
+}
+
+func (w eventLogWriter) Write(bs []byte) (int, error) {
+	if w.elog == nil {
+		return len(bs), nil
+	}
+	line := strings.TrimSpace(string(bs))
+	switch {
+	case strings.Contains(line, " WARNING: "):
+		_ = w.elog.Warning(serviceEventID, line)
+	case strings.HasPrefix(line, "panic:"):
+		_ = w.elog.Error(serviceEventID, line)
+	}
+	return len(bs), nil
+}
+
+func installService(name, user, password string, args []string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(name); err == nil {
+		s.Close()
+		return errors.New("service already exists")
+	}
+
+	s, err := m.CreateService(name, exe, mgr.Config{
+		DisplayName:      serviceDisplayName,
+		Description:      serviceDescription,
+		StartType:        mgr.StartAutomatic,
+		DelayedAutoStart: true,
+		ServiceStartName: user,
+		Password:         password,
+	}, args...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if err := s.SetRecoveryActions(serviceRecoveryActions, serviceRecoveryResetPeriod); err != nil {
+		_ = s.Delete()
+		return err
+	}
+	// Apply the recovery actions also when we stop with an error, not only
+	// when we crash.
+	enabled := uint32(1)
+	if err := windows.ChangeServiceConfig2(s.Handle, windows.SERVICE_CONFIG_FAILURE_ACTIONS_FLAG, (*byte)(unsafe.Pointer(&enabled))); err != nil {
+		_ = s.Delete()
+		return err
+	}
+
+	if err := eventlog.InstallAsEventCreate(name, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
+		_ = s.Delete()
+		return err
+	}
+	return nil
+}
+
+func uninstallService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if err := s.Delete(); err != nil {
+		return err
+	}
+	// The event log source may have been removed by hand; that's fine.
+	_ = eventlog.Remove(name)
+	return nil
+}
+
+func startService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	return s.Start()
+}
+
+func stopService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	status, err := s.Control(svc.Stop)
+	if err != nil {
+		return err
+	}
+	timeout := time.After(serviceStopTimeout)
+	for status.State != svc.Stopped {
+		select {
+		case <-timeout:
+			return errors.New("timed out waiting for the service to stop")
+		case <-time.After(300 * time.Millisecond):
+		}
+		if status, err = s.Query(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
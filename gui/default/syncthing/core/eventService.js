@@ -80,6 +80,7 @@ angular.module('syncthing.core')
             FOLDER_SCAN_PROGRESS: 'FolderScanProgress',   // Emitted every ScanProgressIntervalS seconds, indicating how far into the scan it is at.
             FOLDER_PAUSED: 'FolderPaused',   // Emitted when a folder is paused
             FOLDER_RESUMED: 'FolderResumed',   // Emitted when a folder is resumed
+            EVENTS_DROPPED: 'EventsDropped',   // Inserted where events were dropped because we fell behind
 
             start: function () {
                 $http.get(urlbase + '/events?limit=1')
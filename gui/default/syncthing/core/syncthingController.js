@@ -68,11 +68,16 @@ angular.module('syncthing.core')
         $scope.versioningDefaults = {
             selector: "none",
             trashcanClean: 0,
+            trashcanMaxSize: 0,
             cleanupIntervalS: 3600,
             simpleKeep: 5,
             staggeredMaxAge: 365,
             staggeredCleanInterval: 3600,
             externalCommand: "",
+            s3Endpoint: "",
+            s3Bucket: "",
+            s3Region: "",
+            s3Prefix: "",
         };
 
         $scope.localStateTotal = {
@@ -140,6 +145,12 @@ angular.module('syncthing.core')
 
             $http.get(urlbase + '/system/upgrade').success(function (data) {
                 $scope.upgradeInfo = data;
+                $scope.upgradeInfo.incompatibleDevices = Object.keys(data.incompatiblePeers || {}).map(function (deviceID) {
+                    return {
+                        name: $scope.friendlyNameFromID(deviceID),
+                        version: data.incompatiblePeers[deviceID]
+                    };
+                });
             }).error(function () {
                 $scope.upgradeInfo = null;
             });
@@ -163,6 +174,15 @@ angular.module('syncthing.core')
             }
         });
 
+        $scope.$on(Events.EVENTS_DROPPED, function (event, arg) {
+            // We missed some events, so what we know may be out of date.
+            console.log('EventsDropped', arg.data.count);
+            refreshConfig();
+            refreshCluster();
+            refreshConnectionStats();
+            refreshGlobalChanges();
+        });
+
         $scope.$on('HTTPError', function (event, arg) {
             // Emitted when a HTTP call fails. We use the status code to try
             // to figure out what's wrong.
@@ -436,6 +456,8 @@ angular.module('syncthing.core')
             $scope.config.options._listenAddressesStr = $scope.config.options.listenAddresses.join(', ');
             $scope.config.options._globalAnnounceServersStr = $scope.config.options.globalAnnounceServers.join(', ');
             $scope.config.options._urAcceptedStr = "" + $scope.config.options.urAccepted;
+            $scope.config.options._urExcludedCategoriesStr = $scope.config.options.urExcludedCategories.join(', ');
+            $scope.config.options._disabledListenAddressesStr = $scope.config.options.disabledListenAddresses.join(', ');
 
             $scope.devices = deviceMap($scope.config.devices);
             for (var id in $scope.devices) {
@@ -471,12 +493,18 @@ angular.module('syncthing.core')
                 }
 
                 var listenersFailed = [];
+                var listenersBound = [];
                 for (var address in data.connectionServiceStatus) {
-                    if (data.connectionServiceStatus[address].error) {
-                        listenersFailed.push(address + ": " + data.connectionServiceStatus[address].error);
+                    var status = data.connectionServiceStatus[address];
+                    if (status.error) {
+                        listenersFailed.push(address + ": " + status.error);
+                    } else if (status.boundAddress && status.boundAddress !== address) {
+                        // Listening on port zero, i.e. a port picked by the system.
+                        listenersBound.push(address + ": " + status.boundAddress);
                     }
                 }
                 $scope.listenersFailed = listenersFailed;
+                $scope.listenersBound = listenersBound;
                 $scope.listenersTotal = $scope.sizeOf(data.connectionServiceStatus);
 
                 $scope.discoveryTotal = data.discoveryMethods;
@@ -559,16 +587,19 @@ angular.module('syncthing.core')
         }
 
         function recalcCompletion(device) {
-            var total = 0, needed = 0, deletes = 0, items = 0;
+            var total = 0, needed = 0, deletes = 0, items = 0, rate = 0;
             for (var folder in $scope.completion[device]) {
-                if (folder === "_total" || folder === '_needBytes' || folder === '_needItems') {
+                if (folder === "_total" || folder === '_needBytes' || folder === '_needItems' || folder === '_remainingSeconds') {
                     continue;
                 }
                 total += $scope.completion[device][folder].globalBytes;
                 needed += $scope.completion[device][folder].needBytes;
                 items += $scope.completion[device][folder].needItems;
                 deletes += $scope.completion[device][folder].needDeletes;
+                rate += $scope.completion[device][folder].bytesPerSecond || 0;
             }
+            // Null when there is no telling, e.g. while nothing is moving.
+            $scope.completion[device]._remainingSeconds = rate >= 1 ? needed / rate : null;
             if (total == 0) {
                 $scope.completion[device]._total = 100;
                 $scope.completion[device]._needBytes = 0;
@@ -791,6 +822,18 @@ angular.module('syncthing.core')
             }).error($scope.emitHTTPError);
         };
 
+        $scope.retryFailed = function () {
+            var url = urlbase + '/folder/retry?folder=' + encodeURIComponent($scope.failed.folder);
+            $http.post(url).error($scope.emitHTTPError);
+        };
+
+        $scope.skipFailed = function (path) {
+            var url = urlbase + '/folder/skip?folder=' + encodeURIComponent($scope.failed.folder);
+            $http.post(url, [path]).success(function () {
+                $scope.refreshFailed($scope.failed.page, $scope.failed.perpage);
+            }).error($scope.emitHTTPError);
+        };
+
         $scope.refreshRemoteNeed = function (folder, page, perpage) {
             if (!$scope.remoteNeedDevice) {
                 return;
@@ -913,7 +956,7 @@ angular.module('syncthing.core')
             if (status === 'idle' || status === 'localadditions') {
                 return 'success';
             }
-            if (status == 'paused') {
+            if (status == 'paused' || status == 'paused-by-schedule') {
                 return 'default';
             }
             if (status === 'syncing' || status === 'sync-preparing' || status === 'scanning' || status === 'cleaning') {
@@ -922,7 +965,7 @@ angular.module('syncthing.core')
             if (status === 'unknown') {
                 return 'info';
             }
-            if (status === 'stopped' || status === 'outofsync' || status === 'error' || status === 'faileditems') {
+            if (status === 'stopped' || status === 'outofsync' || status === 'error' || status === 'faileditems' || status === 'quarantined') {
                 return 'danger';
             }
             if (status === 'unshared' || status === 'scan-waiting' || status === 'sync-waiting' || status === 'clean-waiting') {
@@ -1429,6 +1472,12 @@ angular.module('syncthing.core')
                         return x.trim();
                     });
                 });
+                $scope.tmpOptions.urExcludedCategories = $scope.tmpOptions._urExcludedCategoriesStr.split(/[ ,]+/).filter(function (x) {
+                    return x !== '';
+                });
+                $scope.tmpOptions.disabledListenAddresses = $scope.tmpOptions._disabledListenAddressesStr.split(/[ ,]+/).filter(function (x) {
+                    return x !== '';
+                });
 
                 // Apply new settings locally
                 $scope.thisDeviceIn($scope.tmpDevices).name = $scope.tmpOptions.deviceName;
@@ -1486,7 +1535,8 @@ angular.module('syncthing.core')
             $('#upgrade').modal('hide');
             $('#majorUpgrade').modal('hide');
             $('#upgrading').modal();
-            $http.post(urlbase + '/system/upgrade').success(function () {
+            // The warnings were shown in the upgrade dialog.
+            $http.post(urlbase + '/system/upgrade?force=true').success(function () {
                 $('#restarting').modal();
                 $('#upgrading').modal('hide');
             }).error(function () {
@@ -1730,6 +1780,11 @@ angular.module('syncthing.core')
             return devices;
         };
 
+        $scope.setMaintenanceFreeze = function (freeze) {
+            $scope.config.options.maintenanceFreeze = freeze;
+            $scope.saveConfig();
+        };
+
         $scope.setAllDevicesPause = function (pause) {
             for (var id in $scope.devices) {
                 $scope.devices[id].paused = pause;
@@ -1889,6 +1944,7 @@ angular.module('syncthing.core')
                 // undefined path leads to invalid input field
                 $scope.currentFolder.path = '';
             }
+            $scope.currentFolder._pullOrderPatternsStr = ($scope.currentFolder.pullOrderPatterns || []).join(', ');
             initShareEditing('folder');
             editFolderModal();
         }
@@ -1897,7 +1953,7 @@ angular.module('syncthing.core')
             if (!$scope.currentFolder._guiVersioning) {
                 return false;
             }
-            return ['none', 'external'].indexOf($scope.currentFolder._guiVersioning.selector) === -1;
+            return ['none', 'external', 's3'].indexOf($scope.currentFolder._guiVersioning.selector) === -1;
         };
 
         function initVersioningEditing() {
@@ -1916,6 +1972,7 @@ angular.module('syncthing.core')
             switch (currentVersioning.type) {
             case "trashcan":
                 $scope.currentFolder._guiVersioning.trashcanClean = +currentVersioning.params.cleanoutDays;
+                $scope.currentFolder._guiVersioning.trashcanMaxSize = +(currentVersioning.params.maxSizeMiB || 0);
                 break;
             case "simple":
                 $scope.currentFolder._guiVersioning.simpleKeep = +currentVersioning.params.keep;
@@ -1928,6 +1985,12 @@ angular.module('syncthing.core')
             case "external":
                 $scope.currentFolder._guiVersioning.externalCommand = currentVersioning.params.command;
                 break;
+            case "s3":
+                $scope.currentFolder._guiVersioning.s3Endpoint = currentVersioning.params.endpoint;
+                $scope.currentFolder._guiVersioning.s3Bucket = currentVersioning.params.bucket;
+                $scope.currentFolder._guiVersioning.s3Region = currentVersioning.params.region;
+                $scope.currentFolder._guiVersioning.s3Prefix = currentVersioning.params.prefix;
+                break;
             }
         };
 
@@ -2053,6 +2116,7 @@ angular.module('syncthing.core')
             switch (folderCfg._guiVersioning.selector) {
             case "trashcan":
                 folderCfg.versioning.params.cleanoutDays = '' + folderCfg._guiVersioning.trashcanClean;
+                folderCfg.versioning.params.maxSizeMiB = '' + folderCfg._guiVersioning.trashcanMaxSize;
                 break;
             case "simple":
                 folderCfg.versioning.params.keep = '' + folderCfg._guiVersioning.simpleKeep,
@@ -2065,11 +2129,24 @@ angular.module('syncthing.core')
             case "external":
                 folderCfg.versioning.params.command = '' + folderCfg._guiVersioning.externalCommand;
                 break;
+            case "s3":
+                folderCfg.versioning.params.endpoint = '' + folderCfg._guiVersioning.s3Endpoint;
+                folderCfg.versioning.params.bucket = '' + folderCfg._guiVersioning.s3Bucket;
+                folderCfg.versioning.params.region = '' + folderCfg._guiVersioning.s3Region;
+                folderCfg.versioning.params.prefix = '' + folderCfg._guiVersioning.s3Prefix;
+                break;
             default:
                 delete folderCfg.versioning;
             }
             delete folderCfg._guiVersioning;
 
+            folderCfg.pullOrderPatterns = (folderCfg._pullOrderPatternsStr || '').split(',').map(function (x) {
+                return x.trim();
+            }).filter(function (x) {
+                return x !== '';
+            });
+            delete folderCfg._pullOrderPatternsStr;
+
             if ($scope.editingDefaults) {
                 $scope.config.defaults.folder = folderCfg;
                 $scope.saveConfig();
@@ -2467,6 +2544,10 @@ angular.module('syncthing.core')
             $http.post(urlbase + "/db/override?folder=" + encodeURIComponent(folder));
         };
 
+        $scope.confirmChanges = function (folder) {
+            $http.post(urlbase + "/db/confirm?folder=" + encodeURIComponent(folder));
+        };
+
         $scope.showLocalChanged = function (folder) {
             $scope.localChangedFolder = folder;
             $scope.localChanged = $scope.refreshLocalChanged(1, 10);
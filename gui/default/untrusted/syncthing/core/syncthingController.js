@@ -68,11 +68,16 @@ angular.module('syncthing.core')
         $scope.versioningDefaults = {
             selector: "none",
             trashcanClean: 0,
+            trashcanMaxSize: 0,
             cleanupIntervalS: 3600,
             simpleKeep: 5,
             staggeredMaxAge: 365,
             staggeredCleanInterval: 3600,
             externalCommand: "",
+            s3Endpoint: "",
+            s3Bucket: "",
+            s3Region: "",
+            s3Prefix: "",
         };
 
         $scope.localStateTotal = {
@@ -920,7 +925,7 @@ angular.module('syncthing.core')
             if (status === 'idle' || status === 'localadditions') {
                 return 'success';
             }
-            if (status == 'paused') {
+            if (status == 'paused' || status == 'paused-by-schedule') {
                 return 'default';
             }
             if (status === 'syncing' || status === 'sync-preparing' || status === 'scanning' || status === 'cleaning') {
@@ -929,7 +934,7 @@ angular.module('syncthing.core')
             if (status === 'unknown') {
                 return 'info';
             }
-            if (status === 'stopped' || status === 'outofsync' || status === 'error' || status === 'faileditems' || status === 'localunencrypted') {
+            if (status === 'stopped' || status === 'outofsync' || status === 'error' || status === 'faileditems' || status === 'localunencrypted' || status === 'quarantined') {
                 return 'danger';
             }
             if (status === 'unshared' || status === 'scan-waiting' || status === 'sync-waiting' || status === 'clean-waiting') {
@@ -1747,6 +1752,11 @@ angular.module('syncthing.core')
             return devices;
         };
 
+        $scope.setMaintenanceFreeze = function (freeze) {
+            $scope.config.options.maintenanceFreeze = freeze;
+            $scope.saveConfig();
+        };
+
         $scope.setAllDevicesPause = function (pause) {
             for (var id in $scope.devices) {
                 $scope.devices[id].paused = pause;
@@ -1928,7 +1938,7 @@ angular.module('syncthing.core')
             if (!$scope.currentFolder._guiVersioning) {
                 return false;
             }
-            return ['none', 'external'].indexOf($scope.currentFolder._guiVersioning.selector) === -1;
+            return ['none', 'external', 's3'].indexOf($scope.currentFolder._guiVersioning.selector) === -1;
         };
 
         function initVersioningEditing() {
@@ -1947,6 +1957,7 @@ angular.module('syncthing.core')
             switch (currentVersioning.type) {
             case "trashcan":
                 $scope.currentFolder._guiVersioning.trashcanClean = +currentVersioning.params.cleanoutDays;
+                $scope.currentFolder._guiVersioning.trashcanMaxSize = +(currentVersioning.params.maxSizeMiB || 0);
                 break;
             case "simple":
                 $scope.currentFolder._guiVersioning.simpleKeep = +currentVersioning.params.keep;
@@ -1959,6 +1970,12 @@ angular.module('syncthing.core')
             case "external":
                 $scope.currentFolder._guiVersioning.externalCommand = currentVersioning.params.command;
                 break;
+            case "s3":
+                $scope.currentFolder._guiVersioning.s3Endpoint = currentVersioning.params.endpoint;
+                $scope.currentFolder._guiVersioning.s3Bucket = currentVersioning.params.bucket;
+                $scope.currentFolder._guiVersioning.s3Region = currentVersioning.params.region;
+                $scope.currentFolder._guiVersioning.s3Prefix = currentVersioning.params.prefix;
+                break;
             }
         };
 
@@ -2093,6 +2110,7 @@ angular.module('syncthing.core')
             switch (folderCfg._guiVersioning.selector) {
             case "trashcan":
                 folderCfg.versioning.params.cleanoutDays = '' + folderCfg._guiVersioning.trashcanClean;
+                folderCfg.versioning.params.maxSizeMiB = '' + folderCfg._guiVersioning.trashcanMaxSize;
                 break;
             case "simple":
                 folderCfg.versioning.params.keep = '' + folderCfg._guiVersioning.simpleKeep,
@@ -2105,6 +2123,12 @@ angular.module('syncthing.core')
             case "external":
                 folderCfg.versioning.params.command = '' + folderCfg._guiVersioning.externalCommand;
                 break;
+            case "s3":
+                folderCfg.versioning.params.endpoint = '' + folderCfg._guiVersioning.s3Endpoint;
+                folderCfg.versioning.params.bucket = '' + folderCfg._guiVersioning.s3Bucket;
+                folderCfg.versioning.params.region = '' + folderCfg._guiVersioning.s3Region;
+                folderCfg.versioning.params.prefix = '' + folderCfg._guiVersioning.s3Prefix;
+                break;
             default:
                 delete folderCfg.versioning;
             }
@@ -2507,6 +2531,10 @@ angular.module('syncthing.core')
             $http.post(urlbase + "/db/override?folder=" + encodeURIComponent(folder));
         };
 
+        $scope.confirmChanges = function (folder) {
+            $http.post(urlbase + "/db/confirm?folder=" + encodeURIComponent(folder));
+        };
+
         $scope.showLocalChanged = function (folder, folderType) {
             $scope.localChangedFolder = folder;
             $scope.localChangedType = folderType;
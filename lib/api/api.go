@@ -36,10 +36,12 @@ import (
 	"github.com/thejerf/suture/v4"
 	"github.com/vitrun/qart/qr"
 
+	"github.com/syncthing/syncthing/lib/backup"
 	"github.com/syncthing/syncthing/lib/build"
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/connections"
 	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/discover"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
@@ -65,6 +67,7 @@ const (
 	EventSubBufferSize    = 1000
 	defaultEventTimeout   = time.Minute
 	httpsCertLifetimeDays = 820
+	devCertLifetimeDays   = 20 * 365
 	featureFlagUntrusted  = "untrusted"
 )
 
@@ -84,6 +87,7 @@ type service struct {
 	urService            *ur.Service
 	noUpgrade            bool
 	tlsDefaultCommonName string
+	locations            *locations.Set
 	configChanged        chan struct{} // signals intentional listener close due to config change
 	started              chan string   // signals startup complete by sending the listener address, for testing only
 	startedOnce          chan struct{} // the service has started successfully at least once
@@ -101,7 +105,7 @@ type Service interface {
 	WaitForStart() error
 }
 
-func New(id protocol.DeviceID, cfg config.Wrapper, assetDir, tlsDefaultCommonName string, m model.Model, defaultSub, diskSub events.BufferedSubscription, evLogger events.Logger, discoverer discover.Manager, connectionsService connections.Service, urService *ur.Service, fss model.FolderSummaryService, errors, systemLog logger.Recorder, noUpgrade bool) Service {
+func New(id protocol.DeviceID, cfg config.Wrapper, locs *locations.Set, assetDir, tlsDefaultCommonName string, m model.Model, defaultSub, diskSub events.BufferedSubscription, evLogger events.Logger, discoverer discover.Manager, connectionsService connections.Service, urService *ur.Service, fss model.FolderSummaryService, errors, systemLog logger.Recorder, noUpgrade bool) Service {
 	return &service{
 		id:      id,
 		cfg:     cfg,
@@ -110,6 +114,9 @@ func New(id protocol.DeviceID, cfg config.Wrapper, assetDir, tlsDefaultCommonNam
 		eventSubs: map[events.EventType]events.BufferedSubscription{
 			DefaultEventMask: defaultSub,
 			DiskEventMask:    diskSub,
+			// Subscribed from the start, so that the audit log covers
+			// changes made before anyone asks for it.
+			events.ConfigChanged: events.NewBufferedSubscription(evLogger.Subscribe(events.ConfigChanged), EventSubBufferSize),
 		},
 		eventSubsMut:         sync.NewMutex(),
 		evLogger:             evLogger,
@@ -121,6 +128,7 @@ func New(id protocol.DeviceID, cfg config.Wrapper, assetDir, tlsDefaultCommonNam
 		systemLog:            systemLog,
 		noUpgrade:            noUpgrade,
 		tlsDefaultCommonName: tlsDefaultCommonName,
+		locations:            locs,
 		configChanged:        make(chan struct{}),
 		startedOnce:          make(chan struct{}),
 		exitChan:             make(chan *svcutil.FatalErr, 1),
@@ -133,8 +141,8 @@ func (s *service) WaitForStart() error {
 }
 
 func (s *service) getListener(guiCfg config.GUIConfiguration) (net.Listener, error) {
-	httpsCertFile := locations.Get(locations.HTTPSCertFile)
-	httpsKeyFile := locations.Get(locations.HTTPSKeyFile)
+	httpsCertFile := s.locations.Get(locations.HTTPSCertFile)
+	httpsKeyFile := s.locations.Get(locations.HTTPSKeyFile)
 	cert, err := tls.LoadX509KeyPair(httpsCertFile, httpsKeyFile)
 
 	// If the certificate has expired or will expire in the next month, fail
@@ -161,12 +169,30 @@ func (s *service) getListener(guiCfg config.GUIConfiguration) (net.Listener, err
 	}
 	tlsCfg := tlsutil.SecureDefault()
 	tlsCfg.Certificates = []tls.Certificate{cert}
+	if guiCfg.RequireClientCertificate {
+		// The certificates are usually self signed, so we check them
+		// against the configured fingerprints instead of a CA.
+		tlsCfg.ClientAuth = tls.RequireAnyClientCert
+		tlsCfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !guiCfg.AllowsClientCertificate(rawCerts[0]) {
+				return errClientCertificateNotAllowed
+			}
+			return nil
+		}
+	}
 
 	if guiCfg.Network() == "unix" {
 		// When listening on a UNIX socket we should unlink before bind,
 		// lest we get a "bind: address already in use". We don't
-		// particularly care if this succeeds or not.
-		os.Remove(guiCfg.Address())
+		// particularly care if this succeeds or not, but take care not to
+		// remove anything else that happens to be at the path.
+		if info, err := os.Lstat(guiCfg.Address()); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(guiCfg.Address())
+		}
+		// The directory, e.g. /run/syncthing, may not exist yet.
+		if err := os.MkdirAll(filepath.Dir(guiCfg.Address()), 0755); err != nil {
+			return nil, err
+		}
 	}
 	rawListener, err := net.Listen(guiCfg.Network(), guiCfg.Address())
 	if err != nil {
@@ -238,45 +264,66 @@ func (s *service) Serve(ctx context.Context) error {
 
 	// The GET handlers
 	restMux.HandlerFunc(http.MethodGet, "/rest/cluster/pending/devices", s.getPendingDevices) // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/config/audit", s.getConfigAudit)               // [since]
 	restMux.HandlerFunc(http.MethodGet, "/rest/cluster/pending/folders", s.getPendingFolders) // [device]
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/completion", s.getDBCompletion)             // [device] [folder]
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/file", s.getDBFile)                         // folder file
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/ignores", s.getDBIgnores)                   // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/db/ignores/explain", s.getDBIgnoresExplain)    // folder file
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/need", s.getDBNeed)                         // folder [perpage] [page]
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/remoteneed", s.getDBRemoteNeed)             // device folder [perpage] [page]
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/localchanged", s.getDBLocalChanged)         // folder
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/status", s.getDBStatus)                     // folder
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/browse", s.getDBBrowse)                     // folder [prefix] [dirsonly] [levels]
-	restMux.HandlerFunc(http.MethodGet, "/rest/folder/versions", s.getFolderVersions)         // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/db/size", s.getDBSize)                         // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/db/tombstones", s.getDBTombstones)             // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/versions", s.getFolderVersions)         // folder [path]
 	restMux.HandlerFunc(http.MethodGet, "/rest/folder/errors", s.getFolderErrors)             // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/conflicts", s.getFolderConflicts)       // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/outofsync", s.getFolderOutOfSync)       // folder [device] [perpage] [page]
 	restMux.HandlerFunc(http.MethodGet, "/rest/folder/pullerrors", s.getFolderErrors)         // folder (deprecated)
-	restMux.HandlerFunc(http.MethodGet, "/rest/events", s.getIndexEvents)                     // [since] [limit] [timeout] [events]
-	restMux.HandlerFunc(http.MethodGet, "/rest/events/disk", s.getDiskEvents)                 // [since] [limit] [timeout]
+	restMux.HandlerFunc(http.MethodGet, "/rest/events", s.getIndexEvents)                     // [since] [limit] [timeout] [events] [folder]
+	restMux.HandlerFunc(http.MethodGet, "/rest/events/disk", s.getDiskEvents)                 // [since] [limit] [timeout] [folder]
+	restMux.HandlerFunc(http.MethodGet, "/rest/events/stream", s.getEventStream)              // [since] [limit] [events] [folder] (websocket)
 	restMux.HandlerFunc(http.MethodGet, "/rest/stats/device", s.getDeviceStats)               // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/stats/folder", s.getFolderStats)               // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/svc/deviceid", s.getDeviceID)                  // id
 	restMux.HandlerFunc(http.MethodGet, "/rest/svc/lang", s.getLang)                          // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/svc/report", s.getReport)                      // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/svc/report/payload", s.getReportPayload)       // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/svc/random/string", s.getRandomString)         // [length]
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/browse", s.getSystemBrowse)             // current
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/connections", s.getSystemConnections)   // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/discovery", s.getSystemDiscovery)       // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/error", s.getSystemError)               // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/ping", s.restPing)                      // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/relays", s.getSystemRelays)             // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/status", s.getSystemStatus)             // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/upgrade", s.getSystemUpgrade)           // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/version", s.getSystemVersion)           // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/debug", s.getSystemDebug)               // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/log", s.getSystemLog)                   // [since]
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/log.txt", s.getSystemLogTxt)            // [since]
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/certrotation", s.getSystemCertRotation) // -
 
 	// The POST handlers
+	restMux.HandlerFunc(http.MethodPost, "/rest/cluster/push-config", s.postClusterPushConfig)   // device <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/cluster/benchmark", s.postClusterBenchmark)      // device [seconds]
+	restMux.HandlerFunc(http.MethodPost, "/rest/cluster/upgrade", s.postClusterUpgrade)          // [device...] [version] [checkonly]
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/prio", s.postDBPrio)                          // folder file [perpage] [page]
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/ignores", s.postDBIgnores)                    // folder
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/override", s.postDBOverride)                  // folder
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/revert", s.postDBRevert)                      // folder
+	restMux.HandlerFunc(http.MethodPost, "/rest/db/confirm", s.postDBConfirm)                    // folder
+	restMux.HandlerFunc(http.MethodPost, "/rest/db/check", s.postDBCheck)                        // [folder]
+	restMux.HandlerFunc(http.MethodPost, "/rest/db/verify", s.postDBVerify)                      // folder [repair]
+	restMux.HandlerFunc(http.MethodPost, "/rest/db/compact", s.postDBCompact)                    // -
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/scan", s.postDBScan)                          // folder [sub...] [delay]
 	restMux.HandlerFunc(http.MethodPost, "/rest/folder/versions", s.postFolderVersionsRestore)   // folder <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/pause", s.makeFolderPauseHandler(true))   // [folder]
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/resume", s.makeFolderPauseHandler(false)) // [folder]
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/skip", s.postFolderSkip)                  // folder <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/retry", s.postFolderRetry)                // folder [<body>]
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/error", s.postSystemError)                // <body>
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/error/clear", s.postSystemErrorClear)     // -
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/ping", s.restPing)                        // -
@@ -287,13 +334,19 @@ func (s *service) Serve(ctx context.Context) error {
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/pause", s.makeDevicePauseHandler(true))   // [device]
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/resume", s.makeDevicePauseHandler(false)) // [device]
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/debug", s.postSystemDebug)                // [enable] [disable]
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/freeze", s.makeFreezeHandler(true))       // -
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/unfreeze", s.makeFreezeHandler(false))    // -
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/lowimpact", s.postSystemLowImpact)        // [enabled]
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/certrotation", s.postSystemCertRotation)  // -
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/backup", s.postSystemBackup)              // <body>
 
 	// Config endpoints
 
 	configBuilder := &configMuxBuilder{
-		Router: restMux,
-		id:     s.id,
-		cfg:    s.cfg,
+		Router:    restMux,
+		id:        s.id,
+		cfg:       s.cfg,
+		locations: s.locations,
 	}
 
 	configBuilder.registerConfig("/rest/config")
@@ -303,11 +356,15 @@ func (s *service) Serve(ctx context.Context) error {
 	configBuilder.registerDevices("/rest/config/devices")
 	configBuilder.registerFolder("/rest/config/folders/:id")
 	configBuilder.registerDevice("/rest/config/devices/:id")
+	configBuilder.registerDeviceGroups("/rest/config/groups")
+	configBuilder.registerDeviceGroup("/rest/config/groups/:id")
 	configBuilder.registerDefaultFolder("/rest/config/defaults/folder")
 	configBuilder.registerDefaultDevice("/rest/config/defaults/device")
 	configBuilder.registerOptions("/rest/config/options")
 	configBuilder.registerLDAP("/rest/config/ldap")
 	configBuilder.registerGUI("/rest/config/gui")
+	configBuilder.registerBundle("/rest/config/bundle")
+	configBuilder.registerHistory("/rest/config/history")
 
 	// Deprecated config endpoints
 	configBuilder.registerConfigDeprecated("/rest/system/config") // POST instead of PUT
@@ -339,9 +396,14 @@ func (s *service) Serve(ctx context.Context) error {
 
 	guiCfg := s.cfg.GUI()
 
+	// Prometheus metrics, for those who want them
+	if guiCfg.MetricsEnabled {
+		mux.Handle("/metrics", metricsAuthMiddleware(guiCfg, newMetricsHandler(s.cfg, s.model, s.locations, s.noUpgrade)))
+	}
+
 	// Wrap everything in CSRF protection. The /rest prefix should be
 	// protected, other requests will grant cookies.
-	var handler http.Handler = newCsrfManager(s.id.String()[:5], "/rest", guiCfg, mux, locations.Get(locations.CsrfTokens))
+	var handler http.Handler = newCsrfManager(s.id.String()[:5], "/rest", guiCfg, apiKeyScopeMiddleware(guiCfg, mux), s.locations.Get(locations.CsrfTokens))
 
 	// Add our version and ID as a header to responses
 	handler = withDetailsMiddleware(s.id, handler)
@@ -461,7 +523,7 @@ func (s *service) CommitConfiguration(from, to config.Configuration) bool {
 		s.statics.setUntrusted(untrusted)
 	}
 
-	if to.GUI == from.GUI {
+	if reflect.DeepEqual(to.GUI, from.GUI) {
 		// No GUI changes, we're done here.
 		return true
 	}
@@ -651,6 +713,82 @@ func (s *service) getPendingFolders(w http.ResponseWriter, r *http.Request) {
 	sendJSON(w, folders)
 }
 
+func (s *service) postClusterPushConfig(w http.ResponseWriter, r *http.Request) {
+	deviceID, err := protocol.DeviceIDFromString(r.URL.Query().Get("device"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bs, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := config.ParseFragment(bs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.model.PushConfig(deviceID, bs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+const (
+	defaultBenchmarkSeconds = 10
+	maxBenchmarkSeconds     = 60
+)
+
+func (s *service) postClusterBenchmark(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	deviceID, err := protocol.DeviceIDFromString(qs.Get("device"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	seconds := defaultBenchmarkSeconds
+	if str := qs.Get("seconds"); str != "" {
+		seconds, err = strconv.Atoi(str)
+		if err != nil || seconds <= 0 || seconds > maxBenchmarkSeconds {
+			http.Error(w, fmt.Sprintf("seconds must be between 1 and %d", maxBenchmarkSeconds), http.StatusBadRequest)
+			return
+		}
+	}
+
+	res, err := s.model.Benchmark(r.Context(), deviceID, time.Duration(seconds)*time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sendJSON(w, res)
+}
+
+// clusterUpgradeTimeout is how long we wait for the answers to an upgrade
+// request, which includes the devices downloading the release.
+const clusterUpgradeTimeout = 10 * time.Minute
+
+func (s *service) postClusterUpgrade(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	var devices []protocol.DeviceID
+	for _, str := range qs["device"] {
+		deviceID, err := protocol.DeviceIDFromString(str)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		devices = append(devices, deviceID)
+	}
+	checkOnly, _ := strconv.ParseBool(qs.Get("checkonly"))
+
+	ctx, cancel := context.WithTimeout(r.Context(), clusterUpgradeTimeout)
+	defer cancel()
+	sendJSON(w, s.model.RequestUpgrades(ctx, devices, qs.Get("version"), checkOnly))
+}
+
 func (s *service) restPing(w http.ResponseWriter, r *http.Request) {
 	sendJSON(w, map[string]string{"ping": "pong"})
 }
@@ -771,6 +909,13 @@ func (s *service) postDBRevert(w http.ResponseWriter, r *http.Request) {
 	go s.model.Revert(folder)
 }
 
+func (s *service) postDBConfirm(w http.ResponseWriter, r *http.Request) {
+	if err := s.model.ConfirmMassChange(r.URL.Query().Get("folder")); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
 func getPagingParams(qs url.Values) (int, int) {
 	page, err := strconv.Atoi(qs.Get("page"))
 	if err != nil || page < 1 {
@@ -998,6 +1143,9 @@ func (s *service) getSystemStatus(w http.ResponseWriter, r *http.Request) {
 
 	res["connectionServiceStatus"] = s.connectionsService.ListenerStatus()
 	res["lastDialStatus"] = s.connectionsService.ConnectionStatus()
+	if s.cfg.Options().NATEnabled {
+		res["natDevices"] = s.connectionsService.NATStatus()
+	}
 	res["cpuPercent"] = 0 // deprecated from API
 	res["pathSeparator"] = string(filepath.Separator)
 	res["urVersionMax"] = ur.Version
@@ -1005,6 +1153,13 @@ func (s *service) getSystemStatus(w http.ResponseWriter, r *http.Request) {
 	res["startTime"] = ur.StartTime
 	res["guiAddressOverridden"] = s.cfg.GUI().IsOverridden()
 	res["guiAddressUsed"] = s.listenerAddr.String()
+	res["maintenanceFreeze"] = s.cfg.Options().MaintenanceFreeze
+	res["lowImpactScans"] = s.cfg.Options().LowImpactScans
+	if budget := uint64(s.cfg.Options().MemoryBudgetMiB) << 20; budget > 0 {
+		res["memoryBudget"] = budget
+		// The share of the memory budget in use, in percent.
+		res["memoryPressure"] = 100 * (m.Sys - m.HeapReleased) / budget
+	}
 
 	sendJSON(w, res)
 }
@@ -1049,6 +1204,48 @@ func (s *service) getSystemLogTxt(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// configAuditEntry is a single change in the configuration audit log.
+type configAuditEntry struct {
+	Time  time.Time `json:"time"`
+	Actor string    `json:"actor"`
+	config.Change
+}
+
+func (s *service) getConfigAudit(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	since, err := time.Parse(time.RFC3339, q.Get("since"))
+	if err != nil {
+		l.Debugln(err)
+	}
+
+	s.eventSubsMut.Lock()
+	bufsub := s.eventSubs[events.ConfigChanged]
+	s.eventSubsMut.Unlock()
+
+	entries := make([]configAuditEntry, 0)
+	for _, ev := range bufsub.Since(0, nil, 0) {
+		if !ev.Time.After(since) {
+			continue
+		}
+		data, ok := ev.Data.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		actor, _ := data["actor"].(string)
+		changes, _ := data["changes"].([]config.Change)
+		for _, change := range changes {
+			entries = append(entries, configAuditEntry{
+				Time:   ev.Time,
+				Actor:  actor,
+				Change: change,
+			})
+		}
+	}
+	sendJSON(w, map[string][]configAuditEntry{
+		"changes": entries,
+	})
+}
+
 type fileEntry struct {
 	name string
 	data []byte
@@ -1081,7 +1278,7 @@ func (s *service) getSupportBundle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Panic files
-	if panicFiles, err := filepath.Glob(filepath.Join(locations.GetBaseDir(locations.ConfigBaseDir), "panic*")); err == nil {
+	if panicFiles, err := filepath.Glob(filepath.Join(s.locations.GetBaseDir(locations.ConfigBaseDir), "panic*")); err == nil {
 		for _, f := range panicFiles {
 			if panicFile, err := ioutil.ReadFile(f); err != nil {
 				l.Warnf("Support bundle: failed to load %s: %s", filepath.Base(f), err)
@@ -1092,7 +1289,7 @@ func (s *service) getSupportBundle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Archived log (default on Windows)
-	if logFile, err := ioutil.ReadFile(locations.Get(locations.LogFile)); err == nil {
+	if logFile, err := ioutil.ReadFile(s.locations.Get(locations.LogFile)); err == nil {
 		files = append(files, fileEntry{name: "log-ondisk.txt", data: logFile})
 	}
 
@@ -1148,7 +1345,7 @@ func (s *service) getSupportBundle(w http.ResponseWriter, r *http.Request) {
 
 	// Set zip file name and path
 	zipFileName := fmt.Sprintf("support-bundle-%s-%s.zip", s.id.Short().String(), time.Now().Format("2006-01-02T150405"))
-	zipFilePath := filepath.Join(locations.GetBaseDir(locations.ConfigBaseDir), zipFileName)
+	zipFilePath := filepath.Join(s.locations.GetBaseDir(locations.ConfigBaseDir), zipFileName)
 
 	// Write buffer zip to local zip file (back up)
 	if err := ioutil.WriteFile(zipFilePath, zipFilesBuffer.Bytes(), 0600); err != nil {
@@ -1181,6 +1378,10 @@ func (s *service) getSystemHTTPMetrics(w http.ResponseWriter, r *http.Request) {
 	w.Write(bs)
 }
 
+func (s *service) getSystemRelays(w http.ResponseWriter, r *http.Request) {
+	sendJSON(w, s.connectionsService.RelayProbeResults())
+}
+
 func (s *service) getSystemDiscovery(w http.ResponseWriter, r *http.Request) {
 	devices := make(map[string]discover.CacheEntry)
 
@@ -1210,6 +1411,21 @@ func (s *service) getReport(w http.ResponseWriter, r *http.Request) {
 
 }
 
+func (s *service) getReportPayload(w http.ResponseWriter, r *http.Request) {
+	bs, err := s.urService.Payload(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if bs == nil {
+		http.Error(w, "Usage reporting is not enabled", http.StatusNotFound)
+		return
+	}
+	// As is, rather than through sendJSON, to show exactly what is sent.
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(bs)
+}
+
 func (s *service) getRandomString(w http.ResponseWriter, r *http.Request) {
 	length := 32
 	if val, _ := strconv.Atoi(r.URL.Query().Get("length")); val > 0 {
@@ -1238,6 +1454,21 @@ func (s *service) getDBIgnores(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *service) getDBIgnoresExplain(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+
+	explanation, err := s.model.ExplainIgnore(qs.Get("folder"), qs.Get("file"))
+	if err != nil && !ignore.IsParseError(err) {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	sendJSON(w, map[string]interface{}{
+		"explanation": explanation,
+		"error":       errorString(err),
+	})
+}
+
 func (s *service) postDBIgnores(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 
@@ -1265,11 +1496,21 @@ func (s *service) postDBIgnores(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *service) getIndexEvents(w http.ResponseWriter, r *http.Request) {
-	mask := s.getEventMask(r.URL.Query().Get("events"))
-	sub := s.getEventSub(mask)
+	sub := s.getEventSub(s.requestEventMask(r))
 	s.getEvents(w, r, sub)
 }
 
+// requestEventMask returns the event types asked for in the request, that
+// the requester may see.
+func (s *service) requestEventMask(r *http.Request) events.EventType {
+	mask := s.getEventMask(r.URL.Query().Get("events"))
+	if key, ok := s.cfg.GUI().APIKeyScope(apiKeyFromRequest(r)); ok && key.Scope != config.APIKeyScopeAdmin {
+		// The saved configuration includes the credentials for full access.
+		mask &^= events.ConfigSaved
+	}
+	return mask
+}
+
 func (s *service) getDiskEvents(w http.ResponseWriter, r *http.Request) {
 	sub := s.getEventSub(DiskEventMask)
 	s.getEvents(w, r, sub)
@@ -1299,11 +1540,9 @@ func (s *service) getEvents(w http.ResponseWriter, r *http.Request, eventSub eve
 	f := w.(http.Flusher)
 	f.Flush()
 
-	// If there are no events available return an empty slice, as this gets serialized as `[]`
-	evs := eventSub.Since(since, []events.Event{}, timeout)
-	if 0 < limit && limit < len(evs) {
-		evs = evs[len(evs)-limit:]
-	}
+	// If there are no events available this returns an empty slice, as
+	// this gets serialized as `[]`
+	evs, _ := nextEvents(eventSub, since, newEventFilter(qs), limit, timeout)
 
 	sendJSON(w, evs)
 }
@@ -1347,8 +1586,19 @@ func (s *service) getSystemUpgrade(w http.ResponseWriter, r *http.Request) {
 	res := make(map[string]interface{})
 	res["running"] = build.Version
 	res["latest"] = rel.Tag
-	res["newer"] = upgrade.CompareVersions(rel.Tag, build.Version) == upgrade.Newer
+	newer := upgrade.CompareVersions(rel.Tag, build.Version) == upgrade.Newer
+	res["newer"] = newer
 	res["majorNewer"] = upgrade.CompareVersions(rel.Tag, build.Version) == upgrade.MajorNewer
+	res["minPeerVersion"] = rel.MinPeerVersion
+	res["configSchemaChange"] = rel.ConfigSchemaChange
+	res["incompatiblePeers"] = s.incompatiblePeers(rel)
+
+	if newer {
+		s.evLogger.Log(events.UpgradeAvailable, map[string]string{
+			"running": build.Version,
+			"latest":  rel.Tag,
+		})
+	}
 
 	sendJSON(w, res)
 }
@@ -1389,6 +1639,19 @@ func (s *service) postSystemUpgrade(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if upgrade.CompareVersions(rel.Tag, build.Version) > upgrade.Equal {
+		// Upgrading anyway needs to be asked for, after having seen the
+		// warnings in the upgrade information.
+		if force, _ := strconv.ParseBool(r.URL.Query().Get("force")); !force {
+			if peers := s.incompatiblePeers(rel); len(peers) > 0 {
+				http.Error(w, fmt.Sprintf("%s doesn't work with %d connected devices older than %s, set force to upgrade anyway", rel.Tag, len(peers), rel.MinPeerVersion), http.StatusConflict)
+				return
+			}
+			if rel.ConfigSchemaChange {
+				http.Error(w, fmt.Sprintf("%s migrates the configuration to a format older versions can't use, set force to upgrade anyway", rel.Tag), http.StatusConflict)
+				return
+			}
+		}
+
 		err = upgrade.To(rel)
 		if err != nil {
 			l.Warnln("upgrading:", err)
@@ -1404,6 +1667,20 @@ func (s *service) postSystemUpgrade(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// incompatiblePeers returns the versions of the connected devices that the
+// release doesn't work with.
+func (s *service) incompatiblePeers(rel upgrade.Release) map[string]string {
+	peers := make(map[string]string)
+	conns, _ := s.model.ConnectionStats()["connections"].(map[string]model.ConnectionInfo)
+	for device, ci := range conns {
+		// Other implementations are listed with their name first.
+		if ci.Connected && strings.HasPrefix(ci.ClientVersion, "v") && rel.IncompatiblePeer(ci.ClientVersion) {
+			peers[device] = ci.ClientVersion
+		}
+	}
+	return peers
+}
+
 func (s *service) makeDevicePauseHandler(paused bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var qs = r.URL.Query()
@@ -1411,7 +1688,7 @@ func (s *service) makeDevicePauseHandler(paused bool) http.HandlerFunc {
 
 		var msg string
 		var status int
-		_, err := s.cfg.Modify(func(cfg *config.Configuration) {
+		waiter, err := s.cfg.As(requestActor(r, s.cfg.GUI())).Modify(func(cfg *config.Configuration) {
 			if deviceStr == "" {
 				for i := range cfg.Devices {
 					cfg.Devices[i].Paused = paused
@@ -1436,12 +1713,192 @@ func (s *service) makeDevicePauseHandler(paused bool) http.HandlerFunc {
 			cfg.Devices[i].Paused = paused
 		})
 
-		if msg != "" {
-			http.Error(w, msg, status)
-		} else if err != nil {
+		s.finishPause(w, waiter, err, msg, status)
+	}
+}
+
+func (s *service) makeFolderPauseHandler(paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var qs = r.URL.Query()
+		var folder = qs.Get("folder")
+
+		var msg string
+		var status int
+		waiter, err := s.cfg.As(requestActor(r, s.cfg.GUI())).Modify(func(cfg *config.Configuration) {
+			if folder == "" {
+				for i := range cfg.Folders {
+					cfg.Folders[i].Paused = paused
+				}
+				return
+			}
+
+			_, i, ok := cfg.Folder(folder)
+			if !ok {
+				msg = "not found"
+				status = http.StatusNotFound
+				return
+			}
+
+			cfg.Folders[i].Paused = paused
+		})
+
+		s.finishPause(w, waiter, err, msg, status)
+	}
+}
+
+// finishPause reports the outcome of a pause or resume request, saving the
+// config once the change has been applied so that it survives a restart.
+func (s *service) finishPause(w http.ResponseWriter, waiter config.Waiter, err error, msg string, status int) {
+	if msg != "" {
+		http.Error(w, msg, status)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	waiter.Wait()
+	if err := s.cfg.Save(); err != nil {
+		l.Warnln("Saving config:", err)
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+func (s *service) makeFreezeHandler(freeze bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		waiter, err := s.cfg.As(requestActor(r, s.cfg.GUI())).Modify(func(cfg *config.Configuration) {
+			cfg.Options.MaintenanceFreeze = freeze
+		})
+		if err != nil {
 			http.Error(w, err.Error(), 500)
+			return
+		}
+		waiter.Wait()
+	}
+}
+
+// postSystemLowImpact switches the low impact profile for scans on, or off
+// with enabled=false.
+func (s *service) postSystemLowImpact(w http.ResponseWriter, r *http.Request) {
+	enabled := true
+	if str := r.URL.Query().Get("enabled"); str != "" {
+		var err error
+		enabled, err = strconv.ParseBool(str)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 	}
+	waiter, err := s.cfg.As(requestActor(r, s.cfg.GUI())).Modify(func(cfg *config.Configuration) {
+		cfg.Options.LowImpactScans = enabled
+	})
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	waiter.Wait()
+}
+
+func (s *service) getSystemCertRotation(w http.ResponseWriter, r *http.Request) {
+	next, err := tls.LoadX509KeyPair(s.locations.Get(locations.CertNextFile), s.locations.Get(locations.KeyNextFile))
+	if os.IsNotExist(err) {
+		sendJSON(w, map[string]interface{}{
+			"deviceID": s.id,
+		})
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.sendCertRotation(w, next)
+}
+
+// postSystemCertRotation starts rotating our certificate by generating the
+// one to rotate to, which is announced to other devices until it replaces
+// the current one at startup after the overlap period.
+func (s *service) postSystemCertRotation(w http.ResponseWriter, r *http.Request) {
+	certFile, keyFile := s.locations.Get(locations.CertNextFile), s.locations.Get(locations.KeyNextFile)
+	next, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if os.IsNotExist(err) {
+		l.Infoln("Generating certificate to rotate to")
+		next, err = tlsutil.NewCertificate(certFile, keyFile, s.tlsDefaultCommonName, devCertLifetimeDays)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cert, err := tls.LoadX509KeyPair(s.locations.Get(locations.CertFile), s.locations.Get(locations.KeyFile))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := s.model.SetNextCertificate(next, cert); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.sendCertRotation(w, next)
+}
+
+// postSystemBackup streams an encrypted bundle of the device certificate and
+// key, the configuration and optionally the database, to be imported with
+// "syncthing backup import".
+func (s *service) postSystemBackup(w http.ResponseWriter, r *http.Request) {
+	// The backup includes the device key and every credential, so it
+	// takes full access regardless of what the scope filter lets through.
+	if key, ok := s.cfg.GUI().APIKeyScope(apiKeyFromRequest(r)); ok && key.Scope != config.APIKeyScopeAdmin {
+		l.Debugf("API key %q (%v) denied backup", key.Name, key.Scope)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	bs, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req struct {
+		Password string `json:"password"`
+		Database bool   `json:"database"`
+	}
+	if err := json.Unmarshal(bs, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Password == "" {
+		http.Error(w, backup.ErrNoPassword.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filename := fmt.Sprintf("syncthing-%s-%s.stbackup", s.id.Short(), time.Now().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+
+	export := func(db backend.Reader) error {
+		return backup.Export(w, req.Password, s.locations, db)
+	}
+	if req.Database {
+		err = s.model.ReadDatabase(export)
+	} else {
+		err = export(nil)
+	}
+	if err != nil {
+		// The bundle is cut short, which import detects.
+		l.Warnln("Exporting backup:", err)
+	}
+}
+
+func (s *service) sendCertRotation(w http.ResponseWriter, next tls.Certificate) {
+	nextX509, err := x509.ParseCertificate(next.Certificate[0])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	overlap := time.Duration(s.cfg.Options().CertificateRotationOverlapDays) * 24 * time.Hour
+	sendJSON(w, map[string]interface{}{
+		"deviceID":     s.id,
+		"nextDeviceID": protocol.NewDeviceID(next.Certificate[0]),
+		"replaceAfter": nextX509.NotBefore.Add(overlap),
+	})
 }
 
 func (s *service) postDBScan(w http.ResponseWriter, r *http.Request) {
@@ -1469,6 +1926,59 @@ func (s *service) postDBScan(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *service) postDBCheck(w http.ResponseWriter, r *http.Request) {
+	results, err := s.model.CheckDatabase(r.URL.Query().Get("folder"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sendJSON(w, results)
+}
+
+func (s *service) postDBVerify(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	var repair bool
+	if str := qs.Get("repair"); str != "" {
+		var err error
+		repair, err = strconv.ParseBool(str)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	report, err := s.model.VerifyFolder(qs.Get("folder"), repair)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sendJSON(w, report)
+}
+
+func (s *service) postDBCompact(w http.ResponseWriter, r *http.Request) {
+	if err := s.model.CompactDatabase(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *service) getDBSize(w http.ResponseWriter, r *http.Request) {
+	size, err := s.model.DatabaseSize()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sendJSON(w, size)
+}
+
+func (s *service) getDBTombstones(w http.ResponseWriter, r *http.Request) {
+	folder := r.URL.Query().Get("folder")
+	report, err := s.model.Tombstones(folder)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, report)
+}
+
 func (s *service) postDBPrio(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	folder := qs.Get("folder")
@@ -1521,6 +2031,15 @@ func (s *service) getFolderVersions(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), 500)
 		return
 	}
+	// Only the versions of the given file, or of the files in the given
+	// directory.
+	if prefix := strings.Trim(qs.Get("path"), "/"); prefix != "" {
+		for name := range versions {
+			if name != prefix && !strings.HasPrefix(name, prefix+"/") {
+				delete(versions, name)
+			}
+		}
+	}
 	sendJSON(w, versions)
 }
 
@@ -1579,6 +2098,96 @@ func (s *service) getFolderErrors(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *service) getFolderOutOfSync(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	folder := qs.Get("folder")
+
+	device := protocol.LocalDeviceID // the default, i.e. what we need
+	if str := qs.Get("device"); str != "" {
+		var err error
+		device, err = protocol.DeviceIDFromString(str)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, perpage := getPagingParams(qs)
+
+	items, err := s.model.OutOfSyncItems(folder, device, page, perpage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	res := make([]jsonOutOfSyncItem, len(items))
+	for i, item := range items {
+		res[i] = jsonOutOfSyncItem(item)
+	}
+	sendJSON(w, map[string]interface{}{
+		"folder":  folder,
+		"items":   res,
+		"page":    page,
+		"perpage": perpage,
+	})
+}
+
+func (s *service) postFolderSkip(w http.ResponseWriter, r *http.Request) {
+	paths, err := readPaths(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(paths) == 0 {
+		http.Error(w, "no items to skip", http.StatusBadRequest)
+		return
+	}
+	if err := s.model.SkipItems(r.URL.Query().Get("folder"), paths); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *service) postFolderRetry(w http.ResponseWriter, r *http.Request) {
+	paths, err := readPaths(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.model.RetryItems(r.URL.Query().Get("folder"), paths); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// readPaths reads a list of paths in JSON from the request body, which may
+// be empty.
+func readPaths(r *http.Request) ([]string, error) {
+	bs, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil || len(bytes.TrimSpace(bs)) == 0 {
+		return nil, err
+	}
+	var paths []string
+	if err := json.Unmarshal(bs, &paths); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+func (s *service) getFolderConflicts(w http.ResponseWriter, r *http.Request) {
+	folder := r.URL.Query().Get("folder")
+	conflicts, err := s.model.Conflicts(folder)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, map[string]interface{}{
+		"folder":    folder,
+		"conflicts": conflicts,
+	})
+}
+
 func (s *service) getSystemBrowse(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	current := qs.Get("current")
@@ -1711,6 +2320,17 @@ func (f jsonFileInfoTrunc) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+type jsonOutOfSyncItem model.OutOfSyncItem
+
+func (i jsonOutOfSyncItem) MarshalJSON() ([]byte, error) {
+	m := fileIntfJSONMap(i.FileInfoTruncated)
+	m["numBlocks"] = nil // explicitly unknown
+	m["error"] = i.Error
+	m["failures"] = i.Failures
+	m["skipped"] = i.Skipped
+	return json.Marshal(m)
+}
+
 func fileIntfJSONMap(f protocol.FileIntf) map[string]interface{} {
 	out := map[string]interface{}{
 		"name":          f.FileName(),
@@ -8,9 +8,14 @@ package api
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"strings"
@@ -25,8 +30,10 @@ import (
 )
 
 var (
-	sessions    = make(map[string]bool)
+	sessions    = make(map[string]string) // session ID => user name
 	sessionsMut = sync.NewMutex()
+
+	errClientCertificateNotAllowed = errors.New("client certificate not allowed")
 )
 
 func emitLoginAttempt(success bool, username string, evLogger events.Logger) {
@@ -38,7 +45,7 @@ func emitLoginAttempt(success bool, username string, evLogger events.Logger) {
 
 func basicAuthAndSessionMiddleware(cookieName string, guiCfg config.GUIConfiguration, ldapCfg config.LDAPConfiguration, next http.Handler, evLogger events.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if guiCfg.IsValidAPIKey(r.Header.Get("X-API-Key")) {
+		if hasClientCertificate(r) || guiCfg.IsValidAPIKey(apiKeyFromRequest(r)) {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -102,7 +109,7 @@ func basicAuthAndSessionMiddleware(cookieName string, guiCfg config.GUIConfigura
 
 		sessionid := rand.String(32)
 		sessionsMut.Lock()
-		sessions[sessionid] = true
+		sessions[sessionid] = username
 		sessionsMut.Unlock()
 		http.SetCookie(w, &http.Cookie{
 			Name:   cookieName,
@@ -115,6 +122,138 @@ func basicAuthAndSessionMiddleware(cookieName string, guiCfg config.GUIConfigura
 	})
 }
 
+// apiKeyFromRequest returns the API key from the X-API-Key header or, for
+// clients that can't set custom headers such as metrics scrapers, from a
+// bearer token.
+func apiKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if hdr := r.Header.Get("Authorization"); strings.HasPrefix(hdr, "Bearer ") {
+		return strings.TrimPrefix(hdr, "Bearer ")
+	}
+	return ""
+}
+
+// hasClientCertificate returns whether the request was made with a client
+// certificate. The listener only asks for them when they are required, and
+// then only accepts the allowed ones.
+func hasClientCertificate(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.PeerCertificates) > 0
+}
+
+// metricsAuthMiddleware requires an API key for the metrics, unless GUI
+// authentication is enabled and has already been taken care of.
+func metricsAuthMiddleware(guiCfg config.GUIConfiguration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if guiCfg.IsAuthEnabled() || hasClientCertificate(r) || guiCfg.IsValidAPIKey(apiKeyFromRequest(r)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+}
+
+// requestActor returns who made the request, for the configuration change
+// events: the name of the API key, or the GUI user when known.
+func requestActor(r *http.Request, guiCfg config.GUIConfiguration) string {
+	if key, ok := guiCfg.APIKeyScope(apiKeyFromRequest(r)); ok {
+		if key.Name == "" {
+			return "apikey"
+		}
+		return "apikey:" + key.Name
+	}
+	if hasClientCertificate(r) {
+		sum := sha256.Sum256(r.TLS.PeerCertificates[0].Raw)
+		return "clientcert:" + hex.EncodeToString(sum[:8])
+	}
+	if user, _, ok := r.BasicAuth(); ok {
+		return "gui:" + user
+	}
+	for _, cookie := range r.Cookies() {
+		if !strings.HasPrefix(cookie.Name, "sessionid-") {
+			continue
+		}
+		sessionsMut.Lock()
+		user, ok := sessions[cookie.Value]
+		sessionsMut.Unlock()
+		if ok && user != "" {
+			return "gui:" + user
+		}
+	}
+	return "gui"
+}
+
+// apiKeyScopeMiddleware rejects requests made with a scoped API key that
+// are outside of what the key permits. Requests without an API key, or with
+// one granting full access, are passed on unchanged.
+func apiKeyScopeMiddleware(guiCfg config.GUIConfiguration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, ok := guiCfg.APIKeyScope(apiKeyFromRequest(r))
+		if !ok || key.Scope == config.APIKeyScopeAdmin || apiKeyPermits(key, r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		l.Debugf("API key %q (%v) denied %s %s", key.Name, key.Scope, r.Method, r.URL.Path)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+}
+
+// The endpoints modifying the folder given by the folder parameter, which
+// folder-admin keys may use for the folders they are set up for.
+var folderScopedEndpoints = map[string]bool{
+	"/rest/db/scan":     true,
+	"/rest/db/override": true,
+	"/rest/db/revert":   true,
+	"/rest/db/ignores":  true,
+}
+
+// Configuration endpoints returning secrets, such as the folder encryption
+// passwords, proxy credentials or a bundle signed with the device key,
+// which scoped API keys may not use at all.
+var secretConfigPrefixes = []string{
+	"/rest/config/folders",
+	"/rest/config/devices",
+	"/rest/config/defaults/",
+	"/rest/config/options",
+	"/rest/config/bundle",
+	"/rest/config/history",
+	"/rest/debug/",
+}
+
+// apiKeyPermits returns whether the request is allowed for the given scoped
+// API key. Read-only keys may only read, folder-admin keys may additionally
+// act on the folders they are set up for, using the endpoints meant for
+// that. They can't change the folder configuration, as that includes the
+// path, versioning and hooks, which allow access to anything the device
+// can reach. Neither can read the configuration as a whole, including earlier
+// versions of it, as it includes the credentials for full access.
+func apiKeyPermits(key config.APIKeyConfiguration, r *http.Request) bool {
+	switch r.URL.Path {
+	case "/rest/config", "/rest/system/config", "/rest/config/gui":
+		return false
+	}
+	for _, prefix := range secretConfigPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return false
+		}
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+
+	if key.Scope != config.APIKeyScopeFolderAdmin {
+		return false
+	}
+	if folderScopedEndpoints[r.URL.Path] || strings.HasPrefix(r.URL.Path, "/rest/folder/") {
+		folder := r.URL.Query().Get("folder")
+		return folder != "" && key.AllowsFolder(folder)
+	}
+	return false
+}
+
 func auth(username string, password string, guiCfg config.GUIConfiguration, ldapCfg config.LDAPConfiguration) bool {
 	if guiCfg.AuthMode == config.AuthModeLDAP {
 		return authLDAP(username, password, ldapCfg)
@@ -135,12 +274,14 @@ func authLDAP(username string, password string, cfg config.LDAPConfiguration) bo
 	if err != nil {
 		hostname = address
 	}
+	tlsCfg, err := ldapTLSConfig(hostname, cfg)
+	if err != nil {
+		l.Warnln("LDAP TLS configuration:", err)
+		return false
+	}
 	var connection *ldap.Conn
 	if cfg.Transport == config.LDAPTransportTLS {
-		connection, err = ldap.DialTLS("tcp", address, &tls.Config{
-			ServerName:         hostname,
-			InsecureSkipVerify: cfg.InsecureSkipVerify,
-		})
+		connection, err = ldap.DialTLS("tcp", address, tlsCfg)
 	} else {
 		connection, err = ldap.Dial("tcp", address)
 	}
@@ -151,7 +292,7 @@ func authLDAP(username string, password string, cfg config.LDAPConfiguration) bo
 	}
 
 	if cfg.Transport == config.LDAPTransportStartTLS {
-		err = connection.StartTLS(&tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify})
+		err = connection.StartTLS(tlsCfg)
 		if err != nil {
 			l.Warnln("LDAP Start TLS:", err)
 			return false
@@ -160,7 +301,9 @@ func authLDAP(username string, password string, cfg config.LDAPConfiguration) bo
 
 	defer connection.Close()
 
-	err = connection.Bind(fmt.Sprintf(cfg.BindDN, username), password)
+	// The username is escaped so that it can't change the meaning of the
+	// bind DN or search filter it is interpolated into.
+	err = connection.Bind(fmt.Sprintf(cfg.BindDN, escapeDNValue(username)), password)
 	if err != nil {
 		l.Warnln("LDAP Bind:", err)
 		return false
@@ -180,7 +323,7 @@ func authLDAP(username string, password string, cfg config.LDAPConfiguration) bo
 	// the user. If this matches precisely one user then we are good to go.
 	// The search filter uses the same %s interpolation as the bind DN.
 
-	searchString := fmt.Sprintf(cfg.SearchFilter, username)
+	searchString := fmt.Sprintf(cfg.SearchFilter, ldap.EscapeFilter(username))
 	const sizeLimit = 2  // we search for up to two users -- we only want to match one, so getting any number >1 is a failure.
 	const timeLimit = 60 // Search for up to a minute...
 	searchReq := ldap.NewSearchRequest(cfg.SearchBaseDN, ldap.ScopeWholeSubtree, ldap.DerefFindingBaseObj, sizeLimit, timeLimit, false, searchString, nil, nil)
@@ -198,6 +341,48 @@ func authLDAP(username string, password string, cfg config.LDAPConfiguration) bo
 	return true
 }
 
+// ldapTLSConfig returns the TLS configuration for connecting to the LDAP
+// server, trusting the configured certificate authorities if any.
+func ldapTLSConfig(hostname string, cfg config.LDAPConfiguration) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		ServerName:         hostname,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CACertificateFile == "" {
+		return tlsCfg, nil
+	}
+	bs, err := ioutil.ReadFile(cfg.CACertificateFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bs) {
+		return nil, errors.New("no certificates found in " + cfg.CACertificateFile)
+	}
+	tlsCfg.RootCAs = pool
+	return tlsCfg, nil
+}
+
+// escapeDNValue escapes the characters that are special in an attribute
+// value of a distinguished name, as described in RFC 4514.
+func escapeDNValue(s string) string {
+	var b strings.Builder
+	for i, c := range s {
+		switch {
+		case strings.ContainsRune(`,+"\<>;=`, c),
+			i == 0 && (c == ' ' || c == '#'),
+			i == len(s)-1 && c == ' ':
+			b.WriteByte('\\')
+			b.WriteRune(c)
+		case c == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
 // Convert an ISO-8859-1 encoded byte string to UTF-8. Works by the
 // principle that ISO-8859-1 bytes are equivalent to unicode code points,
 // that a rune slice is a list of code points, and that stringifying a slice
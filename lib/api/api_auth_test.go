@@ -7,8 +7,13 @@
 package api
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/tlsutil"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -44,3 +49,61 @@ func TestStaticAuthPasswordFail(t *testing.T) {
 		t.Fatalf("should fail auth")
 	}
 }
+
+func TestEscapeDNValue(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"user":              "user",
+		"jöns":              "jöns",
+		"a,ou=admins":       `a\,ou\=admins`,
+		`x+y"z\`:            `x\+y\"z\\`,
+		"<a>;":              `\<a\>\;`,
+		" #lead":            `\ #lead`,
+		"#hash":             `\#hash`,
+		"trail ":            `trail\ `,
+		"in between spaces": "in between spaces",
+	}
+	for in, out := range cases {
+		if res := escapeDNValue(in); res != out {
+			t.Errorf("escapeDNValue(%q) => %q, expected %q", in, res, out)
+		}
+	}
+}
+
+func TestLDAPTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	tlsCfg, err := ldapTLSConfig("ldap.example.com", config.LDAPConfiguration{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsCfg.ServerName != "ldap.example.com" || !tlsCfg.InsecureSkipVerify || tlsCfg.RootCAs != nil {
+		t.Errorf("unexpected TLS config %+v", tlsCfg)
+	}
+
+	dir, err := ioutil.TempDir("", "syncthing-ldap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	if _, err := tlsutil.NewCertificate(caFile, filepath.Join(dir, "key.pem"), "ca.example.com", 1); err != nil {
+		t.Fatal(err)
+	}
+	tlsCfg, err = ldapTLSConfig("ldap.example.com", config.LDAPConfiguration{CACertificateFile: caFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsCfg.RootCAs == nil {
+		t.Error("expected the configured CA to be trusted")
+	}
+
+	if _, err := ldapTLSConfig("ldap.example.com", config.LDAPConfiguration{CACertificateFile: filepath.Join(dir, "key.pem")}); err == nil {
+		t.Error("expected an error for a file without certificates")
+	}
+	if _, err := ldapTLSConfig("ldap.example.com", config.LDAPConfiguration{CACertificateFile: filepath.Join(dir, "missing.pem")}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -57,8 +57,8 @@ func newCsrfManager(unique string, prefix string, apiKeyValidator apiKeyValidato
 }
 
 func (m *csrfManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Allow requests carrying a valid API key
-	if m.apiKeyValidator.IsValidAPIKey(r.Header.Get("X-API-Key")) {
+	// Allow requests carrying a valid API key or client certificate
+	if hasClientCertificate(r) || m.apiKeyValidator.IsValidAPIKey(apiKeyFromRequest(r)) {
 		// Set the access-control-allow-origin header for CORS requests
 		// since a valid API key has been provided
 		w.Header().Add("Access-Control-Allow-Origin", "*")
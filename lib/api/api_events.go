@@ -0,0 +1,169 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package api
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/syncthing/syncthing/lib/events"
+)
+
+// eventFilter selects the events a subscriber is interested in, beyond the
+// event types of the subscription.
+type eventFilter struct {
+	folders map[string]struct{}
+}
+
+func newEventFilter(qs url.Values) eventFilter {
+	var f eventFilter
+	if folders := qs.Get("folder"); folders != "" {
+		f.folders = make(map[string]struct{})
+		for _, folder := range strings.Split(folders, ",") {
+			f.folders[strings.TrimSpace(folder)] = struct{}{}
+		}
+	}
+	return f
+}
+
+// matches returns whether the event passes the filter. With a folder filter,
+// events about other folders are left out, while those that aren't about
+// any folder pass.
+func (f eventFilter) matches(ev events.Event) bool {
+	if ev.Type == events.EventsDropped || f.folders == nil {
+		return true
+	}
+	folder := ev.Folder()
+	if folder == "" {
+		return true
+	}
+	_, ok := f.folders[folder]
+	return ok
+}
+
+// nextEvents waits up to the timeout for events after since, and returns
+// those passing the filter, and the ID of the last event seen, whether
+// filtered out or not. Events that were dropped on the way, because the
+// subscriber fell behind, are replaced by an EventsDropped marker, as are
+// the oldest events beyond the limit.
+func nextEvents(sub events.BufferedSubscription, since int, filter eventFilter, limit int, timeout time.Duration) ([]events.Event, int) {
+	deadline := time.Now().Add(timeout)
+	for {
+		evs := sub.Since(since, nil, time.Until(deadline))
+		if len(evs) == 0 {
+			return []events.Event{}, since
+		}
+		last := evs[len(evs)-1].SubscriptionID
+
+		res := make([]events.Event, 0, len(evs))
+		prev := since
+		for _, ev := range evs {
+			// The first request, without a since, gets what's there (or the
+			// latest up to the limit) without further ado.
+			if since > 0 && ev.SubscriptionID > prev+1 {
+				res = append(res, droppedMarker(ev.SubscriptionID-1, ev.SubscriptionID-prev-1))
+			}
+			prev = ev.SubscriptionID
+			if filter.matches(ev) {
+				res = append(res, ev)
+			}
+		}
+
+		if 0 < limit && limit < len(res) {
+			dropped := 0
+			for _, ev := range res[:len(res)-limit] {
+				dropped += droppedCount(ev)
+			}
+			res = res[len(res)-limit:]
+			if since > 0 {
+				res = append([]events.Event{droppedMarker(res[0].SubscriptionID-1, dropped)}, res...)
+			}
+		}
+
+		if len(res) > 0 || !time.Now().Before(deadline) {
+			return res, last
+		}
+		since = last
+	}
+}
+
+// droppedMarker returns an EventsDropped event standing in for the given
+// number of events, up to and including the given ID.
+func droppedMarker(id, count int) events.Event {
+	return events.Event{
+		SubscriptionID: id,
+		Time:           time.Now(),
+		Type:           events.EventsDropped,
+		Data: map[string]interface{}{
+			"count": count,
+		},
+	}
+}
+
+func droppedCount(ev events.Event) int {
+	if ev.Type != events.EventsDropped {
+		return 1
+	}
+	return ev.Data.(map[string]interface{})["count"].(int)
+}
+
+// getEventStream streams events over a websocket, one JSON encoded event
+// per message, with the same parameters as the long polling event
+// endpoints. The limit caps how many events may queue up while the
+// subscriber is busy; beyond it, the oldest ones are dropped.
+func (s *service) getEventStream(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	sub := s.getEventSub(s.requestEventMask(r))
+	filter := newEventFilter(qs)
+	since, _ := strconv.Atoi(qs.Get("since"))
+	limit := EventSubBufferSize
+	if l, err := strconv.Atoi(qs.Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	srv := websocket.Server{
+		// Requests are authenticated like all others, so there's no need
+		// to check the origin, which non-browser clients don't send.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			// Lift the deadline of the request from the HTTP server.
+			_ = ws.SetDeadline(time.Time{})
+
+			// We don't expect anything from the subscriber, but reading
+			// tells us when it goes away.
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				_, _ = io.Copy(ioutil.Discard, ws)
+				cancel()
+			}()
+
+			for ctx.Err() == nil {
+				if sub.Mask()&(events.FolderSummary|events.FolderCompletion) != 0 {
+					s.fss.OnEventRequest()
+				}
+				var evs []events.Event
+				evs, since = nextEvents(sub, since, filter, limit, defaultEventTimeout)
+				for _, ev := range evs {
+					if err := websocket.JSON.Send(ws, ev); err != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+	srv.ServeHTTP(w, r)
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// fixedEventSub has a fixed set of events, some of which may have been
+// dropped, i.e. have gaps in their IDs.
+type fixedEventSub []events.Event
+
+func (s fixedEventSub) Since(id int, into []events.Event, timeout time.Duration) []events.Event {
+	for _, ev := range s {
+		if ev.SubscriptionID > id {
+			into = append(into, ev)
+		}
+	}
+	return into
+}
+
+func (s fixedEventSub) Mask() events.EventType { return events.AllEvents }
+
+func folderEvent(id int, folder string) events.Event {
+	return events.Event{
+		SubscriptionID: id,
+		Type:           events.StateChanged,
+		Data:           map[string]interface{}{"folder": folder},
+	}
+}
+
+func eventSummary(evs []events.Event) string {
+	var parts []string
+	for _, ev := range evs {
+		switch ev.Type {
+		case events.EventsDropped:
+			parts = append(parts, "dropped:"+strconv.Itoa(ev.SubscriptionID)+":"+strconv.Itoa(droppedCount(ev)))
+		default:
+			parts = append(parts, ev.Folder()+":"+strconv.Itoa(ev.SubscriptionID))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestNextEvents(t *testing.T) {
+	t.Parallel()
+
+	sub := fixedEventSub{
+		folderEvent(1, "a"),
+		folderEvent(2, "b"),
+		// 3 and 4 were dropped
+		folderEvent(5, "a"),
+		folderEvent(6, "b"),
+		{SubscriptionID: 7, Type: events.DeviceConnected, Data: map[string]string{"id": "device"}},
+		folderEvent(8, "a"),
+	}
+	all := url.Values{}
+	onlyA := url.Values{"folder": []string{"a"}}
+
+	cases := []struct {
+		since  int
+		filter url.Values
+		limit  int
+		result string
+	}{
+		// The first request gets what's there.
+		{0, all, 0, "a:1 b:2 a:5 b:6 :7 a:8"},
+		{0, all, 2, ":7 a:8"},
+		// Later ones get to know what they missed.
+		{1, all, 0, "b:2 dropped:4:2 a:5 b:6 :7 a:8"},
+		{5, all, 0, "b:6 :7 a:8"},
+		{1, all, 3, "dropped:5:4 b:6 :7 a:8"},
+		// Filtering doesn't count as dropping.
+		{1, onlyA, 0, "dropped:4:2 a:5 :7 a:8"},
+		{5, onlyA, 0, ":7 a:8"},
+		{5, onlyA, 1, "dropped:7:1 a:8"},
+		{8, all, 0, ""},
+	}
+	for _, tc := range cases {
+		evs, last := nextEvents(sub, tc.since, newEventFilter(tc.filter), tc.limit, 0)
+		if res := eventSummary(evs); res != tc.result {
+			t.Errorf("since %d, filter %v, limit %d: expected %q, got %q", tc.since, tc.filter, tc.limit, tc.result, res)
+		}
+		if last != 8 {
+			t.Errorf("since %d: expected the last ID seen to be 8, got %d", tc.since, last)
+		}
+	}
+
+	// Events that were all filtered out are skipped, not returned empty.
+	evs, last := nextEvents(sub, 6, newEventFilter(url.Values{"folder": []string{"b"}}), 0, 0)
+	if len(evs) != 1 || evs[0].Type != events.DeviceConnected || last != 8 {
+		t.Errorf("Unexpected result %v, %d", eventSummary(evs), last)
+	}
+	evs, last = nextEvents(sub, 7, newEventFilter(url.Values{"folder": []string{"b"}}), 0, 0)
+	if len(evs) != 0 || last != 8 {
+		t.Errorf("Unexpected result %v, %d", eventSummary(evs), last)
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	t.Parallel()
+
+	evLogger := events.NewLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go evLogger.Serve(ctx)
+
+	svc := New(protocol.LocalDeviceID, new(mockedConfig), locations.Default(), "", "syncthing", nil, nil, nil, evLogger, nil, nil, nil, nil, nil, nil, false).(*service)
+	defer os.Remove(token)
+	srv := httptest.NewServer(http.HandlerFunc(svc.getEventStream))
+	defer srv.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/rest/events/stream?events=StateChanged&folder=a"
+	ws, err := websocket.Dial(wsURL, "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	// The subscription is created by the request, but we don't know
+	// exactly when, so keep logging until something arrives.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			evLogger.Log(events.StateChanged, map[string]interface{}{"folder": "b"})
+			evLogger.Log(events.DeviceConnected, map[string]interface{}{"id": "device"})
+			evLogger.Log(events.StateChanged, map[string]interface{}{"folder": "a"})
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+
+	_ = ws.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for i := 0; i < 3; i++ {
+		var ev events.Event
+		if err := websocket.JSON.Receive(ws, &ev); err != nil {
+			t.Fatal(err)
+		}
+		if ev.Type != events.StateChanged || ev.Folder() != "a" {
+			t.Fatalf("Unexpected event %+v", ev)
+		}
+	}
+}
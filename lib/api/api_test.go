@@ -10,6 +10,9 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -43,10 +46,12 @@ var (
 	confDir = filepath.Join("testdata", "config")
 	token   = filepath.Join(confDir, "csrftokens.txt")
 	dev1    protocol.DeviceID
+	dev2    protocol.DeviceID
 )
 
 func init() {
 	dev1, _ = protocol.DeviceIDFromString("AIR6LPZ-7K4PTTV-UXQSMUU-CPQ5YWH-OEDFIIQ-JUG777G-2YQXXR5-YD6AWQR")
+	dev2, _ = protocol.DeviceIDFromString("GYRZZQB-IRNPV4Z-T7TC52W-EQYJ3TT-FDQW6MW-DFLMU42-SSSU6EM-FBK2VAY")
 }
 
 func TestMain(m *testing.M) {
@@ -115,7 +120,7 @@ func TestStopAfterBrokenConfig(t *testing.T) {
 	}
 	w := config.Wrap("/dev/null", cfg, protocol.LocalDeviceID, events.NoopLogger)
 
-	srv := New(protocol.LocalDeviceID, w, "", "syncthing", nil, nil, nil, events.NoopLogger, nil, nil, nil, nil, nil, nil, false).(*service)
+	srv := New(protocol.LocalDeviceID, w, locations.Default(), "", "syncthing", nil, nil, nil, events.NoopLogger, nil, nil, nil, nil, nil, nil, false).(*service)
 	defer os.Remove(token)
 	srv.started = make(chan string)
 
@@ -279,6 +284,16 @@ func TestAPIServiceRequests(t *testing.T) {
 			Type:   "application/json",
 			Prefix: "{",
 		},
+		{
+			URL:    "/rest/folder/outofsync?folder=default",
+			Code:   200,
+			Type:   "application/json",
+			Prefix: "{",
+		},
+		{
+			URL:  "/rest/folder/outofsync?folder=default&device=nonsense",
+			Code: 400,
+		},
 		{
 			URL:    "/rest/db/status?folder=default",
 			Code:   200,
@@ -326,6 +341,11 @@ func TestAPIServiceRequests(t *testing.T) {
 			Prefix:  "{",
 			Timeout: 5 * time.Second,
 		},
+		{
+			// Usage reporting isn't enabled in the test config.
+			URL:  "/rest/svc/report/payload",
+			Code: 404,
+		},
 
 		// /rest/system
 		{
@@ -593,7 +613,7 @@ func startHTTP(cfg config.Wrapper) (string, context.CancelFunc, error) {
 
 	// Instantiate the API service
 	urService := ur.New(cfg, m, connections, false)
-	svc := New(protocol.LocalDeviceID, cfg, assetDir, "syncthing", m, eventSub, diskEventSub, events.NoopLogger, discoverer, connections, urService, &mockedFolderSummaryService{}, errorLog, systemLog, false).(*service)
+	svc := New(protocol.LocalDeviceID, cfg, locations.Default(), assetDir, "syncthing", m, eventSub, diskEventSub, events.NoopLogger, discoverer, connections, urService, &mockedFolderSummaryService{}, errorLog, systemLog, false).(*service)
 	defer os.Remove(token)
 	svc.started = addrChan
 
@@ -1051,6 +1071,131 @@ func TestAccessControlAllowOriginHeader(t *testing.T) {
 	}
 }
 
+func TestScopedAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	cfg := new(mockedConfig)
+	cfg.gui.APIKey = "admin"
+	cfg.gui.ScopedAPIKeys = []config.APIKeyConfiguration{
+		{Name: "monitoring", Key: "monitor", Scope: config.APIKeyScopeReadOnly},
+		{Name: "photos", Key: "photos", Scope: config.APIKeyScopeFolderAdmin, Folders: []string{"photos"}},
+	}
+	baseURL, cancel, err := startHTTP(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+	cli := &http.Client{
+		Timeout: time.Second,
+	}
+
+	cases := []struct {
+		key    string
+		method string
+		path   string
+		code   int
+	}{
+		{"admin", http.MethodGet, "/rest/config", http.StatusOK},
+		{"admin", http.MethodPost, "/rest/db/scan?folder=other", http.StatusOK},
+		{"monitor", http.MethodGet, "/rest/system/status", http.StatusOK},
+		{"monitor", http.MethodGet, "/rest/config", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/gui", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/system/config", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/history", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/history/1", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/history/1/diff", http.StatusForbidden},
+		{"photos", http.MethodGet, "/rest/config/history/1", http.StatusForbidden},
+		// Nor anything else including secrets.
+		{"monitor", http.MethodGet, "/rest/config/folders", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/folders/photos", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/devices", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/devices/" + protocol.LocalDeviceID.String(), http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/defaults/folder", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/options", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/bundle", http.StatusForbidden},
+		{"photos", http.MethodGet, "/rest/config/folders/photos", http.StatusForbidden},
+		{"photos", http.MethodGet, "/rest/config/bundle?folders=photos", http.StatusForbidden},
+		{"monitor", http.MethodGet, "/rest/config/ldap", http.StatusOK},
+		{"monitor", http.MethodPost, "/rest/db/scan?folder=photos", http.StatusForbidden},
+		{"photos", http.MethodGet, "/rest/system/status", http.StatusOK},
+		{"photos", http.MethodGet, "/rest/config", http.StatusForbidden},
+		{"photos", http.MethodPost, "/rest/db/scan?folder=photos", http.StatusOK},
+		{"photos", http.MethodPost, "/rest/db/scan?folder=other", http.StatusForbidden},
+		{"photos", http.MethodDelete, "/rest/config/folders/other", http.StatusForbidden},
+		{"photos", http.MethodPost, "/rest/system/shutdown", http.StatusForbidden},
+		{"photos", http.MethodPut, "/rest/config/options", http.StatusForbidden},
+		{"photos", http.MethodPost, "/rest/folder/pause", http.StatusForbidden},
+		// Only endpoints modifying the folder are allowed with it.
+		{"photos", http.MethodPost, "/rest/system/shutdown?folder=photos", http.StatusForbidden},
+		{"photos", http.MethodPost, "/rest/system/restart?folder=photos", http.StatusForbidden},
+		{"photos", http.MethodPost, "/rest/system/upgrade?folder=photos", http.StatusForbidden},
+		{"photos", http.MethodPost, "/rest/config/history/1/restore?folder=photos", http.StatusForbidden},
+		{"photos", http.MethodPut, "/rest/config/options?folder=photos", http.StatusForbidden},
+		// The folder configuration can't be changed, not even for the
+		// folder the key is for.
+		{"photos", http.MethodPut, "/rest/config/folders/photos", http.StatusForbidden},
+		{"photos", http.MethodPatch, "/rest/config/folders/photos", http.StatusForbidden},
+		{"photos", http.MethodDelete, "/rest/config/folders/photos", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest(tc.method, baseURL+tc.path, nil)
+		req.Header.Set("X-API-Key", tc.key)
+		resp, err := cli.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.code {
+			t.Errorf("%s %s with key %q: expected %d, got %s", tc.method, tc.path, tc.key, tc.code, resp.Status)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	const testAPIKey = "foobarbaz"
+	cfg := new(mockedConfig)
+	cfg.gui.APIKey = testAPIKey
+	cfg.gui.MetricsEnabled = true
+	baseURL, cancel, err := startHTTP(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+	cli := &http.Client{
+		Timeout: 5 * time.Second,
+	}
+
+	resp, err := cli.Get(baseURL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatal("GET on /metrics without API key should fail, not", resp.Status)
+	}
+
+	req, _ := http.NewRequest("GET", baseURL+"/metrics", nil)
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+	resp, err = cli.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal("GET on /metrics with API key should succeed, not", resp.Status)
+	}
+	if !bytes.Contains(bs, []byte("syncthing_build_info{")) {
+		t.Errorf("expected build info in metrics, got:\n%s", bs)
+	}
+}
+
 func TestOptionsRequest(t *testing.T) {
 	t.Parallel()
 
@@ -1093,7 +1238,7 @@ func TestEventMasks(t *testing.T) {
 	cfg := new(mockedConfig)
 	defSub := new(mockedEventSub)
 	diskSub := new(mockedEventSub)
-	svc := New(protocol.LocalDeviceID, cfg, "", "syncthing", nil, defSub, diskSub, events.NoopLogger, nil, nil, nil, nil, nil, nil, false).(*service)
+	svc := New(protocol.LocalDeviceID, cfg, locations.Default(), "", "syncthing", nil, defSub, diskSub, events.NoopLogger, nil, nil, nil, nil, nil, nil, false).(*service)
 	defer os.Remove(token)
 
 	if mask := svc.getEventMask(""); mask != DefaultEventMask {
@@ -1121,6 +1266,79 @@ func TestEventMasks(t *testing.T) {
 	}
 }
 
+func TestConfigAudit(t *testing.T) {
+	t.Parallel()
+
+	evLogger := events.NewLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go evLogger.Serve(ctx)
+
+	svc := New(protocol.LocalDeviceID, new(mockedConfig), locations.Default(), "", "syncthing", nil, nil, nil, evLogger, nil, nil, nil, nil, nil, nil, false).(*service)
+	defer os.Remove(token)
+
+	evLogger.Log(events.ConfigChanged, map[string]interface{}{
+		"actor": "apikey:backup",
+		"changes": []config.Change{
+			{Action: config.ActionFolderShared, Folder: "default", Device: "device"},
+			{Action: config.ActionFolderModified, Folder: "default"},
+		},
+	})
+	if evs := svc.getEventSub(events.ConfigChanged).Since(0, nil, 10*time.Second); len(evs) != 1 {
+		t.Fatal("expected one event, got", len(evs))
+	}
+
+	rec := httptest.NewRecorder()
+	svc.getConfigAudit(rec, httptest.NewRequest(http.MethodGet, "/rest/config/audit", nil))
+	var res struct {
+		Changes []configAuditEntry
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Changes) != 2 {
+		t.Fatalf("expected two changes, got %+v", res.Changes)
+	}
+	if c := res.Changes[0]; c.Actor != "apikey:backup" || c.Action != config.ActionFolderShared || c.Folder != "default" || c.Device != "device" {
+		t.Errorf("unexpected change %+v", c)
+	}
+
+	rec = httptest.NewRecorder()
+	since := time.Now().Add(time.Hour).Format(time.RFC3339)
+	svc.getConfigAudit(rec, httptest.NewRequest(http.MethodGet, "/rest/config/audit?since="+since, nil))
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Changes) != 0 {
+		t.Errorf("expected no changes after %v, got %+v", since, res.Changes)
+	}
+}
+
+func TestBackupRequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	cfg := new(mockedConfig)
+	cfg.gui.APIKey = "admin"
+	cfg.gui.ScopedAPIKeys = []config.APIKeyConfiguration{
+		{Name: "monitoring", Key: "monitor", Scope: config.APIKeyScopeReadOnly},
+		{Name: "photos", Key: "photos", Scope: config.APIKeyScopeFolderAdmin, Folders: []string{"photos"}},
+	}
+	svc := New(protocol.LocalDeviceID, cfg, locations.Default(), "", "syncthing", nil, nil, nil, events.NoopLogger, nil, nil, nil, nil, nil, nil, false).(*service)
+
+	for _, key := range []string{"monitor", "photos"} {
+		req := httptest.NewRequest(http.MethodPost, "/rest/system/backup?folder=photos", strings.NewReader(`{"password": "pass"}`))
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		svc.postSystemBackup(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Backup with key %q: expected %d, got %d", key, http.StatusForbidden, rec.Code)
+		}
+		if rec.Body.Len() > len("Forbidden\n") {
+			t.Errorf("Backup with key %q returned data", key)
+		}
+	}
+}
+
 func TestBrowse(t *testing.T) {
 	t.Parallel()
 
@@ -1197,6 +1415,82 @@ func TestPrefixMatch(t *testing.T) {
 	}
 }
 
+func TestClientCertificateRequired(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "syncthing-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	allowed, err := tlsutil.NewCertificate(filepath.Join(dir, "allowed.crt"), filepath.Join(dir, "allowed.key"), "allowed", 31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := tlsutil.NewCertificate(filepath.Join(dir, "other.crt"), filepath.Join(dir, "other.key"), "other", 31)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := new(mockedConfig)
+	cfg.gui.APIKey = "foobarbaz"
+	cfg.gui.RequireClientCertificate = true
+	sum := sha256.Sum256(allowed.Certificate[0])
+	cfg.gui.ClientCertificates = []string{hex.EncodeToString(sum[:])}
+	baseURL, cancel, err := startHTTP(cfg)
+	if err != nil {
+		t.Fatal("Unexpected error from getting base URL:", err)
+	}
+	defer cancel()
+	httpsURL := strings.Replace(baseURL, "http://", "https://", 1)
+
+	client := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{
+			Timeout: time.Minute,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true,
+					Certificates:       certs,
+				},
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+
+	// The allowed certificate grants access, without an API key or CSRF
+	// token.
+	resp, err := client(allowed).Post(httpsURL+"/rest/system/ping", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected access with the allowed certificate, got status %d", resp.StatusCode)
+	}
+
+	// Other certificates, or none, don't get past the handshake.
+	for _, c := range []*http.Client{client(other), client()} {
+		if resp, err := c.Get(httpsURL + "/rest/system/ping"); err == nil {
+			resp.Body.Close()
+			t.Errorf("Expected the TLS handshake to fail, got status %d", resp.StatusCode)
+		}
+	}
+
+	// Plain HTTP is only redirected, even with the API key.
+	req, _ := http.NewRequest(http.MethodGet, baseURL+"/rest/system/ping", nil)
+	req.Header.Set("X-API-Key", "foobarbaz")
+	resp, err = client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTemporaryRedirect {
+		t.Errorf("Expected a redirect to HTTPS, got status %d", resp.StatusCode)
+	}
+}
+
 func TestShouldRegenerateCertificate(t *testing.T) {
 	dir, err := ioutil.TempDir("", "syncthing-test")
 	if err != nil {
@@ -1341,8 +1635,28 @@ func TestConfigChanges(t *testing.T) {
 		t.Error("Expected folder to be paused")
 	}
 
+	// Pause folder1 through the dedicated endpoint, which persists it
+	mod(http.MethodPost, "/rest/folder/pause?folder=folder1", nil)
+	saved, _, err := config.Load(tmpFile.Name(), protocol.LocalDeviceID, events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f, ok := saved.Folder("folder1"); !ok || !f.Paused {
+		t.Error("Expected folder1 to be paused in the saved config")
+	}
+
+	// Resume all folders
+	mod(http.MethodPost, "/rest/folder/resume", nil)
+	for _, f := range w.FolderList() {
+		if f.Paused {
+			t.Errorf("Expected folder %v to be resumed", f.ID)
+		}
+	}
+	req, _ := http.NewRequest(http.MethodPost, baseURL+"/rest/folder/pause?folder=nonexistent", nil)
+	do(req, http.StatusNotFound)
+
 	// Delete folder2
-	req, _ := http.NewRequest(http.MethodDelete, baseURL+folder2Path, nil)
+	req, _ = http.NewRequest(http.MethodDelete, baseURL+folder2Path, nil)
 	do(req, http.StatusOK)
 
 	// Check folder1 is still there and folder2 gone
@@ -1359,6 +1673,56 @@ func TestConfigChanges(t *testing.T) {
 	if opts.MaxSendKbps != 50 {
 		t.Error("Exepcted 50 for MaxSendKbps, got", opts.MaxSendKbps)
 	}
+
+	// New folders and devices start out from the defaults
+	mod(http.MethodPatch, "/rest/config/defaults/folder", map[string]interface{}{"rescanIntervalS": 1234, "ignorePerms": true})
+	mod(http.MethodPatch, "/rest/config/defaults/device", map[string]interface{}{"compression": "always", "introducer": true})
+	mod(http.MethodPost, "/rest/config/folders", map[string]interface{}{"id": "folder3", "path": "folder3"})
+	dev2Path := "/rest/config/devices/" + dev2.String()
+	mod(http.MethodPut, dev2Path, map[string]interface{}{"deviceID": dev2.String()})
+
+	resp = get("/rest/config/folders/folder3")
+	folder = config.FolderConfiguration{}
+	if err := unmarshalTo(resp.Body, &folder); err != nil {
+		t.Fatal(err)
+	}
+	if folder.RescanIntervalS != 1234 || !folder.IgnorePerms {
+		t.Errorf("Expected folder settings from defaults, got %v and %v", folder.RescanIntervalS, folder.IgnorePerms)
+	}
+	resp = get(dev2Path)
+	dev = config.DeviceConfiguration{}
+	if err := unmarshalTo(resp.Body, &dev); err != nil {
+		t.Fatal(err)
+	}
+	if dev.Compression != protocol.CompressionAlways || !dev.Introducer {
+		t.Errorf("Expected device settings from defaults, got %v and %v", dev.Compression, dev.Introducer)
+	}
+
+	// Sharing a folder with a device group shares it with the members
+	mod(http.MethodPut, "/rest/config/groups/group1", map[string]interface{}{"name": "Group", "devices": []string{dev2.String()}})
+	mod(http.MethodPost, "/rest/config/groups/group1/share?folders=folder1", nil)
+	resp = get("/rest/config/folders/folder1")
+	folder = config.FolderConfiguration{}
+	if err := unmarshalTo(resp.Body, &folder); err != nil {
+		t.Fatal(err)
+	}
+	if !folder.SharedWith(dev2) {
+		t.Error("Expected folder to be shared with the group member")
+	}
+
+	// Deleting the group unshares it again
+	req, _ = http.NewRequest(http.MethodDelete, baseURL+"/rest/config/groups/group1", nil)
+	do(req, http.StatusOK)
+	req, _ = http.NewRequest(http.MethodGet, baseURL+"/rest/config/groups/group1", nil)
+	do(req, http.StatusNotFound)
+	resp = get("/rest/config/folders/folder1")
+	folder = config.FolderConfiguration{}
+	if err := unmarshalTo(resp.Body, &folder); err != nil {
+		t.Fatal(err)
+	}
+	if folder.SharedWith(dev2) || len(folder.DeviceGroups) != 0 {
+		t.Error("Expected folder to be unshared from the group")
+	}
 }
 
 func equalStrings(a, b []string) bool {
@@ -1393,3 +1757,57 @@ func runningInContainer() bool {
 	}
 	return false
 }
+
+func TestUnixSocketListener(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no Unix sockets on Windows")
+	}
+
+	dir, err := ioutil.TempDir("", "syncthing-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	locs, err := locations.NewSet(dir, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := New(protocol.LocalDeviceID, new(mockedConfig), locs, "", "syncthing", nil, nil, nil, events.NoopLogger, nil, nil, nil, nil, nil, nil, false).(*service)
+
+	// The directory is created, and a stale socket replaced.
+	sock := filepath.Join(dir, "run", "api.sock")
+	guiCfg := config.GUIConfiguration{
+		RawAddress:               "unix://" + sock,
+		RawUnixSocketPermissions: "0660",
+	}
+	for i := 0; i < 2; i++ {
+		listener, err := svc.getListener(guiCfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Lstat(sock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode()&os.ModeSocket == 0 || info.Mode().Perm() != 0660 {
+			t.Errorf("Unexpected socket mode %v", info.Mode())
+		}
+		listener.(*tlsutil.DowngradingListener).Listener.(*net.UnixListener).SetUnlinkOnClose(false)
+		listener.Close()
+	}
+
+	// Anything else at the path is left alone.
+	if err := os.Remove(sock); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(sock, []byte("precious"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if listener, err := svc.getListener(guiCfg); err == nil {
+		listener.Close()
+		t.Fatal("Expected an error listening on top of a regular file")
+	}
+	if data, err := ioutil.ReadFile(sock); err != nil || string(data) != "precious" {
+		t.Error("Expected the regular file to remain untouched")
+	}
+}
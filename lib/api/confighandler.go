@@ -7,22 +7,32 @@
 package api
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/julienschmidt/httprouter"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/protocol"
 )
 
 type configMuxBuilder struct {
 	*httprouter.Router
-	id  protocol.DeviceID
-	cfg config.Wrapper
+	id        protocol.DeviceID
+	cfg       config.Wrapper
+	locations *locations.Set
+}
+
+// cfgAs returns the configuration, with modifications attributed to
+// whoever made the request.
+func (c *configMuxBuilder) cfgAs(r *http.Request) config.Wrapper {
+	return c.cfg.As(requestActor(r, c.cfg.GUI()))
 }
 
 func (c *configMuxBuilder) registerConfig(path string) {
@@ -57,12 +67,20 @@ func (c *configMuxBuilder) registerFolders(path string) {
 	})
 
 	c.HandlerFunc(http.MethodPut, path, func(w http.ResponseWriter, r *http.Request) {
-		var folders []config.FolderConfiguration
-		if err := unmarshalTo(r.Body, &folders); err != nil {
+		var data []json.RawMessage
+		if err := unmarshalTo(r.Body, &data); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
+		folders := make([]config.FolderConfiguration, len(data))
+		for i, bs := range data {
+			folders[i] = c.cfg.DefaultFolder()
+			if err := json.Unmarshal(bs, &folders[i]); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
 			cfg.SetFolders(folders)
 		})
 		if err != nil {
@@ -73,7 +91,7 @@ func (c *configMuxBuilder) registerFolders(path string) {
 	})
 
 	c.HandlerFunc(http.MethodPost, path, func(w http.ResponseWriter, r *http.Request) {
-		c.adjustFolder(w, r, config.FolderConfiguration{}, false)
+		c.adjustFolder(w, r, c.cfg.DefaultFolder(), false)
 	})
 }
 
@@ -83,12 +101,20 @@ func (c *configMuxBuilder) registerDevices(path string) {
 	})
 
 	c.HandlerFunc(http.MethodPut, path, func(w http.ResponseWriter, r *http.Request) {
-		var devices []config.DeviceConfiguration
-		if err := unmarshalTo(r.Body, &devices); err != nil {
+		var data []json.RawMessage
+		if err := unmarshalTo(r.Body, &data); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
+		devices := make([]config.DeviceConfiguration, len(data))
+		for i, bs := range data {
+			devices[i] = c.cfg.DefaultDevice()
+			if err := json.Unmarshal(bs, &devices[i]); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
 			cfg.SetDevices(devices)
 		})
 		if err != nil {
@@ -99,19 +125,7 @@ func (c *configMuxBuilder) registerDevices(path string) {
 	})
 
 	c.HandlerFunc(http.MethodPost, path, func(w http.ResponseWriter, r *http.Request) {
-		var device config.DeviceConfiguration
-		if err := unmarshalTo(r.Body, &device); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
-			cfg.SetDevice(device)
-		})
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		c.finish(w, waiter)
+		c.adjustDevice(w, r, c.cfg.DefaultDevice(), false)
 	})
 }
 
@@ -126,7 +140,7 @@ func (c *configMuxBuilder) registerFolder(path string) {
 	})
 
 	c.Handle(http.MethodPut, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
-		c.adjustFolder(w, r, config.FolderConfiguration{}, false)
+		c.adjustFolder(w, r, c.cfg.DefaultFolder(), false)
 	})
 
 	c.Handle(http.MethodPatch, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
@@ -138,8 +152,8 @@ func (c *configMuxBuilder) registerFolder(path string) {
 		c.adjustFolder(w, r, folder, false)
 	})
 
-	c.Handle(http.MethodDelete, path, func(w http.ResponseWriter, _ *http.Request, p httprouter.Params) {
-		waiter, err := c.cfg.RemoveFolder(p.ByName("id"))
+	c.Handle(http.MethodDelete, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		waiter, err := c.cfgAs(r).RemoveFolder(p.ByName("id"))
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -170,7 +184,7 @@ func (c *configMuxBuilder) registerDevice(path string) {
 	})
 
 	c.Handle(http.MethodPut, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
-		c.adjustDevice(w, r, config.DeviceConfiguration{}, false)
+		c.adjustDevice(w, r, c.cfg.DefaultDevice(), false)
 	})
 
 	c.Handle(http.MethodPatch, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
@@ -179,9 +193,9 @@ func (c *configMuxBuilder) registerDevice(path string) {
 		}
 	})
 
-	c.Handle(http.MethodDelete, path, func(w http.ResponseWriter, _ *http.Request, p httprouter.Params) {
+	c.Handle(http.MethodDelete, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 		id, err := protocol.DeviceIDFromString(p.ByName("id"))
-		waiter, err := c.cfg.RemoveDevice(id)
+		waiter, err := c.cfgAs(r).RemoveDevice(id)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -190,6 +204,110 @@ func (c *configMuxBuilder) registerDevice(path string) {
 	})
 }
 
+func (c *configMuxBuilder) registerDeviceGroups(path string) {
+	c.HandlerFunc(http.MethodGet, path, func(w http.ResponseWriter, _ *http.Request) {
+		sendJSON(w, c.cfg.RawCopy().DeviceGroups)
+	})
+
+	c.HandlerFunc(http.MethodPut, path, func(w http.ResponseWriter, r *http.Request) {
+		var groups []config.DeviceGroupConfiguration
+		if err := unmarshalTo(r.Body, &groups); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
+			cfg.DeviceGroups = groups
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		c.finish(w, waiter)
+	})
+
+	c.HandlerFunc(http.MethodPost, path, func(w http.ResponseWriter, r *http.Request) {
+		c.adjustDeviceGroup(w, r, config.DeviceGroupConfiguration{})
+	})
+}
+
+func (c *configMuxBuilder) registerDeviceGroup(path string) {
+	groupFromParams := func(w http.ResponseWriter, p httprouter.Params) (config.DeviceGroupConfiguration, bool) {
+		cfg := c.cfg.RawCopy()
+		group, _, ok := cfg.DeviceGroup(p.ByName("id"))
+		if !ok {
+			http.Error(w, "No device group with given ID", http.StatusNotFound)
+			return config.DeviceGroupConfiguration{}, false
+		}
+		return group, true
+	}
+
+	c.Handle(http.MethodGet, path, func(w http.ResponseWriter, _ *http.Request, p httprouter.Params) {
+		if group, ok := groupFromParams(w, p); ok {
+			sendJSON(w, group)
+		}
+	})
+
+	c.Handle(http.MethodPut, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		c.adjustDeviceGroup(w, r, config.DeviceGroupConfiguration{ID: p.ByName("id")})
+	})
+
+	c.Handle(http.MethodPatch, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if group, ok := groupFromParams(w, p); ok {
+			c.adjustDeviceGroup(w, r, group)
+		}
+	})
+
+	c.Handle(http.MethodDelete, path, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
+			cfg.RemoveDeviceGroup(p.ByName("id"))
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		c.finish(w, waiter)
+	})
+
+	// Share the given folders with, or unshare them from, all members of
+	// the group.
+	shareHandler := func(share bool) httprouter.Handle {
+		return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			group, ok := groupFromParams(w, p)
+			if !ok {
+				return
+			}
+			folders := splitList(r.URL.Query().Get("folders"))
+			cfg := c.cfg.RawCopy()
+			for _, id := range folders {
+				if _, _, ok := cfg.Folder(id); !ok {
+					http.Error(w, "No folder with ID "+id, http.StatusNotFound)
+					return
+				}
+			}
+			waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
+				for _, id := range folders {
+					_, i, ok := cfg.Folder(id)
+					if !ok {
+						continue
+					}
+					folder := &cfg.Folders[i]
+					folder.DeviceGroups = removeString(folder.DeviceGroups, group.ID)
+					if share {
+						folder.DeviceGroups = append(folder.DeviceGroups, group.ID)
+					}
+				}
+			})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			c.finish(w, waiter)
+		}
+	}
+	c.Handle(http.MethodPost, path+"/share", shareHandler(true))    // folders
+	c.Handle(http.MethodPost, path+"/unshare", shareHandler(false)) // folders
+}
+
 func (c *configMuxBuilder) registerDefaultFolder(path string) {
 	c.HandlerFunc(http.MethodGet, path, func(w http.ResponseWriter, _ *http.Request) {
 		sendJSON(w, c.cfg.DefaultFolder())
@@ -260,6 +378,169 @@ func (c *configMuxBuilder) registerGUI(path string) {
 	})
 }
 
+func (c *configMuxBuilder) registerBundle(path string) {
+	// Export the given folders and devices as a signed bundle.
+	c.HandlerFunc(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request) {
+		qs := r.URL.Query()
+		var devices []protocol.DeviceID
+		for _, idStr := range splitList(qs.Get("devices")) {
+			id, err := protocol.DeviceIDFromString(idStr)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			devices = append(devices, id)
+		}
+		bundle, err := c.cfg.RawCopy().ExportBundle(c.id, splitList(qs.Get("folders")), devices)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		cert, err := tls.LoadX509KeyPair(c.locations.Get(locations.CertFile), c.locations.Get(locations.KeyFile))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		signed, err := bundle.Sign(cert)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sendJSON(w, signed)
+	})
+
+	// Verify a bundle and show what importing it would entail, including
+	// whether it was created by a known device, which is required to
+	// import it, and suggested paths for the folders that need one.
+	c.HandlerFunc(http.MethodPost, path+"/verify", func(w http.ResponseWriter, r *http.Request) {
+		var signed config.SignedBundle
+		if err := unmarshalTo(r.Body, &signed); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		bundle, err := signed.Open()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cfg := c.cfg.RawCopy()
+		paths := make(map[string]string)
+		for _, id := range cfg.NewBundleFolders(bundle) {
+			for _, folder := range bundle.Folders {
+				if folder.ID == id {
+					paths[id] = cfg.SuggestedFolderPath(folder)
+				}
+			}
+		}
+		sendJSON(w, map[string]interface{}{
+			"bundle":         bundle,
+			"signerKnown":    cfg.KnowsBundleSigner(bundle),
+			"suggestedPaths": paths,
+		})
+	})
+
+	// Import a bundle, with paths for all folders that don't exist yet.
+	c.HandlerFunc(http.MethodPost, path+"/import", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Bundle config.SignedBundle `json:"bundle"`
+			Paths  map[string]string   `json:"paths"`
+		}
+		if err := unmarshalTo(r.Body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		bundle, err := req.Bundle.Open()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var importErr error
+		waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
+			importErr = cfg.ImportBundle(bundle, req.Paths)
+		})
+		if importErr == config.ErrBundleUnknownSigner {
+			http.Error(w, importErr.Error(), http.StatusForbidden)
+			return
+		} else if importErr != nil {
+			http.Error(w, importErr.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		c.finish(w, waiter)
+	})
+}
+
+func (c *configMuxBuilder) registerHistory(path string) {
+	historicConfig := func(w http.ResponseWriter, id string) (config.Configuration, bool) {
+		cfg, err := c.cfg.HistoricConfig(id)
+		if err == config.ErrNoSuchHistoryEntry {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return config.Configuration{}, false
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return config.Configuration{}, false
+		}
+		return cfg, true
+	}
+
+	c.HandlerFunc(http.MethodGet, path, func(w http.ResponseWriter, _ *http.Request) {
+		entries, err := c.cfg.History()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if entries == nil {
+			entries = make([]config.HistoryEntry, 0)
+		}
+		sendJSON(w, entries)
+	})
+
+	c.Handle(http.MethodGet, path+"/:id", func(w http.ResponseWriter, _ *http.Request, p httprouter.Params) {
+		if cfg, ok := historicConfig(w, p.ByName("id")); ok {
+			sendJSON(w, cfg)
+		}
+	})
+
+	// The changes from the given version to the one in the to parameter,
+	// or to the current configuration.
+	c.Handle(http.MethodGet, path+"/:id/diff", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		from, ok := historicConfig(w, p.ByName("id"))
+		if !ok {
+			return
+		}
+		to := c.cfg.RawCopy()
+		if id := r.URL.Query().Get("to"); id != "" {
+			if to, ok = historicConfig(w, id); !ok {
+				return
+			}
+		}
+		diff, err := config.Diff(from, to)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, diff)
+	})
+
+	c.Handle(http.MethodPost, path+"/:id/restore", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		restored, ok := historicConfig(w, p.ByName("id"))
+		if !ok {
+			return
+		}
+		waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
+			*cfg = restored
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		c.finish(w, waiter)
+	})
+}
+
 func (c *configMuxBuilder) adjustConfig(w http.ResponseWriter, r *http.Request) {
 	to, err := config.ReadJSON(r.Body, c.id)
 	r.Body.Close()
@@ -270,7 +551,7 @@ func (c *configMuxBuilder) adjustConfig(w http.ResponseWriter, r *http.Request)
 	}
 	var errMsg string
 	var status int
-	waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
+	waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
 		if to.GUI.Password, err = checkGUIPassword(cfg.GUI.Password, to.GUI.Password); err != nil {
 			l.Warnln("bcrypting password:", err)
 			errMsg = err.Error()
@@ -293,7 +574,7 @@ func (c *configMuxBuilder) adjustFolder(w http.ResponseWriter, r *http.Request,
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
+	waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
 		if defaults {
 			cfg.Defaults.Folder = folder
 		} else {
@@ -312,7 +593,7 @@ func (c *configMuxBuilder) adjustDevice(w http.ResponseWriter, r *http.Request,
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
+	waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
 		if defaults {
 			cfg.Defaults.Device = device
 		} else {
@@ -326,12 +607,31 @@ func (c *configMuxBuilder) adjustDevice(w http.ResponseWriter, r *http.Request,
 	c.finish(w, waiter)
 }
 
+func (c *configMuxBuilder) adjustDeviceGroup(w http.ResponseWriter, r *http.Request, group config.DeviceGroupConfiguration) {
+	if err := unmarshalTo(r.Body, &group); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if group.ID == "" {
+		http.Error(w, "Device group ID must not be empty", http.StatusBadRequest)
+		return
+	}
+	waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
+		cfg.SetDeviceGroup(group)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.finish(w, waiter)
+}
+
 func (c *configMuxBuilder) adjustOptions(w http.ResponseWriter, r *http.Request, opts config.OptionsConfiguration) {
 	if err := unmarshalTo(r.Body, &opts); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
+	waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
 		cfg.Options = opts
 	})
 	if err != nil {
@@ -350,7 +650,7 @@ func (c *configMuxBuilder) adjustGUI(w http.ResponseWriter, r *http.Request, gui
 	}
 	var errMsg string
 	var status int
-	waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
+	waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
 		if gui.Password, err = checkGUIPassword(oldPassword, gui.Password); err != nil {
 			l.Warnln("bcrypting password:", err)
 			errMsg = err.Error()
@@ -373,7 +673,7 @@ func (c *configMuxBuilder) adjustLDAP(w http.ResponseWriter, r *http.Request, ld
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	waiter, err := c.cfg.Modify(func(cfg *config.Configuration) {
+	waiter, err := c.cfgAs(r).Modify(func(cfg *config.Configuration) {
 		cfg.LDAP = ldap
 	})
 	if err != nil {
@@ -393,6 +693,28 @@ func unmarshalTo(body io.ReadCloser, to interface{}) error {
 	return json.Unmarshal(bs, to)
 }
 
+// splitList returns the non-empty elements of a comma separated list.
+func splitList(s string) []string {
+	var res []string
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// removeString returns the list without any occurrences of s.
+func removeString(list []string, s string) []string {
+	res := list[:0]
+	for _, e := range list {
+		if e != s {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
 func checkGUIPassword(oldPassword, newPassword string) (string, error) {
 	if newPassword == oldPassword {
 		return newPassword, nil
@@ -68,6 +68,14 @@ func (c *mockedConfig) RequiresRestart() bool {
 	return false
 }
 
+func (c *mockedConfig) History() ([]config.HistoryEntry, error) {
+	return nil, nil
+}
+
+func (c *mockedConfig) HistoricConfig(id string) (config.Configuration, error) {
+	return config.Configuration{}, config.ErrNoSuchHistoryEntry
+}
+
 func (c *mockedConfig) AddOrUpdatePendingDevice(device protocol.DeviceID, name, address string) {}
 
 func (c *mockedConfig) AddOrUpdatePendingFolder(id, label string, device protocol.DeviceID) {}
@@ -99,6 +107,10 @@ func (c *mockedConfig) RemoveDevice(id protocol.DeviceID) (config.Waiter, error)
 	return noopWaiter{}, nil
 }
 
+func (c *mockedConfig) As(actor string) config.Wrapper {
+	return c
+}
+
 func (c *mockedConfig) IgnoredDevice(id protocol.DeviceID) bool {
 	return false
 }
@@ -10,6 +10,8 @@ import (
 	"context"
 
 	"github.com/syncthing/syncthing/lib/connections"
+	"github.com/syncthing/syncthing/lib/nat"
+	"github.com/syncthing/syncthing/lib/relay/client"
 )
 
 type mockedConnections struct{}
@@ -22,10 +24,18 @@ func (m *mockedConnections) ConnectionStatus() map[string]connections.Connection
 	return nil
 }
 
+func (m *mockedConnections) RelayProbeResults() map[string][]client.ProbeResult {
+	return nil
+}
+
 func (m *mockedConnections) NATType() string {
 	return ""
 }
 
+func (m *mockedConnections) NATStatus() []nat.DeviceStatus {
+	return nil
+}
+
 func (m *mockedConnections) Serve(ctx context.Context) error { return nil }
 
 func (m *mockedConnections) ExternalAddresses() []string { return nil }
@@ -8,10 +8,13 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/ignore"
 	"github.com/syncthing/syncthing/lib/model"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/stats"
@@ -33,6 +36,14 @@ func (m *mockedModel) Override(folder string) {}
 
 func (m *mockedModel) Revert(folder string) {}
 
+func (m *mockedModel) ConfirmMassChange(folder string) error {
+	return nil
+}
+
+func (m *mockedModel) HeldMassChange(folder string) *model.MassChange {
+	return nil
+}
+
 func (m *mockedModel) NeedFolderFiles(folder string, page, perpage int) ([]db.FileInfoTruncated, []db.FileInfoTruncated, []db.FileInfoTruncated, error) {
 	return nil, nil, nil, nil
 }
@@ -41,6 +52,22 @@ func (*mockedModel) RemoteNeedFolderFiles(folder string, device protocol.DeviceI
 	return nil, nil
 }
 
+func (*mockedModel) OutOfSyncItems(folder string, device protocol.DeviceID, page, perpage int) ([]model.OutOfSyncItem, error) {
+	return nil, nil
+}
+
+func (*mockedModel) SkipItems(folder string, paths []string) error {
+	return nil
+}
+
+func (*mockedModel) RetryItems(folder string, paths []string) error {
+	return nil
+}
+
+func (*mockedModel) VerifyFolder(folder string, repair bool) (model.VerifyReport, error) {
+	return model.VerifyReport{}, nil
+}
+
 func (*mockedModel) LocalChangedFolderFiles(folder string, page, perpage int) ([]db.FileInfoTruncated, error) {
 	return nil, nil
 }
@@ -49,6 +76,22 @@ func (m *mockedModel) FolderProgressBytesCompleted(_ string) int64 {
 	return 0
 }
 
+func (m *mockedModel) CompactDatabase() error {
+	return nil
+}
+
+func (m *mockedModel) ReadDatabase(fn func(backend.Reader) error) error {
+	return nil
+}
+
+func (m *mockedModel) CheckDatabase(_ string) (map[string]db.CheckResult, error) {
+	return nil, nil
+}
+
+func (m *mockedModel) DatabaseSize() (model.DatabaseSize, error) {
+	return model.DatabaseSize{}, nil
+}
+
 func (m *mockedModel) NumConnections() int {
 	return 0
 }
@@ -88,6 +131,10 @@ func (m *mockedModel) CurrentIgnores(folder string) ([]string, []string, error)
 	return nil, nil, nil
 }
 
+func (m *mockedModel) ExplainIgnore(folder, file string) (ignore.Explanation, error) {
+	return ignore.Explanation{}, nil
+}
+
 func (m *mockedModel) SetIgnores(folder string, content []string) error {
 	return nil
 }
@@ -140,10 +187,34 @@ func (m *mockedModel) PendingFolders(device protocol.DeviceID) (map[string]db.Pe
 	return nil, nil
 }
 
+func (m *mockedModel) PushConfig(device protocol.DeviceID, fragment []byte) error {
+	return nil
+}
+
+func (m *mockedModel) RequestUpgrades(ctx context.Context, devices []protocol.DeviceID, version string, checkOnly bool) map[protocol.DeviceID]model.UpgradeResult {
+	return nil
+}
+
+func (m *mockedModel) Benchmark(ctx context.Context, device protocol.DeviceID, duration time.Duration) (model.DeviceBenchmark, error) {
+	return model.DeviceBenchmark{}, nil
+}
+
 func (m *mockedModel) FolderErrors(folder string) ([]model.FileError, error) {
 	return nil, nil
 }
 
+func (m *mockedModel) Conflicts(folder string) ([]model.Conflict, error) {
+	return nil, nil
+}
+
+func (m *mockedModel) ConflictCount(folder string) int {
+	return 0
+}
+
+func (m *mockedModel) Tombstones(folder string) (model.TombstoneReport, error) {
+	return model.TombstoneReport{}, nil
+}
+
 func (m *mockedModel) WatchError(folder string) error {
 	return nil
 }
@@ -158,7 +229,7 @@ func (m *mockedModel) IndexUpdate(deviceID protocol.DeviceID, folder string, fil
 	return nil
 }
 
-func (m *mockedModel) Request(deviceID protocol.DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, weakHash uint32, fromTemporary bool) (protocol.RequestResponse, error) {
+func (m *mockedModel) Request(deviceID protocol.DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, hashAlgo protocol.BlockHashAlgorithm, weakHash uint32, fromTemporary bool) (protocol.RequestResponse, error) {
 	return nil, nil
 }
 
@@ -172,8 +243,24 @@ func (m *mockedModel) DownloadProgress(deviceID protocol.DeviceID, folder string
 	return nil
 }
 
+func (m *mockedModel) ConfigPush(deviceID protocol.DeviceID, push protocol.ConfigPush) error {
+	return nil
+}
+
+func (m *mockedModel) UpgradeRequest(deviceID protocol.DeviceID, req protocol.UpgradeRequest) error {
+	return nil
+}
+
 func (m *mockedModel) AddConnection(conn protocol.Connection, hello protocol.Hello) {}
 
+func (m *mockedModel) AddSecondaryConnection(conn protocol.Connection) {}
+
+func (m *mockedModel) SecondaryClosed(conn protocol.Connection, err error) {}
+
+func (m *mockedModel) DeviceConnections(protocol.DeviceID) []protocol.Connection {
+	return nil
+}
+
 func (m *mockedModel) OnHello(protocol.DeviceID, net.Addr, protocol.Hello) error {
 	return nil
 }
@@ -182,6 +269,10 @@ func (m *mockedModel) GetHello(protocol.DeviceID) protocol.HelloIntf {
 	return nil
 }
 
+func (m *mockedModel) SetNextCertificate(next, cert tls.Certificate) error {
+	return nil
+}
+
 func (m *mockedModel) StartDeadlockDetector(timeout time.Duration) {}
 
 func (m *mockedModel) DBSnapshot(_ string) (*db.Snapshot, error) {
@@ -0,0 +1,228 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/model"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+	"github.com/syncthing/syncthing/lib/upgrade"
+)
+
+// How often the latest release is looked up for the upgrade metric.
+const metricsUpgradeCheckInterval = time.Hour
+
+var (
+	buildInfoDesc = prometheus.NewDesc("syncthing_build_info",
+		"Version of the running Syncthing.", []string{"version", "os", "arch"}, nil)
+	upgradeAvailableDesc = prometheus.NewDesc("syncthing_upgrade_available",
+		"Whether a newer release is available.", []string{"latest"}, nil)
+	databaseSizeDesc = prometheus.NewDesc("syncthing_database_size_bytes",
+		"Size of the database on disk.", nil, nil)
+
+	folderStateDesc = prometheus.NewDesc("syncthing_folder_state",
+		"Current state of the folder.", []string{"folder", "state"}, nil)
+	folderCompletionDesc = prometheus.NewDesc("syncthing_folder_completion_percent",
+		"How much of the global state of the folder is available locally.", []string{"folder"}, nil)
+	folderGlobalBytesDesc = prometheus.NewDesc("syncthing_folder_global_bytes",
+		"Size of the global state of the folder.", []string{"folder"}, nil)
+	folderGlobalItemsDesc = prometheus.NewDesc("syncthing_folder_global_items",
+		"Number of items in the global state of the folder.", []string{"folder"}, nil)
+	folderLocalBytesDesc = prometheus.NewDesc("syncthing_folder_local_bytes",
+		"Size of the local state of the folder.", []string{"folder"}, nil)
+	folderLocalItemsDesc = prometheus.NewDesc("syncthing_folder_local_items",
+		"Number of items in the local state of the folder.", []string{"folder"}, nil)
+	folderNeedBytesDesc = prometheus.NewDesc("syncthing_folder_need_bytes",
+		"Amount of data that needs to be synced to the folder.", []string{"folder"}, nil)
+	folderNeedItemsDesc = prometheus.NewDesc("syncthing_folder_need_items",
+		"Number of items that need to be synced to the folder.", []string{"folder"}, nil)
+	folderLastScanDesc = prometheus.NewDesc("syncthing_folder_last_scan_timestamp_seconds",
+		"When the folder was last scanned successfully.", []string{"folder"}, nil)
+	folderScanDurationDesc = prometheus.NewDesc("syncthing_folder_last_scan_duration_seconds",
+		"How long the last successful scan of the folder took.", []string{"folder"}, nil)
+
+	deviceConnectedDesc = prometheus.NewDesc("syncthing_device_connected",
+		"Whether the device is connected.", []string{"device", "type"}, nil)
+	devicePausedDesc = prometheus.NewDesc("syncthing_device_paused",
+		"Whether the device is paused.", []string{"device"}, nil)
+	deviceReceivedDesc = prometheus.NewDesc("syncthing_device_received_bytes_total",
+		"Data received from the device over the current connection.", []string{"device"}, nil)
+	deviceSentDesc = prometheus.NewDesc("syncthing_device_sent_bytes_total",
+		"Data sent to the device over the current connection.", []string{"device"}, nil)
+	receivedDesc = prometheus.NewDesc("syncthing_received_bytes_total",
+		"Data received from all devices.", nil, nil)
+	sentDesc = prometheus.NewDesc("syncthing_sent_bytes_total",
+		"Data sent to all devices.", nil, nil)
+)
+
+// metricsCollector gathers the metrics from the model at the time they are
+// scraped.
+type metricsCollector struct {
+	cfg       config.Wrapper
+	model     model.Model
+	locations *locations.Set
+	noUpgrade bool
+
+	mut           sync.Mutex
+	latest        string
+	latestChecked time.Time
+}
+
+func newMetricsHandler(cfg config.Wrapper, m model.Model, locs *locations.Set, noUpgrade bool) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		&metricsCollector{
+			cfg:       cfg,
+			model:     m,
+			locations: locs,
+			noUpgrade: noUpgrade,
+			mut:       sync.NewMutex(),
+		},
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(buildInfoDesc, prometheus.GaugeValue, 1, build.Version, runtime.GOOS, runtime.GOARCH)
+	if latest, ok := c.latestRelease(); ok {
+		newer := 0.0
+		if upgrade.CompareVersions(latest, build.Version) > upgrade.Equal {
+			newer = 1
+		}
+		ch <- prometheus.MustNewConstMetric(upgradeAvailableDesc, prometheus.GaugeValue, newer, latest)
+	}
+	if size, err := dirSize(c.locations.Get(locations.Database)); err == nil {
+		ch <- prometheus.MustNewConstMetric(databaseSizeDesc, prometheus.GaugeValue, float64(size))
+	}
+
+	c.collectFolders(ch)
+	c.collectDevices(ch)
+}
+
+func (c *metricsCollector) collectFolders(ch chan<- prometheus.Metric) {
+	folderStats, err := c.model.FolderStatistics()
+	if err != nil {
+		l.Debugln("metrics: folder statistics:", err)
+	}
+
+	for _, folder := range c.cfg.FolderList() {
+		id := folder.ID
+
+		state, _, err := c.model.State(id)
+		if err != nil {
+			state = "error"
+		}
+		ch <- prometheus.MustNewConstMetric(folderStateDesc, prometheus.GaugeValue, 1, id, state)
+
+		comp := c.model.Completion(protocol.LocalDeviceID, id)
+		ch <- prometheus.MustNewConstMetric(folderCompletionDesc, prometheus.GaugeValue, comp.CompletionPct, id)
+		ch <- prometheus.MustNewConstMetric(folderGlobalBytesDesc, prometheus.GaugeValue, float64(comp.GlobalBytes), id)
+		ch <- prometheus.MustNewConstMetric(folderGlobalItemsDesc, prometheus.GaugeValue, float64(comp.GlobalItems), id)
+		ch <- prometheus.MustNewConstMetric(folderNeedBytesDesc, prometheus.GaugeValue, float64(comp.NeedBytes), id)
+		ch <- prometheus.MustNewConstMetric(folderNeedItemsDesc, prometheus.GaugeValue, float64(comp.NeedItems), id)
+
+		if snap, err := c.model.DBSnapshot(id); err == nil {
+			local := snap.LocalSize()
+			snap.Release()
+			ch <- prometheus.MustNewConstMetric(folderLocalBytesDesc, prometheus.GaugeValue, float64(local.Bytes), id)
+			ch <- prometheus.MustNewConstMetric(folderLocalItemsDesc, prometheus.GaugeValue, float64(local.Files+local.Directories+local.Symlinks), id)
+		}
+
+		if stats, ok := folderStats[id]; ok && !stats.LastScan.IsZero() {
+			ch <- prometheus.MustNewConstMetric(folderLastScanDesc, prometheus.GaugeValue, float64(stats.LastScan.Unix()), id)
+			ch <- prometheus.MustNewConstMetric(folderScanDurationDesc, prometheus.GaugeValue, stats.LastScanDurationS, id)
+		}
+	}
+}
+
+func (c *metricsCollector) collectDevices(ch chan<- prometheus.Metric) {
+	for key, val := range c.model.ConnectionStats() {
+		info, ok := val.(model.ConnectionInfo)
+		if !ok {
+			continue
+		}
+		if key == "total" {
+			ch <- prometheus.MustNewConstMetric(receivedDesc, prometheus.CounterValue, float64(info.InBytesTotal))
+			ch <- prometheus.MustNewConstMetric(sentDesc, prometheus.CounterValue, float64(info.OutBytesTotal))
+			continue
+		}
+
+		connected := 0.0
+		if info.Connected {
+			connected = 1
+		}
+		paused := 0.0
+		if info.Paused {
+			paused = 1
+		}
+		ch <- prometheus.MustNewConstMetric(deviceConnectedDesc, prometheus.GaugeValue, connected, key, info.Type)
+		ch <- prometheus.MustNewConstMetric(devicePausedDesc, prometheus.GaugeValue, paused, key)
+		if info.Connected {
+			ch <- prometheus.MustNewConstMetric(deviceReceivedDesc, prometheus.CounterValue, float64(info.InBytesTotal), key)
+			ch <- prometheus.MustNewConstMetric(deviceSentDesc, prometheus.CounterValue, float64(info.OutBytesTotal), key)
+		}
+	}
+}
+
+// latestRelease returns the most recently seen latest release. The lookup
+// happens in the background, so as not to hold up scrapes on the network,
+// and at most once per metricsUpgradeCheckInterval.
+func (c *metricsCollector) latestRelease() (string, bool) {
+	if c.noUpgrade || upgrade.DisabledByCompilation {
+		return "", false
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if time.Since(c.latestChecked) > metricsUpgradeCheckInterval {
+		c.latestChecked = time.Now()
+		opts := c.cfg.Options()
+		go func() {
+			rel, err := upgrade.LatestRelease(opts.ReleasesURL, build.Version, opts.UpgradeToPreReleases)
+			if err != nil {
+				l.Debugln("metrics: checking for upgrades:", err)
+				return
+			}
+			c.mut.Lock()
+			c.latest = rel.Tag
+			c.mut.Unlock()
+		}()
+	}
+	return c.latest, c.latest != ""
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -17,6 +17,14 @@ import (
 func getRedactedConfig(s *service) config.Configuration {
 	rawConf := s.cfg.RawCopy()
 	rawConf.GUI.APIKey = "REDACTED"
+	for i := range rawConf.GUI.ScopedAPIKeys {
+		rawConf.GUI.ScopedAPIKeys[i].Key = "REDACTED"
+	}
+	for i := range rawConf.Webhooks {
+		if rawConf.Webhooks[i].Secret != "" {
+			rawConf.Webhooks[i].Secret = "REDACTED"
+		}
+	}
 	if rawConf.GUI.Password != "" {
 		rawConf.GUI.Password = "REDACTED"
 	}
@@ -0,0 +1,268 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package backup exports the identity of a device, that is its certificate
+// and key, together with its configuration and optionally its database, as
+// a single password encrypted bundle, and imports such a bundle into a
+// fresh installation.
+package backup
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// Within the encrypted stream, a bundle is a sequence of records, each a
+// kind followed by a length prefixed key and value.
+const (
+	recordFile     byte = 1 // location, contents
+	recordDatabase byte = 2 // database key, value
+)
+
+// Guards against allocating huge amounts of memory for a corrupted length.
+const maxFieldSize = 256 << 20
+
+var (
+	ErrNoPassword = errors.New("a password is required")
+	ErrExists     = errors.New("refusing to overwrite an existing installation")
+	errCorrupt    = errors.New("backup is corrupted")
+)
+
+type backupFile struct {
+	loc      locations.LocationEnum
+	mode     os.FileMode
+	optional bool
+}
+
+var backupFiles = []backupFile{
+	{locations.CertFile, 0644, false},
+	{locations.KeyFile, 0600, false},
+	{locations.ConfigFile, 0600, false},
+	{locations.CertNextFile, 0644, true},
+	{locations.KeyNextFile, 0600, true},
+	{locations.HTTPSCertFile, 0644, true},
+	{locations.HTTPSKeyFile, 0600, true},
+}
+
+// Export writes a bundle with the certificates, keys and configuration in
+// the given locations and, unless db is nil, everything in the database,
+// encrypted with the password.
+func Export(w io.Writer, password string, locs *locations.Set, db backend.Reader) error {
+	if password == "" {
+		return ErrNoPassword
+	}
+
+	// Read the files before writing anything, so that a missing one
+	// doesn't result in a partial bundle.
+	files := make([][]byte, len(backupFiles))
+	for i, f := range backupFiles {
+		bs, err := ioutil.ReadFile(locs.Get(f.loc))
+		if f.optional && os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		files[i] = bs
+	}
+
+	sw, err := newSealWriter(w, password)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(sw)
+
+	for i, f := range backupFiles {
+		if files[i] == nil {
+			continue
+		}
+		if err := writeRecord(bw, recordFile, []byte(f.loc), files[i]); err != nil {
+			return err
+		}
+	}
+
+	if db != nil {
+		it, err := db.NewPrefixIterator(nil)
+		if err != nil {
+			return err
+		}
+		defer it.Release()
+		for it.Next() {
+			if err := writeRecord(bw, recordDatabase, it.Key(), it.Value()); err != nil {
+				return err
+			}
+		}
+		if err := it.Error(); err != nil {
+			return err
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return sw.Close()
+}
+
+func writeRecord(w *bufio.Writer, kind byte, key, val []byte) error {
+	w.WriteByte(kind)
+	var size [binary.MaxVarintLen64]byte
+	for _, bs := range [][]byte{key, val} {
+		n := binary.PutUvarint(size[:], uint64(len(bs)))
+		w.Write(size[:n])
+		w.Write(bs)
+	}
+	// Write errors are sticky, so this catches any of them.
+	_, err := w.Write(nil)
+	return err
+}
+
+// Contents describes an imported bundle.
+type Contents struct {
+	DeviceID protocol.DeviceID
+	Database bool
+}
+
+// Import restores a bundle into the given locations, which must not have a
+// device certificate or database yet. The files are only written once the
+// whole bundle was read successfully, and a partially restored database is
+// removed.
+func Import(r io.Reader, password string, locs *locations.Set) (Contents, error) {
+	var res Contents
+
+	dbPath := locs.Get(locations.Database)
+	for _, path := range []string{locs.Get(locations.CertFile), dbPath, backend.BadgerPath(dbPath)} {
+		if _, err := os.Lstat(path); err == nil {
+			return res, fmt.Errorf("%w: %s exists", ErrExists, path)
+		}
+	}
+
+	or, err := newOpenReader(r, password)
+	if err != nil {
+		return res, err
+	}
+
+	var db backend.Backend
+	var tx backend.WriteTransaction
+	defer func() {
+		if tx != nil {
+			tx.Release()
+		}
+		if db != nil {
+			db.Close()
+			if !res.Database {
+				os.RemoveAll(dbPath)
+			}
+		}
+	}()
+
+	files, err := readRecords(bufio.NewReader(or), func(key, val []byte) error {
+		if tx == nil {
+			if db, err = backend.OpenLevelDBAuto(dbPath); err != nil {
+				return err
+			}
+			if tx, err = db.NewWriteTransaction(); err != nil {
+				return err
+			}
+		}
+		return tx.Put(key, val)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	cert, err := tls.X509KeyPair(files[locations.CertFile], files[locations.KeyFile])
+	if err != nil {
+		return res, fmt.Errorf("device certificate: %w", err)
+	}
+	if _, ok := files[locations.ConfigFile]; !ok {
+		return res, fmt.Errorf("%w: no configuration", errCorrupt)
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return res, err
+		}
+		res.Database = true
+	}
+
+	for _, f := range backupFiles {
+		bs, ok := files[f.loc]
+		if !ok {
+			continue
+		}
+		path := locs.Get(f.loc)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return res, err
+		}
+		if err := ioutil.WriteFile(path, bs, f.mode); err != nil {
+			return res, err
+		}
+	}
+
+	res.DeviceID = protocol.NewDeviceID(cert.Certificate[0])
+	return res, nil
+}
+
+// readRecords returns the files in the bundle, passing database entries to
+// the given function.
+func readRecords(r *bufio.Reader, putDB func(key, val []byte) error) (map[locations.LocationEnum][]byte, error) {
+	files := make(map[locations.LocationEnum][]byte)
+	for {
+		kind, err := r.ReadByte()
+		if err == io.EOF {
+			return files, nil
+		} else if err != nil {
+			return nil, err
+		}
+		key, err := readField(r)
+		if err != nil {
+			return nil, err
+		}
+		val, err := readField(r)
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case recordFile:
+			files[locations.LocationEnum(key)] = val
+		case recordDatabase:
+			if err := putDB(key, val); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errCorrupt
+		}
+	}
+}
+
+func readField(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err == io.EOF {
+		return nil, errCorrupt
+	} else if err != nil {
+		return nil, err
+	}
+	if size > maxFieldSize {
+		return nil, errCorrupt
+	}
+	bs := make([]byte, size)
+	if _, err := io.ReadFull(r, bs); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, errCorrupt
+	} else if err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
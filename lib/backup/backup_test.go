@@ -0,0 +1,169 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package backup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/tlsutil"
+)
+
+func tempLocations(t *testing.T) *locations.Set {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "syncthing-backup-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	locs, err := locations.NewSet(dir, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return locs
+}
+
+// exportTestBundle sets up an installation with a database of several
+// chunks and returns a bundle of it, along with the device ID.
+func exportTestBundle(t *testing.T, db backend.Backend) ([]byte, protocol.DeviceID) {
+	t.Helper()
+	locs := tempLocations(t)
+	cert, err := tlsutil.NewCertificate(locs.Get(locations.CertFile), locs.Get(locations.KeyFile), "syncthing", 365)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(locs.Get(locations.ConfigFile), []byte("<configuration/>"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if db != nil {
+		for i := 0; i < 3; i++ {
+			if err := db.Put([]byte(fmt.Sprintf("key%d", i)), []byte(rand.String(chunkSize/2))); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, "pass", locs, db); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), protocol.NewDeviceID(cert.Certificate[0])
+}
+
+func TestExportImport(t *testing.T) {
+	db := backend.OpenMemory()
+	defer db.Close()
+	bundle, id := exportTestBundle(t, db)
+
+	locs := tempLocations(t)
+	res, err := Import(bytes.NewReader(bundle), "pass", locs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.DeviceID != id || !res.Database {
+		t.Errorf("Unexpected result %+v, expected device ID %v and database", res, id)
+	}
+
+	if bs, err := ioutil.ReadFile(locs.Get(locations.ConfigFile)); err != nil || string(bs) != "<configuration/>" {
+		t.Errorf("Config not restored: %q, %v", bs, err)
+	}
+	if _, err := os.Stat(locs.Get(locations.HTTPSCertFile)); !os.IsNotExist(err) {
+		t.Error("Missing optional file was created:", err)
+	}
+
+	restored, err := backend.OpenLevelDBAuto(locs.Get(locations.Database))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+	for i := 0; i < 3; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+		expected, _ := db.Get(key)
+		if got, err := restored.Get(key); err != nil || !bytes.Equal(got, expected) {
+			t.Errorf("Database entry %s not restored: %v", key, err)
+		}
+	}
+
+	// Importing again would overwrite the identity.
+	if _, err := Import(bytes.NewReader(bundle), "pass", locs); !errors.Is(err, ErrExists) {
+		t.Error("Expected error importing into existing installation, got", err)
+	}
+}
+
+func TestImportFailures(t *testing.T) {
+	db := backend.OpenMemory()
+	defer db.Close()
+	bundle, _ := exportTestBundle(t, db)
+
+	cases := []struct {
+		name     string
+		bundle   []byte
+		password string
+		err      error
+	}{
+		{"wrong password", bundle, "wrong", ErrDecrypt},
+		{"truncated", bundle[:len(bundle)-chunkSize/2], "pass", errTruncated},
+		{"last chunk dropped", dropLastChunk(t, bundle), "pass", errTruncated},
+		{"not a backup", []byte("<configuration/>"), "pass", ErrNotBackup},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			locs := tempLocations(t)
+			if _, err := Import(bytes.NewReader(tc.bundle), tc.password, locs); !errors.Is(err, tc.err) {
+				t.Errorf("Expected error %v, got %v", tc.err, err)
+			}
+			for _, loc := range []locations.LocationEnum{locations.CertFile, locations.ConfigFile, locations.Database} {
+				if _, err := os.Stat(locs.Get(loc)); !os.IsNotExist(err) {
+					t.Errorf("%v exists after failed import", loc)
+				}
+			}
+		})
+	}
+}
+
+func TestExportWithoutDatabase(t *testing.T) {
+	bundle, _ := exportTestBundle(t, nil)
+	locs := tempLocations(t)
+	res, err := Import(bytes.NewReader(bundle), "pass", locs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Database {
+		t.Error("Database restored without one in the bundle")
+	}
+	if _, err := os.Stat(locs.Get(locations.Database)); !os.IsNotExist(err) {
+		t.Error("Database created without one in the bundle")
+	}
+}
+
+// dropLastChunk returns the bundle without its last chunk, which is whole
+// by itself.
+func dropLastChunk(t *testing.T, bundle []byte) []byte {
+	t.Helper()
+	pos := len(magic) + saltSize
+	last := pos
+	for pos < len(bundle) {
+		last = pos
+		size := int(binary.BigEndian.Uint32(bundle[pos:]))
+		pos += 4 + chacha20poly1305.NonceSizeX + size
+	}
+	if last == len(magic)+saltSize {
+		t.Fatal("bundle has only one chunk")
+	}
+	return bundle[:last]
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package backup
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// A bundle starts with the magic string and a random salt, followed by
+// chunks of at most chunkSize bytes of plaintext, each sealed with
+// XChaCha20-Poly1305 under the key derived from the password and the salt.
+// A chunk is stored as the length of its ciphertext, its nonce and the
+// ciphertext. The index of the chunk and whether it's the last one are
+// authenticated, so that reordered or missing chunks are detected.
+const (
+	magic     = "syncthing-backup-v1\n"
+	saltSize  = 16
+	chunkSize = 1 << 20
+)
+
+var (
+	ErrNotBackup = errors.New("not a Syncthing backup")
+	ErrDecrypt   = errors.New("cannot decrypt backup: wrong password or corrupted data")
+	errTruncated = errors.New("backup is truncated")
+)
+
+func newAEAD(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, 32768, 8, 1, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20poly1305.NewX(key)
+}
+
+func chunkAD(index uint64, last bool) []byte {
+	ad := make([]byte, 9)
+	binary.BigEndian.PutUint64(ad, index)
+	if last {
+		ad[8] = 1
+	}
+	return ad
+}
+
+// A sealWriter encrypts what is written to it into chunks. Close must be
+// called to write the last one.
+type sealWriter struct {
+	w     io.Writer
+	aead  cipher.AEAD
+	buf   []byte
+	index uint64
+}
+
+func newSealWriter(w io.Writer, password string) (*sealWriter, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append([]byte(magic), salt...)); err != nil {
+		return nil, err
+	}
+	return &sealWriter{
+		w:    w,
+		aead: aead,
+		buf:  make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (s *sealWriter) Write(data []byte) (int, error) {
+	written := 0
+	for len(data) > 0 {
+		// A full chunk is only written once there's more, as the last
+		// one must be marked as such.
+		if len(s.buf) == chunkSize {
+			if err := s.seal(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(s.buf[len(s.buf):chunkSize], data)
+		s.buf = s.buf[:len(s.buf)+n]
+		data = data[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close writes the last chunk, without closing the underlying writer.
+func (s *sealWriter) Close() error {
+	return s.seal(true)
+}
+
+func (s *sealWriter) seal(last bool) error {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	sealed := s.aead.Seal(nil, nonce, s.buf, chunkAD(s.index, last))
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(sealed)))
+	for _, bs := range [][]byte{size[:], nonce, sealed} {
+		if _, err := s.w.Write(bs); err != nil {
+			return err
+		}
+	}
+	s.index++
+	s.buf = s.buf[:0]
+	return nil
+}
+
+// An openReader decrypts the chunks read from the underlying reader. It
+// returns io.EOF only after the last chunk was read.
+type openReader struct {
+	r     io.Reader
+	aead  cipher.AEAD
+	buf   []byte
+	index uint64
+	last  bool
+}
+
+func newOpenReader(r io.Reader, password string) (*openReader, error) {
+	header := make([]byte, len(magic)+saltSize)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[:len(magic)]) != magic {
+		return nil, ErrNotBackup
+	}
+	aead, err := newAEAD(password, header[len(magic):])
+	if err != nil {
+		return nil, err
+	}
+	return &openReader{r: r, aead: aead}, nil
+}
+
+func (o *openReader) Read(p []byte) (int, error) {
+	for len(o.buf) == 0 {
+		if o.last {
+			return 0, io.EOF
+		}
+		if err := o.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, o.buf)
+	o.buf = o.buf[n:]
+	return n, nil
+}
+
+func (o *openReader) open() error {
+	var size [4]byte
+	if _, err := io.ReadFull(o.r, size[:]); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errTruncated
+	} else if err != nil {
+		return err
+	}
+	n := int(binary.BigEndian.Uint32(size[:]))
+	if n > chunkSize+o.aead.Overhead() {
+		return ErrDecrypt
+	}
+	sealed := make([]byte, o.aead.NonceSize()+n)
+	if _, err := io.ReadFull(o.r, sealed); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errTruncated
+	} else if err != nil {
+		return err
+	}
+	nonce, sealed := sealed[:o.aead.NonceSize()], sealed[o.aead.NonceSize():]
+
+	// Only the last chunk is authenticated as such. Not decrypting in
+	// place, as a failed attempt clears the output.
+	plain, err := o.aead.Open(nil, nonce, sealed, chunkAD(o.index, false))
+	if err != nil {
+		plain, err = o.aead.Open(nil, nonce, sealed, chunkAD(o.index, true))
+		if err != nil {
+			return ErrDecrypt
+		}
+		o.last = true
+	}
+	o.buf = plain
+	o.index++
+	return nil
+}
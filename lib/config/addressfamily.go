@@ -0,0 +1,102 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import "net"
+
+func (f AddressFamily) String() string {
+	switch f {
+	case AddressFamilyAny:
+		return "any"
+	case AddressFamilyPreferIPv4:
+		return "preferIPv4"
+	case AddressFamilyPreferIPv6:
+		return "preferIPv6"
+	case AddressFamilyIPv4Only:
+		return "ipv4Only"
+	case AddressFamilyIPv6Only:
+		return "ipv6Only"
+	default:
+		return "unknown"
+	}
+}
+
+func (f AddressFamily) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+func (f *AddressFamily) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "preferIPv4":
+		*f = AddressFamilyPreferIPv4
+	case "preferIPv6":
+		*f = AddressFamilyPreferIPv6
+	case "ipv4Only":
+		*f = AddressFamilyIPv4Only
+	case "ipv6Only":
+		*f = AddressFamilyIPv6Only
+	default:
+		*f = AddressFamilyAny
+	}
+	return nil
+}
+
+// UsesIPv4 returns whether IPv4 addresses may be used.
+func (f AddressFamily) UsesIPv4() bool {
+	return f != AddressFamilyIPv6Only
+}
+
+// UsesIPv6 returns whether IPv6 addresses may be used.
+func (f AddressFamily) UsesIPv6() bool {
+	return f != AddressFamilyIPv4Only
+}
+
+// Allows returns whether the address may be used. A nil address, i.e. a
+// host name yet to be resolved, is always allowed.
+func (f AddressFamily) Allows(ip net.IP) bool {
+	switch {
+	case ip == nil:
+		return true
+	case ip.To4() != nil:
+		return f.UsesIPv4()
+	default:
+		return f.UsesIPv6()
+	}
+}
+
+// Prefers returns whether the address should be tried before addresses of
+// the other family. Without a preference, and for nil addresses, that's
+// always true.
+func (f AddressFamily) Prefers(ip net.IP) bool {
+	switch {
+	case ip == nil:
+		return true
+	case f == AddressFamilyPreferIPv4:
+		return ip.To4() != nil
+	case f == AddressFamilyPreferIPv6:
+		return ip.To4() == nil
+	default:
+		return true
+	}
+}
+
+// Network returns the network restricted to the allowed family, e.g.
+// "tcp4" for "tcp" when only IPv4 is allowed. Networks that are already
+// restricted are returned as is.
+func (f AddressFamily) Network(network string) string {
+	if network == "" || network[len(network)-1] == '4' || network[len(network)-1] == '6' {
+		return network
+	}
+	switch f {
+	case AddressFamilyIPv4Only:
+		return network + "4"
+	case AddressFamilyIPv6Only:
+		return network + "6"
+	default:
+		return network
+	}
+}
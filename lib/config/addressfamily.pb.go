@@ -0,0 +1,84 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/addressfamily.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/syncthing/syncthing/proto/ext"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type AddressFamily int32
+
+const (
+	AddressFamilyAny        AddressFamily = 0
+	AddressFamilyPreferIPv4 AddressFamily = 1
+	AddressFamilyPreferIPv6 AddressFamily = 2
+	AddressFamilyIPv4Only   AddressFamily = 3
+	AddressFamilyIPv6Only   AddressFamily = 4
+)
+
+var AddressFamily_name = map[int32]string{
+	0: "ADDRESS_FAMILY_ANY",
+	1: "ADDRESS_FAMILY_PREFER_IPV4",
+	2: "ADDRESS_FAMILY_PREFER_IPV6",
+	3: "ADDRESS_FAMILY_IPV4_ONLY",
+	4: "ADDRESS_FAMILY_IPV6_ONLY",
+}
+
+var AddressFamily_value = map[string]int32{
+	"ADDRESS_FAMILY_ANY":         0,
+	"ADDRESS_FAMILY_PREFER_IPV4": 1,
+	"ADDRESS_FAMILY_PREFER_IPV6": 2,
+	"ADDRESS_FAMILY_IPV4_ONLY":   3,
+	"ADDRESS_FAMILY_IPV6_ONLY":   4,
+}
+
+func (AddressFamily) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f3dfe5906c5d1dbe, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.AddressFamily", AddressFamily_name, AddressFamily_value)
+}
+
+func init() { proto.RegisterFile("lib/config/addressfamily.proto", fileDescriptor_f3dfe5906c5d1dbe) }
+
+var fileDescriptor_f3dfe5906c5d1dbe = []byte{
+	// 324 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0xd2, 0x31, 0x6b, 0xc2, 0x40,
+	0x14, 0x07, 0xf0, 0x4b, 0x15, 0xa1, 0x81, 0x42, 0x08, 0x2d, 0x2d, 0x37, 0x1c, 0x42, 0x71, 0x68,
+	0x29, 0x06, 0x5a, 0xb9, 0x3d, 0x45, 0x05, 0xa9, 0xd5, 0x10, 0x41, 0x48, 0x97, 0x60, 0x62, 0x12,
+	0x03, 0x31, 0x27, 0x49, 0x2c, 0xcd, 0x57, 0xc8, 0x20, 0xdd, 0x3a, 0x05, 0x3a, 0x74, 0xe8, 0x47,
+	0x71, 0x0c, 0x4e, 0x5d, 0x35, 0x5f, 0xa4, 0x78, 0x19, 0xda, 0x48, 0xe2, 0xf6, 0xee, 0xfe, 0xef,
+	0xfd, 0x78, 0x70, 0xc7, 0x22, 0xc7, 0xd6, 0x04, 0x9d, 0xb8, 0xa6, 0x6d, 0x09, 0x93, 0xe9, 0xd4,
+	0x33, 0x7c, 0xdf, 0x9c, 0xcc, 0x6d, 0x27, 0x6c, 0x2e, 0x3c, 0x12, 0x10, 0xbe, 0x96, 0x65, 0xf0,
+	0xda, 0x33, 0x16, 0xc4, 0x17, 0xe8, 0xa5, 0xb6, 0x34, 0x05, 0x8b, 0x58, 0x84, 0x1e, 0x68, 0x95,
+	0x35, 0xc3, 0x53, 0xe3, 0x2d, 0xc8, 0xca, 0xdb, 0x8f, 0x0a, 0x7b, 0x26, 0x66, 0x5e, 0x97, 0x7a,
+	0xfc, 0x1d, 0xcb, 0x8b, 0xed, 0xb6, 0xdc, 0x19, 0x8d, 0xd4, 0xae, 0xf8, 0xdc, 0xeb, 0x2b, 0xaa,
+	0x38, 0x50, 0x38, 0x00, 0xcf, 0xa3, 0xb8, 0xce, 0xe5, 0x5a, 0x45, 0x37, 0xe4, 0xc7, 0x2c, 0x3c,
+	0xe8, 0x96, 0xe4, 0x4e, 0xb7, 0x23, 0xab, 0x3d, 0x69, 0xdc, 0xe2, 0x18, 0x88, 0xa3, 0xb8, 0x7e,
+	0x99, 0x9b, 0x92, 0x3c, 0xc3, 0x34, 0xbc, 0x9e, 0xf4, 0xda, 0xda, 0xac, 0x1a, 0x65, 0xd1, 0x51,
+	0x17, 0x73, 0x27, 0xc7, 0x5c, 0x5c, 0xee, 0x62, 0x5e, 0x62, 0xaf, 0x0e, 0xdc, 0xfd, 0xa2, 0xea,
+	0x70, 0xd0, 0x57, 0xb8, 0x0a, 0xbc, 0x8f, 0xe2, 0xfa, 0x45, 0x6e, 0x74, 0xbf, 0xcc, 0xd0, 0x75,
+	0xc2, 0xcd, 0xaa, 0x51, 0x1c, 0x14, 0x8b, 0x38, 0x13, 0xab, 0xc5, 0x22, 0x2e, 0x13, 0x69, 0x00,
+	0xab, 0xdf, 0x5f, 0x08, 0x3c, 0x3e, 0xad, 0xb7, 0x08, 0x24, 0x5b, 0x04, 0xd6, 0x3b, 0xc4, 0x24,
+	0x3b, 0xc4, 0xbc, 0xa7, 0x08, 0x7c, 0xa6, 0x88, 0x49, 0x52, 0x04, 0x7e, 0x52, 0x04, 0x5e, 0x6e,
+	0x2c, 0x3b, 0x98, 0x2d, 0xb5, 0xa6, 0x4e, 0xe6, 0x82, 0x1f, 0xba, 0x7a, 0x30, 0xb3, 0x5d, 0xeb,
+	0x5f, 0xf5, 0xf7, 0x65, 0xb4, 0x1a, 0x7d, 0xed, 0x87, 0xdf, 0x01, 0x00, 0x3b, 0xb3, 0x2d, 0xe9,
+	0x47, 0x02, 0x00, 0x00,
+}
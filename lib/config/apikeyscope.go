@@ -0,0 +1,37 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+func (t APIKeyScope) String() string {
+	switch t {
+	case APIKeyScopeReadOnly:
+		return "read-only"
+	case APIKeyScopeFolderAdmin:
+		return "folder-admin"
+	case APIKeyScopeAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+func (t APIKeyScope) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *APIKeyScope) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "folder-admin":
+		*t = APIKeyScopeFolderAdmin
+	case "admin":
+		*t = APIKeyScopeAdmin
+	default:
+		// Unknown scopes get the least privileges.
+		*t = APIKeyScopeReadOnly
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/apikeyscope.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/syncthing/syncthing/proto/ext"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type APIKeyScope int32
+
+const (
+	APIKeyScopeReadOnly    APIKeyScope = 0
+	APIKeyScopeFolderAdmin APIKeyScope = 1
+	APIKeyScopeAdmin       APIKeyScope = 2
+)
+
+var APIKeyScope_name = map[int32]string{
+	0: "API_KEY_SCOPE_READ_ONLY",
+	1: "API_KEY_SCOPE_FOLDER_ADMIN",
+	2: "API_KEY_SCOPE_ADMIN",
+}
+
+var APIKeyScope_value = map[string]int32{
+	"API_KEY_SCOPE_READ_ONLY":    0,
+	"API_KEY_SCOPE_FOLDER_ADMIN": 1,
+	"API_KEY_SCOPE_ADMIN":        2,
+}
+
+func (APIKeyScope) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b8395f302f9cb882, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.APIKeyScope", APIKeyScope_name, APIKeyScope_value)
+}
+
+func init() { proto.RegisterFile("lib/config/apikeyscope.proto", fileDescriptor_b8395f302f9cb882) }
+
+var fileDescriptor_b8395f302f9cb882 = []byte{
+	// 290 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xc9, 0xc9, 0x4c, 0xd2,
+	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x4f, 0x2c, 0xc8, 0xcc, 0x4e, 0xad, 0x2c, 0x4e, 0xce,
+	0x2f, 0x48, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xc8, 0x48, 0x29, 0x17, 0xa5,
+	0x16, 0xe4, 0x17, 0xeb, 0x83, 0x05, 0x93, 0x4a, 0xd3, 0xf4, 0xd3, 0xf3, 0xd3, 0xf3, 0xc1, 0x1c,
+	0x30, 0x0b, 0xa2, 0x58, 0x8a, 0x33, 0xb5, 0xa2, 0x04, 0xc2, 0xd4, 0xfa, 0xc9, 0xc8, 0xc5, 0xed,
+	0x18, 0xe0, 0xe9, 0x9d, 0x5a, 0x19, 0x0c, 0x32, 0x4d, 0xc8, 0x9b, 0x4b, 0xdc, 0x31, 0xc0, 0x33,
+	0xde, 0xdb, 0x35, 0x32, 0x3e, 0xd8, 0xd9, 0x3f, 0xc0, 0x35, 0x3e, 0xc8, 0xd5, 0xd1, 0x25, 0xde,
+	0xdf, 0xcf, 0x27, 0x52, 0x80, 0x41, 0x4a, 0xaf, 0x6b, 0xae, 0x82, 0x30, 0x92, 0xea, 0xa0, 0xd4,
+	0xc4, 0x14, 0xff, 0xbc, 0x9c, 0xca, 0x4b, 0x7d, 0xaa, 0xd8, 0x84, 0x85, 0x42, 0xb8, 0xa4, 0x50,
+	0x0d, 0x73, 0xf3, 0xf7, 0x71, 0x71, 0x0d, 0x8a, 0x77, 0x74, 0xf1, 0xf5, 0xf4, 0x13, 0x60, 0x94,
+	0x32, 0xe9, 0x9a, 0xab, 0x20, 0x86, 0xa4, 0xd1, 0x2d, 0x3f, 0x27, 0x25, 0xb5, 0xc8, 0x31, 0x25,
+	0x37, 0x33, 0xef, 0x52, 0x9f, 0x2a, 0x0e, 0x19, 0x21, 0x47, 0x2e, 0x61, 0x54, 0x53, 0x21, 0xc6,
+	0x31, 0x49, 0x69, 0x74, 0xcd, 0x55, 0x10, 0x40, 0xd2, 0x04, 0x33, 0x08, 0x43, 0x4c, 0x8a, 0x65,
+	0xc5, 0x12, 0x39, 0x06, 0x27, 0xef, 0x13, 0x0f, 0xe5, 0x18, 0x2e, 0x3c, 0x94, 0x63, 0x38, 0xf1,
+	0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x09, 0x8f, 0xe5, 0x18, 0x16, 0x3c, 0x96, 0x63, 0xbc,
+	0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86, 0x28, 0xcd, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24,
+	0xbd, 0xe4, 0xfc, 0x5c, 0xfd, 0xe2, 0xca, 0xbc, 0xe4, 0x92, 0x8c, 0xcc, 0xbc, 0x74, 0x24, 0x16,
+	0x22, 0x4a, 0x92, 0xd8, 0xc0, 0xe1, 0x69, 0x0c, 0x18, 0x00, 0xd2, 0xfd, 0x21, 0x9e, 0xa7, 0x01,
+	0x00, 0x00,
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+const bundleVersion = 1
+
+var (
+	ErrBundleSignature     = errors.New("bundle signature is invalid")
+	ErrBundleUnsupported   = errors.New("unsupported bundle version")
+	ErrBundleUnknownSigner = errors.New("bundle was not created by a known device")
+
+	errNoSuchDevice = errors.New("no such device")
+	errNoSuchFolder = errors.New("no such folder")
+)
+
+// A Bundle is a portable subset of a configuration, containing selected
+// folders and devices. It never contains local paths or secrets, so that it
+// can be handed to another device to be imported there.
+type Bundle struct {
+	Version  int                   `json:"version"`
+	DeviceID protocol.DeviceID     `json:"deviceID"`
+	Created  time.Time             `json:"created"`
+	Folders  []FolderConfiguration `json:"folders"`
+	Devices  []DeviceConfiguration `json:"devices"`
+}
+
+// A SignedBundle is a serialized Bundle together with the certificate of the
+// exporting device and a signature over the payload made with that
+// certificate's key. As anyone can create a certificate, the signature only
+// means something if the exporting device is already known; see
+// KnowsBundleSigner.
+type SignedBundle struct {
+	Payload     []byte `json:"payload"`
+	Certificate []byte `json:"certificate"`
+	Signature   []byte `json:"signature"`
+}
+
+// MissingPathsError is returned when importing a bundle that contains new
+// folders for which no local path was given.
+type MissingPathsError struct {
+	Folders []string
+}
+
+func (e *MissingPathsError) Error() string {
+	return fmt.Sprintf("missing local path for folders: %s", strings.Join(e.Folders, ", "))
+}
+
+// ExportBundle returns a bundle with the given folders and devices. The
+// exporting device is always included, and folders are only shared with
+// devices that are part of the bundle.
+func (cfg Configuration) ExportBundle(myID protocol.DeviceID, folders []string, devices []protocol.DeviceID) (Bundle, error) {
+	b := Bundle{
+		Version:  bundleVersion,
+		DeviceID: myID,
+		Created:  time.Now().Truncate(time.Second),
+	}
+
+	included := make(map[protocol.DeviceID]bool, len(devices)+1)
+	for _, id := range append([]protocol.DeviceID{myID}, devices...) {
+		if included[id] {
+			continue
+		}
+		dev, _, ok := cfg.Device(id)
+		if !ok {
+			return Bundle{}, fmt.Errorf("device %v: %w", id, errNoSuchDevice)
+		}
+		dev.IntroducedBy = protocol.EmptyDeviceID
+		dev.IgnoredFolders = nil
+		dev.DeprecatedPendingFolders = nil
+		// The proxy may hold credentials, and whether one is needed
+		// depends on the network of the exporting device.
+		dev.ProxyURL = ""
+		b.Devices = append(b.Devices, dev)
+		included[id] = true
+	}
+
+	for _, id := range folders {
+		folder, _, ok := cfg.Folder(id)
+		if !ok {
+			return Bundle{}, fmt.Errorf("folder %v: %w", id, errNoSuchFolder)
+		}
+		folder = folder.Copy()
+		folder.Path = ""
+		// The versioning parameters may hold local paths and credentials,
+		// and aren't imported anyway.
+		folder.Versioning = VersioningConfiguration{}
+		folder.LocalEncryptionPassword = ""
+		// Hooks run commands on the exporting device and mustn't travel.
+		folder.Hooks = nil
+		shared := folder.Devices[:0]
+		for _, dev := range folder.Devices {
+			if !included[dev.DeviceID] {
+				continue
+			}
+			dev.IntroducedBy = protocol.EmptyDeviceID
+			dev.EncryptionPassword = ""
+			shared = append(shared, dev)
+		}
+		folder.Devices = shared
+		b.Folders = append(b.Folders, folder)
+	}
+
+	return b, nil
+}
+
+// Sign serializes the bundle and signs it with the given device certificate.
+func (b Bundle) Sign(cert tls.Certificate) (SignedBundle, error) {
+	signer, ok := cert.PrivateKey.(crypto.Signer)
+	if !ok || len(cert.Certificate) == 0 {
+		return SignedBundle{}, errors.New("certificate cannot be used for signing")
+	}
+	payload, err := json.Marshal(b)
+	if err != nil {
+		return SignedBundle{}, err
+	}
+	digest := sha256.Sum256(payload)
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return SignedBundle{}, err
+	}
+	return SignedBundle{
+		Payload:     payload,
+		Certificate: cert.Certificate[0],
+		Signature:   sig,
+	}, nil
+}
+
+// Open verifies the signature of the bundle and that it was created by the
+// device owning the included certificate, and returns the contained bundle.
+// It doesn't verify that the device is one we know.
+func (s SignedBundle) Open() (Bundle, error) {
+	cert, err := x509.ParseCertificate(s.Certificate)
+	if err != nil {
+		return Bundle{}, err
+	}
+	var algo x509.SignatureAlgorithm
+	switch cert.PublicKeyAlgorithm {
+	case x509.ECDSA:
+		algo = x509.ECDSAWithSHA256
+	case x509.RSA:
+		algo = x509.SHA256WithRSA
+	default:
+		return Bundle{}, ErrBundleSignature
+	}
+	if err := cert.CheckSignature(algo, s.Payload, s.Signature); err != nil {
+		return Bundle{}, ErrBundleSignature
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(s.Payload, &b); err != nil {
+		return Bundle{}, err
+	}
+	if b.Version != bundleVersion {
+		return Bundle{}, ErrBundleUnsupported
+	}
+	if b.DeviceID != protocol.NewDeviceID(s.Certificate) {
+		return Bundle{}, ErrBundleSignature
+	}
+	return b, nil
+}
+
+// KnowsBundleSigner returns whether the device that created the bundle, as
+// verified by SignedBundle.Open, is a configured device.
+func (cfg Configuration) KnowsBundleSigner(b Bundle) bool {
+	_, _, ok := cfg.Device(b.DeviceID)
+	return ok
+}
+
+// SuggestedFolderPath returns a path below the default folder path that may
+// be offered to the user when importing the given folder.
+func (cfg Configuration) SuggestedFolderPath(folder FolderConfiguration) string {
+	name := folder.Label
+	if name == "" {
+		name = folder.ID
+	}
+	return filepath.Join(cfg.Defaults.Folder.Path, fs.SanitizePath(name))
+}
+
+// NewBundleFolders returns the IDs of the folders in the bundle that don't
+// exist in the configuration yet, i.e. those that need a local path.
+func (cfg Configuration) NewBundleFolders(b Bundle) []string {
+	var ids []string
+	for _, folder := range b.Folders {
+		if _, _, ok := cfg.Folder(folder.ID); !ok {
+			ids = append(ids, folder.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ImportBundle merges the bundle into the configuration, which must already
+// contain the device that created it. Devices that are not yet known are
+// added based on the device defaults. Existing folders
+// gain the shares from the bundle, while new folders are created based on
+// the folder defaults at the path given in paths, which must have an entry
+// for each new folder. Settings local to the exporting device, such as
+// hooks, are never taken from the bundle.
+func (cfg *Configuration) ImportBundle(b Bundle, paths map[string]string) error {
+	if !cfg.KnowsBundleSigner(b) {
+		return ErrBundleUnknownSigner
+	}
+
+	var missing []string
+	for _, id := range cfg.NewBundleFolders(b) {
+		if paths[id] == "" {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingPathsError{Folders: missing}
+	}
+
+	for _, dev := range b.Devices {
+		if _, _, ok := cfg.Device(dev.DeviceID); ok {
+			continue
+		}
+		newDev := cfg.Defaults.Device.Copy()
+		newDev.DeviceID = dev.DeviceID
+		newDev.Name = dev.Name
+		newDev.Addresses = dev.Addresses
+		newDev.Compression = dev.Compression
+		newDev.CertName = dev.CertName
+		cfg.SetDevice(newDev)
+	}
+
+	for _, folder := range b.Folders {
+		existing, _, ok := cfg.Folder(folder.ID)
+		if !ok {
+			existing = cfg.Defaults.Folder.Copy()
+			existing.ID = folder.ID
+			existing.Label = folder.Label
+			existing.Type = folder.Type
+			existing.Path = paths[folder.ID]
+			existing.Devices = nil
+		}
+		for _, dev := range folder.Devices {
+			if !existing.SharedWith(dev.DeviceID) {
+				existing.Devices = append(existing.Devices, FolderDeviceConfiguration{DeviceID: dev.DeviceID})
+			}
+		}
+		cfg.SetFolder(existing)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"bytes"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// ActorSyncthing is the actor for configuration changes made by Syncthing
+// itself, rather than on behalf of a user or remote device.
+const ActorSyncthing = "syncthing"
+
+// ActorDevice returns the actor for configuration changes made due to a
+// remote device, e.g. an introducer or auto accepted folder.
+func ActorDevice(id protocol.DeviceID) string {
+	return "device:" + id.String()
+}
+
+// The actions of configuration changes.
+const (
+	ActionDeviceAdded      = "deviceAdded"
+	ActionDeviceRemoved    = "deviceRemoved"
+	ActionDeviceModified   = "deviceModified"
+	ActionFolderAdded      = "folderAdded"
+	ActionFolderRemoved    = "folderRemoved"
+	ActionFolderModified   = "folderModified"
+	ActionFolderShared     = "folderShared"
+	ActionFolderUnshared   = "folderUnshared"
+	ActionOptionsModified  = "optionsModified"
+	ActionGUIModified      = "guiModified"
+	ActionLDAPModified     = "ldapModified"
+	ActionDefaultsModified = "defaultsModified"
+	ActionIgnoresModified  = "ignoredDevicesModified"
+	ActionWebhooksModified = "webhooksModified"
+	ActionGroupsModified   = "deviceGroupsModified"
+)
+
+// A Change is a single modification of the configuration, such as a folder
+// being shared with a device.
+type Change struct {
+	Action string `json:"action"`
+	Folder string `json:"folder,omitempty"`
+	Device string `json:"device,omitempty"`
+}
+
+// configurationChanges returns what changed between the two configurations.
+// Sharing with the local device isn't considered a change of its own.
+func configurationChanges(myID protocol.DeviceID, from, to Configuration) []Change {
+	var changes []Change
+
+	fromDevices := from.DeviceMap()
+	toDevices := to.DeviceMap()
+	for _, dev := range to.Devices {
+		old, ok := fromDevices[dev.DeviceID]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Action: ActionDeviceAdded, Device: dev.DeviceID.String()})
+		case !marshalEqual(&old, &dev):
+			changes = append(changes, Change{Action: ActionDeviceModified, Device: dev.DeviceID.String()})
+		}
+	}
+	for _, dev := range from.Devices {
+		if _, ok := toDevices[dev.DeviceID]; !ok {
+			changes = append(changes, Change{Action: ActionDeviceRemoved, Device: dev.DeviceID.String()})
+		}
+	}
+
+	fromFolders := from.FolderMap()
+	toFolders := to.FolderMap()
+	for _, folder := range to.Folders {
+		old, ok := fromFolders[folder.ID]
+		if !ok {
+			changes = append(changes, Change{Action: ActionFolderAdded, Folder: folder.ID})
+		}
+		changes = append(changes, sharingChanges(myID, old, folder)...)
+		if ok && !foldersEqualIgnoringSharing(old, folder) {
+			changes = append(changes, Change{Action: ActionFolderModified, Folder: folder.ID})
+		}
+	}
+	for _, folder := range from.Folders {
+		if _, ok := toFolders[folder.ID]; !ok {
+			changes = append(changes, Change{Action: ActionFolderRemoved, Folder: folder.ID})
+		}
+	}
+
+	if !marshalEqual(&from.Options, &to.Options) {
+		changes = append(changes, Change{Action: ActionOptionsModified})
+	}
+	if !marshalEqual(&from.GUI, &to.GUI) {
+		changes = append(changes, Change{Action: ActionGUIModified})
+	}
+	if !marshalEqual(&from.LDAP, &to.LDAP) {
+		changes = append(changes, Change{Action: ActionLDAPModified})
+	}
+	if !marshalEqual(&from.Defaults, &to.Defaults) {
+		changes = append(changes, Change{Action: ActionDefaultsModified})
+	}
+	if !ignoredDevicesEqual(from.IgnoredDevices, to.IgnoredDevices) {
+		changes = append(changes, Change{Action: ActionIgnoresModified})
+	}
+	if !webhooksEqual(from.Webhooks, to.Webhooks) {
+		changes = append(changes, Change{Action: ActionWebhooksModified})
+	}
+	if !deviceGroupsEqual(from.DeviceGroups, to.DeviceGroups) {
+		changes = append(changes, Change{Action: ActionGroupsModified})
+	}
+
+	return changes
+}
+
+// sharingChanges returns the devices the folder was shared with or unshared
+// from.
+func sharingChanges(myID protocol.DeviceID, from, to FolderConfiguration) []Change {
+	var changes []Change
+	for _, dev := range to.Devices {
+		if dev.DeviceID != myID && !from.SharedWith(dev.DeviceID) {
+			changes = append(changes, Change{Action: ActionFolderShared, Folder: to.ID, Device: dev.DeviceID.String()})
+		}
+	}
+	for _, dev := range from.Devices {
+		if dev.DeviceID != myID && !to.SharedWith(dev.DeviceID) {
+			changes = append(changes, Change{Action: ActionFolderUnshared, Folder: to.ID, Device: dev.DeviceID.String()})
+		}
+	}
+	return changes
+}
+
+func foldersEqualIgnoringSharing(a, b FolderConfiguration) bool {
+	a.Devices, b.Devices = nil, nil
+	return marshalEqual(&a, &b)
+}
+
+func ignoredDevicesEqual(a, b []ObservedDevice) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !marshalEqual(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func webhooksEqual(a, b []WebhookConfiguration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !marshalEqual(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func deviceGroupsEqual(a, b []DeviceGroupConfiguration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !marshalEqual(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// marshalEqual compares the protobuf encodings rather than the structs, as
+// the latter differ between nil and empty slices.
+func marshalEqual(a, b interface{ Marshal() ([]byte, error) }) bool {
+	ab, err := a.Marshal()
+	if err != nil {
+		return false
+	}
+	bb, err := b.Marshal()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ab, bb)
+}
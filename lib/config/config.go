@@ -204,6 +204,16 @@ func (cfg Configuration) Copy() Configuration {
 	newCfg.IgnoredDevices = make([]ObservedDevice, len(cfg.IgnoredDevices))
 	copy(newCfg.IgnoredDevices, cfg.IgnoredDevices)
 
+	newCfg.Webhooks = make([]WebhookConfiguration, len(cfg.Webhooks))
+	for i := range cfg.Webhooks {
+		newCfg.Webhooks[i] = cfg.Webhooks[i].Copy()
+	}
+
+	newCfg.DeviceGroups = make([]DeviceGroupConfiguration, len(cfg.DeviceGroups))
+	for i := range cfg.DeviceGroups {
+		newCfg.DeviceGroups[i] = cfg.DeviceGroups[i].Copy()
+	}
+
 	return newCfg
 }
 
@@ -236,6 +246,8 @@ func (cfg *Configuration) prepare(myID protocol.DeviceID) error {
 
 	cfg.Defaults.prepare(myID, existingDevices)
 
+	cfg.prepareWebhooks()
+
 	cfg.removeDeprecatedProtocols()
 
 	util.FillNilExceptDeprecated(cfg)
@@ -263,7 +275,9 @@ func (cfg *Configuration) ensureMyDevice(myID protocol.DeviceID) {
 func (cfg *Configuration) prepareFoldersAndDevices(myID protocol.DeviceID) (map[protocol.DeviceID]bool, error) {
 	existingDevices := cfg.prepareDeviceList()
 
-	sharedFolders, err := cfg.prepareFolders(myID, existingDevices)
+	groups := cfg.prepareDeviceGroups(existingDevices)
+
+	sharedFolders, err := cfg.prepareFolders(myID, existingDevices, groups)
 	if err != nil {
 		return nil, err
 	}
@@ -292,7 +306,7 @@ func (cfg *Configuration) prepareDeviceList() map[protocol.DeviceID]bool {
 	return existingDevices
 }
 
-func (cfg *Configuration) prepareFolders(myID protocol.DeviceID, existingDevices map[protocol.DeviceID]bool) (map[protocol.DeviceID][]string, error) {
+func (cfg *Configuration) prepareFolders(myID protocol.DeviceID, existingDevices map[protocol.DeviceID]bool, groups map[string]DeviceGroupConfiguration) (map[protocol.DeviceID][]string, error) {
 	// Prepare folders and check for duplicates. Duplicates are bad and
 	// dangerous, can't currently be resolved in the GUI, and shouldn't
 	// happen when configured by the GUI. We return with an error in that
@@ -314,6 +328,7 @@ func (cfg *Configuration) prepareFolders(myID protocol.DeviceID, existingDevices
 			return nil, fmt.Errorf("folder %q: %w", folder.ID, errFolderIDDuplicate)
 		}
 
+		folder.applyDeviceGroups(groups)
 		folder.prepare(myID, existingDevices)
 
 		existingFolders[folder.ID] = folder
@@ -411,6 +426,63 @@ func (cfg *Configuration) SetDevices(devices []DeviceConfiguration) {
 	cfg.Devices = append(cfg.Devices, filtered...)
 }
 
+// ReplaceDeviceID changes every reference to the device from into one to
+// the device to, keeping its settings and folder shares, as when the device
+// rotated its certificate. If to is already configured as well, the
+// entries of from are dropped instead.
+func (cfg *Configuration) ReplaceDeviceID(from, to protocol.DeviceID) {
+	_, _, toExists := cfg.Device(to)
+	replace := func(id *protocol.DeviceID) {
+		if *id == from {
+			*id = to
+		}
+	}
+
+	devices := cfg.Devices[:0]
+	for _, device := range cfg.Devices {
+		if device.DeviceID == from {
+			if toExists {
+				continue
+			}
+			device.NextDeviceID = protocol.EmptyDeviceID
+		}
+		replace(&device.DeviceID)
+		replace(&device.IntroducedBy)
+		devices = append(devices, device)
+	}
+	cfg.Devices = devices
+
+	for i := range cfg.Folders {
+		folder := &cfg.Folders[i]
+		_, toShared := folder.Device(to)
+		fdevices := folder.Devices[:0]
+		for _, device := range folder.Devices {
+			if device.DeviceID == from && toShared {
+				continue
+			}
+			replace(&device.DeviceID)
+			replace(&device.IntroducedBy)
+			fdevices = append(fdevices, device)
+		}
+		folder.Devices = fdevices
+		replace(&folder.ConflictPreferredDevice)
+	}
+
+	for i := range cfg.DeviceGroups {
+		group := &cfg.DeviceGroups[i]
+		gdevices := group.Devices[:0]
+		seen := make(map[protocol.DeviceID]bool, len(group.Devices))
+		for _, id := range group.Devices {
+			replace(&id)
+			if !seen[id] {
+				seen[id] = true
+				gdevices = append(gdevices, id)
+			}
+		}
+		group.Devices = gdevices
+	}
+}
+
 func (cfg *Configuration) Folder(id string) (FolderConfiguration, int, bool) {
 	for i, folder := range cfg.Folders {
 		if folder.ID == id {
@@ -24,15 +24,17 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Configuration struct {
-	Version                  int                   `protobuf:"varint,1,opt,name=version,proto3,casttype=int" json:"version" xml:"version,attr"`
-	Folders                  []FolderConfiguration `protobuf:"bytes,2,rep,name=folders,proto3" json:"folders" xml:"folder"`
-	Devices                  []DeviceConfiguration `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices" xml:"device"`
-	GUI                      GUIConfiguration      `protobuf:"bytes,4,opt,name=gui,proto3" json:"gui" xml:"gui"`
-	LDAP                     LDAPConfiguration     `protobuf:"bytes,5,opt,name=ldap,proto3" json:"ldap" xml:"ldap"`
-	Options                  OptionsConfiguration  `protobuf:"bytes,6,opt,name=options,proto3" json:"options" xml:"options"`
-	IgnoredDevices           []ObservedDevice      `protobuf:"bytes,7,rep,name=ignored_devices,json=ignoredDevices,proto3" json:"remoteIgnoredDevices" xml:"remoteIgnoredDevice"`
-	DeprecatedPendingDevices []ObservedDevice      `protobuf:"bytes,8,rep,name=pending_devices,json=pendingDevices,proto3" json:"-" xml:"pendingDevice,omitempty"` // Deprecated: Do not use.
-	Defaults                 Defaults              `protobuf:"bytes,9,opt,name=defaults,proto3" json:"defaults" xml:"defaults"`
+	Version                  int                        `protobuf:"varint,1,opt,name=version,proto3,casttype=int" json:"version" xml:"version,attr"`
+	Folders                  []FolderConfiguration      `protobuf:"bytes,2,rep,name=folders,proto3" json:"folders" xml:"folder"`
+	Devices                  []DeviceConfiguration      `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices" xml:"device"`
+	GUI                      GUIConfiguration           `protobuf:"bytes,4,opt,name=gui,proto3" json:"gui" xml:"gui"`
+	LDAP                     LDAPConfiguration          `protobuf:"bytes,5,opt,name=ldap,proto3" json:"ldap" xml:"ldap"`
+	Options                  OptionsConfiguration       `protobuf:"bytes,6,opt,name=options,proto3" json:"options" xml:"options"`
+	IgnoredDevices           []ObservedDevice           `protobuf:"bytes,7,rep,name=ignored_devices,json=ignoredDevices,proto3" json:"remoteIgnoredDevices" xml:"remoteIgnoredDevice"`
+	DeprecatedPendingDevices []ObservedDevice           `protobuf:"bytes,8,rep,name=pending_devices,json=pendingDevices,proto3" json:"-" xml:"pendingDevice,omitempty"` // Deprecated: Do not use.
+	Defaults                 Defaults                   `protobuf:"bytes,9,opt,name=defaults,proto3" json:"defaults" xml:"defaults"`
+	Webhooks                 []WebhookConfiguration     `protobuf:"bytes,10,rep,name=webhooks,proto3" json:"webhooks" xml:"webhook"`
+	DeviceGroups             []DeviceGroupConfiguration `protobuf:"bytes,11,rep,name=device_groups,json=deviceGroups,proto3" json:"deviceGroups" xml:"deviceGroup"`
 }
 
 func (m *Configuration) Reset()         { *m = Configuration{} }
@@ -114,48 +116,54 @@ func init() {
 func init() { proto.RegisterFile("lib/config/config.proto", fileDescriptor_baadf209193dc627) }
 
 var fileDescriptor_baadf209193dc627 = []byte{
-	// 654 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xc7, 0xed, 0xa6, 0x4d, 0xda, 0xed, 0x17, 0x32, 0x08, 0x5c, 0x3e, 0xbc, 0x61, 0x15, 0x50,
-	0x41, 0xa5, 0x95, 0xca, 0x05, 0x71, 0x23, 0x44, 0x94, 0x0a, 0x24, 0x2a, 0xa3, 0x22, 0xe0, 0x82,
-	0x92, 0x78, 0xeb, 0xae, 0x94, 0xd8, 0x96, 0xbd, 0xae, 0xda, 0x47, 0xe0, 0x86, 0x78, 0x02, 0x4e,
-	0x48, 0xdc, 0x79, 0x88, 0xdc, 0x92, 0x23, 0xa7, 0x95, 0x9a, 0xdc, 0x7c, 0xf4, 0x91, 0x13, 0xda,
-	0x0f, 0xbb, 0xb6, 0x6a, 0xe0, 0x64, 0xcf, 0xfc, 0xff, 0xf3, 0x9b, 0xd5, 0x78, 0xc7, 0xe0, 0xc6,
-	0x80, 0xf4, 0x76, 0xfa, 0xbe, 0x77, 0x44, 0x5c, 0xf5, 0xd8, 0x0e, 0x42, 0x9f, 0xfa, 0x46, 0x5d,
-	0x46, 0x37, 0x5b, 0x05, 0xc3, 0x91, 0x3f, 0x70, 0x70, 0x28, 0x83, 0x38, 0xec, 0x52, 0xe2, 0x7b,
-	0xd2, 0x5d, 0x72, 0x39, 0xf8, 0x84, 0xf4, 0x71, 0x95, 0xeb, 0x6e, 0xc1, 0xe5, 0xc6, 0xa4, 0xca,
-	0x82, 0x0a, 0x96, 0x81, 0xd3, 0x0d, 0xaa, 0x3c, 0xf7, 0x0a, 0x1e, 0x3f, 0xe0, 0x42, 0x54, 0x65,
-	0xdb, 0x28, 0xda, 0x7a, 0x11, 0x0e, 0x4f, 0xb0, 0xa3, 0xa4, 0x25, 0x7c, 0x4a, 0xe5, 0x2b, 0xfa,
-	0xde, 0x00, 0xab, 0xcf, 0x8b, 0xd5, 0x86, 0x0d, 0x1a, 0x27, 0x38, 0x8c, 0x88, 0xef, 0x99, 0x7a,
-	0x53, 0xdf, 0x5c, 0x68, 0x3f, 0x49, 0x18, 0xcc, 0x52, 0x29, 0x83, 0xc6, 0xe9, 0x70, 0xf0, 0x14,
-	0xa9, 0x78, 0xab, 0x4b, 0x69, 0x88, 0x7e, 0x33, 0x58, 0x23, 0x1e, 0x4d, 0xc6, 0xad, 0x95, 0x62,
-	0xde, 0xce, 0xaa, 0x8c, 0x77, 0xa0, 0x21, 0x87, 0x17, 0x99, 0x73, 0xcd, 0xda, 0xe6, 0xf2, 0xee,
-	0xad, 0x6d, 0x35, 0xed, 0x17, 0x22, 0x5d, 0x3a, 0x41, 0x1b, 0x8e, 0x18, 0xd4, 0x78, 0x53, 0x55,
-	0x93, 0x32, 0xb8, 0x22, 0x9a, 0xca, 0x18, 0xd9, 0x99, 0xc0, 0xb9, 0x72, 0xdc, 0x91, 0x59, 0x2b,
-	0x73, 0x3b, 0x22, 0xfd, 0x17, 0xae, 0xaa, 0xc9, 0xb9, 0x32, 0x46, 0x76, 0x26, 0x18, 0x36, 0xa8,
-	0xb9, 0x31, 0x31, 0xe7, 0x9b, 0xfa, 0xe6, 0xf2, 0xae, 0x99, 0x31, 0xf7, 0x0e, 0xf7, 0xcb, 0xc0,
-	0xfb, 0x1c, 0x38, 0x65, 0xb0, 0xb6, 0x77, 0xb8, 0x9f, 0x30, 0xc8, 0x6b, 0x52, 0x06, 0x97, 0x04,
-	0xd3, 0x8d, 0x09, 0xfa, 0x3a, 0x69, 0x71, 0xc9, 0xe6, 0x82, 0xf1, 0x01, 0xcc, 0xf3, 0x2f, 0x6a,
-	0x2e, 0x08, 0xe8, 0x46, 0x06, 0x7d, 0xdd, 0x79, 0x76, 0x50, 0xa6, 0x3e, 0x54, 0xd4, 0x79, 0x2e,
-	0x25, 0x0c, 0x8a, 0xb2, 0x94, 0x41, 0x20, 0xb8, 0x3c, 0xe0, 0x60, 0xa1, 0xda, 0x42, 0x33, 0xde,
-	0x83, 0x86, 0xba, 0x08, 0x66, 0x5d, 0xd0, 0x6f, 0x67, 0xf4, 0x37, 0x32, 0x5d, 0x6e, 0xd0, 0xcc,
-	0xe6, 0xa0, 0x8a, 0x52, 0x06, 0x57, 0x05, 0x5b, 0xc5, 0xc8, 0xce, 0x14, 0xe3, 0x87, 0x0e, 0xd6,
-	0x89, 0xeb, 0xf9, 0x21, 0x76, 0x3e, 0x65, 0x93, 0x6e, 0x88, 0x49, 0x5f, 0xcf, 0x5b, 0xa8, 0xbb,
-	0x25, 0x27, 0xde, 0x3e, 0x56, 0xf0, 0x6b, 0x21, 0x1e, 0xfa, 0x14, 0xef, 0xcb, 0xe2, 0x4e, 0x3e,
-	0xf1, 0x0d, 0xd1, 0xa9, 0x42, 0x44, 0xc9, 0xb8, 0x75, 0xb5, 0x22, 0x9f, 0x8e, 0x5b, 0x95, 0x2c,
-	0x7b, 0x8d, 0x94, 0x62, 0xe3, 0xb3, 0x0e, 0xd6, 0x03, 0xec, 0x39, 0xc4, 0x73, 0xf3, 0xb3, 0x2e,
-	0xfe, 0xf3, 0xac, 0x2f, 0xd5, 0xa4, 0xcd, 0x0e, 0x0e, 0x42, 0xdc, 0xef, 0x52, 0xec, 0x1c, 0x48,
-	0x80, 0x62, 0x26, 0x0c, 0xea, 0x8f, 0x52, 0x06, 0xef, 0x88, 0x43, 0x07, 0x45, 0x6d, 0xcb, 0x1f,
-	0x12, 0x8a, 0x87, 0x01, 0x3d, 0x43, 0xa6, 0x6e, 0xaf, 0x95, 0xb4, 0xc8, 0x38, 0x00, 0x8b, 0x0e,
-	0x3e, 0xea, 0xc6, 0x03, 0x1a, 0x99, 0x4b, 0xe2, 0x93, 0x5c, 0xb9, 0xb8, 0x99, 0x32, 0xdf, 0x46,
-	0x6a, 0x52, 0xb9, 0x33, 0x65, 0x70, 0x4d, 0xdd, 0x47, 0x99, 0x40, 0x76, 0xae, 0xa1, 0x9f, 0x3a,
-	0x58, 0xcc, 0x4a, 0x8d, 0xb7, 0xa0, 0x2e, 0x57, 0x40, 0xac, 0xe8, 0x7f, 0xd6, 0xc9, 0x52, 0x7d,
-	0x54, 0xc9, 0xa5, 0x6d, 0x52, 0x79, 0x0e, 0x95, 0x63, 0x33, 0xe7, 0xca, 0xd0, 0xaa, 0x5d, 0xca,
-	0xa1, 0xb2, 0xe4, 0xd2, 0x2a, 0xa9, 0x7c, 0xfb, 0xd5, 0xe8, 0xdc, 0xd2, 0x26, 0xe7, 0x96, 0x36,
-	0x9a, 0x5a, 0xfa, 0x64, 0x6a, 0xe9, 0x5f, 0x66, 0x96, 0xf6, 0x6d, 0x66, 0xe9, 0x93, 0x99, 0xa5,
-	0xfd, 0x9a, 0x59, 0xda, 0xc7, 0x07, 0x2e, 0xa1, 0xc7, 0x71, 0x6f, 0xbb, 0xef, 0x0f, 0x77, 0xa2,
-	0x33, 0xaf, 0x4f, 0x8f, 0x89, 0xe7, 0x16, 0xde, 0x2e, 0x7e, 0x63, 0xbd, 0xba, 0xf8, 0x67, 0x3d,
-	0xfe, 0x13, 0x00, 0x00, 0xff, 0xff, 0x50, 0xe9, 0xd5, 0x50, 0xb6, 0x05, 0x00, 0x00,
+	// 743 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0x39, 0x6f, 0x13, 0x41,
+	0x14, 0xc7, 0xbd, 0x71, 0xe2, 0x63, 0x9c, 0x03, 0x16, 0x04, 0x1b, 0x8e, 0x1d, 0xb3, 0x32, 0x28,
+	0x41, 0x21, 0x91, 0x42, 0x13, 0xd1, 0x61, 0x2c, 0x42, 0x04, 0x12, 0xd1, 0xa2, 0x70, 0x35, 0x91,
+	0xed, 0x1d, 0xaf, 0x47, 0xd8, 0x3b, 0xd6, 0x1e, 0x21, 0xa9, 0xa8, 0xa9, 0x40, 0x7c, 0x02, 0x5a,
+	0x7a, 0x3e, 0x44, 0xba, 0xb8, 0xa4, 0x1a, 0x29, 0x71, 0xe7, 0x72, 0x4b, 0x2a, 0x34, 0xc7, 0x6e,
+	0x76, 0x94, 0x05, 0x2a, 0xef, 0x7b, 0xff, 0xff, 0xfb, 0xbd, 0xf1, 0x9b, 0x03, 0x5c, 0x1f, 0xe0,
+	0xce, 0x46, 0x97, 0x78, 0x3d, 0xec, 0xca, 0x9f, 0xf5, 0x91, 0x4f, 0x42, 0xa2, 0x97, 0x44, 0x74,
+	0xa3, 0x91, 0x31, 0xf4, 0xc8, 0xc0, 0x41, 0xbe, 0x08, 0x22, 0xbf, 0x1d, 0x62, 0xe2, 0x09, 0xb7,
+	0xe2, 0x72, 0xd0, 0x01, 0xee, 0xa2, 0x3c, 0xd7, 0x9d, 0x8c, 0xcb, 0x8d, 0x70, 0x9e, 0xc5, 0xca,
+	0x58, 0x06, 0x4e, 0x7b, 0x94, 0xe7, 0xb9, 0x9b, 0xf1, 0x90, 0x11, 0x13, 0x82, 0x3c, 0xdb, 0x72,
+	0xd6, 0xd6, 0x09, 0x90, 0x7f, 0x80, 0x9c, 0x1c, 0xc2, 0x47, 0xd4, 0xe9, 0x13, 0xf2, 0x21, 0x8f,
+	0xb0, 0x7a, 0xe1, 0x5f, 0xb9, 0x3e, 0x89, 0x72, 0xd7, 0x54, 0x45, 0x87, 0xa1, 0xf8, 0xb4, 0xbe,
+	0x54, 0xc1, 0xc2, 0x93, 0xac, 0x45, 0xb7, 0x41, 0xf9, 0x00, 0xf9, 0x01, 0x26, 0x9e, 0xa1, 0xd5,
+	0xb5, 0x95, 0xb9, 0xe6, 0xd6, 0x94, 0xc2, 0x24, 0x15, 0x53, 0xa8, 0x1f, 0x0e, 0x07, 0x8f, 0x2c,
+	0x19, 0xaf, 0xb5, 0xc3, 0xd0, 0xb7, 0x7e, 0x53, 0x58, 0xc4, 0x5e, 0x38, 0x3d, 0x69, 0xcc, 0x67,
+	0xf3, 0x76, 0x52, 0xa5, 0xbf, 0x06, 0x65, 0xb1, 0x1d, 0x81, 0x31, 0x53, 0x2f, 0xae, 0xd4, 0x36,
+	0x6f, 0xae, 0xcb, 0xfd, 0x7b, 0xca, 0xd3, 0xca, 0x0a, 0x9a, 0xf0, 0x98, 0xc2, 0x02, 0x6b, 0x2a,
+	0x6b, 0x62, 0x0a, 0xe7, 0x79, 0x53, 0x11, 0x5b, 0x76, 0x22, 0x30, 0xae, 0xf8, 0xab, 0x81, 0x51,
+	0x54, 0xb9, 0x2d, 0x9e, 0xfe, 0x0b, 0x57, 0xd6, 0xa4, 0x5c, 0x11, 0x5b, 0x76, 0x22, 0xe8, 0x36,
+	0x28, 0xba, 0x11, 0x36, 0x66, 0xeb, 0xda, 0x4a, 0x6d, 0xd3, 0x48, 0x98, 0xdb, 0x7b, 0x3b, 0x2a,
+	0xf0, 0x1e, 0x03, 0x9e, 0x51, 0x58, 0xdc, 0xde, 0xdb, 0x99, 0x52, 0xc8, 0x6a, 0x62, 0x0a, 0xab,
+	0x9c, 0xe9, 0x46, 0xd8, 0xfa, 0x36, 0x6e, 0x30, 0xc9, 0x66, 0x82, 0xfe, 0x0e, 0xcc, 0xb2, 0x33,
+	0x62, 0xcc, 0x71, 0xe8, 0x72, 0x02, 0x7d, 0xd1, 0x7a, 0xbc, 0xab, 0x52, 0xef, 0x4b, 0xea, 0x2c,
+	0x93, 0xa6, 0x14, 0xf2, 0xb2, 0x98, 0x42, 0xc0, 0xb9, 0x2c, 0x60, 0x60, 0xae, 0xda, 0x5c, 0xd3,
+	0xdf, 0x82, 0xb2, 0x3c, 0x5a, 0x46, 0x89, 0xd3, 0x6f, 0x25, 0xf4, 0x97, 0x22, 0xad, 0x36, 0xa8,
+	0x27, 0x73, 0x90, 0x45, 0x31, 0x85, 0x0b, 0x9c, 0x2d, 0x63, 0xcb, 0x4e, 0x14, 0xfd, 0x87, 0x06,
+	0x96, 0xb0, 0xeb, 0x11, 0x1f, 0x39, 0xfb, 0xc9, 0xa4, 0xcb, 0x7c, 0xd2, 0xd7, 0xd2, 0x16, 0xf2,
+	0xb4, 0x8a, 0x89, 0x37, 0xfb, 0x12, 0x7e, 0xd5, 0x47, 0x43, 0x12, 0xa2, 0x1d, 0x51, 0xdc, 0x4a,
+	0x27, 0xbe, 0xcc, 0x3b, 0xe5, 0x88, 0xd6, 0xf4, 0xa4, 0x71, 0x25, 0x27, 0x1f, 0x9f, 0x34, 0x72,
+	0x59, 0xf6, 0x22, 0x56, 0x62, 0xfd, 0xb3, 0x06, 0x96, 0x46, 0xc8, 0x73, 0xb0, 0xe7, 0xa6, 0x6b,
+	0xad, 0xfc, 0x73, 0xad, 0xcf, 0xe4, 0xa4, 0x8d, 0x16, 0x1a, 0xf9, 0xa8, 0xdb, 0x0e, 0x91, 0xb3,
+	0x2b, 0x00, 0x92, 0x39, 0xa5, 0x50, 0x7b, 0x10, 0x53, 0x78, 0x9b, 0x2f, 0x7a, 0x94, 0xd5, 0xd6,
+	0xc8, 0x10, 0x87, 0x68, 0x38, 0x0a, 0x8f, 0x2c, 0x43, 0xb3, 0x17, 0x15, 0x2d, 0xd0, 0x77, 0x41,
+	0xc5, 0x41, 0xbd, 0x76, 0x34, 0x08, 0x03, 0xa3, 0xca, 0xb7, 0xe4, 0xd2, 0xf9, 0xc9, 0x14, 0xf9,
+	0xa6, 0x25, 0x27, 0x95, 0x3a, 0x63, 0x0a, 0x17, 0xe5, 0x79, 0x14, 0x09, 0xcb, 0x4e, 0x35, 0xbd,
+	0x07, 0x2a, 0xf2, 0xf2, 0x07, 0x06, 0xa8, 0x17, 0xb3, 0x9b, 0xfc, 0x46, 0xe4, 0xd5, 0x4d, 0x5e,
+	0x4b, 0xe8, 0x49, 0x55, 0xba, 0xcb, 0x32, 0xc1, 0xe6, 0x5d, 0x96, 0xdf, 0x76, 0xea, 0xd2, 0x3f,
+	0x81, 0x05, 0x31, 0xbc, 0x7d, 0xfe, 0x7c, 0x04, 0x46, 0x8d, 0x37, 0xab, 0xab, 0x17, 0x6b, 0x9b,
+	0x69, 0x6a, 0xc3, 0x2d, 0xd9, 0x70, 0xde, 0x39, 0x77, 0xb0, 0xa6, 0x97, 0x33, 0x57, 0x8c, 0x27,
+	0x59, 0xe3, 0x5a, 0x26, 0xb6, 0x95, 0x0a, 0xeb, 0xa7, 0x06, 0x2a, 0xc9, 0x8c, 0xf4, 0x57, 0xa0,
+	0x24, 0xee, 0x3a, 0x7f, 0x8b, 0xfe, 0xf3, 0x6e, 0x98, 0x72, 0x05, 0xb2, 0xe4, 0xc2, 0xb3, 0x21,
+	0xf3, 0x0c, 0x2a, 0x3a, 0x1a, 0x33, 0x2a, 0x34, 0xef, 0xd1, 0x48, 0xa1, 0xa2, 0xe4, 0xc2, 0x9b,
+	0x21, 0xf3, 0xcd, 0xe7, 0xc7, 0xa7, 0x66, 0x61, 0x7c, 0x6a, 0x16, 0x8e, 0xcf, 0x4c, 0x6d, 0x7c,
+	0x66, 0x6a, 0x5f, 0x27, 0x66, 0xe1, 0xfb, 0xc4, 0xd4, 0xc6, 0x13, 0xb3, 0xf0, 0x6b, 0x62, 0x16,
+	0xde, 0xaf, 0xba, 0x38, 0xec, 0x47, 0x9d, 0xf5, 0x2e, 0x19, 0x6e, 0x04, 0x47, 0x5e, 0x37, 0xec,
+	0x63, 0xcf, 0xcd, 0x7c, 0x9d, 0xbf, 0xdf, 0x9d, 0x12, 0x7f, 0x9c, 0x1f, 0xfe, 0x19, 0x00, 0xcb,
+	0x1c, 0x38, 0x1e, 0xf1, 0x06, 0x00, 0x00,
 }
 
 func (m *Configuration) Marshal() (dAtA []byte, err error) {
@@ -178,6 +186,34 @@ func (m *Configuration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeviceGroups) > 0 {
+		for iNdEx := len(m.DeviceGroups) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeviceGroups[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.Webhooks) > 0 {
+		for iNdEx := len(m.Webhooks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Webhooks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
 	{
 		size, err := m.Defaults.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -377,6 +413,18 @@ func (m *Configuration) ProtoSize() (n int) {
 	}
 	l = m.Defaults.ProtoSize()
 	n += 1 + l + sovConfig(uint64(l))
+	if len(m.Webhooks) > 0 {
+		for _, e := range m.Webhooks {
+			l = e.ProtoSize()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	if len(m.DeviceGroups) > 0 {
+		for _, e := range m.DeviceGroups {
+			l = e.ProtoSize()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -715,6 +763,74 @@ func (m *Configuration) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Webhooks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Webhooks = append(m.Webhooks, WebhookConfiguration{})
+			if err := m.Webhooks[len(m.Webhooks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceGroups", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceGroups = append(m.DeviceGroups, DeviceGroupConfiguration{})
+			if err := m.DeviceGroups[len(m.DeviceGroups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
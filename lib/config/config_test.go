@@ -9,6 +9,8 @@ package config
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -19,6 +21,7 @@ import (
 	"reflect"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -87,6 +90,14 @@ func TestDefaultValues(t *testing.T) {
 			RawStunServers:          []string{"default"},
 			AnnounceLANAddresses:    true,
 			FeatureFlags:            []string{},
+			RelayPreferences:        []string{},
+			ConfigHistory:           10,
+			DNSDiscoveryZones:       []string{},
+			URExcludedCategories:    []string{},
+			DisabledListenAddresses: []string{},
+			LocalAnnMDNSEnabled:     true,
+
+			CertificateRotationOverlapDays: 14,
 		},
 		Defaults: Defaults{
 			Folder: FolderConfiguration{
@@ -108,15 +119,24 @@ func TestDefaultValues(t *testing.T) {
 				WeakHashThresholdPct: 25,
 				MarkerName:           ".stfolder",
 				MaxConcurrentWrites:  2,
+				Schedule:             []string{},
+				DeviceGroups:         []string{},
+				Hooks:                []FolderHookConfiguration{},
+				SymlinkRewrites:      []SymlinkRewrite{},
+				PullOrderPatterns:    []string{},
+				RescanJitterS:        300,
 			},
 			Device: DeviceConfiguration{
-				Addresses:       []string{"dynamic"},
-				AllowedNetworks: []string{},
-				Compression:     protocol.CompressionMetadata,
-				IgnoredFolders:  []ObservedFolder{},
+				Addresses:         []string{"dynamic"},
+				AllowedNetworks:   []string{},
+				TransportPriority: []string{},
+				Compression:       protocol.CompressionMetadata,
+				IgnoredFolders:    []ObservedFolder{},
 			},
 		},
 		IgnoredDevices: []ObservedDevice{},
+		Webhooks:       []WebhookConfiguration{},
+		DeviceGroups:   []DeviceGroupConfiguration{},
 	}
 	expected.Devices = []DeviceConfiguration{expected.Defaults.Device.Copy()}
 	expected.Devices[0].DeviceID = device1
@@ -176,25 +196,32 @@ func TestDeviceConfig(t *testing.T) {
 				MarkerName:           DefaultMarkerName,
 				JunctionsAsDirs:      true,
 				MaxConcurrentWrites:  maxConcurrentWritesDefault,
+				Schedule:             []string{},
+				DeviceGroups:         []string{},
+				Hooks:                []FolderHookConfiguration{},
+				SymlinkRewrites:      []SymlinkRewrite{},
+				PullOrderPatterns:    []string{},
 			},
 		}
 
 		expectedDevices := []DeviceConfiguration{
 			{
-				DeviceID:        device1,
-				Name:            "node one",
-				Addresses:       []string{"tcp://a"},
-				Compression:     protocol.CompressionMetadata,
-				AllowedNetworks: []string{},
-				IgnoredFolders:  []ObservedFolder{},
+				DeviceID:          device1,
+				Name:              "node one",
+				Addresses:         []string{"tcp://a"},
+				Compression:       protocol.CompressionMetadata,
+				AllowedNetworks:   []string{},
+				TransportPriority: []string{},
+				IgnoredFolders:    []ObservedFolder{},
 			},
 			{
-				DeviceID:        device4,
-				Name:            "node two",
-				Addresses:       []string{"tcp://b"},
-				Compression:     protocol.CompressionMetadata,
-				AllowedNetworks: []string{},
-				IgnoredFolders:  []ObservedFolder{},
+				DeviceID:          device4,
+				Name:              "node two",
+				Addresses:         []string{"tcp://b"},
+				Compression:       protocol.CompressionMetadata,
+				AllowedNetworks:   []string{},
+				TransportPriority: []string{},
+				IgnoredFolders:    []ObservedFolder{},
 			},
 		}
 		expectedDeviceIDs := []protocol.DeviceID{device1, device4}
@@ -228,6 +255,17 @@ func TestNoListenAddresses(t *testing.T) {
 	}
 }
 
+func TestDisabledListenAddresses(t *testing.T) {
+	opts := OptionsConfiguration{
+		RawListenAddresses:      []string{"default", "tcp://192.0.2.1:0"},
+		DisabledListenAddresses: []string{"quic://0.0.0.0:22000", "tcp://192.0.2.1:0"},
+	}
+	expected := []string{"tcp://0.0.0.0:22000", "dynamic+https://relays.syncthing.net/endpoint"}
+	if diff, equal := messagediff.PrettyDiff(expected, opts.ListenAddresses()); !equal {
+		t.Errorf("Unexpected ListenAddresses. Diff:\n%s", diff)
+	}
+}
+
 func TestOverriddenValues(t *testing.T) {
 	expected := OptionsConfiguration{
 		RawListenAddresses:      []string{"tcp://:23000"},
@@ -270,6 +308,14 @@ func TestOverriddenValues(t *testing.T) {
 		StunKeepaliveMinS:       900,
 		RawStunServers:          []string{"foo"},
 		FeatureFlags:            []string{"feature"},
+		RelayPreferences:        []string{},
+		ConfigHistory:           5,
+		DNSDiscoveryZones:       []string{},
+		URExcludedCategories:    []string{},
+		DisabledListenAddresses: []string{},
+		LocalAnnMDNSEnabled:     false,
+
+		CertificateRotationOverlapDays: 30,
 	}
 	expectedPath := "/media/syncthing"
 
@@ -293,30 +339,34 @@ func TestDeviceAddressesDynamic(t *testing.T) {
 	name, _ := os.Hostname()
 	expected := map[protocol.DeviceID]DeviceConfiguration{
 		device1: {
-			DeviceID:        device1,
-			Addresses:       []string{"dynamic"},
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device1,
+			Addresses:         []string{"dynamic"},
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device2: {
-			DeviceID:        device2,
-			Addresses:       []string{"dynamic"},
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device2,
+			Addresses:         []string{"dynamic"},
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device3: {
-			DeviceID:        device3,
-			Addresses:       []string{"dynamic"},
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device3,
+			Addresses:         []string{"dynamic"},
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device4: {
-			DeviceID:        device4,
-			Name:            name, // Set when auto created
-			Addresses:       []string{"dynamic"},
-			Compression:     protocol.CompressionMetadata,
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device4,
+			Name:              name, // Set when auto created
+			Addresses:         []string{"dynamic"},
+			Compression:       protocol.CompressionMetadata,
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 	}
 
@@ -336,33 +386,37 @@ func TestDeviceCompression(t *testing.T) {
 	name, _ := os.Hostname()
 	expected := map[protocol.DeviceID]DeviceConfiguration{
 		device1: {
-			DeviceID:        device1,
-			Addresses:       []string{"dynamic"},
-			Compression:     protocol.CompressionMetadata,
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device1,
+			Addresses:         []string{"dynamic"},
+			Compression:       protocol.CompressionMetadata,
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device2: {
-			DeviceID:        device2,
-			Addresses:       []string{"dynamic"},
-			Compression:     protocol.CompressionMetadata,
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device2,
+			Addresses:         []string{"dynamic"},
+			Compression:       protocol.CompressionMetadata,
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device3: {
-			DeviceID:        device3,
-			Addresses:       []string{"dynamic"},
-			Compression:     protocol.CompressionNever,
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device3,
+			Addresses:         []string{"dynamic"},
+			Compression:       protocol.CompressionNever,
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device4: {
-			DeviceID:        device4,
-			Name:            name, // Set when auto created
-			Addresses:       []string{"dynamic"},
-			Compression:     protocol.CompressionMetadata,
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device4,
+			Name:              name, // Set when auto created
+			Addresses:         []string{"dynamic"},
+			Compression:       protocol.CompressionMetadata,
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 	}
 
@@ -382,30 +436,34 @@ func TestDeviceAddressesStatic(t *testing.T) {
 	name, _ := os.Hostname()
 	expected := map[protocol.DeviceID]DeviceConfiguration{
 		device1: {
-			DeviceID:        device1,
-			Addresses:       []string{"tcp://192.0.2.1", "tcp://192.0.2.2"},
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device1,
+			Addresses:         []string{"tcp://192.0.2.1", "tcp://192.0.2.2"},
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device2: {
-			DeviceID:        device2,
-			Addresses:       []string{"tcp://192.0.2.3:6070", "tcp://[2001:db8::42]:4242"},
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device2,
+			Addresses:         []string{"tcp://192.0.2.3:6070", "tcp://[2001:db8::42]:4242"},
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device3: {
-			DeviceID:        device3,
-			Addresses:       []string{"tcp://[2001:db8::44]:4444", "tcp://192.0.2.4:6090"},
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device3,
+			Addresses:         []string{"tcp://[2001:db8::44]:4444", "tcp://192.0.2.4:6090"},
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 		device4: {
-			DeviceID:        device4,
-			Name:            name, // Set when auto created
-			Addresses:       []string{"dynamic"},
-			Compression:     protocol.CompressionMetadata,
-			AllowedNetworks: []string{},
-			IgnoredFolders:  []ObservedFolder{},
+			DeviceID:          device4,
+			Name:              name, // Set when auto created
+			Addresses:         []string{"dynamic"},
+			Compression:       protocol.CompressionMetadata,
+			AllowedNetworks:   []string{},
+			TransportPriority: []string{},
+			IgnoredFolders:    []ObservedFolder{},
 		},
 	}
 
@@ -563,6 +621,7 @@ func TestNewSaveLoad(t *testing.T) {
 	path := "testdata/temp.xml"
 	os.Remove(path)
 	defer os.Remove(path)
+	defer os.RemoveAll(filepath.Join("testdata", historyDirName))
 
 	exists := func(path string) bool {
 		_, err := os.Stat(path)
@@ -727,6 +786,9 @@ func TestGUIConfigURL(t *testing.T) {
 		{"127.0.0.2:8080", "http://127.0.0.2:8080/"},
 		{"[::]:8080", "http://[::1]:8080/"},
 		{"[2001::42]:8080", "http://[2001::42]:8080/"},
+		{"/run/syncthing/api.sock", "unix:///run/syncthing/api.sock"},
+		{"unix:///run/syncthing/api.sock", "unix:///run/syncthing/api.sock"},
+		{"unixs:///run/syncthing/api.sock", "unix:///run/syncthing/api.sock"},
 	}
 
 	for _, tc := range testcases {
@@ -740,6 +802,35 @@ func TestGUIConfigURL(t *testing.T) {
 	}
 }
 
+func TestGUIConfigUnixAddress(t *testing.T) {
+	testcases := []struct {
+		raw     string
+		network string
+		address string
+		tls     bool
+	}{
+		{"127.0.0.1:8384", "tcp", "127.0.0.1:8384", false},
+		{"/run/syncthing/api.sock", "unix", "/run/syncthing/api.sock", false},
+		{"unix:///run/syncthing/api.sock", "unix", "/run/syncthing/api.sock", false},
+		{"unixs:///run/syncthing/api.sock", "unix", "/run/syncthing/api.sock", true},
+	}
+
+	for _, tc := range testcases {
+		c := GUIConfiguration{
+			RawAddress: tc.raw,
+		}
+		if network := c.Network(); network != tc.network {
+			t.Errorf("Incorrect network %s != %s for addr %s", network, tc.network, tc.raw)
+		}
+		if address := c.Address(); address != tc.address {
+			t.Errorf("Incorrect address %s != %s for addr %s", address, tc.address, tc.raw)
+		}
+		if tls := c.UseTLS(); tls != tc.tls {
+			t.Errorf("Incorrect TLS %v != %v for addr %s", tls, tc.tls, tc.raw)
+		}
+	}
+}
+
 func TestDuplicateDevices(t *testing.T) {
 	// Duplicate devices should be removed
 
@@ -1204,6 +1295,34 @@ func TestMaxConcurrentFolders(t *testing.T) {
 	}
 }
 
+func TestMemoryBudget(t *testing.T) {
+	opts := OptionsConfiguration{
+		RawMaxFolderConcurrency: 4,
+		RawMaxCIRequestKiB:      -1,
+		DatabaseTuning:          TuningLarge,
+	}
+	if opts.LowMemory() {
+		t.Error("Expected no memory budget by default")
+	}
+	if opts.EffectiveDatabaseTuning() != TuningLarge {
+		t.Error("Expected the configured database tuning without a budget")
+	}
+
+	opts.MemoryBudgetMiB = 128
+	if !opts.LowMemory() {
+		t.Error("Expected a memory budget")
+	}
+	if res := opts.MaxFolderConcurrency(); res != 1 {
+		t.Errorf("Wrong MaxFolderConcurrency %d within a memory budget, expected 1", res)
+	}
+	if res, exp := opts.MaxConcurrentIncomingRequestKiB(), 2*protocol.MaxBlockSize/1024; res != exp {
+		t.Errorf("Wrong MaxConcurrentIncomingRequestKiB %d within a memory budget, expected %d", res, exp)
+	}
+	if res := opts.EffectiveDatabaseTuning(); res != TuningLowMemory {
+		t.Errorf("Wrong database tuning %v within a memory budget", res)
+	}
+}
+
 func adjustDeviceConfiguration(cfg *DeviceConfiguration, id protocol.DeviceID, name string) {
 	cfg.DeviceID = id
 	cfg.Name = name
@@ -1350,3 +1469,378 @@ func TestInternalVersioningConfiguration(t *testing.T) {
 		}
 	}
 }
+
+func TestScopedAPIKeys(t *testing.T) {
+	xmlCfg := `<configuration version="` + strconv.Itoa(CurrentVersion) + `">
+    <gui enabled="true">
+        <apikey>main</apikey>
+        <scopedApiKey name="monitoring" scope="read-only">
+            <key>monitor</key>
+        </scopedApiKey>
+        <scopedApiKey name="photos" scope="folder-admin">
+            <key>photos</key>
+            <folder>photos</folder>
+            <folder> photos </folder>
+        </scopedApiKey>
+        <scopedApiKey name="generated" scope="bogus"></scopedApiKey>
+    </gui>
+</configuration>`
+
+	cfg, _, err := ReadXML(strings.NewReader(xmlCfg), device1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gui := cfg.GUI
+
+	if len(gui.ScopedAPIKeys) != 3 {
+		t.Fatalf("expected three scoped API keys, got %d", len(gui.ScopedAPIKeys))
+	}
+	if gui.ScopedAPIKeys[2].Key == "" {
+		t.Error("expected a key to be generated")
+	}
+	if gui.ScopedAPIKeys[2].Scope != APIKeyScopeReadOnly {
+		t.Errorf("unknown scope should be read-only, got %v", gui.ScopedAPIKeys[2].Scope)
+	}
+	if folders := gui.ScopedAPIKeys[1].Folders; len(folders) != 1 {
+		t.Errorf("expected folders to be deduplicated, got %v", folders)
+	}
+
+	cases := []struct {
+		key    string
+		valid  bool
+		scope  APIKeyScope
+		folder bool
+	}{
+		{"", false, APIKeyScopeReadOnly, false},
+		{"wrong", false, APIKeyScopeReadOnly, false},
+		{"main", true, APIKeyScopeAdmin, true},
+		{"monitor", true, APIKeyScopeReadOnly, false},
+		{"photos", true, APIKeyScopeFolderAdmin, true},
+	}
+	for _, tc := range cases {
+		if valid := gui.IsValidAPIKey(tc.key); valid != tc.valid {
+			t.Errorf("key %q: expected valid %v, got %v", tc.key, tc.valid, valid)
+		}
+		scope, _ := gui.APIKeyScope(tc.key)
+		if scope.Scope != tc.scope {
+			t.Errorf("key %q: expected scope %v, got %v", tc.key, tc.scope, scope.Scope)
+		}
+		if allowed := scope.AllowsFolder("photos"); allowed != tc.folder {
+			t.Errorf("key %q: expected folder access %v, got %v", tc.key, tc.folder, allowed)
+		}
+		if scope.AllowsFolder("other") != (tc.scope == APIKeyScopeAdmin) {
+			t.Errorf("key %q: unexpected access to other folder", tc.key)
+		}
+	}
+
+	copied := gui.Copy()
+	copied.ScopedAPIKeys[1].Folders[0] = "changed"
+	if gui.ScopedAPIKeys[1].Folders[0] != "photos" {
+		t.Error("copy should not share folders with the original")
+	}
+}
+
+func TestClientCertificates(t *testing.T) {
+	cert := []byte("not really a certificate")
+	sum := sha256.Sum256(cert)
+	fp := hex.EncodeToString(sum[:])
+	var colons []string
+	for i := 0; i < len(fp); i += 2 {
+		colons = append(colons, strings.ToUpper(fp[i:i+2]))
+	}
+
+	xmlCfg := `<configuration version="` + strconv.Itoa(CurrentVersion) + `">
+    <gui enabled="true" tls="false">
+        <requireClientCertificate>true</requireClientCertificate>
+        <clientCertificate> ` + strings.Join(colons, ":") + ` </clientCertificate>
+        <clientCertificate>` + fp + `</clientCertificate>
+    </gui>
+</configuration>`
+
+	cfg, _, err := ReadXML(strings.NewReader(xmlCfg), device1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gui := cfg.GUI
+
+	if len(gui.ClientCertificates) != 1 || gui.ClientCertificates[0] != fp {
+		t.Errorf("expected fingerprints to be normalized and deduplicated, got %v", gui.ClientCertificates)
+	}
+	if !gui.AllowsClientCertificate(cert) {
+		t.Error("expected certificate to be allowed")
+	}
+	if gui.AllowsClientCertificate([]byte("some other certificate")) {
+		t.Error("expected other certificate to not be allowed")
+	}
+	if !gui.UseTLS() {
+		t.Error("expected client certificates to imply TLS")
+	}
+}
+
+func TestConfigurationChanges(t *testing.T) {
+	from := New(device1)
+	from.Devices = append(from.Devices, DeviceConfiguration{DeviceID: device2}, DeviceConfiguration{DeviceID: device3})
+	from.Folders = []FolderConfiguration{
+		{ID: "shared", Devices: []FolderDeviceConfiguration{{DeviceID: device1}, {DeviceID: device2}}},
+		{ID: "removed", Devices: []FolderDeviceConfiguration{{DeviceID: device1}}},
+	}
+
+	to := from.Copy()
+	to.Devices = []DeviceConfiguration{to.Devices[0], to.Devices[1], {DeviceID: device4}}
+	to.Folders = []FolderConfiguration{
+		{ID: "shared", Label: "Shared", Devices: []FolderDeviceConfiguration{{DeviceID: device1}, {DeviceID: device4}}},
+		{ID: "added", Devices: []FolderDeviceConfiguration{{DeviceID: device1}, {DeviceID: device2}}},
+	}
+	to.Options.URAccepted = -1
+
+	expected := []Change{
+		{Action: ActionDeviceAdded, Device: device4.String()},
+		{Action: ActionDeviceRemoved, Device: device3.String()},
+		{Action: ActionFolderShared, Folder: "shared", Device: device4.String()},
+		{Action: ActionFolderUnshared, Folder: "shared", Device: device2.String()},
+		{Action: ActionFolderModified, Folder: "shared"},
+		{Action: ActionFolderAdded, Folder: "added"},
+		{Action: ActionFolderShared, Folder: "added", Device: device2.String()},
+		{Action: ActionFolderRemoved, Folder: "removed"},
+		{Action: ActionOptionsModified},
+	}
+	if changes := configurationChanges(device1, from, to); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("unexpected changes:\n%+v\nexpected:\n%+v", changes, expected)
+	}
+
+	if changes := configurationChanges(device1, to, to.Copy()); len(changes) != 0 {
+		t.Errorf("unexpected changes for identical configurations: %+v", changes)
+	}
+}
+
+func TestDeviceGroups(t *testing.T) {
+	cfg := New(device1)
+	cfg.Devices = append(cfg.Devices, DeviceConfiguration{DeviceID: device2}, DeviceConfiguration{DeviceID: device3}, DeviceConfiguration{DeviceID: device4})
+	cfg.DeviceGroups = []DeviceGroupConfiguration{
+		{ID: "laptops", Devices: []protocol.DeviceID{device3, device2}},
+	}
+	cfg.Folders = []FolderConfiguration{
+		{ID: "folder", Path: "testdata", DeviceGroups: []string{"laptops", "nonexistent"}, Devices: []FolderDeviceConfiguration{{DeviceID: device2}}},
+	}
+	w := wrap("/dev/null", New(device1), device1)
+	defer w.stop()
+	replace(t, w, cfg)
+
+	sharedWith := func() map[protocol.DeviceID]string {
+		t.Helper()
+		folder, ok := w.Folder("folder")
+		if !ok {
+			t.Fatal("folder missing")
+		}
+		res := make(map[protocol.DeviceID]string)
+		for _, dev := range folder.Devices {
+			res[dev.DeviceID] = dev.DeviceGroup
+		}
+		return res
+	}
+
+	// device2 was shared with explicitly and stays so, device3 is added
+	// through the group.
+	expected := map[protocol.DeviceID]string{device1: "", device2: "", device3: "laptops"}
+	if shared := sharedWith(); !reflect.DeepEqual(shared, expected) {
+		t.Errorf("Expected %v, got %v", expected, shared)
+	}
+	if folder, _ := w.Folder("folder"); !reflect.DeepEqual(folder.DeviceGroups, []string{"laptops"}) {
+		t.Errorf("Expected only the existing group to remain, got %v", folder.DeviceGroups)
+	}
+
+	// Changing the membership changes sharing.
+	raw := w.RawCopy()
+	raw.DeviceGroups[0].Devices = []protocol.DeviceID{device2, device4}
+	replace(t, w, raw)
+	expected = map[protocol.DeviceID]string{device1: "", device2: "", device4: "laptops"}
+	if shared := sharedWith(); !reflect.DeepEqual(shared, expected) {
+		t.Errorf("Expected %v, got %v", expected, shared)
+	}
+
+	// Removing the group unshares the folder from the devices it was
+	// shared with through it.
+	raw = w.RawCopy()
+	raw.RemoveDeviceGroup("laptops")
+	replace(t, w, raw)
+	expected = map[protocol.DeviceID]string{device1: "", device2: ""}
+	if shared := sharedWith(); !reflect.DeepEqual(shared, expected) {
+		t.Errorf("Expected %v, got %v", expected, shared)
+	}
+}
+
+func TestReplaceDeviceID(t *testing.T) {
+	cfg := New(device1)
+	cfg.Devices = append(cfg.Devices,
+		DeviceConfiguration{DeviceID: device2, Name: "rotating", NextDeviceID: device4},
+		DeviceConfiguration{DeviceID: device3, IntroducedBy: device2},
+	)
+	cfg.DeviceGroups = []DeviceGroupConfiguration{{ID: "group", Devices: []protocol.DeviceID{device2, device3}}}
+	cfg.Folders = []FolderConfiguration{{
+		ID:                      "folder",
+		ConflictPreferredDevice: device2,
+		Devices:                 []FolderDeviceConfiguration{{DeviceID: device1}, {DeviceID: device2, EncryptionPassword: "secret"}, {DeviceID: device3, IntroducedBy: device2}},
+	}}
+
+	cfg.ReplaceDeviceID(device2, device4)
+
+	if _, _, ok := cfg.Device(device2); ok {
+		t.Error("old device ID remains")
+	}
+	dev, _, ok := cfg.Device(device4)
+	if !ok {
+		t.Fatal("new device ID missing")
+	}
+	if dev.Name != "rotating" || dev.NextDeviceID != protocol.EmptyDeviceID {
+		t.Errorf("unexpected device after replacement: %v", dev)
+	}
+	if dev, _, _ := cfg.Device(device3); dev.IntroducedBy != device4 {
+		t.Errorf("introducer not replaced: %v", dev.IntroducedBy)
+	}
+	folder := cfg.Folders[0]
+	if fdev, ok := folder.Device(device4); !ok || fdev.EncryptionPassword != "secret" {
+		t.Errorf("folder share not carried over: %v", folder.Devices)
+	}
+	if fdev, _ := folder.Device(device3); fdev.IntroducedBy != device4 {
+		t.Errorf("folder introducer not replaced: %v", fdev.IntroducedBy)
+	}
+	if folder.ConflictPreferredDevice != device4 {
+		t.Errorf("conflict preferred device not replaced: %v", folder.ConflictPreferredDevice)
+	}
+	if expected := []protocol.DeviceID{device4, device3}; !reflect.DeepEqual(cfg.DeviceGroups[0].Devices, expected) {
+		t.Errorf("expected group devices %v, got %v", expected, cfg.DeviceGroups[0].Devices)
+	}
+
+	// When the new ID is already configured the old entries are dropped.
+	cfg.Devices = append(cfg.Devices, DeviceConfiguration{DeviceID: device2})
+	cfg.Folders[0].Devices = append(cfg.Folders[0].Devices, FolderDeviceConfiguration{DeviceID: device2})
+	cfg.ReplaceDeviceID(device2, device4)
+	if len(cfg.Devices) != 3 {
+		t.Errorf("expected three devices, got %v", cfg.Devices)
+	}
+	if len(cfg.Folders[0].Devices) != 3 {
+		t.Errorf("expected three folder devices, got %v", cfg.Folders[0].Devices)
+	}
+}
+
+func TestSymlinkRewrites(t *testing.T) {
+	fcfg := FolderConfiguration{
+		SymlinkRewrites: []SymlinkRewrite{
+			{From: "/home/jb/", To: "/Users/jb"},
+			{From: "/srv", To: "/mnt/srv"},
+		},
+	}
+
+	local := map[string]string{
+		"/home/jb":          "/Users/jb",
+		"/home/jb/docs/a":   "/Users/jb/docs/a",
+		"/home/jbx/docs":    "/home/jbx/docs",
+		"/srv/www":          "/mnt/srv/www",
+		"relative/srv/www":  "relative/srv/www",
+		"/elsewhere/srv/ww": "/elsewhere/srv/ww",
+	}
+	for synced, expected := range local {
+		if res := fcfg.LocalSymlinkTarget(synced); res != expected {
+			t.Errorf("Local target for %q is %q, expected %q", synced, res, expected)
+		}
+		if res := fcfg.SyncedSymlinkTarget(expected); res != synced {
+			t.Errorf("Synced target for %q is %q, expected %q", expected, res, synced)
+		}
+	}
+}
+
+func TestFragmentApply(t *testing.T) {
+	cfg := New(device1)
+	cfg.Defaults.Folder.Path = "/data"
+	cfg.Devices = append(cfg.Devices, DeviceConfiguration{DeviceID: device2, Name: "two", ConfigManager: true})
+	cfg.Folders = append(cfg.Folders, FolderConfiguration{ID: "existing", Path: "/somewhere", RescanIntervalS: 60})
+	cfg.Options.RelaysEnabled = true
+	cfg.Options.LocalAnnEnabled = true
+
+	frag, err := ParseFragment([]byte(`{
+		"devices": [
+			{"deviceID": "` + device2.String() + `", "configManager": false, "paused": true},
+			{"deviceID": "` + device3.String() + `", "name": "three", "configManager": true, "upgradeManager": true}
+		],
+		"folders": [
+			{"id": "existing", "label": "Existing"},
+			{"id": "new", "label": "New Folder", "devices": [{"deviceID": "` + device3.String() + `"}]}
+		],
+		"options": {"relaysEnabled": false}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := frag.Apply(&cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if dev, _, ok := cfg.Device(device2); !ok || dev.Name != "two" || !dev.Paused || !dev.ConfigManager {
+		t.Errorf("device 2 not patched as expected: %+v", dev)
+	}
+	if dev, _, ok := cfg.Device(device3); !ok || dev.Name != "three" || dev.ConfigManager || dev.UpgradeManager {
+		t.Errorf("device 3 not added as expected: %+v", dev)
+	}
+	if fcfg, _, ok := cfg.Folder("existing"); !ok || fcfg.Label != "Existing" || fcfg.Path != "/somewhere" || fcfg.RescanIntervalS != 60 {
+		t.Errorf("existing folder not patched as expected: %+v", fcfg)
+	}
+	if fcfg, _, ok := cfg.Folder("new"); !ok || fcfg.Path != filepath.Join("/data", "New Folder") || !fcfg.SharedWith(device3) {
+		t.Errorf("new folder not added as expected: %+v", fcfg)
+	}
+	if cfg.Options.RelaysEnabled || !cfg.Options.LocalAnnEnabled {
+		t.Error("options not patched as expected")
+	}
+
+	for _, bs := range []string{``, `{}`, `{"folders": [{"label": "no ID"}]}`, `{"options": {"relaysEnabled": "maybe"}}`} {
+		if _, err := ParseFragment([]byte(bs)); err == nil {
+			t.Errorf("fragment %q should be invalid", bs)
+		}
+	}
+}
+
+func TestFragmentLocalFolderSettings(t *testing.T) {
+	cfg := New(device1)
+	cfg.Folders = append(cfg.Folders, FolderConfiguration{
+		ID:                      "existing",
+		Path:                    "/somewhere",
+		FilesystemType:          fs.FilesystemTypeBasic,
+		Versioning:              VersioningConfiguration{Type: "simple", Params: map[string]string{"keep": "5"}, CleanupIntervalS: 3600},
+		Hooks:                   []FolderHookConfiguration{{Event: "folderIdle", Command: "/bin/true"}},
+		LocalEncryptionPassword: "secret",
+	})
+
+	// Settings that give access to the local system can't be changed,
+	// neither for existing nor for new folders.
+	for _, folder := range []string{
+		`{"id": "existing", "path": "/elsewhere"}`,
+		`{"id": "existing", "filesystemType": "fake"}`,
+		`{"id": "existing", "versioning": {"type": "external", "params": {"command": "rm -rf /"}}}`,
+		`{"id": "existing", "versioning": {"type": "simple", "params": {"keep": "5"}, "fsPath": "/elsewhere"}}`,
+		`{"id": "existing", "hooks": [{"event": "folderIdle", "command": "rm -rf /"}]}`,
+		`{"id": "existing", "hooks": []}`,
+		`{"id": "existing", "localEncryptionPassword": ""}`,
+		`{"id": "new", "path": "/elsewhere"}`,
+		`{"id": "new", "hooks": [{"event": "folderIdle", "command": "rm -rf /"}]}`,
+	} {
+		to := cfg.Copy()
+		frag := Fragment{Folders: []json.RawMessage{json.RawMessage(folder)}}
+		if err := frag.Apply(&to); err == nil {
+			t.Errorf("fragment folder %s should be rejected", folder)
+		}
+	}
+
+	// Repeating the current values is fine.
+	frag := Fragment{Folders: []json.RawMessage{json.RawMessage(`{
+		"id": "existing",
+		"label": "Existing",
+		"path": "/somewhere",
+		"versioning": {"type": "simple", "params": {"keep": "5"}},
+		"hooks": [{"event": "folderIdle", "command": "/bin/true"}]
+	}`)}}
+	if err := frag.Apply(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if fcfg, _, _ := cfg.Folder("existing"); fcfg.Label != "Existing" {
+		t.Error("folder not patched")
+	}
+}
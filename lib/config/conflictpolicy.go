@@ -0,0 +1,42 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+func (p ConflictPolicy) String() string {
+	switch p {
+	case ConflictPolicyKeepBoth:
+		return "keep-both"
+	case ConflictPolicyKeepNewest:
+		return "keep-newest"
+	case ConflictPolicyKeepLargest:
+		return "keep-largest"
+	case ConflictPolicyPreferDevice:
+		return "prefer-device"
+	default:
+		return "unknown"
+	}
+}
+
+func (p ConflictPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *ConflictPolicy) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "keep-both":
+		*p = ConflictPolicyKeepBoth
+	case "keep-newest":
+		*p = ConflictPolicyKeepNewest
+	case "keep-largest":
+		*p = ConflictPolicyKeepLargest
+	case "prefer-device":
+		*p = ConflictPolicyPreferDevice
+	default:
+		*p = ConflictPolicyKeepBoth
+	}
+	return nil
+}
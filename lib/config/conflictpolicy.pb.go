@@ -0,0 +1,79 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/conflictpolicy.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ConflictPolicy int32
+
+const (
+	ConflictPolicyKeepBoth     ConflictPolicy = 0
+	ConflictPolicyKeepNewest   ConflictPolicy = 1
+	ConflictPolicyKeepLargest  ConflictPolicy = 2
+	ConflictPolicyPreferDevice ConflictPolicy = 3
+)
+
+var ConflictPolicy_name = map[int32]string{
+	0: "CONFLICT_POLICY_KEEP_BOTH",
+	1: "CONFLICT_POLICY_KEEP_NEWEST",
+	2: "CONFLICT_POLICY_KEEP_LARGEST",
+	3: "CONFLICT_POLICY_PREFER_DEVICE",
+}
+
+var ConflictPolicy_value = map[string]int32{
+	"CONFLICT_POLICY_KEEP_BOTH":     0,
+	"CONFLICT_POLICY_KEEP_NEWEST":   1,
+	"CONFLICT_POLICY_KEEP_LARGEST":  2,
+	"CONFLICT_POLICY_PREFER_DEVICE": 3,
+}
+
+func (ConflictPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_45993ab162f648a9, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.ConflictPolicy", ConflictPolicy_name, ConflictPolicy_value)
+}
+
+func init() { proto.RegisterFile("lib/config/conflictpolicy.proto", fileDescriptor_45993ab162f648a9) }
+
+var fileDescriptor_45993ab162f648a9 = []byte{
+	// 315 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x31, 0x4f, 0xc2, 0x40,
+	0x14, 0xc7, 0xaf, 0x68, 0x18, 0x6e, 0x30, 0xa4, 0x83, 0x91, 0x13, 0xce, 0x26, 0x4e, 0x3a, 0xd0,
+	0xc1, 0xc9, 0xc1, 0x18, 0x28, 0x87, 0x12, 0x1a, 0x68, 0x90, 0x68, 0x74, 0x69, 0xec, 0xe5, 0x38,
+	0x2e, 0x41, 0xae, 0x29, 0x87, 0x86, 0xaf, 0xd0, 0xc9, 0xc1, 0xb5, 0x89, 0x83, 0x83, 0x1f, 0x85,
+	0xb1, 0xa3, 0x2b, 0xf4, 0x8b, 0x18, 0x0e, 0x13, 0x45, 0x98, 0xee, 0x7f, 0xef, 0xbd, 0xdf, 0x6f,
+	0x78, 0x0f, 0x1e, 0x0d, 0x45, 0x60, 0x53, 0x39, 0xea, 0x0b, 0xae, 0x9f, 0xa1, 0xa0, 0x2a, 0x94,
+	0x43, 0x41, 0xa7, 0x95, 0x30, 0x92, 0x4a, 0x9a, 0xf9, 0x55, 0x13, 0x1d, 0x47, 0x2c, 0x94, 0x63,
+	0x5b, 0x17, 0x83, 0x49, 0xdf, 0xe6, 0x92, 0x4b, 0xfd, 0xd1, 0x69, 0x35, 0x7c, 0xfa, 0x96, 0x83,
+	0x7b, 0xce, 0x8f, 0xc5, 0xd3, 0x16, 0xf3, 0x1c, 0x16, 0x9d, 0x4e, 0xbb, 0xe1, 0x36, 0x9d, 0x9e,
+	0xef, 0x75, 0xdc, 0xa6, 0x73, 0xef, 0xb7, 0x08, 0xf1, 0xfc, 0x5a, 0xa7, 0x77, 0x5d, 0x00, 0x08,
+	0xc5, 0x89, 0xb5, 0xbf, 0x8e, 0xb4, 0x18, 0x0b, 0x6b, 0x52, 0x0d, 0xcc, 0x0b, 0x78, 0xb8, 0x15,
+	0x6d, 0x93, 0x3b, 0x72, 0xd3, 0x2b, 0x18, 0xa8, 0x14, 0x27, 0xd6, 0xc1, 0x26, 0xdc, 0x66, 0x2f,
+	0x6c, 0xac, 0xcc, 0x4b, 0x58, 0xda, 0x8a, 0xbb, 0xd5, 0xee, 0xd5, 0x92, 0xcf, 0xa1, 0x72, 0x9c,
+	0x58, 0xc5, 0x4d, 0xde, 0x7d, 0x8c, 0xf8, 0x52, 0x50, 0x85, 0xe5, 0xff, 0x02, 0xaf, 0x4b, 0x1a,
+	0xa4, 0xeb, 0xd7, 0xc9, 0x6d, 0xd3, 0x21, 0x85, 0x1d, 0x84, 0xe3, 0xc4, 0x42, 0xeb, 0x06, 0x2f,
+	0x62, 0x7d, 0x16, 0xd5, 0xd9, 0xb3, 0xa0, 0x0c, 0xed, 0x7e, 0x7e, 0x60, 0x50, 0x6b, 0xcd, 0xe6,
+	0x18, 0xa4, 0x73, 0x0c, 0x66, 0x0b, 0x6c, 0xa4, 0x0b, 0x6c, 0xbc, 0x66, 0x18, 0xbc, 0x67, 0xd8,
+	0x48, 0x33, 0x0c, 0xbe, 0x32, 0x0c, 0x1e, 0x4e, 0xb8, 0x50, 0x83, 0x49, 0x50, 0xa1, 0xf2, 0xc9,
+	0x1e, 0x4f, 0x47, 0x54, 0x0d, 0xc4, 0x88, 0xff, 0x49, 0xbf, 0x57, 0x0a, 0xf2, 0x7a, 0xd5, 0x67,
+	0xdf, 0x03, 0x00, 0x60, 0x90, 0x6d, 0xf7, 0xba, 0x01, 0x00, 0x00,
+}
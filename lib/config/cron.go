@@ -0,0 +1,178 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Every combination of days of the month and weekdays recurs within this
+// many years, accounting for leap years.
+const maxCronSearch = 8 * 366 * 24 * time.Hour
+
+var errCronSyntax = errors.New(`expected "minute hour day-of-month month day-of-week"`)
+
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+var months = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// A CronSchedule is a set of times given by a cron expression, in local
+// time.
+type CronSchedule struct {
+	minutes [60]bool
+	hours   [24]bool
+	mdays   [32]bool // 1-31
+	months  [13]bool // 1-12
+	wdays   [7]bool
+	// As in cron, a time matches when either the day of the month or the
+	// weekday does, unless one of them is unrestricted.
+	anyMday, anyWday bool
+}
+
+// ParseCron parses a standard five field cron expression, with lists,
+// ranges, steps and the names of months and weekdays, or one of the macros
+// @hourly, @daily, @weekly, @monthly and @yearly.
+func ParseCron(expr string) (CronSchedule, error) {
+	var c CronSchedule
+	if macro, ok := cronMacros[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return c, errCronSyntax
+	}
+	if err := parseCronField(fields[0], 0, 59, nil, c.minutes[:]); err != nil {
+		return c, fmt.Errorf("minute: %w", err)
+	}
+	if err := parseCronField(fields[1], 0, 23, nil, c.hours[:]); err != nil {
+		return c, fmt.Errorf("hour: %w", err)
+	}
+	if err := parseCronField(fields[2], 1, 31, nil, c.mdays[:]); err != nil {
+		return c, fmt.Errorf("day of month: %w", err)
+	}
+	if err := parseCronField(fields[3], 1, 12, months, c.months[:]); err != nil {
+		return c, fmt.Errorf("month: %w", err)
+	}
+	// Sunday is both zero and seven.
+	var wdays [8]bool
+	names := make(map[string]int, len(weekdays))
+	for name, day := range weekdays {
+		names[name] = int(day)
+	}
+	if err := parseCronField(fields[4], 0, 7, names, wdays[:]); err != nil {
+		return c, fmt.Errorf("day of week: %w", err)
+	}
+	copy(c.wdays[:], wdays[:7])
+	c.wdays[time.Sunday] = c.wdays[time.Sunday] || wdays[7]
+	c.anyMday = strings.HasPrefix(fields[2], "*")
+	c.anyWday = strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+func parseCronField(str string, min, max int, names map[string]int, set []bool) error {
+	for _, part := range strings.Split(str, ",") {
+		step := 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			part = part[:i]
+		}
+
+		first, last := min, max
+		if part != "*" {
+			bounds := strings.Split(part, "-")
+			if len(bounds) > 2 {
+				return errCronSyntax
+			}
+			var err error
+			if first, err = parseCronValue(bounds[0], min, max, names); err != nil {
+				return err
+			}
+			last = first
+			if len(bounds) == 2 {
+				if last, err = parseCronValue(bounds[1], min, max, names); err != nil {
+					return err
+				}
+			} else if step > 1 {
+				// "5/10" means from five onwards.
+				last = max
+			}
+			if last < first {
+				return fmt.Errorf("invalid range %q", part)
+			}
+		}
+
+		for v := first; v <= last; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+func parseCronValue(str string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(str)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(str)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid value %q", str)
+	}
+	return v, nil
+}
+
+// Next returns the first time of the schedule after t, or the zero time if
+// there is none, as with "0 0 31 2 *".
+func (c CronSchedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	for limit := t.Add(maxCronSearch); next.Before(limit); {
+		if !c.months[next.Month()] || !c.matchesDay(next) {
+			y, m, d := next.Date()
+			next = time.Date(y, m, d+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.hours[next.Hour()] {
+			y, m, d := next.Date()
+			next = time.Date(y, m, d, next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.minutes[next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+func (c CronSchedule) matchesDay(t time.Time) bool {
+	mday, wday := c.mdays[t.Day()], c.wdays[t.Weekday()]
+	switch {
+	case c.anyMday && c.anyWday:
+		return true
+	case c.anyMday:
+		return wday
+	case c.anyWday:
+		return mday
+	default:
+		return mday || wday
+	}
+}
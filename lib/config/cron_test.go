@@ -0,0 +1,88 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	valid := []string{
+		"0 3 * * *",
+		"*/15 * * * *",
+		"30 1-5/2 1,15 * Mon-Fri",
+		"0 0 * Jan,Jul 7",
+		"5/10 * * * *",
+		"@daily",
+	}
+	for _, str := range valid {
+		if _, err := ParseCron(str); err != nil {
+			t.Errorf("%q: unexpected error: %v", str, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"0 3 * *",
+		"0 3 * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * * Someday",
+		"@sometimes",
+	}
+	for _, str := range invalid {
+		if _, err := ParseCron(str); err == nil {
+			t.Errorf("%q: expected an error", str)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// 2021-03-01 is a Monday.
+	at := func(month, day, hour, minute int) time.Time {
+		return time.Date(2021, time.Month(month), day, hour, minute, 0, 0, time.Local)
+	}
+	cases := []struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"0 3 * * *", at(3, 1, 2, 59), at(3, 1, 3, 0)},
+		{"0 3 * * *", at(3, 1, 3, 0), at(3, 2, 3, 0)},
+		{"*/15 * * * *", at(3, 1, 12, 1), at(3, 1, 12, 15)},
+		{"30 22 * * Sat,Sun", at(3, 1, 0, 0), at(3, 6, 22, 30)},
+		{"0 0 * * 7", at(3, 1, 0, 0), at(3, 7, 0, 0)},
+		// Either the day of the month or the weekday.
+		{"0 0 15 * Fri", at(3, 1, 0, 0), at(3, 5, 0, 0)},
+		{"0 0 15 * Fri", at(3, 13, 0, 0), at(3, 15, 0, 0)},
+		{"0 12 1 Jun *", at(3, 1, 0, 0), at(6, 1, 12, 0)},
+		{"@monthly", at(3, 31, 23, 0), at(4, 1, 0, 0)},
+	}
+	for _, tc := range cases {
+		cron, err := ParseCron(tc.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next := cron.Next(tc.from); !next.Equal(tc.expected) {
+			t.Errorf("%q from %v: next %v != expected %v", tc.expr, tc.from, next, tc.expected)
+		}
+	}
+
+	never, err := ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := never.Next(at(3, 1, 0, 0)); !next.IsZero() {
+		t.Errorf("impossible schedule matched at %v", next)
+	}
+}
@@ -8,6 +8,9 @@ package config
 
 import (
 	"sort"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
 )
 
 func (cfg DeviceConfiguration) Copy() DeviceConfiguration {
@@ -18,6 +21,8 @@ func (cfg DeviceConfiguration) Copy() DeviceConfiguration {
 	copy(c.AllowedNetworks, cfg.AllowedNetworks)
 	c.IgnoredFolders = make([]ObservedFolder, len(cfg.IgnoredFolders))
 	copy(c.IgnoredFolders, cfg.IgnoredFolders)
+	c.TransportPriority = make([]string, len(cfg.TransportPriority))
+	copy(c.TransportPriority, cfg.TransportPriority)
 	return c
 }
 
@@ -44,6 +49,26 @@ func (cfg *DeviceConfiguration) IgnoredFolder(folder string) bool {
 	return false
 }
 
+const (
+	defaultZstdLevel = 3
+	maxZstdLevel     = 19
+)
+
+// ZstdLevel returns the zstd level to compress messages to the device with,
+// or zero to use LZ4.
+func (cfg DeviceConfiguration) ZstdLevel() int {
+	switch {
+	case cfg.CompressionLevel < 0:
+		return 0
+	case cfg.CompressionLevel == 0:
+		return defaultZstdLevel
+	case cfg.CompressionLevel > maxZstdLevel:
+		return maxZstdLevel
+	default:
+		return cfg.CompressionLevel
+	}
+}
+
 func sortedObservedFolderSlice(input map[string]ObservedFolder) []ObservedFolder {
 	output := make([]ObservedFolder, 0, len(input))
 	for _, folder := range input {
@@ -65,3 +90,12 @@ func deduplicateObservedFoldersToMap(input []ObservedFolder) map[string]Observed
 
 	return output
 }
+
+// Keepalive returns the ping interval and timeout for connections to the
+// device, where zero means the protocol defaults.
+func (cfg DeviceConfiguration) Keepalive() protocol.Keepalive {
+	return protocol.Keepalive{
+		PingInterval:   time.Duration(cfg.PingIntervalS) * time.Second,
+		ReceiveTimeout: time.Duration(cfg.PingTimeoutS) * time.Second,
+	}
+}
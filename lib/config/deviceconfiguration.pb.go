@@ -44,6 +44,44 @@ type DeviceConfiguration struct {
 	MaxRequestKiB            int                                                  `protobuf:"varint,16,opt,name=max_request_kib,json=maxRequestKib,proto3,casttype=int" json:"maxRequestKiB" xml:"maxRequestKiB"`
 	Untrusted                bool                                                 `protobuf:"varint,17,opt,name=untrusted,proto3" json:"untrusted" xml:"untrusted"`
 	RemoteGUIPort            int                                                  `protobuf:"varint,18,opt,name=remote_gui_port,json=remoteGuiPort,proto3,casttype=int" json:"remoteGUIPort" xml:"remoteGUIPort"`
+	ProxyURL                 string                                               `protobuf:"bytes,19,opt,name=proxy_url,json=proxyUrl,proto3" json:"proxyURL" xml:"proxyURL,omitempty"`
+	// Transports in order of preference, e.g. "tcp-lan", "tcp-wan", "quic",
+	// "relay". An empty list means the default order.
+	TransportPriority []string `protobuf:"bytes,20,rep,name=transport_priority,json=transportPriority,proto3" json:"transportPriority" xml:"transportPriority,omitempty"`
+	// The number of concurrent connections to maintain to the device, if
+	// both sides agree. Blocks are requested over all of them, while
+	// everything else uses the first one. Zero and one mean a single
+	// connection.
+	NumConnections int `protobuf:"varint,21,opt,name=num_connections,json=numConnections,proto3,casttype=int" json:"numConnections" xml:"numConnections"`
+	// The directory in which folders auto-accepted from this device are
+	// created. Empty means the default folder path.
+	AutoAcceptPath string `protobuf:"bytes,22,opt,name=auto_accept_path,json=autoAcceptPath,proto3" json:"autoAcceptPath" xml:"autoAcceptPath"`
+	// The zstd level, from 1 to 19, to compress messages with when the
+	// device supports zstd. Zero means the default level, while a negative
+	// value keeps using LZ4.
+	CompressionLevel int `protobuf:"varint,23,opt,name=compression_level,json=compressionLevel,proto3,casttype=int" json:"compressionLevel" xml:"compressionLevel"`
+	// How much of the configuration of the devices it introduces an
+	// introducer decides: only which devices there are, also their
+	// addresses, or also their names and whether they are introducers
+	// themselves.
+	IntroducerAuthority IntroducerAuthority `protobuf:"varint,24,opt,name=introducer_authority,json=introducerAuthority,proto3,enum=config.IntroducerAuthority" json:"introducerAuthority" xml:"introducerAuthority,attr"`
+	// Whether configuration fragments pushed by the device are applied,
+	// letting it manage the folders, devices and options of this one.
+	ConfigManager bool `protobuf:"varint,25,opt,name=config_manager,json=configManager,proto3" json:"configManager" xml:"configManager"`
+	// How often to make sure a message is sent to the device, and how
+	// long to wait for one from it before giving up on the connection.
+	// Lower values notice dead connections sooner, e.g. of mobile devices
+	// switching networks, at the cost of more traffic. Zero means 90 and
+	// 300 seconds. Changes apply to new connections.
+	PingIntervalS int `protobuf:"varint,26,opt,name=ping_interval_s,json=pingIntervalS,proto3,casttype=int" json:"pingIntervalS" xml:"pingIntervalS"`
+	PingTimeoutS  int `protobuf:"varint,27,opt,name=ping_timeout_s,json=pingTimeoutS,proto3,casttype=int" json:"pingTimeoutS" xml:"pingTimeoutS"`
+	// The device ID the device announced it is rotating its certificate
+	// to, signed with its current certificate. Connections from this ID
+	// are accepted and replace the current one in the configuration.
+	NextDeviceID github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,28,opt,name=next_device_id,json=nextDeviceId,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"nextDeviceID" xml:"nextDeviceID,attr,omitempty" nodefault:"true"`
+	// Whether upgrade requests from the device are acted upon, letting it
+	// upgrade this one.
+	UpgradeManager bool `protobuf:"varint,29,opt,name=upgrade_manager,json=upgradeManager,proto3" json:"upgradeManager" xml:"upgradeManager"`
 }
 
 func (m *DeviceConfiguration) Reset()         { *m = DeviceConfiguration{} }
@@ -88,72 +126,99 @@ func init() {
 }
 
 var fileDescriptor_744b782bd13071dd = []byte{
-	// 1026 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xbf, 0x6f, 0xdb, 0x46,
-	0x18, 0x15, 0xeb, 0xc4, 0xb6, 0xce, 0x3f, 0x64, 0xd3, 0x88, 0xc3, 0x18, 0x88, 0x4e, 0x50, 0x35,
-	0x28, 0x68, 0x22, 0x17, 0x6e, 0x27, 0xa3, 0x2d, 0x50, 0xc6, 0x68, 0x63, 0x18, 0x4d, 0x5c, 0x16,
-	0x5d, 0xbc, 0xb0, 0x24, 0xef, 0xac, 0x1c, 0x2c, 0xf2, 0x58, 0xf2, 0xa8, 0x58, 0x40, 0xff, 0x80,
-	0x76, 0x2b, 0x02, 0x74, 0xea, 0x92, 0xf6, 0xdf, 0xe8, 0xd0, 0xd5, 0x9b, 0x35, 0x16, 0x1d, 0x0e,
-	0x88, 0xbd, 0x71, 0x29, 0xc0, 0x31, 0x53, 0x71, 0x77, 0x14, 0x45, 0xca, 0x51, 0x50, 0xa0, 0x1b,
-	0xef, 0xbd, 0x77, 0xef, 0xdd, 0xf7, 0xe9, 0xbb, 0x13, 0xe8, 0x0c, 0x88, 0xbb, 0xeb, 0xd1, 0xe0,
-	0x94, 0xf4, 0x77, 0x11, 0x1e, 0x12, 0x0f, 0xab, 0x45, 0x12, 0x39, 0x8c, 0xd0, 0xa0, 0x17, 0x46,
-	0x94, 0x51, 0x7d, 0x51, 0x81, 0x3b, 0xdb, 0x42, 0x2d, 0x21, 0x8f, 0x0e, 0x76, 0x5d, 0x1c, 0x2a,
-	0x7e, 0xe7, 0x5e, 0xc9, 0x85, 0xba, 0x31, 0x8e, 0x86, 0x18, 0xe5, 0x54, 0x1d, 0x9f, 0x33, 0xf5,
-	0xd9, 0xfe, 0x67, 0x03, 0x6c, 0x1d, 0xc8, 0x8c, 0xc7, 0xe5, 0x0c, 0xfd, 0x4f, 0x0d, 0xd4, 0x55,
-	0xb6, 0x4d, 0x90, 0xa1, 0xb5, 0xb4, 0xee, 0xaa, 0xf9, 0x9b, 0x76, 0xc1, 0x61, 0xed, 0x6f, 0x0e,
-	0x3f, 0xee, 0x13, 0xf6, 0x3c, 0x71, 0x7b, 0x1e, 0xf5, 0x77, 0xe3, 0x51, 0xe0, 0xb1, 0xe7, 0x24,
-	0xe8, 0x97, 0xbe, 0xca, 0x27, 0xea, 0x29, 0xf7, 0xc3, 0x83, 0x2b, 0x0e, 0x97, 0x27, 0xdf, 0x29,
-	0x87, 0xcb, 0x28, 0xff, 0xce, 0x38, 0x6c, 0x9e, 0xfb, 0x83, 0xfd, 0x36, 0x41, 0x0f, 0x1d, 0xc6,
-	0xa2, 0x76, 0x2b, 0xa0, 0x08, 0x9f, 0x3a, 0xc9, 0x80, 0xed, 0xb7, 0x59, 0x94, 0xe0, 0x76, 0x7a,
-	0xd9, 0x59, 0xca, 0xc9, 0xec, 0xb2, 0x53, 0x6c, 0xfc, 0x71, 0xdc, 0xd1, 0x5e, 0x8e, 0x3b, 0x85,
-	0xe9, 0xab, 0x71, 0x47, 0xb3, 0x26, 0x2c, 0xd2, 0x8f, 0xc1, 0xad, 0xc0, 0xf1, 0xb1, 0xf1, 0x5e,
-	0x4b, 0xeb, 0xd6, 0xcd, 0x4f, 0x52, 0x0e, 0xe5, 0x3a, 0xe3, 0xf0, 0x9e, 0x8c, 0x13, 0x0b, 0xe9,
-	0xf9, 0x90, 0xfa, 0x84, 0x61, 0x3f, 0x64, 0x23, 0x91, 0xb4, 0xf5, 0x16, 0xdc, 0x92, 0x3b, 0xf5,
-	0x73, 0x50, 0x77, 0x10, 0x8a, 0x70, 0x1c, 0xe3, 0xd8, 0x58, 0x68, 0x2d, 0x74, 0xeb, 0xe6, 0x49,
-	0xca, 0xe1, 0x14, 0xcc, 0x38, 0x7c, 0x20, 0xbd, 0x73, 0xa4, 0xe4, 0xdc, 0x2a, 0x4a, 0x42, 0xa3,
-	0xc0, 0xf1, 0x89, 0x27, 0xb2, 0x36, 0x6f, 0xe8, 0xde, 0x5c, 0x76, 0x96, 0x72, 0x81, 0x35, 0xf5,
-	0xd5, 0x87, 0x60, 0xc5, 0xa3, 0x7e, 0x28, 0x56, 0x84, 0x06, 0xc6, 0xad, 0x96, 0xd6, 0x5d, 0xdf,
-	0xbb, 0xd3, 0x2b, 0x7a, 0xfc, 0x78, 0x4a, 0x9a, 0x9f, 0xa6, 0x1c, 0x96, 0xd5, 0x19, 0x87, 0xdb,
-	0xf2, 0x50, 0x25, 0x4c, 0x35, 0x3a, 0xbd, 0xec, 0x6c, 0xcc, 0x82, 0x56, 0x79, 0xab, 0x8e, 0x41,
-	0xdd, 0xc3, 0x11, 0xb3, 0x65, 0x23, 0x6f, 0xcb, 0x46, 0x3e, 0x11, 0xbf, 0x9d, 0x00, 0x9f, 0xaa,
-	0x66, 0xde, 0x57, 0xde, 0x39, 0xf0, 0x96, 0x86, 0xde, 0x9d, 0xc3, 0x59, 0x85, 0x8b, 0x7e, 0x02,
-	0x00, 0x09, 0x58, 0x44, 0x51, 0xe2, 0xe1, 0xc8, 0x58, 0x6c, 0x69, 0xdd, 0x65, 0x73, 0x3f, 0xe5,
-	0xb0, 0x84, 0x66, 0x1c, 0xde, 0x51, 0x53, 0x52, 0x40, 0x45, 0x11, 0x8d, 0x19, 0xcc, 0x2a, 0xed,
-	0xd3, 0x7f, 0xd7, 0xc0, 0x4e, 0x7c, 0x46, 0x42, 0x7b, 0x82, 0x89, 0xf1, 0xb6, 0x23, 0xec, 0xd3,
-	0xa1, 0x33, 0x88, 0x8d, 0x25, 0x19, 0x86, 0x52, 0x0e, 0x0d, 0xa1, 0x3a, 0x2c, 0x89, 0xac, 0x5c,
-	0x93, 0x71, 0xf8, 0xbe, 0x8c, 0x9e, 0x27, 0x28, 0x0e, 0x72, 0xff, 0x9d, 0x0a, 0x6b, 0x6e, 0x82,
-	0xfe, 0x87, 0x06, 0xd6, 0x8a, 0x33, 0x23, 0xdb, 0x1d, 0x19, 0xcb, 0xf2, 0xc6, 0xfd, 0xf2, 0xbf,
-	0x6e, 0x5c, 0xca, 0xe1, 0xea, 0xd4, 0xd5, 0x1c, 0x65, 0x1c, 0x76, 0xab, 0x3d, 0x44, 0xe6, 0x68,
-	0xfe, 0x9d, 0xdb, 0xbc, 0x21, 0x13, 0x37, 0x4e, 0xde, 0xb2, 0x8a, 0xad, 0xbe, 0x07, 0x16, 0x43,
-	0x27, 0x89, 0x31, 0x32, 0xea, 0xb2, 0x9b, 0x3b, 0x29, 0x87, 0x39, 0x92, 0x71, 0xb8, 0x2a, 0x23,
-	0xd5, 0xb2, 0x6d, 0xe5, 0xb8, 0xfe, 0x03, 0xd8, 0x70, 0x06, 0x03, 0xfa, 0x02, 0x23, 0x3b, 0xc0,
-	0xec, 0x05, 0x8d, 0xce, 0x62, 0x03, 0xc8, 0x2b, 0xf5, 0x75, 0xca, 0x61, 0x23, 0xe7, 0x9e, 0xe6,
-	0x54, 0xf1, 0x46, 0x54, 0xf1, 0xea, 0xa0, 0x19, 0xf3, 0x48, 0x6b, 0xd6, 0x4e, 0xff, 0x0e, 0x6c,
-	0x39, 0x09, 0xa3, 0xb6, 0xe3, 0x79, 0x38, 0x64, 0xf6, 0x29, 0x1d, 0x20, 0x1c, 0xc5, 0xc6, 0x8a,
-	0x3c, 0xfe, 0x87, 0x29, 0x87, 0x9b, 0x82, 0xfe, 0x5c, 0xb2, 0x5f, 0x28, 0x32, 0xe3, 0xf0, 0xae,
-	0x3a, 0xc2, 0x2c, 0xd3, 0xb6, 0x6e, 0xaa, 0xf5, 0x67, 0x60, 0xcd, 0x77, 0xce, 0xed, 0x18, 0x07,
-	0xc8, 0x3e, 0x73, 0xc3, 0xd8, 0x58, 0x6d, 0x69, 0xdd, 0xdb, 0xe6, 0x07, 0xe2, 0x72, 0xfa, 0xce,
-	0xf9, 0x37, 0x38, 0x40, 0x47, 0x6e, 0x28, 0x5c, 0x37, 0xa5, 0x6b, 0x09, 0x6b, 0xbf, 0xe1, 0x70,
-	0x81, 0x04, 0xcc, 0x2a, 0x0b, 0x27, 0x86, 0x11, 0xf6, 0x86, 0xca, 0x70, 0xad, 0x62, 0x68, 0x61,
-	0x6f, 0x38, 0x6b, 0x38, 0xc1, 0x2a, 0x86, 0x13, 0x50, 0x0f, 0x40, 0x83, 0xf4, 0x03, 0x1a, 0x61,
-	0x54, 0xd4, 0xbf, 0xde, 0x5a, 0xe8, 0xae, 0xec, 0x6d, 0xf7, 0xd4, 0xbf, 0x46, 0xef, 0x59, 0xfe,
-	0xaf, 0xa1, 0x6a, 0x32, 0x1f, 0x89, 0x59, 0x4c, 0x39, 0x5c, 0xcf, 0xb7, 0x4d, 0x1b, 0xb3, 0xa5,
-	0xa6, 0xaa, 0x0c, 0xb7, 0xad, 0x19, 0x99, 0xfe, 0x93, 0x06, 0x1a, 0x21, 0x0e, 0x10, 0x09, 0xfa,
-	0x45, 0x60, 0xe3, 0x9d, 0x81, 0x4f, 0x44, 0xe0, 0x15, 0x87, 0xc6, 0x01, 0x0e, 0x23, 0xec, 0x39,
-	0x0c, 0xa3, 0x63, 0x65, 0x90, 0x7b, 0xa6, 0x1c, 0x6a, 0x8f, 0x8a, 0x37, 0x28, 0x2c, 0x73, 0xa5,
-	0xd1, 0x30, 0x34, 0x6b, 0xbd, 0xc2, 0xc5, 0xfa, 0xaf, 0x1a, 0x68, 0xa8, 0x6e, 0x7e, 0x9f, 0xe0,
-	0x98, 0xd9, 0x67, 0xc4, 0x35, 0x36, 0x64, 0x3f, 0xe3, 0x2b, 0x0e, 0xd7, 0xbe, 0x12, 0x6d, 0x92,
-	0xcc, 0x11, 0x31, 0x53, 0x0e, 0xd7, 0xfc, 0x32, 0x50, 0x14, 0x5c, 0x41, 0x27, 0x4d, 0x4e, 0x2f,
-	0x3b, 0x33, 0xf2, 0x59, 0xe0, 0xe5, 0xb8, 0x53, 0x4d, 0xb0, 0x2a, 0xbc, 0xab, 0x7f, 0x06, 0xea,
-	0x49, 0xc0, 0xa2, 0x24, 0x66, 0x18, 0x19, 0x9b, 0x72, 0x26, 0x5b, 0xe2, 0x7f, 0xa6, 0x00, 0x33,
-	0x0e, 0x1b, 0xf2, 0x04, 0x05, 0xd2, 0xb6, 0xa6, 0xac, 0xac, 0x4e, 0x3c, 0x70, 0x0c, 0xdb, 0xfd,
-	0x84, 0xd8, 0x21, 0x8d, 0x98, 0xa1, 0x4f, 0xab, 0xb3, 0x24, 0xf5, 0xe5, 0xb7, 0x87, 0xc7, 0x34,
-	0x62, 0xa2, 0xba, 0xa8, 0x0c, 0x14, 0xd5, 0x55, 0xd0, 0x72, 0x75, 0x55, 0xf9, 0x2c, 0x20, 0xaa,
-	0xab, 0x24, 0x58, 0x13, 0x3e, 0x21, 0x62, 0x69, 0x1e, 0x5d, 0xbc, 0x6e, 0xd6, 0xc6, 0xaf, 0x9b,
-	0xb5, 0x8b, 0xab, 0xa6, 0x36, 0xbe, 0x6a, 0x6a, 0x3f, 0x5f, 0x37, 0x6b, 0xaf, 0xae, 0x9b, 0xda,
-	0xf8, 0xba, 0x59, 0xfb, 0xeb, 0xba, 0x59, 0x3b, 0x79, 0xf0, 0x1f, 0x1e, 0x3b, 0x35, 0x31, 0xee,
-	0xa2, 0x7c, 0xf4, 0x3e, 0xfa, 0x37, 0x00, 0x00, 0xff, 0xff, 0xbf, 0x4a, 0x4f, 0x60, 0x33, 0x09,
-	0x00, 0x00,
+	// 1460 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xbf, 0x6f, 0x1b, 0xc7,
+	0x12, 0xd6, 0x59, 0xb6, 0x2c, 0xae, 0x25, 0x51, 0x5a, 0xd9, 0xf2, 0x5a, 0x7a, 0xe6, 0xf2, 0xf1,
+	0xb1, 0xa0, 0x5f, 0x6c, 0x29, 0x51, 0x92, 0xc6, 0x48, 0x02, 0x98, 0x36, 0x12, 0x0b, 0xfe, 0xa5,
+	0x9c, 0x6c, 0x04, 0x51, 0x73, 0x39, 0xde, 0xad, 0xa9, 0x83, 0x78, 0x3f, 0x72, 0xb7, 0x47, 0x93,
+	0x40, 0x80, 0xa4, 0x74, 0x3a, 0xc3, 0x40, 0xaa, 0x34, 0x4e, 0x80, 0xfc, 0x15, 0x29, 0xd2, 0xba,
+	0x13, 0xcb, 0x24, 0xc5, 0x02, 0x96, 0xba, 0x2b, 0xaf, 0x74, 0x15, 0xec, 0xee, 0x71, 0x79, 0x47,
+	0x4a, 0x42, 0x00, 0x77, 0xb7, 0xdf, 0x37, 0xfb, 0xcd, 0xec, 0x70, 0x66, 0x77, 0x08, 0xea, 0x1d,
+	0xa7, 0xb5, 0x61, 0xf9, 0xde, 0x53, 0xa7, 0xbd, 0x61, 0x93, 0xae, 0x63, 0x11, 0xb9, 0x88, 0x43,
+	0x93, 0x3a, 0xbe, 0xb7, 0x1e, 0x84, 0x3e, 0xf5, 0xe1, 0x8c, 0x04, 0x57, 0x57, 0xb8, 0xb5, 0x80,
+	0x2c, 0xbf, 0xb3, 0xd1, 0x22, 0x81, 0xe4, 0x57, 0xaf, 0xe4, 0x54, 0xfc, 0x56, 0x44, 0xc2, 0x2e,
+	0xb1, 0x33, 0x2a, 0xef, 0xc0, 0xf1, 0x68, 0xe8, 0xdb, 0xb1, 0x45, 0x42, 0x33, 0xa6, 0x7b, 0x7e,
+	0xe8, 0xd0, 0x7e, 0x66, 0x55, 0x22, 0x3d, 0x2a, 0x3f, 0x6b, 0x2f, 0xd6, 0xc0, 0xf2, 0x1d, 0x11,
+	0xc9, 0xed, 0x7c, 0x24, 0xf0, 0x0f, 0x0d, 0x94, 0x64, 0x84, 0x86, 0x63, 0x23, 0xad, 0xaa, 0x35,
+	0xe6, 0x9a, 0xbf, 0x68, 0xaf, 0x19, 0x9e, 0xfa, 0x9b, 0xe1, 0x8f, 0xda, 0x0e, 0xdd, 0x8b, 0x5b,
+	0xeb, 0x96, 0xef, 0x6e, 0x44, 0x7d, 0xcf, 0xa2, 0x7b, 0x8e, 0xd7, 0xce, 0x7d, 0xe5, 0xe3, 0x5e,
+	0x97, 0xea, 0x5b, 0x77, 0x0e, 0x19, 0x9e, 0x1d, 0x7e, 0x27, 0x0c, 0xcf, 0xda, 0xd9, 0x77, 0xca,
+	0x70, 0xa5, 0xe7, 0x76, 0x6e, 0xd6, 0x1c, 0xfb, 0xba, 0x49, 0x69, 0x58, 0xab, 0x7a, 0xbe, 0x4d,
+	0x9e, 0x9a, 0x71, 0x87, 0xde, 0xac, 0xd1, 0x30, 0x26, 0xb5, 0xe4, 0xa0, 0x7e, 0x3e, 0x23, 0xd3,
+	0x83, 0xba, 0xda, 0xf8, 0x7c, 0x50, 0xd7, 0x5e, 0x0e, 0xea, 0x4a, 0xf4, 0xd5, 0xa0, 0xae, 0xe9,
+	0x43, 0xd6, 0x86, 0xdb, 0xe0, 0xac, 0x67, 0xba, 0x04, 0x9d, 0xa9, 0x6a, 0x8d, 0x52, 0xf3, 0x93,
+	0x84, 0x61, 0xb1, 0x4e, 0x19, 0xbe, 0x22, 0xdc, 0xf1, 0x85, 0xd0, 0xbc, 0xee, 0xbb, 0x0e, 0x25,
+	0x6e, 0x40, 0xfb, 0xdc, 0xd3, 0xf2, 0x31, 0xb8, 0x2e, 0x76, 0xc2, 0x1e, 0x28, 0x99, 0xb6, 0x1d,
+	0x92, 0x28, 0x22, 0x11, 0x9a, 0xae, 0x4e, 0x37, 0x4a, 0xcd, 0xdd, 0x84, 0xe1, 0x11, 0x98, 0x32,
+	0x7c, 0x4d, 0x68, 0x67, 0x48, 0x4e, 0xb9, 0xaa, 0x8e, 0x64, 0xf7, 0x3d, 0xd3, 0x75, 0x2c, 0xee,
+	0x6b, 0x69, 0xc2, 0xee, 0xed, 0x41, 0xfd, 0x7c, 0x66, 0xa0, 0x8f, 0x74, 0x61, 0x17, 0x5c, 0xb0,
+	0x7c, 0x37, 0xe0, 0x2b, 0xc7, 0xf7, 0xd0, 0xd9, 0xaa, 0xd6, 0x58, 0xd8, 0xbc, 0xb4, 0xae, 0x72,
+	0x7c, 0x7b, 0x44, 0x36, 0x3f, 0x4d, 0x18, 0xce, 0x5b, 0xa7, 0x0c, 0xaf, 0x88, 0xa0, 0x72, 0x98,
+	0x4c, 0x74, 0x72, 0x50, 0x5f, 0x1c, 0x07, 0xf5, 0xfc, 0x56, 0x48, 0x40, 0xc9, 0x22, 0x21, 0x35,
+	0x44, 0x22, 0xcf, 0x89, 0x44, 0xde, 0xe5, 0xbf, 0x1d, 0x07, 0x1f, 0xca, 0x64, 0x5e, 0x95, 0xda,
+	0x19, 0x70, 0x4c, 0x42, 0x2f, 0x9f, 0xc0, 0xe9, 0x4a, 0x05, 0xee, 0x02, 0x30, 0x2a, 0x56, 0x34,
+	0x53, 0xd5, 0x1a, 0xb3, 0xcd, 0x9b, 0x09, 0xc3, 0x39, 0x34, 0x65, 0xf8, 0x92, 0xac, 0x12, 0x05,
+	0xa9, 0x43, 0x94, 0xc7, 0x30, 0x3d, 0xb7, 0x0f, 0xfe, 0xaa, 0x81, 0xd5, 0x68, 0xdf, 0x09, 0x8c,
+	0x21, 0xc6, 0xcb, 0xdb, 0x08, 0x89, 0xeb, 0x77, 0xcd, 0x4e, 0x84, 0xce, 0x0b, 0x67, 0x76, 0xc2,
+	0x30, 0xe2, 0x56, 0x5b, 0x39, 0x23, 0x3d, 0xb3, 0x49, 0x19, 0xfe, 0x9f, 0x70, 0x7d, 0x92, 0x81,
+	0x0a, 0xe4, 0xea, 0xa9, 0x16, 0xfa, 0x89, 0x1e, 0xe0, 0xef, 0x1a, 0x98, 0x57, 0x31, 0xdb, 0x46,
+	0xab, 0x8f, 0x66, 0x45, 0xc7, 0xfd, 0xf4, 0x4e, 0x1d, 0x97, 0x30, 0x3c, 0x37, 0x52, 0x6d, 0xf6,
+	0x53, 0x86, 0x1b, 0xc5, 0x1c, 0xda, 0xcd, 0xfe, 0xc9, 0x3d, 0xb7, 0x34, 0x61, 0xc6, 0x3b, 0x4e,
+	0x74, 0x59, 0x41, 0x16, 0x6e, 0x82, 0x99, 0xc0, 0x8c, 0x23, 0x62, 0xa3, 0x92, 0xc8, 0xe6, 0x6a,
+	0xc2, 0x70, 0x86, 0xa4, 0x0c, 0xcf, 0x09, 0x97, 0x72, 0x59, 0xd3, 0x33, 0x1c, 0x7e, 0x07, 0x16,
+	0xcd, 0x4e, 0xc7, 0x7f, 0x46, 0x6c, 0xc3, 0x23, 0xf4, 0x99, 0x1f, 0xee, 0x47, 0x08, 0x88, 0x96,
+	0xfa, 0x32, 0x61, 0xb8, 0x9c, 0x71, 0x0f, 0x33, 0x4a, 0xdd, 0x11, 0x45, 0xbc, 0x58, 0x68, 0xe8,
+	0x24, 0x52, 0x1f, 0x97, 0x83, 0xdf, 0x80, 0x65, 0x33, 0xa6, 0xbe, 0x61, 0x5a, 0x16, 0x09, 0xa8,
+	0xf1, 0xd4, 0xef, 0xd8, 0x24, 0x8c, 0xd0, 0x05, 0x11, 0xfe, 0xfb, 0x09, 0xc3, 0x4b, 0x9c, 0xbe,
+	0x25, 0xd8, 0xcf, 0x25, 0x99, 0x32, 0x7c, 0x59, 0x86, 0x30, 0xce, 0xd4, 0xf4, 0x49, 0x6b, 0xf8,
+	0x08, 0xcc, 0xbb, 0x66, 0xcf, 0x88, 0x88, 0x67, 0x1b, 0xfb, 0xad, 0x20, 0x42, 0x73, 0x55, 0xad,
+	0x71, 0xae, 0xf9, 0x1e, 0x6f, 0x4e, 0xd7, 0xec, 0xed, 0x10, 0xcf, 0xbe, 0xd7, 0x0a, 0xb8, 0xea,
+	0x92, 0x50, 0xcd, 0x61, 0xb5, 0xb7, 0x0c, 0x4f, 0x3b, 0x1e, 0xd5, 0xf3, 0x86, 0x43, 0xc1, 0x90,
+	0x58, 0x5d, 0x29, 0x38, 0x5f, 0x10, 0xd4, 0x89, 0xd5, 0x1d, 0x17, 0x1c, 0x62, 0x05, 0xc1, 0x21,
+	0x08, 0x3d, 0x50, 0x76, 0xda, 0x9e, 0x1f, 0x12, 0x5b, 0x9d, 0x7f, 0xa1, 0x3a, 0xdd, 0xb8, 0xb0,
+	0xb9, 0xb2, 0x2e, 0x1f, 0x90, 0xf5, 0x47, 0xd9, 0xdb, 0x22, 0xcf, 0xd4, 0xbc, 0xc1, 0x6b, 0x31,
+	0x61, 0x78, 0x21, 0xdb, 0x36, 0x4a, 0xcc, 0xb2, 0xac, 0xaa, 0x3c, 0x5c, 0xd3, 0xc7, 0xcc, 0xe0,
+	0x8f, 0x1a, 0x28, 0x07, 0xc4, 0xb3, 0x1d, 0xaf, 0xad, 0x1c, 0x96, 0x4f, 0x75, 0x78, 0x97, 0x3b,
+	0x3c, 0x64, 0x18, 0xdd, 0x21, 0x41, 0x48, 0x2c, 0x93, 0x12, 0x7b, 0x5b, 0x0a, 0x64, 0x9a, 0x09,
+	0xc3, 0xda, 0x0d, 0x75, 0x07, 0x05, 0x79, 0x2e, 0x57, 0x1a, 0x48, 0xd3, 0x17, 0x0a, 0x5c, 0x04,
+	0x7f, 0xd6, 0x40, 0x59, 0x66, 0xf3, 0xdb, 0x98, 0x44, 0xd4, 0xd8, 0x77, 0x5a, 0x68, 0x51, 0xe4,
+	0x33, 0x3a, 0x64, 0x78, 0xfe, 0x01, 0x4f, 0x93, 0x60, 0xee, 0x39, 0xcd, 0x84, 0xe1, 0x79, 0x37,
+	0x0f, 0xa8, 0x03, 0x17, 0xd0, 0x61, 0x92, 0x93, 0x83, 0xfa, 0x98, 0xf9, 0x38, 0xf0, 0x72, 0x50,
+	0x2f, 0x7a, 0xd0, 0x0b, 0x7c, 0x0b, 0x7e, 0x06, 0x4a, 0xb1, 0x47, 0xc3, 0x38, 0xa2, 0xc4, 0x46,
+	0x4b, 0xa2, 0x26, 0xab, 0xfc, 0x9d, 0x51, 0x60, 0xca, 0x70, 0x59, 0x44, 0xa0, 0x90, 0x9a, 0x3e,
+	0x62, 0xc5, 0xe9, 0xf8, 0x05, 0x47, 0x89, 0xd1, 0x8e, 0x1d, 0x23, 0xf0, 0x43, 0x8a, 0xe0, 0xe8,
+	0x74, 0xba, 0xa0, 0xbe, 0x78, 0xb2, 0xb5, 0xed, 0x87, 0x94, 0x9f, 0x2e, 0xcc, 0x03, 0xea, 0x74,
+	0x05, 0x34, 0x7f, 0xba, 0xa2, 0xf9, 0x38, 0xc0, 0x4f, 0x57, 0xf0, 0xa0, 0x0f, 0xf9, 0xd8, 0xe1,
+	0x4b, 0xf8, 0x3d, 0x28, 0x05, 0xa1, 0xdf, 0xeb, 0x1b, 0x71, 0xd8, 0x41, 0xcb, 0xe2, 0x4d, 0x69,
+	0xf1, 0xd9, 0x60, 0x9b, 0x83, 0x4f, 0xf4, 0xfb, 0xfc, 0x7d, 0x09, 0xb2, 0xef, 0x94, 0x61, 0x24,
+	0x7f, 0xdb, 0x0c, 0x28, 0x76, 0x3c, 0x9c, 0x84, 0xf9, 0x80, 0x30, 0x44, 0xf9, 0x70, 0x30, 0x54,
+	0xd5, 0x33, 0x34, 0xec, 0xc0, 0xe7, 0x1a, 0x80, 0x34, 0x34, 0xbd, 0x88, 0x27, 0xc6, 0x08, 0x42,
+	0x47, 0x8c, 0x46, 0xe8, 0xa2, 0xb8, 0x7d, 0xbe, 0xe6, 0xcd, 0xaf, 0xd8, 0xed, 0x8c, 0x4c, 0x19,
+	0xfe, 0xaf, 0x88, 0x63, 0x82, 0x29, 0x06, 0xb4, 0x76, 0x0a, 0xaf, 0x4f, 0xca, 0xc2, 0x5d, 0x50,
+	0xf6, 0x62, 0xd7, 0xb0, 0x7c, 0xcf, 0x23, 0xe2, 0x45, 0x88, 0xd0, 0x25, 0xf1, 0x43, 0x7d, 0xc0,
+	0xfb, 0xcc, 0x8b, 0xdd, 0xdb, 0x23, 0x26, 0x65, 0xf8, 0xa2, 0x1c, 0x5c, 0x0a, 0xb0, 0x6a, 0xee,
+	0x31, 0x73, 0xf8, 0x18, 0x2c, 0xe6, 0xef, 0xb8, 0xc0, 0xa4, 0x7b, 0x68, 0x45, 0xa4, 0xfb, 0xff,
+	0x5c, 0x7c, 0x74, 0x65, 0x6d, 0x9b, 0x74, 0x4f, 0x89, 0x17, 0xe1, 0x9a, 0x3e, 0x66, 0x07, 0x5b,
+	0x60, 0x29, 0x37, 0x20, 0x18, 0x1d, 0xd2, 0x25, 0x1d, 0x74, 0x59, 0xc4, 0xfc, 0x71, 0xc2, 0x70,
+	0x7e, 0x9e, 0xb8, 0xcf, 0xb9, 0xe3, 0xa6, 0x0f, 0x41, 0xa8, 0xb8, 0x27, 0xb6, 0xc0, 0xdf, 0x34,
+	0x70, 0x71, 0xf4, 0x82, 0x1b, 0x6a, 0x7a, 0x45, 0x48, 0xcc, 0x3d, 0x6b, 0xc3, 0xeb, 0x62, 0x4b,
+	0xd9, 0xdc, 0x1a, 0x9a, 0x34, 0x9f, 0x24, 0x0c, 0x2f, 0x3b, 0x93, 0xc4, 0x68, 0xca, 0x9c, 0xe4,
+	0xd4, 0xfb, 0x8d, 0x4e, 0x22, 0xf5, 0xe3, 0x24, 0xe1, 0x23, 0xb0, 0x20, 0x23, 0x31, 0x5c, 0xd3,
+	0x33, 0xdb, 0x24, 0x44, 0x57, 0x44, 0xb3, 0x36, 0x78, 0x53, 0x49, 0xe6, 0x81, 0x24, 0x54, 0x53,
+	0x15, 0xd0, 0x9a, 0x5e, 0xb4, 0x82, 0x5f, 0x81, 0x72, 0xc0, 0xaf, 0x47, 0xc7, 0xa3, 0x24, 0xec,
+	0x9a, 0x1d, 0x23, 0x42, 0xab, 0x22, 0xb5, 0x1b, 0x5c, 0x91, 0x53, 0x5b, 0x19, 0xb3, 0xa3, 0x14,
+	0x0b, 0xa8, 0x4a, 0x6a, 0xd1, 0x18, 0xee, 0x80, 0x05, 0x21, 0x4c, 0x1d, 0x97, 0xf8, 0x31, 0x35,
+	0x22, 0xb4, 0x26, 0x74, 0x6f, 0xf0, 0x11, 0x81, 0x33, 0x8f, 0x25, 0xc1, 0x65, 0xa1, 0x92, 0x1d,
+	0x82, 0x4a, 0xb5, 0x60, 0x0a, 0x7f, 0x38, 0x03, 0x16, 0x3c, 0xd2, 0xa3, 0xc6, 0xe8, 0x7f, 0xc2,
+	0x7f, 0xc4, 0xd4, 0xf2, 0xd7, 0xbb, 0xfe, 0x4f, 0x98, 0x7b, 0x48, 0x7a, 0x34, 0x3f, 0xc5, 0x78,
+	0xb9, 0x75, 0xca, 0xf0, 0xa6, 0xec, 0x83, 0x1c, 0x38, 0x3e, 0x77, 0x1e, 0x37, 0xcf, 0xac, 0x9d,
+	0xb2, 0x21, 0x3d, 0xa8, 0x17, 0x9c, 0x64, 0xff, 0x2d, 0x0a, 0x81, 0xc8, 0xc9, 0x27, 0x67, 0x65,
+	0xc3, 0x1d, 0x50, 0x8e, 0x83, 0x76, 0x68, 0xda, 0x44, 0x95, 0xc0, 0x55, 0x51, 0x02, 0xa2, 0xc5,
+	0x32, 0x6a, 0x54, 0x03, 0xb2, 0xc5, 0x8a, 0x70, 0x4d, 0x1f, 0xb3, 0x6b, 0xde, 0x7b, 0xfd, 0xa6,
+	0x32, 0x35, 0x78, 0x53, 0x99, 0x7a, 0x7d, 0x58, 0xd1, 0x06, 0x87, 0x15, 0xed, 0xc5, 0x51, 0x65,
+	0xea, 0xd5, 0x51, 0x45, 0x1b, 0x1c, 0x55, 0xa6, 0xfe, 0x3c, 0xaa, 0x4c, 0xed, 0x5e, 0xfb, 0x17,
+	0x79, 0x95, 0x95, 0xd5, 0x9a, 0x11, 0xf9, 0xfd, 0xf0, 0x9f, 0x01, 0x00, 0x90, 0x09, 0x6b, 0xe2,
+	0x7a, 0x0e, 0x00, 0x00,
 }
 
 func (m *DeviceConfiguration) Marshal() (dAtA []byte, err error) {
@@ -176,6 +241,106 @@ func (m *DeviceConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.UpgradeManager {
+		i--
+		if m.UpgradeManager {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
+	}
+	{
+		size := m.NextDeviceID.ProtoSize()
+		i -= size
+		if _, err := m.NextDeviceID.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xe2
+	if m.PingTimeoutS != 0 {
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.PingTimeoutS))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.PingIntervalS != 0 {
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.PingIntervalS))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.ConfigManager {
+		i--
+		if m.ConfigManager {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.IntroducerAuthority != 0 {
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.IntroducerAuthority))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.CompressionLevel != 0 {
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.CompressionLevel))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	if len(m.AutoAcceptPath) > 0 {
+		i -= len(m.AutoAcceptPath)
+		copy(dAtA[i:], m.AutoAcceptPath)
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(len(m.AutoAcceptPath)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb2
+	}
+	if m.NumConnections != 0 {
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.NumConnections))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if len(m.TransportPriority) > 0 {
+		for iNdEx := len(m.TransportPriority) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TransportPriority[iNdEx])
+			copy(dAtA[i:], m.TransportPriority[iNdEx])
+			i = encodeVarintDeviceconfiguration(dAtA, i, uint64(len(m.TransportPriority[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if len(m.ProxyURL) > 0 {
+		i -= len(m.ProxyURL)
+		copy(dAtA[i:], m.ProxyURL)
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(len(m.ProxyURL)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
 	if m.RemoteGUIPort != 0 {
 		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.RemoteGUIPort))
 		i--
@@ -423,6 +588,43 @@ func (m *DeviceConfiguration) ProtoSize() (n int) {
 	if m.RemoteGUIPort != 0 {
 		n += 2 + sovDeviceconfiguration(uint64(m.RemoteGUIPort))
 	}
+	l = len(m.ProxyURL)
+	if l > 0 {
+		n += 2 + l + sovDeviceconfiguration(uint64(l))
+	}
+	if len(m.TransportPriority) > 0 {
+		for _, s := range m.TransportPriority {
+			l = len(s)
+			n += 2 + l + sovDeviceconfiguration(uint64(l))
+		}
+	}
+	if m.NumConnections != 0 {
+		n += 2 + sovDeviceconfiguration(uint64(m.NumConnections))
+	}
+	l = len(m.AutoAcceptPath)
+	if l > 0 {
+		n += 2 + l + sovDeviceconfiguration(uint64(l))
+	}
+	if m.CompressionLevel != 0 {
+		n += 2 + sovDeviceconfiguration(uint64(m.CompressionLevel))
+	}
+	if m.IntroducerAuthority != 0 {
+		n += 2 + sovDeviceconfiguration(uint64(m.IntroducerAuthority))
+	}
+	if m.ConfigManager {
+		n += 3
+	}
+	if m.PingIntervalS != 0 {
+		n += 2 + sovDeviceconfiguration(uint64(m.PingIntervalS))
+	}
+	if m.PingTimeoutS != 0 {
+		n += 2 + sovDeviceconfiguration(uint64(m.PingTimeoutS))
+	}
+	l = m.NextDeviceID.ProtoSize()
+	n += 2 + l + sovDeviceconfiguration(uint64(l))
+	if m.UpgradeManager {
+		n += 3
+	}
 	return n
 }
 
@@ -918,6 +1120,270 @@ func (m *DeviceConfiguration) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyURL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProxyURL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransportPriority", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TransportPriority = append(m.TransportPriority, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumConnections", wireType)
+			}
+			m.NumConnections = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumConnections |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AutoAcceptPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AutoAcceptPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressionLevel", wireType)
+			}
+			m.CompressionLevel = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompressionLevel |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntroducerAuthority", wireType)
+			}
+			m.IntroducerAuthority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IntroducerAuthority |= IntroducerAuthority(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigManager", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConfigManager = bool(v != 0)
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PingIntervalS", wireType)
+			}
+			m.PingIntervalS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PingIntervalS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PingTimeoutS", wireType)
+			}
+			m.PingTimeoutS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PingTimeoutS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextDeviceID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.NextDeviceID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpgradeManager", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UpgradeManager = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeviceconfiguration(dAtA[iNdEx:])
@@ -0,0 +1,126 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+)
+
+func (g DeviceGroupConfiguration) Copy() DeviceGroupConfiguration {
+	g.Devices = append([]protocol.DeviceID(nil), g.Devices...)
+	return g
+}
+
+// prepareDeviceGroups makes sure the groups have unique IDs and only
+// contain existing devices, and returns them by ID.
+func (cfg *Configuration) prepareDeviceGroups(existingDevices map[protocol.DeviceID]bool) map[string]DeviceGroupConfiguration {
+	groups := make(map[string]DeviceGroupConfiguration, len(cfg.DeviceGroups))
+	for i := range cfg.DeviceGroups {
+		group := &cfg.DeviceGroups[i]
+		group.ID = strings.TrimSpace(group.ID)
+		if _, ok := groups[group.ID]; ok || group.ID == "" {
+			group.ID = rand.String(8)
+		}
+
+		seen := make(map[protocol.DeviceID]struct{}, len(group.Devices))
+		devices := group.Devices[:0]
+		for _, id := range group.Devices {
+			if _, ok := seen[id]; ok || !existingDevices[id] {
+				continue
+			}
+			seen[id] = struct{}{}
+			devices = append(devices, id)
+		}
+		sort.Slice(devices, func(a, b int) bool {
+			return devices[a].Compare(devices[b]) == -1
+		})
+		group.Devices = devices
+
+		groups[group.ID] = *group
+	}
+	sort.Slice(cfg.DeviceGroups, func(a, b int) bool {
+		return cfg.DeviceGroups[a].ID < cfg.DeviceGroups[b].ID
+	})
+	return groups
+}
+
+// applyDeviceGroups shares the folder with the current members of its
+// device groups, and unshares it from devices it was shared with only
+// because they were in one of those groups before.
+func (f *FolderConfiguration) applyDeviceGroups(groups map[string]DeviceGroupConfiguration) {
+	// The group each member device is shared with through, the first one
+	// listed if there are several.
+	members := make(map[protocol.DeviceID]string)
+	seen := make(map[string]struct{}, len(f.DeviceGroups))
+	folderGroups := f.DeviceGroups[:0]
+	for _, id := range f.DeviceGroups {
+		group, ok := groups[id]
+		if _, dup := seen[id]; !ok || dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		folderGroups = append(folderGroups, id)
+		for _, dev := range group.Devices {
+			if _, ok := members[dev]; !ok {
+				members[dev] = id
+			}
+		}
+	}
+	f.DeviceGroups = folderGroups
+
+	present := make(map[protocol.DeviceID]struct{}, len(f.Devices))
+	devices := f.Devices[:0]
+	for _, dev := range f.Devices {
+		if dev.DeviceGroup != "" {
+			group, ok := members[dev.DeviceID]
+			if !ok {
+				continue
+			}
+			dev.DeviceGroup = group
+		}
+		present[dev.DeviceID] = struct{}{}
+		devices = append(devices, dev)
+	}
+	for dev, group := range members {
+		if _, ok := present[dev]; !ok {
+			devices = append(devices, FolderDeviceConfiguration{
+				DeviceID:    dev,
+				DeviceGroup: group,
+			})
+		}
+	}
+	f.Devices = devices
+}
+
+func (cfg *Configuration) DeviceGroup(id string) (DeviceGroupConfiguration, int, bool) {
+	for i, group := range cfg.DeviceGroups {
+		if group.ID == id {
+			return group, i, true
+		}
+	}
+	return DeviceGroupConfiguration{}, 0, false
+}
+
+func (cfg *Configuration) SetDeviceGroup(group DeviceGroupConfiguration) {
+	if _, i, ok := cfg.DeviceGroup(group.ID); ok {
+		cfg.DeviceGroups[i] = group
+	} else {
+		cfg.DeviceGroups = append(cfg.DeviceGroups, group)
+	}
+}
+
+// RemoveDeviceGroup removes the group, and thereby unshares the folders
+// shared with it from its members.
+func (cfg *Configuration) RemoveDeviceGroup(id string) {
+	if _, i, ok := cfg.DeviceGroup(id); ok {
+		cfg.DeviceGroups = append(cfg.DeviceGroups[:i], cfg.DeviceGroups[i+1:]...)
+	}
+}
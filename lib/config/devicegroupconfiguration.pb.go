@@ -0,0 +1,425 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/devicegroupconfiguration.proto
+
+package config
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_syncthing_syncthing_lib_protocol "github.com/syncthing/syncthing/lib/protocol"
+	_ "github.com/syncthing/syncthing/proto/ext"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type DeviceGroupConfiguration struct {
+	ID   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id" xml:"id,attr"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name" xml:"name,attr"`
+	// Folders shared with the group are shared with each of these devices.
+	Devices []github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,3,rep,name=devices,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"devices" xml:"device"`
+}
+
+func (m *DeviceGroupConfiguration) Reset()         { *m = DeviceGroupConfiguration{} }
+func (m *DeviceGroupConfiguration) String() string { return proto.CompactTextString(m) }
+func (*DeviceGroupConfiguration) ProtoMessage()    {}
+func (*DeviceGroupConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_802fd62bd0761da4, []int{0}
+}
+func (m *DeviceGroupConfiguration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeviceGroupConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeviceGroupConfiguration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeviceGroupConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceGroupConfiguration.Merge(m, src)
+}
+func (m *DeviceGroupConfiguration) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *DeviceGroupConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceGroupConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceGroupConfiguration proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*DeviceGroupConfiguration)(nil), "config.DeviceGroupConfiguration")
+}
+
+func init() {
+	proto.RegisterFile("lib/config/devicegroupconfiguration.proto", fileDescriptor_802fd62bd0761da4)
+}
+
+var fileDescriptor_802fd62bd0761da4 = []byte{
+	// 314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x50, 0x31, 0x4b, 0xc3, 0x40,
+	0x18, 0xbd, 0xbb, 0x4a, 0x4b, 0x43, 0x45, 0xc8, 0x14, 0x1c, 0xee, 0x4a, 0xc9, 0xd0, 0x82, 0xb4,
+	0x83, 0x9d, 0xc4, 0xa9, 0x06, 0xa4, 0xb8, 0x75, 0x74, 0x6b, 0x72, 0x31, 0x3d, 0x48, 0x72, 0x25,
+	0xbd, 0x48, 0x9d, 0x5c, 0x1d, 0xc5, 0x3f, 0xa0, 0x3f, 0x27, 0x5b, 0x33, 0x8a, 0xc3, 0x41, 0x93,
+	0x2d, 0x63, 0x7e, 0x81, 0xe4, 0xce, 0x96, 0x8e, 0x6e, 0xdf, 0x7b, 0x7c, 0xef, 0x7d, 0xef, 0x7d,
+	0xc6, 0x28, 0x64, 0xee, 0xc4, 0xe3, 0xf1, 0x13, 0x0b, 0x26, 0xd4, 0x7f, 0x66, 0x9e, 0x1f, 0x24,
+	0x3c, 0x5d, 0x6b, 0x26, 0x4d, 0x96, 0x82, 0xf1, 0x78, 0xbc, 0x4e, 0xb8, 0xe0, 0x66, 0x5b, 0x93,
+	0x97, 0x5d, 0x7f, 0x2b, 0x34, 0x35, 0xf8, 0x44, 0x86, 0xe5, 0x28, 0xd5, 0x7d, 0xa3, 0xba, 0x3b,
+	0x55, 0x99, 0x8e, 0x81, 0x18, 0xb5, 0x60, 0x1f, 0x0e, 0xbb, 0xb3, 0x69, 0x21, 0x09, 0x9a, 0x3b,
+	0x95, 0x24, 0x88, 0xd1, 0x5a, 0x92, 0xf3, 0x6d, 0x14, 0xde, 0x0c, 0x18, 0xbd, 0x5a, 0x0a, 0x91,
+	0x0c, 0xaa, 0x9d, 0xdd, 0xf9, 0x9b, 0xeb, 0x9d, 0x8d, 0x18, 0xfd, 0xc8, 0x6d, 0x34, 0x77, 0x16,
+	0x88, 0x51, 0xf3, 0xd6, 0x38, 0x8b, 0x97, 0x91, 0x6f, 0x21, 0xe5, 0x33, 0xac, 0x24, 0x51, 0xb8,
+	0x96, 0xe4, 0x42, 0x79, 0x34, 0xe0, 0xe8, 0xd2, 0x3d, 0xa2, 0x85, 0xda, 0x32, 0x5f, 0x8d, 0x8e,
+	0x6e, 0xb5, 0xb1, 0x5a, 0xfd, 0xd6, 0xb0, 0x37, 0xf3, 0x33, 0x49, 0xc0, 0x8f, 0x24, 0xd3, 0x80,
+	0x89, 0x55, 0xea, 0x8e, 0x3d, 0x1e, 0x4d, 0x36, 0x2f, 0xb1, 0x27, 0x56, 0x2c, 0x0e, 0x4e, 0xa6,
+	0xe6, 0x2d, 0xaa, 0xa3, 0xc7, 0xc3, 0xb1, 0xae, 0xa8, 0xe2, 0x1f, 0xec, 0x6a, 0x49, 0x7a, 0xea,
+	0xbe, 0xc6, 0xcd, 0xf1, 0xb6, 0x1e, 0xdf, 0x72, 0x1b, 0x2e, 0x0e, 0x6b, 0xb3, 0x87, 0x6c, 0x8f,
+	0x41, 0xbe, 0xc7, 0x20, 0x2b, 0x30, 0xcc, 0x0b, 0x0c, 0xdf, 0x4b, 0x0c, 0xbe, 0x4a, 0x0c, 0xf3,
+	0x12, 0x83, 0xef, 0x12, 0x83, 0xc7, 0xd1, 0x3f, 0x92, 0xe8, 0xcf, 0xbb, 0x6d, 0x95, 0xe8, 0xfa,
+	0x77, 0x00, 0x6c, 0x33, 0xc5, 0x3d, 0xb5, 0x01, 0x00, 0x00,
+}
+
+func (m *DeviceGroupConfiguration) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeviceGroupConfiguration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeviceGroupConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Devices) > 0 {
+		for iNdEx := len(m.Devices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Devices[iNdEx].ProtoSize()
+				i -= size
+				if _, err := m.Devices[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintDevicegroupconfiguration(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDevicegroupconfiguration(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintDevicegroupconfiguration(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintDevicegroupconfiguration(dAtA []byte, offset int, v uint64) int {
+	offset -= sovDevicegroupconfiguration(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *DeviceGroupConfiguration) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovDevicegroupconfiguration(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDevicegroupconfiguration(uint64(l))
+	}
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.ProtoSize()
+			n += 1 + l + sovDevicegroupconfiguration(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovDevicegroupconfiguration(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozDevicegroupconfiguration(x uint64) (n int) {
+	return sovDevicegroupconfiguration(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DeviceGroupConfiguration) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevicegroupconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceGroupConfiguration: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceGroupConfiguration: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevicegroupconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevicegroupconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDevicegroupconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevicegroupconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevicegroupconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDevicegroupconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevicegroupconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevicegroupconfiguration
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDevicegroupconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_syncthing_syncthing_lib_protocol.DeviceID
+			m.Devices = append(m.Devices, v)
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDevicegroupconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDevicegroupconfiguration
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDevicegroupconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipDevicegroupconfiguration(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowDevicegroupconfiguration
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDevicegroupconfiguration
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDevicegroupconfiguration
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthDevicegroupconfiguration
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupDevicegroupconfiguration
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthDevicegroupconfiguration
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthDevicegroupconfiguration        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowDevicegroupconfiguration          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupDevicegroupconfiguration = fmt.Errorf("proto: unexpected end of group")
+)
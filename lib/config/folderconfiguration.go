@@ -9,6 +9,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"path"
 	"runtime"
 	"sort"
 	"strings"
@@ -39,6 +40,11 @@ func (f FolderConfiguration) Copy() FolderConfiguration {
 	c.Devices = make([]FolderDeviceConfiguration, len(f.Devices))
 	copy(c.Devices, f.Devices)
 	c.Versioning = f.Versioning.Copy()
+	c.Schedule = append([]string(nil), f.Schedule...)
+	c.DeviceGroups = append([]string(nil), f.DeviceGroups...)
+	c.Hooks = append([]FolderHookConfiguration(nil), f.Hooks...)
+	c.SymlinkRewrites = append([]SymlinkRewrite(nil), f.SymlinkRewrites...)
+	c.PullOrderPatterns = append([]string(nil), f.PullOrderPatterns...)
 	return c
 }
 
@@ -50,6 +56,9 @@ func (f FolderConfiguration) Filesystem() fs.Filesystem {
 		opts = append(opts, fs.WithJunctionsAsDirs())
 	}
 	filesystem := fs.NewFilesystem(f.FilesystemType, f.Path, opts...)
+	if f.LocalEncryptionPassword != "" {
+		filesystem = fs.NewEncryptedFilesystem(filesystem, fs.LocalEncryptionKey(f.ID, f.LocalEncryptionPassword))
+	}
 	if !f.CaseSensitiveFS {
 		filesystem = fs.NewCaseFilesystem(filesystem, opts...)
 	}
@@ -216,6 +225,45 @@ func (f *FolderConfiguration) prepare(myID protocol.DeviceID, existingDevices ma
 	if f.Type == FolderTypeReceiveEncrypted {
 		f.IgnorePerms = true
 	}
+
+	if len(f.Schedule) > 0 {
+		var schedule []string
+		for _, window := range util.UniqueTrimmedStrings(f.Schedule) {
+			if _, err := ParseSchedule([]string{window}); err != nil {
+				l.Warnf("Folder %s: ignoring invalid %v", f.Description(), err)
+				continue
+			}
+			schedule = append(schedule, window)
+		}
+		f.Schedule = schedule
+	}
+
+	if f.RescanCron != "" {
+		if _, err := ParseCron(f.RescanCron); err != nil {
+			l.Warnf("Folder %s: ignoring invalid rescan cron expression %q: %v", f.Description(), f.RescanCron, err)
+			f.RescanCron = ""
+		}
+	}
+	if f.RescanJitterS < 0 {
+		f.RescanJitterS = 0
+	}
+	if f.MassChangeThresholdPct < 0 {
+		f.MassChangeThresholdPct = 0
+	} else if f.MassChangeThresholdPct > 100 {
+		f.MassChangeThresholdPct = 100
+	}
+
+	if len(f.PullOrderPatterns) > 0 {
+		var patterns []string
+		for _, pattern := range util.UniqueTrimmedStrings(f.PullOrderPatterns) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				l.Warnf("Folder %s: ignoring invalid pull order pattern %q: %v", f.Description(), pattern, err)
+				continue
+			}
+			patterns = append(patterns, pattern)
+		}
+		f.PullOrderPatterns = patterns
+	}
 }
 
 // RequiresRestartOnly returns a copy with only the attributes that require
@@ -250,6 +298,17 @@ func (f *FolderConfiguration) SharedWith(device protocol.DeviceID) bool {
 	return ok
 }
 
+// HasEncryptedDevices returns whether the folder is shared encrypted with
+// any untrusted device.
+func (f *FolderConfiguration) HasEncryptedDevices() bool {
+	for _, dev := range f.Devices {
+		if dev.EncryptionPassword != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *FolderConfiguration) CheckAvailableSpace(req uint64) error {
 	val := f.MinDiskFree.BaseValue()
 	if val <= 0 {
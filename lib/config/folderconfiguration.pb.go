@@ -9,6 +9,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	fs "github.com/syncthing/syncthing/lib/fs"
 	github_com_syncthing_syncthing_lib_protocol "github.com/syncthing/syncthing/lib/protocol"
+	protocol "github.com/syncthing/syncthing/lib/protocol"
 	_ "github.com/syncthing/syncthing/proto/ext"
 	io "io"
 	math "math"
@@ -30,6 +31,9 @@ type FolderDeviceConfiguration struct {
 	DeviceID           github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"deviceID" xml:"id,attr"`
 	IntroducedBy       github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,2,opt,name=introduced_by,json=introducedBy,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"introducedBy" xml:"introducedBy,attr"`
 	EncryptionPassword string                                               `protobuf:"bytes,3,opt,name=encryption_password,json=encryptionPassword,proto3" json:"encryptionPassword" xml:"encryptionPassword"`
+	// Set when the folder is shared with the device because of its
+	// membership of this device group, rather than directly.
+	DeviceGroup string `protobuf:"bytes,4,opt,name=device_group,json=deviceGroup,proto3" json:"deviceGroup" xml:"deviceGroup,attr,omitempty"`
 }
 
 func (m *FolderDeviceConfiguration) Reset()         { *m = FolderDeviceConfiguration{} }
@@ -82,7 +86,7 @@ type FolderConfiguration struct {
 	Copiers                 int                         `protobuf:"varint,14,opt,name=copiers,proto3,casttype=int" json:"copiers" xml:"copiers"`
 	PullerMaxPendingKiB     int                         `protobuf:"varint,15,opt,name=puller_max_pending_kib,json=pullerMaxPendingKib,proto3,casttype=int" json:"pullerMaxPendingKiB" xml:"pullerMaxPendingKiB"`
 	Hashers                 int                         `protobuf:"varint,16,opt,name=hashers,proto3,casttype=int" json:"hashers" xml:"hashers"`
-	Order                   PullOrder                   `protobuf:"varint,17,opt,name=order,proto3,enum=config.PullOrder" json:"order" xml:"order"`
+	Order                   PullOrder                   `protobuf:"varint,17,opt,name=order,proto3,enum=config.PullOrder" json:"order" xml:"order" restart:"false"`
 	IgnoreDelete            bool                        `protobuf:"varint,18,opt,name=ignore_delete,json=ignoreDelete,proto3" json:"ignoreDelete" xml:"ignoreDelete"`
 	ScanProgressIntervalS   int                         `protobuf:"varint,19,opt,name=scan_progress_interval_s,json=scanProgressIntervalS,proto3,casttype=int" json:"scanProgressIntervalS" xml:"scanProgressIntervalS"`
 	PullerPauseS            int                         `protobuf:"varint,20,opt,name=puller_pause_s,json=pullerPauseS,proto3,casttype=int" json:"pullerPauseS" xml:"pullerPauseS"`
@@ -100,6 +104,91 @@ type FolderConfiguration struct {
 	CopyRangeMethod         fs.CopyRangeMethod          `protobuf:"varint,32,opt,name=copy_range_method,json=copyRangeMethod,proto3,enum=fs.CopyRangeMethod" json:"copyRangeMethod" xml:"copyRangeMethod" default:"standard"`
 	CaseSensitiveFS         bool                        `protobuf:"varint,33,opt,name=case_sensitive_fs,json=caseSensitiveFs,proto3" json:"caseSensitiveFS" xml:"caseSensitiveFS"`
 	JunctionsAsDirs         bool                        `protobuf:"varint,34,opt,name=follow_junctions,json=followJunctions,proto3" json:"junctionsAsDirs" xml:"junctionsAsDirs"`
+	// Trust blocks that the serving device verified against the requested
+	// hash before sending them, instead of hashing them again locally.
+	// Never applies to untrusted devices.
+	RelaxedVerification bool `protobuf:"varint,35,opt,name=relaxed_verification,json=relaxedVerification,proto3" json:"relaxedVerification" xml:"relaxedVerification"`
+	// Time windows, such as "Mon-Fri 22:00-06:00", outside of which the
+	// folder is neither scanned nor synced. Empty means always.
+	Schedule []string `protobuf:"bytes,36,rep,name=schedule,proto3" json:"schedule" xml:"schedule"`
+	// How to resolve a local file being changed in conflict with a remote
+	// one. With the prefer-device policy, changes made by the preferred
+	// device win.
+	ConflictPolicy          ConflictPolicy                                       `protobuf:"varint,37,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=config.ConflictPolicy" json:"conflictPolicy" xml:"conflictPolicy"`
+	ConflictPreferredDevice github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,38,opt,name=conflict_preferred_device,json=conflictPreferredDevice,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"conflictPreferredDevice" xml:"conflictPreferredDevice"`
+	// Sync-conflict copies older than this are removed. Zero keeps them
+	// indefinitely.
+	ConflictRetentionDays int `protobuf:"varint,39,opt,name=conflict_retention_days,json=conflictRetentionDays,proto3,casttype=int" json:"conflictRetentionDays" xml:"conflictRetentionDays"`
+	// Deleted items are forgotten from the index this long after the
+	// deletion, once all devices sharing the folder have it. Zero keeps them
+	// indefinitely.
+	TombstoneRetentionDays int `protobuf:"varint,56,opt,name=tombstone_retention_days,json=tombstoneRetentionDays,proto3,casttype=int" json:"tombstoneRetentionDays" xml:"tombstoneRetentionDays"`
+	// Split files into blocks at content defined boundaries, so that
+	// inserted or removed data only changes the blocks around it. Not used
+	// when the folder is shared with untrusted devices.
+	ContentDefinedBlocks bool `protobuf:"varint,40,opt,name=content_defined_blocks,json=contentDefinedBlocks,proto3" json:"contentDefinedBlocks" xml:"contentDefinedBlocks"`
+	// The hash function for the blocks of new and changed files. Files
+	// hashed with anything but SHA-256 are not synced to devices that don't
+	// support it.
+	BlockHashAlgorithm protocol.BlockHashAlgorithm `protobuf:"varint,57,opt,name=block_hash_algorithm,json=blockHashAlgorithm,proto3,enum=protocol.BlockHashAlgorithm" json:"blockHashAlgorithm" xml:"blockHashAlgorithm"`
+	// The folder is shared with all current members of these device groups.
+	DeviceGroups []string `protobuf:"bytes,41,rep,name=device_groups,json=deviceGroups,proto3" json:"deviceGroups" xml:"deviceGroup"`
+	// External commands to run on events in the folder.
+	Hooks []FolderHookConfiguration `protobuf:"bytes,42,rep,name=hooks,proto3" json:"hooks" xml:"hook"`
+	// On case insensitive filesystems, store a file whose name differs only
+	// in case from that of another item under a conflict name, instead of
+	// failing to sync it.
+	RenameCaseCollisions bool `protobuf:"varint,43,opt,name=rename_case_collisions,json=renameCaseCollisions,proto3" json:"renameCaseCollisions" xml:"renameCaseCollisions"`
+	// Sync extended attributes, including POSIX ACLs, and alternate data
+	// streams on Windows, with devices that support it.
+	SyncXattrs bool `protobuf:"varint,44,opt,name=sync_xattrs,json=syncXattrs,proto3" json:"syncXattrs" xml:"syncXattrs"`
+	// Sync the owner and group of items with devices that support it. They
+	// are applied by name where the name exists locally, otherwise by ID,
+	// which requires running with the privileges to do so.
+	SyncOwnership bool `protobuf:"varint,55,opt,name=sync_ownership,json=syncOwnership,proto3" json:"syncOwnership" xml:"syncOwnership"`
+	// Whether symlinks are synced as such, synced as the items they point
+	// to, or not synced at all. Symlinks from other devices are only
+	// created with the sync policy.
+	SymlinkPolicy SymlinkPolicy `protobuf:"varint,45,opt,name=symlink_policy,json=symlinkPolicy,proto3,enum=config.SymlinkPolicy" json:"symlinkPolicy" xml:"symlinkPolicy"`
+	// Applied to absolute symlink targets, the first that matches is used.
+	SymlinkRewrites []SymlinkRewrite `protobuf:"bytes,46,rep,name=symlink_rewrites,json=symlinkRewrites,proto3" json:"symlinkRewrites" xml:"symlinkRewrite"`
+	// Relative to the other folders. Folders with a higher priority get a
+	// larger share of the hashers when their number isn't set explicitly.
+	Priority int `protobuf:"varint,47,opt,name=priority,proto3,casttype=int" json:"priority" xml:"priority"`
+	// Stores the folder's names and contents encrypted on local disk with
+	// a key derived from this password. Changing it makes the existing
+	// data unreadable.
+	LocalEncryptionPassword string `protobuf:"bytes,48,opt,name=local_encryption_password,json=localEncryptionPassword,proto3" json:"localEncryptionPassword" xml:"localEncryptionPassword"`
+	// Keeps the files pulled from other devices in their temporary files
+	// until everything that's needed has been transferred, then moves them
+	// into place and performs the deletions all at once. Consumers of the
+	// folder never see half of a set of changes.
+	StagedPull bool `protobuf:"varint,49,opt,name=staged_pull,json=stagedPull,proto3" json:"stagedPull" xml:"stagedPull"`
+	// Files matching these glob patterns are pulled first, in the order of
+	// the patterns, and otherwise in the pull order. Patterns without a
+	// slash match the file name, others the path within the folder.
+	PullOrderPatterns []string `protobuf:"bytes,50,rep,name=pull_order_patterns,json=pullOrderPatterns,proto3" json:"pullOrderPatterns" xml:"pullOrderPattern" restart:"false"`
+	// The folder is on storage that comes and goes, like a USB drive. It's
+	// paused while the folder marker is missing instead of failing, and
+	// resumed once the marker is back.
+	RemovableStorage bool `protobuf:"varint,51,opt,name=removable_storage,json=removableStorage,proto3" json:"removableStorage" xml:"removableStorage" restart:"false"`
+	// Limit how fast files are read while scanning, in KiB and read
+	// operations per second, zero meaning no limit. With scan_low_priority,
+	// hashing runs at the lowest CPU and I/O priority, where the platform
+	// allows this per thread.
+	ScanMaxKbps     int  `protobuf:"varint,52,opt,name=scan_max_kbps,json=scanMaxKbps,proto3,casttype=int" json:"scanMaxKbps" xml:"scanMaxKbps"`
+	ScanMaxIOPS     int  `protobuf:"varint,53,opt,name=scan_max_iops,json=scanMaxIops,proto3,casttype=int" json:"scanMaxIOPS" xml:"scanMaxIOPS"`
+	ScanLowPriority bool `protobuf:"varint,54,opt,name=scan_low_priority,json=scanLowPriority,proto3" json:"scanLowPriority" xml:"scanLowPriority"`
+	// A cron expression, such as "0 3 * * Sun", for the times of full
+	// rescans instead of every rescan_interval_s. Each rescan starts at a
+	// random point up to rescan_jitter_s after the time, so that devices
+	// with the same schedule don't all rescan at once.
+	RescanCron    string `protobuf:"bytes,58,opt,name=rescan_cron,json=rescanCron,proto3" json:"rescanCron" xml:"rescanCron"`
+	RescanJitterS int    `protobuf:"varint,59,opt,name=rescan_jitter_s,json=rescanJitterS,proto3,casttype=int" json:"rescanJitterS" xml:"rescanJitterS" default:"300"`
+	// Hold back incoming changes that would delete or overwrite more than
+	// this percentage of the local files at once, until confirmed. Zero
+	// disables the check.
+	MassChangeThresholdPct int `protobuf:"varint,60,opt,name=mass_change_threshold_pct,json=massChangeThresholdPct,proto3,casttype=int" json:"massChangeThresholdPct" xml:"massChangeThresholdPct"`
 	// Legacy deprecated
 	DeprecatedReadOnly       bool    `protobuf:"varint,9000,opt,name=read_only,json=readOnly,proto3" json:"-" xml:"ro,attr,omitempty"`                       // Deprecated: Do not use.
 	DeprecatedMinDiskFreePct float64 `protobuf:"fixed64,9001,opt,name=min_disk_free_pct,json=minDiskFreePct,proto3" json:"-" xml:"minDiskFreePct,omitempty"` // Deprecated: Do not use.
@@ -149,135 +238,196 @@ func init() {
 }
 
 var fileDescriptor_44a9785876ed3afa = []byte{
-	// 2043 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcd, 0x6f, 0x24, 0x47,
-	0x15, 0x77, 0x7b, 0xbf, 0xec, 0xf2, 0x77, 0x79, 0xbd, 0xdb, 0xf1, 0x26, 0x53, 0x93, 0x66, 0x36,
-	0x38, 0x51, 0xe2, 0xdd, 0x75, 0x10, 0x12, 0x2b, 0x16, 0xc8, 0xd8, 0xb1, 0x58, 0x16, 0x67, 0x47,
-	0xed, 0x85, 0x15, 0x01, 0xa9, 0xe9, 0xe9, 0xae, 0x99, 0xa9, 0xb8, 0xbf, 0xa8, 0xea, 0x59, 0x7b,
-	0xf6, 0x10, 0x2d, 0x17, 0x04, 0x22, 0x07, 0x64, 0x0e, 0xdc, 0x50, 0x24, 0x10, 0x82, 0xfc, 0x03,
-	0x48, 0xfc, 0x05, 0x7b, 0x41, 0x9e, 0x13, 0x42, 0x1c, 0x4a, 0x8a, 0xf7, 0x36, 0xc7, 0x3e, 0xfa,
-	0x84, 0xaa, 0xaa, 0xbb, 0xa7, 0xbb, 0x67, 0x22, 0x21, 0x71, 0x9b, 0xfa, 0xfd, 0x5e, 0xbd, 0xf7,
-	0xeb, 0x57, 0xaf, 0x5e, 0xbf, 0x1e, 0xd0, 0xf0, 0x48, 0xfb, 0x8e, 0x13, 0x06, 0x1d, 0xd2, 0xbd,
-	0xd3, 0x09, 0x3d, 0x17, 0x53, 0xb5, 0xe8, 0x53, 0x3b, 0x26, 0x61, 0xb0, 0x1d, 0xd1, 0x30, 0x0e,
-	0xe1, 0x55, 0x05, 0x6e, 0xde, 0x9a, 0xb0, 0x8e, 0x07, 0x11, 0x56, 0x46, 0x9b, 0x1b, 0x05, 0x92,
-	0x91, 0xe7, 0x19, 0xbc, 0x59, 0x80, 0xa3, 0xbe, 0xe7, 0x85, 0xd4, 0xc5, 0x34, 0xe5, 0xb6, 0x0a,
-	0xdc, 0x33, 0x4c, 0x19, 0x09, 0x03, 0x12, 0x74, 0xa7, 0x28, 0xd8, 0x44, 0x05, 0xcb, 0xb6, 0x17,
-	0x3a, 0x47, 0x55, 0x57, 0x50, 0x18, 0x74, 0xd8, 0x1d, 0x21, 0x88, 0xa5, 0xd8, 0xeb, 0x29, 0xe6,
-	0x84, 0xd1, 0x80, 0xda, 0x41, 0x17, 0xfb, 0x38, 0xee, 0x85, 0x6e, 0xca, 0xce, 0xe3, 0x93, 0x58,
-	0xfd, 0x34, 0xfe, 0x75, 0x09, 0xbc, 0xb6, 0x2f, 0x9f, 0x67, 0x0f, 0x3f, 0x23, 0x0e, 0xde, 0x2d,
-	0x2a, 0x80, 0x5f, 0x68, 0x60, 0xde, 0x95, 0xb8, 0x45, 0x5c, 0x5d, 0xab, 0x6b, 0x5b, 0x8b, 0xcd,
-	0xcf, 0xb4, 0x97, 0x1c, 0xcd, 0xfc, 0x87, 0xa3, 0x6f, 0x74, 0x49, 0xdc, 0xeb, 0xb7, 0xb7, 0x9d,
-	0xd0, 0xbf, 0xc3, 0x06, 0x81, 0x13, 0xf7, 0x48, 0xd0, 0x2d, 0xfc, 0x12, 0x12, 0x64, 0x10, 0x27,
-	0xf4, 0xb6, 0x95, 0xf7, 0x87, 0x7b, 0xe7, 0x1c, 0xcd, 0x65, 0xbf, 0x47, 0x1c, 0xcd, 0xb9, 0xe9,
-	0xef, 0x84, 0xa3, 0xa5, 0x13, 0xdf, 0xbb, 0x6f, 0x10, 0xf7, 0x5d, 0x3b, 0x8e, 0xa9, 0x31, 0x3a,
-	0x6b, 0x5c, 0x4b, 0x7f, 0x27, 0x67, 0x8d, 0xdc, 0xee, 0xd7, 0xc3, 0x86, 0x76, 0x3a, 0x6c, 0xe4,
-	0x3e, 0xcc, 0x8c, 0x71, 0xe1, 0x5f, 0x34, 0xb0, 0x44, 0x82, 0x98, 0x86, 0x6e, 0xdf, 0xc1, 0xae,
-	0xd5, 0x1e, 0xe8, 0xb3, 0x52, 0xf0, 0x8b, 0xff, 0x4b, 0xf0, 0x88, 0xa3, 0xc5, 0xb1, 0xd7, 0xe6,
-	0x20, 0xe1, 0xe8, 0xa6, 0x12, 0x5a, 0x00, 0x73, 0xc9, 0x6b, 0x13, 0xa8, 0x10, 0x6c, 0x96, 0x3c,
-	0x40, 0x07, 0xac, 0xe3, 0xc0, 0xa1, 0x83, 0x48, 0xe4, 0xd8, 0x8a, 0x6c, 0xc6, 0x8e, 0x43, 0xea,
-	0xea, 0x97, 0xea, 0xda, 0xd6, 0x7c, 0x73, 0x67, 0xc4, 0x11, 0x1c, 0xd3, 0xad, 0x94, 0x4d, 0x38,
-	0xd2, 0x65, 0xd8, 0x49, 0xca, 0x30, 0xa7, 0xd8, 0x1b, 0x7f, 0xac, 0x83, 0x75, 0x75, 0xb0, 0xe5,
-	0x23, 0x3d, 0x04, 0xb3, 0xe9, 0x51, 0xce, 0x37, 0x77, 0xcf, 0x39, 0x9a, 0x95, 0x8f, 0x38, 0x4b,
-	0x44, 0x84, 0x5a, 0xe9, 0x04, 0xea, 0x41, 0xe8, 0xe2, 0x8e, 0xdd, 0xf7, 0xe2, 0xfb, 0x46, 0x4c,
-	0xfb, 0xb8, 0x78, 0x24, 0xa7, 0xc3, 0xc6, 0xec, 0xc3, 0xbd, 0xcf, 0xc5, 0xb3, 0xcd, 0x12, 0x17,
-	0xfe, 0x08, 0x5c, 0xf1, 0xec, 0x36, 0xf6, 0x64, 0xc6, 0xe7, 0x9b, 0xdf, 0x1d, 0x71, 0xa4, 0x80,
-	0x84, 0xa3, 0xba, 0x74, 0x2a, 0x57, 0xa9, 0x5f, 0x8a, 0x59, 0x6c, 0xd3, 0xf8, 0xbe, 0xd1, 0xb1,
-	0x3d, 0x26, 0xdd, 0x82, 0x31, 0xfd, 0x62, 0xd8, 0x98, 0x31, 0xd5, 0x66, 0xd8, 0x05, 0x2b, 0x1d,
-	0xe2, 0x61, 0x36, 0x60, 0x31, 0xf6, 0x2d, 0x51, 0xdf, 0x32, 0x49, 0xcb, 0x3b, 0x70, 0xbb, 0xc3,
-	0xb6, 0xf7, 0x73, 0xea, 0xc9, 0x20, 0xc2, 0xcd, 0x77, 0x46, 0x1c, 0x2d, 0x77, 0x4a, 0x58, 0xc2,
-	0xd1, 0x75, 0x19, 0xbd, 0x0c, 0x1b, 0x66, 0xc5, 0x0e, 0x1e, 0x80, 0xcb, 0x91, 0x1d, 0xf7, 0xf4,
-	0xcb, 0x52, 0xfe, 0xb7, 0x46, 0x1c, 0xc9, 0x75, 0xc2, 0xd1, 0x2d, 0xb9, 0x5f, 0x2c, 0x52, 0xf1,
-	0x79, 0x4a, 0x3e, 0x15, 0xc2, 0xe7, 0x73, 0xe6, 0xe2, 0xac, 0xa1, 0x7d, 0x6a, 0xca, 0x6d, 0xb0,
-	0x05, 0x2e, 0x4b, 0xb1, 0x57, 0x52, 0xb1, 0xea, 0xf6, 0x6e, 0xab, 0xe3, 0x90, 0x62, 0xb7, 0x44,
-	0x88, 0x58, 0x49, 0x5c, 0x91, 0x21, 0xc4, 0x22, 0x2f, 0xa3, 0xf9, 0x7c, 0x65, 0x4a, 0x2b, 0xf8,
-	0x33, 0x70, 0x4d, 0xd5, 0x39, 0xd3, 0xaf, 0xd6, 0x2f, 0x6d, 0x2d, 0xec, 0xbc, 0x59, 0x76, 0x3a,
-	0xe5, 0xf2, 0x36, 0x91, 0x28, 0xfb, 0x11, 0x47, 0xd9, 0xce, 0x84, 0xa3, 0x45, 0x19, 0x4a, 0xad,
-	0x0d, 0x33, 0x23, 0xe0, 0xef, 0x35, 0xb0, 0x46, 0x31, 0x73, 0xec, 0xc0, 0x22, 0x41, 0x8c, 0xe9,
-	0x33, 0xdb, 0xb3, 0x98, 0x7e, 0xad, 0xae, 0x6d, 0x5d, 0x69, 0x76, 0x47, 0x1c, 0xad, 0x28, 0xf2,
-	0x61, 0xca, 0x1d, 0x26, 0x1c, 0xbd, 0x2d, 0x3d, 0x55, 0xf0, 0x6a, 0x8a, 0xde, 0xff, 0xe6, 0xdd,
-	0xbb, 0xc6, 0x05, 0x47, 0x97, 0x48, 0x10, 0x8f, 0xce, 0x1a, 0xd7, 0xa7, 0x99, 0x5f, 0x9c, 0x35,
-	0x2e, 0x0b, 0x3b, 0xb3, 0x1a, 0x04, 0xfe, 0x43, 0x03, 0xb0, 0xc3, 0xac, 0x63, 0x3b, 0x76, 0x7a,
-	0x98, 0x5a, 0x38, 0xb0, 0xdb, 0x1e, 0x76, 0xf5, 0xb9, 0xba, 0xb6, 0x35, 0xd7, 0xfc, 0xad, 0x76,
-	0xce, 0xd1, 0xea, 0xfe, 0xe1, 0x53, 0xc5, 0x7e, 0xa8, 0xc8, 0x11, 0x47, 0xab, 0x1d, 0x56, 0xc6,
-	0x12, 0x8e, 0xde, 0x51, 0x45, 0x50, 0x21, 0xaa, 0x6a, 0xb3, 0x1a, 0xdf, 0x98, 0x6a, 0x28, 0x74,
-	0x0a, 0x8b, 0xd3, 0x61, 0x63, 0x22, 0xac, 0x39, 0x11, 0x14, 0xfe, 0xbd, 0x2c, 0xde, 0xc5, 0x9e,
-	0x3d, 0xb0, 0x98, 0x3e, 0x2f, 0x73, 0xfa, 0x1b, 0x21, 0x7e, 0x25, 0xf7, 0xb2, 0x27, 0xc8, 0x43,
-	0x91, 0xe7, 0xdc, 0x8d, 0x82, 0x12, 0x8e, 0xbe, 0x5e, 0x96, 0xae, 0xf0, 0xaa, 0xf2, 0x7b, 0xa5,
-	0x2c, 0x4f, 0x33, 0xbe, 0x38, 0x6b, 0xcc, 0xde, 0xbb, 0x7b, 0x3a, 0x6c, 0x54, 0xa3, 0x9a, 0xd5,
-	0x98, 0xf0, 0xe7, 0x60, 0x91, 0x74, 0x83, 0x90, 0x62, 0x2b, 0xc2, 0xd4, 0x67, 0x3a, 0x90, 0xf9,
-	0x7e, 0x30, 0xe2, 0x68, 0x41, 0xe1, 0x2d, 0x01, 0x27, 0x1c, 0xdd, 0x50, 0xdd, 0x62, 0x8c, 0xe5,
-	0xe5, 0xbb, 0x5a, 0x05, 0xcd, 0xe2, 0x56, 0xf8, 0x4b, 0x0d, 0x2c, 0xdb, 0xfd, 0x38, 0xb4, 0x82,
-	0x90, 0xfa, 0xb6, 0x47, 0x9e, 0x63, 0x7d, 0x41, 0x06, 0xf9, 0x78, 0xc4, 0xd1, 0x92, 0x60, 0x3e,
-	0xca, 0x88, 0x3c, 0x03, 0x25, 0xf4, 0xab, 0x4e, 0x0e, 0x4e, 0x5a, 0x65, 0xc7, 0x66, 0x96, 0xfd,
-	0xc2, 0x10, 0x2c, 0xf9, 0x24, 0xb0, 0x5c, 0xc2, 0x8e, 0xac, 0x0e, 0xc5, 0x58, 0x5f, 0xac, 0x6b,
-	0x5b, 0x0b, 0x3b, 0x8b, 0xd9, 0xb5, 0x3a, 0x24, 0xcf, 0x71, 0xf3, 0x41, 0x7a, 0x83, 0x16, 0x7c,
-	0x12, 0xec, 0x11, 0x76, 0xb4, 0x4f, 0xb1, 0x50, 0x84, 0xa4, 0xa2, 0x02, 0x56, 0x3c, 0x8a, 0xfa,
-	0x6d, 0xe3, 0xe2, 0xac, 0x71, 0xe9, 0x5e, 0xfd, 0xb6, 0x59, 0xdc, 0x06, 0xbb, 0x00, 0x8c, 0xdf,
-	0xf3, 0xfa, 0x92, 0x8c, 0x86, 0xb2, 0x68, 0x3f, 0xce, 0x99, 0xf2, 0x15, 0x7e, 0x2b, 0x15, 0x50,
-	0xd8, 0x9a, 0x70, 0xb4, 0x2a, 0xe3, 0x8f, 0x21, 0xc3, 0x2c, 0xf0, 0xf0, 0x01, 0xb8, 0xe6, 0x84,
-	0x11, 0xc1, 0x94, 0xe9, 0xcb, 0xb2, 0xda, 0xbe, 0x26, 0x7a, 0x40, 0x0a, 0xe5, 0xaf, 0xd9, 0x74,
-	0x9d, 0xd5, 0x8d, 0x99, 0x19, 0xc0, 0x7f, 0x6a, 0xe0, 0x86, 0x98, 0x30, 0x30, 0xb5, 0x7c, 0xfb,
-	0xc4, 0x8a, 0x70, 0xe0, 0x92, 0xa0, 0x6b, 0x1d, 0x91, 0xb6, 0xbe, 0x22, 0xdd, 0xfd, 0x41, 0x14,
-	0xef, 0x7a, 0x4b, 0x9a, 0x1c, 0xd8, 0x27, 0x2d, 0x65, 0xf0, 0x88, 0x34, 0x47, 0x1c, 0xad, 0x47,
-	0x93, 0x70, 0xc2, 0xd1, 0x6b, 0xaa, 0x89, 0x4e, 0x72, 0x85, 0xb2, 0x9d, 0xba, 0x75, 0x3a, 0x7c,
-	0x3a, 0x6c, 0x4c, 0x8b, 0x6f, 0x4e, 0xb1, 0x6d, 0x8b, 0x74, 0xf4, 0x6c, 0xd6, 0x13, 0xe9, 0x58,
-	0x1d, 0xa7, 0x23, 0x85, 0xf2, 0x74, 0xa4, 0xeb, 0x71, 0x3a, 0x52, 0x00, 0x7e, 0x00, 0xae, 0xc8,
-	0x59, 0x4b, 0x5f, 0x93, 0xbd, 0x7c, 0x2d, 0x3b, 0x31, 0x11, 0xff, 0xb1, 0x20, 0x9a, 0xba, 0x78,
-	0xd9, 0x49, 0x9b, 0x84, 0xa3, 0x05, 0xe9, 0x4d, 0xae, 0x0c, 0x53, 0xa1, 0xf0, 0x11, 0x58, 0x4a,
-	0x2f, 0x94, 0x8b, 0x3d, 0x1c, 0x63, 0x1d, 0xca, 0x62, 0x7f, 0x4b, 0x4e, 0x16, 0x92, 0xd8, 0x93,
-	0x78, 0xc2, 0x11, 0x2c, 0x5c, 0x29, 0x05, 0x1a, 0x66, 0xc9, 0x06, 0x9e, 0x00, 0x5d, 0xf6, 0xe9,
-	0x88, 0x86, 0x5d, 0x8a, 0x19, 0x2b, 0x36, 0xec, 0x75, 0xf9, 0x7c, 0xe2, 0xe5, 0xbb, 0x21, 0x6c,
-	0x5a, 0xa9, 0x49, 0xb1, 0x6d, 0xab, 0xd7, 0xd9, 0x54, 0x36, 0x7f, 0xf6, 0xe9, 0x9b, 0xe1, 0x21,
-	0x58, 0x4e, 0xeb, 0x22, 0xb2, 0xfb, 0x0c, 0x5b, 0x4c, 0xbf, 0x2e, 0xe3, 0xbd, 0x27, 0x9e, 0x43,
-	0x31, 0x2d, 0x41, 0x1c, 0xe6, 0xcf, 0x51, 0x04, 0x73, 0xef, 0x25, 0x53, 0x88, 0xc1, 0x92, 0xa8,
-	0x32, 0x91, 0x54, 0x8f, 0x38, 0x31, 0xd3, 0x37, 0xa4, 0xcf, 0xef, 0x09, 0x9f, 0xbe, 0x7d, 0xb2,
-	0x9b, 0xe1, 0xe3, 0x5b, 0x57, 0x00, 0xa7, 0x76, 0x40, 0xd5, 0xe9, 0xcc, 0xd2, 0x6e, 0xe8, 0x82,
-	0xeb, 0x2e, 0x61, 0xa2, 0x33, 0x5b, 0x2c, 0xb2, 0x29, 0xc3, 0x96, 0x1c, 0x00, 0xf4, 0x1b, 0xf2,
-	0x24, 0xe4, 0xc8, 0x95, 0xf2, 0x87, 0x92, 0x96, 0xa3, 0x45, 0x3e, 0x72, 0x4d, 0x52, 0x86, 0x39,
-	0xc5, 0xbe, 0x18, 0x25, 0xc6, 0x7e, 0x64, 0x91, 0xc0, 0xc5, 0x27, 0x98, 0xe9, 0x37, 0x27, 0xa2,
-	0x3c, 0xc1, 0x7e, 0xf4, 0x50, 0xb1, 0xd5, 0x28, 0x05, 0x6a, 0x1c, 0xa5, 0x00, 0xc2, 0x1d, 0x70,
-	0x55, 0x1e, 0x80, 0xab, 0xeb, 0xd2, 0xef, 0xe6, 0x88, 0xa3, 0x14, 0xc9, 0xdf, 0xf0, 0x6a, 0x69,
-	0x98, 0x29, 0x0e, 0x63, 0x70, 0xf3, 0x18, 0xdb, 0x47, 0x96, 0xa8, 0x6a, 0x2b, 0xee, 0x51, 0xcc,
-	0x7a, 0xa1, 0xe7, 0x5a, 0x91, 0x13, 0xeb, 0xaf, 0xc9, 0x84, 0x8b, 0xf6, 0x7e, 0x5d, 0x98, 0x7c,
-	0xdf, 0x66, 0xbd, 0x27, 0x99, 0x41, 0xcb, 0x89, 0x13, 0x8e, 0x36, 0xa5, 0xcb, 0x69, 0x64, 0x7e,
-	0xa8, 0x53, 0xb7, 0xc2, 0x5d, 0xb0, 0xe0, 0xdb, 0xf4, 0x08, 0x53, 0x2b, 0xb0, 0x7d, 0xac, 0x6f,
-	0xca, 0xe1, 0xca, 0x10, 0xed, 0x4c, 0xc1, 0x1f, 0xd9, 0x3e, 0xce, 0xdb, 0xd9, 0x18, 0x32, 0xcc,
-	0x02, 0x0f, 0x07, 0x60, 0x53, 0x7c, 0xc4, 0x58, 0xe1, 0x71, 0x80, 0x29, 0xeb, 0x91, 0xc8, 0xea,
-	0xd0, 0xd0, 0xb7, 0x22, 0x9b, 0xe2, 0x20, 0xd6, 0x6f, 0xc9, 0x14, 0x7c, 0x7b, 0xc4, 0xd1, 0x4d,
-	0x61, 0xf5, 0x38, 0x33, 0xda, 0xa7, 0xa1, 0xdf, 0x92, 0x26, 0x09, 0x47, 0x6f, 0x64, 0x1d, 0x6f,
-	0x1a, 0x6f, 0x98, 0x5f, 0xb5, 0x13, 0xfe, 0x4a, 0x03, 0x6b, 0x7e, 0xe8, 0x5a, 0x31, 0xf1, 0xb1,
-	0x75, 0x4c, 0x02, 0x37, 0x3c, 0xb6, 0x98, 0xfe, 0xba, 0x4c, 0xd8, 0x4f, 0xcf, 0x39, 0x5a, 0x33,
-	0xed, 0xe3, 0x83, 0xd0, 0x7d, 0x42, 0x7c, 0xfc, 0x54, 0xb2, 0xe2, 0x1d, 0xbe, 0xec, 0x97, 0x90,
-	0x7c, 0x04, 0x2d, 0xc3, 0x59, 0xe6, 0x4e, 0x87, 0x8d, 0x49, 0x2f, 0x66, 0xc5, 0x07, 0x7c, 0xa1,
-	0x81, 0x8d, 0xf4, 0x9a, 0x38, 0x7d, 0x2a, 0xb4, 0x59, 0xc7, 0x94, 0xc4, 0x98, 0xe9, 0x6f, 0x48,
-	0x31, 0x3f, 0x14, 0xad, 0x57, 0x15, 0x7c, 0xca, 0x3f, 0x95, 0x74, 0xc2, 0xd1, 0xed, 0xc2, 0xad,
-	0x29, 0x71, 0x85, 0xcb, 0xb3, 0x53, 0xb8, 0x3b, 0xda, 0x8e, 0x39, 0xcd, 0x93, 0x68, 0x62, 0x59,
-	0x6d, 0x77, 0xc4, 0x17, 0x93, 0x5e, 0x1b, 0x37, 0xb1, 0x94, 0xd8, 0x17, 0x78, 0x7e, 0xf9, 0x8b,
-	0xa0, 0x61, 0x96, 0x6c, 0xa0, 0x07, 0x56, 0xe5, 0x97, 0xac, 0x25, 0x7a, 0x81, 0xa5, 0xfa, 0x2b,
-	0x92, 0xfd, 0xf5, 0x46, 0xd6, 0x5f, 0x9b, 0x82, 0x1f, 0x37, 0x59, 0x39, 0xdc, 0xb7, 0x4b, 0x58,
-	0x9e, 0xd9, 0x32, 0x6c, 0x98, 0x15, 0x3b, 0xf8, 0x99, 0x06, 0xd6, 0x64, 0x09, 0xc9, 0x0f, 0x61,
-	0x4b, 0x7d, 0x09, 0xeb, 0x75, 0x19, 0x6f, 0x5d, 0x7c, 0x48, 0xec, 0x86, 0xd1, 0xc0, 0x14, 0xdc,
-	0x81, 0xa4, 0x9a, 0x8f, 0xc4, 0x28, 0xe6, 0x94, 0xc1, 0x84, 0xa3, 0xad, 0xbc, 0x8c, 0x0a, 0x78,
-	0x21, 0x8d, 0x2c, 0xb6, 0x03, 0xd7, 0xa6, 0xae, 0x78, 0xff, 0xcf, 0x65, 0x0b, 0xb3, 0xea, 0x08,
-	0xfe, 0x59, 0xc8, 0xb1, 0x45, 0x03, 0xc5, 0x01, 0x23, 0x31, 0x79, 0x26, 0x32, 0xaa, 0xbf, 0x29,
-	0xd3, 0x79, 0x22, 0xe6, 0xc2, 0x5d, 0x9b, 0xe1, 0xc3, 0x8c, 0xdb, 0x97, 0x73, 0xa1, 0x53, 0x86,
-	0x12, 0x8e, 0x36, 0x94, 0x98, 0x32, 0x2e, 0x66, 0xa0, 0x09, 0xdb, 0x49, 0x48, 0x8c, 0x81, 0x95,
-	0x20, 0x66, 0xc5, 0x86, 0xc1, 0x3f, 0x69, 0x60, 0xb5, 0x13, 0x7a, 0x5e, 0x78, 0x6c, 0x7d, 0xd2,
-	0x0f, 0x1c, 0x31, 0x8e, 0x30, 0xdd, 0x18, 0xab, 0xfc, 0x41, 0x06, 0x7e, 0xc0, 0xf6, 0x08, 0x65,
-	0x42, 0xe5, 0x27, 0x65, 0x28, 0x57, 0x59, 0xc1, 0xa5, 0xca, 0xaa, 0xed, 0x24, 0x24, 0x54, 0x56,
-	0x82, 0x98, 0x2b, 0x4a, 0x51, 0x0e, 0xc3, 0x23, 0x30, 0x4f, 0xb1, 0xed, 0x5a, 0x61, 0xe0, 0x0d,
-	0xf4, 0xbf, 0xee, 0x4b, 0x79, 0x07, 0xe7, 0x1c, 0xc1, 0x3d, 0x1c, 0x51, 0xec, 0xd8, 0x31, 0x76,
-	0x4d, 0x6c, 0xbb, 0x8f, 0x03, 0x6f, 0x30, 0xe2, 0x48, 0x7b, 0x2f, 0xff, 0x7a, 0xa7, 0xa1, 0x1c,
-	0x0f, 0xdf, 0x0d, 0x7d, 0x22, 0x7a, 0x75, 0x3c, 0x90, 0x5f, 0xef, 0x13, 0xa8, 0xae, 0x99, 0x73,
-	0x34, 0x75, 0x00, 0x7f, 0x01, 0xd6, 0x4a, 0x33, 0xa3, 0xec, 0x9f, 0x7f, 0x13, 0x41, 0xb5, 0xe6,
-	0x87, 0xe7, 0x1c, 0xe9, 0xe3, 0xa0, 0x07, 0xe3, 0xc9, 0xaf, 0xe5, 0xc4, 0x59, 0xe8, 0x5a, 0x75,
-	0x70, 0x6c, 0x39, 0x71, 0x41, 0x81, 0xae, 0x99, 0xcb, 0x65, 0x12, 0xfe, 0x04, 0x5c, 0x53, 0xef,
-	0x4b, 0xa6, 0x7f, 0xb1, 0x2f, 0xef, 0xfa, 0x77, 0x44, 0xe3, 0x19, 0x07, 0x52, 0x73, 0x10, 0x2b,
-	0x3f, 0x5c, 0xba, 0xa5, 0xe0, 0x3a, 0xbd, 0xe0, 0xba, 0x66, 0x66, 0xfe, 0x9a, 0x8f, 0x5e, 0x7e,
-	0x59, 0x9b, 0x19, 0x7e, 0x59, 0x9b, 0x79, 0x79, 0x5e, 0xd3, 0x86, 0xe7, 0x35, 0xed, 0x77, 0xaf,
-	0x6a, 0x33, 0x9f, 0xbf, 0xaa, 0x69, 0xc3, 0x57, 0xb5, 0x99, 0x7f, 0xbf, 0xaa, 0xcd, 0x7c, 0xfc,
-	0xf6, 0xff, 0xf0, 0x7f, 0x89, 0xba, 0xae, 0xed, 0xab, 0xf2, 0x7f, 0x93, 0xf7, 0xff, 0x1b, 0x00,
-	0x00, 0xff, 0xff, 0x3e, 0xb6, 0x85, 0xe6, 0x55, 0x13, 0x00, 0x00,
+	// 3015 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x59, 0xcf, 0x6f, 0xdc, 0xc6,
+	0xf5, 0x37, 0xfd, 0x53, 0x1a, 0x5b, 0xbf, 0x46, 0x96, 0x3c, 0x56, 0x1c, 0x51, 0x61, 0xd6, 0x8e,
+	0x92, 0x38, 0xb2, 0x2d, 0x3b, 0xf9, 0x7e, 0xe3, 0x26, 0x6d, 0xbd, 0x52, 0xd4, 0x38, 0x8e, 0x63,
+	0x95, 0x72, 0xe3, 0x24, 0x2d, 0xc0, 0x72, 0xc9, 0xd9, 0x5d, 0x46, 0x5c, 0x92, 0xe5, 0x50, 0x96,
+	0x36, 0x0d, 0x82, 0xb4, 0x28, 0xfa, 0x03, 0xc9, 0xa1, 0x70, 0x0f, 0xbd, 0x06, 0x68, 0x51, 0xb4,
+	0xf9, 0x07, 0x5a, 0xf4, 0x2f, 0xc8, 0xa1, 0x85, 0x75, 0x2c, 0x7a, 0x20, 0x10, 0xf9, 0xb6, 0xc7,
+	0xbd, 0xd5, 0xa7, 0x62, 0xde, 0x90, 0xc3, 0x21, 0x97, 0x06, 0x0a, 0xe4, 0xa4, 0x9d, 0xcf, 0xe7,
+	0xcd, 0x7b, 0x8f, 0x33, 0x6f, 0xde, 0xbc, 0x79, 0x42, 0x0d, 0xdf, 0x6b, 0x5d, 0x72, 0xc2, 0xa0,
+	0xed, 0x75, 0x2e, 0xb5, 0x43, 0xdf, 0xa5, 0xb1, 0x18, 0xec, 0xc4, 0x76, 0xe2, 0x85, 0xc1, 0x4a,
+	0x14, 0x87, 0x49, 0x88, 0x8f, 0x0b, 0x70, 0xe1, 0xa9, 0x11, 0xe9, 0xa4, 0x1f, 0x51, 0x21, 0xb4,
+	0x30, 0xa7, 0x90, 0xcc, 0xfb, 0x28, 0x87, 0x17, 0x14, 0x38, 0xda, 0xf1, 0xfd, 0x30, 0x76, 0x69,
+	0x9c, 0x71, 0xcb, 0x0a, 0x77, 0x9f, 0xc6, 0xcc, 0x0b, 0x03, 0x2f, 0xe8, 0xd4, 0x78, 0xb0, 0xa0,
+	0x2b, 0x92, 0x2d, 0x3f, 0x74, 0xb6, 0xab, 0xaa, 0x54, 0x01, 0xfe, 0xc7, 0xf7, 0x9c, 0x24, 0x0a,
+	0x7d, 0xcf, 0xe9, 0xd7, 0xd8, 0x12, 0xbe, 0x77, 0xc3, 0x70, 0xbb, 0xce, 0xd6, 0xa2, 0xfa, 0x21,
+	0xfd, 0x9e, 0xef, 0x05, 0xdb, 0x25, 0x4d, 0xfa, 0x28, 0x1f, 0xd3, 0xdd, 0xd8, 0x4b, 0xf2, 0x4f,
+	0x9e, 0xe7, 0x02, 0xf0, 0xd3, 0x09, 0xfd, 0x4b, 0x2d, 0x1a, 0x65, 0x38, 0xe6, 0x78, 0x9b, 0x5d,
+	0xe2, 0x8b, 0xc6, 0x32, 0xec, 0x5c, 0x86, 0x39, 0x61, 0xd4, 0x8f, 0xed, 0xa0, 0x43, 0x7b, 0x34,
+	0xe9, 0x86, 0x6e, 0xc6, 0x8e, 0xd3, 0xbd, 0x44, 0xfc, 0x34, 0xfe, 0x71, 0x14, 0x9d, 0xdd, 0x00,
+	0xbf, 0xd7, 0xe9, 0x7d, 0xcf, 0xa1, 0x6b, 0xaa, 0xe7, 0xf8, 0x4b, 0x0d, 0x8d, 0xbb, 0x80, 0x5b,
+	0x9e, 0x4b, 0xb4, 0x25, 0x6d, 0xf9, 0x54, 0xf3, 0x73, 0xed, 0xab, 0x54, 0x3f, 0xf4, 0xef, 0x54,
+	0xbf, 0xd6, 0xf1, 0x92, 0xee, 0x4e, 0x6b, 0xc5, 0x09, 0x7b, 0x97, 0x58, 0x3f, 0x70, 0x92, 0xae,
+	0x17, 0x74, 0x94, 0x5f, 0xaa, 0xbb, 0x2b, 0x42, 0xfb, 0xcd, 0xf5, 0x83, 0x54, 0x1f, 0xcb, 0x7f,
+	0x0f, 0x52, 0x7d, 0xcc, 0xcd, 0x7e, 0x0f, 0x53, 0x7d, 0x62, 0xaf, 0xe7, 0x5f, 0x37, 0x3c, 0xf7,
+	0xa2, 0x9d, 0x24, 0xb1, 0x31, 0x78, 0xd8, 0x38, 0x91, 0xfd, 0x1e, 0x3e, 0x6c, 0x48, 0xb9, 0x5f,
+	0xef, 0x37, 0xb4, 0x07, 0xfb, 0x0d, 0xa9, 0xc3, 0xcc, 0x19, 0x17, 0xff, 0x49, 0x43, 0x13, 0x5e,
+	0x90, 0xc4, 0xa1, 0xbb, 0xe3, 0x50, 0xd7, 0x6a, 0xf5, 0xc9, 0x61, 0x70, 0xf8, 0xd3, 0x6f, 0xe4,
+	0xf0, 0x20, 0xd5, 0x4f, 0x15, 0x5a, 0x9b, 0xfd, 0x61, 0xaa, 0x9f, 0x11, 0x8e, 0x2a, 0xa0, 0x74,
+	0x79, 0x66, 0x04, 0xe5, 0x0e, 0x9b, 0x25, 0x0d, 0xd8, 0x41, 0xb3, 0x34, 0x70, 0xe2, 0x7e, 0xc4,
+	0xd7, 0xd8, 0x8a, 0x6c, 0xc6, 0x76, 0xc3, 0xd8, 0x25, 0x47, 0x96, 0xb4, 0xe5, 0xf1, 0xe6, 0xea,
+	0x20, 0xd5, 0x71, 0x41, 0x6f, 0x66, 0xec, 0x30, 0xd5, 0x09, 0x98, 0x1d, 0xa5, 0x0c, 0xb3, 0x46,
+	0x1e, 0x27, 0xe8, 0x54, 0xb6, 0x73, 0x9d, 0x38, 0xdc, 0x89, 0xc8, 0x51, 0xd0, 0xfe, 0xfd, 0x41,
+	0xaa, 0x9f, 0x14, 0xf8, 0xf7, 0x38, 0x3c, 0x4c, 0xf5, 0x25, 0x50, 0xab, 0x60, 0xe0, 0xf6, 0xc5,
+	0xb0, 0xe7, 0x25, 0xb4, 0x17, 0x25, 0x7d, 0xfe, 0x59, 0x0b, 0x4f, 0xa6, 0x4d, 0x55, 0x9d, 0xf1,
+	0x9f, 0x97, 0xd1, 0xac, 0x08, 0xa7, 0x72, 0x20, 0x6d, 0xa1, 0xc3, 0x59, 0x00, 0x8d, 0x37, 0xd7,
+	0x0e, 0x52, 0xfd, 0x30, 0x2c, 0xec, 0x61, 0x8f, 0x7f, 0xd7, 0x62, 0x69, 0xdf, 0x97, 0x82, 0xd0,
+	0xa5, 0x6d, 0x7b, 0xc7, 0x4f, 0xae, 0x1b, 0x49, 0xbc, 0x43, 0xd5, 0x40, 0x78, 0xb0, 0xdf, 0x38,
+	0x7c, 0x73, 0xfd, 0x0b, 0xbe, 0xa2, 0x87, 0x3d, 0x17, 0xff, 0x00, 0x1d, 0xf3, 0xed, 0x16, 0xf5,
+	0x61, 0x9f, 0xc7, 0x9b, 0xdf, 0x19, 0xa4, 0xba, 0x00, 0xe4, 0x57, 0xc1, 0x28, 0xd3, 0x1b, 0x53,
+	0x96, 0xd8, 0x71, 0x72, 0xdd, 0x68, 0xdb, 0x3e, 0x03, 0xb5, 0xa8, 0xa0, 0x3f, 0xdd, 0x6f, 0x1c,
+	0x32, 0xc5, 0x64, 0xdc, 0x41, 0x53, 0x6d, 0xcf, 0xa7, 0xac, 0xcf, 0x12, 0xda, 0xb3, 0xf8, 0xa9,
+	0x82, 0xad, 0x99, 0x5c, 0xc5, 0x2b, 0x6d, 0xb6, 0xb2, 0x21, 0xa9, 0xbb, 0xfd, 0x88, 0x36, 0x5f,
+	0x18, 0xa4, 0xfa, 0x64, 0xbb, 0x84, 0x0d, 0x53, 0xfd, 0x34, 0x58, 0x2f, 0xc3, 0x86, 0x59, 0x91,
+	0xc3, 0xb7, 0xd1, 0xd1, 0xc8, 0x4e, 0xba, 0xd9, 0xd6, 0xbc, 0x3a, 0x48, 0x75, 0x18, 0x0f, 0x53,
+	0xfd, 0x29, 0x98, 0xcf, 0x07, 0x99, 0xf3, 0x72, 0x49, 0x3e, 0xe1, 0x8e, 0x8f, 0x4b, 0xe6, 0xf1,
+	0xc3, 0x86, 0xf6, 0x89, 0x09, 0xd3, 0xf0, 0x26, 0x3a, 0x0a, 0xce, 0x1e, 0xcb, 0x9c, 0x15, 0xb9,
+	0x64, 0x45, 0x6c, 0x07, 0x38, 0xbb, 0xcc, 0x4d, 0x24, 0xc2, 0xc5, 0x29, 0x30, 0xc1, 0x07, 0x32,
+	0x78, 0xc7, 0xe5, 0xc8, 0x04, 0x29, 0xfc, 0x23, 0x74, 0x42, 0x6c, 0x2e, 0x23, 0xc7, 0x97, 0x8e,
+	0x2c, 0x9f, 0x5c, 0x7d, 0xa6, 0xac, 0xb4, 0x26, 0x65, 0x34, 0x75, 0x7e, 0xd8, 0x06, 0xa9, 0x9e,
+	0xcf, 0x1c, 0xa6, 0xfa, 0x29, 0x25, 0xc2, 0x0c, 0x33, 0x27, 0xf0, 0xef, 0x34, 0x34, 0x13, 0x53,
+	0xe6, 0xd8, 0x81, 0xe5, 0x05, 0x09, 0x8d, 0xef, 0xdb, 0xbe, 0xc5, 0xc8, 0x89, 0x25, 0x6d, 0xf9,
+	0x58, 0xb3, 0x33, 0x48, 0xf5, 0x29, 0x41, 0xde, 0xcc, 0xb8, 0xad, 0x61, 0xaa, 0x3f, 0x0f, 0x9a,
+	0x2a, 0x78, 0x75, 0x89, 0xae, 0xbe, 0x72, 0xf9, 0xb2, 0xf1, 0x38, 0xd5, 0x8f, 0x78, 0x41, 0x32,
+	0x78, 0xd8, 0x38, 0x5d, 0x27, 0xfe, 0xf8, 0x61, 0xe3, 0x28, 0x97, 0x33, 0xab, 0x46, 0xf0, 0xdf,
+	0x35, 0x84, 0xdb, 0xcc, 0xda, 0xb5, 0x13, 0xa7, 0x4b, 0x63, 0x8b, 0x06, 0x76, 0xcb, 0xa7, 0x2e,
+	0x19, 0x5b, 0xd2, 0x96, 0xc7, 0x9a, 0x9f, 0x69, 0x07, 0xa9, 0x3e, 0xbd, 0xb1, 0x75, 0x4f, 0xb0,
+	0x6f, 0x08, 0x72, 0x90, 0xea, 0xd3, 0x6d, 0x56, 0xc6, 0x86, 0xa9, 0xfe, 0x82, 0x08, 0x82, 0x0a,
+	0x51, 0xf5, 0x36, 0x8f, 0xf1, 0xb9, 0x5a, 0x41, 0xee, 0x27, 0x97, 0x78, 0xb0, 0xdf, 0x18, 0x31,
+	0x6b, 0x8e, 0x18, 0xc5, 0x7f, 0x2d, 0x3b, 0xef, 0x52, 0xdf, 0xee, 0x5b, 0x8c, 0x8c, 0xc3, 0x9a,
+	0xfe, 0x86, 0x3b, 0x3f, 0x25, 0xb5, 0xac, 0x73, 0x72, 0x8b, 0xaf, 0x73, 0x9b, 0x95, 0xa0, 0x61,
+	0xaa, 0x3f, 0x57, 0x76, 0x5d, 0xe0, 0x55, 0xcf, 0xaf, 0x94, 0x56, 0xb9, 0x4e, 0xf8, 0xf1, 0xc3,
+	0xc6, 0xe1, 0x2b, 0x97, 0x1f, 0xec, 0x37, 0xaa, 0x56, 0xcd, 0xaa, 0x4d, 0xfc, 0x63, 0x74, 0xca,
+	0xeb, 0x04, 0x61, 0x4c, 0xad, 0x88, 0xc6, 0x3d, 0x46, 0x10, 0xac, 0xf7, 0xeb, 0x3c, 0x5d, 0x09,
+	0x7c, 0x93, 0xc3, 0xc3, 0x54, 0x9f, 0x17, 0xd9, 0xa2, 0xc0, 0x64, 0xf8, 0x4e, 0x57, 0x41, 0x53,
+	0x9d, 0x8a, 0x7f, 0xa6, 0xa1, 0x49, 0x7b, 0x27, 0x09, 0xad, 0x20, 0x8c, 0x7b, 0xb6, 0xef, 0x7d,
+	0x44, 0xc9, 0x49, 0x30, 0xf2, 0xc1, 0x20, 0xd5, 0x27, 0x38, 0xf3, 0x4e, 0x4e, 0xc8, 0x15, 0x28,
+	0xa1, 0x4f, 0xda, 0x39, 0x3c, 0x2a, 0x95, 0x6f, 0x9b, 0x59, 0xd6, 0x8b, 0x43, 0x34, 0xd1, 0xf3,
+	0x02, 0xcb, 0xf5, 0xd8, 0xb6, 0xd5, 0x8e, 0x29, 0x25, 0xa7, 0x96, 0xb4, 0xe5, 0x93, 0xab, 0xa7,
+	0xf2, 0x63, 0xb5, 0xe5, 0x7d, 0x44, 0x9b, 0xaf, 0x67, 0x27, 0xe8, 0x64, 0xcf, 0x0b, 0xd6, 0x3d,
+	0xb6, 0xbd, 0x11, 0x53, 0xee, 0x91, 0x0e, 0x1e, 0x29, 0x98, 0xba, 0x15, 0x4b, 0xe7, 0x8d, 0xc7,
+	0x0f, 0x1b, 0x47, 0xae, 0x2c, 0x9d, 0x37, 0xd5, 0x69, 0xb8, 0x83, 0x50, 0x51, 0x01, 0x91, 0x09,
+	0xb0, 0xa6, 0xe7, 0xd6, 0xde, 0x95, 0x4c, 0xf9, 0x08, 0x5f, 0xc8, 0x1c, 0x50, 0xa6, 0x0e, 0x53,
+	0x7d, 0x1a, 0xec, 0x17, 0x90, 0x61, 0x2a, 0x3c, 0x7e, 0x1d, 0x9d, 0x70, 0xc2, 0xc8, 0xa3, 0x31,
+	0x23, 0x93, 0x10, 0x6d, 0xcf, 0xf2, 0x1c, 0x90, 0x41, 0xf2, 0x72, 0xcf, 0xc6, 0x79, 0xdc, 0x98,
+	0xb9, 0x00, 0xfe, 0xa7, 0x86, 0xe6, 0x79, 0xed, 0x45, 0x63, 0xab, 0x67, 0xef, 0x59, 0x11, 0x0d,
+	0x5c, 0x2f, 0xe8, 0x58, 0xdb, 0x5e, 0x8b, 0x4c, 0x81, 0xba, 0xdf, 0xf3, 0xe0, 0x9d, 0xdd, 0x04,
+	0x91, 0xdb, 0xf6, 0xde, 0xa6, 0x10, 0xb8, 0xe5, 0x35, 0x07, 0xa9, 0x3e, 0x1b, 0x8d, 0xc2, 0xc3,
+	0x54, 0x3f, 0x2b, 0x92, 0xe8, 0x28, 0xa7, 0x84, 0x6d, 0xed, 0xd4, 0x7a, 0xf8, 0xc1, 0x7e, 0xa3,
+	0xce, 0xbe, 0x59, 0x23, 0xdb, 0xe2, 0xcb, 0xd1, 0xb5, 0x59, 0x97, 0x2f, 0xc7, 0x74, 0xb1, 0x1c,
+	0x19, 0x24, 0x97, 0x23, 0x1b, 0x17, 0xcb, 0x91, 0x01, 0xfc, 0x66, 0x83, 0x2a, 0x94, 0xcc, 0x40,
+	0x2e, 0x9f, 0xc9, 0x77, 0x8c, 0xdb, 0xbf, 0xc3, 0x89, 0xe6, 0x45, 0x7e, 0xd9, 0x81, 0x8c, 0xbc,
+	0x2e, 0x60, 0x34, 0x72, 0xcf, 0x89, 0x9b, 0x0d, 0x38, 0x7c, 0x0b, 0x4d, 0x64, 0x87, 0xcc, 0xa5,
+	0x3e, 0x4d, 0x28, 0xc1, 0x70, 0x00, 0x2e, 0x40, 0x8d, 0x03, 0xc4, 0x3a, 0xe0, 0xc3, 0x54, 0xc7,
+	0xca, 0x31, 0x13, 0xa0, 0x61, 0x96, 0x64, 0xf0, 0x1e, 0x22, 0x90, 0xbb, 0xa3, 0x38, 0xec, 0xc4,
+	0x94, 0x31, 0x35, 0x89, 0xcf, 0xc2, 0x37, 0xf3, 0x0b, 0x79, 0x8e, 0xcb, 0x6c, 0x66, 0x22, 0x6a,
+	0x2a, 0x17, 0x3e, 0xd7, 0xb2, 0x72, 0x3d, 0xea, 0x27, 0xe3, 0x2d, 0x34, 0x99, 0xc5, 0x4a, 0x64,
+	0xef, 0x30, 0x6a, 0x31, 0x72, 0x1a, 0xec, 0xbd, 0xc4, 0xbf, 0x43, 0x30, 0x9b, 0x9c, 0xd8, 0x92,
+	0xdf, 0xa1, 0x82, 0x52, 0x7b, 0x49, 0x14, 0x53, 0x34, 0xc1, 0x23, 0x2f, 0x2f, 0xf2, 0x19, 0x99,
+	0x03, 0x9d, 0xdf, 0xe5, 0x3a, 0x7b, 0xf6, 0xde, 0x5a, 0x8e, 0x17, 0x27, 0x51, 0x01, 0x6b, 0xb3,
+	0xa2, 0xc8, 0x7e, 0x66, 0x69, 0x36, 0x76, 0xd1, 0x69, 0xd7, 0x63, 0x3c, 0x5b, 0x5b, 0x2c, 0xb2,
+	0x63, 0x46, 0x2d, 0x28, 0x0a, 0xc8, 0x3c, 0xec, 0x04, 0x14, 0x7f, 0x19, 0xbf, 0x05, 0x34, 0x94,
+	0x1b, 0xb2, 0xf8, 0x1b, 0xa5, 0x0c, 0xb3, 0x46, 0x5e, 0xb5, 0xc2, 0xab, 0x34, 0xcb, 0x0b, 0x5c,
+	0xba, 0x47, 0x19, 0x39, 0x33, 0x62, 0xe5, 0x2e, 0xed, 0x45, 0x37, 0x05, 0x5b, 0xb5, 0xa2, 0x50,
+	0x85, 0x15, 0x05, 0xc4, 0xab, 0xe8, 0x38, 0x6c, 0x80, 0x4b, 0x08, 0xe8, 0x5d, 0x18, 0xa4, 0x7a,
+	0x86, 0xc8, 0x5b, 0x5f, 0x0c, 0x0d, 0x33, 0xc3, 0x71, 0x82, 0xce, 0xec, 0x52, 0x7b, 0xdb, 0xe2,
+	0x91, 0x6e, 0x25, 0xdd, 0x98, 0xb2, 0x6e, 0xe8, 0xbb, 0x56, 0xe4, 0x24, 0xe4, 0x2c, 0x2c, 0x38,
+	0x4f, 0xf9, 0xa7, 0xb9, 0xc8, 0x9b, 0x36, 0xeb, 0xde, 0xcd, 0x05, 0x36, 0x9d, 0x64, 0x98, 0xea,
+	0x0b, 0xa0, 0xb2, 0x8e, 0x94, 0x9b, 0x5a, 0x3b, 0x15, 0xaf, 0xa1, 0x93, 0x3d, 0x3b, 0xde, 0xa6,
+	0xb1, 0x15, 0xd8, 0x3d, 0x4a, 0x16, 0xa0, 0xe0, 0x32, 0x78, 0x8a, 0x13, 0xf0, 0x3b, 0x76, 0x8f,
+	0xca, 0x14, 0x57, 0x40, 0x86, 0xa9, 0xf0, 0xb8, 0x8f, 0x16, 0xf8, 0x73, 0xca, 0x0a, 0x77, 0x03,
+	0x1a, 0xb3, 0xae, 0x17, 0x59, 0xed, 0x38, 0xec, 0x59, 0x91, 0x1d, 0xd3, 0x20, 0x21, 0x4f, 0xc1,
+	0x12, 0xbc, 0x36, 0x48, 0xf5, 0x33, 0x5c, 0xea, 0x4e, 0x2e, 0xb4, 0x11, 0x87, 0xbd, 0x4d, 0x10,
+	0x19, 0xa6, 0xfa, 0xd3, 0x79, 0x16, 0xac, 0xe3, 0x0d, 0xf3, 0x49, 0x33, 0xf1, 0x2f, 0x35, 0x34,
+	0xd3, 0x0b, 0x5d, 0x2b, 0xf1, 0x7a, 0xd4, 0xda, 0xf5, 0x02, 0x37, 0xdc, 0xb5, 0x18, 0x39, 0x07,
+	0x0b, 0xf6, 0xc3, 0x83, 0x54, 0x9f, 0x31, 0xed, 0xdd, 0xdb, 0xa1, 0x7b, 0xd7, 0xeb, 0xd1, 0x7b,
+	0xc0, 0xf2, 0x7b, 0x7d, 0xb2, 0x57, 0x42, 0x64, 0x59, 0x5a, 0x86, 0xf3, 0x95, 0x7b, 0xb0, 0xdf,
+	0x18, 0xd5, 0x62, 0x56, 0x74, 0xe0, 0x4f, 0x35, 0x34, 0x97, 0x1d, 0x13, 0x67, 0x27, 0xe6, 0xbe,
+	0x59, 0xf0, 0x44, 0x65, 0xe4, 0x69, 0x70, 0xe6, 0x6d, 0x9e, 0x8e, 0x45, 0xc0, 0x67, 0xfc, 0x3d,
+	0xa0, 0x87, 0xa9, 0x7e, 0x5e, 0x39, 0x35, 0x25, 0x4e, 0x39, 0x3c, 0xab, 0xca, 0xd9, 0xd1, 0x56,
+	0xcd, 0x3a, 0x4d, 0x3c, 0x89, 0xe5, 0xb1, 0xdd, 0xe6, 0x6f, 0x37, 0xb2, 0x58, 0x24, 0xb1, 0x8c,
+	0xd8, 0xe0, 0xb8, 0x3c, 0xfc, 0x2a, 0x68, 0x98, 0x25, 0x19, 0xec, 0xa3, 0x69, 0x78, 0xf7, 0x5b,
+	0x3c, 0x17, 0x58, 0x22, 0xe7, 0xea, 0x90, 0x73, 0xe7, 0xf3, 0x9c, 0xdb, 0xe4, 0x7c, 0x91, 0x78,
+	0xa1, 0xe0, 0x6f, 0x95, 0x30, 0xb9, 0xb2, 0x65, 0xd8, 0x30, 0x2b, 0x72, 0xf8, 0x73, 0x0d, 0xcd,
+	0x40, 0x08, 0xc1, 0x93, 0xdc, 0x12, 0x6f, 0x72, 0xb2, 0x04, 0xf6, 0x66, 0xf9, 0xe3, 0x62, 0x2d,
+	0x8c, 0xfa, 0x26, 0xe7, 0x6e, 0x03, 0xd5, 0xbc, 0xc5, 0xcb, 0x33, 0xa7, 0x0c, 0x0e, 0x53, 0x7d,
+	0x59, 0x86, 0x91, 0x82, 0x2b, 0xcb, 0xc8, 0x12, 0x3b, 0x70, 0xed, 0xd8, 0xe5, 0x35, 0xc1, 0x58,
+	0x3e, 0x30, 0xab, 0x8a, 0xf0, 0x1f, 0xb9, 0x3b, 0x36, 0x4f, 0xa0, 0x34, 0x60, 0x5e, 0xe2, 0xdd,
+	0xe7, 0x2b, 0x4a, 0x9e, 0x81, 0xe5, 0xdc, 0xe3, 0xb5, 0xe2, 0x9a, 0xcd, 0xe8, 0x56, 0xce, 0x6d,
+	0x40, 0xad, 0xe8, 0x94, 0xa1, 0x61, 0xaa, 0xcf, 0x09, 0x67, 0xca, 0x38, 0xaf, 0x8b, 0x46, 0x64,
+	0x47, 0x21, 0x5e, 0x1a, 0x56, 0x8c, 0x98, 0x15, 0x19, 0x86, 0xff, 0xa0, 0xa1, 0xe9, 0x76, 0xe8,
+	0xfb, 0xe1, 0xae, 0xf5, 0xe1, 0x4e, 0xe0, 0x24, 0x5e, 0x18, 0x30, 0x62, 0x14, 0x5e, 0xbe, 0x95,
+	0x83, 0x37, 0xd8, 0xba, 0x17, 0x33, 0xee, 0xe5, 0x87, 0x65, 0x48, 0x7a, 0x59, 0xc1, 0xc1, 0xcb,
+	0xaa, 0xec, 0x28, 0xc4, 0xbd, 0xac, 0x18, 0x31, 0xa7, 0x84, 0x47, 0x12, 0xc6, 0x1d, 0x74, 0x3a,
+	0xa6, 0xbe, 0xbd, 0x47, 0x5d, 0xeb, 0x3e, 0x8d, 0xbd, 0xb6, 0xe7, 0x40, 0x31, 0x45, 0x9e, 0x05,
+	0x47, 0xaf, 0xf1, 0x73, 0x91, 0xf1, 0xef, 0x2a, 0xb4, 0x2c, 0x53, 0x6a, 0x38, 0xc3, 0xac, 0x9b,
+	0x81, 0xaf, 0xa3, 0x31, 0xe6, 0x74, 0xa9, 0xbb, 0xe3, 0x53, 0xd2, 0x58, 0x3a, 0xb2, 0x3c, 0xde,
+	0x5c, 0xe4, 0x8d, 0x94, 0x1c, 0x1b, 0xa6, 0xfa, 0x64, 0x76, 0xb5, 0x0a, 0xc0, 0x30, 0x25, 0x87,
+	0xb7, 0xd1, 0x54, 0x7e, 0xc1, 0x59, 0xa2, 0xf9, 0x44, 0xce, 0x97, 0xa3, 0x3d, 0xbf, 0xa9, 0x36,
+	0x81, 0x15, 0xd1, 0xee, 0x94, 0x30, 0x19, 0xed, 0x65, 0xd8, 0x30, 0x2b, 0x72, 0xf8, 0x6f, 0x1a,
+	0x3a, 0x5b, 0x58, 0x8b, 0x69, 0x9b, 0xc6, 0x31, 0x75, 0x2d, 0xf1, 0xfc, 0x23, 0x17, 0xa0, 0x37,
+	0xf3, 0xf1, 0x37, 0x6c, 0xcd, 0x9c, 0x91, 0x36, 0x73, 0xfd, 0x82, 0x54, 0x72, 0x6d, 0x2d, 0x6f,
+	0x40, 0x5b, 0xe6, 0x49, 0xb3, 0xf1, 0x2e, 0x92, 0x94, 0x15, 0xd3, 0x84, 0x06, 0xd0, 0xa9, 0x71,
+	0xed, 0x3e, 0x23, 0xcf, 0x15, 0xa5, 0x4d, 0x2e, 0x62, 0xe6, 0x12, 0xeb, 0x76, 0x9f, 0xc9, 0xd2,
+	0xa6, 0x96, 0x2d, 0x4a, 0x9b, 0x5a, 0x1a, 0xff, 0x14, 0x91, 0x24, 0xec, 0xb5, 0x58, 0x12, 0x06,
+	0xb4, 0x6a, 0xf9, 0xff, 0xc1, 0xf2, 0x8d, 0x41, 0xaa, 0xcf, 0x4b, 0x99, 0xaa, 0xe9, 0x73, 0x60,
+	0xba, 0x9e, 0x96, 0xb6, 0x9f, 0x30, 0x1d, 0xfb, 0x68, 0xde, 0x09, 0x03, 0x8e, 0x58, 0x2e, 0x6d,
+	0x7b, 0x01, 0x6f, 0xa2, 0xf1, 0x04, 0xc6, 0xc8, 0x32, 0x04, 0xf1, 0x2b, 0xfc, 0x6a, 0xce, 0x24,
+	0xd6, 0x85, 0x00, 0x24, 0x47, 0x26, 0xaf, 0xe6, 0x3a, 0xd2, 0x30, 0x6b, 0xe7, 0xe0, 0x9f, 0x6b,
+	0xe8, 0xb4, 0xc8, 0xbd, 0x50, 0x0b, 0xd8, 0x7e, 0x27, 0x8c, 0xbd, 0xa4, 0xdb, 0x23, 0xaf, 0x42,
+	0x44, 0x9e, 0x5b, 0x91, 0xfb, 0x0d, 0x13, 0xf8, 0x9d, 0x7e, 0x23, 0x97, 0x11, 0x25, 0x4c, 0x6b,
+	0x04, 0x97, 0x25, 0xcc, 0x28, 0x65, 0x98, 0x35, 0xf2, 0xf8, 0x7d, 0x34, 0xa1, 0x76, 0xc9, 0x18,
+	0x79, 0x1e, 0x4e, 0xd4, 0x35, 0xb8, 0x4c, 0x8a, 0xbe, 0x16, 0xff, 0xc2, 0x99, 0x6a, 0x9f, 0x8c,
+	0x67, 0x0f, 0xb5, 0xf9, 0x65, 0x96, 0x66, 0xe0, 0x0f, 0xd0, 0x31, 0xde, 0x0a, 0x66, 0xe4, 0x85,
+	0xa5, 0x23, 0xea, 0xab, 0x4b, 0xb4, 0x4e, 0xde, 0x0c, 0xc3, 0xed, 0xf2, 0xab, 0xeb, 0xd9, 0xec,
+	0xd5, 0x25, 0x66, 0x0d, 0x53, 0x1d, 0x89, 0x37, 0x42, 0x18, 0x6e, 0x73, 0x4b, 0x47, 0xf9, 0x0f,
+	0x53, 0x90, 0x7c, 0xa7, 0x62, 0xca, 0x4b, 0x19, 0x0b, 0xf2, 0xb7, 0x13, 0xfa, 0xbe, 0xc7, 0x20,
+	0x2f, 0xbe, 0x58, 0xec, 0x94, 0x90, 0xe0, 0xe9, 0x75, 0x4d, 0xf2, 0x72, 0xa7, 0xea, 0x48, 0xc3,
+	0xac, 0x9d, 0xc3, 0xab, 0x27, 0x7e, 0x12, 0xad, 0x3d, 0x3b, 0x49, 0x62, 0x46, 0x2e, 0x82, 0x09,
+	0xa8, 0x9e, 0x38, 0xfc, 0x1e, 0xa0, 0xb2, 0x7a, 0x2a, 0x20, 0xc3, 0x54, 0x78, 0x7c, 0x07, 0x4d,
+	0x82, 0x12, 0x59, 0x3d, 0x91, 0xff, 0x03, 0x3d, 0xbc, 0x27, 0x35, 0xc1, 0x19, 0x59, 0xf7, 0x0c,
+	0x53, 0x7d, 0x56, 0xaa, 0x92, 0xa8, 0x61, 0x96, 0xa5, 0x70, 0x1b, 0x4d, 0x66, 0x6d, 0xf2, 0x3c,
+	0x95, 0xbd, 0x04, 0x81, 0x33, 0x27, 0x1f, 0xd3, 0x82, 0xcd, 0x32, 0x59, 0x66, 0x47, 0x81, 0x14,
+	0x3b, 0x0a, 0x0a, 0x76, 0x94, 0x31, 0xfe, 0x85, 0x86, 0xa6, 0x73, 0x43, 0x59, 0x43, 0x9e, 0x91,
+	0x15, 0xd8, 0xd3, 0xf9, 0x8a, 0x29, 0x53, 0xd0, 0xcd, 0x1b, 0xd9, 0x56, 0x4e, 0xb1, 0x12, 0xce,
+	0x64, 0xea, 0x2c, 0xe3, 0x7c, 0x7b, 0x27, 0xcb, 0x90, 0x59, 0x9d, 0x8a, 0x6f, 0xa0, 0xb1, 0x28,
+	0xf6, 0x78, 0xd8, 0xf6, 0xc9, 0x25, 0xc8, 0x04, 0xe7, 0x79, 0xda, 0xcf, 0x31, 0x99, 0xf6, 0x73,
+	0x40, 0x9e, 0x76, 0x29, 0x82, 0xf7, 0xd0, 0x59, 0x3f, 0x74, 0x6c, 0xdf, 0xaa, 0xeb, 0x3e, 0x5f,
+	0x86, 0x9a, 0x18, 0xea, 0x57, 0x10, 0x7a, 0xa3, 0xae, 0x05, 0x2d, 0x72, 0xea, 0x13, 0x78, 0xc3,
+	0x7c, 0xd2, 0x4c, 0x88, 0xa0, 0xc4, 0xee, 0x50, 0x17, 0xea, 0x2c, 0x72, 0x45, 0x89, 0x20, 0x80,
+	0x79, 0x89, 0x54, 0x44, 0x90, 0x84, 0x78, 0x04, 0xc9, 0x01, 0xfe, 0x95, 0x86, 0x66, 0x8b, 0x32,
+	0xcd, 0x8a, 0xec, 0x24, 0xa1, 0x71, 0xc0, 0xc8, 0x2a, 0x1c, 0xd9, 0x7b, 0x83, 0x54, 0x9f, 0x89,
+	0xf2, 0x52, 0x6b, 0x33, 0x23, 0x87, 0xa9, 0x7e, 0x41, 0xbe, 0x00, 0x55, 0xa6, 0xae, 0x1f, 0x3c,
+	0x5d, 0x15, 0x82, 0xb7, 0xf3, 0xa8, 0x52, 0x1c, 0xf2, 0xc6, 0x65, 0x2f, 0xbc, 0x2f, 0x9e, 0x71,
+	0x49, 0x18, 0xdb, 0x1d, 0x4a, 0xae, 0xc2, 0x47, 0xf1, 0x7e, 0xc4, 0xb4, 0x24, 0xb7, 0x04, 0x27,
+	0xbd, 0xa8, 0x12, 0xf5, 0xaf, 0xf5, 0x91, 0xf9, 0xf8, 0x0e, 0x9a, 0x80, 0xb7, 0x36, 0x2f, 0xbd,
+	0xb7, 0x5b, 0x11, 0x23, 0xd7, 0x20, 0x02, 0x5e, 0xe4, 0x5d, 0x22, 0x4e, 0xdc, 0xb6, 0xf7, 0x6e,
+	0xb5, 0x94, 0x2c, 0xa5, 0x60, 0x32, 0x0e, 0x54, 0x41, 0xfc, 0x99, 0xa6, 0x68, 0xf4, 0xc2, 0x88,
+	0x91, 0x97, 0x41, 0x63, 0xe7, 0x20, 0xd5, 0x4f, 0x6e, 0x09, 0xc1, 0x9b, 0x77, 0x36, 0xb7, 0x14,
+	0x03, 0x7c, 0x58, 0x35, 0xc0, 0x31, 0xa5, 0x9b, 0x52, 0x12, 0x2d, 0x0f, 0x1f, 0xec, 0x37, 0x54,
+	0xbd, 0xd2, 0x9b, 0x9b, 0x61, 0xc4, 0xf0, 0x7b, 0x68, 0x06, 0x9c, 0xe1, 0x25, 0x9e, 0x0c, 0xf2,
+	0x57, 0x60, 0x3d, 0x2f, 0xc2, 0x31, 0x72, 0xec, 0xe0, 0xed, 0x70, 0x77, 0xb3, 0x88, 0xf5, 0x39,
+	0xe9, 0x85, 0x82, 0x1b, 0x66, 0x55, 0x92, 0x07, 0x5e, 0xd6, 0x62, 0x76, 0xe2, 0x30, 0x20, 0xd7,
+	0x8b, 0x87, 0x9f, 0x80, 0xd7, 0xe2, 0x30, 0x90, 0x81, 0x57, 0x40, 0x86, 0xa9, 0xf0, 0xd8, 0x47,
+	0x59, 0x97, 0xd8, 0xfa, 0xd0, 0xe3, 0x11, 0x60, 0x31, 0xf2, 0x2d, 0x58, 0x2d, 0x5e, 0x81, 0x4c,
+	0x08, 0xea, 0x2d, 0x60, 0xf8, 0x02, 0x3d, 0xa3, 0xe8, 0xca, 0x50, 0xb5, 0x39, 0x7d, 0x59, 0xed,
+	0x0f, 0x1c, 0xb9, 0x7a, 0xf9, 0xb2, 0x59, 0xd6, 0x80, 0x3f, 0x46, 0x67, 0x7b, 0x36, 0x63, 0x96,
+	0xd3, 0x85, 0x47, 0x42, 0xf9, 0x8d, 0xfc, 0x5a, 0x51, 0x03, 0x70, 0xa1, 0x35, 0x90, 0xa9, 0xbc,
+	0x92, 0xcf, 0x65, 0x0f, 0xad, 0x3a, 0xba, 0xa8, 0x01, 0xea, 0x79, 0xbc, 0x8d, 0xc6, 0x63, 0x6a,
+	0xbb, 0x56, 0x18, 0xf8, 0x7d, 0xf2, 0xe7, 0x0d, 0xd8, 0x83, 0xdb, 0x07, 0xa9, 0x8e, 0xd7, 0x69,
+	0x14, 0x53, 0xc7, 0x4e, 0xa8, 0x6b, 0x52, 0xdb, 0xbd, 0x13, 0xf8, 0xfd, 0x41, 0xaa, 0x6b, 0x2f,
+	0xc9, 0x7f, 0x87, 0xc5, 0x61, 0xcd, 0xff, 0x8d, 0x66, 0x46, 0x50, 0xa2, 0x99, 0x63, 0x71, 0xa6,
+	0x00, 0xff, 0x04, 0xcd, 0x94, 0xda, 0xa1, 0xf0, 0x89, 0x7f, 0xe1, 0x46, 0xb5, 0xe6, 0x1b, 0x07,
+	0xa9, 0x4e, 0x0a, 0xa3, 0xb7, 0x8b, 0xa6, 0xe6, 0xa6, 0x93, 0xe4, 0xa6, 0x17, 0xab, 0x3d, 0xd1,
+	0x4d, 0x27, 0x51, 0x3c, 0x20, 0x9a, 0x39, 0x59, 0x26, 0xf1, 0xfb, 0xe8, 0x84, 0x68, 0xfb, 0x30,
+	0xf2, 0xe5, 0x06, 0x2c, 0xe6, 0xb7, 0xf9, 0xfb, 0xb9, 0x30, 0x24, 0x5a, 0x7c, 0xac, 0xfc, 0x71,
+	0xd9, 0x14, 0x45, 0x75, 0xb6, 0x8e, 0x44, 0x33, 0x73, 0x7d, 0xcd, 0x5b, 0x5f, 0x7d, 0xbd, 0x78,
+	0x68, 0xff, 0xeb, 0xc5, 0x43, 0x5f, 0x1d, 0x2c, 0x6a, 0xfb, 0x07, 0x8b, 0xda, 0x6f, 0x1f, 0x2d,
+	0x1e, 0xfa, 0xe2, 0xd1, 0xa2, 0xb6, 0xff, 0x68, 0xf1, 0xd0, 0xbf, 0x1e, 0x2d, 0x1e, 0xfa, 0xe0,
+	0xf9, 0xff, 0xa1, 0xca, 0x15, 0x37, 0x4a, 0xeb, 0x38, 0x54, 0x3f, 0x57, 0xff, 0x3b, 0x00, 0xb6,
+	0xc5, 0xba, 0x14, 0x4a, 0x1f, 0x00, 0x00,
 }
 
 func (m *FolderDeviceConfiguration) Marshal() (dAtA []byte, err error) {
@@ -300,6 +450,13 @@ func (m *FolderDeviceConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeviceGroup) > 0 {
+		i -= len(m.DeviceGroup)
+		copy(dAtA[i:], m.DeviceGroup)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.DeviceGroup)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.EncryptionPassword) > 0 {
 		i -= len(m.EncryptionPassword)
 		copy(dAtA[i:], m.EncryptionPassword)
@@ -383,6 +540,267 @@ func (m *FolderConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.MassChangeThresholdPct != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.MassChangeThresholdPct))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.RescanJitterS != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.RescanJitterS))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xd8
+	}
+	if len(m.RescanCron) > 0 {
+		i -= len(m.RescanCron)
+		copy(dAtA[i:], m.RescanCron)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.RescanCron)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xd2
+	}
+	if m.BlockHashAlgorithm != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.BlockHashAlgorithm))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.TombstoneRetentionDays != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.TombstoneRetentionDays))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.SyncOwnership {
+		i--
+		if m.SyncOwnership {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.ScanLowPriority {
+		i--
+		if m.ScanLowPriority {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.ScanMaxIOPS != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.ScanMaxIOPS))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.ScanMaxKbps != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.ScanMaxKbps))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.RemovableStorage {
+		i--
+		if m.RemovableStorage {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x98
+	}
+	if len(m.PullOrderPatterns) > 0 {
+		for iNdEx := len(m.PullOrderPatterns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PullOrderPatterns[iNdEx])
+			copy(dAtA[i:], m.PullOrderPatterns[iNdEx])
+			i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.PullOrderPatterns[iNdEx])))
+			i--
+			dAtA[i] = 0x3
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	if m.StagedPull {
+		i--
+		if m.StagedPull {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x88
+	}
+	if len(m.LocalEncryptionPassword) > 0 {
+		i -= len(m.LocalEncryptionPassword)
+		copy(dAtA[i:], m.LocalEncryptionPassword)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.LocalEncryptionPassword)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.Priority != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xf8
+	}
+	if len(m.SymlinkRewrites) > 0 {
+		for iNdEx := len(m.SymlinkRewrites) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SymlinkRewrites[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFolderconfiguration(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xf2
+		}
+	}
+	if m.SymlinkPolicy != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.SymlinkPolicy))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.SyncXattrs {
+		i--
+		if m.SyncXattrs {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.RenameCaseCollisions {
+		i--
+		if m.RenameCaseCollisions {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd8
+	}
+	if len(m.Hooks) > 0 {
+		for iNdEx := len(m.Hooks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hooks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFolderconfiguration(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xd2
+		}
+	}
+	if len(m.DeviceGroups) > 0 {
+		for iNdEx := len(m.DeviceGroups) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DeviceGroups[iNdEx])
+			copy(dAtA[i:], m.DeviceGroups[iNdEx])
+			i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.DeviceGroups[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xca
+		}
+	}
+	if m.ContentDefinedBlocks {
+		i--
+		if m.ContentDefinedBlocks {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.ConflictRetentionDays != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.ConflictRetentionDays))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xb8
+	}
+	{
+		size := m.ConflictPreferredDevice.ProtoSize()
+		i -= size
+		if _, err := m.ConflictPreferredDevice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0xb2
+	if m.ConflictPolicy != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.ConflictPolicy))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xa8
+	}
+	if len(m.Schedule) > 0 {
+		for iNdEx := len(m.Schedule) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Schedule[iNdEx])
+			copy(dAtA[i:], m.Schedule[iNdEx])
+			i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.Schedule[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if m.RelaxedVerification {
+		i--
+		if m.RelaxedVerification {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x98
+	}
 	if m.JunctionsAsDirs {
 		i--
 		if m.JunctionsAsDirs {
@@ -701,6 +1119,10 @@ func (m *FolderDeviceConfiguration) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovFolderconfiguration(uint64(l))
 	}
+	l = len(m.DeviceGroup)
+	if l > 0 {
+		n += 1 + l + sovFolderconfiguration(uint64(l))
+	}
 	return n
 }
 
@@ -817,6 +1239,100 @@ func (m *FolderConfiguration) ProtoSize() (n int) {
 	if m.JunctionsAsDirs {
 		n += 3
 	}
+	if m.RelaxedVerification {
+		n += 3
+	}
+	if len(m.Schedule) > 0 {
+		for _, s := range m.Schedule {
+			l = len(s)
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if m.ConflictPolicy != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.ConflictPolicy))
+	}
+	l = m.ConflictPreferredDevice.ProtoSize()
+	n += 2 + l + sovFolderconfiguration(uint64(l))
+	if m.ConflictRetentionDays != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.ConflictRetentionDays))
+	}
+	if m.ContentDefinedBlocks {
+		n += 3
+	}
+	if len(m.DeviceGroups) > 0 {
+		for _, s := range m.DeviceGroups {
+			l = len(s)
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if len(m.Hooks) > 0 {
+		for _, e := range m.Hooks {
+			l = e.ProtoSize()
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if m.RenameCaseCollisions {
+		n += 3
+	}
+	if m.SyncXattrs {
+		n += 3
+	}
+	if m.SymlinkPolicy != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.SymlinkPolicy))
+	}
+	if len(m.SymlinkRewrites) > 0 {
+		for _, e := range m.SymlinkRewrites {
+			l = e.ProtoSize()
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if m.Priority != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.Priority))
+	}
+	l = len(m.LocalEncryptionPassword)
+	if l > 0 {
+		n += 2 + l + sovFolderconfiguration(uint64(l))
+	}
+	if m.StagedPull {
+		n += 3
+	}
+	if len(m.PullOrderPatterns) > 0 {
+		for _, s := range m.PullOrderPatterns {
+			l = len(s)
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if m.RemovableStorage {
+		n += 3
+	}
+	if m.ScanMaxKbps != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.ScanMaxKbps))
+	}
+	if m.ScanMaxIOPS != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.ScanMaxIOPS))
+	}
+	if m.ScanLowPriority {
+		n += 3
+	}
+	if m.SyncOwnership {
+		n += 3
+	}
+	if m.TombstoneRetentionDays != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.TombstoneRetentionDays))
+	}
+	if m.BlockHashAlgorithm != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.BlockHashAlgorithm))
+	}
+	l = len(m.RescanCron)
+	if l > 0 {
+		n += 2 + l + sovFolderconfiguration(uint64(l))
+	}
+	if m.RescanJitterS != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.RescanJitterS))
+	}
+	if m.MassChangeThresholdPct != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.MassChangeThresholdPct))
+	}
 	if m.DeprecatedReadOnly {
 		n += 4
 	}
@@ -962,6 +1478,38 @@ func (m *FolderDeviceConfiguration) Unmarshal(dAtA []byte) error {
 			}
 			m.EncryptionPassword = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceGroup", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceGroup = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFolderconfiguration(dAtA[iNdEx:])
@@ -1767,6 +2315,617 @@ func (m *FolderConfiguration) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.JunctionsAsDirs = bool(v != 0)
+		case 35:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelaxedVerification", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RelaxedVerification = bool(v != 0)
+		case 36:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedule", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Schedule = append(m.Schedule, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 37:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConflictPolicy", wireType)
+			}
+			m.ConflictPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConflictPolicy |= ConflictPolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 38:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConflictPreferredDevice", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ConflictPreferredDevice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 39:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConflictRetentionDays", wireType)
+			}
+			m.ConflictRetentionDays = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConflictRetentionDays |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 40:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentDefinedBlocks", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ContentDefinedBlocks = bool(v != 0)
+		case 41:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceGroups", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceGroups = append(m.DeviceGroups, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 42:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hooks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hooks = append(m.Hooks, FolderHookConfiguration{})
+			if err := m.Hooks[len(m.Hooks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 43:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RenameCaseCollisions", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RenameCaseCollisions = bool(v != 0)
+		case 44:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SyncXattrs", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SyncXattrs = bool(v != 0)
+		case 45:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SymlinkPolicy", wireType)
+			}
+			m.SymlinkPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SymlinkPolicy |= SymlinkPolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 46:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SymlinkRewrites", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SymlinkRewrites = append(m.SymlinkRewrites, SymlinkRewrite{})
+			if err := m.SymlinkRewrites[len(m.SymlinkRewrites)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 47:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 48:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalEncryptionPassword", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LocalEncryptionPassword = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 49:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StagedPull", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StagedPull = bool(v != 0)
+		case 50:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PullOrderPatterns", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PullOrderPatterns = append(m.PullOrderPatterns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 51:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovableStorage", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RemovableStorage = bool(v != 0)
+		case 52:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanMaxKbps", wireType)
+			}
+			m.ScanMaxKbps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScanMaxKbps |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 53:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanMaxIOPS", wireType)
+			}
+			m.ScanMaxIOPS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScanMaxIOPS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 54:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanLowPriority", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ScanLowPriority = bool(v != 0)
+		case 55:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SyncOwnership", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SyncOwnership = bool(v != 0)
+		case 56:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TombstoneRetentionDays", wireType)
+			}
+			m.TombstoneRetentionDays = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TombstoneRetentionDays |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 57:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHashAlgorithm", wireType)
+			}
+			m.BlockHashAlgorithm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHashAlgorithm |= protocol.BlockHashAlgorithm(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 58:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RescanCron", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RescanCron = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 59:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RescanJitterS", wireType)
+			}
+			m.RescanJitterS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RescanJitterS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 60:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MassChangeThresholdPct", wireType)
+			}
+			m.MassChangeThresholdPct = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MassChangeThresholdPct |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 9000:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedReadOnly", wireType)
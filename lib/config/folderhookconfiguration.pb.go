@@ -0,0 +1,399 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/folderhookconfiguration.proto
+
+package config
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/syncthing/syncthing/proto/ext"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// A FolderHookConfiguration runs an external command when something
+// happens in the folder.
+type FolderHookConfiguration struct {
+	// One of folderIdle, conflictCreated or itemFailed.
+	Event   string `protobuf:"bytes,1,opt,name=event,proto3" json:"event" xml:"event,attr"`
+	Command string `protobuf:"bytes,2,opt,name=command,proto3" json:"command" xml:"command"`
+	// The command is killed when it runs for longer than this; zero means
+	// the default of one minute.
+	TimeoutS int `protobuf:"varint,3,opt,name=timeout_s,json=timeoutS,proto3,casttype=int" json:"timeoutS" xml:"timeoutS,attr"`
+}
+
+func (m *FolderHookConfiguration) Reset()         { *m = FolderHookConfiguration{} }
+func (m *FolderHookConfiguration) String() string { return proto.CompactTextString(m) }
+func (*FolderHookConfiguration) ProtoMessage()    {}
+func (*FolderHookConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab86ac6d141a211b, []int{0}
+}
+func (m *FolderHookConfiguration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FolderHookConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FolderHookConfiguration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FolderHookConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FolderHookConfiguration.Merge(m, src)
+}
+func (m *FolderHookConfiguration) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *FolderHookConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_FolderHookConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FolderHookConfiguration proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*FolderHookConfiguration)(nil), "config.FolderHookConfiguration")
+}
+
+func init() {
+	proto.RegisterFile("lib/config/folderhookconfiguration.proto", fileDescriptor_ab86ac6d141a211b)
+}
+
+var fileDescriptor_ab86ac6d141a211b = []byte{
+	// 289 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0xc8, 0xc9, 0x4c, 0xd2,
+	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x4f, 0xcb, 0xcf, 0x49, 0x49, 0x2d, 0xca, 0xc8, 0xcf,
+	0xcf, 0x86, 0x08, 0x94, 0x16, 0x25, 0x96, 0x64, 0xe6, 0xe7, 0xe9, 0x15, 0x14, 0xe5, 0x97, 0xe4,
+	0x0b, 0xb1, 0x41, 0x04, 0xa5, 0x38, 0x53, 0x2b, 0x4a, 0x20, 0x42, 0x4a, 0xef, 0x18, 0xb9, 0xc4,
+	0xdd, 0xc0, 0x9a, 0x3c, 0xf2, 0xf3, 0xb3, 0x9d, 0x91, 0x35, 0x09, 0x39, 0x72, 0xb1, 0xa6, 0x96,
+	0xa5, 0xe6, 0x95, 0x48, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x3a, 0x69, 0xbf, 0xba, 0x27, 0x0f, 0x11,
+	0xf8, 0x74, 0x4f, 0x5e, 0xa0, 0x22, 0x37, 0xc7, 0x4a, 0x09, 0xcc, 0xd3, 0x49, 0x2c, 0x29, 0x29,
+	0x52, 0x7a, 0x75, 0x5e, 0x85, 0x0b, 0xc1, 0x0d, 0x82, 0x28, 0x14, 0x32, 0xe3, 0x62, 0x4f, 0xce,
+	0xcf, 0xcd, 0x4d, 0xcc, 0x4b, 0x91, 0x60, 0x02, 0x1b, 0x22, 0xf3, 0xea, 0x9e, 0x3c, 0x4c, 0xe8,
+	0xd3, 0x3d, 0x79, 0x5e, 0xb0, 0x31, 0x50, 0xbe, 0x52, 0x10, 0x4c, 0x46, 0x28, 0x82, 0x8b, 0xb3,
+	0x24, 0x33, 0x37, 0x35, 0xbf, 0xb4, 0x24, 0xbe, 0x58, 0x82, 0x59, 0x81, 0x51, 0x83, 0xd5, 0xc9,
+	0xfa, 0xd5, 0x3d, 0x79, 0x0e, 0xa8, 0x60, 0xf0, 0xa7, 0x7b, 0xf2, 0xc2, 0x60, 0xad, 0x30, 0x01,
+	0x88, 0x23, 0x7e, 0xdd, 0x93, 0x67, 0xce, 0xcc, 0x2b, 0x79, 0x75, 0x5e, 0x85, 0x17, 0x45, 0x22,
+	0x08, 0xae, 0xd1, 0xc9, 0xfb, 0xc4, 0x43, 0x39, 0x86, 0x0b, 0x0f, 0xe5, 0x18, 0x4e, 0x3c, 0x92,
+	0x63, 0xbc, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x05, 0x8f, 0xe5, 0x18, 0x2f, 0x3c,
+	0x96, 0x63, 0xb8, 0xf1, 0x58, 0x8e, 0x21, 0x4a, 0x33, 0x3d, 0xb3, 0x24, 0xa3, 0x34, 0x49, 0x2f,
+	0x39, 0x3f, 0x57, 0xbf, 0xb8, 0x32, 0x2f, 0xb9, 0x24, 0x23, 0x33, 0x2f, 0x1d, 0x89, 0x85, 0x08,
+	0xee, 0x24, 0x36, 0x70, 0x20, 0x1a, 0x03, 0x06, 0x00, 0x8b, 0x9c, 0xc1, 0x45, 0x83, 0x01, 0x00,
+	0x00,
+}
+
+func (m *FolderHookConfiguration) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FolderHookConfiguration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FolderHookConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimeoutS != 0 {
+		i = encodeVarintFolderhookconfiguration(dAtA, i, uint64(m.TimeoutS))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Command) > 0 {
+		i -= len(m.Command)
+		copy(dAtA[i:], m.Command)
+		i = encodeVarintFolderhookconfiguration(dAtA, i, uint64(len(m.Command)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Event) > 0 {
+		i -= len(m.Event)
+		copy(dAtA[i:], m.Event)
+		i = encodeVarintFolderhookconfiguration(dAtA, i, uint64(len(m.Event)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintFolderhookconfiguration(dAtA []byte, offset int, v uint64) int {
+	offset -= sovFolderhookconfiguration(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *FolderHookConfiguration) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Event)
+	if l > 0 {
+		n += 1 + l + sovFolderhookconfiguration(uint64(l))
+	}
+	l = len(m.Command)
+	if l > 0 {
+		n += 1 + l + sovFolderhookconfiguration(uint64(l))
+	}
+	if m.TimeoutS != 0 {
+		n += 1 + sovFolderhookconfiguration(uint64(m.TimeoutS))
+	}
+	return n
+}
+
+func sovFolderhookconfiguration(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozFolderhookconfiguration(x uint64) (n int) {
+	return sovFolderhookconfiguration(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *FolderHookConfiguration) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFolderhookconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FolderHookConfiguration: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FolderHookConfiguration: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Event = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Command", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Command = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutS", wireType)
+			}
+			m.TimeoutS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFolderhookconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFolderhookconfiguration
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthFolderhookconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipFolderhookconfiguration(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowFolderhookconfiguration
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFolderhookconfiguration
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFolderhookconfiguration
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthFolderhookconfiguration
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupFolderhookconfiguration
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthFolderhookconfiguration
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthFolderhookconfiguration        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowFolderhookconfiguration          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupFolderhookconfiguration = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,144 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// A Fragment is part of a configuration, in its JSON form, as pushed by a
+// managing device. Devices and folders are matched by ID and changed like a
+// PATCH request to the REST API would, or added if they don't exist yet.
+// The options are patched likewise. Anything not mentioned stays as it is.
+// The folder settings that give access to this device's file system or run
+// commands on it can't be changed this way.
+type Fragment struct {
+	Devices []json.RawMessage `json:"devices,omitempty"`
+	Folders []json.RawMessage `json:"folders,omitempty"`
+	Options json.RawMessage   `json:"options,omitempty"`
+}
+
+// ParseFragment parses and checks a fragment, without applying it.
+func ParseFragment(bs []byte) (Fragment, error) {
+	var frag Fragment
+	if err := json.Unmarshal(bs, &frag); err != nil {
+		return Fragment{}, err
+	}
+	if len(frag.Devices) == 0 && len(frag.Folders) == 0 && len(frag.Options) == 0 {
+		return Fragment{}, errors.New("empty configuration fragment")
+	}
+	// Applying it to an empty configuration catches the errors that don't
+	// depend on what is already there.
+	var cfg Configuration
+	if err := frag.Apply(&cfg); err != nil {
+		return Fragment{}, err
+	}
+	return frag, nil
+}
+
+// Apply applies the fragment to the given configuration. The configuration
+// is left partly modified when an error is returned.
+func (f Fragment) Apply(cfg *Configuration) error {
+	for _, bs := range f.Devices {
+		var id struct {
+			DeviceID protocol.DeviceID `json:"deviceID"`
+		}
+		if err := json.Unmarshal(bs, &id); err != nil {
+			return fmt.Errorf("device: %w", err)
+		}
+		if id.DeviceID == protocol.EmptyDeviceID {
+			return errors.New("device: missing device ID")
+		}
+		device, _, ok := cfg.Device(id.DeviceID)
+		if !ok {
+			device = cfg.Defaults.Device.Copy()
+		}
+		// Which devices may manage the configuration or upgrades isn't up
+		// to them.
+		manager, upgradeManager := device.ConfigManager, device.UpgradeManager
+		if err := json.Unmarshal(bs, &device); err != nil {
+			return fmt.Errorf("device %v: %w", id.DeviceID, err)
+		}
+		device.ConfigManager, device.UpgradeManager = manager, upgradeManager
+		cfg.SetDevice(device)
+	}
+
+	for _, bs := range f.Folders {
+		var id struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(bs, &id); err != nil {
+			return fmt.Errorf("folder: %w", err)
+		}
+		if id.ID == "" {
+			return errors.New("folder: missing folder ID")
+		}
+		folder, _, ok := cfg.Folder(id.ID)
+		if !ok {
+			folder = cfg.Defaults.Folder.Copy()
+			folder.Path = ""
+		}
+		before := folder.Copy()
+		if err := json.Unmarshal(bs, &folder); err != nil {
+			return fmt.Errorf("folder %s: %w", id.ID, err)
+		}
+		if field := changedLocalFolderSetting(before, folder); field != "" {
+			return fmt.Errorf("folder %s: %s can't be changed by a managing device", id.ID, field)
+		}
+		if folder.Path == "" {
+			// The manager can't be expected to know our file system, so
+			// new folders end up in the default location, like auto
+			// accepted ones.
+			name := fs.SanitizePath(folder.Label)
+			if name == "" {
+				name = fs.SanitizePath(folder.ID)
+			}
+			folder.Path = filepath.Join(cfg.Defaults.Folder.Path, name)
+		}
+		cfg.SetFolder(folder)
+	}
+
+	if len(f.Options) > 0 {
+		opts := cfg.Options.Copy()
+		if err := json.Unmarshal(f.Options, &opts); err != nil {
+			return fmt.Errorf("options: %w", err)
+		}
+		cfg.Options = opts
+	}
+
+	return nil
+}
+
+// changedLocalFolderSetting returns the name of the first setting that is
+// up to the local device and differs between the folders, if any.
+func changedLocalFolderSetting(before, after FolderConfiguration) string {
+	switch {
+	case after.Path != before.Path:
+		return "path"
+	case after.FilesystemType != before.FilesystemType:
+		return "filesystemType"
+	case !reflect.DeepEqual(after.Versioning.toInternal(), before.Versioning.toInternal()):
+		return "versioning"
+	case after.LocalEncryptionPassword != before.LocalEncryptionPassword:
+		return "localEncryptionPassword"
+	case len(after.Hooks) != len(before.Hooks):
+		return "hooks"
+	}
+	for i := range after.Hooks {
+		if after.Hooks[i] != before.Hooks[i] {
+			return "hooks"
+		}
+	}
+	return ""
+}
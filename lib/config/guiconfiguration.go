@@ -7,12 +7,15 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/util"
 )
 
 func (c GUIConfiguration) IsAuthEnabled() bool {
@@ -44,6 +47,9 @@ func (c GUIConfiguration) Address() string {
 		return override
 	}
 
+	if path, _, ok := parseUnixAddress(c.RawAddress); ok {
+		return path
+	}
 	return c.RawAddress
 }
 
@@ -66,13 +72,16 @@ func (c GUIConfiguration) Network() string {
 			return "unix"
 		}
 	}
-	if strings.HasPrefix(c.RawAddress, "/") {
+	if _, _, ok := parseUnixAddress(c.RawAddress); ok {
 		return "unix"
 	}
 	return "tcp"
 }
 
 func (c GUIConfiguration) UseTLS() bool {
+	if c.RequireClientCertificate {
+		return true
+	}
 	if override := os.Getenv("STGUIADDRESS"); override != "" {
 		if strings.HasPrefix(override, "http") {
 			return strings.HasPrefix(override, "https:")
@@ -81,12 +90,32 @@ func (c GUIConfiguration) UseTLS() bool {
 			return strings.HasPrefix(override, "unixs:")
 		}
 	}
+	if _, useTLS, ok := parseUnixAddress(c.RawAddress); ok && useTLS {
+		return true
+	}
 	return c.RawUseTLS
 }
 
+// parseUnixAddress returns the socket path of a GUI address that is either
+// an absolute path or an URL like "unix:///run/syncthing/api.sock", and
+// whether the scheme ("unixs") asks for TLS.
+func parseUnixAddress(addr string) (string, bool, bool) {
+	if strings.HasPrefix(addr, "/") {
+		return addr, false, true
+	}
+	if !strings.HasPrefix(addr, "unix:") && !strings.HasPrefix(addr, "unixs:") {
+		return "", false, false
+	}
+	url, err := url.Parse(addr)
+	if err != nil || url.Path == "" {
+		return "", false, false
+	}
+	return url.Path, url.Scheme == "unixs", true
+}
+
 func (c GUIConfiguration) URL() string {
-	if strings.HasPrefix(c.RawAddress, "/") {
-		return "unix://" + c.RawAddress
+	if path, _, ok := parseUnixAddress(c.RawAddress); ok {
+		return "unix://" + path
 	}
 
 	u := url.URL{
@@ -114,26 +143,88 @@ func (c GUIConfiguration) URL() string {
 }
 
 // IsValidAPIKey returns true when the given API key is valid, including both
-// the value in config and any overrides
+// the value in config, any overrides and the scoped API keys
 func (c GUIConfiguration) IsValidAPIKey(apiKey string) bool {
+	_, ok := c.APIKeyScope(apiKey)
+	return ok
+}
+
+// APIKeyScope returns the permissions granted by the given API key. The
+// main API key and any override grant full access.
+func (c GUIConfiguration) APIKeyScope(apiKey string) (APIKeyConfiguration, bool) {
 	switch apiKey {
 	case "":
-		return false
+		return APIKeyConfiguration{}, false
 
 	case c.APIKey, os.Getenv("STGUIAPIKEY"):
-		return true
+		return APIKeyConfiguration{Key: apiKey, Scope: APIKeyScopeAdmin}, true
+	}
 
-	default:
-		return false
+	for _, key := range c.ScopedAPIKeys {
+		if key.Key == apiKey {
+			return key, true
+		}
 	}
+	return APIKeyConfiguration{}, false
 }
 
 func (c *GUIConfiguration) prepare() {
 	if c.APIKey == "" {
 		c.APIKey = rand.String(32)
 	}
+	for i := range c.ScopedAPIKeys {
+		if c.ScopedAPIKeys[i].Key == "" {
+			c.ScopedAPIKeys[i].Key = rand.String(32)
+		}
+		c.ScopedAPIKeys[i].Folders = util.UniqueTrimmedStrings(c.ScopedAPIKeys[i].Folders)
+	}
+	for i, fp := range c.ClientCertificates {
+		// Accept fingerprints as printed by "openssl x509 -fingerprint".
+		c.ClientCertificates[i] = strings.ToLower(strings.ReplaceAll(fp, ":", ""))
+	}
+	c.ClientCertificates = util.UniqueTrimmedStrings(c.ClientCertificates)
+}
+
+// AllowsClientCertificate returns whether the DER encoded certificate is
+// one of the allowed client certificates.
+func (c GUIConfiguration) AllowsClientCertificate(cert []byte) bool {
+	sum := sha256.Sum256(cert)
+	fp := hex.EncodeToString(sum[:])
+	for _, allowed := range c.ClientCertificates {
+		if allowed == fp {
+			return true
+		}
+	}
+	return false
 }
 
 func (c GUIConfiguration) Copy() GUIConfiguration {
+	keys := c.ScopedAPIKeys
+	c.ScopedAPIKeys = make([]APIKeyConfiguration, len(keys))
+	for i, key := range keys {
+		c.ScopedAPIKeys[i] = key.Copy()
+	}
+	c.ClientCertificates = append([]string(nil), c.ClientCertificates...)
 	return c
 }
+
+func (k APIKeyConfiguration) Copy() APIKeyConfiguration {
+	k.Folders = append([]string(nil), k.Folders...)
+	return k
+}
+
+// AllowsFolder returns whether the key may be used to manage the given
+// folder.
+func (k APIKeyConfiguration) AllowsFolder(folder string) bool {
+	switch k.Scope {
+	case APIKeyScopeAdmin:
+		return true
+	case APIKeyScopeFolderAdmin:
+		for _, f := range k.Folders {
+			if f == folder {
+				return true
+			}
+		}
+	}
+	return false
+}
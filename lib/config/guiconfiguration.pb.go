@@ -37,6 +37,16 @@ type GUIConfiguration struct {
 	Debugging                 bool     `protobuf:"varint,11,opt,name=debugging,proto3" json:"debugging" xml:"debugging,attr"`
 	InsecureSkipHostCheck     bool     `protobuf:"varint,12,opt,name=insecure_skip_host_check,json=insecureSkipHostCheck,proto3" json:"insecureSkipHostcheck" xml:"insecureSkipHostcheck,omitempty"`
 	InsecureAllowFrameLoading bool     `protobuf:"varint,13,opt,name=insecure_allow_frame_loading,json=insecureAllowFrameLoading,proto3" json:"insecureAllowFrameLoading" xml:"insecureAllowFrameLoading,omitempty"`
+	// Additional API keys with limited permissions, for example for
+	// monitoring.
+	ScopedAPIKeys []APIKeyConfiguration `protobuf:"bytes,14,rep,name=scoped_api_keys,json=scopedApiKeys,proto3" json:"scopedApiKeys" xml:"scopedApiKey"`
+	// Serve metrics in the Prometheus exposition format on /metrics.
+	MetricsEnabled bool `protobuf:"varint,15,opt,name=metrics_enabled,json=metricsEnabled,proto3" json:"metricsEnabled" xml:"metricsEnabled,omitempty"`
+	// Require clients to present one of the client certificates, given as
+	// SHA-256 fingerprints, when connecting. This implies TLS, and a client
+	// certificate grants the same access as the API key.
+	RequireClientCertificate bool     `protobuf:"varint,16,opt,name=require_client_certificate,json=requireClientCertificate,proto3" json:"requireClientCertificate" xml:"requireClientCertificate,omitempty"`
+	ClientCertificates       []string `protobuf:"bytes,17,rep,name=client_certificates,json=clientCertificates,proto3" json:"clientCertificates" xml:"clientCertificate"`
 }
 
 func (m *GUIConfiguration) Reset()         { *m = GUIConfiguration{} }
@@ -72,67 +82,130 @@ func (m *GUIConfiguration) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GUIConfiguration proto.InternalMessageInfo
 
+type APIKeyConfiguration struct {
+	Name  string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name" xml:"name,attr"`
+	Key   string      `protobuf:"bytes,2,opt,name=key,proto3" json:"key" xml:"key"`
+	Scope APIKeyScope `protobuf:"varint,3,opt,name=scope,proto3,enum=config.APIKeyScope" json:"scope" xml:"scope,attr"`
+	// The folders that may be managed with a folder-admin key.
+	Folders []string `protobuf:"bytes,4,rep,name=folders,proto3" json:"folders" xml:"folder"`
+}
+
+func (m *APIKeyConfiguration) Reset()         { *m = APIKeyConfiguration{} }
+func (m *APIKeyConfiguration) String() string { return proto.CompactTextString(m) }
+func (*APIKeyConfiguration) ProtoMessage()    {}
+func (*APIKeyConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a9586d611855d64, []int{1}
+}
+func (m *APIKeyConfiguration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *APIKeyConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_APIKeyConfiguration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *APIKeyConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIKeyConfiguration.Merge(m, src)
+}
+func (m *APIKeyConfiguration) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *APIKeyConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIKeyConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIKeyConfiguration proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*GUIConfiguration)(nil), "config.GUIConfiguration")
+	proto.RegisterType((*APIKeyConfiguration)(nil), "config.APIKeyConfiguration")
 }
 
 func init() { proto.RegisterFile("lib/config/guiconfiguration.proto", fileDescriptor_2a9586d611855d64) }
 
 var fileDescriptor_2a9586d611855d64 = []byte{
-	// 837 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x16, 0x5b, 0x47, 0xb2, 0xb6, 0xae, 0x60, 0xb0, 0x4d, 0xcb, 0x04, 0x0d, 0xd7, 0x51, 0xd8,
-	0xc2, 0x01, 0x02, 0x39, 0x71, 0x5a, 0x24, 0xf0, 0xa1, 0x80, 0x1c, 0x20, 0x4d, 0x60, 0x17, 0x08,
-	0xe8, 0xfa, 0x92, 0x0b, 0xb1, 0x22, 0xd7, 0xd2, 0x42, 0xfc, 0x2b, 0x77, 0x09, 0x4b, 0x87, 0xf6,
-	0x19, 0x0a, 0xf5, 0x5c, 0xa0, 0xcf, 0xd0, 0x4b, 0x5f, 0x21, 0x37, 0xe9, 0x54, 0xe4, 0xb4, 0x40,
-	0xa4, 0x1b, 0x8f, 0x3c, 0xe6, 0x54, 0xec, 0xf2, 0x47, 0xa2, 0xac, 0xd4, 0xbd, 0xed, 0x7c, 0xf3,
-	0xcd, 0x7c, 0x33, 0xc3, 0x19, 0x10, 0xdc, 0x75, 0x49, 0xef, 0xc0, 0x0e, 0xfc, 0x0b, 0xd2, 0x3f,
-	0xe8, 0xc7, 0x24, 0x7b, 0xc5, 0x11, 0x62, 0x24, 0xf0, 0x3b, 0x61, 0x14, 0xb0, 0x40, 0xad, 0x67,
-	0xe0, 0xed, 0x5b, 0x2b, 0x54, 0x14, 0xb3, 0x81, 0x17, 0x38, 0x38, 0xa3, 0xdc, 0x6e, 0xe2, 0x11,
-	0xcb, 0x9e, 0xed, 0xb7, 0x3b, 0x60, 0xf7, 0x87, 0xf3, 0x97, 0xcf, 0x56, 0x13, 0xa9, 0x3d, 0xd0,
-	0xc0, 0x3e, 0xea, 0xb9, 0xd8, 0xd1, 0x94, 0x3d, 0x65, 0x7f, 0xfb, 0xf8, 0x45, 0xc2, 0x61, 0x01,
-	0xa5, 0x1c, 0xde, 0x1d, 0x79, 0xee, 0x51, 0x3b, 0xb7, 0x1f, 0x20, 0xc6, 0xa2, 0xf6, 0x9e, 0x83,
-	0x2f, 0x50, 0xec, 0xb2, 0xa3, 0x36, 0x8b, 0x62, 0xdc, 0x4e, 0xa6, 0xc6, 0xce, 0xaa, 0xff, 0xfd,
-	0xd4, 0xd8, 0x12, 0x0e, 0xb3, 0xc8, 0xa2, 0xfe, 0x02, 0x1a, 0xc8, 0x71, 0x22, 0x4c, 0xa9, 0xf6,
-	0xd1, 0x9e, 0xb2, 0xdf, 0x3c, 0xb6, 0xe7, 0x1c, 0x02, 0x13, 0x5d, 0x76, 0x33, 0x54, 0x28, 0xe6,
-	0x84, 0x94, 0xc3, 0x6f, 0xa4, 0x62, 0x6e, 0xaf, 0x88, 0x3d, 0x3a, 0x7c, 0xd2, 0x79, 0xd8, 0x79,
-	0xd8, 0x79, 0x74, 0xf4, 0xf4, 0xf1, 0xd3, 0x6f, 0xdb, 0xef, 0xa7, 0x46, 0xab, 0x0a, 0x4d, 0x66,
-	0xc6, 0x4a, 0x52, 0xb3, 0x48, 0xa9, 0xfe, 0xa3, 0x80, 0x2f, 0x63, 0x9f, 0x8c, 0x2c, 0x1a, 0xd8,
-	0x43, 0xcc, 0xac, 0x10, 0x47, 0x1e, 0xa1, 0x94, 0x04, 0x3e, 0xd5, 0x3e, 0x96, 0xf5, 0xfc, 0xa1,
-	0xcc, 0x39, 0xd4, 0x4c, 0x74, 0x79, 0xee, 0x93, 0xd1, 0x99, 0x64, 0xbd, 0x5a, 0x92, 0x12, 0x0e,
-	0x6f, 0xc6, 0x9b, 0x1c, 0x29, 0x87, 0x5f, 0xcb, 0x62, 0x37, 0x7a, 0x1f, 0x04, 0x1e, 0x61, 0xd8,
-	0x0b, 0xd9, 0x58, 0x8c, 0x08, 0x5e, 0xc3, 0x99, 0xcc, 0x8c, 0x0f, 0x16, 0x60, 0x6e, 0x96, 0x57,
-	0x9f, 0x83, 0xad, 0x98, 0xe2, 0x48, 0xdb, 0x92, 0x4d, 0x1c, 0x26, 0x1c, 0x4a, 0x3b, 0xe5, 0xf0,
-	0xf3, 0xac, 0x2c, 0x8a, 0xa3, 0x6a, 0x15, 0xad, 0x2a, 0x64, 0x4a, 0xbe, 0xfa, 0x1a, 0x6c, 0x87,
-	0x88, 0xd2, 0xcb, 0x20, 0x72, 0xb4, 0x1b, 0x32, 0xd7, 0xf7, 0x09, 0x87, 0x25, 0x96, 0x72, 0xa8,
-	0xc9, 0x7c, 0x05, 0x50, 0xcd, 0xa9, 0x5e, 0x85, 0xcd, 0x32, 0x56, 0xf5, 0x40, 0x53, 0x6c, 0xa4,
-	0x25, 0x56, 0x52, 0xab, 0xef, 0x29, 0xfb, 0xad, 0xc3, 0xdd, 0x4e, 0xb6, 0xaa, 0x9d, 0x6e, 0xcc,
-	0x06, 0x3f, 0x06, 0x0e, 0xce, 0xe4, 0x50, 0x6e, 0x95, 0x72, 0x05, 0xb0, 0x26, 0x77, 0x15, 0x36,
-	0xcb, 0x58, 0x15, 0x83, 0x46, 0x4c, 0xb1, 0xc5, 0x5c, 0xaa, 0x35, 0xe4, 0x3a, 0x9f, 0xce, 0x39,
-	0x6c, 0x8a, 0xc1, 0x52, 0xfc, 0xd3, 0xe9, 0x59, 0xc2, 0x61, 0x3d, 0x96, 0xaf, 0x94, 0xc3, 0x96,
-	0x54, 0x61, 0x2e, 0xcd, 0xd6, 0x3a, 0x99, 0x1a, 0xdb, 0x85, 0x91, 0x4e, 0x8d, 0x9c, 0x37, 0x99,
-	0x19, 0xcb, 0x70, 0x53, 0x82, 0x2e, 0x15, 0x32, 0x28, 0x24, 0xd6, 0x10, 0x8f, 0xb5, 0x6d, 0x39,
-	0x30, 0x21, 0x53, 0xef, 0xbe, 0x7a, 0x79, 0x82, 0xc7, 0x42, 0x03, 0x85, 0xe4, 0x04, 0x8f, 0x53,
-	0x0e, 0xbf, 0xc8, 0x3a, 0x09, 0xc9, 0x10, 0x8f, 0xab, 0x7d, 0xec, 0xae, 0x83, 0x93, 0x99, 0x91,
-	0x67, 0x30, 0xf3, 0x78, 0xf5, 0x77, 0x05, 0xdc, 0x24, 0x3e, 0xc5, 0x76, 0x1c, 0x61, 0x0b, 0x39,
-	0x1e, 0xf1, 0x2d, 0x64, 0xdb, 0xe2, 0x8e, 0x9a, 0xb2, 0x39, 0x2b, 0xe1, 0xf0, 0xb3, 0x82, 0xd0,
-	0x15, 0xfe, 0xae, 0x74, 0xa7, 0x1c, 0xde, 0x93, 0xc2, 0x1b, 0x7c, 0xd5, 0x2a, 0xee, 0xfc, 0x27,
-	0xc3, 0xdc, 0x94, 0x5c, 0x3d, 0x01, 0x37, 0xd8, 0x00, 0x7b, 0x58, 0x03, 0xb2, 0xf5, 0xef, 0x12,
-	0x0e, 0x33, 0x20, 0xe5, 0xf0, 0x4e, 0x36, 0x53, 0x61, 0xad, 0x9c, 0x6e, 0xfe, 0x10, 0x37, 0xdb,
-	0xc8, 0xdf, 0x66, 0x16, 0xa2, 0x9e, 0x83, 0xa6, 0x83, 0x7b, 0x71, 0xbf, 0x4f, 0xfc, 0xbe, 0xf6,
-	0x89, 0xec, 0xea, 0x49, 0xc2, 0xe1, 0x12, 0x2c, 0xb7, 0xb9, 0x44, 0xca, 0xcf, 0xd5, 0xaa, 0x42,
-	0xe6, 0x32, 0x48, 0xfd, 0x5b, 0x01, 0x5a, 0x39, 0x39, 0x3a, 0x24, 0xa1, 0x35, 0x08, 0x28, 0xb3,
-	0xec, 0x01, 0xb6, 0x87, 0xda, 0x8e, 0x94, 0xf9, 0x55, 0xdc, 0x75, 0xc1, 0x39, 0x1b, 0x92, 0xf0,
-	0x45, 0x40, 0x99, 0x24, 0x94, 0x77, 0xbd, 0xd1, 0xbb, 0x76, 0xd7, 0xd7, 0x70, 0xd2, 0xa9, 0xb1,
-	0x59, 0xc4, 0xbc, 0x02, 0x3f, 0x13, 0xb0, 0xfa, 0x97, 0x02, 0xbe, 0x5a, 0x7e, 0x73, 0xd7, 0x0d,
-	0x2e, 0xad, 0x8b, 0x08, 0x79, 0xd8, 0x72, 0x03, 0xe4, 0x88, 0x21, 0x7d, 0x2a, 0xab, 0xff, 0x39,
-	0xe1, 0xf0, 0x56, 0xf9, 0x75, 0x04, 0xed, 0xb9, 0x60, 0x9d, 0x66, 0xa4, 0x94, 0xc3, 0xfb, 0xd5,
-	0x05, 0x58, 0x67, 0x54, 0xbb, 0xb8, 0xf7, 0x3f, 0x78, 0xe6, 0x87, 0xe5, 0x8e, 0x4f, 0xde, 0xbc,
-	0xd3, 0x6b, 0xb3, 0x77, 0x7a, 0xed, 0xcd, 0x5c, 0x57, 0x66, 0x73, 0x5d, 0xf9, 0x6d, 0xa1, 0xd7,
-	0xfe, 0x5c, 0xe8, 0xca, 0x6c, 0xa1, 0xd7, 0xde, 0x2e, 0xf4, 0xda, 0xeb, 0xfb, 0x7d, 0xc2, 0x06,
-	0x71, 0xaf, 0x63, 0x07, 0xde, 0x01, 0x1d, 0xfb, 0x36, 0x1b, 0x10, 0xbf, 0xbf, 0xf2, 0x5a, 0xfe,
-	0xc1, 0x7a, 0x75, 0xf9, 0xbb, 0x7a, 0xfc, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd8, 0x8c, 0xef,
-	0xc0, 0x01, 0x07, 0x00, 0x00,
+	// 1173 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x4f, 0x6f, 0xdb, 0x36,
+	0x14, 0xb7, 0x9a, 0xd4, 0x8e, 0xd9, 0xd6, 0x49, 0x99, 0x76, 0x55, 0xbb, 0xd6, 0x74, 0x5d, 0xb5,
+	0x70, 0xb1, 0xc2, 0x69, 0xd3, 0x0d, 0x2d, 0x82, 0x61, 0x83, 0x1d, 0xac, 0x6b, 0x91, 0x0c, 0x08,
+	0x94, 0x65, 0x87, 0x62, 0x80, 0x20, 0x4b, 0x8c, 0x4d, 0x58, 0x7f, 0x5c, 0x51, 0x42, 0xe2, 0xc3,
+	0xf6, 0x0d, 0x06, 0x0c, 0xd9, 0xae, 0x03, 0xf6, 0x01, 0x76, 0xda, 0x0e, 0xfb, 0x0a, 0xb9, 0xd9,
+	0xa7, 0x61, 0x27, 0x02, 0x75, 0x6e, 0x3a, 0xea, 0xd8, 0xd3, 0x40, 0x4a, 0x96, 0x2d, 0xdb, 0x59,
+	0x7b, 0xe3, 0xfb, 0xbd, 0xdf, 0x7b, 0xbf, 0x47, 0xea, 0x3d, 0x91, 0xe0, 0xae, 0x45, 0x5a, 0x1b,
+	0x86, 0xeb, 0x1c, 0x92, 0xf6, 0x46, 0x3b, 0x20, 0xf1, 0x2a, 0xf0, 0x74, 0x9f, 0xb8, 0x4e, 0xbd,
+	0xe7, 0xb9, 0xbe, 0x0b, 0xf3, 0x31, 0x78, 0xeb, 0xf6, 0x14, 0x55, 0xef, 0x91, 0x2e, 0xee, 0x53,
+	0xc3, 0xed, 0xe1, 0x98, 0x75, 0xeb, 0xe6, 0xb4, 0x37, 0xf0, 0x3b, 0xb6, 0x6b, 0x8e, 0x5d, 0x45,
+	0x7c, 0xec, 0xc7, 0xcb, 0xea, 0xe9, 0x55, 0xb0, 0xf6, 0xf5, 0xc1, 0xab, 0xed, 0x69, 0x19, 0xd8,
+	0x02, 0x05, 0xec, 0xe8, 0x2d, 0x0b, 0x9b, 0xb2, 0x54, 0x91, 0x6a, 0x2b, 0xcd, 0x97, 0x21, 0x43,
+	0x63, 0x28, 0x62, 0xe8, 0xee, 0xb1, 0x6d, 0x6d, 0x55, 0x13, 0xfb, 0x91, 0xee, 0xfb, 0x5e, 0xb5,
+	0x62, 0xe2, 0x43, 0x3d, 0xb0, 0xfc, 0xad, 0xaa, 0xef, 0x05, 0xb8, 0x1a, 0x0e, 0x94, 0xcb, 0xd3,
+	0xfe, 0x77, 0x03, 0x65, 0x99, 0x3b, 0xd4, 0x71, 0x16, 0xf8, 0x03, 0x28, 0xe8, 0xa6, 0xe9, 0x61,
+	0x4a, 0xe5, 0x0b, 0x15, 0xa9, 0x56, 0x6c, 0x1a, 0x23, 0x86, 0x80, 0xaa, 0x1f, 0x35, 0x62, 0x94,
+	0x2b, 0x26, 0x84, 0x88, 0xa1, 0x07, 0x42, 0x31, 0xb1, 0xa7, 0xc4, 0x9e, 0x6c, 0x3e, 0xab, 0x3f,
+	0xae, 0x3f, 0xae, 0x3f, 0xd9, 0x7a, 0xfe, 0xf4, 0xf9, 0xa7, 0xd5, 0x77, 0x03, 0xa5, 0x94, 0x85,
+	0x4e, 0x86, 0xca, 0x54, 0x52, 0x75, 0x9c, 0x12, 0xfe, 0x23, 0x81, 0x1b, 0x81, 0x43, 0x8e, 0x35,
+	0xea, 0x1a, 0x5d, 0xec, 0x6b, 0x3d, 0xec, 0xd9, 0x84, 0x52, 0xe2, 0x3a, 0x54, 0x5e, 0x12, 0xf5,
+	0xfc, 0x26, 0x8d, 0x18, 0x92, 0x55, 0xfd, 0xe8, 0xc0, 0x21, 0xc7, 0xfb, 0x82, 0xb5, 0x37, 0x21,
+	0x85, 0x0c, 0x5d, 0x0f, 0x16, 0x39, 0x22, 0x86, 0xee, 0x8b, 0x62, 0x17, 0x7a, 0x1f, 0xb9, 0x36,
+	0xf1, 0xb1, 0xdd, 0xf3, 0xfb, 0xfc, 0x88, 0xd0, 0x7b, 0x38, 0x27, 0x43, 0xe5, 0xdc, 0x02, 0xd4,
+	0xc5, 0xf2, 0xf0, 0x05, 0x58, 0x0e, 0x28, 0xf6, 0xe4, 0x65, 0xb1, 0x89, 0xcd, 0x90, 0x21, 0x61,
+	0x47, 0x0c, 0x5d, 0x8b, 0xcb, 0xa2, 0xd8, 0xcb, 0x56, 0x51, 0xca, 0x42, 0xaa, 0xe0, 0xc3, 0xd7,
+	0x60, 0xa5, 0xa7, 0x53, 0x7a, 0xe4, 0x7a, 0xa6, 0x7c, 0x51, 0xe4, 0xfa, 0x22, 0x64, 0x28, 0xc5,
+	0x22, 0x86, 0x64, 0x91, 0x6f, 0x0c, 0x64, 0x73, 0xc2, 0x79, 0x58, 0x4d, 0x63, 0xa1, 0x0d, 0x8a,
+	0xbc, 0x23, 0x35, 0xde, 0x92, 0x72, 0xbe, 0x22, 0xd5, 0x4a, 0x9b, 0x6b, 0xf5, 0xb8, 0x55, 0xeb,
+	0x8d, 0xc0, 0xef, 0x7c, 0xe3, 0x9a, 0x38, 0x96, 0xd3, 0x13, 0x2b, 0x95, 0x1b, 0x03, 0x33, 0x72,
+	0xf3, 0xb0, 0x9a, 0xc6, 0x42, 0x0c, 0x0a, 0x01, 0xc5, 0x9a, 0x6f, 0x51, 0xb9, 0x20, 0xda, 0x79,
+	0x77, 0xc4, 0x50, 0x91, 0x1f, 0x2c, 0xc5, 0xdf, 0xee, 0xee, 0x87, 0x0c, 0xe5, 0x03, 0xb1, 0x8a,
+	0x18, 0x2a, 0x09, 0x15, 0xdf, 0xa2, 0x71, 0x5b, 0x87, 0x03, 0x65, 0x65, 0x6c, 0x44, 0x03, 0x25,
+	0xe1, 0x9d, 0x0c, 0x95, 0x49, 0xb8, 0x2a, 0x40, 0x8b, 0x72, 0x19, 0xbd, 0x47, 0xb4, 0x2e, 0xee,
+	0xcb, 0x2b, 0xe2, 0xc0, 0xb8, 0x4c, 0xbe, 0xb1, 0xf7, 0x6a, 0x07, 0xf7, 0xb9, 0x86, 0xde, 0x23,
+	0x3b, 0xb8, 0x1f, 0x31, 0xf4, 0x51, 0xbc, 0x13, 0x31, 0xb1, 0xd9, 0x7d, 0xac, 0xcd, 0x82, 0x27,
+	0x43, 0x25, 0xc9, 0xa0, 0x26, 0xf1, 0xf0, 0x17, 0x09, 0x5c, 0x27, 0x0e, 0xc5, 0x46, 0xe0, 0x61,
+	0x4d, 0x37, 0x6d, 0xe2, 0x68, 0xba, 0x61, 0xf0, 0x39, 0x2a, 0x8a, 0xcd, 0x69, 0x21, 0x43, 0xeb,
+	0x63, 0x42, 0x83, 0xfb, 0x1b, 0xc2, 0x1d, 0x31, 0x74, 0x4f, 0x08, 0x2f, 0xf0, 0x65, 0xab, 0xb8,
+	0xf3, 0xbf, 0x0c, 0x75, 0x51, 0x72, 0xb8, 0x03, 0x2e, 0xfa, 0x1d, 0x6c, 0x63, 0x19, 0x88, 0xad,
+	0x7f, 0x16, 0x32, 0x14, 0x03, 0x11, 0x43, 0x77, 0xe2, 0x33, 0xe5, 0xd6, 0xd4, 0xe8, 0x26, 0x0b,
+	0x3e, 0xb3, 0x85, 0x64, 0xad, 0xc6, 0x21, 0xf0, 0x00, 0x14, 0x4d, 0xdc, 0x0a, 0xda, 0x6d, 0xe2,
+	0xb4, 0xe5, 0x4b, 0x62, 0x57, 0xcf, 0x42, 0x86, 0x26, 0x60, 0xda, 0xcd, 0x29, 0x92, 0x7e, 0xae,
+	0x52, 0x16, 0x52, 0x27, 0x41, 0xf0, 0x6f, 0x09, 0xc8, 0xe9, 0xc9, 0xd1, 0x2e, 0xe9, 0x69, 0x1d,
+	0x97, 0xfa, 0x9a, 0xd1, 0xc1, 0x46, 0x57, 0xbe, 0x2c, 0x64, 0x7e, 0xe4, 0x73, 0x3d, 0xe6, 0xec,
+	0x77, 0x49, 0xef, 0xa5, 0x4b, 0x7d, 0x41, 0x48, 0xe7, 0x7a, 0xa1, 0x77, 0x66, 0xae, 0xdf, 0xc3,
+	0x89, 0x06, 0xca, 0x62, 0x11, 0x75, 0x0e, 0xde, 0xe6, 0x30, 0xfc, 0x53, 0x02, 0xb7, 0x27, 0xdf,
+	0xdc, 0xb2, 0xdc, 0x23, 0xed, 0xd0, 0xd3, 0x6d, 0xac, 0x59, 0xae, 0x6e, 0xf2, 0x43, 0xba, 0x22,
+	0xaa, 0x7f, 0x13, 0x32, 0x74, 0x33, 0xfd, 0x3a, 0x9c, 0xf6, 0x82, 0xb3, 0x76, 0x63, 0x52, 0xc4,
+	0xd0, 0xc3, 0x6c, 0x03, 0xcc, 0x32, 0xb2, 0xbb, 0xb8, 0xf7, 0x01, 0x3c, 0xf5, 0x7c, 0x39, 0xf8,
+	0x97, 0x04, 0x56, 0xc5, 0x85, 0x64, 0x6a, 0xc9, 0x5c, 0x50, 0xb9, 0x54, 0x59, 0xaa, 0x5d, 0xda,
+	0xfc, 0x38, 0x1d, 0x76, 0xd1, 0xda, 0x99, 0xcb, 0xa7, 0xe9, 0x9c, 0x32, 0x94, 0x1b, 0x31, 0x74,
+	0x65, 0x5f, 0xc4, 0xc6, 0x14, 0xfe, 0xbf, 0xbd, 0x12, 0x27, 0x6b, 0x88, 0x31, 0xe0, 0xed, 0x0c,
+	0xc5, 0x6e, 0xa6, 0x51, 0x71, 0xef, 0x4c, 0x03, 0xd1, 0x40, 0xc9, 0x86, 0x9d, 0x0c, 0x95, 0x6c,
+	0x62, 0x35, 0xeb, 0x87, 0x7d, 0xb0, 0x6a, 0x63, 0xdf, 0x23, 0x06, 0xd5, 0xc6, 0x77, 0xe0, 0xaa,
+	0x38, 0xdc, 0xbd, 0x90, 0xa1, 0x52, 0xe2, 0xfa, 0x2a, 0xbd, 0x0a, 0xcb, 0xa2, 0x86, 0x2c, 0x9c,
+	0x3d, 0x46, 0xf9, 0x3c, 0xa7, 0x3a, 0x93, 0x0d, 0xfe, 0x21, 0x81, 0x5b, 0x1e, 0x7e, 0x13, 0x10,
+	0x0f, 0x6b, 0x86, 0x45, 0xb0, 0xe3, 0x6b, 0x06, 0xf6, 0x7c, 0x72, 0x48, 0x0c, 0xdd, 0xc7, 0xf2,
+	0x9a, 0x28, 0xc3, 0x09, 0x19, 0x92, 0x13, 0xd6, 0xb6, 0x20, 0x6d, 0x4f, 0x38, 0x11, 0x43, 0x35,
+	0x51, 0xd0, 0x79, 0x84, 0x6c, 0x69, 0x1f, 0x40, 0x53, 0xcf, 0xd5, 0x82, 0x3f, 0x49, 0x60, 0x7d,
+	0xbe, 0x4c, 0x2a, 0x5f, 0xad, 0x2c, 0xd5, 0x8a, 0xcd, 0xef, 0x43, 0x86, 0xa0, 0x31, 0x1b, 0xc4,
+	0x3f, 0xdb, 0x0d, 0x51, 0xe1, 0x9c, 0x8b, 0x17, 0x74, 0x75, 0x0e, 0x8d, 0x06, 0xca, 0x82, 0x2c,
+	0xea, 0x02, 0xac, 0xfa, 0xeb, 0x05, 0xb0, 0xbe, 0xa0, 0xa1, 0xe0, 0xe7, 0x60, 0xd9, 0xd1, 0x6d,
+	0x2c, 0x9e, 0x32, 0xc5, 0x66, 0x8d, 0xdf, 0x88, 0xdc, 0x8e, 0x18, 0x5a, 0x15, 0x95, 0x70, 0x23,
+	0xfd, 0x7d, 0x14, 0x53, 0x4b, 0x15, 0x2c, 0xf8, 0x00, 0x2c, 0xf1, 0x3f, 0x7a, 0xfc, 0x46, 0xb9,
+	0x16, 0x32, 0xc4, 0xcd, 0x88, 0xa1, 0xa2, 0x88, 0xed, 0xe2, 0x7e, 0x55, 0xe5, 0x08, 0xfc, 0x0e,
+	0x5c, 0x14, 0x8d, 0x24, 0x5e, 0x0f, 0xa5, 0xcd, 0xf5, 0x6c, 0x8b, 0x8b, 0x96, 0x6b, 0x7e, 0xc2,
+	0xff, 0x8a, 0x82, 0x15, 0x31, 0xb4, 0x36, 0xe9, 0xde, 0x54, 0x1d, 0x4c, 0x4c, 0x35, 0x26, 0xc2,
+	0x2f, 0x41, 0xe1, 0xd0, 0xb5, 0x4c, 0xec, 0x51, 0x79, 0x59, 0x1c, 0xec, 0x7d, 0xfe, 0x32, 0x4a,
+	0xa0, 0x88, 0xa1, 0xcb, 0x22, 0x4d, 0x6c, 0xf3, 0x14, 0xf9, 0x78, 0xa9, 0x8e, 0x29, 0xcd, 0x9d,
+	0xd3, 0xb7, 0xe5, 0xdc, 0xf0, 0x6d, 0x39, 0x77, 0x3a, 0x2a, 0x4b, 0xc3, 0x51, 0x59, 0xfa, 0xf9,
+	0xac, 0x9c, 0xfb, 0xfd, 0xac, 0x2c, 0x0d, 0xcf, 0xca, 0xb9, 0x7f, 0xcf, 0xca, 0xb9, 0xd7, 0x0f,
+	0xdb, 0xc4, 0xef, 0x04, 0xad, 0xba, 0xe1, 0xda, 0x1b, 0xb4, 0xef, 0x18, 0x7e, 0x87, 0x38, 0xed,
+	0xa9, 0xd5, 0xe4, 0x21, 0xd9, 0xca, 0x8b, 0x57, 0xe3, 0xd3, 0xff, 0x06, 0x00, 0x18, 0xb5, 0x63,
+	0xcd, 0xa6, 0x0a, 0x00, 0x00,
 }
 
 func (m *GUIConfiguration) Marshal() (dAtA []byte, err error) {
@@ -155,6 +228,53 @@ func (m *GUIConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ClientCertificates) > 0 {
+		for iNdEx := len(m.ClientCertificates) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ClientCertificates[iNdEx])
+			copy(dAtA[i:], m.ClientCertificates[iNdEx])
+			i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.ClientCertificates[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if m.RequireClientCertificate {
+		i--
+		if m.RequireClientCertificate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.MetricsEnabled {
+		i--
+		if m.MetricsEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x78
+	}
+	if len(m.ScopedAPIKeys) > 0 {
+		for iNdEx := len(m.ScopedAPIKeys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ScopedAPIKeys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGuiconfiguration(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x72
+		}
+	}
 	if m.InsecureAllowFrameLoading {
 		i--
 		if m.InsecureAllowFrameLoading {
@@ -265,6 +385,57 @@ func (m *GUIConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *APIKeyConfiguration) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *APIKeyConfiguration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *APIKeyConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Folders) > 0 {
+		for iNdEx := len(m.Folders) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Folders[iNdEx])
+			copy(dAtA[i:], m.Folders[iNdEx])
+			i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.Folders[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Scope != 0 {
+		i = encodeVarintGuiconfiguration(dAtA, i, uint64(m.Scope))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintGuiconfiguration(dAtA []byte, offset int, v uint64) int {
 	offset -= sovGuiconfiguration(v)
 	base := offset
@@ -327,6 +498,50 @@ func (m *GUIConfiguration) ProtoSize() (n int) {
 	if m.InsecureAllowFrameLoading {
 		n += 2
 	}
+	if len(m.ScopedAPIKeys) > 0 {
+		for _, e := range m.ScopedAPIKeys {
+			l = e.ProtoSize()
+			n += 1 + l + sovGuiconfiguration(uint64(l))
+		}
+	}
+	if m.MetricsEnabled {
+		n += 2
+	}
+	if m.RequireClientCertificate {
+		n += 3
+	}
+	if len(m.ClientCertificates) > 0 {
+		for _, s := range m.ClientCertificates {
+			l = len(s)
+			n += 2 + l + sovGuiconfiguration(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *APIKeyConfiguration) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovGuiconfiguration(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovGuiconfiguration(uint64(l))
+	}
+	if m.Scope != 0 {
+		n += 1 + sovGuiconfiguration(uint64(m.Scope))
+	}
+	if len(m.Folders) > 0 {
+		for _, s := range m.Folders {
+			l = len(s)
+			n += 1 + l + sovGuiconfiguration(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -696,6 +911,280 @@ func (m *GUIConfiguration) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.InsecureAllowFrameLoading = bool(v != 0)
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScopedAPIKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScopedAPIKeys = append(m.ScopedAPIKeys, APIKeyConfiguration{})
+			if err := m.ScopedAPIKeys[len(m.ScopedAPIKeys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricsEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MetricsEnabled = bool(v != 0)
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequireClientCertificate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RequireClientCertificate = bool(v != 0)
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientCertificates", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientCertificates = append(m.ClientCertificates, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGuiconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *APIKeyConfiguration) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGuiconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: APIKeyConfiguration: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: APIKeyConfiguration: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scope", wireType)
+			}
+			m.Scope = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Scope |= APIKeyScope(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Folders", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Folders = append(m.Folders, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGuiconfiguration(dAtA[iNdEx:])
@@ -0,0 +1,281 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Previous versions of the configuration are kept in this directory
+	// next to the config file.
+	historyDirName    = "config-history"
+	historyTimeFormat = "20060102-150405.000"
+	historyPrefix     = "config-"
+	historySuffix     = ".xml"
+
+	// Number of unchanged lines shown around the changes in a diff.
+	diffContext = 3
+	// Above this many line comparisons we don't bother finding the
+	// smallest diff, and show everything in between as changed.
+	maxDiffWork = 1 << 22
+)
+
+var ErrNoSuchHistoryEntry = errors.New("no such configuration in history")
+
+// A HistoryEntry is a previously saved version of the configuration. The
+// ID is the time it was saved.
+type HistoryEntry struct {
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+	Size int64     `json:"size"`
+}
+
+func (w *wrapper) historyDir() string {
+	return filepath.Join(filepath.Dir(w.path), historyDirName)
+}
+
+// History returns the saved versions of the configuration, oldest first.
+// The newest is the current configuration.
+func (w *wrapper) History() ([]HistoryEntry, error) {
+	infos, err := ioutil.ReadDir(w.historyDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	entries := make([]HistoryEntry, 0, len(infos))
+	for _, info := range infos {
+		id := strings.TrimSuffix(strings.TrimPrefix(info.Name(), historyPrefix), historySuffix)
+		t, err := parseHistoryID(id)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		entries = append(entries, HistoryEntry{
+			ID:   id,
+			Time: t,
+			Size: info.Size(),
+		})
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].Time.Before(entries[b].Time)
+	})
+	return entries, nil
+}
+
+// HistoricConfig returns the saved version of the configuration with the
+// given ID.
+func (w *wrapper) HistoricConfig(id string) (Configuration, error) {
+	if _, err := parseHistoryID(id); err != nil {
+		return Configuration{}, ErrNoSuchHistoryEntry
+	}
+	fd, err := os.Open(filepath.Join(w.historyDir(), historyPrefix+id+historySuffix))
+	if os.IsNotExist(err) {
+		return Configuration{}, ErrNoSuchHistoryEntry
+	} else if err != nil {
+		return Configuration{}, err
+	}
+	defer fd.Close()
+	cfg, _, err := ReadXML(fd, w.myID)
+	return cfg, err
+}
+
+// saveHistoryLocked keeps a copy of the just saved configuration, unless
+// it's the same as the previous one, and removes the oldest copies beyond
+// the configured number.
+func (w *wrapper) saveHistoryLocked(data []byte) error {
+	keep := w.cfg.Options.ConfigHistory
+	entries, err := w.History()
+	if err != nil {
+		return err
+	}
+	dir := w.historyDir()
+
+	if keep > 0 {
+		var prev []byte
+		if len(entries) > 0 {
+			prev, _ = ioutil.ReadFile(filepath.Join(dir, historyPrefix+entries[len(entries)-1].ID+historySuffix))
+		}
+		if !bytes.Equal(prev, data) {
+			if err := os.MkdirAll(dir, 0700); err != nil {
+				return err
+			}
+			id := time.Now().UTC().Format(historyTimeFormat)
+			if err := ioutil.WriteFile(filepath.Join(dir, historyPrefix+id+historySuffix), data, 0600); err != nil {
+				return err
+			}
+			entries = append(entries, HistoryEntry{ID: id})
+		}
+	}
+
+	for len(entries) > keep {
+		if err := os.Remove(filepath.Join(dir, historyPrefix+entries[0].ID+historySuffix)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		entries = entries[1:]
+	}
+	return nil
+}
+
+func parseHistoryID(id string) (time.Time, error) {
+	return time.ParseInLocation(historyTimeFormat, id, time.UTC)
+}
+
+// Diff returns the differences between the XML representations of the two
+// configurations, in unified diff format.
+func Diff(from, to Configuration) (string, error) {
+	var fromBuf, toBuf bytes.Buffer
+	if err := from.WriteXML(&fromBuf); err != nil {
+		return "", err
+	}
+	if err := to.WriteXML(&toBuf); err != nil {
+		return "", err
+	}
+	return diffLines(splitLines(fromBuf.String()), splitLines(toBuf.String())), nil
+}
+
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+func diffLines(a, b []string) string {
+	ops := diffOps(a, b)
+
+	var out strings.Builder
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		// Start a hunk with some context before the first change, and
+		// extend it for as long as changes are close enough together.
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(ops) && j <= end+2*diffContext; j++ {
+			if ops[j].kind != ' ' {
+				end = j
+			}
+		}
+		end += diffContext + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		aBefore, bBefore := countLines(ops[:start])
+		aCount, bCount := countLines(ops[start:end])
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", aBefore+1, aCount, bBefore+1, bCount)
+		for _, op := range ops[start:end] {
+			out.WriteByte(op.kind)
+			out.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				out.WriteByte('\n')
+			}
+		}
+		i = end
+	}
+	return out.String()
+}
+
+// countLines returns the number of lines of the old and new text covered
+// by the operations.
+func countLines(ops []diffOp) (int, int) {
+	var a, b int
+	for _, op := range ops {
+		if op.kind != '+' {
+			a++
+		}
+		if op.kind != '-' {
+			b++
+		}
+	}
+	return a, b
+}
+
+// diffOps returns the operations turning a into b, using the longest common
+// subsequence of lines.
+func diffOps(a, b []string) []diffOp {
+	var prefix, suffix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(am)*len(bm) > maxDiffWork {
+		for _, line := range am {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range bm {
+			ops = append(ops, diffOp{'+', line})
+		}
+	} else {
+		// lcs[i][j] is the length of the longest common subsequence of
+		// am[i:] and bm[j:].
+		lcs := make([][]int, len(am)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(bm)+1)
+		}
+		for i := len(am) - 1; i >= 0; i-- {
+			for j := len(bm) - 1; j >= 0; j-- {
+				if am[i] == bm[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else if lcs[i+1][j] >= lcs[i][j+1] {
+					lcs[i][j] = lcs[i+1][j]
+				} else {
+					lcs[i][j] = lcs[i][j+1]
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(am) || j < len(bm) {
+			switch {
+			case i < len(am) && j < len(bm) && am[i] == bm[j]:
+				ops = append(ops, diffOp{' ', am[i]})
+				i++
+				j++
+			case j == len(bm) || i < len(am) && lcs[i+1][j] >= lcs[i][j+1]:
+				ops = append(ops, diffOp{'-', am[i]})
+				i++
+			default:
+				ops = append(ops, diffOp{'+', bm[j]})
+				j++
+			}
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syncthing-configHistory-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := New(device1)
+	cfg.Options.ConfigHistory = 2
+	w := wrap(filepath.Join(dir, "config.xml"), cfg, device1)
+	defer w.stop()
+
+	setName := func(name string) {
+		t.Helper()
+		waiter, err := w.Modify(func(cfg *Configuration) {
+			cfg.GUI.User = name
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		waiter.Wait()
+		if err := w.Save(); err != nil {
+			t.Fatal(err)
+		}
+		// Make sure the next entry gets a different ID.
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	setName("first")
+	setName("first") // unchanged, doesn't add an entry
+	setName("second")
+	setName("third")
+
+	entries, err := w.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %d", len(entries))
+	}
+
+	old, err := w.HistoricConfig(entries[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if old.GUI.User != "second" {
+		t.Errorf("Expected the oldest kept entry to have user %q, got %q", "second", old.GUI.User)
+	}
+
+	if _, err := w.HistoricConfig("../config"); err != ErrNoSuchHistoryEntry {
+		t.Errorf("Expected %v, got %v", ErrNoSuchHistoryEntry, err)
+	}
+
+	diff, err := Diff(old, w.RawCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "\n-        <user>second</user>\n+        <user>third</user>\n") {
+		t.Errorf("Unexpected diff:\n%s", diff)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	a := strings.Split("a b c d e f g h i j k l m n o p", " ")
+	b := strings.Split("a b x d e f g h i j k l m n p q", " ")
+	for i := range a {
+		a[i] += "\n"
+	}
+	for i := range b {
+		b[i] += "\n"
+	}
+
+	expected := `@@ -1,6 +1,6 @@
+ a
+ b
+-c
++x
+ d
+ e
+ f
+@@ -12,5 +12,5 @@
+ l
+ m
+ n
+-o
+ p
++q
+`
+	if diff := diffLines(a, b); diff != expected {
+		t.Errorf("Unexpected diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+func (a IntroducerAuthority) String() string {
+	switch a {
+	case IntroducerAuthorityMembership:
+		return "membership"
+	case IntroducerAuthorityAddresses:
+		return "addresses"
+	case IntroducerAuthorityFull:
+		return "full"
+	default:
+		return "unknown"
+	}
+}
+
+func (a IntroducerAuthority) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *IntroducerAuthority) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "membership":
+		*a = IntroducerAuthorityMembership
+	case "addresses":
+		*a = IntroducerAuthorityAddresses
+	case "full":
+		*a = IntroducerAuthorityFull
+	default:
+		*a = IntroducerAuthorityMembership
+	}
+	return nil
+}
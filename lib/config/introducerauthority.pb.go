@@ -0,0 +1,76 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/introducerauthority.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type IntroducerAuthority int32
+
+const (
+	IntroducerAuthorityMembership IntroducerAuthority = 0
+	IntroducerAuthorityAddresses  IntroducerAuthority = 1
+	IntroducerAuthorityFull       IntroducerAuthority = 2
+)
+
+var IntroducerAuthority_name = map[int32]string{
+	0: "INTRODUCER_AUTHORITY_MEMBERSHIP",
+	1: "INTRODUCER_AUTHORITY_ADDRESSES",
+	2: "INTRODUCER_AUTHORITY_FULL",
+}
+
+var IntroducerAuthority_value = map[string]int32{
+	"INTRODUCER_AUTHORITY_MEMBERSHIP": 0,
+	"INTRODUCER_AUTHORITY_ADDRESSES":  1,
+	"INTRODUCER_AUTHORITY_FULL":       2,
+}
+
+func (IntroducerAuthority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_083f825cf6ffb44a, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.IntroducerAuthority", IntroducerAuthority_name, IntroducerAuthority_value)
+}
+
+func init() {
+	proto.RegisterFile("lib/config/introducerauthority.proto", fileDescriptor_083f825cf6ffb44a)
+}
+
+var fileDescriptor_083f825cf6ffb44a = []byte{
+	// 287 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0xc9, 0xc9, 0x4c, 0xd2,
+	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0xcf, 0xcc, 0x2b, 0x29, 0xca, 0x4f, 0x29, 0x4d, 0x4e,
+	0x2d, 0x4a, 0x2c, 0x2d, 0xc9, 0xc8, 0x2f, 0xca, 0x2c, 0xa9, 0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9,
+	0x17, 0x62, 0x83, 0xa8, 0x90, 0x52, 0x2e, 0x4a, 0x2d, 0xc8, 0x2f, 0xd6, 0x07, 0x0b, 0x26, 0x95,
+	0xa6, 0xe9, 0xa7, 0xe7, 0xa7, 0xe7, 0x83, 0x39, 0x60, 0x16, 0x44, 0xb1, 0xd6, 0x53, 0x46, 0x2e,
+	0x61, 0x4f, 0xb8, 0x51, 0x8e, 0x30, 0xa3, 0x84, 0xdc, 0xb8, 0xe4, 0x3d, 0xfd, 0x42, 0x82, 0xfc,
+	0x5d, 0x42, 0x9d, 0x5d, 0x83, 0xe2, 0x1d, 0x43, 0x43, 0x3c, 0xfc, 0x83, 0x3c, 0x43, 0x22, 0xe3,
+	0x7d, 0x5d, 0x7d, 0x9d, 0x5c, 0x83, 0x82, 0x3d, 0x3c, 0x03, 0x04, 0x18, 0xa4, 0x14, 0xbb, 0xe6,
+	0x2a, 0xc8, 0x62, 0xd1, 0xed, 0x9b, 0x9a, 0x9b, 0x94, 0x5a, 0x54, 0x9c, 0x91, 0x59, 0x20, 0xe4,
+	0xc2, 0x25, 0x87, 0xd5, 0x1c, 0x47, 0x17, 0x97, 0x20, 0xd7, 0xe0, 0x60, 0xd7, 0x60, 0x01, 0x46,
+	0x29, 0x85, 0xae, 0xb9, 0x0a, 0x32, 0x58, 0x8c, 0x71, 0x4c, 0x49, 0x29, 0x4a, 0x2d, 0x2e, 0x4e,
+	0x2d, 0x16, 0xb2, 0xe2, 0x92, 0xc4, 0x6a, 0x8a, 0x5b, 0xa8, 0x8f, 0x8f, 0x00, 0x93, 0x94, 0x74,
+	0xd7, 0x5c, 0x05, 0x71, 0x2c, 0x06, 0xb8, 0x95, 0xe6, 0xe4, 0x48, 0xb1, 0xac, 0x58, 0x22, 0xc7,
+	0xe0, 0xe4, 0x7d, 0xe2, 0xa1, 0x1c, 0xc3, 0x85, 0x87, 0x72, 0x0c, 0x27, 0x1e, 0xc9, 0x31, 0x5e,
+	0x78, 0x24, 0xc7, 0x38, 0xe1, 0xb1, 0x1c, 0xc3, 0x82, 0xc7, 0x72, 0x8c, 0x17, 0x1e, 0xcb, 0x31,
+	0xdc, 0x78, 0x2c, 0xc7, 0x10, 0xa5, 0x99, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c, 0x9f,
+	0xab, 0x5f, 0x5c, 0x99, 0x97, 0x5c, 0x92, 0x91, 0x99, 0x97, 0x8e, 0xc4, 0x42, 0x84, 0x7d, 0x12,
+	0x1b, 0x38, 0xec, 0x8c, 0x01, 0x03, 0x00, 0x74, 0x52, 0x43, 0x84, 0x90, 0x01, 0x00, 0x00,
+}
@@ -30,6 +30,9 @@ type LDAPConfiguration struct {
 	InsecureSkipVerify bool          `protobuf:"varint,4,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecureSkipVerify" xml:"insecureSkipVerify,omitempty" default:"false"`
 	SearchBaseDN       string        `protobuf:"bytes,5,opt,name=search_base_dn,json=searchBaseDn,proto3" json:"searchBaseDN" xml:"searchBaseDN,omitempty"`
 	SearchFilter       string        `protobuf:"bytes,6,opt,name=search_filter,json=searchFilter,proto3" json:"searchFilter" xml:"searchFilter,omitempty"`
+	// PEM file with the certificate authorities to verify the server
+	// certificate against, instead of the system ones.
+	CACertificateFile string `protobuf:"bytes,7,opt,name=ca_certificate_file,json=caCertificateFile,proto3" json:"caCertificateFile" xml:"caCertificateFile,omitempty"`
 }
 
 func (m *LDAPConfiguration) Reset()         { *m = LDAPConfiguration{} }
@@ -74,39 +77,43 @@ func init() {
 }
 
 var fileDescriptor_9681ad7e41c73956 = []byte{
-	// 500 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xbf, 0x6f, 0xd3, 0x40,
-	0x14, 0xb6, 0x81, 0xba, 0xc4, 0x2a, 0x15, 0x35, 0x50, 0x4c, 0x55, 0xf9, 0x22, 0xcb, 0x43, 0x90,
-	0x50, 0x22, 0x95, 0xad, 0x4c, 0x35, 0x15, 0x03, 0x20, 0x84, 0x5c, 0xe8, 0xc0, 0x12, 0xf9, 0xc7,
-	0x39, 0x39, 0xd5, 0x39, 0x5b, 0x77, 0xe7, 0xaa, 0xe1, 0xaf, 0x80, 0xfe, 0x05, 0xdd, 0xf8, 0x57,
-	0xba, 0xc5, 0x23, 0xd3, 0x49, 0x4d, 0x36, 0x8f, 0x1e, 0x99, 0x50, 0xce, 0x4e, 0x63, 0xa7, 0x51,
-	0xb7, 0xf7, 0xbe, 0xef, 0xbd, 0xef, 0x7d, 0x77, 0x4f, 0x4f, 0x35, 0x23, 0xe4, 0xf5, 0xfc, 0x18,
-	0x87, 0x68, 0xd0, 0x8b, 0x02, 0x37, 0x29, 0xc3, 0x94, 0xb8, 0x0c, 0xc5, 0xb8, 0x9b, 0x90, 0x98,
-	0xc5, 0x9a, 0x52, 0x82, 0x7b, 0xc6, 0x4a, 0x2d, 0x23, 0x2e, 0xa6, 0x49, 0x4c, 0x58, 0x59, 0xb7,
-	0xd7, 0x82, 0x17, 0x55, 0x68, 0xfe, 0x56, 0xd4, 0x9d, 0xcf, 0xc7, 0x47, 0x5f, 0xdf, 0xd7, 0xe5,
-	0xb4, 0xef, 0xea, 0xa6, 0x1b, 0x04, 0x04, 0x52, 0xaa, 0xcb, 0x6d, 0xb9, 0xd3, 0xb2, 0xdf, 0xe5,
-	0x1c, 0x2c, 0xa0, 0x82, 0x83, 0x97, 0x17, 0xa3, 0xe8, 0xd0, 0xac, 0xf2, 0x37, 0xf1, 0x08, 0x31,
-	0x38, 0x4a, 0xd8, 0xd8, 0xcc, 0x27, 0xd6, 0xce, 0x1d, 0xd4, 0x59, 0x34, 0x6a, 0xb1, 0xba, 0xe9,
-	0x21, 0x1c, 0xf4, 0x03, 0xac, 0x3f, 0x10, 0xb2, 0xa7, 0x53, 0x0e, 0x14, 0x1b, 0xe1, 0xe0, 0xf8,
-	0x4b, 0xce, 0x81, 0xe2, 0x89, 0xa8, 0xe0, 0x60, 0x57, 0xe8, 0x97, 0x69, 0x53, 0xfe, 0xe9, 0x2a,
-	0x58, 0x4c, 0xac, 0xaa, 0xef, 0x32, 0xb3, 0x2a, 0x2d, 0xa7, 0x44, 0xb0, 0x76, 0xae, 0xb6, 0x6e,
-	0xdf, 0xae, 0x3f, 0x6c, 0xcb, 0x9d, 0xed, 0x83, 0x17, 0xdd, 0xf2, 0x63, 0xba, 0xf3, 0x57, 0x7f,
-	0x5b, 0x90, 0xf6, 0x51, 0xce, 0xc1, 0xb2, 0xb6, 0xe0, 0xe0, 0x95, 0xb0, 0x70, 0x8b, 0x34, 0x5d,
-	0x3c, 0x5b, 0x83, 0x3b, 0xcb, 0x76, 0xed, 0x8f, 0xac, 0x3e, 0x47, 0x98, 0x42, 0x3f, 0x25, 0xb0,
-	0x4f, 0xcf, 0x50, 0xd2, 0x3f, 0x87, 0x04, 0x85, 0x63, 0xfd, 0x51, 0x5b, 0xee, 0x3c, 0xb6, 0xd3,
-	0x9c, 0x03, 0x6d, 0xc1, 0x9f, 0x9c, 0xa1, 0xe4, 0x54, 0xb0, 0x05, 0x07, 0x07, 0x62, 0xea, 0x5d,
-	0xaa, 0x36, 0xbe, 0x1d, 0xc0, 0xd0, 0x4d, 0x23, 0x76, 0x68, 0x86, 0x6e, 0x44, 0xe1, 0xdc, 0xce,
-	0xfe, 0x7d, 0x0d, 0xff, 0x26, 0xd6, 0x86, 0xa8, 0x74, 0xd6, 0x8c, 0xd4, 0xae, 0x64, 0x75, 0x9b,
-	0x42, 0x97, 0xf8, 0xc3, 0xbe, 0xe7, 0x52, 0x38, 0x5f, 0xcd, 0x86, 0x58, 0xcd, 0xcf, 0x29, 0x07,
-	0x5b, 0x27, 0x82, 0xb1, 0x5d, 0x0a, 0xc5, 0x82, 0xb6, 0x68, 0x2d, 0x2f, 0x38, 0xd8, 0x17, 0x6e,
-	0xeb, 0x60, 0xf3, 0x9b, 0x76, 0xd7, 0x53, 0xc5, 0xc4, 0x6a, 0x28, 0x5d, 0x66, 0x56, 0x63, 0x92,
-	0x53, 0x67, 0xb1, 0x16, 0xab, 0x4f, 0x2a, 0x87, 0x21, 0x8a, 0x18, 0x24, 0xba, 0x22, 0x0c, 0x7e,
-	0x5c, 0x1a, 0xfa, 0x20, 0xf0, 0x15, 0x43, 0x25, 0xb8, 0xd6, 0xd0, 0x2a, 0xe5, 0x34, 0x74, 0xec,
-	0x4f, 0xd7, 0x37, 0x86, 0x94, 0xdd, 0x18, 0xd2, 0xf5, 0xd4, 0x90, 0xb3, 0xa9, 0x21, 0xff, 0x9a,
-	0x19, 0xd2, 0xd5, 0xcc, 0x90, 0xb3, 0x99, 0x21, 0xfd, 0x9d, 0x19, 0xd2, 0x8f, 0xd7, 0x03, 0xc4,
-	0x86, 0xa9, 0xd7, 0xf5, 0xe3, 0x51, 0x8f, 0x8e, 0xb1, 0xcf, 0x86, 0x08, 0x0f, 0x6a, 0xd1, 0xf2,
-	0xfe, 0x3c, 0x45, 0xdc, 0xd9, 0xdb, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xbf, 0x64, 0x4b, 0x05,
-	0xc0, 0x03, 0x00, 0x00,
+	// 562 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xb1, 0x6f, 0xd3, 0x4e,
+	0x18, 0x8d, 0x7f, 0x3f, 0x9a, 0x50, 0xab, 0x54, 0xc4, 0x85, 0x62, 0x4a, 0xe5, 0x0b, 0x91, 0x87,
+	0x20, 0xa1, 0x44, 0x2a, 0x5b, 0x99, 0xe2, 0x54, 0x1d, 0x00, 0x21, 0xe4, 0x42, 0x07, 0x96, 0xe8,
+	0x6c, 0x9f, 0x93, 0x53, 0x9d, 0xb3, 0xe5, 0xbb, 0x54, 0x0d, 0x7f, 0x05, 0xca, 0xc4, 0xd8, 0x8d,
+	0x7f, 0xa5, 0x4c, 0xf1, 0xc8, 0xc2, 0x49, 0x4d, 0x36, 0x8f, 0x1e, 0x99, 0x50, 0xce, 0x49, 0x63,
+	0xc7, 0x51, 0xb7, 0xef, 0xde, 0xfb, 0xbe, 0xf7, 0xde, 0xdd, 0x27, 0x9d, 0x5c, 0xf7, 0xb0, 0xd5,
+	0xb2, 0x7d, 0xe2, 0xe2, 0x5e, 0xcb, 0x73, 0x60, 0x90, 0x96, 0xc3, 0x10, 0x32, 0xec, 0x93, 0x66,
+	0x10, 0xfa, 0xcc, 0x57, 0xca, 0x29, 0x78, 0xa0, 0xad, 0xf5, 0xb2, 0x10, 0x12, 0x1a, 0xf8, 0x21,
+	0x4b, 0xfb, 0x0e, 0xb6, 0xd1, 0xd5, 0xa2, 0xac, 0xff, 0xa9, 0xc8, 0xd5, 0x0f, 0x27, 0xed, 0x4f,
+	0x9d, 0xac, 0x9c, 0xf2, 0x45, 0xae, 0x40, 0xc7, 0x09, 0x11, 0xa5, 0xaa, 0x54, 0x93, 0x1a, 0xdb,
+	0xc6, 0xdb, 0x98, 0x83, 0x25, 0x94, 0x70, 0xf0, 0xec, 0x6a, 0xe0, 0x1d, 0xd7, 0x17, 0xe7, 0xd7,
+	0xfe, 0x00, 0x33, 0x34, 0x08, 0xd8, 0xa8, 0x1e, 0x4f, 0xf4, 0x6a, 0x01, 0x35, 0x97, 0x83, 0x8a,
+	0x2f, 0x57, 0x2c, 0x4c, 0x9c, 0xae, 0x43, 0xd4, 0xff, 0x84, 0xec, 0xf9, 0x94, 0x83, 0xb2, 0x81,
+	0x89, 0x73, 0xf2, 0x31, 0xe6, 0xa0, 0x6c, 0x89, 0x2a, 0xe1, 0x60, 0x5f, 0xe8, 0xa7, 0xc7, 0xbc,
+	0xfc, 0xe3, 0x75, 0x30, 0x99, 0xe8, 0x8b, 0xb9, 0x71, 0xa4, 0x2f, 0xb4, 0xcc, 0x14, 0x21, 0xca,
+	0xa5, 0xbc, 0x7d, 0x77, 0x77, 0xf5, 0xff, 0x9a, 0xd4, 0xd8, 0x3d, 0x7a, 0xda, 0x4c, 0x1f, 0xa6,
+	0x39, 0xbf, 0xf5, 0xe7, 0x25, 0x69, 0xb4, 0x63, 0x0e, 0x56, 0xbd, 0x09, 0x07, 0xcf, 0x45, 0x84,
+	0x3b, 0x24, 0x9f, 0x62, 0x6f, 0x03, 0x6e, 0xae, 0xc6, 0x95, 0x9f, 0x92, 0xfc, 0x04, 0x13, 0x8a,
+	0xec, 0x61, 0x88, 0xba, 0xf4, 0x02, 0x07, 0xdd, 0x4b, 0x14, 0x62, 0x77, 0xa4, 0x3e, 0xa8, 0x49,
+	0x8d, 0x87, 0xc6, 0x30, 0xe6, 0x40, 0x59, 0xf2, 0x67, 0x17, 0x38, 0x38, 0x17, 0x6c, 0xc2, 0xc1,
+	0x91, 0x70, 0x2d, 0x52, 0x19, 0xfb, 0x9a, 0x83, 0x5c, 0x38, 0xf4, 0xd8, 0x71, 0xdd, 0x85, 0x1e,
+	0x45, 0xf3, 0x38, 0x87, 0xf7, 0x0d, 0xfc, 0x9d, 0xe8, 0x5b, 0xa2, 0xd3, 0xdc, 0x60, 0xa9, 0x5c,
+	0x4b, 0xf2, 0x2e, 0x45, 0x30, 0xb4, 0xfb, 0x5d, 0x0b, 0x52, 0x34, 0x5f, 0xcd, 0x96, 0x58, 0xcd,
+	0xb7, 0x29, 0x07, 0x3b, 0x67, 0x82, 0x31, 0x20, 0x45, 0x62, 0x41, 0x3b, 0x34, 0x73, 0x4e, 0x38,
+	0x38, 0x14, 0x69, 0xb3, 0x60, 0xfe, 0x99, 0xf6, 0x37, 0x53, 0xc9, 0x44, 0xcf, 0x29, 0x8d, 0x23,
+	0x3d, 0xe7, 0x64, 0x66, 0x59, 0xa2, 0xf8, 0xf2, 0xa3, 0x45, 0x42, 0x17, 0x7b, 0x0c, 0x85, 0x6a,
+	0x59, 0x04, 0x7c, 0xb7, 0x0a, 0x74, 0x2a, 0xf0, 0xb5, 0x40, 0x29, 0xb8, 0x31, 0xd0, 0x3a, 0x65,
+	0xe6, 0x74, 0x94, 0x5f, 0x92, 0xbc, 0x67, 0xc3, 0xae, 0x8d, 0x42, 0x86, 0x5d, 0x6c, 0x43, 0x86,
+	0xe6, 0xce, 0x48, 0xad, 0x08, 0xdf, 0x1f, 0xd2, 0x94, 0x83, 0x6a, 0xa7, 0xdd, 0x59, 0xd1, 0xa7,
+	0xd8, 0x43, 0x31, 0x07, 0x55, 0x1b, 0xae, 0x81, 0x09, 0x07, 0x2f, 0x45, 0xa4, 0x02, 0x93, 0xcf,
+	0xf5, 0xe2, 0x1e, 0x3e, 0x99, 0xe8, 0x45, 0xe1, 0x71, 0xa4, 0x17, 0x23, 0x98, 0xc5, 0x3e, 0xe3,
+	0xfd, 0xcd, 0xad, 0x56, 0x8a, 0x6e, 0xb5, 0xd2, 0xcd, 0x54, 0x93, 0xa2, 0xa9, 0x26, 0x7d, 0x9f,
+	0x69, 0xa5, 0xeb, 0x99, 0x26, 0x45, 0x33, 0xad, 0xf4, 0x7b, 0xa6, 0x95, 0xbe, 0xbe, 0xea, 0x61,
+	0xd6, 0x1f, 0x5a, 0x4d, 0xdb, 0x1f, 0xb4, 0xe8, 0x88, 0xd8, 0xac, 0x8f, 0x49, 0x2f, 0x53, 0xad,
+	0xfe, 0x12, 0xab, 0x2c, 0xfe, 0x8c, 0x37, 0xff, 0x06, 0x00, 0x40, 0x91, 0xe4, 0x41, 0x8c, 0x04,
+	0x00, 0x00,
 }
 
 func (m *LDAPConfiguration) Marshal() (dAtA []byte, err error) {
@@ -129,6 +136,13 @@ func (m *LDAPConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.CACertificateFile) > 0 {
+		i -= len(m.CACertificateFile)
+		copy(dAtA[i:], m.CACertificateFile)
+		i = encodeVarintLdapconfiguration(dAtA, i, uint64(len(m.CACertificateFile)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.SearchFilter) > 0 {
 		i -= len(m.SearchFilter)
 		copy(dAtA[i:], m.SearchFilter)
@@ -214,6 +228,10 @@ func (m *LDAPConfiguration) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovLdapconfiguration(uint64(l))
 	}
+	l = len(m.CACertificateFile)
+	if l > 0 {
+		n += 1 + l + sovLdapconfiguration(uint64(l))
+	}
 	return n
 }
 
@@ -419,6 +437,38 @@ func (m *LDAPConfiguration) Unmarshal(dAtA []byte) error {
 			}
 			m.SearchFilter = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CACertificateFile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLdapconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLdapconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLdapconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CACertificateFile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLdapconfiguration(dAtA[iNdEx:])
@@ -115,35 +115,35 @@ func init() {
 func init() { proto.RegisterFile("lib/config/observed.proto", fileDescriptor_49f68ff7b178722f) }
 
 var fileDescriptor_49f68ff7b178722f = []byte{
-	// 440 bytes of a gzipped FileDescriptorProto
+	// 438 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x93, 0x3f, 0x6f, 0xd4, 0x30,
 	0x00, 0xc5, 0xe3, 0xf4, 0x68, 0x39, 0x53, 0xfe, 0x28, 0xd3, 0x71, 0x83, 0x5d, 0x9d, 0x32, 0x1c,
 	0x02, 0x25, 0xfc, 0x9b, 0x10, 0x42, 0xe2, 0x74, 0x42, 0x3a, 0x75, 0x40, 0x8a, 0x98, 0x98, 0x48,
-	0x62, 0x37, 0xb5, 0x94, 0x9c, 0xab, 0xc4, 0xad, 0xca, 0xc6, 0xc8, 0xd8, 0xf2, 0x09, 0xf8, 0x38,
-	0xb7, 0x5d, 0x46, 0xc4, 0x60, 0xd4, 0xcb, 0x96, 0x31, 0x12, 0x3b, 0x8a, 0x9d, 0xf8, 0x6e, 0x42,
-	0x4c, 0x6c, 0x7e, 0x4f, 0xcf, 0x3f, 0xf9, 0xbd, 0x28, 0xf0, 0x61, 0xca, 0x22, 0x3f, 0xe6, 0xcb,
-	0x13, 0x96, 0xf8, 0x3c, 0x2a, 0x68, 0x7e, 0x41, 0x89, 0x77, 0x96, 0x73, 0xc1, 0x9d, 0x7d, 0x6d,
-	0x8f, 0x71, 0xc2, 0x79, 0x92, 0x52, 0x5f, 0xb9, 0xd1, 0xf9, 0x89, 0x2f, 0x58, 0x46, 0x0b, 0x11,
-	0x66, 0x67, 0x3a, 0x38, 0x1e, 0xd2, 0x4b, 0xa1, 0x8f, 0x93, 0xdf, 0x00, 0xde, 0x7b, 0xdf, 0x61,
-	0xde, 0xf1, 0x94, 0xd0, 0xdc, 0xf9, 0x04, 0x07, 0xed, 0x85, 0x11, 0x38, 0x02, 0xd3, 0x3b, 0xcf,
-	0xc7, 0x9e, 0xa6, 0x79, 0x3d, 0xcd, 0xfb, 0xd0, 0xd3, 0x66, 0x4f, 0x57, 0x12, 0x5b, 0xb5, 0xc4,
-	0x2a, 0xdf, 0x48, 0x7c, 0xff, 0x32, 0x4b, 0x5f, 0x4d, 0x5a, 0xf1, 0x24, 0x14, 0x22, 0x9f, 0x5c,
-	0xfd, 0xc2, 0xa0, 0x5e, 0xbb, 0x43, 0xe3, 0x04, 0x2a, 0xe9, 0xbc, 0x81, 0x36, 0x23, 0x23, 0xfb,
-	0x08, 0x4c, 0x87, 0x33, 0x6f, 0x23, 0xb1, 0xbd, 0x98, 0xd7, 0x12, 0xdb, 0x8c, 0x34, 0x12, 0xdf,
-	0x55, 0x0c, 0x46, 0x34, 0xa1, 0x5e, 0xbb, 0x07, 0xdd, 0xf9, 0x5b, 0xe9, 0xda, 0x8b, 0x79, 0x60,
-	0x33, 0xe2, 0xbc, 0x85, 0xb7, 0xd2, 0x30, 0xa2, 0xe9, 0x68, 0x4f, 0x21, 0x1e, 0xd7, 0x12, 0x6b,
-	0xa3, 0x91, 0xf8, 0x81, 0xba, 0xaf, 0x94, 0x41, 0xc0, 0xad, 0x0c, 0x74, 0x70, 0x72, 0xbd, 0xb7,
-	0xed, 0x3d, 0xa7, 0x17, 0x2c, 0xa6, 0xff, 0xa1, 0xf7, 0x35, 0x30, 0xc5, 0x0f, 0x67, 0x5f, 0x40,
-	0x4b, 0xf9, 0x29, 0xf1, 0xcb, 0x84, 0x89, 0xd3, 0xf3, 0xc8, 0x8b, 0x79, 0xe6, 0x17, 0x9f, 0x97,
-	0xb1, 0x38, 0x65, 0xcb, 0x64, 0xe7, 0xd4, 0x7e, 0x70, 0xf5, 0x88, 0x98, 0xa7, 0x9e, 0x7e, 0xeb,
-	0x62, 0x6e, 0x56, 0xbb, 0x4d, 0x3a, 0xe7, 0x6f, 0xdb, 0x35, 0x6b, 0xd7, 0xe4, 0xbe, 0x96, 0x2e,
-	0xd8, 0xd9, 0xf2, 0x35, 0x1c, 0x2c, 0xc3, 0x8c, 0x76, 0x53, 0x4e, 0xdb, 0x56, 0xad, 0x36, 0xad,
-	0x5a, 0x61, 0x78, 0x43, 0xa3, 0x02, 0x95, 0x72, 0x8e, 0xe1, 0x41, 0x48, 0x48, 0x4e, 0x8b, 0x62,
-	0x34, 0x50, 0x80, 0x67, 0xb5, 0xc4, 0xbd, 0xd5, 0x48, 0xec, 0x28, 0x46, 0xa7, 0x0d, 0xe6, 0x70,
-	0xd7, 0x08, 0xfa, 0xf8, 0xec, 0x78, 0x75, 0x83, 0xac, 0xf2, 0x06, 0x59, 0xab, 0x0d, 0x02, 0xe5,
-	0x06, 0x81, 0xab, 0x0a, 0x59, 0xdf, 0x2b, 0x04, 0xca, 0x0a, 0x59, 0x3f, 0x2a, 0x64, 0x7d, 0x7c,
-	0xf4, 0x0f, 0x53, 0xe9, 0x9f, 0x20, 0xda, 0x57, 0x93, 0xbd, 0xf8, 0x13, 0x00, 0x00, 0xff, 0xff,
-	0xd0, 0xf0, 0x82, 0x78, 0x30, 0x03, 0x00, 0x00,
+	0x62, 0x37, 0xb5, 0x94, 0xc4, 0x55, 0xe2, 0x56, 0x65, 0x63, 0x64, 0x6c, 0xf9, 0x04, 0x7c, 0x9c,
+	0xdb, 0x2e, 0x23, 0x62, 0x30, 0xea, 0xdd, 0x96, 0x31, 0x12, 0x3b, 0x8a, 0x9d, 0xf3, 0x65, 0x42,
+	0x4c, 0x6c, 0x7e, 0x4f, 0xcf, 0x3f, 0xf9, 0xbd, 0x28, 0xf0, 0x61, 0xca, 0x22, 0x3f, 0xe6, 0xf9,
+	0x09, 0x4b, 0x7c, 0x1e, 0x95, 0xb4, 0xb8, 0xa0, 0xc4, 0x3b, 0x2b, 0xb8, 0xe0, 0xce, 0xbe, 0xb6,
+	0xc7, 0x38, 0xe1, 0x3c, 0x49, 0xa9, 0xaf, 0xdc, 0xe8, 0xfc, 0xc4, 0x17, 0x2c, 0xa3, 0xa5, 0x08,
+	0xb3, 0x33, 0x1d, 0x1c, 0x0f, 0xe9, 0xa5, 0xd0, 0xc7, 0xc9, 0x6f, 0x00, 0xef, 0xbd, 0xef, 0x30,
+	0xef, 0x78, 0x4a, 0x68, 0xe1, 0x7c, 0x82, 0x83, 0xf6, 0xc2, 0x08, 0x1c, 0x81, 0xe9, 0x9d, 0xe7,
+	0x63, 0x4f, 0xd3, 0xbc, 0x2d, 0xcd, 0xfb, 0xb0, 0xa5, 0xcd, 0x9e, 0x2e, 0x25, 0xb6, 0x6a, 0x89,
+	0x55, 0xbe, 0x91, 0xf8, 0xfe, 0x65, 0x96, 0xbe, 0x9a, 0xb4, 0xe2, 0x49, 0x28, 0x44, 0x31, 0xb9,
+	0xfa, 0x85, 0x41, 0xbd, 0x72, 0x87, 0xc6, 0x09, 0x54, 0xd2, 0x79, 0x03, 0x6d, 0x46, 0x46, 0xf6,
+	0x11, 0x98, 0x0e, 0x67, 0xde, 0x5a, 0x62, 0x7b, 0x31, 0xaf, 0x25, 0xb6, 0x19, 0x69, 0x24, 0xbe,
+	0xab, 0x18, 0x8c, 0x68, 0x42, 0xbd, 0x72, 0x0f, 0xba, 0xf3, 0xb7, 0xca, 0xb5, 0x17, 0xf3, 0xc0,
+	0x66, 0xc4, 0x79, 0x0b, 0x6f, 0xa5, 0x61, 0x44, 0xd3, 0xd1, 0x9e, 0x42, 0x3c, 0xae, 0x25, 0xd6,
+	0x46, 0x23, 0xf1, 0x03, 0x75, 0x5f, 0x29, 0x83, 0x80, 0x3b, 0x19, 0xe8, 0xe0, 0xe4, 0x7a, 0x6f,
+	0xd7, 0x7b, 0x4e, 0x2f, 0x58, 0x4c, 0xff, 0x43, 0xef, 0x6b, 0x60, 0x8a, 0x1f, 0xce, 0xbe, 0x80,
+	0x96, 0xf2, 0x53, 0xe2, 0x97, 0x09, 0x13, 0xa7, 0xe7, 0x91, 0x17, 0xf3, 0xcc, 0x2f, 0x3f, 0xe7,
+	0xb1, 0x38, 0x65, 0x79, 0xd2, 0x3b, 0xb5, 0x1f, 0x5c, 0x3d, 0x22, 0xe6, 0xa9, 0xa7, 0xdf, 0xba,
+	0x98, 0x9b, 0xd5, 0x6e, 0x93, 0xce, 0xf9, 0xdb, 0x76, 0xcd, 0xca, 0x35, 0xb9, 0xaf, 0x95, 0x0b,
+	0x7a, 0x5b, 0xbe, 0x86, 0x83, 0x3c, 0xcc, 0x68, 0x37, 0xe5, 0xb4, 0x6d, 0x95, 0x87, 0xbd, 0x56,
+	0xad, 0x30, 0xbc, 0xa1, 0x51, 0x81, 0x4a, 0x39, 0xc7, 0xf0, 0x20, 0x24, 0xa4, 0xa0, 0x65, 0x39,
+	0x1a, 0x28, 0xc0, 0xb3, 0x5a, 0xe2, 0xad, 0xd5, 0x48, 0xec, 0x28, 0x46, 0xa7, 0x0d, 0xe6, 0xb0,
+	0x6f, 0x04, 0xdb, 0xf8, 0xec, 0x78, 0x79, 0x83, 0xac, 0xea, 0x06, 0x59, 0xcb, 0x35, 0x02, 0xd5,
+	0x1a, 0x81, 0xab, 0x0d, 0xb2, 0xbe, 0x6f, 0x10, 0xa8, 0x36, 0xc8, 0xfa, 0xb1, 0x41, 0xd6, 0xc7,
+	0x47, 0xff, 0x30, 0x95, 0xfe, 0x09, 0xa2, 0x7d, 0x35, 0xd9, 0x8b, 0x3f, 0x03, 0x00, 0xd0, 0xf0,
+	0x82, 0x78, 0x30, 0x03, 0x00, 0x00,
 }
 
 func (m *ObservedFolder) Marshal() (dAtA []byte, err error) {
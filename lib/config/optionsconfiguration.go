@@ -25,6 +25,14 @@ func (opts OptionsConfiguration) Copy() OptionsConfiguration {
 	copy(optsCopy.AlwaysLocalNets, opts.AlwaysLocalNets)
 	optsCopy.UnackedNotificationIDs = make([]string, len(opts.UnackedNotificationIDs))
 	copy(optsCopy.UnackedNotificationIDs, opts.UnackedNotificationIDs)
+	optsCopy.RelayPreferences = make([]string, len(opts.RelayPreferences))
+	copy(optsCopy.RelayPreferences, opts.RelayPreferences)
+	optsCopy.DNSDiscoveryZones = make([]string, len(opts.DNSDiscoveryZones))
+	copy(optsCopy.DNSDiscoveryZones, opts.DNSDiscoveryZones)
+	optsCopy.URExcludedCategories = make([]string, len(opts.URExcludedCategories))
+	copy(optsCopy.URExcludedCategories, opts.URExcludedCategories)
+	optsCopy.DisabledListenAddresses = make([]string, len(opts.DisabledListenAddresses))
+	copy(optsCopy.DisabledListenAddresses, opts.DisabledListenAddresses)
 	return optsCopy
 }
 
@@ -33,6 +41,10 @@ func (opts *OptionsConfiguration) prepare(guiPWIsSet bool) {
 
 	opts.RawListenAddresses = util.UniqueTrimmedStrings(opts.RawListenAddresses)
 	opts.RawGlobalAnnServers = util.UniqueTrimmedStrings(opts.RawGlobalAnnServers)
+	opts.RelayPreferences = util.UniqueTrimmedStrings(opts.RelayPreferences)
+	opts.DNSDiscoveryZones = util.UniqueTrimmedStrings(opts.DNSDiscoveryZones)
+	opts.URExcludedCategories = util.UniqueTrimmedStrings(opts.URExcludedCategories)
+	opts.DisabledListenAddresses = util.UniqueTrimmedStrings(opts.DisabledListenAddresses)
 
 	// Very short reconnection intervals are annoying
 	if opts.ReconnectIntervalS < 5 {
@@ -75,6 +87,8 @@ func (opts OptionsConfiguration) IsStunDisabled() bool {
 	return opts.StunKeepaliveMinS < 1 || opts.StunKeepaliveStartS < 1 || !opts.NATEnabled
 }
 
+// ListenAddresses returns the addresses to listen on, with "default"
+// expanded and the disabled ones left out.
 func (opts OptionsConfiguration) ListenAddresses() []string {
 	var addresses []string
 	for _, addr := range opts.RawListenAddresses {
@@ -85,7 +99,21 @@ func (opts OptionsConfiguration) ListenAddresses() []string {
 			addresses = append(addresses, addr)
 		}
 	}
-	return util.UniqueTrimmedStrings(addresses)
+	addresses = util.UniqueTrimmedStrings(addresses)
+	if len(opts.DisabledListenAddresses) == 0 {
+		return addresses
+	}
+	disabled := make(map[string]struct{}, len(opts.DisabledListenAddresses))
+	for _, addr := range opts.DisabledListenAddresses {
+		disabled[addr] = struct{}{}
+	}
+	enabled := addresses[:0]
+	for _, addr := range addresses {
+		if _, ok := disabled[addr]; !ok {
+			enabled = append(enabled, addr)
+		}
+	}
+	return enabled
 }
 
 func (opts OptionsConfiguration) StunServers() []string {
@@ -130,6 +158,11 @@ func (opts OptionsConfiguration) GlobalDiscoveryServers() []string {
 }
 
 func (opts OptionsConfiguration) MaxFolderConcurrency() int {
+	// Concurrent folders each need their own buffers.
+	if opts.LowMemory() {
+		return 1
+	}
+
 	// If a value is set, trust that.
 	if opts.RawMaxFolderConcurrency > 0 {
 		return opts.RawMaxFolderConcurrency
@@ -151,6 +184,15 @@ func (opts OptionsConfiguration) MaxFolderConcurrency() int {
 }
 
 func (opts OptionsConfiguration) MaxConcurrentIncomingRequestKiB() int {
+	// We can't really do less than a couple of concurrent blocks or we'll
+	// pretty much stall completely.
+	const minAllowed = 2 * protocol.MaxBlockSize / 1024
+
+	// Within a memory budget that's all we allow, whatever is configured.
+	if opts.LowMemory() {
+		return minAllowed
+	}
+
 	// Negative is disabled, which in limiter land is spelled zero
 	if opts.RawMaxCIRequestKiB < 0 {
 		return 0
@@ -161,10 +203,7 @@ func (opts OptionsConfiguration) MaxConcurrentIncomingRequestKiB() int {
 		return 256 * 1024 // KiB
 	}
 
-	// We can't really do less than a couple of concurrent blocks or we'll
-	// pretty much stall completely. Check that an explicit value is large
-	// enough.
-	const minAllowed = 2 * protocol.MaxBlockSize / 1024
+	// Check that an explicit value is large enough.
 	if opts.RawMaxCIRequestKiB < minAllowed {
 		return minAllowed
 	}
@@ -187,6 +226,21 @@ func (opts OptionsConfiguration) FeatureFlag(name string) bool {
 	return false
 }
 
+// LowMemory returns whether a memory budget is set, in which case buffers
+// and concurrency are kept to a minimum.
+func (opts OptionsConfiguration) LowMemory() bool {
+	return opts.MemoryBudgetMiB > 0
+}
+
+// EffectiveDatabaseTuning is the configured database tuning, or the low
+// memory tuning when a memory budget is set.
+func (opts OptionsConfiguration) EffectiveDatabaseTuning() Tuning {
+	if opts.LowMemory() {
+		return TuningLowMemory
+	}
+	return opts.DatabaseTuning
+}
+
 // LowestConnectionLimit is the lower of ConnectionLimitEnough or
 // ConnectionLimitMax, or whichever of them is actually set if only one of
 // them is set. It's the point where we should stop dialling.
@@ -81,6 +81,57 @@ type OptionsConfiguration struct {
 	// meaning no limit. Affects incoming connections and prevents
 	// attempting outgoing connections.
 	ConnectionLimitMax int `protobuf:"varint,52,opt,name=connection_limit_max,json=connectionLimitMax,proto3,casttype=int" json:"connectionLimitMax" xml:"connectionLimitMax"`
+	// An explicit proxy (socks5:// or http://) to use for outgoing
+	// connections instead of the one from the environment, if any. QUIC
+	// can't be proxied, so QUIC addresses aren't dialed while it applies.
+	ProxyURL string `protobuf:"bytes,53,opt,name=proxy_url,json=proxyUrl,proto3" json:"proxyURL" xml:"proxyURL"`
+	// When set, the proxy is only used for addresses outside the LAN.
+	ProxyWANOnly bool `protobuf:"varint,54,opt,name=proxy_wan_only,json=proxyWanOnly,proto3" json:"proxyWANOnly" xml:"proxyWANOnly"`
+	// While set, no changes are made to the data in any folder: nothing is
+	// pulled, reverted or restored and old versions are not cleaned out.
+	// Scanning and serving data to other devices continues as usual.
+	MaintenanceFreeze bool `protobuf:"varint,55,opt,name=maintenance_freeze,json=maintenanceFreeze,proto3" json:"maintenanceFreeze" xml:"maintenanceFreeze"`
+	// Relays to prefer over others when picking one from a relay pool,
+	// given as two letter country codes or relay addresses.
+	RelayPreferences []string `protobuf:"bytes,56,rep,name=relay_preferences,json=relayPreferences,proto3" json:"relayPreferences" xml:"relayPreference"`
+	// The storage engine of the index database. An existing database is
+	// converted when this is changed.
+	DatabaseBackend DatabaseBackend `protobuf:"varint,57,opt,name=database_backend,json=databaseBackend,proto3,enum=config.DatabaseBackend" json:"databaseBackend" xml:"databaseBackend" restart:"true"`
+	// Number of previous versions of the configuration to keep in the
+	// config-history directory. Zero disables keeping them.
+	ConfigHistory int `protobuf:"varint,58,opt,name=config_history,json=configHistory,proto3,casttype=int" json:"configHistory" xml:"configHistory" default:"10"`
+	// Run fewer hashers in parallel while other programs keep the CPUs
+	// busy.
+	AdaptiveHashing bool `protobuf:"varint,59,opt,name=adaptive_hashing,json=adaptiveHashing,proto3" json:"adaptiveHashing" xml:"adaptiveHashing"`
+	// DNS zones to look up device addresses in, in addition to the global
+	// discovery servers. See lib/discover for the records used.
+	DNSDiscoveryZones []string `protobuf:"bytes,60,rep,name=dns_discovery_zones,json=dnsDiscoveryZones,proto3" json:"dnsDiscoveryZones" xml:"dnsDiscoveryZone"`
+	// Whether to also announce and look for devices using mDNS service
+	// discovery when local discovery is enabled.
+	LocalAnnMDNSEnabled bool `protobuf:"varint,61,opt,name=local_announce_mdns_enabled,json=localAnnounceMdnsEnabled,proto3" json:"localAnnounceMDNSEnabled" xml:"localAnnounceMDNSEnabled" default:"true"`
+	// The IP address families used for listening, dialing and announcing,
+	// and which one to try first when a device has addresses of both.
+	AddressFamily AddressFamily `protobuf:"varint,62,opt,name=address_family,json=addressFamily,proto3,enum=config.AddressFamily" json:"addressFamily" xml:"addressFamily"`
+	// Categories of usage data to leave out of usage reports, see the
+	// category tags in lib/ur/contract.
+	URExcludedCategories []string `protobuf:"bytes,63,rep,name=usage_reporting_excluded_categories,json=usageReportingExcludedCategories,proto3" json:"urExcludedCategories" xml:"urExcludedCategory"`
+	// Keeps scans from making the system sluggish: every folder scans as
+	// with scan_low_priority and a single hasher, and all of them together
+	// read at most 10 MiB/s.
+	LowImpactScans bool `protobuf:"varint,64,opt,name=low_impact_scans,json=lowImpactScans,proto3" json:"lowImpactScans" xml:"lowImpactScans"`
+	// Listen addresses, as given in raw_listen_addresses or among the
+	// defaults, that are kept in the configuration but not listened on.
+	DisabledListenAddresses []string `protobuf:"bytes,65,rep,name=disabled_listen_addresses,json=disabledListenAddresses,proto3" json:"disabledListenAddresses" xml:"disabledListenAddress"`
+	// How long a newly generated certificate is announced alongside the
+	// current one before it replaces it at startup, giving peers time to
+	// learn the new device ID.
+	CertificateRotationOverlapDays int `protobuf:"varint,66,opt,name=certificate_rotation_overlap_days,json=certificateRotationOverlapDays,proto3,casttype=int" json:"certificateRotationOverlapDays" xml:"certificateRotationOverlapDays" default:"14"`
+	// Keeps memory usage within roughly this many MiB, for routers and
+	// similar devices: pull buffers and incoming requests are limited to a
+	// block or two, folders pull and scan one at a time with a single
+	// hasher, and the database uses the low memory tuning. Zero means no
+	// budget.
+	MemoryBudgetMiB int `protobuf:"varint,67,opt,name=memory_budget_mib,json=memoryBudgetMib,proto3,casttype=int" json:"memoryBudgetMiB" xml:"memoryBudgetMiB" restart:"true"`
 	// Legacy deprecated
 	DeprecatedUPnPEnabled        bool     `protobuf:"varint,9000,opt,name=upnp_enabled,json=upnpEnabled,proto3" json:"-" xml:"upnpEnabled,omitempty"`                                    // Deprecated: Do not use.
 	DeprecatedUPnPLeaseM         int      `protobuf:"varint,9001,opt,name=upnp_lease_m,json=upnpLeaseM,proto3,casttype=int" json:"-" xml:"upnpLeaseMinutes,omitempty"`                   // Deprecated: Do not use.
@@ -133,209 +184,254 @@ func init() {
 }
 
 var fileDescriptor_d09882599506ca03 = []byte{
-	// 3218 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x5a, 0x5d, 0x6c, 0x1d, 0x47,
-	0xf5, 0xcf, 0x26, 0x4d, 0xda, 0x6c, 0x1c, 0x27, 0x1e, 0x3b, 0xf6, 0x36, 0x49, 0xbd, 0xee, 0xcd,
-	0x4d, 0xeb, 0x7e, 0x25, 0xb6, 0x93, 0xe6, 0x9f, 0x46, 0xfa, 0xab, 0xf8, 0xa3, 0x26, 0x6e, 0xec,
-	0xc4, 0x1a, 0xdb, 0x2a, 0x2a, 0x42, 0xab, 0xb9, 0x7b, 0xe7, 0xda, 0x8b, 0xf7, 0xce, 0xde, 0xee,
-	0xcc, 0xfa, 0xda, 0x2d, 0x82, 0xaa, 0x88, 0x8f, 0x37, 0xc0, 0xe2, 0x43, 0x02, 0x09, 0x15, 0x01,
-	0x12, 0xa5, 0x14, 0x21, 0x21, 0x21, 0xc1, 0x0b, 0x08, 0x09, 0xa9, 0x82, 0x07, 0xfb, 0xb1, 0x12,
-	0x65, 0x51, 0x9d, 0x3e, 0xdd, 0x07, 0x1e, 0xee, 0xa3, 0x79, 0x41, 0x33, 0xfb, 0x35, 0xbb, 0x3b,
-	0xb7, 0xc9, 0xdb, 0xdd, 0xf3, 0x3b, 0x73, 0xe6, 0x77, 0xe6, 0xe3, 0xcc, 0x39, 0x33, 0x57, 0xbf,
-	0xec, 0x3a, 0xb5, 0xab, 0xb6, 0x47, 0x1a, 0xce, 0xfa, 0x55, 0xaf, 0xc5, 0x1c, 0x8f, 0xd0, 0xe8,
-	0x2b, 0xf0, 0x11, 0xff, 0xba, 0xd2, 0xf2, 0x3d, 0xe6, 0x81, 0x13, 0x91, 0xf0, 0xfc, 0x88, 0xa4,
-	0xce, 0x02, 0xe2, 0x90, 0xf5, 0x48, 0xe1, 0xfc, 0x39, 0x09, 0xa0, 0xce, 0x9b, 0x38, 0x16, 0x9f,
-	0xc4, 0xdb, 0x2c, 0xfa, 0x59, 0xf9, 0xe8, 0xb6, 0x3e, 0x74, 0x2f, 0xea, 0x61, 0x56, 0xee, 0x01,
-	0xfc, 0x54, 0xd3, 0xcf, 0xba, 0x0e, 0x65, 0x98, 0x58, 0xa8, 0x5e, 0xf7, 0x31, 0xa5, 0x98, 0x1a,
-	0xda, 0xd8, 0xb1, 0xf1, 0x93, 0x33, 0xf4, 0x20, 0x34, 0x01, 0x44, 0xed, 0x45, 0x01, 0x4f, 0x27,
-	0x68, 0x27, 0x34, 0xcf, 0xb8, 0x79, 0x51, 0x37, 0x34, 0x2f, 0x6f, 0x37, 0xdd, 0x5b, 0x95, 0x9c,
-	0xbc, 0x32, 0x56, 0xc7, 0x0d, 0x14, 0xb8, 0xec, 0x56, 0x25, 0xfe, 0x51, 0x39, 0xdc, 0xab, 0x3e,
-	0x1a, 0xff, 0xde, 0xdd, 0xaf, 0x2a, 0x8c, 0xc3, 0xa2, 0x69, 0xf0, 0x1f, 0x4d, 0x37, 0xd6, 0x5d,
-	0xaf, 0x86, 0x5c, 0xab, 0xee, 0x50, 0xdb, 0xdb, 0xc2, 0xfe, 0x8e, 0x45, 0xb1, 0xbf, 0x85, 0x7d,
-	0x6a, 0x1c, 0x15, 0x44, 0x7f, 0xaf, 0x1d, 0x84, 0xe6, 0x20, 0x44, 0xed, 0xcf, 0x0b, 0xbd, 0x69,
-	0x42, 0x56, 0x22, 0xbc, 0x13, 0x9a, 0xe7, 0xd6, 0x13, 0x99, 0x17, 0x10, 0x1b, 0xc7, 0x40, 0x37,
-	0x34, 0x9f, 0x17, 0x84, 0x55, 0xa8, 0x82, 0x77, 0x67, 0xaf, 0x3a, 0xa4, 0x52, 0xed, 0xee, 0x55,
-	0xd5, 0x1d, 0xe4, 0x1d, 0x55, 0x71, 0x83, 0xc3, 0x51, 0xc3, 0xb9, 0xc4, 0xa9, 0x58, 0x0e, 0x3e,
-	0x55, 0x39, 0x8c, 0x09, 0xaa, 0xb9, 0xb8, 0x6e, 0x1c, 0x1b, 0xd3, 0xc6, 0x1f, 0x9b, 0x79, 0x8f,
-	0x3b, 0x7c, 0x36, 0xb5, 0xf8, 0x4a, 0x04, 0x96, 0xbd, 0x8d, 0x81, 0x6e, 0x68, 0x3e, 0xab, 0xf0,
-	0x36, 0x46, 0x25, 0x77, 0x99, 0x1f, 0x60, 0xee, 0x6b, 0x0f, 0x33, 0xbd, 0x80, 0xc3, 0xbd, 0xea,
-	0x23, 0xbc, 0xe9, 0xee, 0x7e, 0xb5, 0x44, 0xaa, 0xe4, 0x66, 0x2c, 0x07, 0x1f, 0x6b, 0xfa, 0x88,
-	0xeb, 0xd9, 0x4a, 0x2f, 0x1f, 0x11, 0x5e, 0xfe, 0x9c, 0x7b, 0x79, 0x66, 0x91, 0xeb, 0xe4, 0x9c,
-	0x1c, 0x72, 0x63, 0x51, 0xc1, 0xc7, 0x67, 0xa2, 0x25, 0xa8, 0x00, 0x15, 0x2e, 0xaa, 0x8d, 0xf4,
-	0x90, 0x4b, 0x0e, 0x16, 0xf9, 0xc0, 0x73, 0xa2, 0x41, 0xc9, 0xbd, 0x7f, 0x68, 0xfa, 0x60, 0xe4,
-	0x1e, 0x8a, 0x6d, 0x59, 0x2d, 0xcf, 0x67, 0xc6, 0xf1, 0x31, 0x6d, 0xfc, 0xf8, 0xcc, 0x8f, 0xb9,
-	0x6b, 0x7d, 0x89, 0xa9, 0x65, 0xcf, 0x67, 0x9d, 0xd0, 0x1c, 0xc8, 0x75, 0xcd, 0x85, 0xdd, 0xd0,
-	0x7c, 0xba, 0xec, 0x14, 0x47, 0x24, 0x8f, 0xa6, 0x26, 0x27, 0xa6, 0xfe, 0xaf, 0x72, 0x18, 0x9a,
-	0xc7, 0x1c, 0xc2, 0x3a, 0x7b, 0x55, 0x85, 0x19, 0x95, 0xf0, 0x70, 0xaf, 0x7a, 0x5c, 0x34, 0xdd,
-	0xdd, 0xaf, 0xe6, 0x98, 0xc0, 0xb2, 0x2e, 0xf8, 0xfa, 0x51, 0x7d, 0xac, 0xe0, 0x4d, 0x33, 0x70,
-	0x99, 0x63, 0x23, 0xca, 0x92, 0xb8, 0x61, 0x9c, 0x18, 0xd3, 0xc6, 0x4f, 0xce, 0xfc, 0x91, 0xbb,
-	0xd6, 0x9f, 0x18, 0x5c, 0x9a, 0xe5, 0x3b, 0xb9, 0x13, 0x9a, 0x83, 0x39, 0xa3, 0x91, 0xb8, 0x1b,
-	0x9a, 0x37, 0xca, 0xee, 0x45, 0x98, 0xe4, 0xe0, 0x17, 0x1b, 0x8d, 0xc9, 0xa9, 0x5b, 0xb7, 0x6e,
-	0x5e, 0xbb, 0x79, 0xfd, 0x4b, 0xb7, 0x22, 0x6f, 0x3b, 0x7b, 0x55, 0xa5, 0x41, 0xb5, 0xf8, 0x70,
-	0xaf, 0x0a, 0xca, 0x46, 0x76, 0xf7, 0xab, 0x05, 0x9a, 0xf0, 0x89, 0x7c, 0xe3, 0xc4, 0xc3, 0x38,
-	0x18, 0x81, 0x7b, 0xfa, 0xe9, 0x26, 0xda, 0xb6, 0x28, 0x26, 0x75, 0x6b, 0xb3, 0xd6, 0xa2, 0xc6,
-	0xa3, 0x62, 0x32, 0x9f, 0xeb, 0x84, 0xe6, 0xa9, 0x26, 0xda, 0x5e, 0xc1, 0xa4, 0x7e, 0xa7, 0xd6,
-	0xe2, 0xc1, 0x65, 0x40, 0xb8, 0x25, 0xc9, 0x92, 0xf9, 0x81, 0xb2, 0x62, 0x62, 0xd0, 0xc7, 0xf6,
-	0x56, 0x64, 0xf0, 0xb1, 0x9c, 0x41, 0x88, 0xed, 0xad, 0xa2, 0xc1, 0x44, 0x96, 0x33, 0x98, 0x08,
-	0xc1, 0x1f, 0x34, 0x7d, 0xc4, 0xc7, 0xb6, 0x47, 0x08, 0xb6, 0x79, 0x78, 0xb7, 0x1c, 0xc2, 0xb0,
-	0xbf, 0x85, 0x5c, 0x8b, 0x1a, 0x27, 0x85, 0xed, 0xaf, 0x8a, 0xa0, 0x9e, 0xa8, 0x2c, 0xc4, 0xf0,
-	0x0a, 0x8f, 0x1d, 0x72, 0xc3, 0x14, 0xe8, 0x86, 0xe6, 0xb8, 0xe8, 0x5b, 0x89, 0x4a, 0xb3, 0x74,
-	0x63, 0x22, 0xa1, 0x74, 0xb8, 0x57, 0x3d, 0x7a, 0x63, 0x42, 0xc4, 0xf7, 0x52, 0x3f, 0x50, 0xdd,
-	0x0b, 0x68, 0xe8, 0xfd, 0x3e, 0x76, 0xd1, 0x0e, 0x4d, 0x63, 0x80, 0x2e, 0x62, 0xc0, 0xcb, 0x9d,
-	0xd0, 0x3c, 0x1d, 0x21, 0xd9, 0x46, 0xaf, 0xc4, 0x84, 0x24, 0x69, 0x71, 0x87, 0x27, 0x3b, 0x16,
-	0xe6, 0x1b, 0x83, 0x77, 0x8e, 0xea, 0x17, 0xe2, 0x8e, 0x52, 0x22, 0xd9, 0x20, 0x35, 0x8d, 0x53,
-	0x62, 0x90, 0xfe, 0xca, 0xd7, 0xf0, 0x08, 0xe4, 0x7a, 0x25, 0x17, 0x96, 0x3a, 0xa1, 0x39, 0xe2,
-	0xab, 0xa1, 0x34, 0xd0, 0xf6, 0xc0, 0x25, 0x96, 0x93, 0x13, 0xd2, 0x96, 0xed, 0x69, 0xaf, 0x37,
-	0xc4, 0x07, 0x79, 0x92, 0x0f, 0x72, 0x2f, 0x9a, 0xd0, 0x88, 0xfc, 0x2c, 0x23, 0xa0, 0xa6, 0x9f,
-	0xa6, 0x0c, 0xf9, 0xcc, 0xaa, 0xf9, 0x5e, 0x9b, 0x62, 0xdf, 0xe8, 0x13, 0x63, 0xfd, 0xff, 0x9d,
-	0xd0, 0xec, 0x13, 0xc0, 0x4c, 0x24, 0xef, 0x86, 0xe6, 0x93, 0xc2, 0x1d, 0x59, 0xd8, 0x73, 0xa4,
-	0x73, 0x4d, 0xc1, 0x2f, 0x35, 0xfd, 0x1c, 0x41, 0xcc, 0x62, 0x3e, 0xe2, 0xa7, 0x1a, 0x72, 0xd3,
-	0x89, 0xed, 0x17, 0x9d, 0xbd, 0x71, 0x10, 0x9a, 0xfa, 0xdd, 0xe9, 0xd5, 0x2c, 0xac, 0xeb, 0x04,
-	0xb1, 0x6c, 0x8e, 0x4d, 0xd1, 0x71, 0x26, 0x52, 0x84, 0x70, 0xb9, 0x41, 0xee, 0x4b, 0x0a, 0xd7,
-	0x52, 0x17, 0x70, 0x90, 0x20, 0xb6, 0x9a, 0xd0, 0x49, 0x16, 0xc4, 0x9f, 0x4a, 0x3c, 0x5d, 0x8c,
-	0x28, 0xb6, 0x9a, 0xc6, 0x19, 0xb1, 0x14, 0xbe, 0xc9, 0x97, 0xc2, 0xc9, 0xbb, 0xd3, 0xab, 0x8b,
-	0x5c, 0xcc, 0x27, 0xff, 0x0c, 0x41, 0x2c, 0xfa, 0x70, 0x48, 0xc0, 0x44, 0xf2, 0x53, 0x49, 0xc8,
-	0xca, 0x72, 0xe5, 0xde, 0xe8, 0xec, 0x55, 0x4b, 0xed, 0xcb, 0xa2, 0x74, 0x07, 0x65, 0x1d, 0x43,
-	0x20, 0xb3, 0x8f, 0x64, 0xe0, 0xef, 0x9a, 0x3e, 0x92, 0x27, 0xef, 0x63, 0x82, 0xdb, 0x62, 0x25,
-	0x9f, 0x15, 0xf4, 0x77, 0x39, 0xfd, 0x53, 0x77, 0xa7, 0x57, 0x61, 0x04, 0x70, 0x07, 0x06, 0x08,
-	0x62, 0xc9, 0x67, 0xea, 0x42, 0x35, 0x71, 0x21, 0x8f, 0x48, 0x4e, 0x5c, 0x93, 0x9d, 0x50, 0xd8,
-	0x50, 0x09, 0xb9, 0x23, 0xd7, 0xb8, 0x23, 0x32, 0x05, 0x38, 0x24, 0xbb, 0x92, 0x48, 0x15, 0xce,
-	0x30, 0xa7, 0x89, 0xbd, 0x80, 0x59, 0xd4, 0x18, 0xc8, 0x3b, 0xb3, 0x1a, 0x01, 0x2b, 0xb1, 0x33,
-	0xc9, 0x27, 0x5f, 0xe9, 0xf5, 0x9c, 0x33, 0x79, 0xa4, 0xd7, 0xf6, 0x53, 0xd8, 0x50, 0x09, 0xd3,
-	0x2d, 0x27, 0x53, 0xc8, 0x3b, 0x93, 0x48, 0xc1, 0x4f, 0x34, 0xdd, 0x08, 0x28, 0x5a, 0xc7, 0x96,
-	0x8f, 0xf9, 0xb9, 0xef, 0x90, 0x75, 0x0b, 0xd9, 0x36, 0x6e, 0x31, 0x5c, 0x37, 0x80, 0xf0, 0x06,
-	0xf1, 0x1d, 0xb0, 0x06, 0xa7, 0x63, 0x29, 0xdf, 0x01, 0x81, 0x9f, 0x7c, 0x75, 0x43, 0xf3, 0xac,
-	0x70, 0x22, 0x13, 0x49, 0x84, 0x65, 0xc5, 0xdc, 0x17, 0x5f, 0xf1, 0x99, 0x49, 0x38, 0x2c, 0x28,
-	0xc0, 0x84, 0x41, 0x22, 0x07, 0x6f, 0xe9, 0x43, 0x45, 0x72, 0x14, 0x63, 0x62, 0x0c, 0x0a, 0x62,
-	0x0b, 0x07, 0xa1, 0x79, 0x62, 0x0d, 0xae, 0x60, 0x4c, 0x3a, 0xa1, 0x79, 0x22, 0xf0, 0xf9, 0xaf,
-	0x6e, 0x68, 0xf6, 0xc5, 0x84, 0xf8, 0xa7, 0x44, 0x26, 0x51, 0x48, 0x7f, 0xed, 0xee, 0x57, 0xe3,
-	0xe6, 0x10, 0xe4, 0x09, 0x70, 0x19, 0xf8, 0x81, 0xa6, 0x3f, 0x5e, 0xec, 0x3d, 0x20, 0xce, 0x1b,
-	0x01, 0xb6, 0x9c, 0xba, 0x31, 0x24, 0x92, 0x88, 0xd7, 0xa3, 0xb1, 0x59, 0x13, 0xe2, 0x85, 0xb9,
-	0x68, 0x6c, 0xe2, 0x2f, 0x79, 0x6c, 0x12, 0x85, 0x4a, 0x34, 0x28, 0xc9, 0x67, 0x57, 0xfe, 0x8a,
-	0x07, 0x25, 0xc1, 0x8a, 0x83, 0x92, 0x68, 0x81, 0xbf, 0x68, 0xfa, 0x60, 0x89, 0x97, 0xef, 0x1a,
-	0xe7, 0x04, 0xa3, 0xef, 0xf0, 0xb5, 0x77, 0x7c, 0x0d, 0xae, 0xc1, 0xc5, 0x4e, 0x68, 0x1e, 0x0f,
-	0xfc, 0x35, 0xb8, 0xd8, 0x0d, 0xcd, 0x9b, 0x09, 0x11, 0xb8, 0x28, 0xad, 0xae, 0x0d, 0xc6, 0x5a,
-	0xf4, 0xd6, 0xd5, 0xab, 0x75, 0xc4, 0xd0, 0x15, 0xba, 0x43, 0x6c, 0xb6, 0xc1, 0x8b, 0x35, 0x82,
-	0xd9, 0x55, 0x82, 0xdb, 0x5c, 0xca, 0x09, 0xc7, 0x46, 0x92, 0x1f, 0x87, 0x7b, 0xd5, 0x87, 0x68,
-	0xb8, 0xbb, 0x5f, 0x8d, 0x58, 0xc0, 0x81, 0x82, 0x1f, 0xbe, 0x0b, 0xfe, 0xad, 0xe9, 0x66, 0xd1,
-	0x85, 0x96, 0x47, 0xf9, 0x09, 0x47, 0xb1, 0x1d, 0xf8, 0xd8, 0xdd, 0x31, 0x86, 0x45, 0xf8, 0xfd,
-	0x91, 0xa8, 0x20, 0xd6, 0xe0, 0xb2, 0x47, 0xd9, 0x42, 0x0a, 0x76, 0x42, 0xf3, 0x6c, 0xe0, 0xe7,
-	0x65, 0xdd, 0xd0, 0x7c, 0x2a, 0x76, 0x32, 0x0f, 0x48, 0xfe, 0x36, 0x90, 0x4b, 0x45, 0x48, 0x2e,
-	0xb7, 0x56, 0xc8, 0x78, 0xe6, 0x29, 0x5a, 0xf0, 0x7a, 0xa1, 0x48, 0x01, 0x5e, 0xcc, 0xbb, 0x95,
-	0x47, 0xc1, 0xbf, 0x14, 0x1e, 0x3a, 0xc4, 0x61, 0x0e, 0xaf, 0x23, 0xf8, 0x79, 0x67, 0x51, 0x63,
-	0x44, 0xac, 0xe2, 0x1f, 0x8a, 0xea, 0x61, 0x0d, 0x2e, 0x44, 0xe8, 0x1c, 0x07, 0x79, 0xc0, 0x38,
-	0x13, 0xf8, 0x39, 0x51, 0x1a, 0x2e, 0x0a, 0x72, 0x39, 0x58, 0xdc, 0x9c, 0xc8, 0x05, 0xf0, 0xa2,
-	0x85, 0xb2, 0x88, 0x9f, 0x40, 0xbc, 0x15, 0x2f, 0x18, 0x0a, 0x14, 0xe0, 0x85, 0xbc, 0x83, 0x39,
-	0x10, 0x78, 0xfa, 0x80, 0x8f, 0xa3, 0xc3, 0xd9, 0x23, 0x56, 0x1b, 0x6d, 0xe2, 0xa0, 0x65, 0x18,
-	0x62, 0xca, 0x66, 0x39, 0xf9, 0x18, 0xbc, 0x47, 0x5e, 0x13, 0x50, 0x4a, 0xbe, 0x20, 0xef, 0x79,
-	0x48, 0x17, 0x0d, 0x80, 0x6f, 0x69, 0xfa, 0x08, 0x0a, 0x98, 0x67, 0x05, 0xad, 0x75, 0x1f, 0xd5,
-	0x71, 0x96, 0x0c, 0x6d, 0x18, 0x8f, 0x8b, 0x81, 0x5c, 0xe6, 0x25, 0x17, 0x57, 0x59, 0x8b, 0x34,
-	0x92, 0x3c, 0xe2, 0x76, 0x5a, 0x9d, 0xa8, 0x40, 0x79, 0xf8, 0xa6, 0xe4, 0xcc, 0x70, 0x72, 0x0a,
-	0x2a, 0xad, 0x81, 0xa6, 0x3e, 0x92, 0x70, 0x60, 0x9e, 0xd5, 0xf2, 0xf9, 0x14, 0x8b, 0xb3, 0x98,
-	0x1a, 0xe7, 0xc5, 0x00, 0xdc, 0xe0, 0x44, 0x62, 0x95, 0x55, 0x6f, 0xd9, 0xc7, 0x30, 0xc6, 0xbb,
-	0xa1, 0x79, 0x3e, 0x9a, 0x42, 0x05, 0x58, 0x81, 0xca, 0x36, 0x60, 0x4b, 0x07, 0x9b, 0x18, 0xb7,
-	0x2c, 0x86, 0x9b, 0x2d, 0xcf, 0x47, 0xbe, 0x83, 0xa9, 0xb5, 0x61, 0x5c, 0x10, 0x2e, 0xdf, 0xe6,
-	0x1b, 0x81, 0xa3, 0xab, 0x19, 0xc8, 0xdd, 0xbd, 0x24, 0x7a, 0x29, 0x02, 0x72, 0x2d, 0x76, 0x5d,
-	0x76, 0x75, 0xea, 0x3a, 0x2c, 0x59, 0x01, 0x3b, 0xfa, 0xa0, 0x8d, 0xec, 0x0d, 0x6c, 0x39, 0xeb,
-	0xc4, 0xf3, 0x71, 0xdd, 0x6a, 0x38, 0x2e, 0xa6, 0xc6, 0x45, 0xe1, 0xe2, 0x02, 0x3f, 0xd1, 0x04,
-	0xbc, 0x10, 0xa1, 0xf3, 0x1c, 0x4c, 0x07, 0xba, 0x84, 0x94, 0xf6, 0x60, 0xba, 0xb7, 0x60, 0xd9,
-	0x0c, 0xf8, 0x9e, 0xa6, 0x9f, 0x6f, 0xf9, 0xde, 0x3a, 0x2f, 0x66, 0xac, 0xa0, 0x55, 0x47, 0x0c,
-	0xcb, 0x05, 0xc2, 0x13, 0xc2, 0xf7, 0x55, 0x9e, 0xdf, 0x26, 0x5a, 0x6b, 0x42, 0x49, 0x2e, 0x06,
-	0xa2, 0x22, 0xbb, 0x07, 0x2e, 0xd1, 0x79, 0x51, 0x1a, 0x08, 0xed, 0x45, 0xd8, 0xcb, 0x22, 0x78,
-	0x47, 0xd3, 0x87, 0x5d, 0xa7, 0xe9, 0x30, 0xab, 0x86, 0x48, 0xbd, 0xed, 0xd4, 0xd9, 0x86, 0xe5,
-	0x10, 0xcb, 0x45, 0xc4, 0x18, 0x15, 0x43, 0xb2, 0x24, 0x8a, 0x47, 0xae, 0x31, 0x93, 0x28, 0x2c,
-	0x90, 0x45, 0x44, 0xb2, 0x82, 0xbf, 0x8c, 0x7d, 0xc6, 0xb0, 0xa8, 0x4c, 0x81, 0xb7, 0x35, 0x1d,
-	0x34, 0x1d, 0x62, 0x6d, 0x78, 0x4d, 0x6c, 0xd5, 0x1d, 0xba, 0x69, 0x35, 0x7c, 0x8c, 0x0d, 0x73,
-	0x4c, 0x1b, 0x3f, 0x35, 0xd5, 0x77, 0x25, 0xba, 0x59, 0xbb, 0xb2, 0xe2, 0xbc, 0x89, 0x67, 0x5e,
-	0xf9, 0x30, 0x34, 0x8f, 0xf0, 0x9d, 0xd8, 0x74, 0xc8, 0x6d, 0xaf, 0x89, 0xe7, 0x1c, 0xba, 0x39,
-	0xef, 0x63, 0x9c, 0xae, 0x8e, 0x82, 0x5c, 0xde, 0x07, 0x63, 0x97, 0x39, 0x91, 0x63, 0x93, 0x63,
-	0x97, 0x61, 0xb1, 0x39, 0xb8, 0xaf, 0xe9, 0x7d, 0xc9, 0x7a, 0x17, 0xc7, 0xce, 0x98, 0x38, 0x76,
-	0xfe, 0x2c, 0x52, 0x9e, 0x64, 0xd1, 0x46, 0x87, 0xcf, 0x29, 0x3f, 0xfb, 0xec, 0x86, 0xe6, 0x5c,
-	0x52, 0x71, 0x24, 0x32, 0xc5, 0x41, 0x14, 0xef, 0x00, 0x5a, 0x38, 0x53, 0x9a, 0x98, 0xa1, 0x2b,
-	0x5f, 0xa6, 0x1e, 0xe1, 0xb1, 0x3b, 0x67, 0x36, 0xff, 0x79, 0xb8, 0x57, 0x1d, 0x7f, 0x58, 0x53,
-	0x3c, 0x3f, 0x92, 0xf8, 0xc2, 0xcc, 0x8e, 0xef, 0x82, 0xd7, 0xf4, 0x01, 0xe4, 0xb6, 0x79, 0xf5,
-	0x15, 0xdd, 0x26, 0x10, 0xcc, 0xa8, 0xf1, 0xa4, 0xb8, 0xc4, 0xe3, 0x45, 0xef, 0x99, 0x08, 0x14,
-	0x55, 0xf9, 0x5d, 0xcc, 0xf8, 0xc2, 0x1f, 0x8a, 0x22, 0x4c, 0x4e, 0x5e, 0x81, 0x45, 0x45, 0xf0,
-	0x5f, 0x4d, 0x1f, 0xf7, 0xb6, 0xb0, 0xdf, 0xf6, 0x1d, 0xc6, 0x03, 0x47, 0xd3, 0x63, 0xd8, 0xaa,
-	0xe3, 0x2d, 0xc7, 0xc6, 0x16, 0x41, 0x4d, 0x4c, 0x79, 0x38, 0x8d, 0x0b, 0x21, 0xa3, 0x92, 0x5d,
-	0x2f, 0x8d, 0xdc, 0x4b, 0x1a, 0x41, 0xd1, 0x66, 0x0e, 0x6f, 0xdd, 0xe5, 0xea, 0x9d, 0xd0, 0xbc,
-	0xe4, 0x95, 0x20, 0xc7, 0xc6, 0x02, 0xbd, 0x47, 0x66, 0x23, 0x53, 0xdd, 0xd0, 0x7c, 0x49, 0x10,
-	0x7c, 0x08, 0xdd, 0xde, 0x8b, 0x92, 0x57, 0x71, 0x3d, 0x78, 0xc0, 0x87, 0x61, 0x01, 0xbe, 0xa6,
-	0x9f, 0xe3, 0x61, 0xcc, 0x72, 0x48, 0x1d, 0x6f, 0x5b, 0x7c, 0x25, 0xd7, 0x5c, 0xcf, 0xde, 0xa4,
-	0xc6, 0x25, 0xb1, 0xa5, 0xf9, 0xa2, 0x01, 0x5c, 0x61, 0x81, 0xe3, 0x4b, 0x0e, 0x99, 0x11, 0x68,
-	0x7a, 0x6b, 0x5b, 0x86, 0x94, 0x99, 0x72, 0x94, 0xff, 0x42, 0x85, 0x25, 0xf0, 0x4f, 0x9e, 0xee,
-	0x12, 0x64, 0x6f, 0xe2, 0xba, 0x45, 0x3c, 0xe6, 0x34, 0x1c, 0x1b, 0x45, 0xf7, 0x0f, 0x75, 0x6a,
-	0x54, 0xc5, 0xfc, 0xbe, 0xcb, 0x87, 0x7b, 0x78, 0x2d, 0x52, 0xba, 0x2b, 0xe9, 0x2c, 0xcc, 0xf1,
-	0xd1, 0x1e, 0x0e, 0x94, 0x48, 0x37, 0x34, 0x2f, 0x44, 0xa1, 0x5d, 0x05, 0x8b, 0xbb, 0x4a, 0x25,
-	0xd2, 0xdd, 0xab, 0xf6, 0xb0, 0xb8, 0xbb, 0x5f, 0xed, 0xc1, 0x02, 0x2a, 0x5b, 0xd4, 0x29, 0x80,
-	0xfa, 0x69, 0xe6, 0xa3, 0x46, 0xc3, 0xb1, 0x2d, 0xdb, 0x45, 0x94, 0x1a, 0x97, 0xc5, 0xb0, 0xbe,
-	0xc0, 0xeb, 0xe5, 0x18, 0x98, 0xe5, 0xf2, 0x6e, 0x68, 0x82, 0x68, 0x40, 0x25, 0x61, 0x7a, 0x51,
-	0x93, 0x53, 0x05, 0x6f, 0xe9, 0x83, 0xf1, 0x10, 0x5b, 0x0d, 0xcf, 0xad, 0x63, 0xdf, 0x6a, 0x21,
-	0xb6, 0x61, 0x3c, 0x25, 0x76, 0xfd, 0x9d, 0x83, 0xd0, 0xbc, 0x30, 0x87, 0x5b, 0x3e, 0xb6, 0x11,
-	0xc3, 0xf5, 0xb9, 0x48, 0x71, 0x5e, 0xe8, 0x2d, 0x23, 0xb6, 0xd1, 0x09, 0x4d, 0xed, 0x85, 0xb4,
-	0x3a, 0xaf, 0x17, 0xe1, 0xe7, 0xbd, 0xa6, 0xc3, 0x27, 0x89, 0xed, 0x54, 0x0c, 0x0d, 0x0e, 0x94,
-	0x70, 0xb0, 0xa9, 0x9f, 0xa5, 0x98, 0x59, 0xae, 0xd7, 0xb6, 0x5a, 0xbe, 0xe3, 0xf9, 0x0e, 0xdb,
-	0x31, 0x9e, 0x16, 0x9b, 0x62, 0xba, 0x13, 0x9a, 0xfd, 0x14, 0xb3, 0x45, 0xaf, 0xbd, 0x1c, 0x23,
-	0x69, 0x64, 0xcb, 0x8b, 0x7b, 0xa6, 0x18, 0x85, 0xe6, 0xe0, 0x3d, 0x4d, 0x1f, 0x6e, 0xa2, 0xed,
-	0xc4, 0x4d, 0xdb, 0x23, 0x76, 0xe0, 0xfb, 0x98, 0xd8, 0x3b, 0xc6, 0xb8, 0x18, 0x47, 0x2a, 0x2e,
-	0x5b, 0x50, 0x7b, 0x09, 0x6d, 0x47, 0x1c, 0x67, 0x33, 0x15, 0x7e, 0xe4, 0x37, 0x15, 0xf2, 0xf4,
-	0xc8, 0x57, 0x81, 0xc9, 0x90, 0x8b, 0xdb, 0x11, 0xb5, 0x5d, 0xa8, 0xb4, 0x0a, 0x3e, 0xd6, 0xf4,
-	0x41, 0xdb, 0x47, 0x74, 0xa3, 0x50, 0x03, 0x3c, 0x23, 0xa6, 0xe5, 0x7d, 0x51, 0x03, 0xcc, 0x26,
-	0x35, 0x80, 0x1d, 0xd7, 0x00, 0xf3, 0xd1, 0xd9, 0xcc, 0x9b, 0x65, 0xd9, 0xb8, 0x32, 0x0c, 0x0b,
-	0x9d, 0x72, 0x5e, 0x2f, 0xc4, 0x7c, 0x2d, 0x0f, 0x94, 0x8c, 0xf0, 0xea, 0xc0, 0x8e, 0xab, 0x83,
-	0xea, 0xc3, 0x98, 0xe1, 0xf5, 0xc1, 0x6c, 0x54, 0x1f, 0x14, 0x8c, 0xf9, 0x2e, 0xf8, 0x99, 0xa6,
-	0x8f, 0x14, 0xdd, 0x4b, 0xae, 0x65, 0x9e, 0x15, 0xf3, 0xef, 0x1c, 0x84, 0xe6, 0xc9, 0x59, 0x28,
-	0xbd, 0x28, 0xe4, 0xad, 0x14, 0x5f, 0x14, 0x94, 0x68, 0xaf, 0xa5, 0xb1, 0xbb, 0x5f, 0xcd, 0x6c,
-	0x43, 0xb5, 0x65, 0xf0, 0x0d, 0x4d, 0x1f, 0xa6, 0x2c, 0x20, 0x16, 0xcf, 0x9c, 0x90, 0xeb, 0x6c,
-	0x61, 0x2b, 0xca, 0x87, 0xa9, 0xf1, 0x5c, 0x9a, 0x8f, 0x0e, 0x72, 0x8d, 0x3b, 0x89, 0xc2, 0x0a,
-	0xc7, 0x57, 0xd2, 0x2c, 0x49, 0x81, 0xe5, 0x93, 0x79, 0x29, 0xa0, 0x1d, 0x9b, 0xbc, 0x39, 0x01,
-	0x55, 0xd6, 0x78, 0x8d, 0x5c, 0xa0, 0xc1, 0xe3, 0x2a, 0x35, 0x9e, 0x17, 0x24, 0x5e, 0xe5, 0x89,
-	0x5a, 0xae, 0xd9, 0x92, 0x43, 0xb2, 0x5a, 0xa2, 0x84, 0xc8, 0x39, 0x62, 0x2e, 0xa0, 0x4e, 0x4d,
-	0xc0, 0xb2, 0x1d, 0x9e, 0x95, 0xf7, 0x89, 0xde, 0x93, 0x87, 0xae, 0x17, 0x44, 0x0c, 0xad, 0x1f,
-	0x84, 0x66, 0x3f, 0x44, 0xed, 0x15, 0x16, 0x48, 0x4f, 0x5c, 0xa7, 0x68, 0xf6, 0x99, 0x5e, 0x46,
-	0x65, 0xb2, 0x07, 0x3e, 0xc3, 0x15, 0x2c, 0x42, 0xd9, 0x1e, 0xd8, 0xd2, 0xcf, 0xf0, 0xb2, 0xb3,
-	0x86, 0x28, 0xb6, 0xa2, 0x37, 0x47, 0xe3, 0xca, 0x98, 0x36, 0xde, 0x3f, 0xd5, 0x9f, 0xa4, 0x45,
-	0xab, 0x42, 0x2a, 0x6e, 0x0f, 0xfb, 0x13, 0xd5, 0x48, 0x96, 0x46, 0x8e, 0xbc, 0xb8, 0x32, 0x16,
-	0x17, 0x21, 0xf1, 0xf2, 0x78, 0x7b, 0xbf, 0xaa, 0xc1, 0x42, 0x53, 0xf0, 0xfd, 0xa3, 0xfa, 0x25,
-	0x1e, 0x35, 0xd2, 0x70, 0xc1, 0x8b, 0x58, 0xdb, 0x6b, 0xf2, 0x25, 0xeb, 0xe3, 0x37, 0x02, 0x4c,
-	0x99, 0xb5, 0xe9, 0xd4, 0x8c, 0xab, 0x62, 0x3a, 0xfe, 0xa6, 0xc5, 0x6f, 0x95, 0x4b, 0x68, 0x7b,
-	0x76, 0x01, 0x46, 0xf8, 0x1d, 0x67, 0xa6, 0x13, 0x9a, 0x66, 0x13, 0x6d, 0xa7, 0x5b, 0x9c, 0x2d,
-	0xc4, 0x36, 0x32, 0x95, 0xf4, 0x14, 0x7c, 0x80, 0x9e, 0x54, 0x00, 0x3e, 0xd0, 0xe4, 0x83, 0x55,
-	0xe2, 0xd7, 0xcf, 0x02, 0x5d, 0xf8, 0x80, 0x66, 0x35, 0xf0, 0xa9, 0xa6, 0x0f, 0xa7, 0x4f, 0x30,
-	0x2e, 0x92, 0x1f, 0x6d, 0x27, 0xc4, 0x06, 0xfe, 0x80, 0x8f, 0xc4, 0x50, 0xf2, 0x84, 0xb1, 0x38,
-	0x7d, 0x57, 0x7e, 0xb7, 0x1d, 0x42, 0x0a, 0x79, 0x9a, 0x48, 0xab, 0x40, 0xd5, 0xcb, 0x99, 0xd2,
-	0x48, 0x0f, 0xb9, 0xb4, 0xf5, 0x95, 0xa4, 0x60, 0xd6, 0x0a, 0x49, 0x8f, 0xbe, 0x5b, 0xfa, 0x79,
-	0xf1, 0xca, 0xd2, 0x08, 0x5c, 0x37, 0xce, 0x6a, 0x3c, 0x92, 0x94, 0xa8, 0xc6, 0xa4, 0xf0, 0xf4,
-	0x16, 0xcf, 0x1a, 0xb8, 0xd6, 0x7c, 0xe0, 0xba, 0x22, 0x1f, 0xb9, 0x47, 0xe2, 0xa2, 0xb2, 0x1b,
-	0x9a, 0x17, 0xe3, 0x23, 0x4b, 0x05, 0x57, 0x60, 0x8f, 0x76, 0xe0, 0x55, 0xfd, 0x74, 0x03, 0x23,
-	0x16, 0xf8, 0xd8, 0x6a, 0xb8, 0x68, 0x9d, 0x1a, 0x53, 0x62, 0xdf, 0x5d, 0xe6, 0x27, 0x7d, 0x0c,
-	0xcc, 0x73, 0x79, 0xfa, 0x22, 0x23, 0x09, 0x2b, 0x30, 0xa7, 0x02, 0xda, 0xfa, 0x88, 0xf4, 0x10,
-	0x13, 0xd5, 0x38, 0x98, 0x78, 0xc1, 0xfa, 0x86, 0x71, 0x4d, 0x2c, 0xda, 0x97, 0x45, 0x78, 0x4d,
-	0x55, 0x16, 0xb9, 0xc6, 0x2b, 0x42, 0x21, 0xcd, 0x7a, 0x94, 0x68, 0x9a, 0x51, 0xa8, 0x1b, 0x83,
-	0x4d, 0x7d, 0xa8, 0xd4, 0x71, 0x13, 0x6d, 0x1b, 0xd7, 0x45, 0xaf, 0x2f, 0xf1, 0x64, 0xb0, 0xd0,
-	0x70, 0x09, 0x6d, 0x77, 0x43, 0xd3, 0x50, 0x75, 0xb9, 0x84, 0xb6, 0xd3, 0xfe, 0x14, 0xcd, 0xc0,
-	0x57, 0xf4, 0xbe, 0xa0, 0x45, 0x5a, 0xe9, 0x31, 0xf2, 0xab, 0x79, 0x31, 0x39, 0x5f, 0x38, 0x08,
-	0xcd, 0x73, 0x59, 0x06, 0xb3, 0xb6, 0x4c, 0x96, 0xb3, 0x33, 0x45, 0xe4, 0x2e, 0x71, 0x5a, 0xd7,
-	0x22, 0xad, 0x18, 0x90, 0xb2, 0x96, 0xdd, 0xfd, 0xaa, 0xba, 0xb1, 0xa1, 0xc1, 0x53, 0x52, 0x13,
-	0xf0, 0x0b, 0x2d, 0xee, 0x3e, 0xb9, 0xb4, 0x7f, 0x6f, 0x5e, 0x38, 0xf9, 0xb6, 0xd8, 0x05, 0x79,
-	0x13, 0xe9, 0x05, 0xbe, 0xe8, 0x7e, 0x2c, 0xed, 0x5e, 0xbe, 0x78, 0x97, 0x38, 0x64, 0xdb, 0xfd,
-	0x7c, 0x6f, 0x2d, 0xbe, 0xac, 0x55, 0xbd, 0x18, 0x1a, 0xd4, 0xb3, 0x56, 0xe0, 0x77, 0x9a, 0xde,
-	0x2f, 0x68, 0x66, 0xd7, 0xf3, 0xbf, 0x8e, 0x88, 0x7e, 0x5b, 0x64, 0xc5, 0x79, 0x13, 0xd2, 0x55,
-	0xbd, 0xa0, 0x5a, 0x49, 0xa9, 0xe6, 0x2f, 0xd7, 0x95, 0x64, 0x2f, 0x7e, 0x96, 0x1e, 0xcf, 0x7d,
-	0xd5, 0x7d, 0x19, 0x1a, 0xec, 0x93, 0x5b, 0x66, 0x94, 0xb3, 0x4b, 0xf8, 0xf7, 0x7b, 0x53, 0x96,
-	0x2e, 0xe4, 0x0b, 0x94, 0xf3, 0x57, 0xe8, 0xbd, 0x29, 0xf7, 0xd2, 0x2b, 0x53, 0x4e, 0x34, 0x13,
-	0xca, 0xe9, 0x9d, 0x7b, 0x43, 0x8f, 0x1e, 0xfb, 0xd2, 0x43, 0xf3, 0x37, 0xf3, 0x62, 0xf7, 0x7e,
-	0x2e, 0xcf, 0x57, 0xbc, 0x97, 0x65, 0xa7, 0xa7, 0xb4, 0x18, 0xfd, 0x0c, 0xc9, 0xa7, 0xd0, 0x7d,
-	0x12, 0x42, 0xc5, 0x95, 0x45, 0xf9, 0xb6, 0xc0, 0x6a, 0xd9, 0xcc, 0xf8, 0x80, 0x0f, 0x91, 0x36,
-	0xb3, 0x74, 0x10, 0x9a, 0x17, 0xb3, 0x1e, 0x97, 0xf2, 0xb5, 0xfe, 0xb2, 0xcd, 0xf2, 0xe3, 0xd4,
-	0x2c, 0xe1, 0xf9, 0xee, 0x41, 0x59, 0x81, 0x67, 0x08, 0x43, 0x85, 0xf3, 0x91, 0xda, 0x88, 0x50,
-	0xe3, 0xb7, 0xd1, 0x2c, 0xad, 0x16, 0x28, 0xc8, 0xe7, 0xca, 0x0a, 0x57, 0x2c, 0x50, 0x28, 0xe1,
-	0xe5, 0xa9, 0x12, 0x4c, 0x4a, 0x7a, 0x33, 0x77, 0x3e, 0xfc, 0x64, 0xf4, 0xc8, 0xfe, 0x27, 0xa3,
-	0x47, 0x3e, 0x3c, 0x18, 0xd5, 0xf6, 0x0f, 0x46, 0xb5, 0xef, 0xde, 0x1f, 0x3d, 0xf2, 0xee, 0xfd,
-	0x51, 0x6d, 0xff, 0xfe, 0xe8, 0x91, 0x8f, 0xee, 0x8f, 0x1e, 0x79, 0xfd, 0x99, 0x75, 0x87, 0x6d,
-	0x04, 0xb5, 0x2b, 0xb6, 0xd7, 0xbc, 0x9a, 0x66, 0xad, 0xd2, 0xaf, 0xec, 0xdf, 0x4b, 0xb5, 0x13,
-	0xe2, 0xef, 0x4a, 0xd7, 0xfe, 0x17, 0x00, 0x00, 0xff, 0xff, 0x2c, 0x30, 0xd1, 0x0d, 0x1a, 0x25,
-	0x00, 0x00,
+	// 3943 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x5a, 0x5d, 0x6c, 0x1d, 0x49,
+	0x56, 0x4e, 0x27, 0x9b, 0xec, 0xa4, 0xe3, 0xd8, 0x71, 0xd9, 0xb1, 0x7b, 0x92, 0xac, 0xdb, 0x73,
+	0x73, 0xb3, 0xeb, 0x99, 0x9d, 0x24, 0xb6, 0x93, 0xc9, 0x66, 0x02, 0xcb, 0xac, 0x7f, 0xc6, 0xc4,
+	0x1b, 0xdb, 0xb1, 0xca, 0xb6, 0x06, 0x06, 0xa1, 0x56, 0xdd, 0xee, 0xba, 0x76, 0xe3, 0xbe, 0xd5,
+	0x77, 0xba, 0xfb, 0xfa, 0x67, 0x16, 0xc1, 0x68, 0x56, 0xfc, 0x3c, 0x20, 0x2d, 0x58, 0xfc, 0x48,
+	0x20, 0xa1, 0x45, 0x80, 0xc4, 0xb0, 0x2c, 0x20, 0xad, 0x84, 0x04, 0x3c, 0x2c, 0x42, 0x42, 0x1a,
+	0xb1, 0x0f, 0xf6, 0x23, 0x12, 0xd0, 0x68, 0x1c, 0x9e, 0xee, 0x03, 0x0f, 0xf7, 0x31, 0xbc, 0xac,
+	0x4e, 0x55, 0xff, 0x54, 0x77, 0xd7, 0x4d, 0xf2, 0xd6, 0x7d, 0xbe, 0x73, 0x4e, 0x9d, 0x53, 0x5d,
+	0x75, 0xaa, 0xce, 0x39, 0xad, 0xdf, 0xf2, 0xdc, 0xc6, 0x5d, 0xdb, 0x67, 0x4d, 0x77, 0xfb, 0xae,
+	0xdf, 0x8e, 0x5c, 0x9f, 0x85, 0xe2, 0xad, 0x13, 0x10, 0x78, 0xbb, 0xd3, 0x0e, 0xfc, 0xc8, 0x47,
+	0x17, 0x04, 0xf1, 0xda, 0xb8, 0xc4, 0x1e, 0x75, 0x98, 0xcb, 0xb6, 0x05, 0xc3, 0xb5, 0x49, 0x09,
+	0x70, 0x48, 0x44, 0x1a, 0x24, 0xa4, 0x0d, 0x62, 0xef, 0x52, 0xe6, 0x24, 0x1c, 0x57, 0x25, 0x8e,
+	0xd0, 0xfd, 0x98, 0x26, 0xe4, 0x09, 0x89, 0x4c, 0x1c, 0x27, 0xa0, 0x61, 0xd8, 0x24, 0x2d, 0xd7,
+	0x3b, 0x4c, 0xf0, 0x8b, 0xf4, 0x20, 0x12, 0x8f, 0xb5, 0x9f, 0xfc, 0xa2, 0x3e, 0xfa, 0x54, 0xd8,
+	0xb8, 0x20, 0xdb, 0x88, 0xfe, 0x54, 0xd3, 0xaf, 0x78, 0x6e, 0x18, 0x51, 0x66, 0x25, 0x2a, 0x68,
+	0x68, 0x68, 0x93, 0xe7, 0xa6, 0x2e, 0xce, 0x87, 0xa7, 0xb1, 0x89, 0x30, 0xd9, 0x5f, 0xe1, 0xf0,
+	0x5c, 0x8a, 0x76, 0x63, 0x73, 0xc8, 0x2b, 0x92, 0x7a, 0xb1, 0x79, 0xeb, 0xa0, 0xe5, 0x3d, 0xaa,
+	0x15, 0xe8, 0xb5, 0x49, 0x87, 0x36, 0x49, 0xc7, 0x8b, 0x1e, 0xd5, 0x92, 0x87, 0xda, 0xf3, 0xe3,
+	0xfa, 0x97, 0x93, 0xe7, 0xa3, 0x93, 0xba, 0x42, 0x39, 0x2e, 0xab, 0x46, 0xff, 0xa7, 0xe9, 0xc6,
+	0xb6, 0xe7, 0x37, 0x88, 0x67, 0x39, 0x6e, 0x68, 0xfb, 0x7b, 0x34, 0x38, 0xb4, 0x42, 0x1a, 0xec,
+	0xd1, 0x20, 0x34, 0xce, 0x72, 0x43, 0x7f, 0xa4, 0x9d, 0xc6, 0xe6, 0x08, 0x26, 0xfb, 0x3f, 0xcf,
+	0xf9, 0xe6, 0x18, 0xdb, 0x10, 0x78, 0x37, 0x36, 0xaf, 0x6e, 0xa7, 0x34, 0xbf, 0xc3, 0x6c, 0x9a,
+	0x00, 0xbd, 0xd8, 0x7c, 0x9b, 0x1b, 0xac, 0x42, 0x15, 0x76, 0x77, 0x8f, 0xeb, 0xa3, 0x2a, 0xd6,
+	0xde, 0x71, 0x5d, 0x3d, 0x40, 0xd1, 0x51, 0x95, 0x6d, 0x78, 0x4c, 0x08, 0x2e, 0xa6, 0x4e, 0x25,
+	0x74, 0xf4, 0xbf, 0x2a, 0x87, 0x29, 0x23, 0x0d, 0x8f, 0x3a, 0xc6, 0xb9, 0x49, 0x6d, 0xea, 0xb5,
+	0xf9, 0xcf, 0xc0, 0xe1, 0x2b, 0x99, 0xc6, 0xf7, 0x05, 0x58, 0xf5, 0x36, 0x01, 0x7a, 0xb1, 0xf9,
+	0x96, 0xc2, 0xdb, 0x04, 0x95, 0xdc, 0x8d, 0x82, 0x0e, 0x05, 0x5f, 0xfb, 0xa8, 0xe9, 0x07, 0x3c,
+	0x3f, 0xae, 0x7f, 0x09, 0x44, 0x8f, 0x4e, 0xea, 0x15, 0xa3, 0x2a, 0x6e, 0x26, 0x74, 0xf4, 0x5f,
+	0x9a, 0x3e, 0xee, 0xf9, 0xb6, 0xd2, 0xcb, 0x2f, 0x71, 0x2f, 0xff, 0x1c, 0xbc, 0x1c, 0x5a, 0xf1,
+	0x6d, 0x59, 0x5f, 0x37, 0x36, 0x47, 0x3d, 0xdf, 0xae, 0xd8, 0xd0, 0x8b, 0xcd, 0x37, 0xc5, 0x12,
+	0xf4, 0xed, 0x57, 0x71, 0x51, 0xad, 0xa4, 0x0f, 0x5d, 0x72, 0xb0, 0x6c, 0x0f, 0xbe, 0xca, 0x05,
+	0x2a, 0xee, 0xfd, 0x44, 0xd3, 0x47, 0x84, 0x7b, 0x24, 0xd1, 0x65, 0xb5, 0xfd, 0x20, 0x32, 0xce,
+	0x4f, 0x6a, 0x53, 0xe7, 0xe7, 0xff, 0x18, 0x5c, 0x1b, 0x48, 0x55, 0xad, 0xfb, 0x41, 0xd4, 0x8d,
+	0xcd, 0xe1, 0xc2, 0xd0, 0x40, 0xec, 0xc5, 0xe6, 0xd7, 0xaa, 0x4e, 0x01, 0x22, 0x79, 0x34, 0x3b,
+	0x33, 0x3d, 0xfb, 0x8d, 0xda, 0xf3, 0xd8, 0x3c, 0xe7, 0xb2, 0xa8, 0x7b, 0x5c, 0x57, 0xa8, 0x51,
+	0x11, 0x9f, 0x1f, 0xd7, 0xcf, 0x73, 0xd1, 0xa3, 0x93, 0x7a, 0xc1, 0x12, 0x5c, 0xe5, 0x45, 0xdf,
+	0x3d, 0xab, 0x4f, 0x96, 0xbc, 0x69, 0x75, 0xbc, 0xc8, 0xb5, 0x49, 0x18, 0xa5, 0x71, 0xc3, 0xb8,
+	0x30, 0xa9, 0x4d, 0x5d, 0x9c, 0xff, 0x47, 0x70, 0x6d, 0x30, 0x55, 0xb8, 0xba, 0x00, 0x3b, 0xb9,
+	0x1b, 0x9b, 0x23, 0x05, 0xa5, 0x82, 0xdc, 0x8b, 0xcd, 0x07, 0x55, 0xf7, 0x04, 0x26, 0x39, 0xf8,
+	0x4b, 0xcd, 0xe6, 0xcc, 0xec, 0xa3, 0x47, 0x0f, 0xef, 0x3d, 0xbc, 0xff, 0xcb, 0x8f, 0x84, 0xb7,
+	0xdd, 0xe3, 0xba, 0x52, 0xa1, 0x9a, 0xfc, 0xfc, 0xb8, 0x8e, 0xaa, 0x4a, 0x8e, 0x4e, 0xea, 0x25,
+	0x33, 0xf1, 0x57, 0x8a, 0xc2, 0xa9, 0x87, 0x49, 0x30, 0x42, 0x4f, 0xf5, 0xcb, 0x2d, 0x72, 0x60,
+	0x85, 0x94, 0x39, 0xd6, 0x6e, 0xa3, 0x1d, 0x1a, 0x5f, 0xe6, 0x1f, 0xf3, 0xeb, 0xdd, 0xd8, 0xbc,
+	0xd4, 0x22, 0x07, 0x1b, 0x94, 0x39, 0x4f, 0x1a, 0x6d, 0x08, 0x2e, 0xc3, 0xdc, 0x2d, 0x89, 0x96,
+	0x7e, 0x1f, 0x2c, 0x33, 0xa6, 0x0a, 0x03, 0x6a, 0xef, 0x09, 0x85, 0xaf, 0x15, 0x14, 0x62, 0x6a,
+	0xef, 0x95, 0x15, 0xa6, 0xb4, 0x82, 0xc2, 0x94, 0x88, 0xfe, 0x41, 0xd3, 0xc7, 0x03, 0x6a, 0xfb,
+	0x8c, 0x51, 0x1b, 0xc2, 0xbb, 0xe5, 0xb2, 0x88, 0x06, 0x7b, 0xc4, 0xb3, 0x42, 0xe3, 0x22, 0xd7,
+	0xfd, 0x6b, 0x3c, 0xa8, 0xa7, 0x2c, 0xcb, 0x09, 0xbc, 0x01, 0xb1, 0x43, 0x16, 0xcc, 0x80, 0x5e,
+	0x6c, 0x4e, 0xf1, 0xb1, 0x95, 0xa8, 0xf4, 0x95, 0x1e, 0x4c, 0xa7, 0x26, 0x3d, 0x3f, 0xae, 0x9f,
+	0x7d, 0x30, 0xcd, 0xe3, 0x7b, 0x65, 0x1c, 0xac, 0x1e, 0x05, 0x35, 0xf5, 0xc1, 0x80, 0x7a, 0xe4,
+	0x30, 0xcc, 0x62, 0x80, 0xce, 0x63, 0xc0, 0x7b, 0xdd, 0xd8, 0xbc, 0x2c, 0x90, 0x7c, 0xa3, 0xd7,
+	0x12, 0x83, 0x24, 0x6a, 0x79, 0x87, 0xa7, 0x3b, 0x16, 0x17, 0x85, 0xd1, 0xa7, 0x67, 0xf5, 0xeb,
+	0xc9, 0x40, 0x99, 0x21, 0xf9, 0x24, 0xb5, 0x8c, 0x4b, 0x7c, 0x92, 0xfe, 0x15, 0xd6, 0xf0, 0x38,
+	0x06, 0xbe, 0x8a, 0x0b, 0xab, 0xdd, 0xd8, 0x1c, 0x0f, 0xd4, 0x50, 0x16, 0x68, 0xfb, 0xe0, 0x92,
+	0x95, 0x33, 0xd3, 0xd2, 0x96, 0xed, 0xab, 0xaf, 0x3f, 0x04, 0x93, 0x3c, 0x03, 0x93, 0xdc, 0xcf,
+	0x4c, 0x6c, 0x08, 0x3f, 0xab, 0x08, 0x6a, 0xe8, 0x97, 0xc3, 0x88, 0x04, 0x91, 0xd5, 0x08, 0xfc,
+	0xfd, 0x90, 0x06, 0xc6, 0x00, 0x9f, 0xeb, 0x6f, 0x76, 0x63, 0x73, 0x80, 0x03, 0xf3, 0x82, 0xde,
+	0x8b, 0xcd, 0x37, 0xb8, 0x3b, 0x32, 0xb1, 0xef, 0x4c, 0x17, 0x44, 0xd1, 0x5f, 0x6a, 0xfa, 0x55,
+	0x46, 0x22, 0x2b, 0x0a, 0x08, 0x9c, 0x6a, 0xc4, 0xcb, 0x3e, 0xec, 0x20, 0x1f, 0xec, 0xa3, 0xd3,
+	0xd8, 0xd4, 0xd7, 0xe6, 0x36, 0xf3, 0xb0, 0xae, 0x33, 0x12, 0xe5, 0xdf, 0xd8, 0xe4, 0x03, 0xe7,
+	0x24, 0x45, 0x08, 0x97, 0x05, 0x0a, 0x6f, 0x52, 0xb8, 0x96, 0x86, 0xc0, 0x23, 0x8c, 0x44, 0x9b,
+	0xa9, 0x39, 0xe9, 0x82, 0xf8, 0xa7, 0x8a, 0x9d, 0x1e, 0x25, 0x21, 0xb5, 0x5a, 0xc6, 0x10, 0x5f,
+	0x0a, 0xbf, 0x09, 0x4b, 0xe1, 0xe2, 0xda, 0xdc, 0xe6, 0x0a, 0x90, 0xe1, 0xe3, 0x0f, 0x31, 0x12,
+	0x89, 0x17, 0x97, 0x75, 0x22, 0x1a, 0x66, 0x0b, 0xb2, 0x44, 0x57, 0xee, 0x8d, 0xee, 0x71, 0xbd,
+	0x22, 0x5f, 0x25, 0x65, 0x3b, 0x28, 0x1f, 0x18, 0x23, 0xd9, 0x7a, 0x41, 0x43, 0xff, 0xae, 0xe9,
+	0xe3, 0x45, 0xe3, 0x03, 0xca, 0xe8, 0x3e, 0x5f, 0xc9, 0x57, 0xb8, 0xf9, 0x47, 0x60, 0xfe, 0xa5,
+	0xb5, 0xb9, 0x4d, 0x2c, 0x00, 0x70, 0x60, 0x98, 0x91, 0x28, 0x7d, 0xcd, 0x5c, 0xa8, 0xa7, 0x2e,
+	0x14, 0x11, 0xc9, 0x89, 0x7b, 0xb2, 0x13, 0x0a, 0x1d, 0x2a, 0x22, 0x38, 0x72, 0x0f, 0x1c, 0x91,
+	0x4d, 0xc0, 0xa3, 0xb2, 0x2b, 0x29, 0x55, 0xe1, 0x4c, 0xe4, 0xb6, 0xa8, 0xdf, 0x89, 0xac, 0xd0,
+	0x18, 0x2e, 0x3a, 0xb3, 0x29, 0x80, 0x8d, 0xc4, 0x99, 0xf4, 0x15, 0x56, 0xba, 0x53, 0x70, 0xa6,
+	0x88, 0xf4, 0xdb, 0x7e, 0x0a, 0x1d, 0x2a, 0x62, 0xb6, 0xe5, 0x64, 0x13, 0x8a, 0xce, 0xa4, 0x54,
+	0xf4, 0x27, 0x9a, 0x6e, 0x74, 0x42, 0xb2, 0x4d, 0xad, 0x80, 0xc2, 0xb9, 0xef, 0xb2, 0x6d, 0x8b,
+	0xd8, 0x36, 0x6d, 0x47, 0xd4, 0x31, 0x10, 0xf7, 0x86, 0xc0, 0x0e, 0xd8, 0xc2, 0x73, 0x09, 0x15,
+	0x76, 0x40, 0x27, 0x48, 0xdf, 0x7a, 0xb1, 0x79, 0x85, 0x3b, 0x91, 0x93, 0x24, 0x83, 0x65, 0xc6,
+	0xc2, 0x1b, 0xac, 0xf8, 0x5c, 0x25, 0x1e, 0xe3, 0x26, 0xe0, 0xd4, 0x82, 0x94, 0x8e, 0xbe, 0xa3,
+	0x8f, 0x96, 0x8d, 0x0b, 0x29, 0x65, 0xc6, 0x08, 0x37, 0x6c, 0xf9, 0x34, 0x36, 0x2f, 0x6c, 0xe1,
+	0x0d, 0x4a, 0x59, 0x37, 0x36, 0x2f, 0x74, 0x02, 0x78, 0xea, 0xc5, 0xe6, 0x40, 0x62, 0x10, 0xbc,
+	0x4a, 0xc6, 0xa4, 0x0c, 0xd9, 0xd3, 0xd1, 0x49, 0x3d, 0x11, 0xc7, 0xa8, 0x68, 0x00, 0xd0, 0xd0,
+	0x1f, 0x68, 0xfa, 0xeb, 0xe5, 0xd1, 0x3b, 0xcc, 0xfd, 0xa8, 0x43, 0x2d, 0xd7, 0x31, 0x46, 0xf9,
+	0x25, 0xe2, 0x43, 0x31, 0x37, 0x5b, 0x9c, 0xbc, 0xbc, 0x28, 0xe6, 0x26, 0x79, 0x93, 0xe7, 0x26,
+	0x65, 0xa8, 0x89, 0x49, 0x49, 0x5f, 0x7b, 0xf2, 0x5b, 0x32, 0x29, 0x29, 0x56, 0x9e, 0x94, 0x94,
+	0x0b, 0xfd, 0x8b, 0xa6, 0x8f, 0x54, 0xec, 0x0a, 0x3c, 0xe3, 0x2a, 0xb7, 0xe8, 0x7b, 0xb0, 0xf6,
+	0xce, 0x6f, 0xe1, 0x2d, 0xbc, 0xd2, 0x8d, 0xcd, 0xf3, 0x9d, 0x60, 0x0b, 0xaf, 0xf4, 0x62, 0xf3,
+	0x61, 0x6a, 0x08, 0x5e, 0x91, 0x56, 0xd7, 0x4e, 0x14, 0xb5, 0xc3, 0x47, 0x77, 0x79, 0x36, 0x77,
+	0x27, 0x3c, 0x64, 0x76, 0xb4, 0x03, 0xe9, 0x1e, 0xa3, 0xd1, 0x5d, 0x46, 0xf7, 0x81, 0x0a, 0x06,
+	0x27, 0x4a, 0xd2, 0x87, 0xe7, 0xc7, 0xf5, 0x57, 0x10, 0x3c, 0x3a, 0xa9, 0x0b, 0x2b, 0xf0, 0x70,
+	0xc9, 0x8f, 0xc0, 0x43, 0xff, 0xa3, 0xe9, 0x66, 0xd9, 0x85, 0xb6, 0x1f, 0xc2, 0x09, 0x17, 0x52,
+	0xbb, 0x13, 0x50, 0xef, 0xd0, 0x18, 0xe3, 0xe1, 0xf7, 0x8f, 0x78, 0x06, 0xb1, 0x85, 0xd7, 0xfd,
+	0x30, 0x5a, 0xce, 0xc0, 0x6e, 0x6c, 0x5e, 0xe9, 0x04, 0x45, 0x5a, 0x2f, 0x36, 0xbf, 0x9a, 0x38,
+	0x59, 0x04, 0x24, 0x7f, 0x9b, 0xc4, 0x0b, 0x79, 0x48, 0xae, 0x4a, 0x2b, 0x68, 0x70, 0xf3, 0xe4,
+	0x12, 0x90, 0x2f, 0x94, 0x4d, 0xc0, 0x37, 0x8a, 0x6e, 0x15, 0x51, 0xf4, 0xdf, 0x0a, 0x0f, 0x5d,
+	0xe6, 0x46, 0x2e, 0xe4, 0x11, 0x70, 0xde, 0x59, 0xa1, 0x31, 0xce, 0x57, 0xf1, 0x1f, 0xf2, 0xec,
+	0x61, 0x0b, 0x2f, 0x0b, 0x74, 0x11, 0x40, 0x08, 0x18, 0x43, 0x9d, 0xa0, 0x40, 0xca, 0xc2, 0x45,
+	0x89, 0x2e, 0x07, 0x8b, 0x87, 0xd3, 0x85, 0x00, 0x5e, 0xd6, 0x50, 0x25, 0xc1, 0x09, 0x04, 0x52,
+	0x90, 0x30, 0x94, 0x4c, 0xc0, 0xd7, 0x8b, 0x0e, 0x16, 0x40, 0xe4, 0xeb, 0xc3, 0x01, 0x15, 0x87,
+	0xb3, 0xcf, 0xac, 0x7d, 0xb2, 0x4b, 0x3b, 0x6d, 0xc3, 0xe0, 0x9f, 0x6c, 0x01, 0x8c, 0x4f, 0xc0,
+	0xa7, 0xec, 0x03, 0x0e, 0x65, 0xc6, 0x97, 0xe8, 0x7d, 0x0f, 0xe9, 0xb2, 0x02, 0xf4, 0x5b, 0x9a,
+	0x3e, 0x4e, 0x3a, 0x91, 0x6f, 0x75, 0xda, 0xdb, 0x01, 0x71, 0x68, 0x7e, 0x19, 0xda, 0x31, 0x5e,
+	0xe7, 0x13, 0xb9, 0x0e, 0x29, 0x17, 0xb0, 0x6c, 0x09, 0x8e, 0xf4, 0x1e, 0xf1, 0x38, 0xcb, 0x4e,
+	0x54, 0xa0, 0x3c, 0x7d, 0xb3, 0xf2, 0xcd, 0x70, 0x66, 0x16, 0x2b, 0xb5, 0xa1, 0x96, 0x3e, 0x9e,
+	0xda, 0x10, 0xf9, 0x56, 0x3b, 0x80, 0x4f, 0xcc, 0xcf, 0xe2, 0xd0, 0xb8, 0xc6, 0x27, 0xe0, 0x01,
+	0x18, 0x92, 0xb0, 0x6c, 0xfa, 0xeb, 0x01, 0xc5, 0x09, 0xde, 0x8b, 0xcd, 0x6b, 0xe2, 0x13, 0x2a,
+	0xc0, 0x1a, 0x56, 0xca, 0xa0, 0x3d, 0x1d, 0xed, 0x52, 0xda, 0xb6, 0x22, 0xda, 0x6a, 0xfb, 0x01,
+	0x09, 0x5c, 0x1a, 0x5a, 0x3b, 0xc6, 0x75, 0xee, 0xf2, 0x63, 0xd8, 0x08, 0x80, 0x6e, 0xe6, 0x20,
+	0xb8, 0x7b, 0x93, 0x8f, 0x52, 0x06, 0xe4, 0x5c, 0xec, 0xbe, 0xec, 0xea, 0xec, 0x7d, 0x5c, 0xd1,
+	0x82, 0x0e, 0xf5, 0x11, 0x9b, 0xd8, 0x3b, 0xd4, 0x72, 0xb7, 0x99, 0x1f, 0x50, 0xc7, 0x6a, 0xba,
+	0x1e, 0x0d, 0x8d, 0x1b, 0xdc, 0xc5, 0x65, 0x38, 0xd1, 0x38, 0xbc, 0x2c, 0xd0, 0x25, 0x00, 0xb3,
+	0x89, 0xae, 0x20, 0x95, 0x3d, 0x98, 0xed, 0x2d, 0x5c, 0x55, 0x83, 0x7e, 0x4f, 0xd3, 0xaf, 0xb5,
+	0x03, 0x7f, 0x1b, 0x92, 0x19, 0xab, 0xd3, 0x76, 0x48, 0x44, 0xe5, 0x04, 0xe1, 0x2b, 0xdc, 0xf7,
+	0x4d, 0xb8, 0xdf, 0xa6, 0x5c, 0x5b, 0x9c, 0x49, 0x4e, 0x06, 0x44, 0x92, 0xdd, 0x07, 0x97, 0xcc,
+	0x79, 0x47, 0x9a, 0x08, 0xed, 0x1d, 0xdc, 0x4f, 0x23, 0xfa, 0x54, 0xd3, 0xc7, 0x3c, 0xb7, 0xe5,
+	0x46, 0x56, 0x83, 0x30, 0x67, 0xdf, 0x75, 0xa2, 0x1d, 0xcb, 0x65, 0x96, 0x47, 0x98, 0x31, 0xc1,
+	0xa7, 0x64, 0x95, 0x27, 0x8f, 0xc0, 0x31, 0x9f, 0x32, 0x2c, 0xb3, 0x15, 0xc2, 0xf2, 0x84, 0xbf,
+	0x8a, 0xbd, 0x60, 0x5a, 0x54, 0xaa, 0xd0, 0x27, 0x9a, 0x8e, 0x5a, 0x2e, 0xb3, 0x76, 0xfc, 0x16,
+	0x85, 0x72, 0xc4, 0xae, 0xd5, 0x0c, 0x28, 0x35, 0xcc, 0x49, 0x6d, 0xea, 0xd2, 0xec, 0xc0, 0x1d,
+	0x51, 0x62, 0xbb, 0xb3, 0xe1, 0x7e, 0x4c, 0xe7, 0xdf, 0xff, 0x3c, 0x36, 0xcf, 0xc0, 0x4e, 0x6c,
+	0xb9, 0xec, 0xb1, 0xdf, 0xa2, 0x8b, 0x6e, 0xb8, 0xbb, 0x14, 0x50, 0x9a, 0xad, 0x8e, 0x12, 0x5d,
+	0xde, 0x07, 0x93, 0xb7, 0xc0, 0x90, 0x73, 0x33, 0x93, 0xb7, 0x70, 0x59, 0x1c, 0x3d, 0xd3, 0xf4,
+	0x81, 0x74, 0xbd, 0xf3, 0x63, 0x67, 0x92, 0x1f, 0x3b, 0x3f, 0xe6, 0x57, 0x9e, 0x74, 0xd1, 0x8a,
+	0xc3, 0xe7, 0x52, 0x90, 0xbf, 0xf6, 0x62, 0x73, 0x31, 0xcd, 0x38, 0x52, 0x9a, 0xe2, 0x20, 0x4a,
+	0x76, 0x40, 0x58, 0x3a, 0x53, 0x5a, 0x34, 0x22, 0x77, 0x7e, 0x25, 0xf4, 0x19, 0xc4, 0xee, 0x82,
+	0xda, 0xe2, 0xeb, 0xf3, 0xe3, 0xfa, 0xd4, 0xab, 0xaa, 0x82, 0xfb, 0x91, 0x64, 0x2f, 0xce, 0xf5,
+	0x04, 0x1e, 0xfa, 0x40, 0x1f, 0x26, 0xde, 0x3e, 0x64, 0x5f, 0xa2, 0x9a, 0xc0, 0x68, 0x14, 0x1a,
+	0x6f, 0xf0, 0x22, 0x1e, 0x24, 0xbd, 0x43, 0x02, 0xe4, 0x59, 0xf9, 0x1a, 0x8d, 0x60, 0xe1, 0x8f,
+	0x8a, 0x08, 0x53, 0xa0, 0xd7, 0x70, 0x99, 0x11, 0xfd, 0xbf, 0xa6, 0x4f, 0x41, 0xfd, 0x65, 0x3f,
+	0x70, 0x23, 0x08, 0x1c, 0x2d, 0x3f, 0xa2, 0x96, 0x43, 0xf7, 0x5c, 0x9b, 0x5a, 0x8c, 0xb4, 0x68,
+	0x08, 0xe1, 0x34, 0x49, 0x84, 0x8c, 0x5a, 0x5e, 0x5e, 0x1a, 0x7f, 0x9a, 0x0a, 0x61, 0x2e, 0xb3,
+	0x48, 0xf7, 0xd6, 0x80, 0xbd, 0x1b, 0x9b, 0x37, 0xfd, 0x0a, 0xe4, 0xda, 0x94, 0xa3, 0x4f, 0xd9,
+	0x82, 0x50, 0xd5, 0x8b, 0xcd, 0x77, 0xb9, 0x81, 0xaf, 0xc0, 0xdb, 0x7f, 0x51, 0x42, 0x16, 0xd7,
+	0xc7, 0x0e, 0xfc, 0x2a, 0x56, 0xa0, 0x5f, 0xd7, 0xaf, 0x42, 0x18, 0xb3, 0x5c, 0xe6, 0xd0, 0x03,
+	0x0b, 0x56, 0x72, 0xc3, 0xf3, 0xed, 0xdd, 0xd0, 0xb8, 0xc9, 0xb7, 0x34, 0x2c, 0x1a, 0x04, 0x0c,
+	0xcb, 0x80, 0xaf, 0xba, 0x6c, 0x9e, 0xa3, 0x59, 0xd5, 0xb6, 0x0a, 0x29, 0x6f, 0xca, 0xe2, 0xfe,
+	0x8b, 0x15, 0x9a, 0xd0, 0x7f, 0xc2, 0x75, 0x97, 0x41, 0xcd, 0xda, 0xb1, 0x98, 0x1f, 0xb9, 0x4d,
+	0xd7, 0x26, 0xa2, 0xfe, 0xe0, 0x84, 0x46, 0x9d, 0x7f, 0xdf, 0xef, 0xc3, 0x74, 0x8f, 0x6d, 0x09,
+	0xa6, 0x35, 0x89, 0x67, 0x79, 0x11, 0x66, 0x7b, 0xac, 0xa3, 0x44, 0x7a, 0xb1, 0x79, 0x5d, 0x84,
+	0x76, 0x15, 0xcc, 0x6b, 0x95, 0x4a, 0xa4, 0x77, 0x5c, 0xef, 0xa3, 0xf1, 0xe8, 0xa4, 0xde, 0xc7,
+	0x0a, 0xac, 0x94, 0x70, 0x42, 0x84, 0xf5, 0xcb, 0x51, 0x40, 0x9a, 0x4d, 0xd7, 0xb6, 0x6c, 0x8f,
+	0x84, 0xa1, 0x71, 0x8b, 0x4f, 0xeb, 0x6d, 0xc8, 0x97, 0x13, 0x60, 0x01, 0xe8, 0xbd, 0xd8, 0x44,
+	0x62, 0x42, 0x25, 0x62, 0x56, 0xa8, 0x29, 0xb0, 0xa2, 0xef, 0xe8, 0x23, 0xc9, 0x14, 0x5b, 0x4d,
+	0xdf, 0x73, 0x68, 0x60, 0xb5, 0x49, 0xb4, 0x63, 0x7c, 0x95, 0xef, 0xfa, 0x27, 0xa7, 0xb1, 0x79,
+	0x7d, 0x91, 0xb6, 0x03, 0x6a, 0x93, 0x88, 0x3a, 0x8b, 0x82, 0x71, 0x89, 0xf3, 0xad, 0x93, 0x68,
+	0xa7, 0x1b, 0x9b, 0xda, 0xed, 0x2c, 0x3b, 0x77, 0xca, 0xf0, 0xdb, 0x7e, 0xcb, 0x85, 0x8f, 0x14,
+	0x1d, 0xd6, 0x0c, 0x0d, 0x0f, 0x57, 0x70, 0xb4, 0xab, 0x5f, 0x09, 0x69, 0x64, 0x79, 0xfe, 0xbe,
+	0xd5, 0x0e, 0x5c, 0x3f, 0x70, 0xa3, 0x43, 0xe3, 0x6b, 0x7c, 0x53, 0xcc, 0x75, 0x63, 0x73, 0x30,
+	0xa4, 0xd1, 0x8a, 0xbf, 0xbf, 0x9e, 0x20, 0x59, 0x64, 0x2b, 0x92, 0xfb, 0x5e, 0x31, 0x4a, 0xe2,
+	0xe8, 0x33, 0x4d, 0x1f, 0x83, 0x2a, 0x57, 0xe2, 0xa6, 0xed, 0x33, 0xbb, 0x13, 0x04, 0x94, 0xd9,
+	0x87, 0xc6, 0x14, 0x9f, 0xc7, 0x90, 0x17, 0x5b, 0xc8, 0xfe, 0x2a, 0x39, 0x10, 0x36, 0x2e, 0xe4,
+	0x2c, 0x70, 0xe4, 0xb7, 0x14, 0xf4, 0xec, 0xc8, 0x57, 0x81, 0xe9, 0x94, 0xf3, 0xea, 0x88, 0x5a,
+	0x2f, 0x56, 0x6a, 0x85, 0xa2, 0xf4, 0x88, 0x1d, 0x90, 0x70, 0xa7, 0x94, 0x03, 0xbc, 0xc9, 0x3f,
+	0xcb, 0x0f, 0x78, 0x0e, 0xb0, 0x90, 0xe6, 0x00, 0x76, 0x92, 0x03, 0x2c, 0x89, 0xb3, 0x19, 0xc4,
+	0xf2, 0xdb, 0xb8, 0x32, 0x0c, 0x73, 0x9e, 0xea, 0xbd, 0x9e, 0x93, 0x61, 0x2d, 0x0f, 0x57, 0x94,
+	0x40, 0x76, 0x60, 0x27, 0xd9, 0x41, 0xfd, 0x55, 0xd4, 0x40, 0x7e, 0xb0, 0x20, 0xf2, 0x83, 0x92,
+	0xb2, 0xc0, 0x43, 0x7f, 0xa6, 0xe9, 0xe3, 0x65, 0xf7, 0xd2, 0xb2, 0xcc, 0x5b, 0xfc, 0xfb, 0xbb,
+	0x50, 0xed, 0x58, 0xc0, 0x52, 0x47, 0xa1, 0xa8, 0xa5, 0xdc, 0x51, 0x50, 0xa2, 0xfd, 0x96, 0x06,
+	0x14, 0x34, 0x32, 0xdd, 0x58, 0xad, 0x19, 0xfd, 0x86, 0xa6, 0x8f, 0x85, 0x51, 0x87, 0x59, 0x70,
+	0x73, 0x22, 0x9e, 0xbb, 0x47, 0x2d, 0x71, 0x1f, 0x0e, 0x8d, 0xaf, 0x67, 0xf7, 0xd1, 0x11, 0xe0,
+	0x78, 0x92, 0x32, 0x6c, 0x00, 0xbe, 0x91, 0xdd, 0x92, 0x14, 0x58, 0xf1, 0x32, 0x2f, 0x05, 0xb4,
+	0x73, 0x33, 0x0f, 0xa7, 0xb1, 0x4a, 0x1b, 0xe4, 0xc8, 0x25, 0x33, 0x20, 0xae, 0x86, 0xc6, 0xdb,
+	0xdc, 0x88, 0x6f, 0xc3, 0x45, 0xad, 0x20, 0xb6, 0xea, 0xb2, 0x3c, 0x97, 0xa8, 0x20, 0xf2, 0x1d,
+	0xb1, 0x10, 0x50, 0x67, 0xa7, 0x71, 0x55, 0x0f, 0xdc, 0xca, 0x07, 0xf8, 0xe8, 0x69, 0xa3, 0xeb,
+	0x36, 0x8f, 0xa1, 0x0e, 0x94, 0xd6, 0x31, 0xd9, 0xdf, 0x88, 0x3a, 0x52, 0x8b, 0xeb, 0x52, 0x98,
+	0xbf, 0x66, 0xc5, 0xa8, 0x9c, 0xf6, 0xd2, 0x36, 0x5c, 0x49, 0x23, 0x96, 0xf5, 0xa1, 0x3d, 0x7d,
+	0x28, 0xed, 0x49, 0x5a, 0xa2, 0x6b, 0x69, 0xdc, 0x99, 0xd4, 0xa6, 0x06, 0x67, 0x07, 0xd3, 0x6b,
+	0xd1, 0x26, 0xa7, 0xf2, 0xea, 0xe1, 0x60, 0xca, 0x2a, 0x68, 0x59, 0xe4, 0x28, 0x92, 0x6b, 0x93,
+	0x49, 0x12, 0x92, 0x2c, 0x8f, 0x4f, 0x4e, 0xea, 0x1a, 0x2e, 0x89, 0xa2, 0xdf, 0x3f, 0xab, 0xdf,
+	0x84, 0xa8, 0x91, 0x85, 0x0b, 0x48, 0x62, 0x6d, 0xbf, 0x05, 0x4b, 0x36, 0xa0, 0x1f, 0x75, 0x68,
+	0x18, 0x59, 0xbb, 0x6e, 0xc3, 0xb8, 0xcb, 0x3f, 0xc7, 0xbf, 0x69, 0x49, 0xaf, 0x72, 0x95, 0x1c,
+	0x2c, 0x2c, 0x63, 0x81, 0x3f, 0x71, 0xe7, 0xbb, 0xb1, 0x69, 0xb6, 0xc8, 0x41, 0xb6, 0xc5, 0xa3,
+	0xe5, 0x44, 0x47, 0xce, 0x92, 0x9d, 0x82, 0x2f, 0xe1, 0x93, 0x12, 0xc0, 0x97, 0xaa, 0x7c, 0x39,
+	0x4b, 0xd2, 0xfd, 0x2c, 0x99, 0x8b, 0x5f, 0x22, 0xd6, 0x80, 0xe6, 0xe0, 0x58, 0xd6, 0x82, 0xf1,
+	0x88, 0xdc, 0xb4, 0x9d, 0xe6, 0x1b, 0xf8, 0x87, 0x30, 0x13, 0xa3, 0x69, 0x0b, 0x63, 0x65, 0x6e,
+	0x4d, 0xee, 0xdb, 0x8e, 0x12, 0x05, 0x3d, 0xbb, 0x48, 0xab, 0x40, 0x55, 0xe7, 0x4c, 0xa9, 0xa4,
+	0x0f, 0x5d, 0xda, 0xfa, 0x4a, 0xa3, 0x70, 0x2e, 0x45, 0xa4, 0xa6, 0xef, 0x9e, 0x7e, 0x8d, 0x77,
+	0x59, 0x9a, 0x1d, 0xcf, 0x4b, 0x6e, 0x35, 0x3e, 0x4b, 0x53, 0x54, 0x63, 0x86, 0x7b, 0xfa, 0x08,
+	0x6e, 0x0d, 0xc0, 0xb5, 0xd4, 0xf1, 0x3c, 0x7e, 0x1f, 0x79, 0xca, 0x92, 0xa4, 0xb2, 0x17, 0x9b,
+	0x37, 0x92, 0x23, 0x4b, 0x05, 0xd7, 0x70, 0x1f, 0x39, 0xf4, 0x6d, 0xfd, 0x72, 0x93, 0x92, 0xa8,
+	0x13, 0x50, 0xab, 0xe9, 0x91, 0xed, 0xd0, 0x98, 0xe5, 0xfb, 0xee, 0x16, 0x9c, 0xf4, 0x09, 0xb0,
+	0x04, 0xf4, 0xac, 0x23, 0x23, 0x11, 0x6b, 0xb8, 0xc0, 0x82, 0xf6, 0xf5, 0x71, 0xa9, 0x11, 0x23,
+	0x72, 0x1c, 0xca, 0xfc, 0xce, 0xf6, 0x8e, 0x71, 0x8f, 0x2f, 0xda, 0xf7, 0x78, 0x78, 0xcd, 0x58,
+	0x56, 0x80, 0xe3, 0x7d, 0xce, 0x90, 0xdd, 0x7a, 0x94, 0x68, 0x76, 0xa3, 0x50, 0x0b, 0xa3, 0x5d,
+	0x7d, 0xb4, 0x32, 0x70, 0x8b, 0x1c, 0x18, 0xf7, 0xf9, 0xa8, 0xef, 0xc2, 0x65, 0xb0, 0x24, 0xb8,
+	0x4a, 0x0e, 0x7a, 0xb1, 0x69, 0xa8, 0x86, 0x5c, 0x25, 0x07, 0xd9, 0x78, 0x0a, 0x31, 0xb4, 0xab,
+	0x5f, 0x6c, 0x07, 0xfe, 0xc1, 0x21, 0x3f, 0x26, 0xdf, 0xe1, 0xc7, 0xe4, 0xda, 0x69, 0x6c, 0xbe,
+	0xb6, 0x0e, 0x44, 0x71, 0x50, 0xbe, 0xd6, 0x4e, 0x9e, 0x7b, 0xb1, 0x39, 0x98, 0xa6, 0x8f, 0x9c,
+	0x00, 0xcb, 0x29, 0x47, 0xa5, 0xe7, 0xa3, 0x93, 0x7a, 0xa6, 0x01, 0x27, 0xd4, 0xc0, 0x43, 0xbf,
+	0xa3, 0xe9, 0x83, 0x62, 0xb4, 0x7d, 0xc2, 0x2c, 0x9f, 0x79, 0x87, 0xc6, 0x03, 0xbe, 0x16, 0x9a,
+	0xd0, 0x4e, 0xe5, 0x02, 0x1f, 0xcc, 0xad, 0x3d, 0x65, 0xbc, 0x92, 0x35, 0xd0, 0x96, 0xde, 0xb3,
+	0xab, 0x99, 0x4c, 0x84, 0xe1, 0x8b, 0x5c, 0xa5, 0x77, 0x68, 0x8d, 0xca, 0x5a, 0x71, 0x82, 0x12,
+	0x06, 0x6f, 0xc8, 0xd2, 0x51, 0x8b, 0xb8, 0x2c, 0xa2, 0x8c, 0xc0, 0x76, 0x84, 0x9c, 0xf1, 0x63,
+	0x6a, 0x7c, 0x83, 0x5b, 0x34, 0x0d, 0x07, 0x84, 0x84, 0x2e, 0x71, 0xb0, 0x17, 0x9b, 0xe3, 0x49,
+	0xb0, 0x29, 0x21, 0x35, 0x5c, 0xe5, 0x46, 0x2d, 0xa8, 0x06, 0x41, 0x51, 0xab, 0x1d, 0xd0, 0x26,
+	0x85, 0x2b, 0x0a, 0x0d, 0x8d, 0x87, 0x7c, 0x49, 0x7e, 0x0b, 0x4a, 0x14, 0x1c, 0x5c, 0xcf, 0xb1,
+	0x5e, 0x6c, 0x5e, 0xcd, 0xfb, 0x4f, 0x39, 0x00, 0x8e, 0x0e, 0x95, 0x68, 0xb8, 0x22, 0x8d, 0xbe,
+	0xab, 0xe9, 0x57, 0xb2, 0x60, 0x9f, 0xfc, 0x81, 0x62, 0xbc, 0xcb, 0xa3, 0xfd, 0x78, 0x1a, 0xed,
+	0x17, 0x13, 0x7c, 0x5e, 0xc0, 0x7c, 0x11, 0x0f, 0x39, 0x45, 0x62, 0x76, 0x0c, 0x96, 0xe8, 0xca,
+	0xc0, 0x5f, 0x16, 0x46, 0xae, 0x3e, 0x28, 0xc6, 0xb2, 0x76, 0xdc, 0x30, 0xf2, 0x83, 0x43, 0xe3,
+	0x11, 0x5f, 0xb8, 0x10, 0xcc, 0x2f, 0x0b, 0xe4, 0xb1, 0x00, 0x7a, 0xb1, 0x39, 0x99, 0xae, 0xd9,
+	0x9c, 0xfa, 0xa2, 0xdc, 0xa5, 0x28, 0x8f, 0x3e, 0xd0, 0xaf, 0x10, 0x87, 0xb4, 0x23, 0x38, 0xdd,
+	0x77, 0x48, 0x08, 0x97, 0x29, 0xe3, 0x67, 0xf8, 0xe7, 0x7b, 0x1b, 0xdc, 0x4a, 0xb1, 0xc7, 0x02,
+	0xca, 0x66, 0xb7, 0x44, 0x87, 0x74, 0xb4, 0x48, 0x41, 0x3f, 0xd2, 0xf4, 0x11, 0x87, 0x85, 0xd2,
+	0xaf, 0x0d, 0x1f, 0xfb, 0x8c, 0x86, 0xc6, 0xcf, 0xf2, 0x6f, 0xf7, 0x29, 0xc4, 0xe8, 0xe1, 0xc5,
+	0xb5, 0x8d, 0xec, 0xaf, 0x81, 0x0f, 0x01, 0x85, 0x15, 0xe3, 0xb0, 0xb0, 0x48, 0xec, 0xc5, 0xe6,
+	0x98, 0x98, 0xcb, 0x12, 0xc2, 0xcb, 0xad, 0x65, 0x22, 0xf4, 0x2d, 0x2a, 0x2a, 0x8e, 0x4e, 0xea,
+	0xd5, 0xc1, 0x70, 0x95, 0x0f, 0x92, 0xe8, 0xeb, 0xe5, 0x2e, 0x3f, 0x78, 0x91, 0x5e, 0x11, 0xbf,
+	0xc9, 0xa7, 0xe6, 0x9f, 0xf9, 0xdf, 0x36, 0x59, 0xe7, 0x7c, 0x71, 0x6d, 0x23, 0xbf, 0x2d, 0x1a,
+	0xc5, 0x06, 0x7a, 0x8e, 0xf5, 0x62, 0xf3, 0xb6, 0xa2, 0xd5, 0x9f, 0x33, 0x28, 0x0e, 0x9a, 0xfe,
+	0xca, 0x5e, 0x80, 0x49, 0x07, 0x8e, 0xca, 0x46, 0x5c, 0x12, 0x74, 0x58, 0xd6, 0x1a, 0x6e, 0xea,
+	0x83, 0xc9, 0x61, 0x6a, 0x89, 0xbf, 0xa8, 0x8c, 0x9f, 0xe3, 0x4b, 0xff, 0x6a, 0xba, 0xf4, 0x93,
+	0xe3, 0x69, 0x89, 0x83, 0xf3, 0x53, 0xb0, 0x1c, 0x89, 0x4c, 0xea, 0xc5, 0xe6, 0x48, 0xb2, 0x3e,
+	0x24, 0x6a, 0x0d, 0x17, 0xb9, 0xd0, 0xa9, 0xa6, 0xdf, 0x2c, 0x97, 0xb0, 0xe9, 0x81, 0xed, 0x75,
+	0x1c, 0xea, 0x58, 0x36, 0x89, 0xe8, 0xb6, 0x0f, 0x95, 0x42, 0xe3, 0x3d, 0xbe, 0x56, 0x78, 0xcf,
+	0x6b, 0x74, 0x0b, 0xbf, 0x9f, 0x70, 0x2c, 0x64, 0x0c, 0xbc, 0x1a, 0x1a, 0x54, 0xe9, 0x59, 0x24,
+	0xaf, 0x80, 0x3c, 0xe0, 0xa1, 0x2a, 0x19, 0x0e, 0x6f, 0x95, 0x26, 0x38, 0xb4, 0x55, 0x23, 0xe3,
+	0xc9, 0x62, 0x09, 0xbb, 0xca, 0x81, 0x36, 0xf5, 0x2b, 0x90, 0x5d, 0xba, 0xad, 0x36, 0xb1, 0x23,
+	0x2b, 0xb4, 0x09, 0x0b, 0x8d, 0x6f, 0xf1, 0xe5, 0xf3, 0x16, 0xdc, 0x13, 0x3d, 0x7f, 0x7f, 0x99,
+	0x43, 0x1b, 0x80, 0x64, 0x65, 0x9e, 0x22, 0xb9, 0x86, 0x4b, 0x7c, 0xe8, 0x7b, 0x9a, 0xfe, 0xba,
+	0xe3, 0x86, 0xfc, 0x7b, 0x59, 0x95, 0xbf, 0xd6, 0xe6, 0xf8, 0x84, 0x41, 0x8d, 0x7f, 0x3c, 0x65,
+	0x5a, 0xa9, 0xfc, 0xa7, 0x26, 0xce, 0x55, 0x25, 0xce, 0xab, 0x09, 0x4a, 0x04, 0xf7, 0x53, 0x88,
+	0xfe, 0x4e, 0xd3, 0xdf, 0xb0, 0x69, 0x90, 0xd4, 0x0b, 0xa8, 0x15, 0xf8, 0x91, 0x28, 0x7c, 0xc0,
+	0xb6, 0xf2, 0x48, 0xdb, 0x72, 0xc8, 0x61, 0x68, 0xcc, 0xf3, 0x00, 0x06, 0x1b, 0x64, 0x42, 0x62,
+	0xc6, 0x09, 0xef, 0x53, 0xc1, 0xba, 0x48, 0x0e, 0xc1, 0xc0, 0x19, 0x11, 0xd1, 0x5e, 0xc8, 0x26,
+	0x87, 0xb8, 0x42, 0xc5, 0x79, 0xe6, 0x3e, 0x7e, 0xc9, 0x08, 0xe8, 0xc7, 0x9a, 0x3e, 0xdc, 0xa2,
+	0x2d, 0x3f, 0x38, 0xb4, 0x1a, 0x1d, 0x67, 0x9b, 0x46, 0x56, 0xcb, 0x6d, 0x18, 0x0b, 0x79, 0x83,
+	0x75, 0x68, 0x95, 0xa3, 0xf3, 0x1c, 0x5c, 0xe5, 0x77, 0xe8, 0xa1, 0x56, 0x91, 0x94, 0x05, 0xf8,
+	0x12, 0xbd, 0x1c, 0xe0, 0xa5, 0x9e, 0x49, 0x59, 0x43, 0x95, 0x04, 0xa7, 0x01, 0xf4, 0x4b, 0x4a,
+	0xc3, 0xe3, 0x12, 0x5f, 0x03, 0xfd, 0xaa, 0x3e, 0xd0, 0x69, 0xb3, 0x76, 0x16, 0x96, 0xfe, 0x6a,
+	0x89, 0x2f, 0xac, 0x5f, 0x38, 0x8d, 0xcd, 0xab, 0x79, 0xd1, 0x64, 0x6b, 0x9d, 0xad, 0xe7, 0x81,
+	0x49, 0xbb, 0x9d, 0x7d, 0x7b, 0x90, 0x4d, 0x00, 0xa9, 0x50, 0x72, 0x74, 0x52, 0x57, 0x0b, 0x1b,
+	0x1a, 0xbe, 0x24, 0x89, 0xa0, 0xbf, 0xd0, 0x92, 0xe1, 0xd3, 0xff, 0x04, 0x3e, 0x5b, 0xe2, 0x73,
+	0xf7, 0x09, 0xdf, 0xa8, 0x45, 0x15, 0xd9, 0x3f, 0x03, 0xda, 0xed, 0xec, 0xac, 0x02, 0x59, 0xb9,
+	0xd7, 0x2f, 0xd9, 0x90, 0x4f, 0xd7, 0xb5, 0xfe, 0x5c, 0xb0, 0x29, 0x55, 0xa3, 0x18, 0x1a, 0xd6,
+	0x73, 0x29, 0xf4, 0xf7, 0x9a, 0x3e, 0xc8, 0xcd, 0xcc, 0xff, 0x08, 0xf8, 0x6b, 0x61, 0xe8, 0x6f,
+	0xf3, 0x42, 0x5c, 0x51, 0x85, 0xf4, 0x77, 0x80, 0x76, 0x3b, 0xcb, 0x21, 0x41, 0xbe, 0xd8, 0xcf,
+	0x57, 0x1a, 0x7b, 0xe3, 0x45, 0x7c, 0x50, 0x6e, 0x53, 0x8f, 0x65, 0x68, 0x78, 0x40, 0x96, 0xcc,
+	0x4d, 0xce, 0xfb, 0xfe, 0x3f, 0xe8, 0x6f, 0xb2, 0xf4, 0x0f, 0x40, 0xc9, 0xe4, 0x62, 0xd7, 0xbe,
+	0xbf, 0xc9, 0xfd, 0xf8, 0xaa, 0x26, 0xa7, 0x9c, 0xa9, 0xc9, 0xe9, 0x3b, 0x6a, 0xea, 0xe2, 0xff,
+	0xa2, 0x2c, 0x4f, 0xff, 0x9b, 0x25, 0x71, 0x3b, 0x2b, 0xda, 0xcb, 0x7f, 0xd1, 0xc9, 0x13, 0x76,
+	0x69, 0x31, 0x06, 0x39, 0x52, 0xac, 0xda, 0x0d, 0x48, 0x48, 0xc8, 0xbb, 0x24, 0xd5, 0x06, 0x85,
+	0xd5, 0xb6, 0x23, 0xe3, 0x87, 0x30, 0x45, 0xda, 0xfc, 0xea, 0x69, 0x6c, 0xde, 0xc8, 0x47, 0x5c,
+	0x2d, 0xb6, 0x17, 0xd6, 0xed, 0xa8, 0x38, 0x4f, 0xad, 0x0a, 0x5e, 0x1c, 0x1e, 0x55, 0x19, 0xa0,
+	0x28, 0x31, 0x5a, 0x4a, 0xc9, 0x45, 0x60, 0xff, 0x5b, 0xf1, 0x95, 0x36, 0x4b, 0x26, 0xc8, 0xa9,
+	0x2c, 0x8f, 0xdf, 0x25, 0x13, 0x2a, 0x78, 0xf5, 0x53, 0x71, 0x4b, 0x2a, 0x7c, 0xf3, 0x4f, 0x3e,
+	0xff, 0x62, 0xe2, 0xcc, 0xc9, 0x17, 0x13, 0x67, 0x3e, 0x3f, 0x9d, 0xd0, 0x4e, 0x4e, 0x27, 0xb4,
+	0xdf, 0x7d, 0x36, 0x71, 0xe6, 0xfb, 0xcf, 0x26, 0xb4, 0x93, 0x67, 0x13, 0x67, 0xfe, 0xe3, 0xd9,
+	0xc4, 0x99, 0x0f, 0xdf, 0xdc, 0x76, 0xa3, 0x9d, 0x4e, 0xe3, 0x8e, 0xed, 0xb7, 0xee, 0x66, 0x85,
+	0x32, 0xe9, 0x29, 0xff, 0x73, 0xba, 0x71, 0x81, 0xff, 0x21, 0x7d, 0xef, 0xa7, 0x03, 0x00, 0xe3,
+	0x13, 0xdc, 0x27, 0xcf, 0x2d, 0x00, 0x00,
 }
 
 func (m *OptionsConfiguration) Marshal() (dAtA []byte, err error) {
@@ -431,6 +527,154 @@ func (m *OptionsConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.MemoryBudgetMiB != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.MemoryBudgetMiB))
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.CertificateRotationOverlapDays != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.CertificateRotationOverlapDays))
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x90
+	}
+	if len(m.DisabledListenAddresses) > 0 {
+		for iNdEx := len(m.DisabledListenAddresses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DisabledListenAddresses[iNdEx])
+			copy(dAtA[i:], m.DisabledListenAddresses[iNdEx])
+			i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.DisabledListenAddresses[iNdEx])))
+			i--
+			dAtA[i] = 0x4
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if m.LowImpactScans {
+		i--
+		if m.LowImpactScans {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x80
+	}
+	if len(m.URExcludedCategories) > 0 {
+		for iNdEx := len(m.URExcludedCategories) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.URExcludedCategories[iNdEx])
+			copy(dAtA[i:], m.URExcludedCategories[iNdEx])
+			i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.URExcludedCategories[iNdEx])))
+			i--
+			dAtA[i] = 0x3
+			i--
+			dAtA[i] = 0xfa
+		}
+	}
+	if m.AddressFamily != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.AddressFamily))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.LocalAnnMDNSEnabled {
+		i--
+		if m.LocalAnnMDNSEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xe8
+	}
+	if len(m.DNSDiscoveryZones) > 0 {
+		for iNdEx := len(m.DNSDiscoveryZones) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DNSDiscoveryZones[iNdEx])
+			copy(dAtA[i:], m.DNSDiscoveryZones[iNdEx])
+			i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.DNSDiscoveryZones[iNdEx])))
+			i--
+			dAtA[i] = 0x3
+			i--
+			dAtA[i] = 0xe2
+		}
+	}
+	if m.AdaptiveHashing {
+		i--
+		if m.AdaptiveHashing {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.ConfigHistory != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.ConfigHistory))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.DatabaseBackend != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.DatabaseBackend))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xc8
+	}
+	if len(m.RelayPreferences) > 0 {
+		for iNdEx := len(m.RelayPreferences) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RelayPreferences[iNdEx])
+			copy(dAtA[i:], m.RelayPreferences[iNdEx])
+			i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.RelayPreferences[iNdEx])))
+			i--
+			dAtA[i] = 0x3
+			i--
+			dAtA[i] = 0xc2
+		}
+	}
+	if m.MaintenanceFreeze {
+		i--
+		if m.MaintenanceFreeze {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.ProxyWANOnly {
+		i--
+		if m.ProxyWANOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb0
+	}
+	if len(m.ProxyURL) > 0 {
+		i -= len(m.ProxyURL)
+		copy(dAtA[i:], m.ProxyURL)
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.ProxyURL)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xaa
+	}
 	if m.ConnectionLimitMax != 0 {
 		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.ConnectionLimitMax))
 		i--
@@ -1072,6 +1316,64 @@ func (m *OptionsConfiguration) ProtoSize() (n int) {
 	if m.ConnectionLimitMax != 0 {
 		n += 2 + sovOptionsconfiguration(uint64(m.ConnectionLimitMax))
 	}
+	l = len(m.ProxyURL)
+	if l > 0 {
+		n += 2 + l + sovOptionsconfiguration(uint64(l))
+	}
+	if m.ProxyWANOnly {
+		n += 3
+	}
+	if m.MaintenanceFreeze {
+		n += 3
+	}
+	if len(m.RelayPreferences) > 0 {
+		for _, s := range m.RelayPreferences {
+			l = len(s)
+			n += 2 + l + sovOptionsconfiguration(uint64(l))
+		}
+	}
+	if m.DatabaseBackend != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.DatabaseBackend))
+	}
+	if m.ConfigHistory != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.ConfigHistory))
+	}
+	if m.AdaptiveHashing {
+		n += 3
+	}
+	if len(m.DNSDiscoveryZones) > 0 {
+		for _, s := range m.DNSDiscoveryZones {
+			l = len(s)
+			n += 2 + l + sovOptionsconfiguration(uint64(l))
+		}
+	}
+	if m.LocalAnnMDNSEnabled {
+		n += 3
+	}
+	if m.AddressFamily != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.AddressFamily))
+	}
+	if len(m.URExcludedCategories) > 0 {
+		for _, s := range m.URExcludedCategories {
+			l = len(s)
+			n += 2 + l + sovOptionsconfiguration(uint64(l))
+		}
+	}
+	if m.LowImpactScans {
+		n += 3
+	}
+	if len(m.DisabledListenAddresses) > 0 {
+		for _, s := range m.DisabledListenAddresses {
+			l = len(s)
+			n += 2 + l + sovOptionsconfiguration(uint64(l))
+		}
+	}
+	if m.CertificateRotationOverlapDays != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.CertificateRotationOverlapDays))
+	}
+	if m.MemoryBudgetMiB != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.MemoryBudgetMiB))
+	}
 	if m.DeprecatedUPnPEnabled {
 		n += 4
 	}
@@ -2288,6 +2590,361 @@ func (m *OptionsConfiguration) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 53:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyURL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProxyURL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 54:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyWANOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ProxyWANOnly = bool(v != 0)
+		case 55:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaintenanceFreeze", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MaintenanceFreeze = bool(v != 0)
+		case 56:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayPreferences", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RelayPreferences = append(m.RelayPreferences, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 57:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseBackend", wireType)
+			}
+			m.DatabaseBackend = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DatabaseBackend |= DatabaseBackend(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 58:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigHistory", wireType)
+			}
+			m.ConfigHistory = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfigHistory |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 59:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdaptiveHashing", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AdaptiveHashing = bool(v != 0)
+		case 60:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DNSDiscoveryZones", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DNSDiscoveryZones = append(m.DNSDiscoveryZones, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 61:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalAnnMDNSEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LocalAnnMDNSEnabled = bool(v != 0)
+		case 62:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddressFamily", wireType)
+			}
+			m.AddressFamily = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AddressFamily |= AddressFamily(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 63:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URExcludedCategories", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.URExcludedCategories = append(m.URExcludedCategories, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 64:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LowImpactScans", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LowImpactScans = bool(v != 0)
+		case 65:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisabledListenAddresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DisabledListenAddresses = append(m.DisabledListenAddresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 66:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CertificateRotationOverlapDays", wireType)
+			}
+			m.CertificateRotationOverlapDays = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CertificateRotationOverlapDays |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 67:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryBudgetMiB", wireType)
+			}
+			m.MemoryBudgetMiB = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryBudgetMiB |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 9000:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedUPnPEnabled", wireType)
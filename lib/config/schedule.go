@@ -0,0 +1,164 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The longest time the state of a schedule can remain unchanged is a week,
+// plus some margin for daylight saving time.
+const maxScheduleSearch = 8 * 24 * time.Hour
+
+var errScheduleSyntax = errors.New(`expected "[days] HH:MM-HH:MM"`)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// A Schedule is a set of weekly recurring time windows, in local time.
+type Schedule []scheduleWindow
+
+type scheduleWindow struct {
+	days       [7]bool
+	start, end int // minutes since midnight
+}
+
+// ParseSchedule parses windows of the form "[days] HH:MM-HH:MM", where days
+// is a comma separated list of weekdays or ranges of weekdays, e.g.
+// "Mon-Fri" or "Sat,Sun". Windows without days apply to every day. A window
+// ending before it starts extends into the next day.
+func ParseSchedule(windows []string) (Schedule, error) {
+	sched := make(Schedule, 0, len(windows))
+	for _, str := range windows {
+		w, err := parseScheduleWindow(str)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", str, err)
+		}
+		sched = append(sched, w)
+	}
+	return sched, nil
+}
+
+func parseScheduleWindow(str string) (scheduleWindow, error) {
+	var w scheduleWindow
+	fields := strings.Fields(str)
+	switch len(fields) {
+	case 1:
+		for i := range w.days {
+			w.days[i] = true
+		}
+	case 2:
+		if err := parseScheduleDays(fields[0], &w.days); err != nil {
+			return w, err
+		}
+		fields = fields[1:]
+	default:
+		return w, errScheduleSyntax
+	}
+
+	times := strings.Split(fields[0], "-")
+	if len(times) != 2 {
+		return w, errScheduleSyntax
+	}
+	var err error
+	if w.start, err = parseScheduleTime(times[0]); err != nil {
+		return w, err
+	}
+	if w.end, err = parseScheduleTime(times[1]); err != nil {
+		return w, err
+	}
+	if w.start == w.end {
+		return w, errors.New("empty time window")
+	}
+	return w, nil
+}
+
+func parseScheduleDays(str string, days *[7]bool) error {
+	for _, part := range strings.Split(strings.ToLower(str), ",") {
+		bounds := strings.Split(part, "-")
+		if len(bounds) > 2 {
+			return errScheduleSyntax
+		}
+		first, ok := weekdays[bounds[0]]
+		if !ok {
+			return fmt.Errorf("unknown day %q", bounds[0])
+		}
+		last, ok := weekdays[bounds[len(bounds)-1]]
+		if !ok {
+			return fmt.Errorf("unknown day %q", bounds[len(bounds)-1])
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func parseScheduleTime(str string) (int, error) {
+	t, err := time.Parse("15:04", str)
+	if err != nil {
+		if str != "24:00" {
+			return 0, fmt.Errorf("invalid time %q", str)
+		}
+		return 24 * 60, nil
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Active returns whether t is within any of the windows. An empty schedule
+// is always active.
+func (s Schedule) Active(t time.Time) bool {
+	if len(s) == 0 {
+		return true
+	}
+	minute := t.Hour()*60 + t.Minute()
+	today := t.Weekday()
+	yesterday := (today + 6) % 7
+	for _, w := range s {
+		if w.start < w.end {
+			if w.days[today] && minute >= w.start && minute < w.end {
+				return true
+			}
+			continue
+		}
+		// The window extends past midnight.
+		if w.days[today] && minute >= w.start || w.days[yesterday] && minute < w.end {
+			return true
+		}
+	}
+	return false
+}
+
+// NextChange returns the first time after t at which the schedule changes
+// between active and inactive, or the zero time if it never does.
+func (s Schedule) NextChange(t time.Time) time.Time {
+	if len(s) == 0 {
+		return time.Time{}
+	}
+	active := s.Active(t)
+	next := t.Truncate(time.Minute)
+	for limit := t.Add(maxScheduleSearch); next.Before(limit); {
+		next = next.Add(time.Minute)
+		if s.Active(next) != active {
+			return next
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	valid := []string{
+		"22:00-06:00",
+		"Mon-Fri 09:00-17:00",
+		"sat,sun 00:00-24:00",
+		"Fri-Mon 12:30-13:30",
+	}
+	for _, str := range valid {
+		if _, err := ParseSchedule([]string{str}); err != nil {
+			t.Errorf("%q: unexpected error: %v", str, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"22:00",
+		"22:00-22:00",
+		"25:00-06:00",
+		"Mon-Fri",
+		"Someday 09:00-17:00",
+		"Mon-Tue-Wed 09:00-17:00",
+		"Mon 09:00-17:00 extra",
+	}
+	for _, str := range invalid {
+		if _, err := ParseSchedule([]string{str}); err == nil {
+			t.Errorf("%q: expected an error", str)
+		}
+	}
+}
+
+func TestScheduleActive(t *testing.T) {
+	sched, err := ParseSchedule([]string{"Mon-Fri 22:00-06:00", "Sun 12:00-14:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2021-03-01 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, 3, day, hour, minute, 0, 0, time.Local)
+	}
+	cases := []struct {
+		t      time.Time
+		active bool
+	}{
+		{at(1, 5, 0), false}, // Monday morning, window started on Sunday
+		{at(1, 21, 59), false},
+		{at(1, 22, 0), true},
+		{at(2, 5, 59), true}, // Tuesday morning
+		{at(2, 6, 0), false},
+		{at(6, 1, 0), true}, // Saturday morning, window started on Friday
+		{at(6, 23, 0), false},
+		{at(7, 13, 0), true},
+		{at(7, 14, 0), false},
+	}
+	for _, tc := range cases {
+		if active := sched.Active(tc.t); active != tc.active {
+			t.Errorf("%v: active %v != expected %v", tc.t, active, tc.active)
+		}
+	}
+
+	if next := sched.NextChange(at(1, 12, 34)); !next.Equal(at(1, 22, 0)) {
+		t.Errorf("next change %v != expected %v", next, at(1, 22, 0))
+	}
+	if next := sched.NextChange(at(6, 1, 0)); !next.Equal(at(6, 6, 0)) {
+		t.Errorf("next change %v != expected %v", next, at(6, 6, 0))
+	}
+	if next := Schedule(nil).NextChange(at(1, 0, 0)); !next.IsZero() {
+		t.Errorf("empty schedule changed at %v", next)
+	}
+	if !Schedule(nil).Active(at(1, 0, 0)) {
+		t.Error("empty schedule should always be active")
+	}
+}
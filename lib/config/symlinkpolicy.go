@@ -0,0 +1,38 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+func (p SymlinkPolicy) String() string {
+	switch p {
+	case SymlinkPolicySync:
+		return "sync"
+	case SymlinkPolicyFollow:
+		return "follow"
+	case SymlinkPolicySkip:
+		return "skip"
+	default:
+		return "unknown"
+	}
+}
+
+func (p SymlinkPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *SymlinkPolicy) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "sync":
+		*p = SymlinkPolicySync
+	case "follow":
+		*p = SymlinkPolicyFollow
+	case "skip":
+		*p = SymlinkPolicySkip
+	default:
+		*p = SymlinkPolicySync
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/symlinkpolicy.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type SymlinkPolicy int32
+
+const (
+	SymlinkPolicySync   SymlinkPolicy = 0
+	SymlinkPolicyFollow SymlinkPolicy = 1
+	SymlinkPolicySkip   SymlinkPolicy = 2
+)
+
+var SymlinkPolicy_name = map[int32]string{
+	0: "SYMLINK_POLICY_SYNC",
+	1: "SYMLINK_POLICY_FOLLOW",
+	2: "SYMLINK_POLICY_SKIP",
+}
+
+var SymlinkPolicy_value = map[string]int32{
+	"SYMLINK_POLICY_SYNC":   0,
+	"SYMLINK_POLICY_FOLLOW": 1,
+	"SYMLINK_POLICY_SKIP":   2,
+}
+
+func (SymlinkPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b56d5a4e1bdff497, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.SymlinkPolicy", SymlinkPolicy_name, SymlinkPolicy_value)
+}
+
+func init() { proto.RegisterFile("lib/config/symlinkpolicy.proto", fileDescriptor_b56d5a4e1bdff497) }
+
+var fileDescriptor_b56d5a4e1bdff497 = []byte{
+	// 244 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcb, 0xc9, 0x4c, 0xd2,
+	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x2f, 0xae, 0xcc, 0xcd, 0xc9, 0xcc, 0xcb, 0x2e, 0xc8,
+	0xcf, 0xc9, 0x4c, 0xae, 0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xc8, 0x49, 0x29,
+	0x17, 0xa5, 0x16, 0xe4, 0x17, 0xeb, 0x83, 0x05, 0x93, 0x4a, 0xd3, 0xf4, 0xd3, 0xf3, 0xd3, 0xf3,
+	0xc1, 0x1c, 0x30, 0x0b, 0xa2, 0x58, 0x6b, 0x25, 0x23, 0x17, 0x6f, 0x30, 0xc4, 0x90, 0x00, 0xb0,
+	0x21, 0x42, 0x7a, 0x5c, 0xc2, 0xc1, 0x91, 0xbe, 0x3e, 0x9e, 0x7e, 0xde, 0xf1, 0x01, 0xfe, 0x3e,
+	0x9e, 0xce, 0x91, 0xf1, 0xc1, 0x91, 0x7e, 0xce, 0x02, 0x0c, 0x52, 0xa2, 0x5d, 0x73, 0x15, 0x04,
+	0x51, 0xd4, 0x06, 0x57, 0xe6, 0x25, 0x0b, 0x19, 0x71, 0x89, 0xa2, 0xa9, 0x77, 0xf3, 0xf7, 0xf1,
+	0xf1, 0x0f, 0x17, 0x60, 0x94, 0x12, 0xef, 0x9a, 0xab, 0x20, 0x8c, 0xa2, 0xc3, 0x2d, 0x3f, 0x27,
+	0x27, 0xbf, 0x1c, 0x9b, 0x1d, 0xde, 0x9e, 0x01, 0x02, 0x4c, 0xd8, 0xec, 0xc8, 0xce, 0x2c, 0x90,
+	0x62, 0x59, 0xb1, 0x44, 0x8e, 0xc1, 0xc9, 0xfb, 0xc4, 0x43, 0x39, 0x86, 0x0b, 0x0f, 0xe5, 0x18,
+	0x4e, 0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x05, 0x8f, 0xe5,
+	0x18, 0x2f, 0x3c, 0x96, 0x63, 0xb8, 0xf1, 0x58, 0x8e, 0x21, 0x4a, 0x33, 0x3d, 0xb3, 0x24, 0xa3,
+	0x34, 0x49, 0x2f, 0x39, 0x3f, 0x57, 0xbf, 0xb8, 0x32, 0x2f, 0xb9, 0x24, 0x23, 0x33, 0x2f, 0x1d,
+	0x89, 0x85, 0x08, 0xb9, 0x24, 0x36, 0xb0, 0xff, 0x8d, 0x01, 0x03, 0x00, 0x6e, 0xaf, 0x11, 0xbc,
+	0x4e, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"path"
+	"strings"
+)
+
+// LocalSymlinkTarget returns the target a synced symlink gets on this
+// device.
+func (f FolderConfiguration) LocalSymlinkTarget(target string) string {
+	for _, rw := range f.SymlinkRewrites {
+		if rewritten, ok := rewritePrefix(target, rw.From, rw.To); ok {
+			return rewritten
+		}
+	}
+	return target
+}
+
+// SyncedSymlinkTarget returns the target a symlink on this device is synced
+// with, i.e. it undoes LocalSymlinkTarget.
+func (f FolderConfiguration) SyncedSymlinkTarget(target string) string {
+	for _, rw := range f.SymlinkRewrites {
+		if rewritten, ok := rewritePrefix(target, rw.To, rw.From); ok {
+			return rewritten
+		}
+	}
+	return target
+}
+
+// rewritePrefix replaces the from prefix of an absolute target with to. The
+// prefix only matches whole path components.
+func rewritePrefix(target, from, to string) (string, bool) {
+	if !path.IsAbs(target) || !path.IsAbs(from) || to == "" {
+		return "", false
+	}
+	from = strings.TrimSuffix(from, "/")
+	switch {
+	case target == from:
+		return path.Clean(to), true
+	case strings.HasPrefix(target, from+"/"):
+		return strings.TrimSuffix(to, "/") + target[len(from):], true
+	}
+	return "", false
+}
@@ -0,0 +1,363 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/symlinkrewrite.proto
+
+package config
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/syncthing/syncthing/proto/ext"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// A SymlinkRewrite maps absolute symlink targets under a path on other
+// devices to one on this device.
+type SymlinkRewrite struct {
+	// The target prefix as synced.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from" xml:"from,attr"`
+	// The target prefix on this device.
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to" xml:"to,attr"`
+}
+
+func (m *SymlinkRewrite) Reset()         { *m = SymlinkRewrite{} }
+func (m *SymlinkRewrite) String() string { return proto.CompactTextString(m) }
+func (*SymlinkRewrite) ProtoMessage()    {}
+func (*SymlinkRewrite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a00f98ad55c7fd1e, []int{0}
+}
+func (m *SymlinkRewrite) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SymlinkRewrite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SymlinkRewrite.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SymlinkRewrite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SymlinkRewrite.Merge(m, src)
+}
+func (m *SymlinkRewrite) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *SymlinkRewrite) XXX_DiscardUnknown() {
+	xxx_messageInfo_SymlinkRewrite.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SymlinkRewrite proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*SymlinkRewrite)(nil), "config.SymlinkRewrite")
+}
+
+func init() { proto.RegisterFile("lib/config/symlinkrewrite.proto", fileDescriptor_a00f98ad55c7fd1e) }
+
+var fileDescriptor_a00f98ad55c7fd1e = []byte{
+	// 224 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcf, 0xc9, 0x4c, 0xd2,
+	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x2f, 0xae, 0xcc, 0xcd, 0xc9, 0xcc, 0xcb, 0x2e, 0x4a,
+	0x2d, 0x2f, 0xca, 0x2c, 0x49, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0x48, 0x4a,
+	0x71, 0xa6, 0x56, 0x94, 0x40, 0x84, 0x94, 0x9a, 0x18, 0xb9, 0xf8, 0x82, 0x21, 0x6a, 0x83, 0x20,
+	0x6a, 0x85, 0x6c, 0xb8, 0x58, 0xd2, 0x8a, 0xf2, 0x73, 0x25, 0x18, 0x15, 0x18, 0x35, 0x38, 0x9d,
+	0x34, 0x5e, 0xdd, 0x93, 0x07, 0xf3, 0x3f, 0xdd, 0x93, 0xe7, 0xaf, 0xc8, 0xcd, 0xb1, 0x52, 0x02,
+	0x71, 0x74, 0x12, 0x4b, 0x4a, 0x8a, 0x94, 0x5e, 0x9d, 0x57, 0xe1, 0x84, 0xf3, 0x82, 0xc0, 0xaa,
+	0x84, 0x8c, 0xb8, 0x98, 0x4a, 0xf2, 0x25, 0x98, 0xc0, 0x7a, 0x95, 0x5e, 0xdd, 0x93, 0x67, 0x2a,
+	0xc9, 0xff, 0x74, 0x4f, 0x9e, 0x17, 0xac, 0xb3, 0x24, 0x1f, 0xae, 0x8f, 0x1d, 0xca, 0x0e, 0x62,
+	0x2a, 0xc9, 0x77, 0xf2, 0x3e, 0xf1, 0x50, 0x8e, 0xe1, 0xc2, 0x43, 0x39, 0x86, 0x13, 0x8f, 0xe4,
+	0x18, 0x2f, 0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58, 0x8e, 0x61, 0xc1, 0x63, 0x39, 0xc6, 0x0b, 0x8f,
+	0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0xd2, 0x4c, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b,
+	0xce, 0xcf, 0xd5, 0x2f, 0xae, 0xcc, 0x4b, 0x2e, 0xc9, 0xc8, 0xcc, 0x4b, 0x47, 0x62, 0x21, 0x7c,
+	0x9e, 0xc4, 0x06, 0xf6, 0x98, 0x31, 0x60, 0x00, 0xb6, 0x1b, 0xf9, 0xe4, 0x0e, 0x01, 0x00, 0x00,
+}
+
+func (m *SymlinkRewrite) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SymlinkRewrite) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SymlinkRewrite) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.To) > 0 {
+		i -= len(m.To)
+		copy(dAtA[i:], m.To)
+		i = encodeVarintSymlinkrewrite(dAtA, i, uint64(len(m.To)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.From) > 0 {
+		i -= len(m.From)
+		copy(dAtA[i:], m.From)
+		i = encodeVarintSymlinkrewrite(dAtA, i, uint64(len(m.From)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSymlinkrewrite(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSymlinkrewrite(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SymlinkRewrite) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.From)
+	if l > 0 {
+		n += 1 + l + sovSymlinkrewrite(uint64(l))
+	}
+	l = len(m.To)
+	if l > 0 {
+		n += 1 + l + sovSymlinkrewrite(uint64(l))
+	}
+	return n
+}
+
+func sovSymlinkrewrite(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSymlinkrewrite(x uint64) (n int) {
+	return sovSymlinkrewrite(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SymlinkRewrite) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSymlinkrewrite
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SymlinkRewrite: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SymlinkRewrite: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSymlinkrewrite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSymlinkrewrite
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSymlinkrewrite
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.From = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSymlinkrewrite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSymlinkrewrite
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSymlinkrewrite
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.To = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSymlinkrewrite(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSymlinkrewrite
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSymlinkrewrite
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSymlinkrewrite(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSymlinkrewrite
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSymlinkrewrite
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSymlinkrewrite
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSymlinkrewrite
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSymlinkrewrite
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSymlinkrewrite
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSymlinkrewrite        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSymlinkrewrite          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSymlinkrewrite = fmt.Errorf("proto: unexpected end of group")
+)
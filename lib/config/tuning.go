@@ -14,6 +14,8 @@ func (t Tuning) String() string {
 		return "small"
 	case TuningLarge:
 		return "large"
+	case TuningLowMemory:
+		return "lowMemory"
 	default:
 		return "unknown"
 	}
@@ -31,6 +33,8 @@ func (t *Tuning) UnmarshalText(bs []byte) error {
 		*t = TuningSmall
 	case "large":
 		*t = TuningLarge
+	case "lowMemory":
+		*t = TuningLowMemory
 	default:
 		*t = TuningAuto
 	}
@@ -24,21 +24,24 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type Tuning int32
 
 const (
-	TuningAuto  Tuning = 0
-	TuningSmall Tuning = 1
-	TuningLarge Tuning = 2
+	TuningAuto      Tuning = 0
+	TuningSmall     Tuning = 1
+	TuningLarge     Tuning = 2
+	TuningLowMemory Tuning = 3
 )
 
 var Tuning_name = map[int32]string{
 	0: "TUNING_AUTO",
 	1: "TUNING_SMALL",
 	2: "TUNING_LARGE",
+	3: "TUNING_LOW_MEMORY",
 }
 
 var Tuning_value = map[string]int32{
-	"TUNING_AUTO":  0,
-	"TUNING_SMALL": 1,
-	"TUNING_LARGE": 2,
+	"TUNING_AUTO":       0,
+	"TUNING_SMALL":      1,
+	"TUNING_LARGE":      2,
+	"TUNING_LOW_MEMORY": 3,
 }
 
 func (Tuning) EnumDescriptor() ([]byte, []int) {
@@ -52,20 +55,22 @@ func init() {
 func init() { proto.RegisterFile("lib/config/tuning.proto", fileDescriptor_204cfa1615fdfefd) }
 
 var fileDescriptor_204cfa1615fdfefd = []byte{
-	// 228 bytes of a gzipped FileDescriptorProto
+	// 260 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0xcf, 0xc9, 0x4c, 0xd2,
 	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x2f, 0x29, 0xcd, 0xcb, 0xcc, 0x4b, 0xd7, 0x2b, 0x28,
 	0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0x08, 0x4a, 0x29, 0x17, 0xa5, 0x16, 0xe4, 0x17, 0xeb, 0x83,
-	0x05, 0x93, 0x4a, 0xd3, 0xf4, 0xd3, 0xf3, 0xd3, 0xf3, 0xc1, 0x1c, 0x30, 0x0b, 0xa2, 0x58, 0xab,
-	0x94, 0x8b, 0x2d, 0x04, 0xac, 0x59, 0x48, 0x9e, 0x8b, 0x3b, 0x24, 0xd4, 0xcf, 0xd3, 0xcf, 0x3d,
-	0xde, 0x31, 0x34, 0xc4, 0x5f, 0x80, 0x41, 0x8a, 0xaf, 0x6b, 0xae, 0x02, 0x17, 0x44, 0xd2, 0xb1,
-	0xb4, 0x24, 0x5f, 0x48, 0x91, 0x8b, 0x07, 0xaa, 0x20, 0xd8, 0xd7, 0xd1, 0xc7, 0x47, 0x80, 0x51,
-	0x8a, 0xbf, 0x6b, 0xae, 0x02, 0x37, 0x44, 0x45, 0x70, 0x6e, 0x62, 0x4e, 0x0e, 0x92, 0x12, 0x1f,
-	0xc7, 0x20, 0x77, 0x57, 0x01, 0x26, 0x64, 0x25, 0x3e, 0x89, 0x45, 0xe9, 0xa9, 0x52, 0x2c, 0x2b,
-	0x96, 0xc8, 0x31, 0x38, 0x79, 0x9f, 0x78, 0x28, 0xc7, 0x70, 0xe1, 0xa1, 0x1c, 0xc3, 0x89, 0x47,
-	0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0xb0, 0xe0, 0xb1, 0x1c, 0xe3, 0x85,
-	0xc7, 0x72, 0x0c, 0x37, 0x1e, 0xcb, 0x31, 0x44, 0x69, 0xa6, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9,
-	0x25, 0xe7, 0xe7, 0xea, 0x17, 0x57, 0xe6, 0x25, 0x97, 0x64, 0x64, 0xe6, 0xa5, 0x23, 0xb1, 0x10,
-	0xbe, 0x4f, 0x62, 0x03, 0x7b, 0xc5, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0x9f, 0x69, 0xc8, 0xbc,
-	0x12, 0x01, 0x00, 0x00,
+	0x05, 0x93, 0x4a, 0xd3, 0xf4, 0xd3, 0xf3, 0xd3, 0xf3, 0xc1, 0x1c, 0x30, 0x0b, 0xa2, 0x58, 0x6b,
+	0x21, 0x23, 0x17, 0x5b, 0x08, 0x58, 0xb7, 0x90, 0x3c, 0x17, 0x77, 0x48, 0xa8, 0x9f, 0xa7, 0x9f,
+	0x7b, 0xbc, 0x63, 0x68, 0x88, 0xbf, 0x00, 0x83, 0x14, 0x5f, 0xd7, 0x5c, 0x05, 0x2e, 0x88, 0xa4,
+	0x63, 0x69, 0x49, 0xbe, 0x90, 0x22, 0x17, 0x0f, 0x54, 0x41, 0xb0, 0xaf, 0xa3, 0x8f, 0x8f, 0x00,
+	0xa3, 0x14, 0x7f, 0xd7, 0x5c, 0x05, 0x6e, 0x88, 0x8a, 0xe0, 0xdc, 0xc4, 0x9c, 0x1c, 0x24, 0x25,
+	0x3e, 0x8e, 0x41, 0xee, 0xae, 0x02, 0x4c, 0xc8, 0x4a, 0x7c, 0x12, 0x8b, 0xd2, 0x53, 0x85, 0xb4,
+	0xb8, 0x04, 0x61, 0x4a, 0xfc, 0xc3, 0xe3, 0x7d, 0x5d, 0x7d, 0xfd, 0x83, 0x22, 0x05, 0x98, 0xa5,
+	0x84, 0xbb, 0xe6, 0x2a, 0xf0, 0x43, 0xd5, 0xe5, 0x97, 0xfb, 0xa6, 0xe6, 0xe6, 0x17, 0x55, 0x4a,
+	0xb1, 0xac, 0x58, 0x22, 0xc7, 0xe0, 0xe4, 0x7d, 0xe2, 0xa1, 0x1c, 0xc3, 0x85, 0x87, 0x72, 0x0c,
+	0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0x38, 0xe1, 0xb1, 0x1c, 0xc3, 0x82, 0xc7, 0x72,
+	0x8c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10, 0xa5, 0x99, 0x9e, 0x59, 0x92, 0x51,
+	0x9a, 0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x5f, 0x5c, 0x99, 0x97, 0x5c, 0x92, 0x91, 0x99, 0x97, 0x8e,
+	0xc4, 0x42, 0x04, 0x55, 0x12, 0x1b, 0xd8, 0xdf, 0xc6, 0x80, 0x01, 0x00, 0xc3, 0xdf, 0x7b, 0xbf,
+	0x3f, 0x01, 0x00, 0x00,
 }
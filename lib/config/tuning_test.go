@@ -23,4 +23,7 @@ func TestTuningMatches(t *testing.T) {
 	if int(config.TuningLarge) != int(backend.TuningLarge) {
 		t.Error("mismatch for TuningLarge")
 	}
+	if int(config.TuningLowMemory) != int(backend.TuningLowMemory) {
+		t.Error("mismatch for TuningLowMemory")
+	}
 }
@@ -0,0 +1,33 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"strings"
+
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/util"
+)
+
+func (c WebhookConfiguration) Copy() WebhookConfiguration {
+	c.Events = append([]string(nil), c.Events...)
+	return c
+}
+
+func (cfg *Configuration) prepareWebhooks() {
+	seen := make(map[string]struct{}, len(cfg.Webhooks))
+	for i := range cfg.Webhooks {
+		hook := &cfg.Webhooks[i]
+		hook.URL = strings.TrimSpace(hook.URL)
+		hook.Events = util.UniqueTrimmedStrings(hook.Events)
+		// Deliveries are queued per webhook by ID, so they must be unique.
+		if _, ok := seen[hook.ID]; ok || hook.ID == "" {
+			hook.ID = rand.String(8)
+		}
+		seen[hook.ID] = struct{}{}
+	}
+}
@@ -0,0 +1,500 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/webhookconfiguration.proto
+
+package config
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/syncthing/syncthing/proto/ext"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type WebhookConfiguration struct {
+	ID      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id" xml:"id,attr"`
+	URL     string `protobuf:"bytes,2,opt,name=url,proto3" json:"url" xml:"url,attr"`
+	Enabled bool   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled" xml:"enabled,attr" default:"true"`
+	// The event types to post, e.g. FolderCompletion. Empty means the
+	// default set of events.
+	Events []string `protobuf:"bytes,4,rep,name=events,proto3" json:"events" xml:"event"`
+	// Key for the HMAC-SHA256 signature of the payload, if any.
+	Secret string `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret" xml:"secret"`
+}
+
+func (m *WebhookConfiguration) Reset()         { *m = WebhookConfiguration{} }
+func (m *WebhookConfiguration) String() string { return proto.CompactTextString(m) }
+func (*WebhookConfiguration) ProtoMessage()    {}
+func (*WebhookConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4505edde0bb42548, []int{0}
+}
+func (m *WebhookConfiguration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WebhookConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WebhookConfiguration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WebhookConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookConfiguration.Merge(m, src)
+}
+func (m *WebhookConfiguration) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *WebhookConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookConfiguration proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*WebhookConfiguration)(nil), "config.WebhookConfiguration")
+}
+
+func init() {
+	proto.RegisterFile("lib/config/webhookconfiguration.proto", fileDescriptor_4505edde0bb42548)
+}
+
+var fileDescriptor_4505edde0bb42548 = []byte{
+	// 381 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0x3f, 0xef, 0x93, 0x40,
+	0x1c, 0xc6, 0xe1, 0x68, 0x69, 0x8b, 0xd5, 0x81, 0x38, 0x90, 0x0e, 0x77, 0x48, 0x30, 0xa9, 0x89,
+	0x69, 0x13, 0x75, 0x62, 0xc4, 0x0e, 0x36, 0x76, 0x22, 0x31, 0x26, 0x6e, 0xfc, 0xb9, 0xb6, 0x17,
+	0x29, 0x18, 0x7a, 0x68, 0x7d, 0x17, 0xa6, 0xaf, 0xc0, 0x97, 0xd3, 0x0d, 0x46, 0xa7, 0x4b, 0x0a,
+	0x1b, 0x23, 0xa3, 0x83, 0x31, 0xc7, 0xd1, 0xdf, 0xaf, 0xdb, 0xf3, 0x3c, 0xdf, 0x7b, 0x3e, 0xdf,
+	0x5c, 0xbe, 0xda, 0xcb, 0x98, 0x04, 0xcb, 0x30, 0x4d, 0xb6, 0x64, 0xb7, 0xfc, 0x81, 0x83, 0x7d,
+	0x9a, 0x7e, 0x15, 0x2e, 0xcf, 0x7c, 0x4a, 0xd2, 0x64, 0xf1, 0x2d, 0x4b, 0x69, 0xaa, 0xab, 0x22,
+	0x9c, 0x4d, 0xf0, 0x89, 0x8a, 0xc8, 0xfa, 0x07, 0xb4, 0xe7, 0x9f, 0x45, 0xe3, 0xfd, 0x7d, 0x43,
+	0x5f, 0x69, 0x80, 0x44, 0x86, 0x6c, 0xca, 0xf3, 0x89, 0xfb, 0xae, 0x62, 0x08, 0xac, 0x57, 0x0d,
+	0x43, 0x80, 0x44, 0x2d, 0x43, 0x4f, 0x4f, 0x87, 0xd8, 0xb1, 0x48, 0xf4, 0xda, 0xa7, 0x34, 0xb3,
+	0x9a, 0xc2, 0x1e, 0xf5, 0xba, 0x2d, 0x6c, 0x40, 0xa2, 0x73, 0x69, 0x83, 0xf5, 0xca, 0x03, 0x24,
+	0xd2, 0x37, 0x9a, 0x92, 0x67, 0xb1, 0x01, 0x3a, 0x8c, 0x53, 0x31, 0xa4, 0x7c, 0xf2, 0x36, 0x0d,
+	0x43, 0x3c, 0x6d, 0x19, 0x7a, 0xd6, 0x81, 0xf2, 0x2c, 0x7e, 0x20, 0x8d, 0x6f, 0xa6, 0x2d, 0x6c,
+	0xfe, 0xe8, 0x5c, 0xda, 0xbc, 0xe2, 0x71, 0xad, 0x07, 0xda, 0x08, 0x27, 0x7e, 0x10, 0xe3, 0xc8,
+	0x50, 0x4c, 0x79, 0x3e, 0x76, 0x3f, 0x34, 0x0c, 0xdd, 0xa2, 0x96, 0xa1, 0x17, 0x1d, 0xae, 0xf7,
+	0x02, 0x69, 0x46, 0x78, 0xeb, 0xe7, 0x31, 0x75, 0x2c, 0x9a, 0xe5, 0x98, 0x6f, 0x98, 0xde, 0xcf,
+	0xff, 0x16, 0xf6, 0x80, 0x0f, 0xbc, 0x1b, 0x45, 0x77, 0x34, 0x15, 0x7f, 0xc7, 0x09, 0x3d, 0x1a,
+	0x03, 0x53, 0x99, 0x4f, 0x5c, 0xab, 0x61, 0xa8, 0x4f, 0x5a, 0x86, 0x9e, 0x88, 0x0d, 0xdc, 0x72,
+	0xd6, 0xb0, 0x53, 0x5e, 0x3f, 0xd7, 0xdf, 0x68, 0xea, 0x11, 0x87, 0x19, 0xa6, 0xc6, 0xb0, 0xfb,
+	0xf0, 0x8c, 0x77, 0x45, 0xd2, 0x32, 0x34, 0xed, 0xba, 0xc2, 0x5a, 0x5e, 0x9f, 0xbb, 0x1f, 0x2f,
+	0x57, 0x28, 0x95, 0x57, 0x28, 0x5d, 0x2a, 0x28, 0x97, 0x15, 0x94, 0x7f, 0xd5, 0x50, 0xfa, 0x5d,
+	0x43, 0xb9, 0xac, 0xa1, 0xf4, 0xa7, 0x86, 0xd2, 0x97, 0x57, 0x3b, 0x42, 0xf7, 0x79, 0xb0, 0x08,
+	0xd3, 0xc3, 0xf2, 0xf8, 0x33, 0x09, 0xe9, 0x9e, 0x24, 0xbb, 0x3b, 0xf5, 0x78, 0xfb, 0x40, 0xed,
+	0x8e, 0xfa, 0xf6, 0xff, 0x00, 0x27, 0xd5, 0xf7, 0x49, 0x10, 0x02, 0x00, 0x00,
+}
+
+func (m *WebhookConfiguration) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WebhookConfiguration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WebhookConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Secret) > 0 {
+		i -= len(m.Secret)
+		copy(dAtA[i:], m.Secret)
+		i = encodeVarintWebhookconfiguration(dAtA, i, uint64(len(m.Secret)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Events[iNdEx])
+			copy(dAtA[i:], m.Events[iNdEx])
+			i = encodeVarintWebhookconfiguration(dAtA, i, uint64(len(m.Events[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Enabled {
+		i--
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.URL) > 0 {
+		i -= len(m.URL)
+		copy(dAtA[i:], m.URL)
+		i = encodeVarintWebhookconfiguration(dAtA, i, uint64(len(m.URL)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintWebhookconfiguration(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWebhookconfiguration(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWebhookconfiguration(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *WebhookConfiguration) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovWebhookconfiguration(uint64(l))
+	}
+	l = len(m.URL)
+	if l > 0 {
+		n += 1 + l + sovWebhookconfiguration(uint64(l))
+	}
+	if m.Enabled {
+		n += 2
+	}
+	if len(m.Events) > 0 {
+		for _, s := range m.Events {
+			l = len(s)
+			n += 1 + l + sovWebhookconfiguration(uint64(l))
+		}
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovWebhookconfiguration(uint64(l))
+	}
+	return n
+}
+
+func sovWebhookconfiguration(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWebhookconfiguration(x uint64) (n int) {
+	return sovWebhookconfiguration(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *WebhookConfiguration) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWebhookconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WebhookConfiguration: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WebhookConfiguration: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.URL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Secret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWebhookconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipWebhookconfiguration(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowWebhookconfiguration
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthWebhookconfiguration
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupWebhookconfiguration
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthWebhookconfiguration
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthWebhookconfiguration        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowWebhookconfiguration          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupWebhookconfiguration = fmt.Errorf("proto: unexpected end of group")
+)
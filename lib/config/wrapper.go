@@ -7,6 +7,7 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
@@ -83,10 +84,15 @@ type Wrapper interface {
 	RawCopy() Configuration
 	RequiresRestart() bool
 	Save() error
+	History() ([]HistoryEntry, error)
+	HistoricConfig(id string) (Configuration, error)
 
 	Modify(ModifyFunction) (Waiter, error)
 	RemoveFolder(id string) (Waiter, error)
 	RemoveDevice(id protocol.DeviceID) (Waiter, error)
+	// As returns a view of the configuration whose modifications are
+	// attributed to the given actor in the ConfigChanged events.
+	As(actor string) Wrapper
 
 	GUI() GUIConfiguration
 	LDAP() LDAPConfiguration
@@ -207,11 +213,19 @@ func (w *wrapper) RawCopy() Configuration {
 }
 
 func (w *wrapper) Modify(fn ModifyFunction) (Waiter, error) {
-	return w.modifyQueued(fn)
+	return w.modifyQueued(ActorSyncthing, fn)
 }
 
-func (w *wrapper) modifyQueued(modifyFunc ModifyFunction) (Waiter, error) {
+func (w *wrapper) As(actor string) Wrapper {
+	return &actorWrapper{
+		wrapper: w,
+		actor:   actor,
+	}
+}
+
+func (w *wrapper) modifyQueued(actor string, modifyFunc ModifyFunction) (Waiter, error) {
 	e := modifyEntry{
+		actor:      actor,
 		modifyFunc: modifyFunc,
 		res:        make(chan modifyResult),
 	}
@@ -252,7 +266,16 @@ func (w *wrapper) Serve(ctx context.Context) error {
 		// Check if the config was actually changed at all.
 		w.mut.Lock()
 		if !reflect.DeepEqual(w.cfg, to) {
+			from := w.cfg
 			waiter, err = w.replaceLocked(to)
+			if err == nil {
+				if changes := configurationChanges(w.myID, from, w.cfg); len(changes) > 0 {
+					w.evLogger.Log(events.ConfigChanged, map[string]interface{}{
+						"actor":   e.actor,
+						"changes": changes,
+					})
+				}
+			}
 			if !saveTimerRunning {
 				saveTimer.Reset(minSaveInterval)
 				saveTimerRunning = true
@@ -348,7 +371,11 @@ func (w *wrapper) DeviceList() []DeviceConfiguration {
 
 // RemoveDevice removes the device from the configuration
 func (w *wrapper) RemoveDevice(id protocol.DeviceID) (Waiter, error) {
-	return w.modifyQueued(func(cfg *Configuration) {
+	return w.removeDevice(ActorSyncthing, id)
+}
+
+func (w *wrapper) removeDevice(actor string, id protocol.DeviceID) (Waiter, error) {
+	return w.modifyQueued(actor, func(cfg *Configuration) {
 		if _, i, ok := cfg.Device(id); ok {
 			cfg.Devices = append(cfg.Devices[:i], cfg.Devices[i+1:]...)
 		}
@@ -381,7 +408,11 @@ func (w *wrapper) FolderList() []FolderConfiguration {
 
 // RemoveFolder removes the folder from the configuration
 func (w *wrapper) RemoveFolder(id string) (Waiter, error) {
-	return w.modifyQueued(func(cfg *Configuration) {
+	return w.removeFolder(ActorSyncthing, id)
+}
+
+func (w *wrapper) removeFolder(actor string, id string) (Waiter, error) {
+	return w.modifyQueued(actor, func(cfg *Configuration) {
 		if _, i, ok := cfg.Folder(id); ok {
 			cfg.Folders = append(cfg.Folders[:i], cfg.Folders[i+1:]...)
 		}
@@ -481,14 +512,20 @@ func (w *wrapper) Save() error {
 	w.mut.Lock()
 	defer w.mut.Unlock()
 
+	var buf bytes.Buffer
+	if err := w.cfg.WriteXML(&buf); err != nil {
+		l.Debugln("WriteXML:", err)
+		return err
+	}
+
 	fd, err := osutil.CreateAtomic(w.path)
 	if err != nil {
 		l.Debugln("CreateAtomic:", err)
 		return err
 	}
 
-	if err := w.cfg.WriteXML(fd); err != nil {
-		l.Debugln("WriteXML:", err)
+	if _, err := fd.Write(buf.Bytes()); err != nil {
+		l.Debugln("Write:", err)
 		fd.Close()
 		return err
 	}
@@ -498,6 +535,11 @@ func (w *wrapper) Save() error {
 		return err
 	}
 
+	if err := w.saveHistoryLocked(buf.Bytes()); err != nil {
+		// The configuration itself was saved fine.
+		l.Warnln("Saving configuration history:", err)
+	}
+
 	w.evLogger.Log(events.ConfigSaved, w.cfg)
 	return nil
 }
@@ -511,6 +553,7 @@ func (w *wrapper) setRequiresRestart() {
 }
 
 type modifyEntry struct {
+	actor      string
 	modifyFunc ModifyFunction
 	res        chan modifyResult
 }
@@ -519,3 +562,21 @@ type modifyResult struct {
 	w   Waiter
 	err error
 }
+
+// actorWrapper attributes the modifications made through it to an actor.
+type actorWrapper struct {
+	*wrapper
+	actor string
+}
+
+func (w *actorWrapper) Modify(fn ModifyFunction) (Waiter, error) {
+	return w.modifyQueued(w.actor, fn)
+}
+
+func (w *actorWrapper) RemoveFolder(id string) (Waiter, error) {
+	return w.removeFolder(w.actor, id)
+}
+
+func (w *actorWrapper) RemoveDevice(id protocol.DeviceID) (Waiter, error) {
+	return w.removeDevice(w.actor, id)
+}
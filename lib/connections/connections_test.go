@@ -8,11 +8,15 @@ package connections
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/nat"
 	"github.com/syncthing/syncthing/lib/protocol"
 )
 
@@ -216,3 +220,203 @@ func TestConnectionStatus(t *testing.T) {
 
 	check(nil, nil)
 }
+
+func TestProxyFor(t *testing.T) {
+	cfg := config.Wrap("/dev/null", config.Configuration{}, protocol.LocalDeviceID, events.NoopLogger)
+	s := &service{cfg: cfg}
+
+	cases := []struct {
+		global, device string
+		wanOnly        bool
+		host           string
+		explicit       bool
+		proxy          string
+	}{
+		{"", "", false, "192.0.2.1:22000", false, ""},
+		{"socks5://proxy:1080", "", false, "192.0.2.1:22000", true, "socks5://proxy:1080"},
+		{"socks5://proxy:1080", "http://other:3128", false, "192.0.2.1:22000", true, "http://other:3128"},
+		{"socks5://proxy:1080", "direct", false, "192.0.2.1:22000", true, ""},
+		{"", "socks5://proxy:1080", false, "192.0.2.1:22000", true, "socks5://proxy:1080"},
+		{"socks5://proxy:1080", "", true, "127.0.0.1:22000", true, ""},
+		{"socks5://proxy:1080", "", false, "127.0.0.1:22000", true, "socks5://proxy:1080"},
+	}
+
+	for _, tc := range cases {
+		opts := config.OptionsConfiguration{ProxyURL: tc.global, ProxyWANOnly: tc.wanOnly}
+		dev := config.DeviceConfiguration{ProxyURL: tc.device}
+		res, err := s.proxyFor(opts, dev, tc.host)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.explicit != tc.explicit {
+			t.Errorf("%+v: explicit %v != expected %v", tc, res.explicit, tc.explicit)
+		}
+		proxy := ""
+		if res.url != nil {
+			proxy = res.url.String()
+		}
+		if proxy != tc.proxy {
+			t.Errorf("%+v: proxy %q != expected %q", tc, proxy, tc.proxy)
+		}
+		if res.allows("quic") != (tc.proxy == "") {
+			t.Errorf("%+v: QUIC allowed %v through proxy %q", tc, res.allows("quic"), tc.proxy)
+		}
+		if !res.allows("tcp") {
+			t.Errorf("%+v: TCP not allowed", tc)
+		}
+	}
+}
+
+func TestTransportPriority(t *testing.T) {
+	order := []string{"tcp-lan", "tcp-wan", "quic", "relay"}
+
+	defaults := map[string]int{"tcp": tcpPriority, "quic": 100, "relay": relayPriority}
+
+	cases := []struct {
+		order     []string
+		transport string
+		lan       bool
+		expected  int
+	}{
+		{nil, "tcp", true, tcpPriority},
+		{nil, "relay", false, relayPriority},
+		{order, "tcp", true, 10},
+		{order, "tcp", false, 20},
+		{order, "quic", true, 30},
+		{order, "quic", false, 30},
+		{order, "relay", false, 40},
+		{[]string{"relay", "tcp-lan"}, "tcp", false, unlistedTransportPriority + tcpPriority},
+		{[]string{"relay", "tcp-lan"}, "relay", false, 10},
+	}
+
+	for _, tc := range cases {
+		if prio := transportPriority(tc.order, tc.transport, defaults[tc.transport], tc.lan); prio != tc.expected {
+			t.Errorf("%+v: priority %d != expected %d", tc, prio, tc.expected)
+		}
+	}
+
+	if err := checkTransportPriority(order); err != nil {
+		t.Error(err)
+	}
+	if err := checkTransportPriority([]string{"tcp", "carrier-pigeon"}); err == nil {
+		t.Error("expected error for unknown transport")
+	}
+}
+
+func TestExistingConnectionAction(t *testing.T) {
+	old := 2 * minConnectionReplaceAge
+
+	cases := []struct {
+		name            string
+		wantSecondary   bool
+		remoteSecondary bool
+		existing, prio  int
+		age             time.Duration
+		expected        connectionAction
+	}{
+		{"both want secondary", true, true, tcpPriority, tcpPriority, old, connectionAddSecondary},
+		// With numConnections > 1 on one side only, neither side may
+		// replace the existing connection with the additional one.
+		{"only we want secondary", true, false, relayPriority, tcpPriority, old, connectionRejectSecondary},
+		{"only remote wants secondary", false, true, relayPriority, tcpPriority, old, connectionRejectSecondary},
+		{"better priority", false, false, relayPriority, tcpPriority, 0, connectionReplace},
+		{"old existing", false, false, tcpPriority, tcpPriority, old, connectionReplace},
+		{"recent existing", false, false, tcpPriority, relayPriority, 0, connectionReject},
+	}
+
+	for _, tc := range cases {
+		if action := existingConnectionAction(tc.wantSecondary, tc.remoteSecondary, tc.existing, tc.prio, tc.age); action != tc.expected {
+			t.Errorf("%s: action %d != expected %d", tc.name, action, tc.expected)
+		}
+	}
+}
+
+func TestRestrictFamily(t *testing.T) {
+	cases := []struct {
+		family   config.AddressFamily
+		addr     string
+		expected string // empty if disallowed
+	}{
+		{config.AddressFamilyAny, "tcp://0.0.0.0:22000", "tcp://0.0.0.0:22000"},
+		{config.AddressFamilyPreferIPv6, "tcp://192.0.2.42:22000", "tcp://192.0.2.42:22000"},
+		{config.AddressFamilyIPv4Only, "tcp://0.0.0.0:22000", "tcp4://0.0.0.0:22000"},
+		{config.AddressFamilyIPv4Only, "quic://[::]:22000", "quic4://0.0.0.0:22000"},
+		{config.AddressFamilyIPv4Only, "tcp://[2001:db8::42]:22000", ""},
+		{config.AddressFamilyIPv4Only, "tcp6://example.com:22000", "tcp6://example.com:22000"},
+		{config.AddressFamilyIPv6Only, "tcp://0.0.0.0:22000", "tcp6://[::]:22000"},
+		{config.AddressFamilyIPv6Only, "quic://example.com:22000", "quic6://example.com:22000"},
+		{config.AddressFamilyIPv6Only, "relay://192.0.2.42:22067", ""},
+		{config.AddressFamilyIPv6Only, "relay://example.com:22067", "relay://example.com:22067"},
+	}
+
+	for _, tc := range cases {
+		uri, err := url.Parse(tc.addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, ok := restrictFamily(tc.family, uri)
+		if tc.expected == "" {
+			if ok {
+				t.Errorf("%v %s: expected disallowed, got %v", tc.family, tc.addr, res)
+			}
+			continue
+		}
+		if !ok || res.String() != tc.expected {
+			t.Errorf("%v %s: got %v, expected %s", tc.family, tc.addr, res, tc.expected)
+		}
+	}
+}
+
+func TestListenPortZero(t *testing.T) {
+	wrapper, cancel := initConfig()
+	defer cancel()
+
+	uri, err := url.Parse("tcp://127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener := (&tcpListenerFactory{}).New(uri, wrapper, &tls.Config{}, make(chan internalConn), nat.NewService(device1, wrapper))
+
+	// There's no port to announce until there is one.
+	if addrs := listener.LANAddresses(); len(addrs) != 0 {
+		t.Error("Expected no addresses before listening, got", addrs)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		listener.Serve(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	timeout := time.After(5 * time.Second)
+	for listener.BoundURI() == nil {
+		select {
+		case <-timeout:
+			t.Fatal("Timed out waiting for the listener")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	bound := listener.BoundURI()
+	if bound.Hostname() != "127.0.0.1" || bound.Port() == "0" {
+		t.Fatal("Expected a picked port, got", bound)
+	}
+	for _, addrs := range [][]*url.URL{listener.LANAddresses(), listener.WANAddresses()} {
+		found := false
+		for _, addr := range addrs {
+			if addr.Port() == "0" && addr.Hostname() != "0.0.0.0" {
+				t.Error("Address with port zero", addr)
+			}
+			if addr.String() == bound.String() {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %v among the addresses, got %v", bound, addrs)
+		}
+	}
+}
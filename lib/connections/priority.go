@@ -0,0 +1,110 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connections
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Distance between the priorities of consecutive entries in a
+	// configured transport priority list, leaving room for the LAN bonus
+	// used to order dials.
+	transportPriorityStep = 10
+	// Priority offset for transports not mentioned in a configured
+	// transport priority list; they are used only as a last resort.
+	unlistedTransportPriority = 1000
+)
+
+var knownTransports = []string{"tcp", "quic", "relay"}
+
+// schemeTransport returns the transport for a dialer or listener scheme,
+// i.e. "tcp" for "tcp", "tcp4" and "tcp6".
+func schemeTransport(scheme string) string {
+	return strings.TrimRight(scheme, "46")
+}
+
+// transportPriority returns the priority of a connection over the given
+// transport, lower being better. Without a configured order the default
+// priority of the transport is used. Otherwise the priority follows the
+// position of the first entry matching the transport, where entries may be
+// qualified with "-lan" or "-wan".
+func transportPriority(order []string, transport string, defaultPriority int, lan bool) int {
+	if len(order) == 0 {
+		return defaultPriority
+	}
+	qualified := transport + "-wan"
+	if lan {
+		qualified = transport + "-lan"
+	}
+	for i, entry := range order {
+		if entry == transport || entry == qualified {
+			return (i + 1) * transportPriorityStep
+		}
+	}
+	return unlistedTransportPriority + defaultPriority
+}
+
+type connectionAction int
+
+const (
+	connectionAdd connectionAction = iota
+	connectionAddSecondary
+	connectionReplace
+	connectionReject
+	connectionRejectSecondary
+)
+
+// existingConnectionAction decides what to do with a new connection to a
+// device we're already connected to. It becomes a secondary connection
+// when both sides asked for one, and is rejected when only one side did.
+// Otherwise it replaces the existing connection if it's better, lower
+// priority being better just like nice etc., or the existing one is old
+// enough to possibly be dead.
+func existingConnectionAction(wantSecondary, remoteSecondary bool, existingPriority, priority int, existingAge time.Duration) connectionAction {
+	switch {
+	case wantSecondary && remoteSecondary:
+		return connectionAddSecondary
+	case wantSecondary || remoteSecondary:
+		return connectionRejectSecondary
+	case existingPriority > priority || existingAge > minConnectionReplaceAge:
+		return connectionReplace
+	default:
+		return connectionReject
+	}
+}
+
+// checkTransportPriority verifies that all entries of a transport priority
+// list are known transports.
+func checkTransportPriority(order []string) error {
+	for _, entry := range order {
+		transport := strings.TrimSuffix(strings.TrimSuffix(entry, "-lan"), "-wan")
+		known := false
+		for _, t := range knownTransports {
+			if t == transport {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown transport %q in priority list", entry)
+		}
+	}
+	return nil
+}
+
+// alwaysWAN returns whether connections over the given transport are always
+// considered WAN connections, as is the case for relays.
+func alwaysWAN(transport string) bool {
+	df, ok := dialers[transport]
+	if _, invalid := df.(invalidDialer); !ok || invalid {
+		return false
+	}
+	return df.AlwaysWAN()
+}
@@ -39,6 +39,7 @@ type quicListener struct {
 	nat atomic.Value
 
 	onAddressesChangedNotifier
+	boundAddress
 
 	uri     *url.URL
 	cfg     config.Wrapper
@@ -101,9 +102,12 @@ func (t *quicListener) serve(ctx context.Context) error {
 		l.Infoln("Listen (BEP/quic):", err)
 		return err
 	}
+	// The port differs when the system picked it.
+	t.setBound(t.uri, packetConn.LocalAddr().(*net.UDPAddr).Port)
 	t.notifyAddressesChanged(t)
 	defer listener.Close()
 	defer t.clearAddresses(t)
+	defer t.clearBound()
 
 	l.Infof("QUIC listener (%v) starting", packetConn.LocalAddr())
 	defer l.Infof("QUIC listener (%v) shutting down", packetConn.LocalAddr())
@@ -159,7 +163,11 @@ func (t *quicListener) URI() *url.URL {
 }
 
 func (t *quicListener) WANAddresses() []*url.URL {
-	uris := []*url.URL{t.uri}
+	addrURI := t.addressURI(t.uri)
+	if addrURI == nil {
+		return nil
+	}
+	uris := []*url.URL{addrURI}
 	t.mut.Lock()
 	if t.address != nil {
 		uris = append(uris, t.address)
@@ -169,9 +177,13 @@ func (t *quicListener) WANAddresses() []*url.URL {
 }
 
 func (t *quicListener) LANAddresses() []*url.URL {
-	addrs := []*url.URL{t.uri}
+	addrURI := t.addressURI(t.uri)
+	if addrURI == nil {
+		return nil
+	}
+	addrs := []*url.URL{addrURI}
 	network := strings.Replace(t.uri.Scheme, "quic", "udp", -1)
-	addrs = append(addrs, getURLsForAllAdaptersIfUnspecified(network, t.uri)...)
+	addrs = append(addrs, getURLsForAllAdaptersIfUnspecified(network, addrURI)...)
 	return addrs
 }
 
@@ -50,6 +50,7 @@ func (t *relayListener) serve(ctx context.Context) error {
 		return err
 	}
 	invitations := clnt.Invitations()
+	t.updatePreferences(clnt)
 
 	t.mut.Lock()
 	t.client = clnt
@@ -112,6 +113,7 @@ func (t *relayListener) serve(ctx context.Context) error {
 		// relay via dynamic+http(s) pool, which upon a relay failing/dropping
 		// us, would pick a different one.
 		case <-time.After(10 * time.Second):
+			t.updatePreferences(clnt)
 			currentURI := clnt.URI()
 			if currentURI != oldURI {
 				oldURI = currentURI
@@ -124,6 +126,25 @@ func (t *relayListener) serve(ctx context.Context) error {
 	}
 }
 
+// updatePreferences passes the configured relay preferences on to clients
+// that pick a relay from a pool.
+func (t *relayListener) updatePreferences(clnt client.RelayClient) {
+	if pc, ok := clnt.(client.ProbingClient); ok {
+		pc.SetPreferences(t.cfg.Options().RelayPreferences)
+	}
+}
+
+// ProbeResults returns the results of probing the relays of the pool, if
+// the listener uses one.
+func (t *relayListener) ProbeResults() []client.ProbeResult {
+	t.mut.RLock()
+	defer t.mut.RUnlock()
+	if pc, ok := t.client.(client.ProbingClient); ok {
+		return pc.ProbeResults()
+	}
+	return nil
+}
+
 func (t *relayListener) URI() *url.URL {
 	return t.uri
 }
@@ -170,6 +191,11 @@ func (t *relayListener) String() string {
 	return t.uri.String()
 }
 
+func (t *relayListener) BoundURI() *url.URL {
+	// Relayed connections don't come in on a local address.
+	return nil
+}
+
 func (t *relayListener) NATType() string {
 	return "unknown"
 }
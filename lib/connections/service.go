@@ -19,11 +19,13 @@ import (
 	"time"
 
 	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/discover"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/nat"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/relay/client"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/sync"
 	"github.com/syncthing/syncthing/lib/util"
@@ -52,6 +54,8 @@ var (
 	errDisabled   = fmt.Errorf("%w: disabled by configuration", errUnsupported)
 	errDeprecated = fmt.Errorf("%w: deprecated", errUnsupported)
 	errNotInBuild = fmt.Errorf("%w: disabled at build time", errUnsupported)
+
+	errNotProxyable = fmt.Errorf("%w: not possible through the configured proxy", errUnsupported)
 )
 
 const (
@@ -63,6 +67,7 @@ const (
 	worstDialerPriority           = math.MaxInt32
 	recentlySeenCutoff            = 7 * 24 * time.Hour
 	shortLivedConnectionThreshold = 5 * time.Second
+	defaultTCPKeepAlivePeriod     = 60 * time.Second // as set by dialer.SetTCPOptions
 )
 
 // From go/src/crypto/tls/cipher_suites.go
@@ -109,11 +114,16 @@ type Service interface {
 	discover.AddressLister
 	ListenerStatus() map[string]ListenerStatusEntry
 	ConnectionStatus() map[string]ConnectionStatusEntry
+	RelayProbeResults() map[string][]client.ProbeResult
 	NATType() string
+	NATStatus() []nat.DeviceStatus
 }
 
 type ListenerStatusEntry struct {
-	Error        *string  `json:"error"`
+	Error *string `json:"error"`
+	// The address actually listened on, with the port the system picked
+	// if listening on port zero.
+	BoundAddress string   `json:"boundAddress,omitempty"`
 	LANAddresses []string `json:"lanAddresses"`
 	WANAddresses []string `json:"wanAddresses"`
 }
@@ -237,18 +247,20 @@ func (s *service) handle(ctx context.Context) error {
 		}
 		remoteCert := certs[0]
 		remoteID := protocol.NewDeviceID(remoteCert.Raw)
+		devLog := l.With("device", remoteID.String())
 
 		// The device ID should not be that of ourselves. It can happen
 		// though, especially in the presence of NAT hairpinning, multiple
 		// clients between the same NAT gateway, and global discovery.
 		if remoteID == s.myID {
-			l.Infof("Connected to myself (%s) at %s - should not happen", remoteID, c)
+			devLog.Infof("Connected to myself (%s) at %s - should not happen", remoteID, c)
 			c.Close()
 			continue
 		}
 
 		_ = c.SetDeadline(time.Now().Add(20 * time.Second))
-		hello, err := protocol.ExchangeHello(c, s.model.GetHello(remoteID))
+		ourHello := s.model.GetHello(remoteID)
+		hello, err := protocol.ExchangeHello(c, ourHello)
 		if err != nil {
 			if protocol.IsVersionMismatch(err) {
 				// The error will be a relatively user friendly description
@@ -266,43 +278,78 @@ func (s *service) handle(ctx context.Context) error {
 				warningFor(remoteID, msg)
 			} else {
 				// It's something else - connection reset or whatever
-				l.Infof("Failed to exchange Hello messages with %s at %s: %s", remoteID, c, err)
+				devLog.Infof("Failed to exchange Hello messages with %s at %s: %s", remoteID, c, err)
 			}
 			c.Close()
 			continue
 		}
 		_ = c.SetDeadline(time.Time{})
 
+		// A certificate the other side is rotating to is only trusted if
+		// vouched for by the one it's currently using.
+		if _, err := hello.VerifiedNextDeviceID(remoteCert); err != nil {
+			devLog.Infof("Ignoring certificate rotation announced by %s at %s: %v", remoteID, c, err)
+			hello.NextCertificate, hello.NextCertificateSignature = nil, nil
+		}
+
 		// The Model will return an error for devices that we don't want to
 		// have a connection with for whatever reason, for example unknown devices.
 		if err := s.model.OnHello(remoteID, c.RemoteAddr(), hello); err != nil {
-			l.Infof("Connection from %s at %s (%s) rejected: %v", remoteID, c.RemoteAddr(), c.Type(), err)
+			devLog.Infof("Connection from %s at %s (%s) rejected: %v", remoteID, c.RemoteAddr(), c.Type(), err)
 			c.Close()
 			continue
 		}
 
+		deviceCfg, ok := s.cfg.Device(remoteID)
+		if !ok {
+			devLog.Infof("Device %s removed from config during connection attempt at %s", remoteID, c)
+			c.Close()
+			continue
+		}
+
+		// The priority of the connection depends on the transport
+		// priority configured for the device, if any.
+		transport := c.connType.Transport()
+		isLAN := s.isLAN(c.RemoteAddr())
+		c.priority = transportPriority(deviceCfg.TransportPriority, transport, c.priority, isLAN && !alwaysWAN(transport))
+
 		// If we have a relay connection, and the new incoming connection is
 		// not a relay connection, we should drop that, and prefer this one.
 		ct, connected := s.model.Connection(remoteID)
 
-		// Lower priority is better, just like nice etc.
-		if connected && (ct.Priority() > c.priority || time.Since(ct.Statistics().StartedAt) > minConnectionReplaceAge) {
+		// When both sides asked for an additional connection it becomes a
+		// secondary one, used only for block transfers alongside the
+		// existing connection.
+		wantSecondary := false
+		if h, ok := ourHello.(*protocol.Hello); ok {
+			wantSecondary = h.Secondary
+		}
+		var action connectionAction
+		if connected {
+			action = existingConnectionAction(wantSecondary, hello.Secondary, ct.Priority(), c.priority, time.Since(ct.Statistics().StartedAt))
+		}
+		secondary := action == connectionAddSecondary
+
+		switch action {
+		case connectionAddSecondary:
+			l.Debugf("Adding secondary connection to %s (existing: %s new: %s)", remoteID, ct, c)
+		case connectionRejectSecondary:
+			// Only one side wants an additional connection; don't let it
+			// replace the existing one, or the side that asked would keep
+			// doing so.
+			l.Debugf("Secondary connection to %s not wanted by both sides (existing: %s new: %s)", remoteID, ct, c)
+			c.Close()
+			continue
+		case connectionReplace:
 			l.Debugf("Switching connections %s (existing: %s new: %s)", remoteID, ct, c)
-		} else if connected {
+		case connectionReject:
 			// We should not already be connected to the other party. TODO: This
 			// could use some better handling. If the old connection is dead but
 			// hasn't timed out yet we may want to drop *that* connection and keep
 			// this one. But in case we are two devices connecting to each other
 			// in parallel we don't want to do that or we end up with no
 			// connections still established...
-			l.Infof("Connected to already connected device %s (existing: %s new: %s)", remoteID, ct, c)
-			c.Close()
-			continue
-		}
-
-		deviceCfg, ok := s.cfg.Device(remoteID)
-		if !ok {
-			l.Infof("Device %s removed from config during connection attempt at %s", remoteID, c)
+			devLog.Infof("Connected to already connected device %s (existing: %s new: %s)", remoteID, ct, c)
 			c.Close()
 			continue
 		}
@@ -322,26 +369,53 @@ func (s *service) handle(ctx context.Context) error {
 			// Incorrect certificate name is something the user most
 			// likely wants to know about, since it's an advanced
 			// config. Warn instead of Info.
-			l.Warnf("Bad certificate from %s at %s: %v", remoteID, c, err)
+			devLog.Warnf("Bad certificate from %s at %s: %v", remoteID, c, err)
 			c.Close()
 			continue
 		}
 
+		// Have TCP notice dead connections about as soon as the protocol
+		// would, and also while sends are stuck, when the protocol can't.
+		keepalive := deviceCfg.Keepalive().WithDefaults()
+		if nc, ok := c.tlsConn.(net.Conn); ok && c.connType.Transport() != "quic" {
+			keepAlivePeriod := keepalive.PingInterval
+			if keepAlivePeriod > defaultTCPKeepAlivePeriod {
+				keepAlivePeriod = defaultTCPKeepAlivePeriod
+			}
+			if err := dialer.SetTCPTimeouts(nc, keepAlivePeriod, keepalive.ReceiveTimeout); err != nil {
+				l.Debugln("Setting TCP timeouts:", err)
+			}
+		}
+
 		// Wrap the connection in rate limiters. The limiter itself will
 		// keep up with config changes to the rate and whether or not LAN
 		// connections are limited.
-		isLAN := s.isLAN(c.RemoteAddr())
 		rd, wr := s.limiter.getLimiters(remoteID, c, isLAN)
 
+		var receiver protocol.Model = s.model
+		if secondary {
+			receiver = protocol.RequestsOnly(s.model, s.model.SecondaryClosed)
+		}
+
+		zstdLevel := 0
+		if protocol.ZstdSupported && hello.HasFeature(protocol.FeatureZstd) {
+			zstdLevel = deviceCfg.ZstdLevel()
+		}
+
 		var protoConn protocol.Connection
 		passwords := s.cfg.FolderPasswords(remoteID)
 		if len(passwords) > 0 {
-			protoConn = protocol.NewEncryptedConnection(passwords, remoteID, rd, wr, c, s.model, c, deviceCfg.Compression)
+			protoConn = protocol.NewEncryptedConnection(passwords, remoteID, rd, wr, c, receiver, c, deviceCfg.Compression, zstdLevel, keepalive)
 		} else {
-			protoConn = protocol.NewConnection(remoteID, rd, wr, c, s.model, c, deviceCfg.Compression)
+			protoConn = protocol.NewConnection(remoteID, rd, wr, c, receiver, c, deviceCfg.Compression, zstdLevel, keepalive)
+		}
+
+		if secondary {
+			s.model.AddSecondaryConnection(protoConn)
+			continue
 		}
 
-		l.Infof("Established secure connection to %s at %s", remoteID, c)
+		devLog.Infof("Established secure connection to %s at %s", remoteID, c)
 
 		s.model.AddConnection(protoConn, hello)
 		continue
@@ -416,6 +490,28 @@ func (s *service) bestDialerPriority(cfg config.Configuration) int {
 	return bestDialerPriority
 }
 
+// bestDevicePriority returns the best priority a connection to the given
+// device may get, considering its transport priority list. Without such a
+// list this is the best priority of any valid dialer.
+func (s *service) bestDevicePriority(cfg config.Configuration, deviceCfg config.DeviceConfiguration, bestDialerPriority int) int {
+	if len(deviceCfg.TransportPriority) == 0 {
+		return bestDialerPriority
+	}
+	best := worstDialerPriority
+	for scheme, df := range dialers {
+		if df.Valid(cfg) != nil {
+			continue
+		}
+		transport := schemeTransport(scheme)
+		for _, lan := range []bool{false, true} {
+			if prio := transportPriority(deviceCfg.TransportPriority, transport, df.Priority(), lan && !df.AlwaysWAN()); prio < best {
+				best = prio
+			}
+		}
+	}
+	return best
+}
+
 func (s *service) dialDevices(ctx context.Context, now time.Time, cfg config.Configuration, bestDialerPriority int, nextDialAt map[string]time.Time, initial bool) {
 	// Figure out current connection limits up front to see if there's any
 	// point in resolving devices and such at all.
@@ -450,9 +546,13 @@ func (s *service) dialDevices(ctx context.Context, now time.Time, cfg config.Con
 		// for dialer priority.
 		priorityCutoff := worstDialerPriority
 		connection, connected := s.model.Connection(deviceCfg.DeviceID)
-		if connected {
+		if connected && int(deviceCfg.NumConnections) > len(s.model.DeviceConnections(deviceCfg.DeviceID)) {
+			// We want additional connections for block transfers, which
+			// may use any transport.
+			l.Debugln("Dialing", deviceCfg.DeviceID, "for a secondary connection")
+		} else if connected {
 			priorityCutoff = connection.Priority()
-			if bestDialerPriority >= priorityCutoff {
+			if s.bestDevicePriority(cfg, deviceCfg, bestDialerPriority) >= priorityCutoff {
 				// Our best dialer is not any better than what we already
 				// have, so nothing to do here.
 				continue
@@ -528,6 +628,13 @@ func (s *service) resolveDialTargets(ctx context.Context, now time.Time, cfg con
 			}
 		}
 
+		uri, ok = restrictFamily(cfg.Options.AddressFamily, uri)
+		if !ok {
+			s.setConnectionStatus(addr, errors.New("address family disallowed"))
+			l.Debugln("Address family for", addr, "is disallowed")
+			continue
+		}
+
 		dialerFactory, err := getDialerFactory(cfg, uri)
 		if err != nil {
 			s.setConnectionStatus(addr, err)
@@ -540,9 +647,22 @@ func (s *service) resolveDialTargets(ctx context.Context, now time.Time, cfg con
 			continue
 		}
 
-		priority := dialerFactory.Priority()
+		isLAN := !dialerFactory.AlwaysWAN() && s.isLANHost(uri.Host)
+		priority := transportPriority(deviceCfg.TransportPriority, schemeTransport(uri.Scheme), dialerFactory.Priority(), isLAN)
 		if priority >= priorityCutoff {
-			l.Debugf("Not dialing using %s as priority is not better than current connection (%d >= %d)", dialerFactory, dialerFactory.Priority(), priorityCutoff)
+			l.Debugf("Not dialing using %s as priority is not better than current connection (%d >= %d)", dialerFactory, priority, priorityCutoff)
+			continue
+		}
+
+		proxy, err := s.proxyFor(cfg.Options, deviceCfg, uri.Host)
+		if err != nil {
+			s.setConnectionStatus(addr, err)
+			l.Infof("Proxy for %v: %v", uri, err)
+			continue
+		}
+		if !proxy.allows(schemeTransport(uri.Scheme)) {
+			s.setConnectionStatus(addr, errNotProxyable)
+			l.Debugf("Not dialing %v as it can't go through the proxy", uri)
 			continue
 		}
 
@@ -551,25 +671,47 @@ func (s *service) resolveDialTargets(ctx context.Context, now time.Time, cfg con
 
 		// For LAN addresses, increase the priority so that we
 		// try these first.
-		switch {
-		case dialerFactory.AlwaysWAN():
-			// Do nothing.
-		case s.isLANHost(uri.Host):
+		if isLAN {
 			priority--
 		}
 
 		dialTargets = append(dialTargets, dialTarget{
-			addr:     addr,
-			dialer:   dialer,
-			priority: priority,
-			deviceID: deviceID,
-			uri:      uri,
+			addr:      addr,
+			dialer:    dialer,
+			priority:  priority,
+			deviceID:  deviceID,
+			uri:       uri,
+			proxy:     proxy,
+			preferred: cfg.Options.AddressFamily.Prefers(hostIP(uri.Host)),
 		})
 	}
 
 	return dialTargets
 }
 
+// proxyFor returns the proxy to use when dialing the given device at the
+// given host. A device level proxy takes precedence over the global one,
+// and the special value "direct" disables proxying for the device.
+func (s *service) proxyFor(opts config.OptionsConfiguration, deviceCfg config.DeviceConfiguration, host string) (proxySetting, error) {
+	raw := deviceCfg.ProxyURL
+	if raw == "" {
+		raw = opts.ProxyURL
+	}
+	switch {
+	case raw == "":
+		return proxySetting{}, nil
+	case raw == "direct":
+		return proxySetting{explicit: true}, nil
+	case opts.ProxyWANOnly && s.isLANHost(host):
+		return proxySetting{explicit: true}, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return proxySetting{}, err
+	}
+	return proxySetting{explicit: true, url: u}, nil
+}
+
 func (s *service) resolveDeviceAddrs(ctx context.Context, cfg config.DeviceConfiguration) []string {
 	var addrs []string
 	for _, addr := range cfg.Addresses {
@@ -662,6 +804,25 @@ func (s *service) logListenAddressesChangedEvent(l ListenerAddresses) {
 }
 
 func (s *service) VerifyConfiguration(from, to config.Configuration) error {
+	proxies := []string{to.Options.ProxyURL}
+	for _, dev := range to.Devices {
+		proxies = append(proxies, dev.ProxyURL)
+		if err := checkTransportPriority(dev.TransportPriority); err != nil {
+			return fmt.Errorf("device %v: %w", dev.DeviceID, err)
+		}
+	}
+	for _, raw := range proxies {
+		if raw == "" || raw == "direct" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("proxy %q: %w", raw, err)
+		}
+		if _, err := dialer.NewProxyDialer(u); err != nil {
+			return fmt.Errorf("proxy %q: %w", raw, err)
+		}
+	}
 	return nil
 }
 
@@ -706,6 +867,13 @@ func (s *service) CommitConfiguration(from, to config.Configuration) bool {
 			continue
 		}
 
+		restricted, ok := restrictFamily(to.Options.AddressFamily, uri)
+		if !ok {
+			l.Debugf("Skipping listener %s as its address family is not allowed", addr)
+			continue
+		}
+		uri, addr = restricted, restricted.String()
+
 		if _, ok := s.listeners[addr]; ok {
 			seen[addr] = struct{}{}
 			continue
@@ -749,7 +917,7 @@ func (s *service) AllAddresses() []string {
 		}
 	}
 	s.listenersMut.RUnlock()
-	return util.UniqueTrimmedStrings(addrs)
+	return s.familyAddresses(util.UniqueTrimmedStrings(addrs))
 }
 
 func (s *service) ExternalAddresses() []string {
@@ -764,7 +932,23 @@ func (s *service) ExternalAddresses() []string {
 		}
 	}
 	s.listenersMut.RUnlock()
-	return util.UniqueTrimmedStrings(addrs)
+	return s.familyAddresses(util.UniqueTrimmedStrings(addrs))
+}
+
+// familyAddresses drops the addresses of disallowed address families and
+// puts those of the preferred one first.
+func (s *service) familyAddresses(addrs []string) []string {
+	family := s.cfg.Options().AddressFamily
+	filtered := addrs[:0]
+	for _, addr := range addrs {
+		if family.Allows(addrHostIP(addr)) {
+			filtered = append(filtered, addr)
+		}
+	}
+	sort.SliceStable(filtered, func(a, b int) bool {
+		return family.Prefers(addrHostIP(filtered[a])) && !family.Prefers(addrHostIP(filtered[b]))
+	})
+	return filtered
 }
 
 func (s *service) ListenerStatus() map[string]ListenerStatusEntry {
@@ -778,6 +962,9 @@ func (s *service) ListenerStatus() map[string]ListenerStatusEntry {
 			status.Error = &errStr
 		}
 
+		if bound := listener.BoundURI(); bound != nil {
+			status.BoundAddress = bound.String()
+		}
 		status.LANAddresses = urlsToStrings(listener.LANAddresses())
 		status.WANAddresses = urlsToStrings(listener.WANAddresses())
 
@@ -787,6 +974,22 @@ func (s *service) ListenerStatus() map[string]ListenerStatusEntry {
 	return result
 }
 
+// RelayProbeResults returns the results of probing the relays of each relay
+// pool listener.
+func (s *service) RelayProbeResults() map[string][]client.ProbeResult {
+	result := make(map[string][]client.ProbeResult)
+	s.listenersMut.RLock()
+	for addr, listener := range s.listeners {
+		if rl, ok := listener.(*relayListener); ok {
+			if results := rl.ProbeResults(); results != nil {
+				result[addr] = results
+			}
+		}
+	}
+	s.listenersMut.RUnlock()
+	return result
+}
+
 type connectionStatusHandler struct {
 	connectionStatusMut sync.RWMutex
 	connectionStatus    map[string]ConnectionStatusEntry // address -> latest error/status
@@ -837,6 +1040,10 @@ func (s *service) NATType() string {
 	return "unknown"
 }
 
+func (s *service) NATStatus() []nat.DeviceStatus {
+	return s.natService.Status()
+}
+
 func getDialerFactory(cfg config.Configuration, uri *url.URL) (dialerFactory, error) {
 	dialerFactory, ok := dialers[uri.Scheme]
 	if !ok {
@@ -953,48 +1160,23 @@ func (s *service) dialParallel(ctx context.Context, deviceID protocol.DeviceID,
 	sort.Ints(priorities)
 
 	for _, prio := range priorities {
-		tgts := dialTargetBuckets[prio]
-		res := make(chan internalConn, len(tgts))
-		wg := stdsync.WaitGroup{}
-		for _, tgt := range tgts {
-			wg.Add(1)
-			go func(tgt dialTarget) {
-				conn, err := tgt.Dial(ctx)
-				if err == nil {
-					// Closes the connection on error
-					err = s.validateIdentity(conn, deviceID)
-				}
-				s.setConnectionStatus(tgt.addr, err)
-				if err != nil {
-					l.Debugln("dialing", deviceID, tgt.uri, "error:", err)
-				} else {
-					l.Debugln("dialing", deviceID, tgt.uri, "success:", conn)
-					res <- conn
-				}
-				wg.Done()
-			}(tgt)
+		// Within a priority, addresses of the preferred address family are
+		// dialed first and the others only if those all fail.
+		var preferred, others []dialTarget
+		for _, tgt := range dialTargetBuckets[prio] {
+			if tgt.preferred {
+				preferred = append(preferred, tgt)
+			} else {
+				others = append(others, tgt)
+			}
 		}
-
-		// Spawn a routine which will unblock main routine in case we fail
-		// to connect to anyone.
-		go func() {
-			wg.Wait()
-			close(res)
-		}()
-
-		// Wait for the first connection, or for channel closure.
-		if conn, ok := <-res; ok {
-			// Got a connection, means more might come back, hence spawn a
-			// routine that will do the discarding.
-			l.Debugln("connected to", deviceID, prio, "using", conn, conn.priority)
-			go func(deviceID protocol.DeviceID, prio int) {
-				wg.Wait()
-				l.Debugln("discarding", len(res), "connections while connecting to", deviceID, prio)
-				for conn := range res {
-					conn.Close()
-				}
-			}(deviceID, prio)
-			return conn, ok
+		for _, tgts := range [][]dialTarget{preferred, others} {
+			if len(tgts) == 0 {
+				continue
+			}
+			if conn, ok := s.dialTargets(ctx, deviceID, prio, tgts); ok {
+				return conn, ok
+			}
 		}
 		// Failed to connect, report that fact.
 		l.Debugln("failed to connect to", deviceID, prio)
@@ -1002,6 +1184,54 @@ func (s *service) dialParallel(ctx context.Context, deviceID protocol.DeviceID,
 	return internalConn{}, false
 }
 
+// dialTargets dials all the targets in parallel, returning the first
+// connection established.
+func (s *service) dialTargets(ctx context.Context, deviceID protocol.DeviceID, prio int, tgts []dialTarget) (internalConn, bool) {
+	res := make(chan internalConn, len(tgts))
+	wg := stdsync.WaitGroup{}
+	for _, tgt := range tgts {
+		wg.Add(1)
+		go func(tgt dialTarget) {
+			conn, err := tgt.Dial(ctx)
+			if err == nil {
+				// Closes the connection on error
+				err = s.validateIdentity(conn, deviceID)
+			}
+			s.setConnectionStatus(tgt.addr, err)
+			if err != nil {
+				l.Debugln("dialing", deviceID, tgt.uri, "error:", err)
+			} else {
+				l.Debugln("dialing", deviceID, tgt.uri, "success:", conn)
+				res <- conn
+			}
+			wg.Done()
+		}(tgt)
+	}
+
+	// Spawn a routine which will unblock main routine in case we fail
+	// to connect to anyone.
+	go func() {
+		wg.Wait()
+		close(res)
+	}()
+
+	// Wait for the first connection, or for channel closure.
+	conn, ok := <-res
+	if ok {
+		// Got a connection, means more might come back, hence spawn a
+		// routine that will do the discarding.
+		l.Debugln("connected to", deviceID, prio, "using", conn, conn.priority)
+		go func(deviceID protocol.DeviceID, prio int) {
+			wg.Wait()
+			l.Debugln("discarding", len(res), "connections while connecting to", deviceID, prio)
+			for conn := range res {
+				conn.Close()
+			}
+		}(deviceID, prio)
+	}
+	return conn, ok
+}
+
 func (s *service) validateIdentity(c internalConn, expectedID protocol.DeviceID) error {
 	cs := c.ConnectionState()
 
@@ -1026,7 +1256,11 @@ func (s *service) validateIdentity(c internalConn, expectedID protocol.DeviceID)
 		return errors.New("connected to self")
 	}
 
-	// We should see the expected device ID
+	// We should see the expected device ID, or the one it announced it is
+	// rotating its certificate to.
+	if dev, ok := s.cfg.Device(expectedID); ok && dev.NextDeviceID != protocol.EmptyDeviceID && remoteID == dev.NextDeviceID {
+		return nil
+	}
 	if !remoteID.Equals(expectedID) {
 		c.Close()
 		return fmt.Errorf("unexpected device id, expected %s got %s", expectedID, remoteID)
@@ -13,9 +13,11 @@ import (
 	"io"
 	"net"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/nat"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/stats"
@@ -186,6 +188,8 @@ type genericListener interface {
 	LANAddresses() []*url.URL
 	Error() error
 	OnAddressesChanged(func(ListenerAddresses))
+	// The address the listener is bound to, if it's bound to one.
+	BoundURI() *url.URL
 	String() string
 	Factory() listenerFactory
 	NATType() string
@@ -194,13 +198,61 @@ type genericListener interface {
 type Model interface {
 	protocol.Model
 	AddConnection(conn protocol.Connection, hello protocol.Hello)
+	AddSecondaryConnection(conn protocol.Connection)
+	SecondaryClosed(conn protocol.Connection, err error)
+	DeviceConnections(remoteID protocol.DeviceID) []protocol.Connection
 	NumConnections() int
 	Connection(remoteID protocol.DeviceID) (protocol.Connection, bool)
+	// OnHello is called with the Hello message received from a device. Any
+	// next certificate it announces has been verified to be signed by the
+	// certificate of the connection.
 	OnHello(protocol.DeviceID, net.Addr, protocol.Hello) error
 	GetHello(protocol.DeviceID) protocol.HelloIntf
 	DeviceStatistics() (map[protocol.DeviceID]stats.DeviceStatistics, error)
 }
 
+// boundAddress keeps the address a listener is actually bound to, which
+// differs from its URI when listening on port zero, i.e. on whatever port
+// the system picks.
+type boundAddress struct {
+	bound *url.URL
+	mut   sync.RWMutex
+}
+
+func (b *boundAddress) setBound(uri *url.URL, port int) {
+	bound := withPort(uri, port)
+	b.mut.Lock()
+	b.bound = bound
+	b.mut.Unlock()
+}
+
+func (b *boundAddress) clearBound() {
+	b.mut.Lock()
+	b.bound = nil
+	b.mut.Unlock()
+}
+
+// BoundURI returns the address the listener is bound to, or nil when it's
+// not listening.
+func (b *boundAddress) BoundURI() *url.URL {
+	b.mut.RLock()
+	defer b.mut.RUnlock()
+	return b.bound
+}
+
+// addressURI returns the address to announce for the listener: the bound
+// one, or the configured one unless that leaves picking the port to the
+// system. It's nil when there's nothing to announce.
+func (b *boundAddress) addressURI(uri *url.URL) *url.URL {
+	if bound := b.BoundURI(); bound != nil {
+		return bound
+	}
+	if uri.Port() == "0" {
+		return nil
+	}
+	return uri
+}
+
 type onAddressesChangedNotifier struct {
 	callbacks []func(ListenerAddresses)
 }
@@ -235,9 +287,30 @@ type dialTarget struct {
 	priority int
 	uri      *url.URL
 	deviceID protocol.DeviceID
+	proxy    proxySetting
+	// Whether the address is of the preferred address family, if any.
+	preferred bool
 }
 
 func (t dialTarget) Dial(ctx context.Context) (internalConn, error) {
 	l.Debugln("dialing", t.deviceID, t.uri, "prio", t.priority)
+	if t.proxy.explicit {
+		ctx = dialer.WithProxy(ctx, t.proxy.url)
+	}
 	return t.dialer.Dial(ctx, t.deviceID, t.uri)
 }
+
+// proxySetting is the proxy to use for a dial target. When not explicit,
+// the proxy from the environment applies. Otherwise a nil url means a
+// direct connection.
+type proxySetting struct {
+	explicit bool
+	url      *url.URL
+}
+
+// allows returns whether the transport can be dialed through the proxy.
+// QUIC can't be, so it's not used at all while an explicit proxy applies,
+// rather than bypassing the proxy.
+func (p proxySetting) allows(transport string) bool {
+	return p.url == nil || transport != "quic"
+}
@@ -31,6 +31,7 @@ func init() {
 type tcpListener struct {
 	svcutil.ServiceWithError
 	onAddressesChangedNotifier
+	boundAddress
 
 	uri     *url.URL
 	cfg     config.Wrapper
@@ -60,11 +61,17 @@ func (t *tcpListener) serve(ctx context.Context) error {
 		l.Infoln("Listen (BEP/tcp):", err)
 		return err
 	}
+	// The port differs when the system picked it.
+	boundAddr := *tcaddr
+	boundAddr.Port = listener.Addr().(*net.TCPAddr).Port
+	tcaddr = &boundAddr
+	t.setBound(t.uri, tcaddr.Port)
 	t.notifyAddressesChanged(t)
 	registry.Register(t.uri.Scheme, tcaddr)
 
 	defer listener.Close()
 	defer t.clearAddresses(t)
+	defer t.clearBound()
 	defer registry.Unregister(t.uri.Scheme, tcaddr)
 
 	l.Infof("TCP listener (%v) starting", listener.Addr())
@@ -146,12 +153,16 @@ func (t *tcpListener) URI() *url.URL {
 }
 
 func (t *tcpListener) WANAddresses() []*url.URL {
-	uris := []*url.URL{t.uri}
+	addrURI := t.addressURI(t.uri)
+	if addrURI == nil {
+		return nil
+	}
+	uris := []*url.URL{addrURI}
 	t.mut.RLock()
 	if t.mapping != nil {
 		addrs := t.mapping.ExternalAddresses()
 		for _, addr := range addrs {
-			uri := *t.uri
+			uri := *addrURI
 			// Does net.JoinHostPort internally
 			uri.Host = addr.String()
 			uris = append(uris, &uri)
@@ -159,7 +170,7 @@ func (t *tcpListener) WANAddresses() []*url.URL {
 			// For every address with a specified IP, add one without an IP,
 			// just in case the specified IP is still internal (router behind DMZ).
 			if len(addr.IP) != 0 && !addr.IP.IsUnspecified() {
-				uri = *t.uri
+				uri = *addrURI
 				addr.IP = nil
 				uri.Host = addr.String()
 				uris = append(uris, &uri)
@@ -171,7 +182,7 @@ func (t *tcpListener) WANAddresses() []*url.URL {
 	// If we support ReusePort, add an unspecified zero port address, which will be resolved by the discovery server
 	// in hopes that TCP punch through works.
 	if dialer.SupportsReusePort {
-		uri := *t.uri
+		uri := *addrURI
 		uri.Host = "0.0.0.0:0"
 		uris = append([]*url.URL{&uri}, uris...)
 	}
@@ -179,8 +190,12 @@ func (t *tcpListener) WANAddresses() []*url.URL {
 }
 
 func (t *tcpListener) LANAddresses() []*url.URL {
-	addrs := []*url.URL{t.uri}
-	addrs = append(addrs, getURLsForAllAdaptersIfUnspecified(t.uri.Scheme, t.uri)...)
+	addrURI := t.addressURI(t.uri)
+	if addrURI == nil {
+		return nil
+	}
+	addrs := []*url.URL{addrURI}
+	addrs = append(addrs, getURLsForAllAdaptersIfUnspecified(addrURI.Scheme, addrURI)...)
 	return addrs
 }
 
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/osutil"
 )
 
@@ -35,6 +36,13 @@ func fixupPort(uri *url.URL, defaultPort int) *url.URL {
 	return &copyURI
 }
 
+// withPort returns a copy of the URI with the port replaced.
+func withPort(uri *url.URL, port int) *url.URL {
+	copyURI := *uri
+	copyURI.Host = net.JoinHostPort(uri.Hostname(), strconv.Itoa(port))
+	return &copyURI
+}
+
 func getURLsForAllAdaptersIfUnspecified(network string, uri *url.URL) []*url.URL {
 	ip, port, err := resolve(network, uri.Host)
 	// Failed to resolve
@@ -117,3 +125,51 @@ func isV4Local(ip net.IP) bool {
 	}
 	return false
 }
+
+// hostIP returns the IP address in the host part of an address, or nil for
+// host names.
+func hostIP(host string) net.IP {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return net.ParseIP(strings.Trim(host, "[]"))
+}
+
+// addrHostIP returns the IP address in the host part of an address URL, or
+// nil for host names.
+func addrHostIP(addr string) net.IP {
+	uri, err := url.Parse(addr)
+	if err != nil {
+		return nil
+	}
+	return hostIP(uri.Host)
+}
+
+// restrictFamily returns the URI restricted to the allowed address family,
+// or false if it's an address of a family that isn't allowed. Unspecified
+// addresses are turned into the unspecified address of the allowed family.
+func restrictFamily(family config.AddressFamily, uri *url.URL) (*url.URL, bool) {
+	ip := hostIP(uri.Host)
+	if ip != nil && !ip.IsUnspecified() && !family.Allows(ip) {
+		return nil, false
+	}
+
+	switch schemeTransport(uri.Scheme) {
+	case "tcp", "quic":
+	default:
+		// Relays are reached over whatever the relay client uses.
+		return uri, true
+	}
+	copyURI := *uri
+	copyURI.Scheme = family.Network(uri.Scheme)
+	if ip != nil && ip.IsUnspecified() {
+		_, port, _ := net.SplitHostPort(uri.Host)
+		switch {
+		case ip.To4() != nil && !family.UsesIPv4():
+			copyURI.Host = net.JoinHostPort("::", port)
+		case ip.To4() == nil && !family.UsesIPv6():
+			copyURI.Host = net.JoinHostPort("0.0.0.0", port)
+		}
+	}
+	return &copyURI, true
+}
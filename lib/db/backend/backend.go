@@ -9,7 +9,7 @@ package backend
 import (
 	"errors"
 	"os"
-	"strings"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -128,16 +128,53 @@ const (
 	TuningAuto Tuning = iota
 	TuningSmall
 	TuningLarge
+	TuningLowMemory
 )
 
-func Open(path string, tuning Tuning) (Backend, error) {
-	if err := maybeCopyDatabase(path, strings.Replace(path, locations.LevelDBDir, locations.BadgerDir, 1), OpenLevelDBAuto, OpenBadger); err != nil {
-		return nil, err
+type Type int
+
+const (
+	// N.b. these constants must match those in lib/config.DatabaseBackend!
+	TypeLevelDB Type = iota
+	TypeBadger
+)
+
+// Open opens the database of the given type. The path is that of the
+// LevelDB database, other types are stored next to it. An existing database
+// of another type is converted.
+func Open(path string, typ Type, tuning Tuning) (Backend, error) {
+	badgerPath := BadgerPath(path)
+	if typ == TypeBadger {
+		if err := maybeCopyDatabase(badgerPath, path, OpenBadger, OpenLevelDBAuto); err != nil {
+			return nil, err
+		}
+		return OpenBadger(badgerPath)
 	}
 
+	if err := maybeCopyDatabase(path, badgerPath, OpenLevelDBAuto, OpenBadger); err != nil {
+		return nil, err
+	}
 	return OpenLevelDB(path, tuning)
 }
 
+// Detect returns the type of the existing database for the given LevelDB
+// path, preferring LevelDB if there is none or both exist.
+func Detect(path string) Type {
+	if _, err := os.Lstat(path); err == nil {
+		return TypeLevelDB
+	}
+	if _, err := os.Lstat(BadgerPath(path)); err == nil {
+		return TypeBadger
+	}
+	return TypeLevelDB
+}
+
+// BadgerPath returns the location of the Badger database corresponding to
+// the given LevelDB path.
+func BadgerPath(path string) string {
+	return filepath.Join(filepath.Dir(path), locations.BadgerDir)
+}
+
 func OpenMemory() Backend {
 	return OpenLevelDBMemory()
 }
@@ -230,23 +267,26 @@ func maybeCopyDatabase(toPath, fromPath string, toOpen, fromOpen opener) error {
 	if err != nil {
 		return err
 	}
-	defer fromDB.Close()
 
 	toDB, err := toOpen(toPath)
 	if err != nil {
+		fromDB.Close()
 		// That's odd, but it will be handled & reported in the usual path
 		// so we can ignore it here.
 		return err
 	}
-	defer toDB.Close()
 
 	l.Infoln("Copying database for format conversion...")
-	if err := copyBackend(toDB, fromDB); err != nil {
+	err = copyBackend(toDB, fromDB)
+	toDB.Close()
+	fromDB.Close()
+	if err != nil {
+		// Don't leave a partial copy around to be used next time.
+		_ = os.RemoveAll(toPath)
 		return err
 	}
 
 	// Move the old database out of the way to mark it as migrated.
-	fromDB.Close()
 	_ = os.Rename(fromPath, fromPath+".migrated."+time.Now().Format("20060102150405"))
 	return nil
 }
@@ -269,7 +309,7 @@ func copyBackend(to, from Backend) error {
 			return err
 		}
 	}
-	if srcIt.Error() != nil {
+	if err := srcIt.Error(); err != nil {
 		return err
 	}
 	srcIt.Release()
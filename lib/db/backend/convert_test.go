@@ -0,0 +1,52 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package backend
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/locations"
+)
+
+func TestOpenConvert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syncthing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, locations.LevelDBDir)
+
+	db, err := Open(path, TypeLevelDB, TuningAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	for _, typ := range []Type{TypeBadger, TypeLevelDB} {
+		db, err := Open(path, typ, TuningAuto)
+		if err != nil {
+			t.Fatal(err)
+		}
+		val, err := db.Get([]byte("key"))
+		db.Close()
+		if err != nil {
+			t.Fatalf("type %d: %v", typ, err)
+		}
+		if string(val) != "value" {
+			t.Errorf("type %d: got %q, expected %q", typ, val, "value")
+		}
+		if detected := Detect(path); detected != typ {
+			t.Errorf("detected type %d, expected %d", detected, typ)
+		}
+	}
+}
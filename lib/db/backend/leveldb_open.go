@@ -106,6 +106,15 @@ func optsFor(location string, tuning Tuning) *opt.Options {
 		defaultCompactionL0Trigger = 8 // number of l0 files
 	}
 
+	if tuning == TuningLowMemory {
+		// Keep the caches and write buffer small, at the price of more
+		// frequent compactions and reads from disk.
+		l.Infoln("Using low-memory database tuning")
+
+		defaultBlockCacheCapacity = 2 << MiB
+		defaultWriteBuffer = 4 << MiB
+	}
+
 	opts := &opt.Options{
 		BlockCacheCapacity:            debugEnvValue("BlockCacheCapacity", defaultBlockCacheCapacity),
 		BlockCacheEvictRemoved:        debugEnvValue("BlockCacheEvictRemoved", 0) != 0,
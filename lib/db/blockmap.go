@@ -28,11 +28,12 @@ func (f *BlockFinder) String() string {
 }
 
 // Iterate takes an iterator function which iterates over all matching blocks
-// for the given hash. The iterator function has to return either true (if
+// for the given hash, passing the folder, file name and offset of the block
+// within the file. The iterator function has to return either true (if
 // they are happy with the block) or false to continue iterating for whatever
 // reason. The iterator finally returns the result, whether or not a
 // satisfying block was eventually found.
-func (f *BlockFinder) Iterate(folders []string, hash []byte, iterFn func(string, string, int32) bool) bool {
+func (f *BlockFinder) Iterate(folders []string, hash []byte, iterFn func(string, string, int64) bool) bool {
 	t, err := f.db.newReadOnlyTransaction()
 	if err != nil {
 		return false
@@ -51,9 +52,15 @@ func (f *BlockFinder) Iterate(folders []string, hash []byte, iterFn func(string,
 		}
 
 		for iter.Next() && iter.Error() == nil {
+			val := iter.Value()
+			if len(val) < blockMapValueLength {
+				// Entry from before block offsets were recorded, to be
+				// replaced by the database migration.
+				continue
+			}
 			file := string(f.db.keyer.NameFromBlockMapKey(iter.Key()))
-			index := int32(binary.BigEndian.Uint32(iter.Value()))
-			if iterFn(folder, osutil.NativeFilename(file), index) {
+			offset := int64(binary.BigEndian.Uint64(val[4:]))
+			if iterFn(folder, osutil.NativeFilename(file), offset) {
 				iter.Release()
 				return true
 			}
@@ -62,3 +69,12 @@ func (f *BlockFinder) Iterate(folders []string, hash []byte, iterFn func(string,
 	}
 	return false
 }
+
+// The block map value is the index of the block within the file, followed by
+// the offset of the block. Older versions only read the index.
+const blockMapValueLength = 4 + 8
+
+func putBlockMapValue(buf []byte, index int, offset int64) {
+	binary.BigEndian.PutUint32(buf, uint32(index))
+	binary.BigEndian.PutUint64(buf[4:], uint64(offset))
+}
@@ -7,7 +7,6 @@
 package db
 
 import (
-	"encoding/binary"
 	"testing"
 
 	"github.com/syncthing/syncthing/lib/protocol"
@@ -58,12 +57,12 @@ func addToBlockMap(db *Lowlevel, folder []byte, fs []protocol.FileInfo) error {
 	defer t.close()
 
 	var keyBuf []byte
-	blockBuf := make([]byte, 4)
+	blockBuf := make([]byte, blockMapValueLength)
 	for _, f := range fs {
 		if !f.IsDirectory() && !f.IsDeleted() && !f.IsInvalid() {
 			name := []byte(f.Name)
 			for i, block := range f.Blocks {
-				binary.BigEndian.PutUint32(blockBuf, uint32(i))
+				putBlockMapValue(blockBuf, i, block.Offset)
 				keyBuf, err = t.keyer.GenerateBlockMapKey(keyBuf, folder, block.Hash, name)
 				if err != nil {
 					return err
@@ -118,21 +117,21 @@ func TestBlockMapAddUpdateWipe(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	f.Iterate(folders, f1.Blocks[0].Hash, func(folder, file string, index int32) bool {
-		if folder != "folder1" || file != "f1" || index != 0 {
+	f.Iterate(folders, f1.Blocks[0].Hash, func(folder, file string, offset int64) bool {
+		if folder != "folder1" || file != "f1" || offset != 0 {
 			t.Fatal("Mismatch")
 		}
 		return true
 	})
 
-	f.Iterate(folders, f2.Blocks[0].Hash, func(folder, file string, index int32) bool {
-		if folder != "folder1" || file != "f2" || index != 0 {
+	f.Iterate(folders, f2.Blocks[0].Hash, func(folder, file string, offset int64) bool {
+		if folder != "folder1" || file != "f2" || offset != 0 {
 			t.Fatal("Mismatch")
 		}
 		return true
 	})
 
-	f.Iterate(folders, f3.Blocks[0].Hash, func(folder, file string, index int32) bool {
+	f.Iterate(folders, f3.Blocks[0].Hash, func(folder, file string, offset int64) bool {
 		t.Fatal("Unexpected block")
 		return true
 	})
@@ -148,18 +147,18 @@ func TestBlockMapAddUpdateWipe(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	f.Iterate(folders, f1.Blocks[0].Hash, func(folder, file string, index int32) bool {
+	f.Iterate(folders, f1.Blocks[0].Hash, func(folder, file string, offset int64) bool {
 		t.Fatal("Unexpected block")
 		return false
 	})
 
-	f.Iterate(folders, f2.Blocks[0].Hash, func(folder, file string, index int32) bool {
+	f.Iterate(folders, f2.Blocks[0].Hash, func(folder, file string, offset int64) bool {
 		t.Fatal("Unexpected block")
 		return false
 	})
 
-	f.Iterate(folders, f3.Blocks[0].Hash, func(folder, file string, index int32) bool {
-		if folder != "folder1" || file != "f3" || index != 0 {
+	f.Iterate(folders, f3.Blocks[0].Hash, func(folder, file string, offset int64) bool {
+		if folder != "folder1" || file != "f3" || offset != 0 {
 			t.Fatal("Mismatch")
 		}
 		return true
@@ -205,15 +204,15 @@ func TestBlockFinderLookup(t *testing.T) {
 	}
 
 	counter := 0
-	f.Iterate(folders, f1.Blocks[0].Hash, func(folder, file string, index int32) bool {
+	f.Iterate(folders, f1.Blocks[0].Hash, func(folder, file string, offset int64) bool {
 		counter++
 		switch counter {
 		case 1:
-			if folder != "folder1" || file != "f1" || index != 0 {
+			if folder != "folder1" || file != "f1" || offset != 0 {
 				t.Fatal("Mismatch")
 			}
 		case 2:
-			if folder != "folder2" || file != "f1" || index != 0 {
+			if folder != "folder2" || file != "f1" || offset != 0 {
 				t.Fatal("Mismatch")
 			}
 		default:
@@ -237,11 +236,11 @@ func TestBlockFinderLookup(t *testing.T) {
 	}
 
 	counter = 0
-	f.Iterate(folders, f1.Blocks[0].Hash, func(folder, file string, index int32) bool {
+	f.Iterate(folders, f1.Blocks[0].Hash, func(folder, file string, offset int64) bool {
 		counter++
 		switch counter {
 		case 1:
-			if folder != "folder2" || file != "f1" || index != 0 {
+			if folder != "folder2" || file != "f1" || offset != 0 {
 				t.Fatal("Mismatch")
 			}
 		default:
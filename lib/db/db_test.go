@@ -530,6 +530,58 @@ func TestCheckGlobals(t *testing.T) {
 	}
 }
 
+func TestFileSetCheck(t *testing.T) {
+	db := newLowlevelMemory(t)
+	defer db.Close()
+
+	fs := newFileSet(t, "test", fs.NewFilesystem(fs.FilesystemTypeFake, ""), db)
+
+	fs.Update(protocol.LocalDeviceID, []protocol.FileInfo{
+		{Name: "foo", Type: protocol.FileInfoTypeFile, Version: protocol.Vector{}.Update(myID), Sequence: 1},
+	})
+	fs.Update(remoteDevice0, []protocol.FileInfo{
+		{Name: "bar", Type: protocol.FileInfoTypeFile, Version: protocol.Vector{}.Update(remoteDevice0.Short())},
+	})
+
+	size, err := fs.DatabaseSize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size == 0 {
+		t.Error("Expected non-zero database size")
+	}
+
+	if res, err := fs.Check(); err != nil {
+		t.Fatal(err)
+	} else if res.Repaired() != 0 {
+		t.Errorf("Expected nothing to repair, got %+v", res)
+	}
+
+	// Remove the need entry of the remote file
+	nk, err := db.keyer.GenerateNeedFileKey(nil, []byte(fs.folder), []byte("bar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(nk); err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := fs.Check(); err != nil {
+		t.Fatal(err)
+	} else if res != (CheckResult{Need: 1}) {
+		t.Errorf("Expected one repaired need entry, got %+v", res)
+	}
+	if _, err := db.Get(nk); err != nil {
+		t.Error("Expected need entry to be restored, got", err)
+	}
+
+	snap := fs.Snapshot()
+	defer snap.Release()
+	if c := snap.NeedSize(protocol.LocalDeviceID); c.Files != 1 {
+		t.Error("Expected 1 needed file, got", c.Files)
+	}
+}
+
 func TestUpdateTo10(t *testing.T) {
 	ldb, err := openJSONS("./testdata/v1.4.0-updateTo10.json")
 	if err != nil {
@@ -203,7 +203,7 @@ func (db *Lowlevel) updateLocalFiles(folder []byte, fs []protocol.FileInfo, meta
 	defer t.close()
 
 	var dk, gk, keyBuf []byte
-	blockBuf := make([]byte, 4)
+	blockBuf := make([]byte, blockMapValueLength)
 	for _, f := range fs {
 		name := []byte(f.Name)
 		dk, err = db.keyer.GenerateDeviceFileKey(dk, folder, protocol.LocalDeviceID[:], name)
@@ -285,7 +285,7 @@ func (db *Lowlevel) updateLocalFiles(folder []byte, fs []protocol.FileInfo, meta
 
 		if len(f.Blocks) != 0 && !f.IsInvalid() && f.Size > 0 {
 			for i, block := range f.Blocks {
-				binary.BigEndian.PutUint32(blockBuf, uint32(i))
+				putBlockMapValue(blockBuf, i, block.Offset)
 				keyBuf, err = db.keyer.GenerateBlockMapKey(keyBuf, folder, block.Hash, name)
 				if err != nil {
 					return err
@@ -379,6 +379,70 @@ func (db *Lowlevel) dropFolder(folder []byte) error {
 	return t.Commit()
 }
 
+// folderSize returns the total size of the keys and values of the entries
+// belonging to the folder. Block lists and version vectors are shared
+// between folders and not included.
+func (db *Lowlevel) folderSize(t readOnlyTransaction, folder []byte) (int64, error) {
+	dk, err := db.keyer.GenerateDeviceFileKey(nil, folder, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	sk, err := db.keyer.GenerateSequenceKey(nil, folder, 0)
+	if err != nil {
+		return 0, err
+	}
+	gk, err := db.keyer.GenerateGlobalVersionKey(nil, folder, nil)
+	if err != nil {
+		return 0, err
+	}
+	nk, err := db.keyer.GenerateNeedFileKey(nil, folder, nil)
+	if err != nil {
+		return 0, err
+	}
+	bk, err := db.keyer.GenerateBlockMapKey(nil, folder, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	blk, err := db.keyer.GenerateBlockListMapKey(nil, folder, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	mk, err := db.keyer.GenerateMtimesKey(nil, folder)
+	if err != nil {
+		return 0, err
+	}
+	fk, err := db.keyer.GenerateFolderMetaKey(nil, folder)
+	if err != nil {
+		return 0, err
+	}
+	prefixes := [][]byte{
+		dk.WithoutNameAndDevice(),
+		sk.WithoutSequence(),
+		gk.WithoutName(),
+		nk.WithoutName(),
+		bk.WithoutHashAndName(),
+		blk.WithoutHashAndName(),
+		mk,
+		fk,
+	}
+
+	var size int64
+	for _, prefix := range prefixes {
+		it, err := t.NewPrefixIterator(prefix)
+		if err != nil {
+			return 0, err
+		}
+		for it.Next() {
+			size += int64(len(it.Key()) + len(it.Value()))
+		}
+		it.Release()
+		if err := it.Error(); err != nil {
+			return 0, err
+		}
+	}
+	return size, nil
+}
+
 func (db *Lowlevel) dropDeviceFolder(device, folder []byte, meta *metadataTracker) error {
 	db.gcMut.RLock()
 	defer db.gcMut.RUnlock()
@@ -441,6 +505,90 @@ func (db *Lowlevel) dropDeviceFolder(device, folder []byte, meta *metadataTracke
 	return t.Commit()
 }
 
+// forgetDeleted removes the files deleted before the cutoff, on which all
+// devices announcing them agree, from the index of all devices, and returns
+// how many there were.
+func (db *Lowlevel) forgetDeleted(folder []byte, cutoff time.Time, meta *metadataTracker) (int, error) {
+	db.gcMut.RLock()
+	defer db.gcMut.RUnlock()
+
+	t, err := db.newReadWriteTransaction(meta.CommitHook(folder))
+	if err != nil {
+		return 0, err
+	}
+	defer t.close()
+
+	var names [][]byte
+	err = t.withTombstones(folder, func(f protocol.FileIntf, agreed bool) bool {
+		if agreed && f.ModTime().Before(cutoff) {
+			names = append(names, []byte(f.FileName()))
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// All devices announcing the file have the same deleted version, which
+	// nobody needs, so the global entry goes away with the device ones. It
+	// is done in one go as the transaction doesn't see its own changes.
+	var dk, gk, keyBuf []byte
+	for _, name := range names {
+		gk, err = db.keyer.GenerateGlobalVersionKey(gk, folder, name)
+		if err != nil {
+			return 0, err
+		}
+		vl, err := t.getGlobalVersionsByKey(gk)
+		if err != nil {
+			return 0, err
+		}
+		removedGlobal := false
+		for _, device := range vl.RawVersions[0].Devices {
+			dk, err = db.keyer.GenerateDeviceFileKey(dk, folder, device, name)
+			if err != nil {
+				return 0, err
+			}
+			f, ok, err := t.getFileTrunc(dk, true)
+			if err != nil {
+				return 0, err
+			} else if !ok {
+				continue
+			}
+			deviceID, err := protocol.DeviceIDFromBytes(device)
+			if err != nil {
+				return 0, err
+			}
+
+			l.Debugf("forgetting deleted; folder=%q device=%v %v", folder, deviceID, f)
+			if !removedGlobal {
+				meta.removeFile(protocol.GlobalDeviceID, f)
+				removedGlobal = true
+			}
+			meta.removeFile(deviceID, f)
+			if deviceID == protocol.LocalDeviceID {
+				keyBuf, err = db.keyer.GenerateSequenceKey(keyBuf, folder, f.SequenceNo())
+				if err != nil {
+					return 0, err
+				}
+				if err := t.Delete(keyBuf); err != nil {
+					return 0, err
+				}
+			}
+			if err := t.Delete(dk); err != nil {
+				return 0, err
+			}
+		}
+		if err := t.Delete(gk); err != nil {
+			return 0, err
+		}
+		if err := t.Checkpoint(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(names), t.Commit()
+}
+
 func (db *Lowlevel) checkGlobals(folder []byte) (int, error) {
 	t, err := db.newReadWriteTransaction()
 	if err != nil {
@@ -846,28 +994,59 @@ func (db *Lowlevel) getMetaAndCheck(folder string) (*metadataTracker, error) {
 	db.gcMut.RLock()
 	defer db.gcMut.RUnlock()
 
-	fixed, err := db.checkLocalNeed([]byte(folder))
+	meta, _, err := db.checkFolderGCLocked(folder)
+	return meta, err
+}
+
+// CheckResult holds the number of inconsistent database entries of a folder
+// that were found and repaired by a check.
+type CheckResult struct {
+	Need     int `json:"need"`
+	Global   int `json:"global"`
+	Sequence int `json:"sequence"`
+}
+
+// Repaired returns the total number of repaired entries.
+func (r CheckResult) Repaired() int {
+	return r.Need + r.Global + r.Sequence
+}
+
+// checkFolderGCLocked checks and repairs the need, global and sequence
+// entries of the folder, and recalculates its metadata.
+func (db *Lowlevel) checkFolderGCLocked(folder string) (*metadataTracker, CheckResult, error) {
+	var res CheckResult
+	var err error
+
+	res.Need, err = db.checkLocalNeed([]byte(folder))
 	if err != nil {
-		return nil, fmt.Errorf("checking local need: %w", err)
+		return nil, res, fmt.Errorf("checking local need: %w", err)
 	}
-	if fixed != 0 {
-		l.Infof("Repaired %d local need entries for folder %v in database", fixed, folder)
+	if res.Need != 0 {
+		l.Infof("Repaired %d local need entries for folder %v in database", res.Need, folder)
 	}
 
-	meta, err := db.recalcMeta(folder)
+	res.Global, err = db.checkGlobals([]byte(folder))
 	if err != nil {
-		return nil, fmt.Errorf("recalculating metadata: %w", err)
+		return nil, res, fmt.Errorf("checking globals: %w", err)
+	}
+	if res.Global != 0 {
+		l.Infof("Repaired %d global entries for folder %v in database", res.Global, folder)
 	}
 
-	fixed, err = db.repairSequenceGCLocked(folder, meta)
+	meta, err := db.countMeta(folder)
 	if err != nil {
-		return nil, fmt.Errorf("repairing sequences: %w", err)
+		return nil, res, fmt.Errorf("recalculating metadata: %w", err)
 	}
-	if fixed != 0 {
-		l.Infof("Repaired %d sequence entries for folder %v in database", fixed, folder)
+
+	res.Sequence, err = db.repairSequenceGCLocked(folder, meta)
+	if err != nil {
+		return nil, res, fmt.Errorf("repairing sequences: %w", err)
+	}
+	if res.Sequence != 0 {
+		l.Infof("Repaired %d sequence entries for folder %v in database", res.Sequence, folder)
 	}
 
-	return meta, nil
+	return meta, res, nil
 }
 
 func (db *Lowlevel) loadMetadataTracker(folder string) (*metadataTracker, error) {
@@ -898,16 +1077,21 @@ func (db *Lowlevel) loadMetadataTracker(folder string) (*metadataTracker, error)
 	return meta, nil
 }
 
-func (db *Lowlevel) recalcMeta(folderStr string) (*metadataTracker, error) {
-	folder := []byte(folderStr)
-
-	meta := newMetadataTracker(db.keyer, db.evLogger)
-	if fixed, err := db.checkGlobals(folder); err != nil {
+func (db *Lowlevel) recalcMeta(folder string) (*metadataTracker, error) {
+	if fixed, err := db.checkGlobals([]byte(folder)); err != nil {
 		return nil, fmt.Errorf("checking globals: %w", err)
 	} else if fixed > 0 {
-		l.Infof("Repaired %d global entries for folder %v in database", fixed, folderStr)
+		l.Infof("Repaired %d global entries for folder %v in database", fixed, folder)
 	}
+	return db.countMeta(folder)
+}
 
+// countMeta calculates the metadata of the folder from its entries in the
+// database, and stores it.
+func (db *Lowlevel) countMeta(folderStr string) (*metadataTracker, error) {
+	folder := []byte(folderStr)
+
+	meta := newMetadataTracker(db.keyer, db.evLogger)
 	t, err := db.newReadWriteTransaction(meta.CommitHook(folder))
 	if err != nil {
 		return nil, err
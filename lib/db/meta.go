@@ -129,6 +129,18 @@ func (m *metadataTracker) fromDB(db *Lowlevel, folder []byte) error {
 	return nil
 }
 
+// replace makes the tracker use the counts of the other one, which must not
+// be used afterwards.
+func (m *metadataTracker) replace(other *metadataTracker) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	other.mut.RLock()
+	defer other.mut.RUnlock()
+
+	m.countsMap = other.countsMap
+	m.dirty = other.dirty
+}
+
 // countsPtr returns a pointer to the corresponding Counts struct, if
 // necessary allocating one in the process
 func (m *metadataTracker) countsPtr(dev protocol.DeviceID, flag uint32) *Counts {
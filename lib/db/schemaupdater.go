@@ -21,7 +21,7 @@ import (
 // do not put restrictions on downgrades (e.g. for repairs after a bugfix).
 const (
 	dbVersion             = 14
-	dbMigrationVersion    = 15
+	dbMigrationVersion    = 16
 	dbMinSyncthingVersion = "v1.9.0"
 )
 
@@ -104,6 +104,7 @@ func (db *schemaUpdater) updateSchema() error {
 		{13, 13, "v1.7.0", db.updateSchemaTo13},
 		{14, 14, "v1.9.0", db.updateSchemaTo14},
 		{14, 15, "v1.9.0", db.migration15},
+		{14, 16, "v1.9.0", db.migration16},
 	}
 
 	for _, m := range migrations {
@@ -782,6 +783,51 @@ func (db *schemaUpdater) migration15(_ int) error {
 	return nil
 }
 
+func (db *schemaUpdater) migration16(_ int) error {
+	// Rewrites the block map to record block offsets, as blocks of a file
+	// are no longer necessarily all of the same size.
+
+	t, err := db.newReadWriteTransaction()
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	var key []byte
+	buf := make([]byte, blockMapValueLength)
+	for _, folderStr := range db.ListFolders() {
+		folder := []byte(folderStr)
+		var putErr error
+		err := t.withHave(folder, protocol.LocalDeviceID[:], nil, false, func(fi protocol.FileIntf) bool {
+			f := fi.(protocol.FileInfo)
+			if len(f.Blocks) == 0 || f.IsInvalid() || f.Size == 0 {
+				return true
+			}
+
+			name := []byte(f.Name)
+			for i, block := range f.Blocks {
+				putBlockMapValue(buf, i, block.Offset)
+				key, putErr = db.keyer.GenerateBlockMapKey(key, folder, block.Hash, name)
+				if putErr != nil {
+					return false
+				}
+				if putErr = t.Put(key, buf); putErr != nil {
+					return false
+				}
+			}
+			putErr = t.Checkpoint()
+			return putErr == nil
+		})
+		if putErr != nil {
+			return putErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return t.Commit()
+}
+
 func (db *schemaUpdater) rewriteGlobals(t readWriteTransaction) error {
 	it, err := t.NewPrefixIterator([]byte{KeyTypeGlobal})
 	if err != nil {
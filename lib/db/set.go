@@ -14,6 +14,7 @@ package db
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/fs"
@@ -144,6 +145,25 @@ func (s *FileSet) Update(device protocol.DeviceID, fs []protocol.FileInfo) {
 	}
 }
 
+// ForgetDeleted removes the files deleted before the cutoff, on which all
+// devices announcing them agree, from the index of all devices. It returns
+// how many files were forgotten.
+func (s *FileSet) ForgetDeleted(cutoff time.Time) int {
+	opStr := fmt.Sprintf("%s ForgetDeleted(%v)", s.folder, cutoff)
+	l.Debugf(opStr)
+
+	s.updateMutex.Lock()
+	defer s.updateMutex.Unlock()
+
+	n, err := s.db.forgetDeleted([]byte(s.folder), cutoff, s.meta)
+	if backend.IsClosed(err) {
+		return 0
+	} else if err != nil {
+		fatalError(err, opStr, s.db)
+	}
+	return n
+}
+
 type Snapshot struct {
 	folder     string
 	t          readOnlyTransaction
@@ -349,6 +369,36 @@ func (s *Snapshot) GlobalSize() Counts {
 	return s.meta.Counts(protocol.GlobalDeviceID, 0)
 }
 
+// TombstoneCounts are the numbers of deleted files in the global index.
+type TombstoneCounts struct {
+	Total int `json:"total"`
+	// Deleted before the cutoff.
+	Expired int `json:"expired"`
+	// Deleted before the cutoff, and all devices announcing the file agree
+	// on the deletion, so that it may be forgotten.
+	Forgettable int `json:"forgettable"`
+}
+
+func (s *Snapshot) Tombstones(cutoff time.Time) TombstoneCounts {
+	opStr := fmt.Sprintf("%s Tombstones(%v)", s.folder, cutoff)
+	l.Debugf(opStr)
+	var counts TombstoneCounts
+	err := s.t.withTombstones([]byte(s.folder), func(f protocol.FileIntf, agreed bool) bool {
+		counts.Total++
+		if f.ModTime().Before(cutoff) {
+			counts.Expired++
+			if agreed {
+				counts.Forgettable++
+			}
+		}
+		return true
+	})
+	if err != nil && !backend.IsClosed(err) {
+		s.fatalError(err, opStr)
+	}
+	return counts
+}
+
 func (s *Snapshot) NeedSize(device protocol.DeviceID) Counts {
 	return s.meta.Counts(device, needFlag)
 }
@@ -412,6 +462,31 @@ func (s *FileSet) RepairSequence() (int, error) {
 	return s.db.repairSequenceGCLocked(s.folder, s.meta)
 }
 
+// Check verifies the consistency of the folder's entries in the database,
+// repairing any problems found, and recalculates the folder metadata.
+func (s *FileSet) Check() (CheckResult, error) {
+	s.updateAndGCMutexLock() // Ensures consistent locking order
+	defer s.updateMutex.Unlock()
+	defer s.db.gcMut.RUnlock()
+	meta, res, err := s.db.checkFolderGCLocked(s.folder)
+	if err != nil {
+		return res, err
+	}
+	s.meta.replace(meta)
+	return res, nil
+}
+
+// DatabaseSize returns the approximate number of bytes taken up by the
+// folder in the database.
+func (s *FileSet) DatabaseSize() (int64, error) {
+	t, err := s.db.newReadOnlyTransaction()
+	if err != nil {
+		return 0, err
+	}
+	defer t.close()
+	return s.db.folderSize(t, []byte(s.folder))
+}
+
 func (s *FileSet) updateAndGCMutexLock() {
 	s.updateMutex.Lock()
 	s.db.gcMut.RLock()
@@ -488,6 +488,38 @@ func TestNeedWithInvalid(t *testing.T) {
 	checkNeed(t, s, protocol.LocalDeviceID, expectedNeed)
 }
 
+func TestBlockFinderOffsets(t *testing.T) {
+	ldb := newLowlevelMemory(t)
+	defer ldb.Close()
+
+	folder := "test"
+	s := newFileSet(t, folder, fs.NewFilesystem(fs.FilesystemTypeBasic, "."), ldb)
+	f := db.NewBlockFinder(ldb)
+
+	// Blocks of differing sizes, as when split at content defined
+	// boundaries.
+	blocks := genBlocks(5)
+	var offset int64
+	for i := range blocks {
+		blocks[i].Offset = offset
+		offset += int64(blocks[i].Size)
+	}
+	s.Update(protocol.LocalDeviceID, []protocol.FileInfo{
+		{Name: "a", Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}, Blocks: blocks, Size: 10},
+	})
+
+	for i, expected := range []int64{0, 0, 1, 3, 6} {
+		var offset int64 = -1
+		f.Iterate([]string{folder}, blocks[i].Hash, func(_, file string, o int64) bool {
+			offset = o
+			return true
+		})
+		if offset != expected {
+			t.Errorf("block %d: offset %d != expected %d", i, offset, expected)
+		}
+	}
+}
+
 func TestUpdateToInvalid(t *testing.T) {
 	ldb := newLowlevelMemory(t)
 	defer ldb.Close()
@@ -530,7 +562,7 @@ func TestUpdateToInvalid(t *testing.T) {
 		t.Errorf("Have incorrect after invalidation;\n A: %v !=\n E: %v", have, localHave)
 	}
 
-	f.Iterate([]string{folder}, oldBlockHash, func(folder, file string, index int32) bool {
+	f.Iterate([]string{folder}, oldBlockHash, func(folder, file string, offset int64) bool {
 		if file == localHave[1].Name {
 			t.Errorf("Found unexpected block in blockmap for invalidated file")
 			return true
@@ -538,7 +570,7 @@ func TestUpdateToInvalid(t *testing.T) {
 		return false
 	})
 
-	if !f.Iterate([]string{folder}, localHave[4].Blocks[0].Hash, func(folder, file string, index int32) bool {
+	if !f.Iterate([]string{folder}, localHave[4].Blocks[0].Hash, func(folder, file string, offset int64) bool {
 		return file == localHave[4].Name
 	}) {
 		t.Errorf("First block of un-invalidated file is missing from blockmap")
@@ -830,7 +862,7 @@ func BenchmarkUpdateOneFile(b *testing.B) {
 		protocol.FileInfo{Name: "zajksdhaskjdh/askjdhaskjdashkajshd/kasjdhaskjdhaskdjhaskdjash/dkjashdaksjdhaskdjahskdjh", Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}, Blocks: genBlocks(8)},
 	}
 
-	be, err := backend.Open("testdata/benchmarkupdate.db", backend.TuningAuto)
+	be, err := backend.Open("testdata/benchmarkupdate.db", backend.TypeLevelDB, backend.TuningAuto)
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -1784,6 +1816,82 @@ func TestConcurrentIndexID(t *testing.T) {
 	}
 }
 
+func TestForgetDeleted(t *testing.T) {
+	ldb := newLowlevelMemory(t)
+	defer ldb.Close()
+
+	s := newFileSet(t, "test", fs.NewFilesystem(fs.FilesystemTypeFake, ""), ldb)
+
+	old := time.Now().Add(-48 * time.Hour).Unix()
+	now := time.Now().Unix()
+	v1 := protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}
+	v2 := protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1001}}}
+	local := fileList{
+		protocol.FileInfo{Name: "live", Version: v1, Blocks: genBlocks(1)},
+		protocol.FileInfo{Name: "old", Version: v2, Deleted: true, ModifiedS: old},
+		protocol.FileInfo{Name: "recent", Version: v2, Deleted: true, ModifiedS: now},
+		protocol.FileInfo{Name: "behind", Version: v2, Deleted: true, ModifiedS: old},
+		protocol.FileInfo{Name: "unannounced", Version: v2, Deleted: true, ModifiedS: old},
+	}
+	remote := fileList{
+		local[0],
+		local[1],
+		local[2],
+		protocol.FileInfo{Name: "behind", Version: v1, Blocks: genBlocks(2)},
+	}
+	s.Update(protocol.LocalDeviceID, local)
+	s.Update(remoteDevice0, remote)
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+	snap := s.Snapshot()
+	counts := snap.Tombstones(cutoff)
+	snap.Release()
+	if exp := (db.TombstoneCounts{Total: 4, Expired: 3, Forgettable: 2}); counts != exp {
+		t.Errorf("Expected %+v, got %+v", exp, counts)
+	}
+
+	if n := s.ForgetDeleted(cutoff); n != 2 {
+		t.Errorf("Expected two forgotten files, got %d", n)
+	}
+
+	snap = s.Snapshot()
+	for _, name := range []string{"old", "unannounced"} {
+		if _, ok := snap.GetGlobal(name); ok {
+			t.Errorf("%s should be forgotten", name)
+		}
+		for _, dev := range []protocol.DeviceID{protocol.LocalDeviceID, remoteDevice0} {
+			if _, ok := snap.Get(dev, name); ok {
+				t.Errorf("%s should be forgotten for %v", name, dev)
+			}
+		}
+	}
+	for _, name := range []string{"live", "recent", "behind"} {
+		if _, ok := snap.Get(protocol.LocalDeviceID, name); !ok {
+			t.Errorf("%s should be kept", name)
+		}
+	}
+	if counts := snap.Tombstones(cutoff); counts.Total != 2 || counts.Forgettable != 0 {
+		t.Errorf("Unexpected counts after forgetting %+v", counts)
+	}
+	localCounts, globalCounts := snap.LocalSize(), snap.GlobalSize()
+	snap.Release()
+
+	// The index and the metadata are consistent.
+	res, err := s.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Repaired() != 0 {
+		t.Errorf("Unexpected repairs %+v", res)
+	}
+	if c := localSize(s); !c.Equal(localCounts) {
+		t.Errorf("Local counts %v changed to %v", localCounts, c)
+	}
+	if c := globalSize(s); !c.Equal(globalCounts) {
+		t.Errorf("Global counts %v changed to %v", globalCounts, c)
+	}
+}
+
 func replace(fs *db.FileSet, device protocol.DeviceID, files []protocol.FileInfo) {
 	fs.Drop(device)
 	fs.Update(device, files)
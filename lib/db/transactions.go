@@ -362,6 +362,45 @@ func (t *readOnlyTransaction) withGlobal(folder, prefix []byte, truncate bool, f
 	return dbi.Error()
 }
 
+// withTombstones iterates over the deleted files in the global index, with
+// whether all devices announcing the file agree on the deletion, i.e. have
+// the same deleted version and none of them has it invalid.
+func (t *readOnlyTransaction) withTombstones(folder []byte, fn func(f protocol.FileIntf, agreed bool) bool) error {
+	key, err := t.keyer.GenerateGlobalVersionKey(nil, folder, nil)
+	if err != nil {
+		return err
+	}
+	dbi, err := t.NewPrefixIterator(key)
+	if err != nil {
+		return err
+	}
+	defer dbi.Release()
+
+	var dk []byte
+	for dbi.Next() {
+		var vl VersionList
+		if err := vl.Unmarshal(dbi.Value()); err != nil {
+			return err
+		}
+		global, ok := vl.GetGlobal()
+		if !ok || !global.Deleted || global.IsInvalid() {
+			continue
+		}
+
+		var f protocol.FileIntf
+		dk, f, err = t.getGlobalFromFileVersion(dk, folder, t.keyer.NameFromGlobalVersionKey(dbi.Key()), true, global)
+		if err != nil {
+			return err
+		}
+
+		agreed := len(vl.RawVersions) == 1 && len(global.InvalidDevices) == 0
+		if !fn(f, agreed) {
+			return nil
+		}
+	}
+	return dbi.Error()
+}
+
 func (t *readOnlyTransaction) withBlocksHash(folder, hash []byte, iterator Iterator) error {
 	key, err := t.keyer.GenerateBlockListMapKey(nil, folder, hash, nil)
 	if err != nil {
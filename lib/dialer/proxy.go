@@ -0,0 +1,129 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package dialer
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+type proxyContextKey struct{}
+
+type proxySetting struct {
+	url *url.URL
+}
+
+// WithProxy returns a context that makes the dial functions in this package
+// use the given proxy URL instead of the proxy configured in the
+// environment. A nil URL means connections are made directly, regardless
+// of the environment. Supported schemes are socks, socks5 and http.
+func WithProxy(ctx context.Context, u *url.URL) context.Context {
+	return context.WithValue(ctx, proxyContextKey{}, proxySetting{u})
+}
+
+// proxyDialer returns the dialer to use for the given context, which is
+// either the proxy set on the context or the one from the environment.
+func proxyDialer(ctx context.Context) (proxy.ContextDialer, error) {
+	setting, ok := ctx.Value(proxyContextKey{}).(proxySetting)
+	if !ok {
+		dialer, ok := proxy.FromEnvironment().(proxy.ContextDialer)
+		if !ok {
+			return nil, errUnexpectedInterfaceType
+		}
+		return dialer, nil
+	}
+	if setting.url == nil {
+		return proxy.Direct, nil
+	}
+	return NewProxyDialer(setting.url)
+}
+
+// NewProxyDialer returns a dialer connecting through the proxy at the given
+// URL.
+func NewProxyDialer(u *url.URL) (proxy.ContextDialer, error) {
+	switch u.Scheme {
+	case "http":
+		return &httpConnectDialer{proxy: u}, nil
+	default:
+		dialer, err := proxy.FromURL(u, proxy.Direct)
+		if err != nil {
+			return nil, err
+		}
+		cd, ok := dialer.(proxy.ContextDialer)
+		if !ok {
+			return nil, errUnexpectedInterfaceType
+		}
+		return cd, nil
+	}
+}
+
+// httpConnectDialer establishes connections through an HTTP proxy using the
+// CONNECT method.
+type httpConnectDialer struct {
+	proxy *url.URL
+}
+
+func (d *httpConnectDialer) Dial(network, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := proxy.Direct.DialContext(ctx, "tcp", d.proxy.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure a stalled proxy doesn't outlive the context.
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user := d.proxy.User; user != nil {
+		pass, _ := user.Password()
+		creds := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + pass))
+		req.Header.Set("Proxy-Authorization", "Basic "+creds)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// The response to a successful CONNECT has no body; the connection is
+	// handed to the caller as is.
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT to %s: %s", addr, resp.Status)
+	}
+	if br.Buffered() > 0 {
+		// The proxy is not supposed to send anything before we do.
+		conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT to %s: unexpected data after response", addr)
+	}
+
+	return conn, nil
+}
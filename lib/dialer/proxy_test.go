@@ -0,0 +1,108 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package dialer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestHTTPConnectProxy(t *testing.T) {
+	// A target that answers a ping with a pong, and a minimal CONNECT proxy
+	// in front of it.
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+	go func() {
+		conn, err := target.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != "ping" {
+			return
+		}
+		conn.Write([]byte("pong"))
+	}()
+
+	proxyLst, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxyLst.Close()
+	gotAuth := make(chan string, 1)
+	go func() {
+		conn, err := proxyLst.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil || req.Method != http.MethodConnect {
+			return
+		}
+		gotAuth <- req.Header.Get("Proxy-Authorization")
+		upstream, err := net.Dial("tcp", req.Host)
+		if err != nil {
+			conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+			return
+		}
+		defer upstream.Close()
+		conn.Write([]byte("HTTP/1.1 200 OK\r\n\r\n"))
+		go io.Copy(upstream, conn)
+		io.Copy(conn, upstream)
+	}()
+
+	u, _ := url.Parse("http://user:pass@" + proxyLst.Addr().String())
+	ctx, cancel := context.WithTimeout(WithProxy(context.Background(), u), 5*time.Second)
+	defer cancel()
+
+	conn, err := DialContext(ctx, "tcp", target.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if conn.RemoteAddr().String() != target.Addr().String() {
+		t.Errorf("remote address %v should be the target, not the proxy", conn.RemoteAddr())
+	}
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := ioutil.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bs) != "pong" {
+		t.Errorf("unexpected data %q", bs)
+	}
+	if auth := <-gotAuth; auth != "Basic dXNlcjpwYXNz" {
+		t.Errorf("unexpected proxy authorization %q", auth)
+	}
+}
+
+func TestNewProxyDialerSchemes(t *testing.T) {
+	for _, raw := range []string{"socks5://127.0.0.1:1080", "socks://127.0.0.1:1080", "http://127.0.0.1:3128"} {
+		u, _ := url.Parse(raw)
+		if _, err := NewProxyDialer(u); err != nil {
+			t.Errorf("%s: unexpected error: %v", raw, err)
+		}
+	}
+	u, _ := url.Parse("ftp://127.0.0.1:21")
+	if _, err := NewProxyDialer(u); err == nil {
+		t.Error("unexpected success for unsupported scheme")
+	}
+}
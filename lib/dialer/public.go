@@ -8,6 +8,7 @@ package dialer
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -47,6 +48,25 @@ func SetTCPOptions(conn net.Conn) error {
 	}
 }
 
+// SetTCPTimeouts sets how soon a TCP connection notices that the other side
+// is gone: the interval of keepalives while idle, and how long sent data may
+// remain unacknowledged (on Linux only). TLS connections are unwrapped.
+func SetTCPTimeouts(conn net.Conn, keepAlivePeriod, userTimeout time.Duration) error {
+	switch conn := conn.(type) {
+	case dialerConn:
+		return SetTCPTimeouts(conn.Conn, keepAlivePeriod, userTimeout)
+	case *tls.Conn:
+		return SetTCPTimeouts(conn.NetConn(), keepAlivePeriod, userTimeout)
+	case *net.TCPConn:
+		if err := conn.SetKeepAlivePeriod(keepAlivePeriod); err != nil {
+			return err
+		}
+		return setUserTimeout(conn, userTimeout)
+	default:
+		return fmt.Errorf("unknown connection type %T", conn)
+	}
+}
+
 func SetTrafficClass(conn net.Conn, class int) error {
 	switch conn := conn.(type) {
 	case dialerConn:
@@ -65,9 +85,9 @@ func SetTrafficClass(conn net.Conn, class int) error {
 }
 
 func dialContextWithFallback(ctx context.Context, fallback proxy.ContextDialer, network, addr string) (net.Conn, error) {
-	dialer, ok := proxy.FromEnvironment().(proxy.ContextDialer)
-	if !ok {
-		return nil, errUnexpectedInterfaceType
+	dialer, err := proxyDialer(ctx)
+	if err != nil {
+		return nil, err
 	}
 	if dialer == proxy.Direct {
 		conn, err := fallback.DialContext(ctx, network, addr)
@@ -94,7 +114,8 @@ func dialContextWithFallback(ctx context.Context, fallback proxy.ContextDialer,
 	return dialTwicePreferFirst(ctx, proxyDialFudgeAddress, fallback.DialContext, "proxy", "fallback", network, addr)
 }
 
-// DialContext dials via context and/or directly, depending on how it is configured.
+// DialContext dials via proxy and/or directly, depending on how it is
+// configured in the environment or on the context (see WithProxy).
 // If dialing via proxy and allowing fallback, dialing for both happens simultaneously
 // and the proxy connection is returned if successful.
 func DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
@@ -106,7 +127,7 @@ func DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
 // fails. It also in parallel dials without reusing the port, just in case reusing the port affects routing decisions badly.
 func DialContextReusePort(ctx context.Context, network, addr string) (net.Conn, error) {
 	// If proxy is configured, there is no point trying to reuse listen addresses.
-	if proxy.FromEnvironment() != proxy.Direct {
+	if dialer, err := proxyDialer(ctx); err != nil || dialer != proxy.Direct {
 		return DialContext(ctx, network, addr)
 	}
 
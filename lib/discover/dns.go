@@ -0,0 +1,114 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package discover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// dnsClient looks up device addresses in a DNS zone, under the name made up
+// of the device ID without dashes. TXT records there hold addresses, such
+// as "tcp://192.0.2.42:22000", separated by spaces. SRV records for
+// _syncthing._tcp and _syncthing._udp give TCP and QUIC addresses
+// respectively. Nothing is announced; the zone is maintained by the user.
+type dnsClient struct {
+	zone     string
+	resolver dnsResolver
+	errorHolder
+}
+
+type dnsResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+var errNoDNSRecords = errors.New("no address records")
+
+func NewDNS(zone string) (Finder, error) {
+	zone = strings.Trim(zone, ".")
+	if zone == "" {
+		return nil, errors.New("empty zone")
+	}
+	return &dnsClient{
+		zone:     zone,
+		resolver: net.DefaultResolver,
+	}, nil
+}
+
+func (c *dnsClient) Lookup(ctx context.Context, device protocol.DeviceID) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	name := dnsDeviceName(device) + "." + c.zone + "."
+	var addresses []string
+	seen := make(map[string]struct{})
+	add := func(addr string) {
+		if _, ok := seen[addr]; !ok {
+			seen[addr] = struct{}{}
+			addresses = append(addresses, addr)
+		}
+	}
+
+	txts, txtErr := c.resolver.LookupTXT(ctx, name)
+	for _, txt := range txts {
+		for _, addr := range strings.Fields(txt) {
+			add(addr)
+		}
+	}
+
+	var srvErr error
+	for _, scheme := range []struct{ proto, scheme string }{{"tcp", "tcp"}, {"udp", "quic"}} {
+		_, srvs, err := c.resolver.LookupSRV(ctx, "syncthing", scheme.proto, name)
+		if err != nil {
+			srvErr = err
+			continue
+		}
+		for _, srv := range srvs {
+			host := strings.TrimSuffix(srv.Target, ".")
+			add(fmt.Sprintf("%s://%s", scheme.scheme, net.JoinHostPort(host, strconv.Itoa(int(srv.Port)))))
+		}
+	}
+
+	if len(addresses) == 0 {
+		l.Debugln("dnsClient.Lookup", name, txtErr, srvErr)
+		if ctx.Err() != nil {
+			// Not a lack of records, but no answer.
+			c.setError(ctx.Err())
+			return nil, ctx.Err()
+		}
+		c.setError(nil)
+		return nil, errNoDNSRecords
+	}
+	c.setError(nil)
+	return addresses, nil
+}
+
+func (c *dnsClient) String() string {
+	return "dns@" + c.zone
+}
+
+func (c *dnsClient) Cache() map[protocol.DeviceID]CacheEntry {
+	// The dnsClient doesn't do caching
+	return nil
+}
+
+// dnsDeviceName returns the label for the device ID, which is its string
+// form without the dashes, in lower case.
+func dnsDeviceName(device protocol.DeviceID) string {
+	return strings.ToLower(strings.Replace(device.String(), "-", "", -1))
+}
+
+func dnsDiscoveryIdentity(zone string) string {
+	return "DNS discovery in zone " + zone
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package discover
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+type fakeResolver struct {
+	txt map[string][]string
+	srv map[string][]*net.SRV
+}
+
+var errNXDomain = errors.New("no such host")
+
+func (r *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if txts, ok := r.txt[name]; ok {
+		return txts, nil
+	}
+	return nil, errNXDomain
+}
+
+func (r *fakeResolver) LookupSRV(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if srvs, ok := r.srv["_"+service+"._"+proto+"."+name]; ok {
+		return "", srvs, nil
+	}
+	return "", nil, errNXDomain
+}
+
+func TestDNSLookup(t *testing.T) {
+	dev1, _ := protocol.DeviceIDFromString("AIR6LPZ-7K4PTTV-UXQSMUU-CPQ5YWH-OEDFIIQ-JUG777G-2YQXXR5-YD6AWQR")
+	dev2 := protocol.LocalDeviceID
+
+	name := "air6lpz7k4pttvuxqsmuucpq5ywhoedfiiqjug777g2yqxxr5yd6awqr.example.com."
+	c := &dnsClient{
+		zone: "example.com",
+		resolver: &fakeResolver{
+			txt: map[string][]string{
+				name: {"tcp://192.0.2.42:22000 relay://192.0.2.43:22067", "tcp://192.0.2.42:22000"},
+			},
+			srv: map[string][]*net.SRV{
+				"_syncthing._udp." + name: {{Target: "host.example.com.", Port: 22000}},
+			},
+		},
+	}
+
+	addrs, err := c.Lookup(context.Background(), dev1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"tcp://192.0.2.42:22000", "relay://192.0.2.43:22067", "quic://host.example.com:22000"}
+	if !reflect.DeepEqual(addrs, expected) {
+		t.Errorf("Got %v, expected %v", addrs, expected)
+	}
+
+	if _, err := c.Lookup(context.Background(), dev2); err != errNoDNSRecords {
+		t.Errorf("Expected no records for another device, got %v", err)
+	}
+}
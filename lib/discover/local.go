@@ -223,12 +223,31 @@ func (c *localClient) registerDevice(src net.Addr, device Announce) bool {
 	ce, existsAlready := c.Get(device.ID)
 	isNewDevice := !existsAlready || time.Since(ce.when) > CacheLifeTime || ce.instanceID != device.InstanceID
 
-	// Any empty or unspecified addresses should be set to the source address
-	// of the announcement. We also skip any addresses we can't parse.
-
 	l.Debugln("discover: Registering addresses for", device.ID)
+	validAddresses := resolveAddresses(src, device.Addresses)
+
+	c.Set(device.ID, CacheEntry{
+		Addresses:  validAddresses,
+		when:       time.Now(),
+		found:      true,
+		instanceID: device.InstanceID,
+	})
+
+	if isNewDevice {
+		c.evLogger.Log(events.DeviceDiscovered, map[string]interface{}{
+			"device": device.ID.String(),
+			"addrs":  validAddresses,
+		})
+	}
+
+	return isNewDevice
+}
+
+// resolveAddresses sets any empty or unspecified addresses to the source
+// address of the announcement, and skips any addresses we can't parse.
+func resolveAddresses(src net.Addr, addrs []string) []string {
 	var validAddresses []string
-	for _, addr := range device.Addresses {
+	for _, addr := range addrs {
 		u, err := url.Parse(addr)
 		if err != nil {
 			continue
@@ -268,20 +287,5 @@ func (c *localClient) registerDevice(src net.Addr, device Announce) bool {
 			l.Debugf("discover: Accepted address %s verbatim", addr)
 		}
 	}
-
-	c.Set(device.ID, CacheEntry{
-		Addresses:  validAddresses,
-		when:       time.Now(),
-		found:      true,
-		instanceID: device.InstanceID,
-	})
-
-	if isNewDevice {
-		c.evLogger.Log(events.DeviceDiscovered, map[string]interface{}{
-			"device": device.ID.String(),
-			"addrs":  validAddresses,
-		})
-	}
-
-	return isNewDevice
+	return validAddresses
 }
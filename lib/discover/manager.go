@@ -238,9 +238,20 @@ func (m *manager) CommitConfiguration(_, to config.Configuration) (handled bool)
 		}
 	}
 
+	for _, zone := range to.Options.DNSDiscoveryZones {
+		toIdentities[dnsDiscoveryIdentity(zone)] = struct{}{}
+	}
+
 	if to.Options.LocalAnnEnabled {
-		toIdentities[ipv4Identity(to.Options.LocalAnnPort)] = struct{}{}
-		toIdentities[ipv6Identity(to.Options.LocalAnnMCAddr)] = struct{}{}
+		if to.Options.AddressFamily.UsesIPv4() {
+			toIdentities[ipv4Identity(to.Options.LocalAnnPort)] = struct{}{}
+		}
+		if to.Options.AddressFamily.UsesIPv6() {
+			toIdentities[ipv6Identity(to.Options.LocalAnnMCAddr)] = struct{}{}
+		}
+		if to.Options.LocalAnnMDNSEnabled {
+			toIdentities[mdnsIdentity()] = struct{}{}
+		}
 	}
 
 	// Remove things that we're not expected to have.
@@ -271,10 +282,24 @@ func (m *manager) CommitConfiguration(_, to config.Configuration) (handled bool)
 		}
 	}
 
+	for _, zone := range to.Options.DNSDiscoveryZones {
+		identity := dnsDiscoveryIdentity(zone)
+		if _, ok := m.finders[identity]; ok {
+			continue
+		}
+		dd, err := NewDNS(zone)
+		if err != nil {
+			l.Warnln("DNS discovery:", err)
+			continue
+		}
+		// Cached like the global discovery servers.
+		m.addLocked(identity, dd, 5*time.Minute, time.Minute)
+	}
+
 	if to.Options.LocalAnnEnabled {
 		// v4 broadcasts
 		v4Identity := ipv4Identity(to.Options.LocalAnnPort)
-		if _, ok := m.finders[v4Identity]; !ok {
+		if _, ok := m.finders[v4Identity]; !ok && to.Options.AddressFamily.UsesIPv4() {
 			bcd, err := NewLocal(m.myID, fmt.Sprintf(":%d", to.Options.LocalAnnPort), m.addressLister, m.evLogger)
 			if err != nil {
 				l.Warnln("IPv4 local discovery:", err)
@@ -285,7 +310,7 @@ func (m *manager) CommitConfiguration(_, to config.Configuration) (handled bool)
 
 		// v6 multicasts
 		v6Identity := ipv6Identity(to.Options.LocalAnnMCAddr)
-		if _, ok := m.finders[v6Identity]; !ok {
+		if _, ok := m.finders[v6Identity]; !ok && to.Options.AddressFamily.UsesIPv6() {
 			mcd, err := NewLocal(m.myID, to.Options.LocalAnnMCAddr, m.addressLister, m.evLogger)
 			if err != nil {
 				l.Warnln("IPv6 local discovery:", err)
@@ -293,6 +318,13 @@ func (m *manager) CommitConfiguration(_, to config.Configuration) (handled bool)
 				m.addLocked(v6Identity, mcd, 0, 0)
 			}
 		}
+
+		// mDNS service discovery
+		if to.Options.LocalAnnMDNSEnabled {
+			if _, ok := m.finders[mdnsIdentity()]; !ok {
+				m.addLocked(mdnsIdentity(), NewMDNS(m.myID, m.addressLister, m.evLogger), 0, 0)
+			}
+		}
 	}
 
 	return true
@@ -0,0 +1,491 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package discover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+)
+
+// mdnsClient announces and finds devices using DNS service discovery over
+// multicast DNS, as done by Bonjour and Avahi. Each device is an instance of
+// the _syncthing._tcp service, named after its device ID without dashes.
+// The TXT record of the instance holds the device ID, the instance ID and
+// the addresses, the latter with the same meaning as in local discovery.
+// SRV and address records let third party tools resolve the instance.
+type mdnsClient struct {
+	myID     protocol.DeviceID
+	addrList AddressLister
+	evLogger events.Logger
+
+	instanceID int64
+	announce   chan struct{}
+
+	*cache
+	errorHolder
+}
+
+// mdnsConn is a socket joined to the mDNS group of one address family.
+type mdnsConn struct {
+	net.PacketConn
+	group *net.UDPAddr
+	// Implemented by both ipv4.PacketConn and ipv6.PacketConn.
+	multicast interface {
+		JoinGroup(ifi *net.Interface, group net.Addr) error
+		SetMulticastInterface(ifi *net.Interface) error
+	}
+}
+
+const (
+	mdnsPort    = 5353
+	mdnsService = "_syncthing._tcp.local."
+	mdnsTTL     = 120 // seconds
+	// The cache flush bit, set on the class of records that only we
+	// announce.
+	mdnsClassUnique = dnsmessage.ClassINET | 1<<15
+	// Answering queries at most this often.
+	mdnsMinAnnounceInterval = time.Second
+)
+
+var (
+	mdnsGroupV4 = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: mdnsPort}
+	mdnsGroupV6 = &net.UDPAddr{IP: net.ParseIP("ff02::fb"), Port: mdnsPort}
+
+	errNoMDNSSockets = errors.New("no mDNS sockets available")
+)
+
+func NewMDNS(id protocol.DeviceID, addrList AddressLister, evLogger events.Logger) FinderService {
+	return &mdnsClient{
+		myID:       id,
+		addrList:   addrList,
+		evLogger:   evLogger,
+		instanceID: rand.Int63(),
+		announce:   make(chan struct{}, 1),
+		cache:      newCache(),
+	}
+}
+
+// Serve opens the sockets, answers queries and registers the announcements
+// received on them until the context is cancelled or a socket fails.
+func (c *mdnsClient) Serve(ctx context.Context) error {
+	var conns []mdnsConn
+	for _, network := range []struct {
+		network string
+		group   *net.UDPAddr
+	}{{"udp4", mdnsGroupV4}, {"udp6", mdnsGroupV6}} {
+		conn, err := listenMDNS(network.network, network.group)
+		if err != nil {
+			l.Debugln("discover: mDNS on", network.network, err)
+			continue
+		}
+		defer conn.Close()
+		conns = append(conns, conn)
+	}
+	if len(conns) == 0 {
+		c.setError(errNoMDNSSockets)
+		return errNoMDNSSockets
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errs := make(chan error, len(conns))
+	for _, conn := range conns {
+		go func(conn mdnsConn) {
+			errs <- c.recv(ctx, conn)
+		}(conn)
+	}
+
+	if query, err := mdnsQuery(); err == nil {
+		c.multicast(conns, query)
+	}
+
+	ticker := time.NewTicker(BroadcastInterval)
+	defer ticker.Stop()
+	for {
+		if msg, ok := c.announcement(); ok {
+			c.multicast(conns, msg)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-c.announce:
+		case err := <-errs:
+			c.setError(err)
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// Don't flood the network when many queries come in.
+		select {
+		case <-time.After(mdnsMinAnnounceInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func listenMDNS(network string, group *net.UDPAddr) (mdnsConn, error) {
+	lc := net.ListenConfig{Control: reuseAddrControl}
+	conn, err := lc.ListenPacket(context.Background(), network, fmt.Sprintf(":%d", mdnsPort))
+	if err != nil {
+		return mdnsConn{}, err
+	}
+	mc := mdnsConn{PacketConn: conn, group: group}
+	if network == "udp4" {
+		mc.multicast = ipv4.NewPacketConn(conn)
+	} else {
+		mc.multicast = ipv6.NewPacketConn(conn)
+	}
+
+	intfs, err := net.Interfaces()
+	if err != nil {
+		conn.Close()
+		return mdnsConn{}, err
+	}
+	joined := 0
+	for _, intf := range multicastInterfaces(intfs) {
+		intf := intf
+		if err := mc.multicast.JoinGroup(&intf, group); err != nil {
+			l.Debugln("mDNS join", group, "on", intf.Name, "failed:", err)
+			continue
+		}
+		joined++
+	}
+	if joined == 0 {
+		conn.Close()
+		return mdnsConn{}, errors.New("no multicast interfaces available")
+	}
+	return mc, nil
+}
+
+func multicastInterfaces(intfs []net.Interface) []net.Interface {
+	var res []net.Interface
+	for _, intf := range intfs {
+		if intf.Flags&net.FlagUp != 0 && intf.Flags&net.FlagMulticast != 0 {
+			res = append(res, intf)
+		}
+	}
+	return res
+}
+
+// Lookup returns a list of addresses the device is available at.
+func (c *mdnsClient) Lookup(_ context.Context, device protocol.DeviceID) (addresses []string, err error) {
+	if cache, ok := c.Get(device); ok {
+		if time.Since(cache.when) < CacheLifeTime {
+			addresses = cache.Addresses
+		}
+	}
+	return
+}
+
+func (c *mdnsClient) String() string {
+	return "mDNS local"
+}
+
+// multicast sends the message to the mDNS group on all interfaces.
+func (c *mdnsClient) multicast(conns []mdnsConn, msg []byte) {
+	intfs, err := net.Interfaces()
+	if err != nil {
+		c.setError(err)
+		return
+	}
+	success := 0
+	for _, conn := range conns {
+		for _, intf := range multicastInterfaces(intfs) {
+			intf := intf
+			if err = conn.multicast.SetMulticastInterface(&intf); err != nil {
+				continue
+			}
+			if _, err = conn.WriteTo(msg, conn.group); err != nil {
+				l.Debugln("mDNS write to", conn.group, "on", intf.Name, "failed:", err)
+				continue
+			}
+			success++
+		}
+	}
+	if success == 0 {
+		c.setError(err)
+	} else {
+		c.setError(nil)
+	}
+}
+
+func (c *mdnsClient) recv(ctx context.Context, conn mdnsConn) error {
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	buf := make([]byte, 9000)
+	for {
+		n, src, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		c.handle(buf[:n], src)
+	}
+}
+
+// handle answers queries for our service and registers the devices in
+// responses.
+func (c *mdnsClient) handle(msg []byte, src net.Addr) {
+	var p dnsmessage.Parser
+	hdr, err := p.Start(msg)
+	if err != nil {
+		return
+	}
+
+	if !hdr.Response {
+		questions, err := p.AllQuestions()
+		if err != nil {
+			return
+		}
+		for _, q := range questions {
+			if strings.EqualFold(q.Name.String(), mdnsService) && (q.Type == dnsmessage.TypePTR || q.Type == dnsmessage.TypeALL) {
+				c.requestAnnouncement()
+				return
+			}
+		}
+		return
+	}
+
+	if err := p.SkipAllQuestions(); err != nil {
+		return
+	}
+	txts, err := mdnsTXTRecords(&p)
+	if err != nil {
+		l.Debugln("mDNS: parsing response from", src, err)
+		return
+	}
+
+	for _, txt := range txts {
+		dev, ok := parseMDNSTXT(txt)
+		if !ok || dev.ID == c.myID {
+			continue
+		}
+		l.Debugf("discover: Received mDNS announcement from %s for %s", src, dev.ID)
+		if c.registerDevice(src, dev) {
+			c.requestAnnouncement()
+		}
+	}
+}
+
+func (c *mdnsClient) requestAnnouncement() {
+	select {
+	case c.announce <- struct{}{}:
+	default:
+	}
+}
+
+// registerDevice caches the device's addresses, returning true if we
+// didn't know about the device before.
+func (c *mdnsClient) registerDevice(src net.Addr, device Announce) bool {
+	ce, existsAlready := c.Get(device.ID)
+	isNewDevice := !existsAlready || time.Since(ce.when) > CacheLifeTime || ce.instanceID != device.InstanceID
+
+	validAddresses := resolveAddresses(src, device.Addresses)
+	c.Set(device.ID, CacheEntry{
+		Addresses:  validAddresses,
+		when:       time.Now(),
+		found:      true,
+		instanceID: device.InstanceID,
+	})
+
+	if isNewDevice {
+		c.evLogger.Log(events.DeviceDiscovered, map[string]interface{}{
+			"device": device.ID.String(),
+			"addrs":  validAddresses,
+		})
+	}
+	return isNewDevice
+}
+
+// announcement returns the response announcing us, and whether there is
+// anything to announce.
+func (c *mdnsClient) announcement() ([]byte, bool) {
+	addrs := c.addrList.AllAddresses()
+	if len(addrs) == 0 {
+		return nil, false
+	}
+	var ips []net.IP
+	if ifAddrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range ifAddrs {
+			if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
+				ips = append(ips, ipnet.IP)
+			}
+		}
+	}
+	msg, err := mdnsAnnouncement(c.myID, c.instanceID, addrs, ips)
+	if err != nil {
+		l.Debugln("mDNS announcement:", err)
+		return nil, false
+	}
+	return msg, true
+}
+
+func mdnsQuery() ([]byte, error) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(mdnsService),
+		Type:  dnsmessage.TypePTR,
+		Class: dnsmessage.ClassINET,
+	}); err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// mdnsAnnouncement builds the response announcing the device. The SRV and
+// address records are only there for other tools, so they are left out
+// when we don't listen on TCP.
+func mdnsAnnouncement(id protocol.DeviceID, instanceID int64, addrs []string, ips []net.IP) ([]byte, error) {
+	label := dnsDeviceName(id)
+	instance, err := dnsmessage.NewName(label + "." + mdnsService)
+	if err != nil {
+		return nil, err
+	}
+	host, err := dnsmessage.NewName(label + ".local.")
+	if err != nil {
+		return nil, err
+	}
+
+	txt := []string{"id=" + id.String(), "instance=" + strconv.FormatInt(instanceID, 10)}
+	port := 0
+	for _, addr := range addrs {
+		txt = append(txt, "addr="+addr)
+		if u, err := url.Parse(addr); err == nil && port == 0 && strings.HasPrefix(u.Scheme, "tcp") {
+			port, _ = strconv.Atoi(u.Port())
+		}
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{Response: true, Authoritative: true})
+	b.EnableCompression()
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	hdr := func(name dnsmessage.Name, class dnsmessage.Class) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: name, Class: class, TTL: mdnsTTL}
+	}
+	if err := b.PTRResource(hdr(dnsmessage.MustNewName(mdnsService), dnsmessage.ClassINET), dnsmessage.PTRResource{PTR: instance}); err != nil {
+		return nil, err
+	}
+	if err := b.TXTResource(hdr(instance, mdnsClassUnique), dnsmessage.TXTResource{TXT: txt}); err != nil {
+		return nil, err
+	}
+	if port != 0 {
+		if err := b.SRVResource(hdr(instance, mdnsClassUnique), dnsmessage.SRVResource{Target: host, Port: uint16(port)}); err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			if ip4 := ip.To4(); ip4 != nil {
+				var a dnsmessage.AResource
+				copy(a.A[:], ip4)
+				err = b.AResource(hdr(host, mdnsClassUnique), a)
+			} else {
+				var aaaa dnsmessage.AAAAResource
+				copy(aaaa.AAAA[:], ip.To16())
+				err = b.AAAAResource(hdr(host, mdnsClassUnique), aaaa)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b.Finish()
+}
+
+// mdnsTXTRecords returns the TXT records among the answers and additional
+// records, skipping all others.
+func mdnsTXTRecords(p *dnsmessage.Parser) ([][]string, error) {
+	var txts [][]string
+	for {
+		hdr, err := p.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if hdr.Type == dnsmessage.TypeTXT {
+			r, err := p.TXTResource()
+			if err != nil {
+				return nil, err
+			}
+			txts = append(txts, r.TXT)
+		} else if err := p.SkipAnswer(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.SkipAllAuthorities(); err != nil {
+		return nil, err
+	}
+	for {
+		hdr, err := p.AdditionalHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if hdr.Type == dnsmessage.TypeTXT {
+			r, err := p.TXTResource()
+			if err != nil {
+				return nil, err
+			}
+			txts = append(txts, r.TXT)
+		} else if err := p.SkipAdditional(); err != nil {
+			return nil, err
+		}
+	}
+	return txts, nil
+}
+
+// parseMDNSTXT returns the announcement in the TXT record, if it is one of
+// ours.
+func parseMDNSTXT(txt []string) (Announce, bool) {
+	var ann Announce
+	var hasID bool
+	for _, kv := range txt {
+		switch {
+		case strings.HasPrefix(kv, "id="):
+			id, err := protocol.DeviceIDFromString(kv[len("id="):])
+			if err != nil {
+				return Announce{}, false
+			}
+			ann.ID = id
+			hasID = true
+		case strings.HasPrefix(kv, "instance="):
+			ann.InstanceID, _ = strconv.ParseInt(kv[len("instance="):], 10, 64)
+		case strings.HasPrefix(kv, "addr="):
+			ann.Addresses = append(ann.Addresses, kv[len("addr="):])
+		}
+	}
+	return ann, hasID
+}
+
+func mdnsIdentity() string {
+	return "mDNS local discovery"
+}
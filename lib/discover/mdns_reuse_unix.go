@@ -0,0 +1,32 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build !solaris,!windows
+
+package discover
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/syncthing/syncthing/lib/dialer"
+)
+
+// reuseAddrControl lets us share the mDNS port with the system's responder.
+func reuseAddrControl(_, _ string, c syscall.RawConn) error {
+	var opErr error
+	err := c.Control(func(fd uintptr) {
+		opErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1)
+		if opErr == nil && dialer.SupportsReusePort {
+			opErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return opErr
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package discover
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestMDNSAnnouncement(t *testing.T) {
+	dev1, _ := protocol.DeviceIDFromString("AIR6LPZ-7K4PTTV-UXQSMUU-CPQ5YWH-OEDFIIQ-JUG777G-2YQXXR5-YD6AWQR")
+	addrs := []string{"tcp://0.0.0.0:22000", "quic://0.0.0.0:22000"}
+
+	msg, err := mdnsAnnouncement(dev1, 1234567890, addrs, []net.IP{net.ParseIP("192.0.2.42"), net.ParseIP("2001:db8::42")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewMDNS(protocol.LocalDeviceID, &fakeAddressLister{}, events.NoopLogger).(*mdnsClient)
+	src := &net.UDPAddr{IP: net.ParseIP("192.0.2.42"), Port: mdnsPort}
+	c.handle(msg, src)
+
+	res, err := c.Lookup(context.Background(), dev1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"tcp://192.0.2.42:22000", "quic://192.0.2.42:22000"}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("Got %v, expected %v", res, expected)
+	}
+	if ce, _ := c.Get(dev1); ce.instanceID != 1234567890 {
+		t.Errorf("Got instance ID %d, expected 1234567890", ce.instanceID)
+	}
+
+	// Our own announcements are ignored.
+	c = NewMDNS(dev1, &fakeAddressLister{}, events.NoopLogger).(*mdnsClient)
+	c.handle(msg, src)
+	if res, _ := c.Lookup(context.Background(), dev1); len(res) != 0 {
+		t.Errorf("Got %v for ourselves, expected nothing", res)
+	}
+}
+
+func TestMDNSQuery(t *testing.T) {
+	msg, err := mdnsQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewMDNS(protocol.LocalDeviceID, &fakeAddressLister{}, events.NoopLogger).(*mdnsClient)
+	c.handle(msg, &net.UDPAddr{IP: net.ParseIP("192.0.2.42"), Port: mdnsPort})
+	select {
+	case <-c.announce:
+	default:
+		t.Error("Expected a query to trigger an announcement")
+	}
+}
@@ -54,6 +54,12 @@ const (
 	ListenAddressesChanged
 	LoginAttempt
 	Failure
+	ConfigChanged
+	UpgradeAvailable
+	ConflictCreated
+	// Not logged, but inserted into event streams where events were
+	// dropped because a subscriber fell behind.
+	EventsDropped
 
 	AllEvents = (1 << iota) - 1
 )
@@ -129,6 +135,14 @@ func (t EventType) String() string {
 		return "FolderWatchStateChanged"
 	case Failure:
 		return "Failure"
+	case ConfigChanged:
+		return "ConfigChanged"
+	case UpgradeAvailable:
+		return "UpgradeAvailable"
+	case ConflictCreated:
+		return "ConflictCreated"
+	case EventsDropped:
+		return "EventsDropped"
 	default:
 		return "Unknown"
 	}
@@ -214,6 +228,14 @@ func UnmarshalEventType(s string) EventType {
 		return FolderWatchStateChanged
 	case "Failure":
 		return Failure
+	case "ConfigChanged":
+		return ConfigChanged
+	case "UpgradeAvailable":
+		return UpgradeAvailable
+	case "ConflictCreated":
+		return ConflictCreated
+	case "EventsDropped":
+		return EventsDropped
 	default:
 		return 0
 	}
@@ -248,6 +270,19 @@ type Event struct {
 	Data     interface{} `json:"data"`
 }
 
+// Folder returns the ID of the folder the event is about, if any.
+func (e Event) Folder() string {
+	switch data := e.Data.(type) {
+	case map[string]interface{}:
+		folder, _ := data["folder"].(string)
+		return folder
+	case map[string]string:
+		return data["folder"]
+	default:
+		return ""
+	}
+}
+
 type Subscription interface {
 	C() <-chan Event
 	Poll(timeout time.Duration) (Event, error)
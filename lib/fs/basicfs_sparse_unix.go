@@ -0,0 +1,50 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build linux darwin freebsd
+
+package fs
+
+import (
+	"errors"
+	"io"
+	"syscall"
+)
+
+func holes(fd basicFile, size int64) (_ []Hole, err error) {
+	defer func() {
+		if _, serr := fd.Seek(0, io.SeekStart); err == nil {
+			err = serr
+		}
+	}()
+
+	var found []Hole
+	for offset := int64(0); offset < size; {
+		data, err := fd.Seek(offset, seekData)
+		if errors.Is(err, syscall.ENXIO) {
+			// No more data up to the end of the file.
+			data = size
+		} else if errors.Is(err, syscall.EINVAL) {
+			// The filesystem doesn't support finding holes.
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if data > size {
+			data = size
+		}
+		if data > offset {
+			found = append(found, Hole{Offset: offset, Length: data - offset})
+		}
+		if data == size {
+			break
+		}
+		if offset, err = fd.Seek(data, seekHole); err != nil {
+			return nil, err
+		}
+	}
+	return found, nil
+}
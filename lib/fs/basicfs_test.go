@@ -593,3 +593,86 @@ func TestWalkInfiniteRecursion(t *testing.T) {
 	defer os.RemoveAll(dir)
 	testWalkInfiniteRecursion(t, FilesystemTypeBasic, dir)
 }
+
+func TestXattr(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("test uses Linux attribute names")
+	}
+
+	fs, dir := setup(t)
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	xattrs := []Xattr{
+		{Name: "user.a", Value: []byte("a")},
+		{Name: "user.b", Value: []byte("b")},
+	}
+	if err := fs.SetXattr("file", xattrs); err == ErrXattrsNotSupported {
+		t.Skip("extended attributes not supported on the temporary dir")
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	got, err := fs.GetXattr("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Name != "user.a" || string(got[1].Value) != "b" {
+		t.Fatalf("Unexpected attributes %v", got)
+	}
+
+	// Attributes that aren't given are removed.
+	if err := fs.SetXattr("file", []Xattr{{Name: "user.b", Value: []byte("c")}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err = fs.GetXattr("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != "user.b" || string(got[0].Value) != "c" {
+		t.Fatalf("Unexpected attributes %v", got)
+	}
+}
+
+func TestHoles(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("test needs hole punching")
+	}
+
+	fs, dir := setup(t)
+	defer os.RemoveAll(dir)
+
+	const size = 1 << 20
+	fd, err := fs.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	if _, err := fd.Write(make([]byte, size)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PunchHole(fd, size/4, size/2); err == ErrSparseNotSupported {
+		t.Skip("hole punching not supported on the temporary dir")
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	holes, err := Holes(fd, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holes) == 0 {
+		t.Skip("finding holes not supported on the temporary dir")
+	}
+	// The filesystem may round the hole to its own block size.
+	if len(holes) != 1 || holes[0].Offset < size/4 || holes[0].Offset+holes[0].Length > 3*size/4 || holes[0].Length < size/4 {
+		t.Errorf("Unexpected holes %v", holes)
+	}
+	if info, err := fd.Stat(); err != nil {
+		t.Fatal(err)
+	} else if info.Size() != size {
+		t.Errorf("Punching a hole changed the size to %d", info.Size())
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build linux
+
+package fs
+
+import "golang.org/x/sys/unix"
+
+// The error returned for an extended attribute that doesn't exist.
+const errNoXattr = unix.ENODATA
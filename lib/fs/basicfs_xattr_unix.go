@@ -0,0 +1,138 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build linux darwin freebsd netbsd
+
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+func (f *BasicFilesystem) GetXattr(name string) ([]Xattr, error) {
+	path, err := f.rooted(name)
+	if err != nil {
+		return nil, err
+	}
+	names, err := listXattrs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	xattrs := make([]Xattr, 0, len(names))
+	buf := make([]byte, 1024)
+	for _, attr := range names {
+		value, err := getXattr(path, attr, &buf)
+		if errors.Is(err, errNoXattr) || errors.Is(err, unix.E2BIG) || errors.Is(err, unix.ERANGE) {
+			// Removed meanwhile, or too large to sync.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		xattrs = append(xattrs, Xattr{Name: attr, Value: value})
+	}
+	return xattrs, nil
+}
+
+func (f *BasicFilesystem) SetXattr(name string, xattrs []Xattr) error {
+	path, err := f.rooted(name)
+	if err != nil {
+		return err
+	}
+	current, err := f.GetXattr(name)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]struct{}, len(xattrs))
+	for _, xattr := range xattrs {
+		keep[xattr.Name] = struct{}{}
+	}
+	for _, xattr := range current {
+		if _, ok := keep[xattr.Name]; ok {
+			continue
+		}
+		if err := unix.Lremovexattr(path, xattr.Name); err != nil && !errors.Is(err, errNoXattr) {
+			return fmt.Errorf("removing extended attribute %s: %w", xattr.Name, err)
+		}
+	}
+
+	for _, xattr := range xattrs {
+		if !xattrSynced(xattr.Name) {
+			continue
+		}
+		if i := sort.Search(len(current), func(i int) bool { return current[i].Name >= xattr.Name }); i < len(current) && current[i].Name == xattr.Name && bytes.Equal(current[i].Value, xattr.Value) {
+			continue
+		}
+		if err := unix.Lsetxattr(path, xattr.Name, xattr.Value, 0); errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) {
+			return ErrXattrsNotSupported
+		} else if err != nil {
+			return fmt.Errorf("setting extended attribute %s: %w", xattr.Name, err)
+		}
+	}
+	return nil
+}
+
+// listXattrs returns the sorted names of the synced attributes of the item.
+// Filesystems without extended attributes have none.
+func listXattrs(path string) ([]string, error) {
+	buf := make([]byte, 1024)
+	for {
+		n, err := unix.Llistxattr(path, buf)
+		if errors.Is(err, unix.ERANGE) {
+			size, err := unix.Llistxattr(path, nil)
+			if err != nil {
+				return nil, err
+			}
+			buf = make([]byte, size+1024)
+			continue
+		} else if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		var names []string
+		for _, attr := range strings.Split(string(buf[:n]), "\x00") {
+			if attr != "" && xattrSynced(attr) {
+				names = append(names, attr)
+			}
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+}
+
+// getXattr returns the value of the attribute, reusing the buffer.
+func getXattr(path, attr string, buf *[]byte) ([]byte, error) {
+	for {
+		n, err := unix.Lgetxattr(path, attr, *buf)
+		if errors.Is(err, unix.ERANGE) && len(*buf) < maxXattrSize {
+			*buf = make([]byte, maxXattrSize)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), (*buf)[:n]...), nil
+	}
+}
+
+// xattrSynced returns whether the attribute is synced. On Linux only user
+// attributes and POSIX ACLs are, as the others are security labels
+// specific to the system or need special privileges.
+func xattrSynced(name string) bool {
+	if runtime.GOOS != "linux" {
+		return true
+	}
+	return strings.HasPrefix(name, "user.") || name == "system.posix_acl_access" || name == "system.posix_acl_default"
+}
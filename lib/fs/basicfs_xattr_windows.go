@@ -0,0 +1,147 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build windows
+
+package fs
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// On Windows the alternate data streams of an item are synced as its
+// extended attributes, named after the stream.
+
+var (
+	modkernel32          = windows.NewLazySystemDLL("kernel32.dll")
+	procFindFirstStreamW = modkernel32.NewProc("FindFirstStreamW")
+	procFindNextStreamW  = modkernel32.NewProc("FindNextStreamW")
+)
+
+// win32FindStreamData is WIN32_FIND_STREAM_DATA.
+type win32FindStreamData struct {
+	StreamSize int64
+	StreamName [windows.MAX_PATH + 36]uint16
+}
+
+func (f *BasicFilesystem) GetXattr(name string) ([]Xattr, error) {
+	path, err := f.rooted(name)
+	if err != nil {
+		return nil, err
+	}
+	streams, err := listStreams(path)
+	if err != nil {
+		return nil, err
+	}
+
+	xattrs := make([]Xattr, 0, len(streams))
+	for _, stream := range streams {
+		value, err := ioutil.ReadFile(path + ":" + stream)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if len(value) > maxXattrSize {
+			continue
+		}
+		xattrs = append(xattrs, Xattr{Name: stream, Value: value})
+	}
+	return xattrs, nil
+}
+
+func (f *BasicFilesystem) SetXattr(name string, xattrs []Xattr) error {
+	path, err := f.rooted(name)
+	if err != nil {
+		return err
+	}
+	current, err := f.GetXattr(name)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]struct{}, len(xattrs))
+	for _, xattr := range xattrs {
+		keep[xattr.Name] = struct{}{}
+	}
+	for _, xattr := range current {
+		if _, ok := keep[xattr.Name]; ok {
+			continue
+		}
+		if err := os.Remove(path + ":" + xattr.Name); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing alternate data stream %s: %w", xattr.Name, err)
+		}
+	}
+
+	for _, xattr := range xattrs {
+		if !validStreamName(xattr.Name) {
+			continue
+		}
+		if i := sort.Search(len(current), func(i int) bool { return current[i].Name >= xattr.Name }); i < len(current) && current[i].Name == xattr.Name && bytes.Equal(current[i].Value, xattr.Value) {
+			continue
+		}
+		if err := ioutil.WriteFile(path+":"+xattr.Name, xattr.Value, 0666); err != nil {
+			return fmt.Errorf("setting alternate data stream %s: %w", xattr.Name, err)
+		}
+	}
+	return nil
+}
+
+// listStreams returns the sorted names of the alternate data streams of the
+// item. Filesystems without them, like FAT, have none.
+func listStreams(path string) ([]string, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	var data win32FindStreamData
+	// 0 is FindStreamInfoStandard.
+	h, _, err := procFindFirstStreamW.Call(uintptr(unsafe.Pointer(p)), 0, uintptr(unsafe.Pointer(&data)), 0)
+	if windows.Handle(h) == windows.InvalidHandle {
+		switch err {
+		case windows.ERROR_HANDLE_EOF, windows.ERROR_INVALID_FUNCTION, windows.ERROR_NOT_SUPPORTED, windows.ERROR_INVALID_PARAMETER:
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer windows.FindClose(windows.Handle(h))
+
+	var streams []string
+	for {
+		// Names are like ":name:$DATA", the unnamed main stream being
+		// "::$DATA".
+		stream := windows.UTF16ToString(data.StreamName[:])
+		if strings.HasSuffix(stream, ":$DATA") {
+			stream = strings.TrimSuffix(strings.TrimPrefix(stream, ":"), ":$DATA")
+			if stream != "" {
+				streams = append(streams, stream)
+			}
+		}
+
+		r, _, err := procFindNextStreamW.Call(h, uintptr(unsafe.Pointer(&data)))
+		if r == 0 {
+			if err == windows.ERROR_HANDLE_EOF {
+				break
+			}
+			return nil, err
+		}
+	}
+	sort.Strings(streams)
+	return streams, nil
+}
+
+func validStreamName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, `:/\`) && !strings.ContainsRune(name, 0)
+}
+
@@ -335,6 +335,20 @@ func (f *caseFilesystem) Unhide(name string) error {
 	return f.Filesystem.Unhide(name)
 }
 
+func (f *caseFilesystem) GetXattr(name string) ([]Xattr, error) {
+	if err := f.checkCase(name); err != nil {
+		return nil, err
+	}
+	return f.Filesystem.GetXattr(name)
+}
+
+func (f *caseFilesystem) SetXattr(name string, xattrs []Xattr) error {
+	if err := f.checkCase(name); err != nil {
+		return err
+	}
+	return f.Filesystem.SetXattr(name, xattrs)
+}
+
 func (f *caseFilesystem) checkCase(name string) error {
 	var err error
 	if name, err = Canonicalize(name); err != nil {
@@ -0,0 +1,713 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/miscreant/miscreant.go"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// The encrypted filesystem stores file contents in blocks, each sealed
+// with its own random nonce and authenticated with its index, so that
+// blocks can be read and written in place.
+const (
+	encKeySize       = 32
+	encBlockSize     = 64 << 10
+	encNonceSize     = chacha20poly1305.NonceSizeX
+	encTagSize       = 16 // chacha20poly1305.Overhead
+	encBlockOverhead = encNonceSize + encTagSize
+	// Longer encrypted names aren't supported by common filesystems.
+	encMaxNameLen = 255
+)
+
+var (
+	errEncryptedNameTooLong = errors.New("name too long for an encrypted folder")
+	errEncryptedBlockShort  = errors.New("encrypted block truncated")
+	errEncryptedGlob        = errors.New("glob patterns are only supported in the last path component")
+)
+
+var encNameEncoding = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// Deriving keys is deliberately slow, while filesystems are created all
+// the time.
+var (
+	localEncryptionKeys    = make(map[[2]string]*[encKeySize]byte)
+	localEncryptionKeysMut = sync.NewMutex()
+)
+
+// LocalEncryptionKey returns the key for storing the given folder encrypted
+// on local disk.
+func LocalEncryptionKey(folderID, password string) *[encKeySize]byte {
+	localEncryptionKeysMut.Lock()
+	defer localEncryptionKeysMut.Unlock()
+	if key, ok := localEncryptionKeys[[2]string{folderID, password}]; ok {
+		return key
+	}
+	// Different from the key used for untrusted devices, so that the same
+	// password doesn't give the same key.
+	bs, err := scrypt.Key([]byte(password), []byte("syncthing-local"+folderID), 32768, 8, 1, encKeySize)
+	if err != nil {
+		panic("key derivation failure: " + err.Error())
+	}
+	var key [encKeySize]byte
+	copy(key[:], bs)
+	localEncryptionKeys[[2]string{folderID, password}] = &key
+	return &key
+}
+
+// The encryptedFilesystem stores names and contents encrypted in the
+// underlying filesystem, presenting them in plaintext. Each path component
+// is encrypted deterministically, so that paths can be looked up directly.
+// Files and directories that can't be decrypted are invisible.
+type encryptedFilesystem struct {
+	Filesystem
+	nameKey [encKeySize]byte
+	content cipher.AEAD
+}
+
+// NewEncryptedFilesystem returns a filesystem storing names and contents
+// encrypted with the key in the given filesystem.
+func NewEncryptedFilesystem(fs Filesystem, key *[encKeySize]byte) Filesystem {
+	return wrapFilesystem(fs, func(underlying Filesystem) Filesystem {
+		f := &encryptedFilesystem{Filesystem: underlying}
+		// Separate keys for names and contents.
+		subKey(key, "names", &f.nameKey)
+		var contentKey [encKeySize]byte
+		subKey(key, "contents", &contentKey)
+		aead, err := chacha20poly1305.NewX(contentKey[:])
+		if err != nil {
+			panic("cipher failure: " + err.Error())
+		}
+		f.content = aead
+		return f
+	})
+}
+
+func subKey(key *[encKeySize]byte, purpose string, dst *[encKeySize]byte) {
+	kdf := hkdf.New(sha256.New, key[:], nil, []byte("syncthing-local-"+purpose))
+	if _, err := io.ReadFull(kdf, dst[:]); err != nil {
+		panic("hkdf failure: " + err.Error())
+	}
+}
+
+func (f *encryptedFilesystem) encryptName(name string) (string, error) {
+	aead, err := miscreant.NewAEAD("AES-SIV", f.nameKey[:], 0)
+	if err != nil {
+		panic("cipher failure: " + err.Error())
+	}
+	enc := encNameEncoding.EncodeToString(aead.Seal(nil, nil, []byte(name), nil))
+	if len(enc) > encMaxNameLen {
+		return "", errEncryptedNameTooLong
+	}
+	return enc, nil
+}
+
+func (f *encryptedFilesystem) decryptName(name string) (string, error) {
+	bs, err := encNameEncoding.DecodeString(name)
+	if err != nil {
+		return "", err
+	}
+	aead, err := miscreant.NewAEAD("AES-SIV", f.nameKey[:], 0)
+	if err != nil {
+		panic("cipher failure: " + err.Error())
+	}
+	dec, err := aead.Open(nil, nil, bs, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(dec), nil
+}
+
+// encryptPath encrypts each component of the path, leaving the root as is.
+func (f *encryptedFilesystem) encryptPath(name string) (string, error) {
+	return f.mapPath("encrypt", name, f.encryptName)
+}
+
+func (f *encryptedFilesystem) decryptPath(name string) (string, error) {
+	return f.mapPath("decrypt", name, f.decryptName)
+}
+
+func (f *encryptedFilesystem) mapPath(op, name string, fn func(string) (string, error)) (string, error) {
+	parts := strings.Split(name, string(PathSeparator))
+	for i, part := range parts {
+		if part == "" || part == "." {
+			continue
+		}
+		mapped, err := fn(part)
+		if err != nil {
+			return "", &os.PathError{Op: op, Path: name, Err: err}
+		}
+		parts[i] = mapped
+	}
+	return strings.Join(parts, string(PathSeparator)), nil
+}
+
+func (f *encryptedFilesystem) Chmod(name string, mode FileMode) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.Chmod(enc, mode)
+}
+
+func (f *encryptedFilesystem) Lchown(name string, uid, gid int) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.Lchown(enc, uid, gid)
+}
+
+func (f *encryptedFilesystem) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.Chtimes(enc, atime, mtime)
+}
+
+func (f *encryptedFilesystem) Create(name string) (File, error) {
+	return f.OpenFile(name, OptReadWrite|OptCreate|OptTruncate, 0666)
+}
+
+func (f *encryptedFilesystem) CreateSymlink(target, name string) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	// The target is encrypted as a whole, as it needn't be a path in this
+	// filesystem.
+	aead, err := miscreant.NewAEAD("AES-SIV", f.nameKey[:], 0)
+	if err != nil {
+		panic("cipher failure: " + err.Error())
+	}
+	encTarget := encNameEncoding.EncodeToString(aead.Seal(nil, nil, []byte(target), nil))
+	return f.Filesystem.CreateSymlink(encTarget, enc)
+}
+
+func (f *encryptedFilesystem) ReadSymlink(name string) (string, error) {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return "", err
+	}
+	encTarget, err := f.Filesystem.ReadSymlink(enc)
+	if err != nil {
+		return "", err
+	}
+	target, err := f.decryptName(encTarget)
+	if err != nil {
+		return "", &os.PathError{Op: "decrypt", Path: name, Err: err}
+	}
+	return target, nil
+}
+
+func (f *encryptedFilesystem) DirNames(name string) ([]string, error) {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return nil, err
+	}
+	encNames, err := f.Filesystem.DirNames(enc)
+	if err != nil {
+		return nil, err
+	}
+	names := encNames[:0]
+	for _, encName := range encNames {
+		dec, err := f.decryptName(encName)
+		if err != nil {
+			l.Debugf("%v: skipping %s in %s: %v", f.URI(), encName, name, err)
+			continue
+		}
+		names = append(names, dec)
+	}
+	return names, nil
+}
+
+func (f *encryptedFilesystem) Lstat(name string) (FileInfo, error) {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Filesystem.Lstat(enc)
+	if err != nil {
+		return nil, err
+	}
+	return encryptedFileInfo{info, filepath.Base(name)}, nil
+}
+
+func (f *encryptedFilesystem) Stat(name string) (FileInfo, error) {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Filesystem.Stat(enc)
+	if err != nil {
+		return nil, err
+	}
+	return encryptedFileInfo{info, filepath.Base(name)}, nil
+}
+
+func (f *encryptedFilesystem) Mkdir(name string, perm FileMode) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.Mkdir(enc, perm)
+}
+
+func (f *encryptedFilesystem) MkdirAll(name string, perm FileMode) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.MkdirAll(enc, perm)
+}
+
+func (f *encryptedFilesystem) Open(name string) (File, error) {
+	return f.OpenFile(name, OptReadOnly, 0)
+}
+
+func (f *encryptedFilesystem) OpenFile(name string, flags int, mode FileMode) (File, error) {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return nil, err
+	}
+	appending := flags&OptAppend != 0
+	if flags&(OptWriteOnly|OptReadWrite) != 0 {
+		// Writes need to read the blocks they modify, and appending is
+		// done by us.
+		flags = flags&^(OptWriteOnly|OptAppend) | OptReadWrite
+	}
+	fd, err := f.Filesystem.OpenFile(enc, flags, mode)
+	if err != nil {
+		return nil, err
+	}
+	info, err := fd.Stat()
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+	return &encryptedFile{
+		fd:        fd,
+		aead:      f.content,
+		name:      name,
+		appending: appending,
+		size:      encPlainSize(info.Size()),
+		mut:       sync.NewMutex(),
+	}, nil
+}
+
+func (f *encryptedFilesystem) Remove(name string) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.Remove(enc)
+}
+
+func (f *encryptedFilesystem) RemoveAll(name string) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.RemoveAll(enc)
+}
+
+func (f *encryptedFilesystem) Rename(oldname, newname string) error {
+	encOld, err := f.encryptPath(oldname)
+	if err != nil {
+		return err
+	}
+	encNew, err := f.encryptPath(newname)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.Rename(encOld, encNew)
+}
+
+func (f *encryptedFilesystem) Walk(name string, walkFn WalkFunc) error {
+	// Walk the plaintext names, using our Lstat and DirNames.
+	return NewWalkFilesystem(f).Walk(name, walkFn)
+}
+
+func (f *encryptedFilesystem) Watch(name string, ignore Matcher, ctx context.Context, ignorePerms bool) (<-chan Event, <-chan error, error) {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	encEvents, errs, err := f.Filesystem.Watch(enc, encryptedMatcher{ignore, f}, ctx, ignorePerms)
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make(chan Event)
+	go func() {
+		for {
+			select {
+			case ev := <-encEvents:
+				dec, err := f.decryptPath(ev.Name)
+				if err != nil {
+					continue
+				}
+				select {
+				case events <- Event{Name: dec, Type: ev.Type}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, errs, nil
+}
+
+func (f *encryptedFilesystem) Hide(name string) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.Hide(enc)
+}
+
+func (f *encryptedFilesystem) Unhide(name string) error {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return err
+	}
+	return f.Filesystem.Unhide(enc)
+}
+
+// Glob matches the pattern against the decrypted names, which is only
+// possible for a pattern in the last path component.
+func (f *encryptedFilesystem) Glob(pattern string) ([]string, error) {
+	dir, base := filepath.Split(pattern)
+	if strings.ContainsAny(dir, `*?[\`) {
+		return nil, errEncryptedGlob
+	}
+	if dir == "" {
+		dir = "."
+	}
+	names, err := f.DirNames(dir)
+	if IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, name := range names {
+		if ok, err := filepath.Match(base, name); err != nil {
+			return nil, err
+		} else if ok {
+			matches = append(matches, filepath.Join(dir, name))
+		}
+	}
+	return matches, nil
+}
+
+func (f *encryptedFilesystem) Usage(name string) (Usage, error) {
+	enc, err := f.encryptPath(name)
+	if err != nil {
+		return Usage{}, err
+	}
+	return f.Filesystem.Usage(enc)
+}
+
+// Extended attributes would be stored in plaintext.
+
+func (f *encryptedFilesystem) GetXattr(name string) ([]Xattr, error) {
+	return nil, ErrXattrsNotSupported
+}
+
+func (f *encryptedFilesystem) SetXattr(name string, xattrs []Xattr) error {
+	return ErrXattrsNotSupported
+}
+
+// encryptedMatcher lets the underlying filesystem match encrypted names.
+type encryptedMatcher struct {
+	Matcher
+	fs *encryptedFilesystem
+}
+
+func (m encryptedMatcher) ShouldIgnore(name string) bool {
+	dec, err := m.fs.decryptPath(name)
+	if err != nil {
+		// Not ours, so no use watching it.
+		return true
+	}
+	return m.Matcher.ShouldIgnore(dec)
+}
+
+type encryptedFileInfo struct {
+	FileInfo
+	name string
+}
+
+func (fi encryptedFileInfo) Name() string {
+	return fi.name
+}
+
+func (fi encryptedFileInfo) Size() int64 {
+	if fi.FileInfo.IsRegular() {
+		return encPlainSize(fi.FileInfo.Size())
+	}
+	return fi.FileInfo.Size()
+}
+
+// encPlainSize returns the size of the contents of an encrypted file of
+// the given size.
+func encPlainSize(size int64) int64 {
+	blocks := size / (encBlockSize + encBlockOverhead)
+	plain := blocks * encBlockSize
+	if rest := size % (encBlockSize + encBlockOverhead); rest > encBlockOverhead {
+		plain += rest - encBlockOverhead
+	}
+	return plain
+}
+
+type encryptedFile struct {
+	fd        File
+	aead      cipher.AEAD
+	name      string
+	appending bool
+
+	// Writes modify whole blocks, so all access is serialized.
+	size   int64 // plaintext
+	offset int64
+	mut    sync.Mutex
+}
+
+func (f *encryptedFile) Close() error {
+	return f.fd.Close()
+}
+
+func (f *encryptedFile) Name() string {
+	return f.name
+}
+
+func (f *encryptedFile) Sync() error {
+	return f.fd.Sync()
+}
+
+func (f *encryptedFile) Stat() (FileInfo, error) {
+	info, err := f.fd.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return encryptedFileInfo{info, filepath.Base(f.name)}, nil
+}
+
+func (f *encryptedFile) Read(p []byte) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	n, err := f.readAtLocked(p, f.offset)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *encryptedFile) ReadAt(p []byte, off int64) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.readAtLocked(p, off)
+}
+
+func (f *encryptedFile) Write(p []byte) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if f.appending {
+		f.offset = f.size
+	}
+	n, err := f.writeAtLocked(p, f.offset)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *encryptedFile) WriteAt(p []byte, off int64) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.writeAtLocked(p, off)
+}
+
+func (f *encryptedFile) Seek(offset int64, whence int) (int64, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *encryptedFile) Truncate(size int64) error {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if size >= f.size {
+		return f.extendLocked(size)
+	}
+
+	idx := size / encBlockSize
+	encSize := idx * (encBlockSize + encBlockOverhead)
+	if rest := size % encBlockSize; rest > 0 {
+		block, err := f.readBlock(idx)
+		if err != nil {
+			return err
+		}
+		if err := f.writeBlock(idx, block[:rest]); err != nil {
+			return err
+		}
+		encSize += rest + encBlockOverhead
+	}
+	if err := f.fd.Truncate(encSize); err != nil {
+		return err
+	}
+	f.size = size
+	return nil
+}
+
+func (f *encryptedFile) readAtLocked(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	n := 0
+	for n < len(p) {
+		if off >= f.size {
+			return n, io.EOF
+		}
+		idx := off / encBlockSize
+		block, err := f.readBlock(idx)
+		if err != nil {
+			return n, err
+		}
+		c := copy(p[n:], block[off-idx*encBlockSize:])
+		n += c
+		off += int64(c)
+	}
+	return n, nil
+}
+
+func (f *encryptedFile) writeAtLocked(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if err := f.extendLocked(off); err != nil {
+		return 0, err
+	}
+	n := 0
+	for n < len(p) {
+		idx := off / encBlockSize
+		inBlock := int(off - idx*encBlockSize)
+		c := len(p) - n
+		if c > encBlockSize-inBlock {
+			c = encBlockSize - inBlock
+		}
+
+		var block []byte
+		if inBlock == 0 && (c == encBlockSize || off+int64(c) >= f.size) {
+			// Nothing of the old block remains.
+			block = make([]byte, c)
+		} else {
+			var err error
+			block, err = f.readBlock(idx)
+			if err != nil {
+				return n, err
+			}
+			if len(block) < inBlock+c {
+				block = append(block, make([]byte, inBlock+c-len(block))...)
+			}
+		}
+		copy(block[inBlock:], p[n:n+c])
+		if err := f.writeBlock(idx, block); err != nil {
+			return n, err
+		}
+
+		n += c
+		off += int64(c)
+		if off > f.size {
+			f.size = off
+		}
+	}
+	return n, nil
+}
+
+// extendLocked fills the file with zeroes up to the given size, as
+// there's no such thing as a hole in an encrypted file.
+func (f *encryptedFile) extendLocked(size int64) error {
+	var zeroes []byte
+	for f.size < size {
+		c := encBlockSize - f.size%encBlockSize
+		if c > size-f.size {
+			c = size - f.size
+		}
+		if zeroes == nil {
+			zeroes = make([]byte, encBlockSize)
+		}
+		if _, err := f.writeAtLocked(zeroes[:c], f.size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *encryptedFile) readBlock(idx int64) ([]byte, error) {
+	buf := make([]byte, encBlockSize+encBlockOverhead)
+	n, err := f.fd.ReadAt(buf, idx*int64(len(buf)))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if n <= encBlockOverhead {
+		return nil, &os.PathError{Op: "decrypt", Path: f.name, Err: errEncryptedBlockShort}
+	}
+	block, err := f.aead.Open(buf[encNonceSize:encNonceSize], buf[:encNonceSize], buf[encNonceSize:n], encBlockAD(idx))
+	if err != nil {
+		return nil, &os.PathError{Op: "decrypt", Path: f.name, Err: err}
+	}
+	return block, nil
+}
+
+func (f *encryptedFile) writeBlock(idx int64, block []byte) error {
+	buf := make([]byte, encNonceSize, encNonceSize+len(block)+encBlockOverhead)
+	if _, err := rand.Read(buf); err != nil {
+		panic("catastrophic randomness failure: " + err.Error())
+	}
+	buf = f.aead.Seal(buf, buf[:encNonceSize], block, encBlockAD(idx))
+	_, err := f.fd.WriteAt(buf, idx*(encBlockSize+encBlockOverhead))
+	return err
+}
+
+// encBlockAD binds a block to its position in the file.
+func encBlockAD(idx int64) []byte {
+	var ad [8]byte
+	binary.BigEndian.PutUint64(ad[:], uint64(idx))
+	return ad[:]
+}
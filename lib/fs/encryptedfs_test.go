@@ -0,0 +1,180 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedFilesystemNames(t *testing.T) {
+	basic, dir := setup(t)
+	defer os.RemoveAll(dir)
+	efs := NewEncryptedFilesystem(basic, LocalEncryptionKey("folder", "password"))
+
+	if err := efs.MkdirAll(filepath.Join("secret", "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := efs.Create(filepath.Join("secret", "dir", "file.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fd.Write([]byte("top secret contents")); err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+
+	// Nothing is stored in plaintext.
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.Contains(path, "secret") || strings.Contains(path, "file") {
+			t.Errorf("plaintext name in %s", path)
+		}
+		if info.Mode().IsRegular() {
+			bs, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if bytes.Contains(bs, []byte("secret")) {
+				t.Errorf("plaintext contents in %s", path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var walked []string
+	err = efs.Walk(".", func(path string, info FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		walked = append(walked, path)
+		if path == filepath.Join("secret", "dir", "file.txt") && info.Size() != int64(len("top secret contents")) {
+			t.Errorf("wrong size %d", info.Size())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{".", "secret", filepath.Join("secret", "dir"), filepath.Join("secret", "dir", "file.txt")}
+	if strings.Join(walked, ",") != strings.Join(expected, ",") {
+		t.Errorf("walked %v, expected %v", walked, expected)
+	}
+
+	if err := efs.Rename(filepath.Join("secret", "dir", "file.txt"), filepath.Join("secret", "renamed")); err != nil {
+		t.Fatal(err)
+	}
+	if matches, err := efs.Glob(filepath.Join("secret", "ren*")); err != nil {
+		t.Fatal(err)
+	} else if len(matches) != 1 || matches[0] != filepath.Join("secret", "renamed") {
+		t.Errorf("unexpected matches %v", matches)
+	}
+
+	// With another key, there is nothing to see.
+	other := NewEncryptedFilesystem(basic, LocalEncryptionKey("folder", "other password"))
+	if names, err := other.DirNames("."); err != nil {
+		t.Fatal(err)
+	} else if len(names) != 0 {
+		t.Errorf("unexpected names %v", names)
+	}
+}
+
+func TestEncryptedFilesystemContents(t *testing.T) {
+	basic, dir := setup(t)
+	defer os.RemoveAll(dir)
+	efs := NewEncryptedFilesystem(basic, LocalEncryptionKey("folder", "password"))
+
+	fd, err := efs.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	// Random writes, including past the end and across blocks, must read
+	// back the same as in a plain file.
+	rnd := rand.New(rand.NewSource(42))
+	var expected []byte
+	for i := 0; i < 50; i++ {
+		off := rnd.Int63n(3*encBlockSize + 1000)
+		data := make([]byte, rnd.Intn(encBlockSize+1000))
+		rnd.Read(data)
+		if _, err := fd.WriteAt(data, off); err != nil {
+			t.Fatal(err)
+		}
+		if end := int(off) + len(data); end > len(expected) {
+			expected = append(expected, make([]byte, end-len(expected))...)
+		}
+		copy(expected[off:], data)
+
+		if i%10 == 9 {
+			size := rnd.Int63n(int64(len(expected)) + 1)
+			if err := fd.Truncate(size); err != nil {
+				t.Fatal(err)
+			}
+			expected = expected[:size]
+		}
+	}
+
+	info, err := fd.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != int64(len(expected)) {
+		t.Fatalf("size %d, expected %d", info.Size(), len(expected))
+	}
+
+	if _, err := fd.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := ioutil.ReadAll(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bs, expected) {
+		t.Error("contents differ")
+	}
+
+	// Tampering is detected.
+	raw, err := os.OpenFile(filepath.Join(dir, fd.(*encryptedFile).fd.Name()), os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := raw.WriteAt([]byte{0xff}, encNonceSize); err != nil {
+		t.Fatal(err)
+	}
+	raw.Close()
+	if _, err := fd.ReadAt(make([]byte, 10), 0); err == nil {
+		t.Error("expected an error reading tampered data")
+	}
+}
+
+func TestEncPlainSize(t *testing.T) {
+	cases := []struct {
+		plain, enc int64
+	}{
+		{0, 0},
+		{1, 1 + encBlockOverhead},
+		{encBlockSize, encBlockSize + encBlockOverhead},
+		{encBlockSize + 1, encBlockSize + 1 + 2*encBlockOverhead},
+	}
+	for _, tc := range cases {
+		if got := encPlainSize(tc.enc); got != tc.plain {
+			t.Errorf("encPlainSize(%d) == %d, expected %d", tc.enc, got, tc.plain)
+		}
+	}
+}
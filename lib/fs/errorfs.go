@@ -46,6 +46,8 @@ func (fs *errorFilesystem) Usage(name string) (Usage, error)             { retur
 func (fs *errorFilesystem) Type() FilesystemType                         { return fs.fsType }
 func (fs *errorFilesystem) URI() string                                  { return fs.uri }
 func (fs *errorFilesystem) SameFile(fi1, fi2 FileInfo) bool              { return false }
+func (fs *errorFilesystem) GetXattr(name string) ([]Xattr, error)        { return nil, fs.err }
+func (fs *errorFilesystem) SetXattr(name string, xattrs []Xattr) error   { return fs.err }
 func (fs *errorFilesystem) Watch(path string, ignore Matcher, ctx context.Context, ignorePerms bool) (<-chan Event, <-chan error, error) {
 	return nil, nil, fs.err
 }
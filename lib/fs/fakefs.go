@@ -17,6 +17,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -181,6 +182,7 @@ type fakeEntry struct {
 	mtime     time.Time
 	children  map[string]*fakeEntry
 	content   []byte
+	xattrs    []Xattr
 }
 
 func (fs *fakefs) entryForName(name string) *fakeEntry {
@@ -632,6 +634,32 @@ func (fs *fakefs) Usage(name string) (Usage, error) {
 	return Usage{}, errors.New("not implemented")
 }
 
+func (fs *fakefs) GetXattr(name string) ([]Xattr, error) {
+	fs.mut.Lock()
+	defer fs.mut.Unlock()
+	time.Sleep(fs.latency)
+	entry := fs.entryForName(name)
+	if entry == nil {
+		return nil, os.ErrNotExist
+	}
+	return append([]Xattr(nil), entry.xattrs...), nil
+}
+
+func (fs *fakefs) SetXattr(name string, xattrs []Xattr) error {
+	fs.mut.Lock()
+	defer fs.mut.Unlock()
+	time.Sleep(fs.latency)
+	entry := fs.entryForName(name)
+	if entry == nil {
+		return os.ErrNotExist
+	}
+	entry.xattrs = append([]Xattr(nil), xattrs...)
+	sort.Slice(entry.xattrs, func(a, b int) bool {
+		return entry.xattrs[a].Name < entry.xattrs[b].Name
+	})
+	return nil
+}
+
 func (fs *fakefs) Type() FilesystemType {
 	return FilesystemTypeFake
 }
@@ -48,6 +48,19 @@ type Filesystem interface {
 	Type() FilesystemType
 	URI() string
 	SameFile(fi1, fi2 FileInfo) bool
+	// GetXattr returns the extended attributes of the item that are
+	// synced, sorted by name.
+	GetXattr(name string) ([]Xattr, error)
+	// SetXattr sets the given extended attributes on the item, and
+	// removes the other synced ones.
+	SetXattr(name string, xattrs []Xattr) error
+}
+
+// An Xattr is an extended attribute. On Linux these include the POSIX ACLs,
+// on Windows the alternate data streams take their place.
+type Xattr struct {
+	Name  string
+	Value []byte
 }
 
 // The File interface abstracts access to a regular file, being a somewhat
@@ -137,6 +150,11 @@ func (evType EventType) String() string {
 
 var ErrWatchNotSupported = errors.New("watching is not supported")
 
+var ErrXattrsNotSupported = errors.New("extended attributes are not supported")
+
+// Values larger than this aren't synced, which is the limit on Linux.
+const maxXattrSize = 64 << 10
+
 // Equivalents from os package.
 
 const ModePerm = FileMode(os.ModePerm)
@@ -160,6 +178,12 @@ const OptWriteOnly = os.O_WRONLY
 // as an error by any function.
 var SkipDir = filepath.SkipDir
 
+// FollowSymlink is used as a return value from WalkFuncs to indicate that
+// the symlink named in the call is to be walked as the item it points to,
+// i.e. the function is called again with the info of that item. It is not
+// returned as an error by any function.
+var FollowSymlink = errors.New("follow symlink")
+
 // IsExist is the equivalent of os.IsExist
 var IsExist = os.IsExist
 
@@ -190,6 +214,8 @@ func NewFilesystem(fsType FilesystemType, uri string, opts ...Option) Filesystem
 		fs = newBasicFilesystem(uri, opts...)
 	case FilesystemTypeFake:
 		fs = newFakeFilesystem(uri, opts...)
+	case FilesystemTypeS3:
+		fs = newS3Filesystem(uri, opts...)
 	default:
 		l.Debugln("Unknown filesystem", fsType, uri)
 		fs = &errorFilesystem{
@@ -163,3 +163,15 @@ func (fs *logFilesystem) Usage(name string) (Usage, error) {
 	l.Debugln(getCaller(), fs.Type(), fs.URI(), "Usage", name, usage, err)
 	return usage, err
 }
+
+func (fs *logFilesystem) GetXattr(name string) ([]Xattr, error) {
+	xattrs, err := fs.Filesystem.GetXattr(name)
+	l.Debugln(getCaller(), fs.Type(), fs.URI(), "GetXattr", name, len(xattrs), err)
+	return xattrs, err
+}
+
+func (fs *logFilesystem) SetXattr(name string, xattrs []Xattr) error {
+	err := fs.Filesystem.SetXattr(name, xattrs)
+	l.Debugln(getCaller(), fs.Type(), fs.URI(), "SetXattr", name, len(xattrs), err)
+	return err
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/s3"
+)
+
+func setupS3(t *testing.T) (Filesystem, *s3.Fake, func()) {
+	t.Helper()
+	srv := s3.NewFake("bucket", "key")
+	ts := httptest.NewServer(srv)
+	uri := strings.Replace(ts.URL, "http://", "http://key:secret@", 1) + "/bucket/folder"
+	fs := NewFilesystem(FilesystemTypeS3, uri)
+	if fs.URI() != ts.URL+"/bucket/folder" {
+		t.Errorf("credentials in URI %s", fs.URI())
+	}
+	return fs, srv, ts.Close
+}
+
+func TestS3FilesystemFiles(t *testing.T) {
+	fs, srv, cleanup := setupS3(t)
+	defer cleanup()
+
+	if err := fs.MkdirAll(filepath.Join("dir", "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join("dir", "sub", "file")
+	fd, err := fs.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fd.Write([]byte("hello world")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fd.WriteAt([]byte("W"), 6); err != nil {
+		t.Fatal(err)
+	}
+	if err := fd.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Date(2021, 2, 3, 4, 5, 6, 7, time.UTC)
+	if err := fs.Chtimes(name, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Chmod(name, 0600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := fs.Lstat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.IsRegular() || info.Size() != 11 || info.Mode() != 0600 || !info.ModTime().Equal(mtime) {
+		t.Errorf("unexpected info %v %v %v %v", info.IsRegular(), info.Size(), info.Mode(), info.ModTime())
+	}
+
+	fd, err = fs.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 5)
+	if n, err := fd.ReadAt(buf, 6); err != nil || string(buf[:n]) != "World" {
+		t.Errorf("ReadAt returned %q, %v", buf[:n], err)
+	}
+	if _, err := fd.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if bs, err := ioutil.ReadAll(fd); err != nil || string(bs) != "World" {
+		t.Errorf("Read returned %q, %v", bs, err)
+	}
+	fd.Close()
+
+	// Appending keeps the contents and metadata.
+	fd, err = fs.OpenFile(name, OptReadWrite|OptAppend, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fd.Write([]byte("!")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fd.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := fs.Lstat(name); err != nil {
+		t.Fatal(err)
+	} else if info.Size() != 12 || info.Mode() != 0600 {
+		t.Errorf("unexpected size %d, mode %v", info.Size(), info.Mode())
+	}
+
+	if _, err := fs.OpenFile(name, OptReadWrite|OptCreate|OptExclusive, 0644); !IsExist(err) {
+		t.Error("expected exists error, got", err)
+	}
+	if _, err := fs.Open("missing"); !IsNotExist(err) {
+		t.Error("expected not exist error, got", err)
+	}
+
+	for _, key := range srv.Keys() {
+		if !strings.HasPrefix(key, "folder/") {
+			t.Errorf("object %q outside the prefix", key)
+		}
+	}
+}
+
+func TestS3FilesystemDirectories(t *testing.T) {
+	fs, _, cleanup := setupS3(t)
+	defer cleanup()
+
+	for _, name := range []string{"a/b/c", "a/d", "e"} {
+		name = filepath.FromSlash(name)
+		if err := fs.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		fd, err := fs.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fd.Close()
+	}
+
+	var walked []string
+	err := fs.Walk(".", func(path string, info FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		walked = append(walked, filepath.ToSlash(path))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := strings.Join(walked, ","); res != ".,a,a/b,a/b/c,a/d,e" {
+		t.Errorf("unexpected walk %s", res)
+	}
+
+	if err := fs.Remove("a"); err == nil {
+		t.Error("removed a directory that isn't empty")
+	}
+	if err := fs.Rename("a", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Lstat(filepath.Join("x", "b", "c")); err != nil {
+		t.Error(err)
+	}
+	if _, err := fs.Lstat("a"); !IsNotExist(err) {
+		t.Error("expected a to be gone, got", err)
+	}
+	if matches, err := fs.Glob(filepath.Join("x", "*")); err != nil {
+		t.Fatal(err)
+	} else if len(matches) != 2 {
+		t.Errorf("unexpected matches %v", matches)
+	}
+
+	if err := fs.RemoveAll("x"); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := fs.DirNames("."); err != nil {
+		t.Fatal(err)
+	} else if len(names) != 1 || names[0] != "e" {
+		t.Errorf("unexpected names %v", names)
+	}
+}
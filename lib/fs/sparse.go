@@ -0,0 +1,37 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import "errors"
+
+var ErrSparseNotSupported = errors.New("sparse files are not supported")
+
+// A Hole is a range of a sparse file that has no data stored, and reads as
+// zeroes.
+type Hole struct {
+	Offset int64
+	Length int64
+}
+
+// Holes returns the holes in the first size bytes of the file, in order.
+// Files the filesystem can't tell the holes of have none. The file offset
+// is reset to the start of the file.
+func Holes(fd File, size int64) ([]Hole, error) {
+	if bf, ok := unwrap(fd).(basicFile); ok {
+		return holes(bf, size)
+	}
+	return nil, nil
+}
+
+// PunchHole deallocates the given range of the file, which then reads as
+// zeroes, without changing its size.
+func PunchHole(fd File, offset, length int64) error {
+	if bf, ok := unwrap(fd).(basicFile); ok {
+		return punchHole(bf, offset, length)
+	}
+	return ErrSparseNotSupported
+}
@@ -12,6 +12,8 @@ func (t FilesystemType) String() string {
 		return "basic"
 	case FilesystemTypeFake:
 		return "fake"
+	case FilesystemTypeS3:
+		return "s3"
 	default:
 		return "unknown"
 	}
@@ -27,6 +29,8 @@ func (t *FilesystemType) UnmarshalText(bs []byte) error {
 		*t = FilesystemTypeBasic
 	case "fake":
 		*t = FilesystemTypeFake
+	case "s3":
+		*t = FilesystemTypeS3
 	default:
 		*t = FilesystemTypeBasic
 	}
@@ -26,16 +26,19 @@ type FilesystemType int32
 const (
 	FilesystemTypeBasic FilesystemType = 0
 	FilesystemTypeFake  FilesystemType = 1
+	FilesystemTypeS3    FilesystemType = 2
 )
 
 var FilesystemType_name = map[int32]string{
 	0: "FILESYSTEM_TYPE_BASIC",
 	1: "FILESYSTEM_TYPE_FAKE",
+	2: "FILESYSTEM_TYPE_S3",
 }
 
 var FilesystemType_value = map[string]int32{
 	"FILESYSTEM_TYPE_BASIC": 0,
 	"FILESYSTEM_TYPE_FAKE":  1,
+	"FILESYSTEM_TYPE_S3":    2,
 }
 
 func (FilesystemType) EnumDescriptor() ([]byte, []int) {
@@ -49,20 +52,21 @@ func init() {
 func init() { proto.RegisterFile("lib/fs/types.proto", fileDescriptor_b556f45c4309ad5d) }
 
 var fileDescriptor_b556f45c4309ad5d = []byte{
-	// 228 bytes of a gzipped FileDescriptorProto
+	// 246 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0xca, 0xc9, 0x4c, 0xd2,
 	0x4f, 0x2b, 0xd6, 0x2f, 0xa9, 0x2c, 0x48, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62,
 	0x4a, 0x2b, 0x96, 0x52, 0x2e, 0x4a, 0x2d, 0xc8, 0x2f, 0xd6, 0x07, 0x0b, 0x24, 0x95, 0xa6, 0xe9,
-	0xa7, 0xe7, 0xa7, 0xe7, 0x83, 0x39, 0x60, 0x16, 0x44, 0xa1, 0x56, 0x0d, 0x17, 0x9f, 0x5b, 0x66,
-	0x4e, 0x6a, 0x71, 0x65, 0x71, 0x49, 0x6a, 0x6e, 0x48, 0x65, 0x41, 0xaa, 0x90, 0x11, 0x97, 0xa8,
-	0x9b, 0xa7, 0x8f, 0x6b, 0x70, 0x64, 0x70, 0x88, 0xab, 0x6f, 0x7c, 0x48, 0x64, 0x80, 0x6b, 0xbc,
-	0x93, 0x63, 0xb0, 0xa7, 0xb3, 0x00, 0x83, 0x94, 0x78, 0xd7, 0x5c, 0x05, 0x61, 0x54, 0xe5, 0x4e,
-	0x89, 0xc5, 0x99, 0xc9, 0x42, 0x06, 0x5c, 0x22, 0xe8, 0x7a, 0xdc, 0x1c, 0xbd, 0x5d, 0x05, 0x18,
-	0xa5, 0xc4, 0xba, 0xe6, 0x2a, 0x08, 0xa1, 0x6a, 0x71, 0x4b, 0xcc, 0x4e, 0x95, 0x62, 0x59, 0xb1,
-	0x44, 0x8e, 0xc1, 0xc9, 0xfd, 0xc4, 0x43, 0x39, 0x86, 0x0b, 0x0f, 0xe5, 0x18, 0x4e, 0x3c, 0x92,
-	0x63, 0xbc, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x05, 0x8f, 0xe5, 0x18, 0x2f, 0x3c,
-	0x96, 0x63, 0xb8, 0xf1, 0x58, 0x8e, 0x21, 0x4a, 0x35, 0x3d, 0xb3, 0x24, 0xa3, 0x34, 0x49, 0x2f,
-	0x39, 0x3f, 0x57, 0xbf, 0xb8, 0x32, 0x2f, 0xb9, 0x24, 0x23, 0x33, 0x2f, 0x1d, 0x89, 0x05, 0xf1,
-	0x7b, 0x12, 0x1b, 0xd8, 0x37, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2c, 0x30, 0x9a, 0x86,
-	0x0c, 0x01, 0x00, 0x00,
+	0xa7, 0xe7, 0xa7, 0xe7, 0x83, 0x39, 0x60, 0x16, 0x44, 0xa1, 0xd6, 0x2a, 0x46, 0x2e, 0x3e, 0xb7,
+	0xcc, 0x9c, 0xd4, 0xe2, 0xca, 0xe2, 0x92, 0xd4, 0xdc, 0x90, 0xca, 0x82, 0x54, 0x21, 0x23, 0x2e,
+	0x51, 0x37, 0x4f, 0x1f, 0xd7, 0xe0, 0xc8, 0xe0, 0x10, 0x57, 0xdf, 0xf8, 0x90, 0xc8, 0x00, 0xd7,
+	0x78, 0x27, 0xc7, 0x60, 0x4f, 0x67, 0x01, 0x06, 0x29, 0xf1, 0xae, 0xb9, 0x0a, 0xc2, 0xa8, 0xca,
+	0x9d, 0x12, 0x8b, 0x33, 0x93, 0x85, 0x0c, 0xb8, 0x44, 0xd0, 0xf5, 0xb8, 0x39, 0x7a, 0xbb, 0x0a,
+	0x30, 0x4a, 0x89, 0x75, 0xcd, 0x55, 0x10, 0x42, 0xd5, 0xe2, 0x96, 0x98, 0x9d, 0x2a, 0xa4, 0xc3,
+	0x25, 0x84, 0xae, 0x23, 0xd8, 0x58, 0x80, 0x49, 0x4a, 0xa4, 0x6b, 0xae, 0x82, 0x00, 0xaa, 0xfa,
+	0x60, 0x63, 0x29, 0x96, 0x15, 0x4b, 0xe4, 0x18, 0x9c, 0xdc, 0x4f, 0x3c, 0x94, 0x63, 0xb8, 0xf0,
+	0x50, 0x8e, 0xe1, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0x58,
+	0xf0, 0x58, 0x8e, 0xf1, 0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f, 0xe5, 0x18, 0xa2, 0x54, 0xd3, 0x33,
+	0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0x8b, 0x2b, 0xf3, 0x92, 0x4b, 0x32, 0x32,
+	0xf3, 0xd2, 0x91, 0x58, 0x90, 0xa0, 0x4a, 0x62, 0x03, 0x7b, 0xde, 0x18, 0x30, 0x00, 0x05, 0xf4,
+	0xbc, 0xa4, 0x3b, 0x01, 0x00, 0x00,
 }
@@ -82,6 +82,13 @@ func (f *walkFilesystem) walk(path string, info FileInfo, walkFn WalkFunc, ances
 		if info.IsDir() && err == SkipDir {
 			return nil
 		}
+		if info.IsSymlink() && err == FollowSymlink {
+			target, err := f.Stat(path)
+			if err != nil {
+				return walkFn(path, info, err)
+			}
+			return f.walk(path, target, walkFn, ancestors)
+		}
 		return err
 	}
 
@@ -125,7 +132,8 @@ func (f *walkFilesystem) walk(path string, info FileInfo, walkFn WalkFunc, ances
 // and directories are filtered by walkFn. The files are walked in lexical
 // order, which makes the output deterministic but means that for very
 // large directories Walk can be inefficient.
-// Walk does not follow symbolic links.
+// Walk does not follow symbolic links, unless walkFn returns FollowSymlink
+// for them.
 func (f *walkFilesystem) Walk(root string, walkFn WalkFunc) error {
 	info, err := f.Lstat(root)
 	if err != nil {
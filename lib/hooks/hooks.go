@@ -0,0 +1,336 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package hooks runs the external commands configured as folder hooks when
+// the corresponding things happen in the folder.
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/thejerf/suture/v4"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+// The events hooks can be run on.
+const (
+	// The folder became idle after local changes were scanned or remote
+	// changes were pulled.
+	FolderIdle = "folderIdle"
+	// A conflict copy was created for an item.
+	ConflictCreated = "conflictCreated"
+	// An item failed to sync in several pulls in a row.
+	ItemFailed = "itemFailed"
+)
+
+const (
+	defaultTimeout = time.Minute
+	// The number of consecutive pulls an item must fail in for the
+	// itemFailed hooks to run.
+	failureThreshold = 3
+	// Number of hook runs queued while an earlier one is running; further
+	// ones are dropped.
+	queueSize = 64
+)
+
+const eventMask = events.LocalIndexUpdated | events.StateChanged | events.FolderErrors | events.ConflictCreated
+
+type Service interface {
+	suture.Service
+	config.Committer
+}
+
+func New(cfg config.Wrapper, evLogger events.Logger) Service {
+	return &service{
+		cfg:         cfg,
+		evLogger:    evLogger,
+		enabledChan: make(chan bool, 1),
+		queue:       make(chan run, queueSize),
+		folders:     make(map[string]*folderState),
+	}
+}
+
+type service struct {
+	cfg      config.Wrapper
+	evLogger events.Logger
+	// Holds the latest change to whether hooks are configured, until the
+	// Serve loop picks it up.
+	enabledChan chan bool
+	queue       chan run
+
+	// Only touched by the Serve loop.
+	folders map[string]*folderState
+}
+
+type folderState struct {
+	// Number of items changed since the folder was last idle.
+	changed int
+	// Number of consecutive pulls each item failed in.
+	failures map[string]int
+	// Whether the ongoing pull reported errors.
+	pullFailed bool
+}
+
+// A fileError is an item failing to sync as reported in the FolderErrors
+// event, in its serialized form.
+type fileError struct {
+	Path string `json:"path"`
+	Err  string `json:"error"`
+}
+
+// A run is a single execution of a hook command, with the environment
+// variables describing what happened.
+type run struct {
+	folder config.FolderConfiguration
+	hook   config.FolderHookConfiguration
+	env    []string
+}
+
+func (s *service) Serve(ctx context.Context) error {
+	cfg := s.cfg.Subscribe(s)
+	defer s.cfg.Unsubscribe(s)
+
+	go s.runQueued(ctx)
+
+	// Events are only listened to while there are hooks configured.
+	var sub events.Subscription
+	var evChan <-chan events.Event
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+	}()
+	enable := func(enabled bool) {
+		if enabled == (sub != nil) {
+			return
+		}
+		if enabled {
+			sub = s.evLogger.Subscribe(eventMask)
+			evChan = sub.C()
+		} else {
+			sub.Unsubscribe()
+			sub, evChan = nil, nil
+			s.folders = make(map[string]*folderState)
+		}
+	}
+	enable(hasHooks(cfg))
+
+	for {
+		select {
+		case enabled := <-s.enabledChan:
+			enable(enabled)
+		case ev, ok := <-evChan:
+			if !ok {
+				evChan = nil
+				continue
+			}
+			s.handle(ev)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *service) handle(ev events.Event) {
+	switch ev.Type {
+	case events.LocalIndexUpdated:
+		data, _ := ev.Data.(map[string]interface{})
+		folder, _ := data["folder"].(string)
+		items, _ := data["items"].(int)
+		s.folder(folder).changed += items
+
+	case events.StateChanged:
+		data, _ := ev.Data.(map[string]interface{})
+		folder, _ := data["folder"].(string)
+		st := s.folder(folder)
+		if data["from"] == "syncing" && !st.pullFailed {
+			st.failures = nil
+		}
+		switch data["to"] {
+		case "syncing":
+			st.pullFailed = false
+		case "idle":
+			if st.changed > 0 {
+				s.trigger(folder, FolderIdle, "STHOOK_ITEMS="+strconv.Itoa(st.changed))
+				st.changed = 0
+			}
+		}
+
+	case events.FolderErrors:
+		data, _ := ev.Data.(map[string]interface{})
+		folder, _ := data["folder"].(string)
+		var errs []fileError
+		if bs, err := json.Marshal(data["errors"]); err == nil {
+			_ = json.Unmarshal(bs, &errs)
+		}
+		st := s.folder(folder)
+		st.pullFailed = true
+		failures := make(map[string]int, len(errs))
+		for _, ferr := range errs {
+			failures[ferr.Path] = st.failures[ferr.Path] + 1
+			if failures[ferr.Path] == failureThreshold {
+				s.trigger(folder, ItemFailed, "STHOOK_ITEM="+ferr.Path, "STHOOK_ERROR="+ferr.Err)
+			}
+		}
+		st.failures = failures
+
+	case events.ConflictCreated:
+		data, _ := ev.Data.(map[string]string)
+		s.trigger(data["folder"], ConflictCreated, "STHOOK_ITEM="+data["item"], "STHOOK_CONFLICT="+data["conflict"])
+	}
+}
+
+func (s *service) folder(id string) *folderState {
+	st, ok := s.folders[id]
+	if !ok {
+		st = &folderState{}
+		s.folders[id] = st
+	}
+	return st
+}
+
+// trigger queues the folder's hooks for the event, with the given extra
+// environment variables.
+func (s *service) trigger(folderID, event string, env ...string) {
+	folder, ok := s.cfg.Folder(folderID)
+	if !ok {
+		return
+	}
+	for _, hook := range folder.Hooks {
+		if hook.Event != event {
+			continue
+		}
+		r := run{
+			folder: folder,
+			hook:   hook,
+			env: append([]string{
+				"STHOOK_EVENT=" + event,
+				"STHOOK_FOLDER_ID=" + folder.ID,
+				"STHOOK_FOLDER_LABEL=" + folder.Label,
+				"STHOOK_FOLDER_PATH=" + folder.Filesystem().URI(),
+			}, env...),
+		}
+		select {
+		case s.queue <- r:
+		default:
+			l.Debugf("Hook %s for folder %s: queue full, dropping", event, folder.Description())
+		}
+	}
+}
+
+// runQueued runs the queued hooks one at a time.
+func (s *service) runQueued(ctx context.Context) {
+	for {
+		select {
+		case r := <-s.queue:
+			s.run(ctx, r)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run executes the hook command, logging its output.
+func (s *service) run(ctx context.Context, r run) {
+	desc := r.folder.Description()
+
+	command := r.hook.Command
+	if runtime.GOOS == "windows" {
+		command = strings.Replace(command, `\`, `\\`, -1)
+	}
+	words, err := shellquote.Split(command)
+	if err != nil {
+		l.Warnf("Hook %s for folder %s: command is invalid: %v", r.hook.Event, desc, err)
+		return
+	}
+	if len(words) == 0 {
+		l.Warnf("Hook %s for folder %s: command is empty", r.hook.Event, desc)
+		return
+	}
+
+	timeout := defaultTimeout
+	if r.hook.TimeoutS > 0 {
+		timeout = time.Duration(r.hook.TimeoutS) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, words[0], words[1:]...)
+	if r.folder.FilesystemType == fs.FilesystemTypeBasic {
+		cmd.Dir = r.folder.Filesystem().URI()
+	}
+	// Don't pass on the GUI credentials.
+	for _, x := range os.Environ() {
+		if !strings.HasPrefix(x, "STGUIAUTH=") && !strings.HasPrefix(x, "STGUIAPIKEY=") {
+			cmd.Env = append(cmd.Env, x)
+		}
+	}
+	cmd.Env = append(cmd.Env, r.env...)
+
+	l.Debugf("Hook %s for folder %s: running %q with %v", r.hook.Event, desc, words, r.env)
+	out, err := cmd.CombinedOutput()
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			l.Infof("Hook %s for folder %s: %s", r.hook.Event, desc, line)
+		}
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		l.Warnf("Hook %s for folder %s: timed out after %v", r.hook.Event, desc, timeout)
+	} else if err != nil {
+		l.Warnf("Hook %s for folder %s: %v", r.hook.Event, desc, err)
+	}
+}
+
+func hasHooks(cfg config.Configuration) bool {
+	for _, folder := range cfg.Folders {
+		if len(folder.Hooks) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) VerifyConfiguration(_, to config.Configuration) error {
+	for _, folder := range to.Folders {
+		for _, hook := range folder.Hooks {
+			switch hook.Event {
+			case FolderIdle, ConflictCreated, ItemFailed:
+			default:
+				return fmt.Errorf("folder %s: unknown hook event %q", folder.Description(), hook.Event)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *service) CommitConfiguration(from, to config.Configuration) bool {
+	if enabled := hasHooks(to); enabled != hasHooks(from) {
+		// Replace any value not yet picked up, so that this never blocks
+		// when the Serve loop isn't running.
+		select {
+		case <-s.enabledChan:
+		default:
+		}
+		s.enabledChan <- enabled
+	}
+	return true
+}
+
+func (s *service) String() string {
+	return "hooks.Service"
+}
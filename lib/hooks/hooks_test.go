@@ -0,0 +1,180 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package hooks
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/model"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func newTestService(hooks ...config.FolderHookConfiguration) *service {
+	cfg := config.New(protocol.LocalDeviceID)
+	cfg.Folders = []config.FolderConfiguration{{ID: "default", Path: "testdata", Hooks: hooks}}
+	w := config.Wrap("", cfg, protocol.LocalDeviceID, events.NoopLogger)
+	return New(w, events.NoopLogger).(*service)
+}
+
+func stateChanged(from, to string) events.Event {
+	return events.Event{Type: events.StateChanged, Data: map[string]interface{}{"folder": "default", "from": from, "to": to}}
+}
+
+func folderErrors(paths ...string) events.Event {
+	errs := make([]model.FileError, len(paths))
+	for i, path := range paths {
+		errs[i] = model.FileError{Path: path, Err: "permission denied"}
+	}
+	return events.Event{Type: events.FolderErrors, Data: map[string]interface{}{"folder": "default", "errors": errs}}
+}
+
+func queued(s *service) []run {
+	var runs []run
+	for {
+		select {
+		case r := <-s.queue:
+			runs = append(runs, r)
+		default:
+			return runs
+		}
+	}
+}
+
+func env(r run, key string) string {
+	for _, kv := range r.env {
+		if strings.HasPrefix(kv, key+"=") {
+			return kv[len(key)+1:]
+		}
+	}
+	return ""
+}
+
+func TestFolderIdle(t *testing.T) {
+	s := newTestService(config.FolderHookConfiguration{Event: FolderIdle, Command: "true"})
+
+	// Becoming idle without changes doesn't run the hook.
+	s.handle(stateChanged("scanning", "idle"))
+	if runs := queued(s); len(runs) != 0 {
+		t.Fatalf("Expected no runs, got %d", len(runs))
+	}
+
+	s.handle(stateChanged("idle", "syncing"))
+	s.handle(events.Event{Type: events.LocalIndexUpdated, Data: map[string]interface{}{"folder": "default", "items": 2}})
+	s.handle(events.Event{Type: events.LocalIndexUpdated, Data: map[string]interface{}{"folder": "default", "items": 3}})
+	s.handle(stateChanged("syncing", "idle"))
+	runs := queued(s)
+	if len(runs) != 1 {
+		t.Fatalf("Expected one run, got %d", len(runs))
+	}
+	if items := env(runs[0], "STHOOK_ITEMS"); items != "5" {
+		t.Errorf("Expected five changed items, got %q", items)
+	}
+
+	s.handle(stateChanged("scanning", "idle"))
+	if runs := queued(s); len(runs) != 0 {
+		t.Fatalf("Expected no runs, got %d", len(runs))
+	}
+}
+
+func TestItemFailed(t *testing.T) {
+	s := newTestService(config.FolderHookConfiguration{Event: ItemFailed, Command: "true"})
+
+	pull := func(failed ...string) []run {
+		t.Helper()
+		s.handle(stateChanged("idle", "syncing"))
+		if len(failed) > 0 {
+			s.handle(folderErrors(failed...))
+		}
+		s.handle(stateChanged("syncing", "idle"))
+		return queued(s)
+	}
+
+	pull("a", "b")
+	pull("a")
+	// b isn't failing consecutively, so only a triggers.
+	runs := pull("a", "b")
+	if len(runs) != 1 || env(runs[0], "STHOOK_ITEM") != "a" || env(runs[0], "STHOOK_ERROR") != "permission denied" {
+		t.Fatalf("Expected one run for a, got %v", runs)
+	}
+	// The hook runs once, not for every further failure.
+	if runs := pull("a", "b"); len(runs) != 0 {
+		t.Fatalf("Expected no runs, got %v", runs)
+	}
+
+	// A pull without errors starts over.
+	pull()
+	pull("a")
+	pull("a")
+	if runs := pull("a"); len(runs) != 1 {
+		t.Fatalf("Expected one run, got %v", runs)
+	}
+}
+
+func TestCommitConfigurationDoesNotBlock(t *testing.T) {
+	s := newTestService()
+	without := s.cfg.RawCopy()
+	with := without.Copy()
+	with.Folders[0].Hooks = []config.FolderHookConfiguration{{Event: FolderIdle, Command: "true"}}
+
+	// Nothing is receiving, as the service isn't running.
+	s.CommitConfiguration(without, with)
+	s.CommitConfiguration(with, without)
+	if enabled := <-s.enabledChan; enabled {
+		t.Error("Expected the latest change to be pending")
+	}
+}
+
+func TestConflictCreated(t *testing.T) {
+	s := newTestService(config.FolderHookConfiguration{Event: ConflictCreated, Command: "true"}, config.FolderHookConfiguration{Event: FolderIdle, Command: "true"})
+
+	s.handle(events.Event{Type: events.ConflictCreated, Data: map[string]string{"folder": "default", "item": "foo.txt", "conflict": "foo.sync-conflict-20210101-000000-AAAAAAA.txt"}})
+	runs := queued(s)
+	if len(runs) != 1 {
+		t.Fatalf("Expected one run, got %d", len(runs))
+	}
+	if ev := env(runs[0], "STHOOK_EVENT"); ev != ConflictCreated {
+		t.Errorf("Unexpected event %q", ev)
+	}
+	if conflict := env(runs[0], "STHOOK_CONFLICT"); conflict != "foo.sync-conflict-20210101-000000-AAAAAAA.txt" {
+		t.Errorf("Unexpected conflict %q", conflict)
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script")
+	}
+
+	dir, err := ioutil.TempDir("", "syncthing-hooks-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := newTestService()
+	s.run(context.Background(), run{
+		folder: config.FolderConfiguration{ID: "default", Path: dir},
+		hook:   config.FolderHookConfiguration{Event: ConflictCreated, Command: `sh -c 'echo "$STHOOK_ITEM" > out.txt'`},
+		env:    []string{"STHOOK_ITEM=foo.txt"},
+	})
+
+	bs, err := ioutil.ReadFile(filepath.Join(dir, "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bs) != "foo.txt\n" {
+		t.Errorf("Unexpected output %q", bs)
+	}
+}
@@ -71,6 +71,29 @@ type Pattern struct {
 	pattern string
 	match   glob.Glob
 	result  Result
+	only    bool   // from an #only line
+	origin  []Line // see Explanation.Lines
+}
+
+// A Line is a line in an ignore file.
+type Line struct {
+	File   string `json:"file"`
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+}
+
+// An Explanation tells whether a file is ignored, and why.
+type Explanation struct {
+	Ignored   bool `json:"ignored"`
+	Deletable bool `json:"deletable"`
+	// Temporary and internal files are always ignored, regardless of the
+	// patterns.
+	Internal bool `json:"internal"`
+	// The first pattern that matched, if any.
+	Pattern string `json:"pattern,omitempty"`
+	// The line the pattern is from, preceded by the #include lines that
+	// led to the file it is in, starting with the root ignore file.
+	Lines []Line `json:"lines,omitempty"`
 }
 
 func (p Pattern) String() string {
@@ -221,9 +244,25 @@ func (m *Matcher) parseLocked(r io.Reader, file string) error {
 
 	m.lines = lines
 
+	// With #only lines everything they don't match is ignored, after all
+	// other patterns had their say.
+	for _, p := range patterns {
+		if p.only {
+			patterns = append(patterns, Pattern{
+				pattern: "**",
+				match:   glob.MustCompile("**", '/'),
+				result:  defaultResult,
+				origin:  p.origin,
+			})
+			break
+		}
+	}
+
 	newHash := hashPatterns(patterns)
 	if newHash == m.curHash {
-		// We've already loaded exactly these patterns.
+		// We've already loaded exactly these patterns, though they might
+		// be on other lines now.
+		m.patterns = patterns
 		return err
 	}
 
@@ -300,6 +339,39 @@ func (m *Matcher) Match(file string) (result Result) {
 	return resultNotMatched
 }
 
+// Explain returns whether the file is ignored like ShouldIgnore, and
+// which pattern and line caused it to be ignored or not. Unlike Match it
+// doesn't use the cache.
+func (m *Matcher) Explain(file string) Explanation {
+	if fs.IsTemporary(file) || fs.IsInternal(file) {
+		return Explanation{Ignored: true, Internal: true}
+	}
+	if file == "." {
+		return Explanation{}
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	file = filepath.ToSlash(file)
+	lowercaseFile := strings.ToLower(file)
+	for _, pattern := range m.patterns {
+		name := file
+		if pattern.result.IsCaseFolded() {
+			name = lowercaseFile
+		}
+		if pattern.match.Match(name) {
+			return Explanation{
+				Ignored:   pattern.result.IsIgnored(),
+				Deletable: pattern.result.IsDeletable(),
+				Pattern:   pattern.String(),
+				Lines:     pattern.origin,
+			}
+		}
+	}
+	return Explanation{}
+}
+
 // Lines return a list of the unprocessed lines in .stignore at last load
 func (m *Matcher) Lines() []string {
 	m.mut.Lock()
@@ -503,16 +575,37 @@ func parseLine(line string) ([]Pattern, error) {
 
 func parseIgnoreFile(fs fs.Filesystem, fd io.Reader, currentFile string, cd ChangeDetector, linesSeen map[string]struct{}) ([]string, []Pattern, error) {
 	var patterns []Pattern
+	var origin []Line
 
 	addPattern := func(line string) error {
 		newPatterns, err := parseLine(line)
 		if err != nil {
 			return fmt.Errorf("invalid pattern %q in ignore file: %w", line, err)
 		}
+		for i := range newPatterns {
+			newPatterns[i].origin = origin
+		}
 		patterns = append(patterns, newPatterns...)
 		return nil
 	}
 
+	// addOnlyPatterns adds the pattern of an #only line, expanded the
+	// same way as an ignore pattern, but including what it matches.
+	addOnlyPatterns := func(line string) error {
+		start := len(patterns)
+		if err := addPatterns(line, addPattern); err != nil {
+			return err
+		}
+		for i := start; i < len(patterns); i++ {
+			if !patterns[i].result.IsIgnored() || patterns[i].result.IsDeletable() {
+				return parseError(fmt.Errorf("invalid #only pattern %q: the ! and (?d) prefixes aren't allowed", line))
+			}
+			patterns[i].result &^= resultInclude
+			patterns[i].only = true
+		}
+		return nil
+	}
+
 	scanner := bufio.NewScanner(fd)
 	var lines []string
 	for scanner.Scan() {
@@ -524,11 +617,12 @@ func parseIgnoreFile(fs fs.Filesystem, fd io.Reader, currentFile string, cd Chan
 	}
 
 	var err error
-	for _, line := range lines {
+	for i, line := range lines {
 		if _, ok := linesSeen[line]; ok {
 			continue
 		}
 		linesSeen[line] = struct{}{}
+		origin = []Line{{File: currentFile, Number: i + 1, Text: line}}
 		switch {
 		case line == "":
 			continue
@@ -554,6 +648,9 @@ func parseIgnoreFile(fs fs.Filesystem, fd io.Reader, currentFile string, cd Chan
 			includeFile := filepath.Join(filepath.Dir(currentFile), includeRel)
 			var includePatterns []Pattern
 			if includePatterns, err = loadParseIncludeFile(fs, includeFile, cd, linesSeen); err == nil {
+				for i := range includePatterns {
+					includePatterns[i].origin = append(origin[:1:1], includePatterns[i].origin...)
+				}
 				patterns = append(patterns, includePatterns...)
 			} else {
 				// Wrap the error, as if the include does not exist, we get a
@@ -562,15 +659,15 @@ func parseIgnoreFile(fs fs.Filesystem, fd io.Reader, currentFile string, cd Chan
 				// there is none, rather than a broken include.
 				err = parseError(fmt.Errorf("failed to load include file %s: %w", includeFile, err))
 			}
-		case strings.HasSuffix(line, "/**"):
-			err = addPattern(line)
-		case strings.HasSuffix(line, "/"):
-			err = addPattern(line + "**")
-		default:
-			err = addPattern(line)
-			if err == nil {
-				err = addPattern(line + "/**")
+		case strings.HasPrefix(line, "#only"):
+			fields := strings.SplitN(line, " ", 2)
+			if len(fields) != 2 || strings.TrimSpace(fields[1]) == "" {
+				err = parseError(errors.New("failed to parse #only line: no pattern?"))
+				break
 			}
+			err = addOnlyPatterns(strings.TrimSpace(fields[1]))
+		default:
+			err = addPatterns(line, addPattern)
 		}
 		if err != nil {
 			return lines, nil, err
@@ -580,6 +677,22 @@ func parseIgnoreFile(fs fs.Filesystem, fd io.Reader, currentFile string, cd Chan
 	return lines, patterns, nil
 }
 
+// addPatterns adds the patterns for a line, so that a directory matches
+// together with everything in it.
+func addPatterns(line string, addPattern func(string) error) error {
+	switch {
+	case strings.HasSuffix(line, "/**"):
+		return addPattern(line)
+	case strings.HasSuffix(line, "/"):
+		return addPattern(line + "**")
+	default:
+		if err := addPattern(line); err != nil {
+			return err
+		}
+		return addPattern(line + "/**")
+	}
+}
+
 // WriteIgnores is a convenience function to avoid code duplication
 func WriteIgnores(filesystem fs.Filesystem, path string, content []string) error {
 	if len(content) == 0 {
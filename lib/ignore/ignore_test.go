@@ -1192,3 +1192,91 @@ func TestEmptyPatterns(t *testing.T) {
 		}
 	}
 }
+
+func TestOnly(t *testing.T) {
+	stignore := `
+	/dir/skip.jpg
+	#only *.jpg
+	#only /docs
+	`
+	pats := New(fs.NewFilesystem(fs.FilesystemTypeBasic, "."), WithCache(true))
+	if err := pats.Parse(bytes.NewBufferString(stignore), ".stignore"); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		f string
+		r bool
+	}{
+		{"a.jpg", false},
+		{"a.png", true},
+		{"dir", true},
+		{filepath.Join("dir", "b.jpg"), false},
+		{filepath.Join("dir", "b.png"), true},
+		{filepath.Join("dir", "skip.jpg"), true},
+		{"docs", false},
+		{filepath.Join("docs", "c.png"), false},
+		{filepath.Join("dir", "docs"), true},
+	}
+	for i, tc := range tests {
+		if r := pats.Match(tc.f); r.IsIgnored() != tc.r {
+			t.Errorf("Incorrect ignoreFile() #%d (%s); E: %v, A: %v", i, tc.f, tc.r, r)
+		}
+	}
+
+	// The scanner must look for included files in ignored directories.
+	if pats.SkipIgnoredDirs() {
+		t.Error("SkipIgnoredDirs should be false")
+	}
+
+	for _, invalid := range []string{"#only", "#only !*.jpg", "#only (?d)*.jpg"} {
+		if err := pats.Parse(strings.NewReader(invalid), ".stignore"); !IsParseError(err) {
+			t.Errorf("%q: expected a parse error, got %v", invalid, err)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "more"), []byte("// more\n(?d)*.tmp\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, ".stignore"), []byte("!keep.tmp\n\n#include more\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pats := New(fs.NewFilesystem(fs.FilesystemTypeBasic, dir), WithCache(true))
+	if err := pats.Load(".stignore"); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := pats.Explain(filepath.Join("dir", "foo.tmp"))
+	if !exp.Ignored || !exp.Deletable || exp.Internal {
+		t.Errorf("Unexpected result %+v", exp)
+	}
+	expectedLines := []Line{
+		{File: ".stignore", Number: 3, Text: "#include more"},
+		{File: "more", Number: 2, Text: "(?d)*.tmp"},
+	}
+	if fmt.Sprint(exp.Lines) != fmt.Sprint(expectedLines) {
+		t.Errorf("Lines %v != expected %v", exp.Lines, expectedLines)
+	}
+
+	exp = pats.Explain("keep.tmp")
+	if exp.Ignored || len(exp.Lines) != 1 || exp.Lines[0].Number != 1 {
+		t.Errorf("Unexpected result %+v", exp)
+	}
+
+	if exp := pats.Explain("foo"); exp.Ignored || exp.Pattern != "" || exp.Lines != nil {
+		t.Errorf("Unexpected result %+v", exp)
+	}
+
+	if exp := pats.Explain(".stignore"); !exp.Ignored || !exp.Internal {
+		t.Errorf("Unexpected result %+v", exp)
+	}
+}
@@ -25,6 +25,8 @@ const (
 	ConfigFile    LocationEnum = "config"
 	CertFile      LocationEnum = "certFile"
 	KeyFile       LocationEnum = "keyFile"
+	CertNextFile  LocationEnum = "certNextFile"
+	KeyNextFile   LocationEnum = "keyNextFile"
 	HTTPSCertFile LocationEnum = "httpsCertFile"
 	HTTPSKeyFile  LocationEnum = "httpsKeyFile"
 	Database      LocationEnum = "database"
@@ -35,6 +37,8 @@ const (
 	GUIAssets     LocationEnum = "GUIAssets"
 	DefFolder     LocationEnum = "defFolder"
 	FailuresFile  LocationEnum = "FailuresFile"
+	RepairLog     LocationEnum = "repairLog"
+	ConfigAudit   LocationEnum = "configAudit"
 )
 
 type BaseDirEnum string
@@ -50,24 +54,73 @@ const (
 	BadgerDir  = "indexdb.badger"
 )
 
-// Platform dependent directories
-var baseDirs = make(map[BaseDirEnum]string, 3)
+// A Set is a set of base directories and the locations expanded from them.
+// The package level functions operate on the default set for the process;
+// separate sets allow several Syncthing instances in one process.
+type Set struct {
+	baseDirs  map[BaseDirEnum]string
+	locations map[LocationEnum]string
+}
+
+// The default set has the platform dependent directories
+var defaultSet = &Set{
+	baseDirs:  make(map[BaseDirEnum]string, 3),
+	locations: make(map[LocationEnum]string),
+}
 
 func init() {
 	userHome := userHomeDir()
 	config := defaultConfigDir(userHome)
-	baseDirs[UserHomeBaseDir] = userHome
-	baseDirs[ConfigBaseDir] = config
-	baseDirs[DataBaseDir] = defaultDataDir(userHome, config)
+	defaultSet.baseDirs[UserHomeBaseDir] = userHome
+	defaultSet.baseDirs[ConfigBaseDir] = config
+	defaultSet.baseDirs[DataBaseDir] = defaultDataDir(userHome, config)
 
-	err := expandLocations()
+	err := defaultSet.expandLocations()
 	if err != nil {
 		fmt.Println(err)
 		panic("Failed to expand locations at init time")
 	}
 }
 
+// Default returns the default set, as modified by SetBaseDir.
+func Default() *Set {
+	return defaultSet
+}
+
+// NewSet returns a set with the given config and data directories, and
+// the default user home directory.
+func NewSet(configDir, dataDir string) (*Set, error) {
+	s := &Set{
+		baseDirs: map[BaseDirEnum]string{
+			UserHomeBaseDir: defaultSet.baseDirs[UserHomeBaseDir],
+		},
+	}
+	if err := s.SetBaseDir(ConfigBaseDir, configDir); err != nil {
+		return nil, err
+	}
+	if err := s.SetBaseDir(DataBaseDir, dataDir); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
 func SetBaseDir(baseDirName BaseDirEnum, path string) error {
+	return defaultSet.SetBaseDir(baseDirName, path)
+}
+
+func Get(location LocationEnum) string {
+	return defaultSet.Get(location)
+}
+
+func GetBaseDir(baseDir BaseDirEnum) string {
+	return defaultSet.GetBaseDir(baseDir)
+}
+
+func GetTimestamped(key LocationEnum) string {
+	return defaultSet.GetTimestamped(key)
+}
+
+func (s *Set) SetBaseDir(baseDirName BaseDirEnum, path string) error {
 	if !filepath.IsAbs(path) {
 		var err error
 		path, err = filepath.Abs(path)
@@ -75,20 +128,21 @@ func SetBaseDir(baseDirName BaseDirEnum, path string) error {
 			return err
 		}
 	}
-	_, ok := baseDirs[baseDirName]
-	if !ok {
+	switch baseDirName {
+	case ConfigBaseDir, DataBaseDir, UserHomeBaseDir:
+	default:
 		return fmt.Errorf("unknown base dir: %s", baseDirName)
 	}
-	baseDirs[baseDirName] = filepath.Clean(path)
-	return expandLocations()
+	s.baseDirs[baseDirName] = filepath.Clean(path)
+	return s.expandLocations()
 }
 
-func Get(location LocationEnum) string {
-	return locations[location]
+func (s *Set) Get(location LocationEnum) string {
+	return s.locations[location]
 }
 
-func GetBaseDir(baseDir BaseDirEnum) string {
-	return baseDirs[baseDir]
+func (s *Set) GetBaseDir(baseDir BaseDirEnum) string {
+	return s.baseDirs[baseDir]
 }
 
 // Use the variables from baseDirs here
@@ -96,6 +150,8 @@ var locationTemplates = map[LocationEnum]string{
 	ConfigFile:    "${config}/config.xml",
 	CertFile:      "${config}/cert.pem",
 	KeyFile:       "${config}/key.pem",
+	CertNextFile:  "${config}/cert-next.pem",
+	KeyNextFile:   "${config}/key-next.pem",
 	HTTPSCertFile: "${config}/https-cert.pem",
 	HTTPSKeyFile:  "${config}/https-key.pem",
 	Database:      "${data}/" + LevelDBDir,
@@ -106,16 +162,16 @@ var locationTemplates = map[LocationEnum]string{
 	GUIAssets:     "${config}/gui",
 	DefFolder:     "${userHome}/Sync",
 	FailuresFile:  "${data}/failures-unreported.txt",
+	RepairLog:     "${data}/repairs.log",
+	ConfigAudit:   "${data}/config-audit.log",
 }
 
-var locations = make(map[LocationEnum]string)
-
 // expandLocations replaces the variables in the locations map with actual
 // directory locations.
-func expandLocations() error {
+func (s *Set) expandLocations() error {
 	newLocations := make(map[LocationEnum]string)
 	for key, dir := range locationTemplates {
-		for varName, value := range baseDirs {
+		for varName, value := range s.baseDirs {
 			dir = strings.Replace(dir, "${"+string(varName)+"}", value, -1)
 		}
 		var err error
@@ -125,7 +181,7 @@ func expandLocations() error {
 		}
 		newLocations[key] = filepath.Clean(dir)
 	}
-	locations = newLocations
+	s.locations = newLocations
 	return nil
 }
 
@@ -197,13 +253,13 @@ func userHomeDir() string {
 	return userHome
 }
 
-func GetTimestamped(key LocationEnum) string {
+func (s *Set) GetTimestamped(key LocationEnum) string {
 	// We take the roundtrip via "${timestamp}" instead of passing the path
 	// directly through time.Format() to avoid issues when the path we are
 	// expanding contains numbers; otherwise for example
 	// /home/user2006/.../panic-20060102-150405.log would get both instances of
 	// 2006 replaced by 2015...
-	tpl := locations[key]
+	tpl := s.locations[key]
 	now := time.Now().Format("20060102-150405")
 	return strings.Replace(tpl, "${timestamp}", now, -1)
 }
@@ -5,11 +5,14 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -33,6 +36,38 @@ const (
 	DebugFlags   = log.Ltime | log.Ldate | log.Lmicroseconds | log.Lshortfile
 )
 
+var (
+	levelPrefixes = [NumLevels]string{"DEBUG: ", "VERBOSE: ", "INFO: ", "WARNING: "}
+	levelNames    = [NumLevels]string{"debug", "verbose", "info", "warning"}
+)
+
+// A Format is the way log lines are written.
+type Format int
+
+const (
+	// FormatText writes plain text lines, prefixed with the time and level.
+	FormatText Format = iota
+	// FormatJSON writes a JSON object per line, with the time, level,
+	// facility and context fields as separate keys.
+	FormatJSON
+)
+
+// ParseFormat returns the log format with the given name.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "", "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return FormatText, fmt.Errorf("unknown log format %q", s)
+	}
+}
+
+type field struct {
+	key, value string
+}
+
 // A MessageHandler is called with the log level and message text.
 type MessageHandler func(l LogLevel, msg string)
 
@@ -40,6 +75,8 @@ type Logger interface {
 	AddHandler(level LogLevel, h MessageHandler)
 	SetFlags(flag int)
 	SetPrefix(prefix string)
+	SetFormat(format Format)
+	With(key, value string) Logger
 	Debugln(vals ...interface{})
 	Debugf(format string, vals ...interface{})
 	Verboseln(vals ...interface{})
@@ -58,6 +95,7 @@ type Logger interface {
 
 type logger struct {
 	logger     *log.Logger
+	format     Format
 	handlers   [NumLevels][]MessageHandler
 	facilities map[string]string   // facility name => description
 	debug      map[string]struct{} // only facility names with debugging enabled
@@ -112,82 +150,105 @@ func (l *logger) callHandlers(level LogLevel, s string) {
 	}
 }
 
-// Debugln logs a line with a DEBUG prefix.
-func (l *logger) Debugln(vals ...interface{}) {
-	l.debugln(3, vals...)
+// SetFormat selects how log lines are written.
+func (l *logger) SetFormat(format Format) {
+	l.mut.Lock()
+	l.format = format
+	l.mut.Unlock()
 }
-func (l *logger) debugln(level int, vals ...interface{}) {
-	s := fmt.Sprintln(vals...)
+
+// output writes the message in the selected format and passes it on to the
+// handlers. It must be called directly from the exported logging methods,
+// for the call depth to point at their caller.
+func (l *logger) output(level LogLevel, facility string, fields []field, s string) {
 	l.mut.Lock()
 	defer l.mut.Unlock()
-	l.logger.Output(level, "DEBUG: "+s)
-	l.callHandlers(LevelDebug, s)
+	if l.format == FormatJSON {
+		l.outputJSON(level, facility, fields, s)
+	} else {
+		l.logger.Output(3, levelPrefixes[level]+s)
+	}
+	l.callHandlers(level, s)
+}
+
+func (l *logger) outputJSON(level LogLevel, facility string, fields []field, s string) {
+	entry := make(map[string]interface{}, len(fields)+6)
+	for _, f := range fields {
+		entry[f.key] = f.value
+	}
+	entry["time"] = time.Now().Format(time.RFC3339Nano)
+	entry["level"] = levelNames[level]
+	entry["message"] = strings.TrimSpace(s)
+	if facility != "" {
+		entry["facility"] = facility
+	}
+	if prefix := strings.Trim(l.logger.Prefix(), "[] "); prefix != "" {
+		entry["prefix"] = prefix
+	}
+	if flags := l.logger.Flags(); flags&(log.Lshortfile|log.Llongfile) != 0 {
+		// Skip outputJSON, output and the logging method.
+		if _, file, line, ok := runtime.Caller(3); ok {
+			if flags&log.Lshortfile != 0 {
+				file = filepath.Base(file)
+			}
+			entry["caller"] = fmt.Sprintf("%s:%d", file, line)
+		}
+	}
+
+	bs, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	l.logger.Writer().Write(append(bs, '\n'))
+}
+
+// With returns a logger that adds the given context field, such as a
+// folder or device ID, to JSON formatted log lines.
+func (l *logger) With(key, value string) Logger {
+	return &facilityLogger{
+		logger: l,
+		fields: []field{{key, value}},
+	}
+}
+
+// Debugln logs a line with a DEBUG prefix.
+func (l *logger) Debugln(vals ...interface{}) {
+	l.output(LevelDebug, "", nil, fmt.Sprintln(vals...))
 }
 
 // Debugf logs a formatted line with a DEBUG prefix.
 func (l *logger) Debugf(format string, vals ...interface{}) {
-	l.debugf(3, format, vals...)
-}
-func (l *logger) debugf(level int, format string, vals ...interface{}) {
-	s := fmt.Sprintf(format, vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(level, "DEBUG: "+s)
-	l.callHandlers(LevelDebug, s)
+	l.output(LevelDebug, "", nil, fmt.Sprintf(format, vals...))
 }
 
 // Infoln logs a line with a VERBOSE prefix.
 func (l *logger) Verboseln(vals ...interface{}) {
-	s := fmt.Sprintln(vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "VERBOSE: "+s)
-	l.callHandlers(LevelVerbose, s)
+	l.output(LevelVerbose, "", nil, fmt.Sprintln(vals...))
 }
 
 // Infof logs a formatted line with a VERBOSE prefix.
 func (l *logger) Verbosef(format string, vals ...interface{}) {
-	s := fmt.Sprintf(format, vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "VERBOSE: "+s)
-	l.callHandlers(LevelVerbose, s)
+	l.output(LevelVerbose, "", nil, fmt.Sprintf(format, vals...))
 }
 
 // Infoln logs a line with an INFO prefix.
 func (l *logger) Infoln(vals ...interface{}) {
-	s := fmt.Sprintln(vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "INFO: "+s)
-	l.callHandlers(LevelInfo, s)
+	l.output(LevelInfo, "", nil, fmt.Sprintln(vals...))
 }
 
 // Infof logs a formatted line with an INFO prefix.
 func (l *logger) Infof(format string, vals ...interface{}) {
-	s := fmt.Sprintf(format, vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "INFO: "+s)
-	l.callHandlers(LevelInfo, s)
+	l.output(LevelInfo, "", nil, fmt.Sprintf(format, vals...))
 }
 
 // Warnln logs a formatted line with a WARNING prefix.
 func (l *logger) Warnln(vals ...interface{}) {
-	s := fmt.Sprintln(vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "WARNING: "+s)
-	l.callHandlers(LevelWarn, s)
+	l.output(LevelWarn, "", nil, fmt.Sprintln(vals...))
 }
 
 // Warnf logs a formatted line with a WARNING prefix.
 func (l *logger) Warnf(format string, vals ...interface{}) {
-	s := fmt.Sprintf(format, vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "WARNING: "+s)
-	l.callHandlers(LevelWarn, s)
+	l.output(LevelWarn, "", nil, fmt.Sprintf(format, vals...))
 }
 
 // ShouldDebug returns true if the given facility has debugging enabled.
@@ -262,6 +323,19 @@ func (l *logger) NewFacility(facility, description string) Logger {
 type facilityLogger struct {
 	*logger
 	facility string
+	fields   []field
+}
+
+// With returns a logger that adds the given context field, such as a
+// folder or device ID, to JSON formatted log lines.
+func (l *facilityLogger) With(key, value string) Logger {
+	fields := make([]field, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+	return &facilityLogger{
+		logger:   l.logger,
+		facility: l.facility,
+		fields:   append(fields, field{key, value}),
+	}
 }
 
 // Debugln logs a line with a DEBUG prefix.
@@ -269,7 +343,7 @@ func (l *facilityLogger) Debugln(vals ...interface{}) {
 	if !l.ShouldDebug(l.facility) {
 		return
 	}
-	l.logger.debugln(3, vals...)
+	l.logger.output(LevelDebug, l.facility, l.fields, fmt.Sprintln(vals...))
 }
 
 // Debugf logs a formatted line with a DEBUG prefix.
@@ -277,7 +351,37 @@ func (l *facilityLogger) Debugf(format string, vals ...interface{}) {
 	if !l.ShouldDebug(l.facility) {
 		return
 	}
-	l.logger.debugf(3, format, vals...)
+	l.logger.output(LevelDebug, l.facility, l.fields, fmt.Sprintf(format, vals...))
+}
+
+// Verboseln logs a line with a VERBOSE prefix.
+func (l *facilityLogger) Verboseln(vals ...interface{}) {
+	l.logger.output(LevelVerbose, l.facility, l.fields, fmt.Sprintln(vals...))
+}
+
+// Verbosef logs a formatted line with a VERBOSE prefix.
+func (l *facilityLogger) Verbosef(format string, vals ...interface{}) {
+	l.logger.output(LevelVerbose, l.facility, l.fields, fmt.Sprintf(format, vals...))
+}
+
+// Infoln logs a line with an INFO prefix.
+func (l *facilityLogger) Infoln(vals ...interface{}) {
+	l.logger.output(LevelInfo, l.facility, l.fields, fmt.Sprintln(vals...))
+}
+
+// Infof logs a formatted line with an INFO prefix.
+func (l *facilityLogger) Infof(format string, vals ...interface{}) {
+	l.logger.output(LevelInfo, l.facility, l.fields, fmt.Sprintf(format, vals...))
+}
+
+// Warnln logs a line with a WARNING prefix.
+func (l *facilityLogger) Warnln(vals ...interface{}) {
+	l.logger.output(LevelWarn, l.facility, l.fields, fmt.Sprintln(vals...))
+}
+
+// Warnf logs a formatted line with a WARNING prefix.
+func (l *facilityLogger) Warnf(format string, vals ...interface{}) {
+	l.logger.output(LevelWarn, l.facility, l.fields, fmt.Sprintf(format, vals...))
 }
 
 // A Recorder keeps a size limited record of log events.
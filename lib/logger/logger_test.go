@@ -5,6 +5,7 @@ package logger
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -185,6 +186,62 @@ func TestControlStripper(t *testing.T) {
 	}
 }
 
+func TestJSONFormat(t *testing.T) {
+	b := new(bytes.Buffer)
+	l := newLogger(b)
+	l.SetFlags(log.Lshortfile)
+	l.SetPrefix("[ABCDE] ")
+	l.SetFormat(FormatJSON)
+
+	f := l.NewFacility("model", "The root hub").With("folder", "abcd-1234")
+	f.Warnf("testing %d", 42)
+
+	var entry map[string]string
+	if err := json.Unmarshal(b.Bytes(), &entry); err != nil {
+		t.Fatalf("not a JSON line: %q: %v", b.String(), err)
+	}
+	expected := map[string]string{
+		"level":    "warning",
+		"message":  "testing 42",
+		"facility": "model",
+		"prefix":   "ABCDE",
+		"folder":   "abcd-1234",
+	}
+	for key, val := range expected {
+		if entry[key] != val {
+			t.Errorf("%s: expected %q, got %q", key, val, entry[key])
+		}
+	}
+	if _, err := time.Parse(time.RFC3339Nano, entry["time"]); err != nil {
+		t.Error("bad time:", err)
+	}
+	if !strings.HasPrefix(entry["caller"], "logger_test.go:") {
+		t.Errorf("should identify this file as the source, got %q", entry["caller"])
+	}
+	if !strings.HasSuffix(b.String(), "}\n") {
+		t.Errorf("expected a single line, got %q", b.String())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	cases := []struct {
+		in  string
+		out Format
+		ok  bool
+	}{
+		{"", FormatText, true},
+		{"text", FormatText, true},
+		{"JSON", FormatJSON, true},
+		{"xml", FormatText, false},
+	}
+	for _, tc := range cases {
+		format, err := ParseFormat(tc.in)
+		if format != tc.out || (err == nil) != tc.ok {
+			t.Errorf("ParseFormat(%q) = %v, %v", tc.in, format, err)
+		}
+	}
+}
+
 func BenchmarkLog(b *testing.B) {
 	l := newLogger(controlStripper{ioutil.Discard})
 	benchmarkLogger(b, l)
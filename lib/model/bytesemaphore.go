@@ -14,8 +14,10 @@ import (
 type byteSemaphore struct {
 	max       int
 	available int
-	mut       sync.Mutex
-	cond      *sync.Cond
+	// The number of takers waiting, by priority.
+	waiting map[int]int
+	mut     sync.Mutex
+	cond    *sync.Cond
 }
 
 func newByteSemaphore(max int) *byteSemaphore {
@@ -25,16 +27,23 @@ func newByteSemaphore(max int) *byteSemaphore {
 	s := byteSemaphore{
 		max:       max,
 		available: max,
+		waiting:   make(map[int]int),
 	}
 	s.cond = sync.NewCond(&s.mut)
 	return &s
 }
 
 func (s *byteSemaphore) takeWithContext(ctx context.Context, bytes int) error {
+	return s.takeWithPriority(ctx, bytes, 0)
+}
+
+// takeWithPriority is like takeWithContext, but doesn't take anything while
+// there are takers with a higher priority waiting.
+func (s *byteSemaphore) takeWithPriority(ctx context.Context, bytes, priority int) error {
 	done := make(chan struct{})
 	var err error
 	go func() {
-		err = s.takeInner(ctx, bytes)
+		err = s.takeInner(ctx, bytes, priority)
 		close(done)
 	}()
 	select {
@@ -47,10 +56,10 @@ func (s *byteSemaphore) takeWithContext(ctx context.Context, bytes int) error {
 }
 
 func (s *byteSemaphore) take(bytes int) {
-	_ = s.takeInner(context.Background(), bytes)
+	_ = s.takeInner(context.Background(), bytes, 0)
 }
 
-func (s *byteSemaphore) takeInner(ctx context.Context, bytes int) error {
+func (s *byteSemaphore) takeInner(ctx context.Context, bytes, priority int) error {
 	// Checking context for bytes <= s.available is required for testing and doesn't do any harm.
 	select {
 	case <-ctx.Done():
@@ -62,7 +71,17 @@ func (s *byteSemaphore) takeInner(ctx context.Context, bytes int) error {
 	if bytes > s.max {
 		bytes = s.max
 	}
-	for bytes > s.available {
+	if bytes > s.available || s.higherWaitingLocked(priority) {
+		s.waiting[priority]++
+		defer func() {
+			if s.waiting[priority]--; s.waiting[priority] == 0 {
+				delete(s.waiting, priority)
+			}
+			// Takers with a lower priority may go ahead now.
+			s.cond.Broadcast()
+		}()
+	}
+	for bytes > s.available || s.higherWaitingLocked(priority) {
 		s.cond.Wait()
 		select {
 		case <-ctx.Done():
@@ -77,6 +96,15 @@ func (s *byteSemaphore) takeInner(ctx context.Context, bytes int) error {
 	return nil
 }
 
+func (s *byteSemaphore) higherWaitingLocked(priority int) bool {
+	for p := range s.waiting {
+		if p > priority {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *byteSemaphore) give(bytes int) {
 	s.mut.Lock()
 	if bytes > s.max {
@@ -6,7 +6,11 @@
 
 package model
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+)
 
 func TestZeroByteSempahore(t *testing.T) {
 	// A semaphore with zero capacity is just a no-op.
@@ -111,3 +115,42 @@ func TestByteSempahoreGiveMore(t *testing.T) {
 		t.Errorf("bad state after large take + give with adjustment")
 	}
 }
+
+func TestByteSemaphorePriority(t *testing.T) {
+	// Waiting takers with a higher priority go first
+
+	s := newByteSemaphore(100)
+	s.take(100)
+
+	order := make(chan int, 2)
+	takeAndGive := func(priority int) {
+		if err := s.takeWithPriority(context.Background(), 100, priority); err != nil {
+			t.Error(err)
+		}
+		order <- priority
+		s.give(100)
+	}
+	go takeAndGive(0)
+	waitForWaiting(t, s, 0)
+	go takeAndGive(1)
+	waitForWaiting(t, s, 1)
+
+	s.give(100)
+	if first, second := <-order, <-order; first != 1 || second != 0 {
+		t.Errorf("Expected priority 1 to go first, got %d then %d", first, second)
+	}
+}
+
+func waitForWaiting(t *testing.T, s *byteSemaphore, priority int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		s.mut.Lock()
+		n := s.waiting[priority]
+		s.mut.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Timed out waiting for a taker")
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// Observations closer together than this are merged with the next one,
+	// as the completion is calculated often in bursts.
+	completionRateMinInterval = time.Second
+	// The time constant of the moving average of the rate.
+	completionRateTimeConstant = 30 * time.Second
+	// The rate history has a sample every completionRateSampleInterval,
+	// spanning completionRateHistoryLength of them.
+	completionRateSampleInterval = 10 * time.Second
+	completionRateHistoryLength  = 60
+	// Rates below this are considered standing still, i.e. there is no
+	// meaningful estimate of the remaining time.
+	completionRateMinBytesPerSecond = 1
+)
+
+// RateSample is the transfer rate of a folder to a device at some point in
+// time.
+type RateSample struct {
+	Time           time.Time `json:"time"`
+	BytesPerSecond float64   `json:"bytesPerSecond"`
+}
+
+type completionRateKey struct {
+	device protocol.DeviceID
+	folder string // empty for all folders shared with the device
+}
+
+// completionRates keeps track of how fast folders are completed on devices,
+// from how the amount of data they still need changes each time the
+// completion is calculated.
+type completionRates struct {
+	mut   sync.Mutex
+	rates map[completionRateKey]*completionRate
+}
+
+type completionRate struct {
+	lastNeed       int64
+	lastUpdate     time.Time
+	bytesPerSecond float64 // moving average
+	history        []RateSample
+}
+
+func newCompletionRates() *completionRates {
+	return &completionRates{
+		mut:   sync.NewMutex(),
+		rates: make(map[completionRateKey]*completionRate),
+	}
+}
+
+// update records the amount of data needed by the device for the folder at
+// the given time, and sets the rate, remaining time and history of the
+// completion accordingly.
+func (r *completionRates) update(device protocol.DeviceID, folder string, comp *FolderCompletion, now time.Time) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	key := completionRateKey{device, folder}
+	rate, ok := r.rates[key]
+	if !ok {
+		rate = &completionRate{lastNeed: comp.NeedBytes, lastUpdate: now}
+		r.rates[key] = rate
+	}
+
+	if dt := now.Sub(rate.lastUpdate); dt >= completionRateMinInterval {
+		// Data that has become needed in the meantime, because of changes
+		// elsewhere, doesn't count as negative progress.
+		var done int64
+		if rate.lastNeed > comp.NeedBytes {
+			done = rate.lastNeed - comp.NeedBytes
+		}
+		current := float64(done) / dt.Seconds()
+		// The weight of the current observation depends on how long it
+		// spans, as the completion isn't calculated at regular intervals.
+		weight := 1 - math.Exp(-float64(dt)/float64(completionRateTimeConstant))
+		rate.bytesPerSecond += weight * (current - rate.bytesPerSecond)
+		rate.lastNeed = comp.NeedBytes
+		rate.lastUpdate = now
+
+		if n := len(rate.history); n == 0 || now.Sub(rate.history[n-1].Time) >= completionRateSampleInterval {
+			rate.history = append(rate.history, RateSample{Time: now, BytesPerSecond: rate.bytesPerSecond})
+			if len(rate.history) > completionRateHistoryLength {
+				rate.history = rate.history[len(rate.history)-completionRateHistoryLength:]
+			}
+		}
+	}
+
+	comp.BytesPerSecond = rate.bytesPerSecond
+	comp.RateHistory = append([]RateSample(nil), rate.history...)
+	switch {
+	case comp.NeedBytes == 0:
+		comp.Remaining = 0
+	case rate.bytesPerSecond < completionRateMinBytesPerSecond:
+		comp.Remaining = -1
+	default:
+		comp.Remaining = time.Duration(float64(comp.NeedBytes) / rate.bytesPerSecond * float64(time.Second))
+	}
+}
+
+// forgetDevice drops what is known about the given device, e.g. because it
+// disconnected and the rates no longer apply.
+func (r *completionRates) forgetDevice(device protocol.DeviceID) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	for key := range r.rates {
+		if key.device == device {
+			delete(r.rates, key)
+		}
+	}
+}
+
+// forgetFolder drops what is known about the given folder, and all folders
+// in aggregate as they include the given one.
+func (r *completionRates) forgetFolder(folder string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	for key := range r.rates {
+		if key.folder == folder || key.folder == "" {
+			delete(r.rates, key)
+		}
+	}
+}
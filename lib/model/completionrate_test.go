@@ -0,0 +1,67 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompletionRates(t *testing.T) {
+	rates := newCompletionRates()
+	now := time.Now()
+
+	comp := FolderCompletion{NeedBytes: 400 << 20}
+	rates.update(device1, "default", &comp, now)
+	if comp.Remaining != -1 || comp.BytesPerSecond != 0 {
+		t.Fatalf("expected unknown remaining time at first, got %v at %v B/s", comp.Remaining, comp.BytesPerSecond)
+	}
+
+	// A steady 1 MiB/s for five minutes.
+	for i := 0; i < 300; i++ {
+		now = now.Add(time.Second)
+		comp = FolderCompletion{NeedBytes: comp.NeedBytes - 1<<20}
+		rates.update(device1, "default", &comp, now)
+	}
+	if comp.BytesPerSecond < 0.99*(1<<20) || comp.BytesPerSecond > 1<<20 {
+		t.Errorf("expected a rate close to 1 MiB/s, got %v", comp.BytesPerSecond)
+	}
+	if comp.Remaining < 95*time.Second || comp.Remaining > 101*time.Second {
+		t.Errorf("expected about 100s remaining, got %v", comp.Remaining)
+	}
+	if len(comp.RateHistory) != 30 {
+		t.Errorf("expected 30 history samples, got %v", len(comp.RateHistory))
+	}
+
+	// Calls in quick succession don't count as standing still.
+	rate := comp.BytesPerSecond
+	comp = FolderCompletion{NeedBytes: comp.NeedBytes}
+	rates.update(device1, "default", &comp, now.Add(time.Millisecond))
+	if comp.BytesPerSecond != rate {
+		t.Errorf("rate changed from %v to %v", rate, comp.BytesPerSecond)
+	}
+
+	// Other folders and devices are separate.
+	other := FolderCompletion{NeedBytes: 1}
+	rates.update(device2, "default", &other, now)
+	if other.BytesPerSecond != 0 || len(other.RateHistory) != 0 {
+		t.Error("rate of one device leaked into another")
+	}
+
+	rates.forgetDevice(device1)
+	comp = FolderCompletion{NeedBytes: comp.NeedBytes}
+	rates.update(device1, "default", &comp, now.Add(time.Minute))
+	if comp.Remaining != -1 {
+		t.Error("expected unknown remaining time after forgetting the device, got", comp.Remaining)
+	}
+
+	comp = FolderCompletion{}
+	rates.update(device1, "default", &comp, now.Add(2*time.Minute))
+	if comp.Remaining != 0 {
+		t.Error("expected no remaining time when nothing is needed, got", comp.Remaining)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+var conflictNameExp = regexp.MustCompile(`^(.*)\.sync-conflict-(\d{8}-\d{6})-([A-Z0-9]{7})([^/\\]*)$`)
+
+// A Conflict is a sync-conflict copy that is still present in a folder.
+type Conflict struct {
+	Name     string    `json:"name"`
+	Original string    `json:"original"`
+	Time     time.Time `json:"time"`
+	// The device that made the change that was preferred over the one
+	// kept in the conflict copy.
+	ModifiedBy string    `json:"modifiedBy"`
+	Size       int64     `json:"size"`
+	ModTime    time.Time `json:"modTime"`
+}
+
+// parseConflictName returns the name of the file a conflict copy was made
+// of, the time of the conflict and the short ID of the device, as encoded
+// in the name by conflictName.
+func parseConflictName(name string) (original string, when time.Time, by string, ok bool) {
+	m := conflictNameExp.FindStringSubmatch(name)
+	if m == nil {
+		return "", time.Time{}, "", false
+	}
+	when, err := time.ParseInLocation("20060102-150405", m[2], time.Local)
+	if err != nil {
+		return "", time.Time{}, "", false
+	}
+	return m[1] + m[4], when, m[3], true
+}
+
+type conflictResolution int
+
+const (
+	conflictKeepBoth conflictResolution = iota
+	conflictKeepRemote
+	conflictKeepLocal
+)
+
+// resolveConflict decides according to the conflict policy which of the
+// local file and the conflicting remote one to keep. The remote file is the
+// one that won the conflict in the global state.
+func resolveConflict(policy config.ConflictPolicy, preferred protocol.DeviceID, local, remote protocol.FileInfo) conflictResolution {
+	switch policy {
+	case config.ConflictPolicyKeepNewest:
+		if local.ModTime().After(remote.ModTime()) {
+			return conflictKeepLocal
+		}
+		return conflictKeepRemote
+
+	case config.ConflictPolicyKeepLargest:
+		if local.Size > remote.Size {
+			return conflictKeepLocal
+		}
+		return conflictKeepRemote
+
+	case config.ConflictPolicyPreferDevice:
+		if preferred == protocol.EmptyDeviceID {
+			break
+		}
+		switch preferred.Short() {
+		case remote.ModifiedBy:
+			return conflictKeepRemote
+		case local.ModifiedBy:
+			return conflictKeepLocal
+		}
+	}
+	return conflictKeepBoth
+}
+
+// Conflicts returns the sync-conflict copies present in the folder.
+func (m *model) Conflicts(folder string) ([]Conflict, error) {
+	m.fmut.RLock()
+	rf, ok := m.folderFiles[folder]
+	m.fmut.RUnlock()
+	if !ok {
+		return nil, errFolderMissing
+	}
+
+	snap := rf.Snapshot()
+	defer snap.Release()
+
+	conflicts := make([]Conflict, 0)
+	snap.WithHaveTruncated(protocol.LocalDeviceID, func(f protocol.FileIntf) bool {
+		if f.IsDeleted() || f.IsInvalid() || f.IsDirectory() {
+			return true
+		}
+		original, when, by, ok := parseConflictName(f.FileName())
+		if !ok {
+			return true
+		}
+		conflicts = append(conflicts, Conflict{
+			Name:       f.FileName(),
+			Original:   original,
+			Time:       when,
+			ModifiedBy: by,
+			Size:       f.FileSize(),
+			ModTime:    f.ModTime(),
+		})
+		return true
+	})
+	return conflicts, nil
+}
+
+// ConflictCount returns the number of sync-conflict copies in the folder, as
+// counted by the last periodic conflict cleanup.
+func (m *model) ConflictCount(folder string) int {
+	m.fmut.RLock()
+	runner, ok := m.folderRunners[folder]
+	m.fmut.RUnlock()
+	if !ok {
+		return 0
+	}
+	return runner.ConflictCount()
+}
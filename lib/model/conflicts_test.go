@@ -0,0 +1,62 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestParseConflictName(t *testing.T) {
+	name := conflictName("dir/file.txt", device1.Short().String())
+	original, when, by, ok := parseConflictName(name)
+	if !ok {
+		t.Fatalf("failed to parse %q", name)
+	}
+	if original != "dir/file.txt" {
+		t.Errorf("original %q != expected %q", original, "dir/file.txt")
+	}
+	if by != device1.Short().String() {
+		t.Errorf("device %q != expected %q", by, device1.Short())
+	}
+	if d := time.Since(when); d < 0 || d > time.Minute {
+		t.Errorf("unexpected conflict time %v", when)
+	}
+
+	for _, name := range []string{"file.txt", "file.sync-conflict-2021.txt", "dir.sync-conflict-20210101-120000-ABCDEFG/file"} {
+		if _, _, _, ok := parseConflictName(name); ok {
+			t.Errorf("%q shouldn't be parsed as a conflict", name)
+		}
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	now := time.Now()
+	local := protocol.FileInfo{Size: 10, ModifiedS: now.Unix(), ModifiedBy: device1.Short()}
+	remote := protocol.FileInfo{Size: 20, ModifiedS: now.Add(-time.Hour).Unix(), ModifiedBy: device2.Short()}
+
+	cases := []struct {
+		policy    config.ConflictPolicy
+		preferred protocol.DeviceID
+		expected  conflictResolution
+	}{
+		{config.ConflictPolicyKeepBoth, protocol.EmptyDeviceID, conflictKeepBoth},
+		{config.ConflictPolicyKeepNewest, protocol.EmptyDeviceID, conflictKeepLocal},
+		{config.ConflictPolicyKeepLargest, protocol.EmptyDeviceID, conflictKeepRemote},
+		{config.ConflictPolicyPreferDevice, device1, conflictKeepLocal},
+		{config.ConflictPolicyPreferDevice, device2, conflictKeepRemote},
+		{config.ConflictPolicyPreferDevice, protocol.EmptyDeviceID, conflictKeepBoth},
+	}
+	for _, tc := range cases {
+		if res := resolveConflict(tc.policy, tc.preferred, local, remote); res != tc.expected {
+			t.Errorf("%v (%v): resolution %v != expected %v", tc.policy, tc.preferred, res, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+)
+
+// DatabaseSize is the size of the database on disk, along with the
+// approximate amount of data stored for each folder.
+type DatabaseSize struct {
+	Total   int64            `json:"total"`
+	Folders map[string]int64 `json:"folders"`
+}
+
+// CompactDatabase compacts the database, reclaiming the space used by
+// deleted and overwritten entries.
+func (m *model) CompactDatabase() error {
+	l.Infoln("Compacting database")
+	return m.db.Compact()
+}
+
+// ReadDatabase calls fn with a consistent snapshot of the whole database,
+// for backups.
+func (m *model) ReadDatabase(fn func(backend.Reader) error) error {
+	snap, err := m.db.NewReadTransaction()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	return fn(snap)
+}
+
+// CheckDatabase checks the database entries of the given folder, or of all
+// running folders if none is given, for consistency and repairs them.
+func (m *model) CheckDatabase(folder string) (map[string]db.CheckResult, error) {
+	fsets, err := m.runningFileSets(folder)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]db.CheckResult, len(fsets))
+	for id, fset := range fsets {
+		res, err := fset.Check()
+		if err != nil {
+			return nil, fmt.Errorf("checking folder %s: %w", id, err)
+		}
+		results[id] = res
+	}
+	return results, nil
+}
+
+// DatabaseSize returns the size of the database, and the approximate
+// amount of it used by each running folder.
+func (m *model) DatabaseSize() (DatabaseSize, error) {
+	fsets, err := m.runningFileSets("")
+	if err != nil {
+		return DatabaseSize{}, err
+	}
+	size := DatabaseSize{Folders: make(map[string]int64, len(fsets))}
+	for id, fset := range fsets {
+		if size.Folders[id], err = fset.DatabaseSize(); err != nil {
+			return DatabaseSize{}, fmt.Errorf("folder %s: %w", id, err)
+		}
+	}
+	if location := m.db.Location(); location != "" {
+		err = filepath.Walk(location, func(_ string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode().IsRegular() {
+				size.Total += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return DatabaseSize{}, err
+		}
+	}
+	return size, nil
+}
+
+// runningFileSets returns the file set of the given folder, or those of all
+// running folders if folder is empty.
+func (m *model) runningFileSets(folder string) (map[string]*db.FileSet, error) {
+	m.fmut.RLock()
+	defer m.fmut.RUnlock()
+
+	if folder != "" {
+		if err := m.checkFolderRunningLocked(folder); err != nil {
+			return nil, err
+		}
+		return map[string]*db.FileSet{folder: m.folderFiles[folder]}, nil
+	}
+
+	fsets := make(map[string]*db.FileSet, len(m.folderRunners))
+	for id := range m.folderRunners {
+		fsets[id] = m.folderFiles[id]
+	}
+	return fsets, nil
+}
@@ -7,22 +7,30 @@
 package model
 
 import (
+	"time"
+
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/sync"
 )
 
 // deviceActivity tracks the number of outstanding requests per device and can
-// answer which device is least busy. It is safe for use from multiple
-// goroutines.
+// answer which device is least busy, or fastest. It is safe for use from
+// multiple goroutines.
 type deviceActivity struct {
-	act map[protocol.DeviceID]int
-	mut sync.Mutex
+	act  map[protocol.DeviceID]int
+	rate map[protocol.DeviceID]float64 // moving average of bytes per second
+	mut  sync.Mutex
 }
 
+// The weight of the latest request in the moving average of the transfer
+// rate.
+const deviceRateWeight = 0.2
+
 func newDeviceActivity() *deviceActivity {
 	return &deviceActivity{
-		act: make(map[protocol.DeviceID]int),
-		mut: sync.NewMutex(),
+		act:  make(map[protocol.DeviceID]int),
+		rate: make(map[protocol.DeviceID]float64),
+		mut:  sync.NewMutex(),
 	}
 }
 
@@ -42,6 +50,42 @@ func (m *deviceActivity) leastBusy(availability []Availability) (Availability, b
 	return selected, found
 }
 
+// fastest returns the device we expect to get a block from the soonest,
+// given its past transfer rate shared among its outstanding requests. If we
+// haven't got anything from any of the devices yet, it is the least busy
+// one.
+func (m *deviceActivity) fastest(availability []Availability) (Availability, bool) {
+	m.mut.Lock()
+	best := 0.0
+	var selected Availability
+	for _, info := range availability {
+		if rate := m.rate[info.ID] / float64(m.act[info.ID]+1); rate > best {
+			best = rate
+			selected = info
+		}
+	}
+	m.mut.Unlock()
+	if best == 0 {
+		return m.leastBusy(availability)
+	}
+	return selected, true
+}
+
+// transferred records that a request for the given number of bytes took the
+// given time to complete.
+func (m *deviceActivity) transferred(availability Availability, bytes int, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	rate := float64(bytes) / d.Seconds()
+	m.mut.Lock()
+	if cur, ok := m.rate[availability.ID]; ok {
+		rate = cur + deviceRateWeight*(rate-cur)
+	}
+	m.rate[availability.ID] = rate
+	m.mut.Unlock()
+}
+
 func (m *deviceActivity) using(availability Availability) {
 	m.mut.Lock()
 	m.act[availability.ID]++
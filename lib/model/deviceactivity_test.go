@@ -8,6 +8,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/protocol"
 )
@@ -58,3 +59,31 @@ func TestDeviceActivity(t *testing.T) {
 		t.Errorf("Least busy device should be n0 (%v) not %v", n0, lb)
 	}
 }
+
+func TestDeviceActivityFastest(t *testing.T) {
+	n0 := Availability{protocol.DeviceID([32]byte{1, 2, 3, 4}), false}
+	n1 := Availability{protocol.DeviceID([32]byte{5, 6, 7, 8}), false}
+	devices := []Availability{n0, n1}
+	na := newDeviceActivity()
+
+	// Without any transfers, it's the least busy.
+	na.using(n0)
+	if d, ok := na.fastest(devices); !ok || d != n1 {
+		t.Errorf("Fastest device should be n1 (%v) not %v", n1, d)
+	}
+	na.done(n0)
+
+	na.transferred(n0, 1<<20, time.Second)
+	na.transferred(n1, 1<<20, 100*time.Millisecond)
+	if d, ok := na.fastest(devices); !ok || d != n1 {
+		t.Errorf("Fastest device should be n1 (%v) not %v", n1, d)
+	}
+
+	// Outstanding requests share the rate.
+	for i := 0; i < 10; i++ {
+		na.using(n1)
+	}
+	if d, ok := na.fastest(devices); !ok || d != n0 {
+		t.Errorf("Fastest device should be n0 (%v) not %v", n0, d)
+	}
+}
@@ -26,6 +26,8 @@ type fakeConnection struct {
 	testutils.FakeConnectionInfo
 	id                       protocol.DeviceID
 	downloadProgressMessages []downloadProgressMessage
+	configPushes             []protocol.ConfigPush
+	upgradeResponses         []protocol.UpgradeResponse
 	closed                   bool
 	files                    []protocol.FileInfo
 	fileData                 map[string][]byte
@@ -82,13 +84,14 @@ func (f *fakeConnection) IndexUpdate(ctx context.Context, folder string, fs []pr
 	return nil
 }
 
-func (f *fakeConnection) Request(ctx context.Context, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error) {
+func (f *fakeConnection) Request(ctx context.Context, folder, name string, blockNo int, offset int64, size int, hash []byte, hashAlgo protocol.BlockHashAlgorithm, weakHash uint32, fromTemporary bool) ([]byte, bool, error) {
 	f.mut.Lock()
 	defer f.mut.Unlock()
 	if f.requestFn != nil {
-		return f.requestFn(ctx, folder, name, offset, size, hash, fromTemporary)
+		data, err := f.requestFn(ctx, folder, name, offset, size, hash, fromTemporary)
+		return data, false, err
 	}
-	return f.fileData[name], nil
+	return f.fileData[name], false, nil
 }
 
 func (f *fakeConnection) ClusterConfig(cc protocol.ClusterConfig) {
@@ -122,9 +125,39 @@ func (f *fakeConnection) DownloadProgress(_ context.Context, folder string, upda
 	})
 }
 
+func (f *fakeConnection) ConfigPush(_ context.Context, push protocol.ConfigPush) error {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.configPushes = append(f.configPushes, push)
+	return nil
+}
+
+func (f *fakeConnection) UpgradeRequest(_ context.Context, version string, checkOnly bool) (protocol.UpgradeResponse, error) {
+	return protocol.UpgradeResponse{
+		Running:  "v1.0.0",
+		Latest:   version,
+		Upgraded: !checkOnly,
+	}, nil
+}
+
+func (f *fakeConnection) UpgradeResponse(_ context.Context, resp protocol.UpgradeResponse) error {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.upgradeResponses = append(f.upgradeResponses, resp)
+	return nil
+}
+
+func (f *fakeConnection) Benchmark(_ context.Context, duration time.Duration) (protocol.BenchmarkResult, error) {
+	return protocol.BenchmarkResult{
+		Duration: duration,
+		Bytes:    int64(duration.Seconds() * (10 << 20)),
+		RTT:      time.Millisecond,
+	}, nil
+}
+
 func (f *fakeConnection) addFileLocked(name string, flags uint32, ftype protocol.FileInfoType, data []byte, version protocol.Vector) {
 	blockSize := protocol.BlockSize(int64(len(data)))
-	blocks, _ := scanner.Blocks(context.TODO(), bytes.NewReader(data), blockSize, int64(len(data)), nil, true)
+	blocks, _ := scanner.Blocks(context.TODO(), bytes.NewReader(data), blockSize, int64(len(data)), nil, protocol.BlockHashAlgorithmSHA256, true)
 
 	if ftype == protocol.FileInfoTypeFile || ftype == protocol.FileInfoTypeDirectory {
 		f.files = append(f.files, protocol.FileInfo{
@@ -22,7 +22,7 @@ import (
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/ignore"
-	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/logger"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/scanner"
@@ -33,15 +33,41 @@ import (
 	"github.com/syncthing/syncthing/lib/watchaggregator"
 )
 
+const (
+	// How often a folder with a schedule checks it, at the least.
+	scheduleCheckInterval = 10 * time.Minute
+	// How often sync-conflict copies are counted and expired.
+	conflictCleanupInterval = time.Hour
+	// How often deleted items past their retention are forgotten.
+	tombstoneGCInterval = 6 * time.Hour
+	// How often a folder that is out of disk space checks whether there is
+	// enough again.
+	outOfDiskRetryInterval = time.Minute
+)
+
+// outOfDiskError is the folder error while the folder or the database is
+// on a disk with less free space than configured.
+type outOfDiskError struct {
+	what string // "folder" or "database"
+	path string
+	err  error
+}
+
+func (e *outOfDiskError) Error() string {
+	return fmt.Sprintf("out of disk space for %s (%v): %v", e.what, e.path, e.err)
+}
+
 type folder struct {
 	stateTracker
 	config.FolderConfiguration
 	*stats.FolderStatisticsReference
-	ioLimiter *byteSemaphore
+	ioLimiter    *byteSemaphore
+	scanThrottle *scanThrottle
 
 	localFlags uint32
 
 	model         *model
+	log           logger.Logger
 	shortID       protocol.ShortID
 	fset          *db.FileSet
 	ignores       *ignore.Matcher
@@ -51,19 +77,38 @@ type folder struct {
 	done          chan struct{}   // used externally, accessible regardless of serve
 
 	scanInterval           time.Duration
+	scanCron               *config.CronSchedule // instead of the interval, if set
+	scanJitter             time.Duration
 	scanTimer              *time.Timer
 	scanDelay              chan time.Duration
 	initialScanFinished    chan struct{}
 	versionCleanupInterval time.Duration
 	versionCleanupTimer    *time.Timer
+	conflictCleanupTimer   *time.Timer
+	conflicts              int32 // as of the last cleanup, accessed atomically
+	tombstoneGCTimer       *time.Timer
 
 	pullScheduled chan struct{}
 	pullPause     time.Duration
 	pullFailTimer *time.Timer
+	// Files found corrupted by Verify, to be pulled again although we
+	// have their global version: path -> local sequence when found. Only
+	// accessed from the serve loop.
+	repairs map[string]int64
 
-	scanErrors []FileError
-	pullErrors []FileError
-	errorsMut  sync.Mutex
+	schedule      config.Schedule
+	scheduleTimer *time.Timer
+	scanPending   bool // a scan was skipped outside of the schedule
+
+	heldMassChange      *MassChange
+	massChangeConfirmed bool
+	massChangeMut       sync.Mutex
+
+	scanErrors   []FileError
+	pullErrors   []FileError
+	pullFailures map[string]int             // path -> pulls in a row that failed on it
+	skippedItems map[string]protocol.Vector // path -> global version not to pull
+	errorsMut    sync.Mutex
 
 	doInSyncChan chan syncRequest
 
@@ -96,8 +141,10 @@ func newFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg conf
 		FolderConfiguration:       cfg,
 		FolderStatisticsReference: stats.NewFolderStatisticsReference(model.db, cfg.ID),
 		ioLimiter:                 ioLimiter,
+		scanThrottle:              newScanThrottle(cfg, model.lowImpactScans),
 
 		model:         model,
+		log:           l.With("folder", cfg.ID),
 		shortID:       model.shortID,
 		fset:          fset,
 		ignores:       ignores,
@@ -111,8 +158,11 @@ func newFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg conf
 		initialScanFinished:    make(chan struct{}),
 		versionCleanupInterval: time.Duration(cfg.Versioning.CleanupIntervalS) * time.Second,
 		versionCleanupTimer:    time.NewTimer(time.Duration(cfg.Versioning.CleanupIntervalS) * time.Second),
+		conflictCleanupTimer:   time.NewTimer(conflictCleanupInterval),
+		tombstoneGCTimer:       time.NewTimer(tombstoneGCInterval),
 
 		pullScheduled: make(chan struct{}, 1), // This needs to be 1-buffered so that we queue a pull if we're busy when it comes.
+		repairs:       make(map[string]int64),
 
 		errorsMut: sync.NewMutex(),
 
@@ -126,11 +176,24 @@ func newFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg conf
 		restartWatchChan: make(chan struct{}, 1),
 		watchMut:         sync.NewMutex(),
 
+		massChangeMut: sync.NewMutex(),
+
 		versioner: ver,
 	}
 	f.pullPause = f.pullBasePause()
 	f.pullFailTimer = time.NewTimer(0)
 	<-f.pullFailTimer.C
+	// The schedule has been validated when preparing the config.
+	f.schedule, _ = config.ParseSchedule(cfg.Schedule)
+	f.scheduleTimer = time.NewTimer(0)
+	<-f.scheduleTimer.C
+	if cfg.RescanCron != "" {
+		// Validated when preparing the config as well.
+		if cron, err := config.ParseCron(cfg.RescanCron); err == nil {
+			f.scanCron = &cron
+			f.scanJitter = time.Duration(cfg.RescanJitterS) * time.Second
+		}
+	}
 	return f
 }
 
@@ -146,9 +209,14 @@ func (f *folder) Serve(ctx context.Context) error {
 	defer func() {
 		f.scanTimer.Stop()
 		f.versionCleanupTimer.Stop()
+		f.conflictCleanupTimer.Stop()
+		f.tombstoneGCTimer.Stop()
+		f.scheduleTimer.Stop()
 		f.setState(FolderIdle)
 	}()
 
+	f.updateSchedule()
+
 	if f.FSWatcherEnabled && f.getHealthErrorAndLoadIgnores() == nil {
 		f.startWatch()
 	}
@@ -182,11 +250,15 @@ func (f *folder) Serve(ctx context.Context) error {
 			// Initial scan has completed, we should do a pull
 			initialCompleted = nil // never hit this case again
 			f.pull()
+			f.conflictCleanupTimerFired()
 
 		case <-f.forcedRescanRequested:
 			f.handleForcedRescans()
 
 		case <-f.scanTimer.C:
+			if f.skipScanBySchedule() {
+				continue
+			}
 			l.Debugln(f, "Scanning due to timer")
 			f.scanTimerFired()
 
@@ -199,6 +271,9 @@ func (f *folder) Serve(ctx context.Context) error {
 			f.scanTimer.Reset(next)
 
 		case fsEvents := <-f.watchChan:
+			if f.skipScanBySchedule() {
+				continue
+			}
 			l.Debugln(f, "Scan due to watcher")
 			f.scanSubdirs(fsEvents)
 
@@ -209,12 +284,73 @@ func (f *folder) Serve(ctx context.Context) error {
 		case <-f.versionCleanupTimer.C:
 			l.Debugln(f, "Doing version cleanup")
 			f.versionCleanupTimerFired()
+
+		case <-f.conflictCleanupTimer.C:
+			l.Debugln(f, "Doing conflict cleanup")
+			f.conflictCleanupTimerFired()
+
+		case <-f.tombstoneGCTimer.C:
+			l.Debugln(f, "Forgetting expired deletes")
+			f.tombstoneGCTimerFired()
+
+		case <-f.scheduleTimer.C:
+			f.updateSchedule()
 		}
 	}
 }
 
+// updateSchedule pauses or resumes the folder according to its schedule,
+// catching up on skipped scans and pulls when resuming.
+func (f *folder) updateSchedule() {
+	if len(f.schedule) == 0 {
+		return
+	}
+
+	now := time.Now()
+	paused := !f.schedule.Active(now)
+	wasPaused := f.setPausedBySchedule(paused)
+	switch {
+	case paused && !wasPaused:
+		f.log.Infof("Pausing %v outside of its schedule", f.Description())
+	case !paused && wasPaused:
+		f.log.Infof("Resuming %v according to its schedule", f.Description())
+		if f.scanPending {
+			f.scanPending = false
+			f.scanTimerFired()
+		}
+		f.SchedulePull()
+	}
+
+	// Check again at the next change, but at least every so often, as
+	// timers don't account for the system sleeping or clock changes.
+	next := scheduleCheckInterval
+	if change := f.schedule.NextChange(now); !change.IsZero() && change.Sub(now) < next {
+		next = change.Sub(now)
+	}
+	f.scheduleTimer.Reset(next)
+}
+
+// skipScanBySchedule returns whether a scan should be skipped because the
+// folder is outside of its schedule, remembering to do it when resuming.
+// The initial scan is never skipped.
+func (f *folder) skipScanBySchedule() bool {
+	if !f.isPausedBySchedule() {
+		return false
+	}
+	select {
+	case <-f.initialScanFinished:
+	default:
+		return false
+	}
+	l.Debugln(f, "Skipping scan outside of schedule")
+	f.scanPending = true
+	return true
+}
+
 func (f *folder) BringToFront(string) {}
 
+func (f *folder) SetPullOrder(config.PullOrder, []string) {}
+
 func (f *folder) Override() {}
 
 func (f *folder) Revert() {}
@@ -269,6 +405,22 @@ func (f *folder) doInSync(fn func() error) error {
 }
 
 func (f *folder) Reschedule() {
+	if f.scanCron != nil {
+		// Scan at the next time of the schedule, plus a random delay of up
+		// to the jitter.
+		now := time.Now()
+		next := f.scanCron.Next(now)
+		if next.IsZero() {
+			return
+		}
+		interval := next.Sub(now)
+		if f.scanJitter > 0 {
+			interval += time.Duration(rand.Int63n(int64(f.scanJitter)))
+		}
+		l.Debugln(f, "next rescan in", interval)
+		f.scanTimer.Reset(interval)
+		return
+	}
 	if f.scanInterval == 0 {
 		return
 	}
@@ -297,16 +449,36 @@ func (f *folder) getHealthErrorWithoutIgnores() error {
 		return err
 	}
 
-	dbPath := locations.Get(locations.Database)
-	if usage, err := fs.NewFilesystem(fs.FilesystemTypeBasic, dbPath).Usage("."); err == nil {
-		if err = config.CheckFreeSpace(f.model.cfg.Options().MinHomeDiskFree, usage); err != nil {
-			return errors.Wrapf(err, "insufficient space on disk for database (%v)", dbPath)
+	// In memory databases have no location to check.
+	if dbPath := f.model.db.Location(); dbPath != "" {
+		if usage, err := fs.NewFilesystem(fs.FilesystemTypeBasic, dbPath).Usage("."); err == nil {
+			if err = config.CheckFreeSpace(f.model.cfg.Options().MinHomeDiskFree, usage); err != nil {
+				return &outOfDiskError{"database", dbPath, err}
+			}
 		}
 	}
 
 	return nil
 }
 
+// getOutOfDiskError returns an error if the folder's disk has less free
+// space than the folder should keep free. Only folders that write changes
+// need it, so unlike the database check this isn't part of the health
+// error and doesn't stop scans.
+func (f *folder) getOutOfDiskError() error {
+	if f.Type == config.FolderTypeSendOnly {
+		return nil
+	}
+	usage, err := f.mtimefs.Usage(".")
+	if err != nil {
+		return nil
+	}
+	if err := config.CheckFreeSpace(f.MinDiskFree, usage); err != nil {
+		return &outOfDiskError{"folder", f.Filesystem().URI(), err}
+	}
+	return nil
+}
+
 func (f *folder) pull() (success bool) {
 	f.pullFailTimer.Stop()
 	select {
@@ -329,7 +501,7 @@ func (f *folder) pull() (success bool) {
 	}()
 
 	// If there is nothing to do, don't even enter sync-waiting state.
-	abort := true
+	abort := len(f.repairs) == 0
 	snap := f.fset.Snapshot()
 	snap.WithNeed(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
 		abort = false
@@ -340,15 +512,46 @@ func (f *folder) pull() (success bool) {
 		// Clears pull failures on items that were needed before, but aren't anymore.
 		f.errorsMut.Lock()
 		f.pullErrors = nil
+		f.pullFailures = nil
 		f.errorsMut.Unlock()
+		f.resetMassChangeConfirmation()
+		return true
+	}
+
+	// Nothing may be changed during a maintenance freeze. A pull is
+	// scheduled once it is lifted.
+	if f.model.cfg.Options().MaintenanceFreeze {
+		l.Debugln("Skipping pull of", f.Description(), "due to maintenance freeze")
+		return true
+	}
+
+	// Nothing is synced outside of the folder's schedule. A pull is
+	// scheduled once it resumes.
+	if f.isPausedBySchedule() {
+		l.Debugln("Skipping pull of", f.Description(), "outside of schedule")
+		return true
+	}
+
+	// Changes that would delete or overwrite much of the folder are held
+	// back until confirmed, which schedules a pull. The puller checks
+	// again on every iteration, for changes arriving in the meantime.
+	snap = f.fset.Snapshot()
+	hold := f.holdMassChange(snap)
+	snap.Release()
+	if hold {
+		l.Debugln("Skipping pull of", f.Description(), "until mass change is confirmed")
 		return true
 	}
 
 	// Abort early (before acquiring a token) if there's a folder error
 	err := f.getHealthErrorWithoutIgnores()
+	if err == nil {
+		err = f.getOutOfDiskError()
+	}
 	f.setError(err)
 	if err != nil {
 		l.Debugln("Skipping pull of", f.Description(), "due to folder error:", err)
+		f.retryIfOutOfDisk(err)
 		return false
 	}
 
@@ -357,7 +560,7 @@ func (f *folder) pull() (success bool) {
 	if f.Type != config.FolderTypeSendOnly {
 		f.setState(FolderSyncWaiting)
 
-		if err := f.ioLimiter.takeWithContext(f.ctx, 1); err != nil {
+		if err := f.ioLimiter.takeWithPriority(f.ctx, 1, f.Priority); err != nil {
 			f.setError(err)
 			return true
 		}
@@ -386,13 +589,31 @@ func (f *folder) pull() (success bool) {
 		return true
 	}
 
+	// The pull might have failed because it ran out of space, in which
+	// case we pause until there is enough again instead of backing off.
+	if err := f.getOutOfDiskError(); err != nil {
+		f.setError(err)
+		f.retryIfOutOfDisk(err)
+		return false
+	}
+
 	// Pulling failed, try again later.
 	delay := f.pullPause + time.Since(startTime)
-	l.Infof("Folder %v isn't making sync progress - retrying in %v.", f.Description(), util.NiceDurationString(delay))
+	f.log.Infof("Folder %v isn't making sync progress - retrying in %v.", f.Description(), util.NiceDurationString(delay))
 	f.pullFailTimer.Reset(delay)
 	return false
 }
 
+// retryIfOutOfDisk schedules checking again for free space if the error
+// is due to running out of it. Clearing the folder error then schedules a
+// pull.
+func (f *folder) retryIfOutOfDisk(err error) {
+	var outOfDisk *outOfDiskError
+	if errors.As(err, &outOfDisk) {
+		f.pullFailTimer.Reset(outOfDiskRetryInterval)
+	}
+}
+
 func (f *folder) scanSubdirs(subDirs []string) error {
 	l.Debugf("%v scanning", f)
 
@@ -422,7 +643,7 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 	f.setState(FolderScanWaiting)
 	defer f.setState(FolderIdle)
 
-	if err := f.ioLimiter.takeWithContext(f.ctx, 1); err != nil {
+	if err := f.ioLimiter.takeWithPriority(f.ctx, 1, f.Priority); err != nil {
 		return err
 	}
 	defer f.ioLimiter.give(1)
@@ -452,6 +673,7 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 
 	f.setState(FolderScanning)
 	f.clearScanErrors(subDirs)
+	scanStart := time.Now()
 
 	batch := newFileInfoBatch(func(fs []protocol.FileInfo) error {
 		if err := f.getHealthErrorWithoutIgnores(); err != nil {
@@ -503,7 +725,7 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 		return err
 	}
 
-	f.ScanCompleted()
+	f.ScanCompleted(time.Since(scanStart))
 	return nil
 }
 
@@ -568,6 +790,13 @@ func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *fileInfoBatch
 	scanCtx, scanCancel := context.WithCancel(f.ctx)
 	defer scanCancel()
 
+	// Requests from encrypted devices carry no hash algorithm, so they can
+	// only be verified against SHA-256 block hashes.
+	hashAlgo := f.BlockHashAlgorithm
+	if f.HasEncryptedDevices() {
+		hashAlgo = protocol.BlockHashAlgorithmSHA256
+	}
+
 	scanConfig := scanner.Config{
 		Folder:                f.ID,
 		Subs:                  subDirs,
@@ -578,11 +807,22 @@ func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *fileInfoBatch
 		IgnorePerms:           f.IgnorePerms,
 		AutoNormalize:         f.AutoNormalize,
 		Hashers:               f.model.numHashers(f.ID),
+		HasherBackoff:         f.model.lowImpactScans,
+		Throttle:              f.scanThrottle,
+		LowPriority:           f.ScanLowPriority || f.model.lowImpactScans.Enabled(),
 		ShortID:               f.shortID,
 		ProgressTickIntervalS: f.ScanProgressIntervalS,
 		LocalFlags:            f.localFlags,
 		ModTimeWindow:         f.modTimeWindow,
 		EventLogger:           f.evLogger,
+		// Encrypted devices need the blocks at fixed offsets.
+		ContentDefinedBlocks: f.ContentDefinedBlocks && !f.HasEncryptedDevices(),
+		BlockHashAlgorithm:   hashAlgo,
+		SyncXattrs:           f.SyncXattrs,
+		SyncOwnership:        f.SyncOwnership,
+		FollowSymlinks:       f.SymlinkPolicy == config.SymlinkPolicyFollow,
+		SkipSymlinks:         f.SymlinkPolicy == config.SymlinkPolicySkip,
+		RewriteSymlinkTarget: f.SyncedSymlinkTarget,
 	}
 	var fchan chan scanner.ScanResult
 	if f.Type == config.FolderTypeReceiveEncrypted {
@@ -696,7 +936,7 @@ func (f *folder) scanSubdirsDeletedAndIgnored(subDirs []string, batch *fileInfoB
 				// it's still here. Simply stat:ing it wont do as there are
 				// tons of corner cases (e.g. parent dir->symlink, missing
 				// permissions)
-				if !osutil.IsDeleted(f.mtimefs, file.Name) {
+				if !f.isDeleted(file.Name) {
 					if ignoredParent != "" {
 						// Don't ignore parents of this not ignored item
 						toIgnore = toIgnore[:0]
@@ -807,7 +1047,7 @@ func (f *folder) findRename(snap *db.Snapshot, file protocol.FileInfo, alreadyUs
 
 		alreadyUsedOrExisting[fi.Name] = struct{}{}
 
-		if !osutil.IsDeleted(f.mtimefs, fi.Name) {
+		if !f.isDeleted(fi.Name) {
 			return true
 		}
 
@@ -831,18 +1071,158 @@ func (f *folder) scanTimerFired() {
 		if err != nil {
 			status = "Failed"
 		}
-		l.Infoln(status, "initial scan of", f.Type.String(), "folder", f.Description())
+		f.log.Infoln(status, "initial scan of", f.Type.String(), "folder", f.Description())
 		close(f.initialScanFinished)
 	}
 
 	f.Reschedule()
 }
 
+// conflictCleanupTimerFired counts the sync-conflict copies in the folder,
+// removing those older than the retention period.
+func (f *folder) conflictCleanupTimerFired() {
+	defer f.conflictCleanupTimer.Reset(conflictCleanupInterval)
+
+	retention := time.Duration(f.ConflictRetentionDays) * 24 * time.Hour
+	if f.Type == config.FolderTypeReceiveEncrypted || f.model.cfg.Options().MaintenanceFreeze || f.isPausedBySchedule() {
+		// Nothing may be removed, so just count.
+		retention = 0
+	}
+
+	var expired []string
+	var count int32
+	snap := f.fset.Snapshot()
+	snap.WithHaveTruncated(protocol.LocalDeviceID, func(fi protocol.FileIntf) bool {
+		if fi.IsDeleted() || fi.IsInvalid() || fi.IsDirectory() {
+			return true
+		}
+		_, when, _, ok := parseConflictName(fi.FileName())
+		if !ok {
+			return true
+		}
+		if retention > 0 && time.Since(when) > retention {
+			expired = append(expired, fi.FileName())
+		} else {
+			count++
+		}
+		return true
+	})
+
+	// Only what we know of is removed; a copy changed since the last scan
+	// is kept and rescanned, like the puller does before deleting.
+	var removed, rescan []string
+	for _, name := range expired {
+		cur, ok := snap.Get(protocol.LocalDeviceID, name)
+		if !ok {
+			continue
+		}
+		switch err := f.checkUnchanged(cur); {
+		case fs.IsNotExist(err):
+			// Already gone, which the scan picks up.
+			rescan = append(rescan, name)
+			continue
+		case err == errModified:
+			l.Debugln(f, "not removing modified expired conflict", name)
+			rescan = append(rescan, name)
+			count++
+			continue
+		case err != nil:
+			l.Debugln(f, "not removing expired conflict", name, err)
+			count++
+			continue
+		}
+		if err := f.mtimefs.Remove(name); err != nil && !fs.IsNotExist(err) {
+			l.Debugln(f, "removing expired conflict", err)
+			count++
+			continue
+		}
+		removed = append(removed, name)
+	}
+	snap.Release()
+	atomic.StoreInt32(&f.conflicts, count)
+
+	if len(removed) > 0 {
+		f.log.Infof("Removed %d sync-conflict copies older than %d days from %v", len(removed), f.ConflictRetentionDays, f.Description())
+	}
+	if rescan = append(rescan, removed...); len(rescan) > 0 {
+		f.scanSubdirs(rescan)
+	}
+}
+
+// checkUnchanged returns errModified if the item on disk isn't the one in
+// the database, i.e. it has changes that weren't scanned yet.
+func (f *folder) checkUnchanged(item protocol.FileInfo) error {
+	if err := f.traversesSymlink(filepath.Dir(item.Name)); err != nil {
+		return err
+	}
+	stat, err := f.lstat(item.Name)
+	if err != nil {
+		return err
+	}
+	statItem, err := scanner.CreateFileInfo(stat, item.Name, f.mtimefs)
+	if err != nil {
+		return err
+	}
+	if !statItem.IsEquivalentOptional(item, f.modTimeWindow, f.IgnorePerms, true, protocol.LocalAllFlags) {
+		return errModified
+	}
+	return nil
+}
+
+// tombstoneGCTimerFired forgets the items deleted longer ago than the
+// retention period, once all devices sharing the folder have the deletion.
+func (f *folder) tombstoneGCTimerFired() {
+	defer f.tombstoneGCTimer.Reset(tombstoneGCInterval)
+
+	if f.TombstoneRetentionDays <= 0 {
+		return
+	}
+	snap := f.fset.Snapshot()
+	cutoff, missing := tombstoneCutoff(f.FolderConfiguration, f.model.id, snap)
+	snap.Release()
+	if len(missing) > 0 {
+		l.Debugf("%v not forgetting deletes without the index of %v", f, missing)
+		return
+	}
+	if n := f.fset.ForgetDeleted(cutoff); n > 0 {
+		f.log.Infof("Forgot %d items deleted more than %d days ago from the index of %v", n, f.TombstoneRetentionDays, f.Description())
+	}
+}
+
+// tombstoneCutoff returns the time before which deletions are past the
+// retention period, and the devices sharing the folder whose index we
+// haven't got. We can't know whether those have seen a deletion, so while
+// there are any, nothing may be forgotten.
+func tombstoneCutoff(cfg config.FolderConfiguration, myID protocol.DeviceID, snap *db.Snapshot) (time.Time, []protocol.DeviceID) {
+	var missing []protocol.DeviceID
+	for _, dev := range cfg.DeviceIDs() {
+		if dev != myID && snap.Sequence(dev) == 0 {
+			missing = append(missing, dev)
+		}
+	}
+	if cfg.TombstoneRetentionDays <= 0 {
+		return time.Time{}, missing
+	}
+	return time.Now().Add(-time.Duration(cfg.TombstoneRetentionDays) * 24 * time.Hour), missing
+}
+
+// ConflictCount returns the number of sync-conflict copies in the folder,
+// as of the last conflict cleanup.
+func (f *folder) ConflictCount() int {
+	return int(atomic.LoadInt32(&f.conflicts))
+}
+
 func (f *folder) versionCleanupTimerFired() {
+	if f.model.cfg.Options().MaintenanceFreeze {
+		l.Debugln("Skipping version cleanup of", f.Description(), "due to maintenance freeze")
+		f.versionCleanupTimer.Reset(f.versionCleanupInterval)
+		return
+	}
+
 	f.setState(FolderCleanWaiting)
 	defer f.setState(FolderIdle)
 
-	if err := f.ioLimiter.takeWithContext(f.ctx, 1); err != nil {
+	if err := f.ioLimiter.takeWithPriority(f.ctx, 1, f.Priority); err != nil {
 		return
 	}
 	defer f.ioLimiter.give(1)
@@ -850,7 +1230,7 @@ func (f *folder) versionCleanupTimerFired() {
 	f.setState(FolderCleaning)
 
 	if err := f.versioner.Clean(f.ctx); err != nil {
-		l.Infoln("Failed to clean versions in %s: %v", f.Description(), err)
+		f.log.Infoln("Failed to clean versions in %s: %v", f.Description(), err)
 	}
 
 	f.versionCleanupTimer.Reset(f.versionCleanupInterval)
@@ -948,7 +1328,7 @@ func (f *folder) monitorWatch(ctx context.Context) {
 			var errOutside *fs.ErrWatchEventOutsideRoot
 			if errors.As(err, &errOutside) {
 				if !warnedOutside {
-					l.Warnln(err)
+					f.log.Warnln(err)
 					warnedOutside = true
 				}
 				f.evLogger.Log(events.Failure, "watching for changes encountered an event outside of the filesystem root")
@@ -986,7 +1366,7 @@ func (f *folder) setWatchError(err error, nextTryIn time.Duration) {
 	}
 	msg := fmt.Sprintf("Error while trying to start filesystem watcher for folder %s, trying again in %v: %v", f.Description(), nextTryIn, err)
 	if prevErr != err {
-		l.Infof(msg)
+		f.log.Infof(msg)
 		return
 	}
 	l.Debugf(msg)
@@ -1016,12 +1396,12 @@ func (f *folder) setError(err error) {
 
 	if err != nil {
 		if oldErr == nil {
-			l.Warnf("Error on folder %s: %v", f.Description(), err)
+			f.log.Warnf("Error on folder %s: %v", f.Description(), err)
 		} else {
-			l.Infof("Error on folder %s changed: %q -> %q", f.Description(), oldErr, err)
+			f.log.Infof("Error on folder %s changed: %q -> %q", f.Description(), oldErr, err)
 		}
 	} else {
-		l.Infoln("Cleared error on folder", f.Description())
+		f.log.Infoln("Cleared error on folder", f.Description())
 		f.SchedulePull()
 	}
 
@@ -1043,13 +1423,44 @@ func (f *folder) pullBasePause() time.Duration {
 	return time.Duration(f.PullerPauseS) * time.Second
 }
 
+// isDeleted returns whether the item is gone from disk. If symlinks are
+// followed, they are taken as the items they point to, including for the
+// parent directories.
+func (f *folder) isDeleted(name string) bool {
+	if f.SymlinkPolicy != config.SymlinkPolicyFollow {
+		return osutil.IsDeleted(f.mtimefs, name)
+	}
+	_, err := f.mtimefs.Stat(name)
+	return fs.IsNotExist(err) || fs.IsErrCaseConflict(err)
+}
+
+// traversesSymlink checks that the path doesn't lead through a symlink, as
+// osutil.TraversesSymlink does, unless symlinks are followed. Those are
+// then never created by the puller, so all of them are the user's.
+func (f *folder) traversesSymlink(name string) error {
+	if f.SymlinkPolicy == config.SymlinkPolicyFollow {
+		return nil
+	}
+	return osutil.TraversesSymlink(f.mtimefs, name)
+}
+
+// lstat returns the info of the item, or of the item it points to if it is
+// a symlink that is followed.
+func (f *folder) lstat(name string) (fs.FileInfo, error) {
+	info, err := f.mtimefs.Lstat(name)
+	if err == nil && info.IsSymlink() && f.SymlinkPolicy == config.SymlinkPolicyFollow {
+		return f.mtimefs.Stat(name)
+	}
+	return info, err
+}
+
 func (f *folder) String() string {
 	return fmt.Sprintf("%s/%s@%p", f.Type, f.folderID, f)
 }
 
 func (f *folder) newScanError(path string, err error) {
 	f.errorsMut.Lock()
-	l.Infof("Scanner (folder %s, item %q): %v", f.Description(), path, err)
+	f.log.Infof("Scanner (folder %s, item %q): %v", f.Description(), path, err)
 	f.scanErrors = append(f.scanErrors, FileError{
 		Err:  err.Error(),
 		Path: path,
@@ -1088,6 +1499,82 @@ func (f *folder) Errors() []FileError {
 	return errors
 }
 
+// SkipItems makes pulls leave the given items alone, until they change
+// again or are retried. Their pull errors are gone right away.
+func (f *folder) SkipItems(paths []string) error {
+	snap := f.fset.Snapshot()
+	defer snap.Release()
+
+	versions := make(map[string]protocol.Vector, len(paths))
+	for _, path := range paths {
+		gf, ok := snap.GetGlobal(path)
+		if !ok {
+			return fmt.Errorf("%s: %w", path, errNoSuchItem)
+		}
+		versions[path] = gf.Version
+	}
+
+	f.errorsMut.Lock()
+	defer f.errorsMut.Unlock()
+	if f.skippedItems == nil {
+		f.skippedItems = make(map[string]protocol.Vector, len(versions))
+	}
+	for path, version := range versions {
+		f.skippedItems[path] = version
+	}
+	filtered := f.pullErrors[:0]
+	for _, fe := range f.pullErrors {
+		if _, ok := versions[fe.Path]; !ok {
+			filtered = append(filtered, fe)
+		}
+	}
+	f.pullErrors = filtered
+	return nil
+}
+
+// RetryItems undoes skipping the given items, or all of them if none are
+// given, and pulls right away instead of waiting for the next retry.
+func (f *folder) RetryItems(paths []string) {
+	f.errorsMut.Lock()
+	if len(paths) == 0 {
+		f.skippedItems = nil
+	}
+	for _, path := range paths {
+		delete(f.skippedItems, path)
+	}
+	f.errorsMut.Unlock()
+
+	f.SchedulePull()
+}
+
+// SkippedItems returns the items that are skipped, with the version of
+// each that is.
+func (f *folder) SkippedItems() map[string]protocol.Vector {
+	f.errorsMut.Lock()
+	defer f.errorsMut.Unlock()
+	res := make(map[string]protocol.Vector, len(f.skippedItems))
+	for path, version := range f.skippedItems {
+		res[path] = version
+	}
+	return res
+}
+
+// isSkipped returns whether the item is skipped, forgetting about it once
+// it has changed.
+func (f *folder) isSkipped(file protocol.FileIntf) bool {
+	f.errorsMut.Lock()
+	defer f.errorsMut.Unlock()
+	version, ok := f.skippedItems[file.FileName()]
+	if !ok {
+		return false
+	}
+	if !version.Equal(file.FileVersion()) {
+		delete(f.skippedItems, file.FileName())
+		return false
+	}
+	return true
+}
+
 // ScheduleForceRescan marks the file such that it gets rehashed on next scan, and schedules a scan.
 func (f *folder) ScheduleForceRescan(path string) {
 	f.forcedRescanPathsMut.Lock()
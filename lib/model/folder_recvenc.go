@@ -36,7 +36,12 @@ func (f *receiveEncryptedFolder) Revert() {
 }
 
 func (f *receiveEncryptedFolder) revert() {
-	l.Infof("Reverting unexpected items in folder %v (receive-encrypted)", f.Description())
+	if f.model.cfg.Options().MaintenanceFreeze {
+		f.log.Infof("Not reverting unexpected items in folder %v due to maintenance freeze", f.Description())
+		return
+	}
+
+	f.log.Infof("Reverting unexpected items in folder %v (receive-encrypted)", f.Description())
 
 	f.setState(FolderScanning)
 	defer f.setState(FolderIdle)
@@ -89,7 +94,7 @@ func (f *receiveEncryptedFolder) revert() {
 		iterErr = batch.flush()
 	}
 	if iterErr != nil {
-		l.Infoln("Failed to delete unexpected items:", iterErr)
+		f.log.Infoln("Failed to delete unexpected items:", iterErr)
 	}
 }
 
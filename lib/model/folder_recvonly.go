@@ -67,7 +67,12 @@ func (f *receiveOnlyFolder) Revert() {
 }
 
 func (f *receiveOnlyFolder) revert() {
-	l.Infof("Reverting folder %v", f.Description)
+	if f.model.cfg.Options().MaintenanceFreeze {
+		f.log.Infof("Not reverting folder %v due to maintenance freeze", f.Description())
+		return
+	}
+
+	f.log.Infof("Reverting folder %v", f.Description)
 
 	f.setState(FolderScanning)
 	defer f.setState(FolderIdle)
@@ -103,7 +108,7 @@ func (f *receiveOnlyFolder) revert() {
 
 			handled, err := delQueue.handle(fi, snap)
 			if err != nil {
-				l.Infof("Revert: deleting %s: %v\n", fi.Name, err)
+				f.log.Infof("Revert: deleting %s: %v\n", fi.Name, err)
 				return true // continue
 			}
 			if !handled {
@@ -140,7 +145,7 @@ func (f *receiveOnlyFolder) revert() {
 	// Handle any queued directories
 	deleted, err := delQueue.flush(snap)
 	if err != nil {
-		l.Infoln("Revert:", err)
+		f.log.Infoln("Revert:", err)
 	}
 	now := time.Now()
 	for _, dir := range deleted {
@@ -100,6 +100,41 @@ func TestRecvOnlyRevertDeletes(t *testing.T) {
 	}
 }
 
+func TestRecvOnlyRevertFrozen(t *testing.T) {
+	// Make sure that Revert doesn't touch anything during a maintenance
+	// freeze.
+
+	m, f, wcfgCancel := setupROFolder(t)
+	defer wcfgCancel()
+	ffs := f.Filesystem()
+	defer cleanupModel(m)
+
+	must(t, ffs.MkdirAll(".stfolder", 0755))
+	must(t, writeFile(ffs, "unknownFile", []byte("hello\n"), 0644))
+	must(t, m.ScanFolder("ro"))
+
+	setFreeze := func(freeze bool) {
+		t.Helper()
+		waiter, err := m.cfg.Modify(func(cfg *config.Configuration) {
+			cfg.Options.MaintenanceFreeze = freeze
+		})
+		must(t, err)
+		waiter.Wait()
+	}
+
+	setFreeze(true)
+	m.Revert("ro")
+	if _, err := ffs.Stat("unknownFile"); err != nil {
+		t.Error("File should not be removed during freeze:", err)
+	}
+
+	setFreeze(false)
+	m.Revert("ro")
+	if _, err := ffs.Stat("unknownFile"); !fs.IsNotExist(err) {
+		t.Error("File should be removed after freeze was lifted")
+	}
+}
+
 func TestRecvOnlyRevertNeeds(t *testing.T) {
 	// Make sure that a new file gets picked up and considered latest, then
 	// gets considered old when we hit Revert.
@@ -423,7 +458,7 @@ func setupKnownFiles(t *testing.T, ffs fs.Filesystem, data []byte) []protocol.Fi
 	if err != nil {
 		t.Fatal(err)
 	}
-	blocks, _ := scanner.Blocks(context.TODO(), bytes.NewReader(data), protocol.BlockSize(int64(len(data))), int64(len(data)), nil, true)
+	blocks, _ := scanner.Blocks(context.TODO(), bytes.NewReader(data), protocol.BlockSize(int64(len(data))), int64(len(data)), nil, protocol.BlockHashAlgorithmSHA256, true)
 	knownFiles := []protocol.FileInfo{
 		{
 			Name:        "knownDir",
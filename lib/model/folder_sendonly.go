@@ -91,7 +91,7 @@ func (f *sendOnlyFolder) Override() {
 }
 
 func (f *sendOnlyFolder) override() {
-	l.Infoln("Overriding global state on folder", f.Description())
+	f.log.Infoln("Overriding global state on folder", f.Description())
 
 	f.setState(FolderScanning)
 	defer f.setState(FolderIdle)
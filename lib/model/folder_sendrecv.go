@@ -14,6 +14,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	stdsync "sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -26,7 +27,6 @@ import (
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/scanner"
-	"github.com/syncthing/syncthing/lib/sha256"
 	"github.com/syncthing/syncthing/lib/sync"
 	"github.com/syncthing/syncthing/lib/versioner"
 	"github.com/syncthing/syncthing/lib/weakhash"
@@ -46,6 +46,9 @@ func init() {
 type pullBlockState struct {
 	*sharedPullerState
 	block protocol.BlockInfo
+	// Someone is waiting for the file, so the block is requested ahead of
+	// others, from the fastest device.
+	prioritized bool
 }
 
 // A copyBlocksState is passed to copy routine if the file has blocks to be
@@ -70,9 +73,21 @@ var (
 	errModified               = errors.New("file modified but not rescanned; will try again later")
 	errUnexpectedDirOnFileDel = errors.New("encountered directory when trying to remove file/symlink")
 	errIncompatibleSymlink    = errors.New("incompatible symlink entry; rescan with newer Syncthing on source")
+	errIncompleteStagedPull   = errors.New("not all staged changes could be synced")
 	contextRemovingOldItem    = "removing item to be replaced"
 )
 
+// A caseCollisionError is the pull error for an item whose name differs
+// only in case from that of another item, on a case insensitive
+// filesystem.
+type caseCollisionError struct {
+	name, other string
+}
+
+func (e *caseCollisionError) Error() string {
+	return fmt.Sprintf("case collision: %q and %q can't both exist on this filesystem", e.name, e.other)
+}
+
 type dbUpdateType int
 
 func (d dbUpdateType) String() string {
@@ -126,6 +141,12 @@ type sendReceiveFolder struct {
 	writeLimiter       *byteSemaphore
 
 	tempPullErrors map[string]string // pull errors that might be just transient
+
+	pullOrder         config.PullOrder
+	pullOrderPatterns []string
+	pullOrderMut      sync.Mutex
+
+	ownershipWarning stdsync.Once
 }
 
 func newSendReceiveFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg config.FolderConfiguration, ver versioner.Versioner, evLogger events.Logger, ioLimiter *byteSemaphore) service {
@@ -134,6 +155,9 @@ func newSendReceiveFolder(model *model, fset *db.FileSet, ignores *ignore.Matche
 		queue:              newJobQueue(),
 		blockPullReorderer: newBlockPullReorderer(cfg.BlockPullOrder, model.id, cfg.DeviceIDs()),
 		writeLimiter:       newByteSemaphore(cfg.MaxConcurrentWrites),
+		pullOrder:          cfg.Order,
+		pullOrderPatterns:  cfg.PullOrderPatterns,
+		pullOrderMut:       sync.NewMutex(),
 	}
 	f.folder.puller = f
 
@@ -151,6 +175,12 @@ func newSendReceiveFolder(model *model, fset *db.FileSet, ignores *ignore.Matche
 		f.PullerMaxPendingKiB = blockSizeKiB
 	}
 
+	// Within a memory budget we copy and pull one block at a time.
+	if model.cfg.Options().LowMemory() {
+		f.Copiers = 1
+		f.PullerMaxPendingKiB = protocol.MaxBlockSize / 1024
+	}
+
 	return f
 }
 
@@ -198,21 +228,25 @@ func (f *sendReceiveFolder) pull() bool {
 
 	f.errorsMut.Lock()
 	pullErrNum := len(f.tempPullErrors)
+	failures := make(map[string]int, pullErrNum)
 	if pullErrNum > 0 {
 		f.pullErrors = make([]FileError, 0, len(f.tempPullErrors))
 		for path, err := range f.tempPullErrors {
-			l.Infof("Puller (folder %s, item %q): %v", f.Description(), path, err)
+			f.log.Infof("Puller (folder %s, item %q): %v", f.Description(), path, err)
+			failures[path] = f.pullFailures[path] + 1
 			f.pullErrors = append(f.pullErrors, FileError{
-				Err:  err,
-				Path: path,
+				Err:      err,
+				Path:     path,
+				Failures: failures[path],
 			})
 		}
 		f.tempPullErrors = nil
 	}
+	f.pullFailures = failures
 	f.errorsMut.Unlock()
 
 	if pullErrNum > 0 {
-		l.Infof("%v: Failed to sync %v items", f.Description(), pullErrNum)
+		f.log.Infof("%v: Failed to sync %v items", f.Description(), pullErrNum)
 		f.evLogger.Log(events.FolderErrors, map[string]interface{}{
 			"folder": f.folderID,
 			"errors": f.Errors(),
@@ -234,7 +268,15 @@ func (f *sendReceiveFolder) pullerIteration(scanChan chan<- string) int {
 	snap := f.fset.Snapshot()
 	defer snap.Release()
 
+	// Changes that arrived since the pull started may need to be held
+	// back as well, in which case there's nothing to do until confirmed.
+	if f.holdMassChange(snap) {
+		l.Debugln(f, "holding back mass change")
+		return 0
+	}
+
 	pullChan := make(chan pullBlockState)
+	prioPullChan := make(chan pullBlockState)
 	copyChan := make(chan copyBlocksState)
 	finisherChan := make(chan *sharedPullerState)
 	dbUpdateChan := make(chan dbUpdateJob)
@@ -257,22 +299,29 @@ func (f *sendReceiveFolder) pullerIteration(scanChan chan<- string) int {
 		copyWg.Add(1)
 		go func() {
 			// copierRoutine finishes when copyChan is closed
-			f.copierRoutine(copyChan, pullChan, finisherChan)
+			f.copierRoutine(copyChan, pullChan, prioPullChan, finisherChan)
 			copyWg.Done()
 		}()
 	}
 
-	pullWg.Add(1)
+	pullWg.Add(2)
 	go func() {
 		// pullerRoutine finishes when pullChan is closed
 		f.pullerRoutine(pullChan, finisherChan)
 		pullWg.Done()
 	}()
+	go func() {
+		// The priority lane has its own limit on pending requests, so that
+		// prioritized blocks don't wait behind the others.
+		f.pullerRoutine(prioPullChan, finisherChan)
+		pullWg.Done()
+	}()
 
 	doneWg.Add(1)
 	// finisherRoutine finishes when finisherChan is closed
+	var staged []*sharedPullerState
 	go func() {
-		f.finisherRoutine(snap, finisherChan, dbUpdateChan, scanChan)
+		staged = f.finisherRoutine(snap, finisherChan, dbUpdateChan, scanChan)
 		doneWg.Done()
 	}()
 
@@ -283,6 +332,7 @@ func (f *sendReceiveFolder) pullerIteration(scanChan chan<- string) int {
 	close(copyChan)
 	copyWg.Wait()
 	close(pullChan)
+	close(prioPullChan)
 	pullWg.Wait()
 
 	// Signal the finisher chan that there will be no more input and wait
@@ -290,6 +340,17 @@ func (f *sendReceiveFolder) pullerIteration(scanChan chan<- string) int {
 	close(finisherChan)
 	doneWg.Wait()
 
+	if err == nil && f.StagedPull {
+		// Nothing is exposed unless everything else has succeeded, so the
+		// staged files are kept for the next try otherwise.
+		if f.hasTempPullErrors() {
+			l.Debugf("%v not moving %d staged files into place due to errors", f, len(staged))
+			err = errIncompleteStagedPull
+		} else {
+			f.finishStaged(staged, snap, dbUpdateChan, scanChan)
+		}
+	}
+
 	if err == nil {
 		f.processDeletions(fileDeletions, dirDeletions, snap, dbUpdateChan, scanChan)
 	}
@@ -325,6 +386,11 @@ func (f *sendReceiveFolder) processNeeded(snap *db.Snapshot, dbUpdateChan chan<-
 			return true
 		}
 
+		if f.isSkipped(intf) {
+			l.Debugln(f, "skipping", intf.FileName())
+			return true
+		}
+
 		changed++
 
 		file := intf.(protocol.FileInfo)
@@ -385,11 +451,14 @@ func (f *sendReceiveFolder) processNeeded(snap *db.Snapshot, dbUpdateChan chan<-
 				f.queue.Push(file.Name, file.Size, file.ModTime())
 			}
 
-		case runtime.GOOS == "windows" && file.IsSymlink():
+		case runtime.GOOS == "windows" && file.IsSymlink(), file.IsSymlink() && f.SymlinkPolicy != config.SymlinkPolicySync:
 			if err := f.handleSymlinkCheckExisting(file, snap, scanChan); err != nil {
 				f.newPullError(file.Name, fmt.Errorf("handling unsupported symlink: %w", err))
 				break
 			}
+			if runtime.GOOS != "windows" {
+				f.log.Warnf("Not syncing symlink %v in %v, as the symlink policy is %v", file.Name, f.Description(), f.SymlinkPolicy)
+			}
 			file.SetUnsupported()
 			l.Debugln(f, "Invalidating symlink (unsupported)", file.Name)
 			dbUpdateChan <- dbUpdateJob{file, dbUpdateInvalidate}
@@ -407,7 +476,7 @@ func (f *sendReceiveFolder) processNeeded(snap *db.Snapshot, dbUpdateChan chan<-
 			}
 
 		default:
-			l.Warnln(file)
+			f.log.Warnln(file)
 			panic("unhandleable item type, can't happen")
 		}
 
@@ -420,22 +489,10 @@ func (f *sendReceiveFolder) processNeeded(snap *db.Snapshot, dbUpdateChan chan<-
 	default:
 	}
 
-	// Now do the file queue. Reorder it according to configuration.
+	changed += f.queueRepairs(snap)
 
-	switch f.Order {
-	case config.PullOrderRandom:
-		f.queue.Shuffle()
-	case config.PullOrderAlphabetic:
-	// The queue is already in alphabetic order.
-	case config.PullOrderSmallestFirst:
-		f.queue.SortSmallestFirst()
-	case config.PullOrderLargestFirst:
-		f.queue.SortLargestFirst()
-	case config.PullOrderOldestFirst:
-		f.queue.SortOldestFirst()
-	case config.PullOrderNewestFirst:
-		f.queue.SortNewestFirst()
-	}
+	// Now do the file queue. Reorder it according to configuration.
+	f.sortQueue(false)
 
 	// Process the file queue.
 
@@ -575,7 +632,7 @@ func (f *sendReceiveFolder) handleDir(file protocol.FileInfo, snap *db.Snapshot,
 		l.Debugf("need dir\n\t%v\n\t%v", file, curFile)
 	}
 
-	info, err := f.mtimefs.Lstat(file.Name)
+	info, err := f.lstat(file.Name)
 	switch {
 	// There is already something under that name, we need to handle that.
 	// Unless it already is a directory, as we only track permissions,
@@ -635,15 +692,27 @@ func (f *sendReceiveFolder) handleDir(file protocol.FileInfo, snap *db.Snapshot,
 			return f.mtimefs.Chmod(path, mode|(info.Mode()&retainBits))
 		}
 
-		if err = f.inWritableDir(mkdir, file.Name); err == nil {
-			dbUpdateChan <- dbUpdateJob{file, dbUpdateHandleDir}
-		} else {
+		if err = f.inWritableDir(mkdir, file.Name); err != nil {
 			f.newPullError(file.Name, errors.Wrap(err, "creating directory"))
+			return
 		}
+		if err = f.setXattrs(file.Name, file); err != nil {
+			f.newPullError(file.Name, err)
+			return
+		}
+		if err = f.setOwnership(file.Name, &file); err != nil {
+			f.newPullError(file.Name, err)
+			return
+		}
+		dbUpdateChan <- dbUpdateJob{file, dbUpdateHandleDir}
 		return
-	// Weird error when stat()'ing the dir. Probably won't work to do
-	// anything else with it if we can't even stat() it.
 	case err != nil:
+		if cerr := f.caseCollision(file.Name, err, snap); cerr != nil {
+			f.newPullError(file.Name, cerr)
+			return
+		}
+		// Weird error when stat()'ing the dir. Probably won't work to do
+		// anything else with it if we can't even stat() it.
 		f.newPullError(file.Name, errors.Wrap(err, "checking file to be replaced"))
 		return
 	}
@@ -657,6 +726,14 @@ func (f *sendReceiveFolder) handleDir(file protocol.FileInfo, snap *db.Snapshot,
 			return
 		}
 	}
+	if err := f.setXattrs(file.Name, file); err != nil {
+		f.newPullError(file.Name, err)
+		return
+	}
+	if err := f.setOwnership(file.Name, &file); err != nil {
+		f.newPullError(file.Name, err)
+		return
+	}
 	dbUpdateChan <- dbUpdateJob{file, dbUpdateHandleDir}
 }
 
@@ -665,7 +742,7 @@ func (f *sendReceiveFolder) handleDir(file protocol.FileInfo, snap *db.Snapshot,
 func (f *sendReceiveFolder) checkParent(file string, scanChan chan<- string) bool {
 	parent := filepath.Dir(file)
 
-	if err := osutil.TraversesSymlink(f.mtimefs, parent); err != nil {
+	if err := f.traversesSymlink(parent); err != nil {
 		f.newPullError(file, errors.Wrap(err, "checking parent dirs"))
 		return false
 	}
@@ -735,7 +812,7 @@ func (f *sendReceiveFolder) handleSymlink(file protocol.FileInfo, snap *db.Snaps
 		return
 	}
 
-	if f.handleSymlinkCheckExisting(file, snap, scanChan); err != nil {
+	if err = f.handleSymlinkCheckExisting(file, snap, scanChan); err != nil {
 		f.newPullError(file.Name, fmt.Errorf("handling symlink: %w", err))
 		return
 	}
@@ -743,7 +820,7 @@ func (f *sendReceiveFolder) handleSymlink(file protocol.FileInfo, snap *db.Snaps
 	// We declare a function that acts on only the path name, so
 	// we can pass it to InWritableDir.
 	createLink := func(path string) error {
-		if err := f.mtimefs.CreateSymlink(file.SymlinkTarget, path); err != nil {
+		if err := f.mtimefs.CreateSymlink(f.LocalSymlinkTarget(file.SymlinkTarget), path); err != nil {
 			return err
 		}
 		return f.maybeCopyOwner(path)
@@ -758,11 +835,14 @@ func (f *sendReceiveFolder) handleSymlink(file protocol.FileInfo, snap *db.Snaps
 
 func (f *sendReceiveFolder) handleSymlinkCheckExisting(file protocol.FileInfo, snap *db.Snapshot, scanChan chan<- string) error {
 	// If there is already something under that name, we need to handle that.
-	info, err := f.mtimefs.Lstat(file.Name)
+	info, err := f.lstat(file.Name)
 	if err != nil {
 		if fs.IsNotExist(err) {
 			return nil
 		}
+		if cerr := f.caseCollision(file.Name, err, snap); cerr != nil {
+			return cerr
+		}
 		return err
 	}
 	// Check that it is what we have in the database.
@@ -1078,12 +1158,12 @@ func (f *sendReceiveFolder) handleFile(file protocol.FileInfo, snap *db.Snapshot
 
 	// Check for an old temporary file which might have some blocks we could
 	// reuse.
-	tempBlocks, err := scanner.HashFile(f.ctx, f.mtimefs, tempName, file.BlockSize(), nil, false)
+	tempBlocks, err := scanner.HashFile(f.ctx, f.mtimefs, tempName, file.BlockSize(), nil, file.BlockHashAlgorithm, false)
 	if err != nil {
 		var caseErr *fs.ErrCaseConflict
 		if errors.As(err, &caseErr) {
 			if rerr := f.mtimefs.Rename(caseErr.Real, tempName); rerr == nil {
-				tempBlocks, err = scanner.HashFile(f.ctx, f.mtimefs, tempName, file.BlockSize(), nil, false)
+				tempBlocks, err = scanner.HashFile(f.ctx, f.mtimefs, tempName, file.BlockSize(), nil, file.BlockHashAlgorithm, false)
 			}
 		}
 	}
@@ -1142,6 +1222,28 @@ func (f *sendReceiveFolder) handleFile(file protocol.FileInfo, snap *db.Snapshot
 	copyChan <- cs
 }
 
+// queueRepairs queues the files found corrupted by Verify that are unchanged
+// since and still have the global version, and returns how many. Each is
+// tried once; verifying again retries failed repairs. The repaired file is
+// identical to what's in the index, so the index doesn't change.
+func (f *sendReceiveFolder) queueRepairs(snap *db.Snapshot) int {
+	queued := 0
+	for name, sequence := range f.repairs {
+		delete(f.repairs, name)
+		cur, ok := snap.Get(protocol.LocalDeviceID, name)
+		if !ok || cur.Sequence != sequence {
+			continue
+		}
+		global, ok := snap.GetGlobal(name)
+		if !ok || global.IsInvalid() || !global.Version.Equal(cur.Version) {
+			continue
+		}
+		f.queue.Push(name, global.Size, global.ModTime())
+		queued++
+	}
+	return queued
+}
+
 // blockDiff returns lists of common and missing (to transform src into tgt)
 // blocks. Both block lists must have been created with the same block size.
 func blockDiff(src, tgt []protocol.BlockInfo) ([]protocol.BlockInfo, []protocol.BlockInfo) {
@@ -1172,6 +1274,18 @@ func blockDiff(src, tgt []protocol.BlockInfo) ([]protocol.BlockInfo, []protocol.
 	return have, need
 }
 
+// firstChangedBlock returns the index of the first block in tgt that differs
+// from the block at the same index in src. Both block lists must have been
+// created with the same block size.
+func firstChangedBlock(src, tgt []protocol.BlockInfo) int {
+	for i := range tgt {
+		if i >= len(src) || !bytes.Equal(tgt[i].Hash, src[i].Hash) {
+			return i
+		}
+	}
+	return len(tgt)
+}
+
 // populateOffsets sets the Offset field on each block
 func populateOffsets(blocks []protocol.BlockInfo) {
 	var offset int64
@@ -1211,6 +1325,16 @@ func (f *sendReceiveFolder) shortcutFile(file, curFile protocol.FileInfo, dbUpda
 		}
 	}
 
+	if err = f.setXattrs(file.Name, file); err != nil {
+		f.newPullError(file.Name, err)
+		return
+	}
+
+	if err = f.setOwnership(file.Name, &file); err != nil {
+		f.newPullError(file.Name, err)
+		return
+	}
+
 	f.mtimefs.Chtimes(file.Name, file.ModTime(), file.ModTime()) // never fails
 
 	dbUpdateChan <- dbUpdateJob{file, dbUpdateShortcutFile}
@@ -1218,7 +1342,7 @@ func (f *sendReceiveFolder) shortcutFile(file, curFile protocol.FileInfo, dbUpda
 
 // copierRoutine reads copierStates until the in channel closes and performs
 // the relevant copies when possible, or passes it to the puller routine.
-func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan chan<- pullBlockState, out chan<- *sharedPullerState) {
+func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan, prioPullChan chan<- pullBlockState, out chan<- *sharedPullerState) {
 	buf := protocol.BufferPool.Get(protocol.MinBlockSize)
 	defer func() {
 		protocol.BufferPool.Put(buf)
@@ -1264,12 +1388,13 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 			default:
 			}
 
-			if !f.DisableSparseFiles && state.reused == 0 && block.IsEmpty() {
+			if !f.DisableSparseFiles && block.IsEmpty() && (state.reused == 0 || dstFd.PunchHole(block.Offset, int64(block.Size)) == nil) {
 				// The block is a block of all zeroes, and we are not reusing
 				// a temp file, so there is no need to do anything with it.
 				// If we were reusing a temp file and had this block to copy,
 				// it would be because the block in the temp file was *not* a
-				// block of all zeroes, so then we should not skip it.
+				// block of all zeroes, so then we punch a hole instead, or
+				// write the zeroes if that isn't possible.
 
 				// Pretend we copied it.
 				state.copiedFromOrigin()
@@ -1282,7 +1407,7 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 			var found bool
 			if f.Type != config.FolderTypeReceiveEncrypted {
 				found, err = weakHashFinder.Iterate(block.WeakHash, buf, func(offset int64) bool {
-					if f.verifyBuffer(buf, block) != nil {
+					if f.verifyBuffer(buf, block, state.file.BlockHashAlgorithm) != nil {
 						return true
 					}
 
@@ -1304,7 +1429,7 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 			}
 
 			if !found {
-				found = f.model.finder.Iterate(folders, block.Hash, func(folder, path string, index int32) bool {
+				found = f.model.finder.Iterate(folders, block.Hash, func(folder, path string, srcOffset int64) bool {
 					ffs := folderFilesystems[folder]
 					fd, err := ffs.Open(path)
 					if err != nil {
@@ -1312,7 +1437,6 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 					}
 					defer fd.Close()
 
-					srcOffset := int64(state.file.BlockSize()) * int64(index)
 					_, err = fd.ReadAt(buf, srcOffset)
 					if err != nil {
 						return false
@@ -1322,7 +1446,7 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 					// case we can't verify the block integrity so we'll take it on
 					// trust. (The other side can and will verify.)
 					if f.Type != config.FolderTypeReceiveEncrypted {
-						if err := f.verifyBuffer(buf, block); err != nil {
+						if err := f.verifyBuffer(buf, block, state.file.BlockHashAlgorithm); err != nil {
 							l.Debugln("Finder failed to verify buffer", err)
 							return false
 						}
@@ -1357,7 +1481,14 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 					sharedPullerState: state.sharedPullerState,
 					block:             block,
 				}
-				pullChan <- ps
+				// Checked per block, as the file may be prioritized while
+				// it is being pulled.
+				if f.queue.Prioritized(state.file.Name) {
+					ps.prioritized = true
+					prioPullChan <- ps
+				} else {
+					pullChan <- ps
+				}
 			} else {
 				state.copyDone(block)
 			}
@@ -1378,13 +1509,23 @@ func (f *sendReceiveFolder) initWeakHashFinder(state copyBlocksState) (*weakhash
 		return nil, nil
 	}
 
+	// Content can only have shifted from the first changed block onwards,
+	// so that is where the search for it starts. This keeps small edits
+	// near the end of large files, such as appends to logs or mailboxes,
+	// cheap to match, and they count as changed relative to that part of
+	// the file only.
+	first := 0
+	if state.hasCurFile {
+		first = firstChangedBlock(state.curFile.Blocks, state.file.Blocks)
+	}
+
 	blocksPercentChanged := 0
-	if tot := len(state.file.Blocks); tot > 0 {
-		blocksPercentChanged = (tot - state.have) * 100 / tot
+	if tot := len(state.file.Blocks); tot > first {
+		blocksPercentChanged = (tot - state.have) * 100 / (tot - first)
 	}
 
 	if blocksPercentChanged < f.WeakHashThresholdPct {
-		l.Debugf("not weak hashing %s. not enough changed %.02f < %d", state.file.Name, blocksPercentChanged, f.WeakHashThresholdPct)
+		l.Debugf("not weak hashing %s. not enough changed %d < %d", state.file.Name, blocksPercentChanged, f.WeakHashThresholdPct)
 		return nil, nil
 	}
 
@@ -1406,7 +1547,11 @@ func (f *sendReceiveFolder) initWeakHashFinder(state copyBlocksState) (*weakhash
 		return nil, nil
 	}
 
-	weakHashFinder, err := weakhash.NewFinder(f.ctx, file, state.file.BlockSize(), hashesToFind)
+	var start int64
+	if first < len(state.file.Blocks) {
+		start = state.file.Blocks[first].Offset
+	}
+	weakHashFinder, err := weakhash.NewFinderAt(f.ctx, file, start, state.file.BlockSize(), hashesToFind)
 	if err != nil {
 		l.Debugln("weak hasher", err)
 		return nil, file
@@ -1414,19 +1559,29 @@ func (f *sendReceiveFolder) initWeakHashFinder(state copyBlocksState) (*weakhash
 	return weakHashFinder, file
 }
 
-func (f *sendReceiveFolder) verifyBuffer(buf []byte, block protocol.BlockInfo) error {
+func (f *sendReceiveFolder) verifyBuffer(buf []byte, block protocol.BlockInfo, hashAlgo protocol.BlockHashAlgorithm) error {
 	if len(buf) != int(block.Size) {
 		return fmt.Errorf("length mismatch %d != %d", len(buf), block.Size)
 	}
 
-	hash := sha256.Sum256(buf)
-	if !bytes.Equal(hash[:], block.Hash) {
+	hash := scanner.HashBlock(hashAlgo, buf)
+	if !bytes.Equal(hash, block.Hash) {
 		return fmt.Errorf("hash mismatch %x != %x", hash, block.Hash)
 	}
 
 	return nil
 }
 
+// trustsVerification returns whether a block verified by the given device
+// may be used without verifying it again.
+func (f *sendReceiveFolder) trustsVerification(id protocol.DeviceID) bool {
+	if !f.RelaxedVerification {
+		return false
+	}
+	dev, ok := f.model.cfg.Device(id)
+	return ok && !dev.Untrusted
+}
+
 func (f *sendReceiveFolder) pullerRoutine(in <-chan pullBlockState, out chan<- *sharedPullerState) {
 	requestLimiter := newByteSemaphore(f.PullerMaxPendingKiB * 1024)
 	wg := sync.NewWaitGroup()
@@ -1474,9 +1629,10 @@ func (f *sendReceiveFolder) pullBlock(state pullBlockState, out chan<- *sharedPu
 		return
 	}
 
-	if !f.DisableSparseFiles && state.reused == 0 && state.block.IsEmpty() {
+	if !f.DisableSparseFiles && state.block.IsEmpty() && (state.reused == 0 || fd.PunchHole(state.block.Offset, int64(state.block.Size)) == nil) {
 		// There is no need to request a block of all zeroes. Pretend we
-		// requested it and handled it correctly.
+		// requested it and handled it correctly. In a reused temp file
+		// the block isn't zeroes yet, hence the hole.
 		state.pullDone(state.block)
 		out <- state.sharedPullerState
 		return
@@ -1492,10 +1648,16 @@ func (f *sendReceiveFolder) pullBlock(state pullBlockState, out chan<- *sharedPu
 		default:
 		}
 
-		// Select the least busy device to pull the block from. If we found no
-		// feasible device at all, fail the block (and in the long run, the
-		// file).
-		selected, found := activity.leastBusy(candidates)
+		// Select the least busy device to pull the block from, or the
+		// fastest one for prioritized blocks. If we found no feasible device
+		// at all, fail the block (and in the long run, the file).
+		var selected Availability
+		var found bool
+		if state.prioritized {
+			selected, found = activity.fastest(candidates)
+		} else {
+			selected, found = activity.leastBusy(candidates)
+		}
 		if !found {
 			if lastError != nil {
 				state.fail(errors.Wrap(lastError, "pull"))
@@ -1511,9 +1673,14 @@ func (f *sendReceiveFolder) pullBlock(state pullBlockState, out chan<- *sharedPu
 		// leastBusy can select another device when someone else asks.
 		activity.using(selected)
 		var buf []byte
+		var verified bool
 		blockNo := int(state.block.Offset / int64(state.file.BlockSize()))
-		buf, lastError = f.model.requestGlobal(f.ctx, selected.ID, f.folderID, state.file.Name, blockNo, state.block.Offset, int(state.block.Size), state.block.Hash, state.block.WeakHash, selected.FromTemporary)
+		t0 := time.Now()
+		buf, verified, lastError = f.model.requestGlobal(f.ctx, selected.ID, f.folderID, state.file.Name, blockNo, state.block.Offset, int(state.block.Size), state.block.Hash, state.file.BlockHashAlgorithm, state.block.WeakHash, selected.FromTemporary)
 		activity.done(selected)
+		if lastError == nil {
+			activity.transferred(selected, len(buf), time.Since(t0))
+		}
 		if lastError != nil {
 			l.Debugln("request:", f.folderID, state.file.Name, state.block.Offset, state.block.Size, "returned error:", lastError)
 			continue
@@ -1525,8 +1692,10 @@ func (f *sendReceiveFolder) pullBlock(state pullBlockState, out chan<- *sharedPu
 		// encrypted hash token. In that case we can't verify the block
 		// integrity so we'll take it on trust. (The other side can and
 		// will verify.)
-		if f.Type != config.FolderTypeReceiveEncrypted {
-			lastError = f.verifyBuffer(buf, state.block)
+		// With relaxed verification we trust the other side to have
+		// verified the block before sending it, unless it's untrusted.
+		if f.Type != config.FolderTypeReceiveEncrypted && !(verified && f.trustsVerification(selected.ID)) {
+			lastError = f.verifyBuffer(buf, state.block, state.file.BlockHashAlgorithm)
 		}
 		if lastError != nil {
 			l.Debugln("request:", f.folderID, state.file.Name, state.block.Offset, state.block.Size, "hash mismatch")
@@ -1558,7 +1727,18 @@ func (f *sendReceiveFolder) performFinish(file, curFile protocol.FileInfo, hasCu
 		return err
 	}
 
-	if stat, err := f.mtimefs.Lstat(file.Name); err == nil {
+	if err := f.setXattrs(tempName, file); err != nil {
+		return err
+	}
+
+	if err := f.setOwnership(tempName, &file); err != nil {
+		return err
+	}
+
+	// A followed symlink to a file is kept, and the new contents are
+	// written to where it points instead.
+	throughSymlink := false
+	if stat, err := f.lstat(file.Name); err == nil {
 		// There is an old file or directory already in place. We need to
 		// handle that.
 
@@ -1566,28 +1746,75 @@ func (f *sendReceiveFolder) performFinish(file, curFile protocol.FileInfo, hasCu
 			return err
 		}
 
-		if !curFile.IsDirectory() && !curFile.IsSymlink() && f.inConflict(curFile.Version, file.Version) {
-			// The new file has been changed in conflict with the existing one. We
-			// should file it away as a conflict instead of just removing or
-			// archiving.
-			// Directories and symlinks aren't checked for conflicts.
+		if stat.IsRegular() && f.SymlinkPolicy == config.SymlinkPolicyFollow {
+			if info, err := f.mtimefs.Lstat(file.Name); err == nil && info.IsSymlink() {
+				throughSymlink = true
+			}
+		}
 
-			err = f.inWritableDir(func(name string) error {
-				return f.moveForConflict(name, file.ModifiedBy.String(), scanChan)
-			}, curFile.Name)
+		if curFile.IsDirectory() || curFile.IsSymlink() || !f.inConflict(curFile.Version, file.Version) {
+			// Directories and symlinks aren't checked for conflicts. A
+			// file behind a symlink is overwritten in place below,
+			// without archiving the old contents.
+			if !throughSymlink {
+				err = f.deleteItemOnDisk(curFile, snap, scanChan)
+			}
 		} else {
-			err = f.deleteItemOnDisk(curFile, snap, scanChan)
+			switch resolveConflict(f.ConflictPolicy, f.ConflictPreferredDevice, curFile, file) {
+			case conflictKeepLocal:
+				// Drop the new file and merge the version vectors, so that
+				// the existing one wins the conflict everywhere.
+				f.log.Infof("Keeping local version of %v in %v due to the %v conflict policy", file.Name, f.Description(), f.ConflictPolicy)
+				if err := f.mtimefs.Remove(tempName); err != nil && !fs.IsNotExist(err) {
+					l.Debugln(f, "removing temp file after conflict", err)
+				}
+				curFile.Version = curFile.Version.Merge(file.Version)
+				dbUpdateChan <- dbUpdateJob{curFile, dbUpdateHandleFile}
+				return nil
+
+			case conflictKeepRemote:
+				// Replace the existing file, archiving it if versioning is
+				// enabled.
+				l.Debugf("%v replacing %v due to the %v conflict policy", f, file.Name, f.ConflictPolicy)
+				if !throughSymlink {
+					err = f.deleteItemOnDisk(curFile, snap, scanChan)
+				}
+
+			case conflictKeepBoth:
+				if throughSymlink {
+					err = f.copyForConflict(curFile.Name, file.ModifiedBy.String(), scanChan)
+					break
+				}
+				fallthrough
+
+			default:
+				// The new file has been changed in conflict with the
+				// existing one. We should file it away as a conflict
+				// instead of just removing or archiving.
+				err = f.inWritableDir(func(name string) error {
+					return f.moveForConflict(name, file.ModifiedBy.String(), scanChan)
+				}, curFile.Name)
+			}
 		}
 		if err != nil {
 			return err
 		}
+	} else if cerr := f.caseCollision(file.Name, err, snap); cerr != nil {
+		if !f.RenameCaseCollisions {
+			return cerr
+		}
+		return f.finishCaseCollision(file, tempName, cerr, dbUpdateChan, scanChan)
 	} else if !fs.IsNotExist(err) {
 		return err
 	}
 
-	// Replace the original content with the new one. If it didn't work,
-	// leave the temp file in place for reuse.
-	if err := osutil.RenameOrCopy(f.CopyRangeMethod, f.mtimefs, f.mtimefs, tempName, file.Name); err != nil {
+	if throughSymlink {
+		if err := f.writeThroughSymlink(file, tempName); err != nil {
+			return err
+		}
+	} else if err := osutil.RenameOrCopy(f.CopyRangeMethod, f.mtimefs, f.mtimefs, tempName, file.Name); err != nil {
+		// Replace the original content with the new one. If it didn't
+		// work, leave the temp file in place for reuse.
 		return err
 	}
 
@@ -1599,51 +1826,149 @@ func (f *sendReceiveFolder) performFinish(file, curFile protocol.FileInfo, hasCu
 	return nil
 }
 
-func (f *sendReceiveFolder) finisherRoutine(snap *db.Snapshot, in <-chan *sharedPullerState, dbUpdateChan chan<- dbUpdateJob, scanChan chan<- string) {
+// caseCollision returns an error if the item can't be stored under its
+// name, because that differs only in case from the name of another item
+// that exists on other devices. That can happen when syncing with case
+// sensitive filesystems. An item that merely changed case isn't a
+// collision, as the old name is deleted.
+func (f *sendReceiveFolder) caseCollision(name string, lstatErr error, snap *db.Snapshot) *caseCollisionError {
+	var caseErr *fs.ErrCaseConflict
+	if !errors.As(lstatErr, &caseErr) {
+		return nil
+	}
+	other, ok := snap.GetGlobal(caseErr.Real)
+	if !ok || other.IsDeleted() || other.IsInvalid() {
+		return nil
+	}
+	return &caseCollisionError{name: name, other: caseErr.Real}
+}
+
+// finishCaseCollision stores the pulled file under a conflict name, as the
+// name itself collides with another item. The file itself is marked as
+// unsupported, so that it isn't needed anymore until it changes again.
+func (f *sendReceiveFolder) finishCaseCollision(file protocol.FileInfo, tempName string, cerr *caseCollisionError, dbUpdateChan chan<- dbUpdateJob, scanChan chan<- string) error {
+	name := conflictName(file.Name, file.ModifiedBy.String())
+	if err := osutil.RenameOrCopy(f.CopyRangeMethod, f.mtimefs, f.mtimefs, tempName, name); err != nil {
+		return err
+	}
+	f.mtimefs.Chtimes(name, file.ModTime(), file.ModTime()) // never fails
+	f.log.Infof("Stored %q in %v as %q due to a case collision with %q", file.Name, f.Description(), name, cerr.other)
+
+	// The copy is a new file to be synced like any other.
+	scanChan <- name
+
+	file.SetUnsupported()
+	dbUpdateChan <- dbUpdateJob{file, dbUpdateInvalidate}
+	return nil
+}
+
+// finisherRoutine finishes the files as they complete, returning those it
+// kept staged in their temporary files instead.
+func (f *sendReceiveFolder) finisherRoutine(snap *db.Snapshot, in <-chan *sharedPullerState, dbUpdateChan chan<- dbUpdateJob, scanChan chan<- string) []*sharedPullerState {
+	var staged []*sharedPullerState
 	for state := range in {
 		if closed, err := state.finalClose(); closed {
 			l.Debugln(f, "closing", state.file.Name)
 
 			f.queue.Done(state.file.Name)
+			if f.Type != config.FolderTypeReceiveEncrypted {
+				f.model.progressEmitter.Deregister(state)
+			}
+
+			if err == nil && f.StagedPull {
+				staged = append(staged, state)
+				continue
+			}
 
 			if err == nil {
 				err = f.performFinish(state.file, state.curFile, state.hasCurFile, state.tempName, snap, dbUpdateChan, scanChan)
 			}
+			f.finished(state, err)
+		}
+	}
+	return staged
+}
 
-			if err != nil {
-				f.newPullError(state.file.Name, err)
-			} else {
-				minBlocksPerBlock := state.file.BlockSize() / protocol.MinBlockSize
-				blockStatsMut.Lock()
-				blockStats["total"] += (state.reused + state.copyTotal + state.pullTotal) * minBlocksPerBlock
-				blockStats["reused"] += state.reused * minBlocksPerBlock
-				blockStats["pulled"] += state.pullTotal * minBlocksPerBlock
-				// copyOriginShifted is counted towards copyOrigin due to progress bar reasons
-				// for reporting reasons we want to separate these.
-				blockStats["copyOrigin"] += (state.copyOrigin - state.copyOriginShifted) * minBlocksPerBlock
-				blockStats["copyOriginShifted"] += state.copyOriginShifted * minBlocksPerBlock
-				blockStats["copyElsewhere"] += (state.copyTotal - state.copyOrigin) * minBlocksPerBlock
-				blockStatsMut.Unlock()
-			}
+// finishStaged moves the staged files into place, once all of them have
+// been transferred.
+func (f *sendReceiveFolder) finishStaged(staged []*sharedPullerState, snap *db.Snapshot, dbUpdateChan chan<- dbUpdateJob, scanChan chan<- string) {
+	l.Debugf("%v moving %d staged files into place", f, len(staged))
+	for _, state := range staged {
+		err := f.performFinish(state.file, state.curFile, state.hasCurFile, state.tempName, snap, dbUpdateChan, scanChan)
+		f.finished(state, err)
+	}
+}
 
-			if f.Type != config.FolderTypeReceiveEncrypted {
-				f.model.progressEmitter.Deregister(state)
-			}
+// finished records the outcome of pulling the file.
+func (f *sendReceiveFolder) finished(state *sharedPullerState, err error) {
+	if err != nil {
+		f.newPullError(state.file.Name, err)
+	} else {
+		minBlocksPerBlock := state.file.BlockSize() / protocol.MinBlockSize
+		blockStatsMut.Lock()
+		blockStats["total"] += (state.reused + state.copyTotal + state.pullTotal) * minBlocksPerBlock
+		blockStats["reused"] += state.reused * minBlocksPerBlock
+		blockStats["pulled"] += state.pullTotal * minBlocksPerBlock
+		// copyOriginShifted is counted towards copyOrigin due to progress bar reasons
+		// for reporting reasons we want to separate these.
+		blockStats["copyOrigin"] += (state.copyOrigin - state.copyOriginShifted) * minBlocksPerBlock
+		blockStats["copyOriginShifted"] += state.copyOriginShifted * minBlocksPerBlock
+		blockStats["copyElsewhere"] += (state.copyTotal - state.copyOrigin) * minBlocksPerBlock
+		blockStatsMut.Unlock()
+	}
+
+	f.evLogger.Log(events.ItemFinished, map[string]interface{}{
+		"folder": f.folderID,
+		"item":   state.file.Name,
+		"error":  events.Error(err),
+		"type":   "file",
+		"action": "update",
+	})
+}
 
-			f.evLogger.Log(events.ItemFinished, map[string]interface{}{
-				"folder": f.folderID,
-				"item":   state.file.Name,
-				"error":  events.Error(err),
-				"type":   "file",
-				"action": "update",
-			})
+// sortQueue orders the queued files by the pull order patterns, and the
+// pull order within those. Newly queued files are already in alphabetic
+// order, so that only needs sorting when the order changes.
+func (f *sendReceiveFolder) sortQueue(changed bool) {
+	f.pullOrderMut.Lock()
+	order, patterns := f.pullOrder, f.pullOrderPatterns
+	f.pullOrderMut.Unlock()
+
+	switch order {
+	case config.PullOrderRandom:
+		f.queue.Shuffle()
+	case config.PullOrderAlphabetic:
+		if changed {
+			f.queue.SortAlphabetic()
 		}
+	case config.PullOrderSmallestFirst:
+		f.queue.SortSmallestFirst()
+	case config.PullOrderLargestFirst:
+		f.queue.SortLargestFirst()
+	case config.PullOrderOldestFirst:
+		f.queue.SortOldestFirst()
+	case config.PullOrderNewestFirst:
+		f.queue.SortNewestFirst()
 	}
+	f.queue.SortByPatterns(patterns)
+	f.queue.SortPrioritized()
 }
 
-// Moves the given filename to the front of the job queue
+// SetPullOrder changes the pull order, including for the files already
+// queued, without restarting the folder.
+func (f *sendReceiveFolder) SetPullOrder(order config.PullOrder, patterns []string) {
+	f.pullOrderMut.Lock()
+	f.pullOrder, f.pullOrderPatterns = order, patterns
+	f.pullOrderMut.Unlock()
+	f.sortQueue(true)
+}
+
+// BringToFront prioritizes the given file, moving it to the front of the job
+// queue and requesting its blocks ahead of others, and makes sure a pull
+// happens to get it.
 func (f *sendReceiveFolder) BringToFront(filename string) {
-	f.queue.BringToFront(filename)
+	f.queue.Prioritize(filename)
+	f.SchedulePull()
 }
 
 func (f *sendReceiveFolder) Jobs(page, perpage int) ([]string, []string, int) {
@@ -1782,7 +2107,7 @@ func removeAvailability(availabilities []Availability, availability Availability
 
 func (f *sendReceiveFolder) moveForConflict(name, lastModBy string, scanChan chan<- string) error {
 	if isConflict(name) {
-		l.Infoln("Conflict for", name, "which is already a conflict copy; not copying again.")
+		f.log.Infoln("Conflict for", name, "which is already a conflict copy; not copying again.")
 		if err := f.mtimefs.Remove(name); err != nil && !fs.IsNotExist(err) {
 			return errors.Wrap(err, contextRemovingOldItem)
 		}
@@ -1798,6 +2123,7 @@ func (f *sendReceiveFolder) moveForConflict(name, lastModBy string, scanChan cha
 
 	newName := conflictName(name, lastModBy)
 	err := f.mtimefs.Rename(name, newName)
+	renamed := err == nil
 	if fs.IsNotExist(err) {
 		// We were supposed to move a file away but it does not exist. Either
 		// the user has already moved it away, or the conflict was between a
@@ -1816,12 +2142,61 @@ func (f *sendReceiveFolder) moveForConflict(name, lastModBy string, scanChan cha
 			}
 		}
 	}
+	if renamed {
+		f.evLogger.Log(events.ConflictCreated, map[string]string{
+			"folder":   f.folderID,
+			"item":     name,
+			"conflict": newName,
+		})
+	}
 	if err == nil {
 		scanChan <- newName
 	}
 	return err
 }
 
+// copyForConflict is moveForConflict for a file behind a followed symlink:
+// the contents are copied to the conflict copy, keeping the symlink.
+func (f *sendReceiveFolder) copyForConflict(name, lastModBy string, scanChan chan<- string) error {
+	if isConflict(name) || f.MaxConflicts == 0 {
+		return nil
+	}
+	newName := conflictName(name, lastModBy)
+	if err := osutil.Copy(f.CopyRangeMethod, f.mtimefs, f.mtimefs, name, newName); err != nil {
+		return err
+	}
+	f.evLogger.Log(events.ConflictCreated, map[string]string{
+		"folder":   f.folderID,
+		"item":     name,
+		"conflict": newName,
+	})
+	scanChan <- newName
+	return nil
+}
+
+// writeThroughSymlink replaces the contents of the file the symlink points
+// to with those of the temp file, which is removed.
+func (f *sendReceiveFolder) writeThroughSymlink(file protocol.FileInfo, tempName string) error {
+	if err := osutil.Copy(f.CopyRangeMethod, f.mtimefs, f.mtimefs, tempName, file.Name); err != nil {
+		return err
+	}
+	if !f.IgnorePerms && !file.NoPermissions {
+		if err := f.mtimefs.Chmod(file.Name, fs.FileMode(file.Permissions&0777)); err != nil {
+			return err
+		}
+	}
+	if err := f.mtimefs.Remove(tempName); err != nil && !fs.IsNotExist(err) {
+		l.Debugln(f, "removing temp file after writing through symlink", err)
+	}
+	return nil
+}
+
+func (f *sendReceiveFolder) hasTempPullErrors() bool {
+	f.errorsMut.Lock()
+	defer f.errorsMut.Unlock()
+	return len(f.tempPullErrors) > 0
+}
+
 func (f *sendReceiveFolder) newPullError(path string, err error) {
 	if errors.Cause(err) == f.ctx.Err() {
 		// Error because the folder stopped - no point logging/tracking
@@ -1874,7 +2249,7 @@ func (f *sendReceiveFolder) deleteItemOnDisk(item protocol.FileInfo, snap *db.Sn
 // deleteDirOnDisk attempts to delete a directory. It checks for files/dirs inside
 // the directory and removes them if possible or returns an error if it fails
 func (f *sendReceiveFolder) deleteDirOnDisk(dir string, snap *db.Snapshot, scanChan chan<- string) error {
-	if err := osutil.TraversesSymlink(f.mtimefs, filepath.Dir(dir)); err != nil {
+	if err := f.traversesSymlink(filepath.Dir(dir)); err != nil {
 		return err
 	}
 
@@ -2014,6 +2389,9 @@ func (f *sendReceiveFolder) scanIfItemChanged(name string, stat fs.FileInfo, ite
 	if err != nil {
 		return errors.Wrap(err, "comparing item on disk to db")
 	}
+	if statItem.IsSymlink() {
+		statItem.SymlinkTarget = f.SyncedSymlinkTarget(statItem.SymlinkTarget)
+	}
 
 	if !statItem.IsEquivalentOptional(item, f.modTimeWindow, f.IgnorePerms, true, protocol.LocalAllFlags) {
 		return errModified
@@ -2026,12 +2404,12 @@ func (f *sendReceiveFolder) scanIfItemChanged(name string, stat fs.FileInfo, ite
 // in the DB before the caller proceeds with actually deleting it.
 // I.e. non-nil error status means "Do not delete!" or "is already deleted".
 func (f *sendReceiveFolder) checkToBeDeleted(file, cur protocol.FileInfo, hasCur bool, scanChan chan<- string) error {
-	if err := osutil.TraversesSymlink(f.mtimefs, filepath.Dir(file.Name)); err != nil {
+	if err := f.traversesSymlink(filepath.Dir(file.Name)); err != nil {
 		l.Debugln(f, "not deleting item behind symlink on disk, but update db", file.Name)
 		return fs.ErrNotExist
 	}
 
-	stat, err := f.mtimefs.Lstat(file.Name)
+	stat, err := f.lstat(file.Name)
 	deleted := fs.IsNotExist(err) || fs.IsErrCaseConflict(err)
 	if !deleted && err != nil {
 		return err
@@ -2068,6 +2446,68 @@ func (f *sendReceiveFolder) maybeCopyOwner(path string) error {
 	return nil
 }
 
+// setXattrs applies the extended attributes of the file, if they are synced
+// and the other device sent them.
+func (f *sendReceiveFolder) setXattrs(path string, file protocol.FileInfo) error {
+	if !f.SyncXattrs || file.XattrData == nil {
+		return nil
+	}
+	xattrs := make([]fs.Xattr, len(file.XattrData.Xattrs))
+	for i, xa := range file.XattrData.Xattrs {
+		xattrs[i] = fs.Xattr{Name: xa.Name, Value: xa.Value}
+	}
+	if err := f.mtimefs.SetXattr(path, xattrs); err != nil && !errors.Is(err, fs.ErrXattrsNotSupported) {
+		return errors.Wrap(err, "setting extended attributes")
+	}
+	return nil
+}
+
+// setOwnership applies the ownership of the file, if it is synced and the
+// other device sent it. Users and groups are mapped by name where they
+// exist locally, and by ID otherwise. If we lack the privileges to change
+// the ownership, the file keeps what it has and that is recorded instead,
+// so that the next scan doesn't see a change to send back.
+func (f *sendReceiveFolder) setOwnership(path string, file *protocol.FileInfo) error {
+	if !f.SyncOwnership || file.OwnershipData == nil || runtime.GOOS == "windows" {
+		return nil
+	}
+	uid, ok := osutil.UserID(file.OwnershipData.UserName)
+	if !ok {
+		uid = file.OwnershipData.UID
+	}
+	gid, ok := osutil.GroupID(file.OwnershipData.GroupName)
+	if !ok {
+		gid = file.OwnershipData.GID
+	}
+
+	info, err := f.mtimefs.Lstat(path)
+	if err != nil {
+		return errors.Wrap(err, "setting ownership")
+	}
+	if info.Owner() == uid && info.Group() == gid {
+		return nil
+	}
+	err = f.mtimefs.Lchown(path, uid, gid)
+	if err == nil {
+		return nil
+	}
+	if !fs.IsPermission(err) {
+		return errors.Wrap(err, "setting ownership")
+	}
+
+	l.Debugf("%v: keeping ownership of %s: %v", f, file.Name, err)
+	f.ownershipWarning.Do(func() {
+		f.log.Warnf("Cannot set the ownership of synced items in folder %s, keeping the local ownership (not running privileged?): %v", f.Description(), err)
+	})
+	file.OwnershipData = &protocol.OwnershipData{
+		UserName:  osutil.UserName(info.Owner()),
+		GroupName: osutil.GroupName(info.Group()),
+		UID:       info.Owner(),
+		GID:       info.Group(),
+	}
+	return nil
+}
+
 func (f *sendReceiveFolder) inWritableDir(fn func(string) error, path string) error {
 	return inWritableDir(fn, f.mtimefs, path, f.IgnorePerms)
 }
@@ -2091,6 +2531,9 @@ func (f *sendReceiveFolder) withLimiter(fn func() error) error {
 type FileError struct {
 	Path string `json:"path"`
 	Err  string `json:"error"`
+	// The number of pulls in a row that failed on the item, zero for
+	// errors while scanning.
+	Failures int `json:"failures,omitempty"`
 }
 
 type fileErrorList []FileError
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/ignore"
@@ -242,7 +243,7 @@ func TestCopierFinder(t *testing.T) {
 			finisherChan := make(chan *sharedPullerState, 1)
 
 			// Run a single fetcher routine
-			go f.copierRoutine(copyChan, pullChan, finisherChan)
+			go f.copierRoutine(copyChan, pullChan, pullChan, finisherChan)
 			defer close(copyChan)
 
 			f.handleFile(requiredFile, f.fset.Snapshot(), copyChan)
@@ -293,7 +294,7 @@ func TestCopierFinder(t *testing.T) {
 			}
 
 			// Verify that the fetched blocks have actually been written to the temp file
-			blks, err := scanner.HashFile(context.TODO(), f.Filesystem(), tempFile, protocol.MinBlockSize, nil, false)
+			blks, err := scanner.HashFile(context.TODO(), f.Filesystem(), tempFile, protocol.MinBlockSize, nil, protocol.BlockHashAlgorithmSHA256, false)
 			if err != nil {
 				t.Log(err)
 			}
@@ -339,7 +340,7 @@ func TestWeakHash(t *testing.T) {
 	// File 1: abcdefgh
 	// File 2: xyabcdef
 	f.Seek(0, os.SEEK_SET)
-	existing, err := scanner.Blocks(context.TODO(), f, protocol.MinBlockSize, size, nil, true)
+	existing, err := scanner.Blocks(context.TODO(), f, protocol.MinBlockSize, size, nil, protocol.BlockHashAlgorithmSHA256, true)
 	if err != nil {
 		t.Error(err)
 	}
@@ -348,7 +349,7 @@ func TestWeakHash(t *testing.T) {
 	remainder := io.LimitReader(f, size-shift)
 	prefix := io.LimitReader(rand.Reader, shift)
 	nf := io.MultiReader(prefix, remainder)
-	desired, err := scanner.Blocks(context.TODO(), nf, protocol.MinBlockSize, size, nil, true)
+	desired, err := scanner.Blocks(context.TODO(), nf, protocol.MinBlockSize, size, nil, protocol.BlockHashAlgorithmSHA256, true)
 	if err != nil {
 		t.Error(err)
 	}
@@ -374,7 +375,7 @@ func TestWeakHash(t *testing.T) {
 	finisherChan := make(chan *sharedPullerState, 1)
 
 	// Run a single fetcher routine
-	go fo.copierRoutine(copyChan, pullChan, finisherChan)
+	go fo.copierRoutine(copyChan, pullChan, pullChan, finisherChan)
 	defer close(copyChan)
 
 	// Test 1 - no weak hashing, file gets fully repulled (`expectBlocks` pulls).
@@ -429,9 +430,169 @@ func TestWeakHash(t *testing.T) {
 	}
 }
 
+func TestWeakHashShiftedTail(t *testing.T) {
+	model, fo, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(fo, model, wcfgCancel)
+	ffs := fo.Filesystem()
+
+	// Insert a few bytes into one of the last blocks of the file,
+	// shifting everything after it. Less of the file changes than the
+	// default threshold, but the shifted blocks should still be found
+	// locally.
+	var size int64 = 4 << 20
+	insertAt := size - 6*protocol.MinBlockSize + 10
+	expectBlocks := int(size / protocol.MinBlockSize)
+
+	data := make([]byte, size)
+	_, err := io.ReadFull(rand.Reader, data)
+	must(t, err)
+	inserted := make([]byte, 0, size)
+	inserted = append(inserted, data[:insertAt]...)
+	inserted = append(inserted, "inserted"...)
+	inserted = append(inserted, data[insertAt:size-int64(len("inserted"))]...)
+
+	must(t, writeFile(ffs, "weakhash", data, 0644))
+	info, err := ffs.Lstat("weakhash")
+	must(t, err)
+
+	existing, err := scanner.Blocks(context.TODO(), bytes.NewReader(data), protocol.MinBlockSize, size, nil, protocol.BlockHashAlgorithmSHA256, true)
+	must(t, err)
+	desired, err := scanner.Blocks(context.TODO(), bytes.NewReader(inserted), protocol.MinBlockSize, size, nil, protocol.BlockHashAlgorithmSHA256, true)
+	must(t, err)
+
+	fo.updateLocalsFromScanning([]protocol.FileInfo{{
+		Name:       "weakhash",
+		Blocks:     existing,
+		Size:       size,
+		ModifiedS:  info.ModTime().Unix(),
+		ModifiedNs: info.ModTime().Nanosecond(),
+	}})
+	desiredFile := protocol.FileInfo{
+		Name:      "weakhash",
+		Size:      size,
+		Blocks:    desired,
+		ModifiedS: info.ModTime().Unix() + 1,
+	}
+
+	copyChan := make(chan copyBlocksState)
+	pullChan := make(chan pullBlockState, expectBlocks)
+	finisherChan := make(chan *sharedPullerState, 1)
+	go fo.copierRoutine(copyChan, pullChan, pullChan, finisherChan)
+	defer close(copyChan)
+
+	fo.handleFile(desiredFile, fo.fset.Snapshot(), copyChan)
+
+	var finish *sharedPullerState
+	select {
+	case finish = <-finisherChan:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out")
+	}
+	defer cleanupSharedPullerState(finish)
+
+	// Only the block with the insertion remains to be pulled.
+	if len(pullChan) != 1 {
+		t.Errorf("expected 1 block to be pulled, got %d", len(pullChan))
+	}
+	expectShifted := expectBlocks - int(insertAt/protocol.MinBlockSize) - 1
+	if finish.copyOriginShifted != expectShifted {
+		t.Errorf("copied %d shifted blocks, expected %d", finish.copyOriginShifted, expectShifted)
+	}
+}
+
+func TestCopierOtherFile(t *testing.T) {
+	// Blocks of another file are found through the block map, also when
+	// they are not at an offset that is a multiple of the block size.
+	model, fo, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(fo, model, wcfgCancel)
+	ffs := fo.Filesystem()
+
+	data := make([]byte, 1<<20)
+	_, err := io.ReadFull(rand.Reader, data)
+	must(t, err)
+	must(t, writeFile(ffs, "source", data, 0644))
+	info, err := ffs.Lstat("source")
+	must(t, err)
+	srcBlocks, err := scanner.ContentBlocks(context.TODO(), bytes.NewReader(data), protocol.MinBlockSize, int64(len(data)), nil, protocol.BlockHashAlgorithmSHA256, true)
+	must(t, err)
+	fo.updateLocalsFromScanning([]protocol.FileInfo{{
+		Name:       "source",
+		Blocks:     srcBlocks,
+		Size:       int64(len(data)),
+		ModifiedS:  info.ModTime().Unix(),
+		ModifiedNs: info.ModTime().Nanosecond(),
+	}})
+
+	// The target is the source without its first block and with some
+	// data appended.
+	appended := make([]byte, 1000)
+	_, err = io.ReadFull(rand.Reader, appended)
+	must(t, err)
+	target := append(append([]byte{}, data[srcBlocks[0].Size:]...), appended...)
+	tgtBlocks, err := scanner.ContentBlocks(context.TODO(), bytes.NewReader(target), protocol.MinBlockSize, int64(len(target)), nil, protocol.BlockHashAlgorithmSHA256, true)
+	must(t, err)
+
+	have := make(map[string]bool)
+	for _, b := range srcBlocks {
+		have[string(b.Hash)] = true
+	}
+	expectPulls := 0
+	for _, b := range tgtBlocks {
+		if !have[string(b.Hash)] {
+			expectPulls++
+		}
+	}
+	if expectPulls == len(tgtBlocks) {
+		t.Fatal("no blocks in common")
+	}
+
+	copyChan := make(chan copyBlocksState)
+	pullChan := make(chan pullBlockState, len(tgtBlocks))
+	finisherChan := make(chan *sharedPullerState, 1)
+	go fo.copierRoutine(copyChan, pullChan, pullChan, finisherChan)
+	defer close(copyChan)
+
+	fo.handleFile(protocol.FileInfo{
+		Name:      "target",
+		Size:      int64(len(target)),
+		Blocks:    tgtBlocks,
+		ModifiedS: info.ModTime().Unix(),
+	}, fo.fset.Snapshot(), copyChan)
+
+	var finish *sharedPullerState
+	select {
+	case finish = <-finisherChan:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out")
+	}
+	cleanupSharedPullerState(finish)
+
+	if len(pullChan) != expectPulls {
+		t.Errorf("expected %d blocks to be pulled, got %d", expectPulls, len(pullChan))
+	}
+	if copied := finish.copyTotal - finish.copyOrigin; copied != len(tgtBlocks)-expectPulls {
+		t.Errorf("copied %d blocks from elsewhere, expected %d", copied, len(tgtBlocks)-expectPulls)
+	}
+
+	fd, err := ffs.Open(fs.TempName("target"))
+	must(t, err)
+	defer fd.Close()
+	for _, b := range tgtBlocks {
+		if !have[string(b.Hash)] {
+			continue
+		}
+		buf := make([]byte, b.Size)
+		_, err := fd.ReadAt(buf, b.Offset)
+		must(t, err)
+		if !bytes.Equal(buf, target[b.Offset:b.Offset+int64(b.Size)]) {
+			t.Errorf("wrong data copied at offset %d", b.Offset)
+		}
+	}
+}
+
 // Test that updating a file removes its old blocks from the blockmap
 func TestCopierCleanup(t *testing.T) {
-	iterFn := func(folder, file string, index int32) bool {
+	iterFn := func(folder, file string, offset int64) bool {
 		return true
 	}
 
@@ -705,8 +866,8 @@ func TestIssue3164(t *testing.T) {
 
 func TestDiff(t *testing.T) {
 	for i, test := range diffTestData {
-		a, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.a), test.s, -1, nil, false)
-		b, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.b), test.s, -1, nil, false)
+		a, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.a), test.s, -1, nil, protocol.BlockHashAlgorithmSHA256, false)
+		b, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.b), test.s, -1, nil, protocol.BlockHashAlgorithmSHA256, false)
 		_, d := blockDiff(a, b)
 		if len(d) != len(test.d) {
 			t.Fatalf("Incorrect length for diff %d; %d != %d", i, len(d), len(test.d))
@@ -726,8 +887,8 @@ func TestDiff(t *testing.T) {
 func BenchmarkDiff(b *testing.B) {
 	testCases := make([]struct{ a, b []protocol.BlockInfo }, 0, len(diffTestData))
 	for _, test := range diffTestData {
-		a, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.a), test.s, -1, nil, false)
-		b, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.b), test.s, -1, nil, false)
+		a, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.a), test.s, -1, nil, protocol.BlockHashAlgorithmSHA256, false)
+		b, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.b), test.s, -1, nil, protocol.BlockHashAlgorithmSHA256, false)
 		testCases = append(testCases, struct{ a, b []protocol.BlockInfo }{a, b})
 	}
 	b.ReportAllocs()
@@ -1142,6 +1303,67 @@ func TestPullCaseOnlyPerformFinish(t *testing.T) {
 	}
 }
 
+func TestConflictPolicy(t *testing.T) {
+	for _, keepLocal := range []bool{true, false} {
+		t.Run(fmt.Sprint("keepLocal=", keepLocal), func(t *testing.T) {
+			m, f, wcfgCancel := setupSendReceiveFolder(t)
+			defer cleanupSRFolder(f, m, wcfgCancel)
+			ffs := f.Filesystem()
+			f.ConflictPolicy = config.ConflictPolicyKeepLargest
+
+			name := "foo"
+			local := []byte("local contents")
+			remoteContents := []byte("remote")
+			if !keepLocal {
+				remoteContents = []byte("much longer remote contents")
+			}
+			must(t, writeFile(ffs, name, local, 0644))
+			must(t, f.scanSubdirs(nil))
+
+			snap := dbSnapshot(t, m, f.ID)
+			defer snap.Release()
+			cur, ok := snap.Get(protocol.LocalDeviceID, name)
+			if !ok {
+				t.Fatal("file is missing")
+			}
+
+			remote := cur
+			remote.Version = protocol.Vector{}.Update(device1.Short())
+			remote.ModifiedBy = device1.Short()
+			remote.Size = int64(len(remoteContents))
+			temp := fs.TempName(name)
+			must(t, writeFile(ffs, temp, remoteContents, 0644))
+			scanChan := make(chan string, 1)
+			dbUpdateChan := make(chan dbUpdateJob, 1)
+
+			must(t, f.performFinish(remote, cur, true, temp, snap, dbUpdateChan, scanChan))
+
+			job := <-dbUpdateChan
+			expected := remoteContents
+			if keepLocal {
+				expected = local
+				if !job.file.Version.GreaterEqual(remote.Version) || !job.file.Version.GreaterEqual(cur.Version) {
+					t.Errorf("local version %v doesn't win over remote %v", job.file.Version, remote.Version)
+				}
+			}
+			fd, err := ffs.Open(name)
+			must(t, err)
+			bs, err := ioutil.ReadAll(fd)
+			fd.Close()
+			must(t, err)
+			if !bytes.Equal(bs, expected) {
+				t.Errorf("got contents %q, expected %q", bs, expected)
+			}
+			if _, err := ffs.Lstat(temp); !fs.IsNotExist(err) {
+				t.Error("temp file wasn't removed:", err)
+			}
+			if conflicts := existingConflicts(name, ffs); len(conflicts) != 0 {
+				t.Error("unexpected conflict copies", conflicts)
+			}
+		})
+	}
+}
+
 func TestPullCaseOnlyDir(t *testing.T) {
 	testPullCaseOnlyDirOrSymlink(t, true)
 }
@@ -1393,8 +1615,457 @@ func startCopier(f *sendReceiveFolder, pullChan chan<- pullBlockState, finisherC
 	wg := sync.NewWaitGroup()
 	wg.Add(1)
 	go func() {
-		f.copierRoutine(copyChan, pullChan, finisherChan)
+		f.copierRoutine(copyChan, pullChan, pullChan, finisherChan)
 		wg.Done()
 	}()
 	return copyChan, wg
 }
+
+func TestConflictRetention(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(f, m, wcfgCancel)
+	ffs := f.Filesystem()
+
+	old := "foo.sync-conflict-20000101-120000-ABCDEFG.txt"
+	modified := "bar.sync-conflict-20000101-120000-ABCDEFG.txt"
+	recent := conflictName("foo.txt", device1.Short().String())
+	for _, name := range []string{"foo.txt", old, modified, recent} {
+		must(t, writeFile(ffs, name, []byte(name), 0644))
+	}
+	must(t, f.scanSubdirs(nil))
+	// Changed after the last scan, so not what the index says.
+	must(t, writeFile(ffs, modified, []byte("changed since"), 0644))
+
+	f.conflictCleanupTimerFired()
+	if n := f.ConflictCount(); n != 3 {
+		t.Errorf("counted %d conflicts without retention, expected 3", n)
+	}
+
+	f.ConflictRetentionDays = 30
+	f.conflictCleanupTimerFired()
+	if n := f.ConflictCount(); n != 2 {
+		t.Errorf("counted %d conflicts after expiry, expected 2", n)
+	}
+	if _, err := ffs.Lstat(old); !fs.IsNotExist(err) {
+		t.Error("expired conflict wasn't removed:", err)
+	}
+	// The modified one was rescanned instead, and goes next time.
+	if fi, ok := m.CurrentFolderFile(f.ID, modified); !ok || fi.Size != int64(len("changed since")) {
+		t.Errorf("modified conflict should have been rescanned, got %v", fi)
+	}
+	for _, name := range []string{"foo.txt", modified, recent} {
+		if _, err := ffs.Lstat(name); err != nil {
+			t.Errorf("%v was removed: %v", name, err)
+		}
+	}
+	if fi, ok := m.CurrentFolderFile(f.ID, old); !ok || !fi.IsDeleted() {
+		t.Error("expired conflict should be deleted in the index")
+	}
+
+	f.conflictCleanupTimerFired()
+	if _, err := ffs.Lstat(modified); !fs.IsNotExist(err) {
+		t.Error("expired conflict wasn't removed once scanned:", err)
+	}
+}
+
+func TestTombstoneRetention(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(f, m, wcfgCancel)
+
+	gone := protocol.FileInfo{
+		Name:      "gone",
+		Deleted:   true,
+		Version:   protocol.Vector{}.Update(device1.Short()),
+		ModifiedS: time.Now().Add(-60 * 24 * time.Hour).Unix(),
+		Sequence:  1,
+	}
+	f.fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{gone})
+	f.TombstoneRetentionDays = 30
+	m.fmut.Lock()
+	m.folderCfgs[f.ID] = f.FolderConfiguration
+	m.fmut.Unlock()
+
+	// Without the index of device1, nothing is forgotten.
+	report, err := m.Tombstones(f.ID)
+	must(t, err)
+	if report.Total != 1 || report.Expired != 1 || report.Forgettable != 0 || len(report.MissingIndexes) != 1 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	f.tombstoneGCTimerFired()
+	if _, ok := m.CurrentFolderFile(f.ID, gone.Name); !ok {
+		t.Fatal("deleted item was forgotten without the index of device1")
+	}
+
+	f.fset.Update(device1, []protocol.FileInfo{gone})
+	report, err = m.Tombstones(f.ID)
+	must(t, err)
+	if report.Forgettable != 1 || len(report.MissingIndexes) != 0 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	f.tombstoneGCTimerFired()
+	if _, ok := m.CurrentFolderFile(f.ID, gone.Name); ok {
+		t.Error("expired deleted item wasn't forgotten")
+	}
+	if _, ok := m.CurrentGlobalFile(f.ID, gone.Name); ok {
+		t.Error("expired deleted item wasn't forgotten globally")
+	}
+}
+
+func TestPullOutOfDisk(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(f, m, wcfgCancel)
+
+	select {
+	case <-f.initialScanFinished:
+	default:
+		close(f.initialScanFinished)
+	}
+
+	f.fset.Update(device1, []protocol.FileInfo{{
+		Name:    "foo",
+		Type:    protocol.FileInfoTypeDirectory,
+		Version: protocol.Vector{}.Update(device1.Short()),
+	}})
+
+	// No disk has all of its space free.
+	f.MinDiskFree = config.Size{Value: 100, Unit: "%"}
+	if f.folder.pull() {
+		t.Fatal("pull succeeded while out of disk space")
+	}
+	var outOfDisk *outOfDiskError
+	if _, _, err := f.getState(); !errors.As(err, &outOfDisk) {
+		t.Fatalf("expected out of disk folder error, got %v", err)
+	}
+	if _, err := f.mtimefs.Lstat("foo"); !fs.IsNotExist(err) {
+		t.Fatalf("foo was pulled while out of disk space, err: %v", err)
+	}
+
+	f.MinDiskFree = config.Size{}
+	if !f.folder.pull() {
+		t.Fatal("pull failed with enough disk space")
+	}
+	if _, _, err := f.getState(); err != nil {
+		t.Fatalf("unexpected folder error %v", err)
+	}
+	if _, err := f.mtimefs.Lstat("foo"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullCaseCollision(t *testing.T) {
+	for _, rename := range []bool{false, true} {
+		t.Run(fmt.Sprint("rename=", rename), func(t *testing.T) {
+			w, wCancel := createTmpWrapper(defaultCfg)
+			defer wCancel()
+			fcfg := testFolderConfigFake()
+			fcfg.Path += "&insens=true"
+			fcfg.RenameCaseCollisions = rename
+			cfg := w.RawCopy()
+			cfg.Folders = []config.FolderConfiguration{fcfg}
+			replace(t, w, cfg)
+			m := setupModel(t, w)
+			m.cancel()
+			<-m.stopped
+			f := m.folderRunners[fcfg.ID].(*sendReceiveFolder)
+			f.ctx = context.Background()
+			ffs := f.Filesystem()
+
+			local := []byte("local")
+			must(t, writeFile(ffs, "Foo", local, 0644))
+			must(t, f.scanSubdirs(nil))
+
+			remote := protocol.FileInfo{
+				Name:       "foo",
+				Type:       protocol.FileInfoTypeFile,
+				Size:       6,
+				ModifiedS:  time.Now().Unix(),
+				ModifiedBy: device1.Short(),
+				Version:    protocol.Vector{}.Update(device1.Short()),
+			}
+			temp := fs.TempName(remote.Name)
+			must(t, writeFile(ffs, temp, []byte("remote"), 0644))
+			scanChan := make(chan string, 1)
+			dbUpdateChan := make(chan dbUpdateJob, 1)
+
+			snap := dbSnapshot(t, m, f.ID)
+			defer snap.Release()
+			err := f.performFinish(remote, protocol.FileInfo{}, false, temp, snap, dbUpdateChan, scanChan)
+
+			if !rename {
+				var cerr *caseCollisionError
+				if !errors.As(err, &cerr) {
+					t.Fatal("Expected case collision error, got", err)
+				}
+				if cerr.other != "Foo" {
+					t.Errorf("Expected collision with Foo, got %q", cerr.other)
+				}
+			} else {
+				must(t, err)
+				job := <-dbUpdateChan
+				if job.jobType != dbUpdateInvalidate || !job.file.IsUnsupported() {
+					t.Errorf("Expected foo to be marked unsupported, got %v", job)
+				}
+				name := <-scanChan
+				if !strings.Contains(name, ".sync-conflict-") {
+					t.Errorf("Unexpected name %q for the renamed file", name)
+				}
+				if bs, err := ioutil.ReadAll(mustOpen(t, ffs, name)); err != nil || string(bs) != "remote" {
+					t.Errorf("Unexpected contents %q of the renamed file, err: %v", bs, err)
+				}
+			}
+
+			if bs, err := ioutil.ReadAll(mustOpen(t, ffs, "Foo")); err != nil || !bytes.Equal(bs, local) {
+				t.Errorf("Unexpected contents %q of Foo, err: %v", bs, err)
+			}
+		})
+	}
+}
+
+func TestPullXattrs(t *testing.T) {
+	w, wCancel := createTmpWrapper(defaultCfg)
+	defer wCancel()
+	fcfg := testFolderConfigFake()
+	fcfg.SyncXattrs = true
+	cfg := w.RawCopy()
+	cfg.Folders = []config.FolderConfiguration{fcfg}
+	replace(t, w, cfg)
+	m := setupModel(t, w)
+	m.cancel()
+	<-m.stopped
+	f := m.folderRunners[fcfg.ID].(*sendReceiveFolder)
+	f.ctx = context.Background()
+	ffs := f.Filesystem()
+
+	must(t, ffs.Mkdir("dir", 0755))
+	must(t, ffs.SetXattr("dir", []fs.Xattr{{Name: "user.old", Value: []byte("old")}}))
+
+	file := protocol.FileInfo{
+		Name:        "dir",
+		Type:        protocol.FileInfoTypeDirectory,
+		Permissions: 0755,
+		ModifiedBy:  device1.Short(),
+		Version:     protocol.Vector{}.Update(device1.Short()),
+		XattrData:   &protocol.XattrData{Xattrs: []protocol.Xattr{{Name: "user.new", Value: []byte("new")}}},
+	}
+	dbUpdateChan := make(chan dbUpdateJob, 1)
+	snap := dbSnapshot(t, m, f.ID)
+	defer snap.Release()
+	f.handleDir(file, snap, dbUpdateChan, nil)
+	if len(f.tempPullErrors) != 0 {
+		t.Fatal("Unexpected pull errors", f.tempPullErrors)
+	}
+	<-dbUpdateChan
+
+	xattrs, err := ffs.GetXattr("dir")
+	must(t, err)
+	if len(xattrs) != 1 || xattrs[0].Name != "user.new" || string(xattrs[0].Value) != "new" {
+		t.Errorf("Unexpected extended attributes %v", xattrs)
+	}
+}
+
+func TestPullOwnership(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("ownership isn't synced on Windows")
+	}
+
+	w, wCancel := createTmpWrapper(defaultCfg)
+	defer wCancel()
+	fcfg := testFolderConfigFake()
+	fcfg.SyncOwnership = true
+	cfg := w.RawCopy()
+	cfg.Folders = []config.FolderConfiguration{fcfg}
+	replace(t, w, cfg)
+	m := setupModel(t, w)
+	m.cancel()
+	<-m.stopped
+	f := m.folderRunners[fcfg.ID].(*sendReceiveFolder)
+	f.ctx = context.Background()
+	ffs := f.Filesystem()
+
+	must(t, ffs.Mkdir("dir", 0755))
+
+	// The names don't exist here, so the IDs are used.
+	file := protocol.FileInfo{
+		Name:          "dir",
+		Type:          protocol.FileInfoTypeDirectory,
+		Permissions:   0755,
+		ModifiedBy:    device1.Short(),
+		Version:       protocol.Vector{}.Update(device1.Short()),
+		OwnershipData: &protocol.OwnershipData{UserName: "syncthing-test-nosuchuser", GroupName: "syncthing-test-nosuchgroup", UID: 1234, GID: 5678},
+	}
+	dbUpdateChan := make(chan dbUpdateJob, 1)
+	snap := dbSnapshot(t, m, f.ID)
+	defer snap.Release()
+	f.handleDir(file, snap, dbUpdateChan, nil)
+	if len(f.tempPullErrors) != 0 {
+		t.Fatal("Unexpected pull errors", f.tempPullErrors)
+	}
+	<-dbUpdateChan
+
+	info, err := ffs.Lstat("dir")
+	must(t, err)
+	if info.Owner() != 1234 || info.Group() != 5678 {
+		t.Errorf("Unexpected ownership %d:%d", info.Owner(), info.Group())
+	}
+}
+
+func TestPullThroughSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks aren't supported")
+	}
+
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(f, m, wcfgCancel)
+	f.SymlinkPolicy = config.SymlinkPolicyFollow
+	ffs := f.Filesystem()
+
+	must(t, writeFile(ffs, "real", []byte("local"), 0644))
+	must(t, ffs.CreateSymlink("real", "link"))
+	must(t, f.scanSubdirs(nil))
+	cur, ok := m.CurrentFolderFile(f.ID, "link")
+	if !ok || cur.Type != protocol.FileInfoTypeFile {
+		t.Fatalf("Expected link to be scanned as a file, got %v", cur)
+	}
+
+	remote := cur
+	remote.Size = 6
+	remote.ModifiedS = time.Now().Unix()
+	remote.ModifiedBy = device1.Short()
+	remote.Version = cur.Version.Update(device1.Short())
+	temp := fs.TempName(remote.Name)
+	must(t, writeFile(ffs, temp, []byte("remote"), 0644))
+	dbUpdateChan := make(chan dbUpdateJob, 1)
+	snap := dbSnapshot(t, m, f.ID)
+	defer snap.Release()
+	must(t, f.performFinish(remote, cur, true, temp, snap, dbUpdateChan, make(chan string, 1)))
+	<-dbUpdateChan
+
+	if info, err := ffs.Lstat("link"); err != nil || !info.IsSymlink() {
+		t.Errorf("Expected link to still be a symlink, err: %v", err)
+	}
+	if bs, err := ioutil.ReadAll(mustOpen(t, ffs, "real")); err != nil || string(bs) != "remote" {
+		t.Errorf("Unexpected contents %q of the link target, err: %v", bs, err)
+	}
+	if _, err := ffs.Lstat(temp); !fs.IsNotExist(err) {
+		t.Errorf("Expected the temp file to be gone, err: %v", err)
+	}
+}
+
+func mustOpen(t *testing.T, ffs fs.Filesystem, name string) fs.File {
+	t.Helper()
+	fd, err := ffs.Open(name)
+	must(t, err)
+	t.Cleanup(func() { fd.Close() })
+	return fd
+}
+
+func TestPullStaged(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(f, m, wcfgCancel)
+	f.StagedPull = true
+	ffs := f.Filesystem()
+
+	must(t, writeFile(ffs, "src", []byte("data"), 0644))
+	must(t, f.scanSubdirs(nil))
+	src, ok := m.CurrentFolderFile(f.ID, "src")
+	if !ok {
+		t.Fatal("src missing")
+	}
+
+	// The contents of a can be copied from src, those of b aren't to be
+	// had from device1.
+	addFakeConn(m, device1)
+	a := src
+	a.Name = "a"
+	a.Version = protocol.Vector{}.Update(device1.Short())
+	b := a
+	b.Name = "b"
+	b.Blocks = []protocol.BlockInfo{{Size: 4, Hash: []byte("not a real hash of anything there")}}
+	m.Index(device1, f.ID, []protocol.FileInfo{a, b})
+
+	scanChan := make(chan string, 10)
+	if changed := f.pullerIteration(scanChan); changed != 2 {
+		t.Errorf("Expected two changes, got %d", changed)
+	}
+	if _, ok := f.tempPullErrors["b"]; !ok {
+		t.Error("Expected a pull error for b")
+	}
+	if _, err := ffs.Lstat("a"); !fs.IsNotExist(err) {
+		t.Error("Expected a not to be in place yet, got", err)
+	}
+	if _, err := ffs.Lstat(fs.TempName("a")); err != nil {
+		t.Error("Expected a to be staged, got", err)
+	}
+
+	// Once b is no longer needed, a is moved into place.
+	m.Index(device1, f.ID, []protocol.FileInfo{a})
+	if changed := f.pullerIteration(scanChan); changed != 1 {
+		t.Errorf("Expected one change, got %d", changed)
+	}
+	if bs, err := ioutil.ReadAll(mustOpen(t, ffs, "a")); err != nil || string(bs) != "data" {
+		t.Errorf("Unexpected contents %q of a, err: %v", bs, err)
+	}
+	if _, err := ffs.Lstat(fs.TempName("a")); !fs.IsNotExist(err) {
+		t.Error("Expected the temp file to be gone, got", err)
+	}
+	if cur, ok := m.CurrentFolderFile(f.ID, "a"); !ok || !cur.Version.Equal(a.Version) {
+		t.Errorf("Unexpected index entry %v for a", cur)
+	}
+}
+
+func TestPullSkipItems(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(f, m, wcfgCancel)
+
+	// The contents of a aren't to be had from device1.
+	addFakeConn(m, device1)
+	a := protocol.FileInfo{
+		Name:    "a",
+		Type:    protocol.FileInfoTypeFile,
+		Size:    4,
+		Version: protocol.Vector{}.Update(device1.Short()),
+		Blocks:  []protocol.BlockInfo{{Size: 4, Hash: []byte("not a real hash of anything there")}},
+	}
+	m.Index(device1, f.ID, []protocol.FileInfo{a})
+
+	for i := 0; i < 2; i++ {
+		if f.pull() {
+			t.Fatal("Expected pull to fail")
+		}
+	}
+	items, err := m.OutOfSyncItems(f.ID, protocol.LocalDeviceID, 1, 10)
+	must(t, err)
+	if len(items) != 1 || items[0].Name != "a" || items[0].Error == "" || items[0].Failures != 2 || items[0].Skipped {
+		t.Fatalf("Unexpected out of sync items %+v", items)
+	}
+
+	must(t, f.SkipItems([]string{"a"}))
+	if errs := f.Errors(); len(errs) != 0 {
+		t.Error("Expected the error to be gone when skipping, got", errs)
+	}
+	if !f.pull() {
+		t.Error("Expected pull to succeed with a skipped")
+	}
+	items, err = m.OutOfSyncItems(f.ID, protocol.LocalDeviceID, 1, 10)
+	must(t, err)
+	if len(items) != 1 || items[0].Error != "" || !items[0].Skipped {
+		t.Fatalf("Unexpected out of sync items %+v", items)
+	}
+
+	// A new version is pulled again.
+	a.Version = a.Version.Update(device1.Short())
+	m.Index(device1, f.ID, []protocol.FileInfo{a})
+	if f.pull() {
+		t.Error("Expected pull of the new version to fail")
+	}
+
+	must(t, f.SkipItems([]string{"a"}))
+	f.RetryItems(nil)
+	if len(f.SkippedItems()) != 0 {
+		t.Error("Expected no more skipped items after retrying")
+	}
+
+	if err := f.SkipItems([]string{"nonexistent"}); !errors.Is(err, errNoSuchItem) {
+		t.Error("Expected error skipping nonexistent item, got", err)
+	}
+}
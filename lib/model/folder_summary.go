@@ -139,6 +139,21 @@ func (c *folderSummaryService) Summary(folder string) (map[string]interface{}, e
 		res["error"] = err.Error()
 	}
 
+	// When the folder resumes or is paused next by its schedule.
+	if haveFcfg && len(fcfg.Schedule) > 0 {
+		if schedule, err := config.ParseSchedule(fcfg.Schedule); err == nil {
+			if change := schedule.NextChange(time.Now()); !change.IsZero() {
+				res["scheduleChange"] = change
+			}
+		}
+	}
+
+	if mc := c.model.HeldMassChange(folder); mc != nil {
+		res["massChange"] = mc
+	}
+
+	res["conflicts"] = c.model.ConflictCount(folder)
+
 	res["version"] = ourSeq + remoteSeq  // legacy
 	res["sequence"] = ourSeq + remoteSeq // new name
 
@@ -219,17 +234,20 @@ func (c *folderSummaryService) processUpdate(ev events.Event) {
 
 	case events.StateChanged:
 		data := ev.Data.(map[string]interface{})
-		if data["to"].(string) != "idle" {
+		to, from := data["to"].(string), data["from"].(string)
+		switch {
+		case to == FolderQuarantined.String():
+			// The summary tells what is held back.
+		case to != FolderIdle.String() && to != FolderPausedBySchedule.String():
 			return
-		}
-		if from := data["from"].(string); from != "syncing" && from != "sync-preparing" {
+		case from != "syncing" && from != "sync-preparing":
 			return
 		}
 
-		// The folder changed to idle from syncing. We should do an
-		// immediate refresh to update the GUI. The send to
-		// c.immediate must be nonblocking so that we can continue
-		// handling events.
+		// The folder changed to idle from syncing, or started holding
+		// back changes. We should do an immediate refresh to update the
+		// GUI. The send to c.immediate must be nonblocking so that we can
+		// continue handling events.
 
 		folder = data["folder"].(string)
 		select {
@@ -347,6 +365,9 @@ func (c *folderSummaryService) sendSummary(ctx context.Context, folder string) {
 		// Get completion percentage of this folder for the
 		// remote device.
 		comp := c.model.Completion(devCfg.DeviceID, folder).Map()
+		// The history is there for whoever asks, and would only bloat
+		// the events.
+		delete(comp, "rateHistory")
 		comp["folder"] = folder
 		comp["device"] = devCfg.DeviceID.String()
 		c.evLogger.Log(events.FolderCompletion, comp)
@@ -14,6 +14,7 @@ import (
 	"github.com/d4l3k/messagediff"
 
 	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
 )
 
 type unifySubsCase struct {
@@ -149,3 +150,38 @@ func BenchmarkUnifySubs(b *testing.B) {
 		}
 	}
 }
+
+func TestStatePausedBySchedule(t *testing.T) {
+	s := newStateTracker("default", events.NoopLogger)
+
+	check := func(expected folderState) {
+		t.Helper()
+		if state, _, _ := s.getState(); state != expected {
+			t.Errorf("state %v != expected %v", state, expected)
+		}
+	}
+
+	s.setPausedBySchedule(true)
+	check(FolderPausedBySchedule)
+
+	// Work done while paused, e.g. a manual scan, returns to paused.
+	s.setState(FolderScanning)
+	check(FolderScanning)
+	s.setState(FolderIdle)
+	check(FolderPausedBySchedule)
+	s.setError(nil)
+	check(FolderPausedBySchedule)
+
+	if was := s.setPausedBySchedule(false); !was {
+		t.Error("should have been paused")
+	}
+	check(FolderIdle)
+
+	// Resuming doesn't interrupt ongoing work.
+	s.setPausedBySchedule(true)
+	s.setState(FolderSyncing)
+	s.setPausedBySchedule(false)
+	check(FolderSyncing)
+	s.setState(FolderIdle)
+	check(FolderIdle)
+}
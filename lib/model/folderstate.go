@@ -25,6 +25,8 @@ const (
 	FolderCleaning
 	FolderCleanWaiting
 	FolderError
+	FolderPausedBySchedule
+	FolderQuarantined
 )
 
 func (s folderState) String() string {
@@ -47,6 +49,10 @@ func (s folderState) String() string {
 		return "clean-waiting"
 	case FolderError:
 		return "error"
+	case FolderPausedBySchedule:
+		return "paused-by-schedule"
+	case FolderQuarantined:
+		return "quarantined"
 	default:
 		return "unknown"
 	}
@@ -60,6 +66,11 @@ type stateTracker struct {
 	current folderState
 	err     error
 	changed time.Time
+
+	// Outside of its schedule the folder is paused rather than idle.
+	pausedBySchedule bool
+	// Incoming changes are held back until confirmed.
+	quarantined bool
 }
 
 func newStateTracker(id string, evLogger events.Logger) stateTracker {
@@ -79,6 +90,14 @@ func (s *stateTracker) setState(newState folderState) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
+	s.setStateLocked(newState)
+}
+
+func (s *stateTracker) setStateLocked(newState folderState) {
+	if newState == FolderIdle {
+		newState = s.idleStateLocked()
+	}
+
 	if newState == s.current {
 		return
 	}
@@ -129,7 +148,7 @@ func (s *stateTracker) setError(err error) {
 		eventData["error"] = err.Error()
 		s.current = FolderError
 	} else {
-		s.current = FolderIdle
+		s.current = s.idleStateLocked()
 	}
 
 	eventData["to"] = s.current.String()
@@ -143,3 +162,52 @@ func (s *stateTracker) setError(err error) {
 
 	s.evLogger.Log(events.StateChanged, eventData)
 }
+
+// setPausedBySchedule sets whether the folder is outside of its schedule,
+// returning whether it was before. An idle folder changes state accordingly.
+func (s *stateTracker) setPausedBySchedule(paused bool) bool {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	was := s.pausedBySchedule
+	s.pausedBySchedule = paused
+	switch {
+	case paused && s.current == FolderIdle:
+		s.setStateLocked(FolderPausedBySchedule)
+	case !paused && s.current == FolderPausedBySchedule:
+		s.setStateLocked(FolderIdle)
+	}
+	return was
+}
+
+func (s *stateTracker) isPausedBySchedule() bool {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.pausedBySchedule
+}
+
+// setQuarantined sets whether incoming changes are held back until
+// confirmed. An idle folder changes state accordingly.
+func (s *stateTracker) setQuarantined(quarantined bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.quarantined = quarantined
+	switch s.current {
+	case FolderIdle, FolderPausedBySchedule, FolderQuarantined:
+		s.setStateLocked(FolderIdle)
+	}
+}
+
+// idleStateLocked returns the state of the folder when it's not doing
+// anything.
+func (s *stateTracker) idleStateLocked() folderState {
+	switch {
+	case s.quarantined:
+		return FolderQuarantined
+	case s.pausedBySchedule:
+		return FolderPausedBySchedule
+	default:
+		return FolderIdle
+	}
+}
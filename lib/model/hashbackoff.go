@@ -0,0 +1,90 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+)
+
+const hashBackoffInterval = 5 * time.Second
+
+// hashBackoff implements scanner.Backoff for the adaptive hashing option,
+// letting fewer hashers run the more CPU time other programs use.
+type hashBackoff struct {
+	cfg config.Wrapper
+	// The share of the CPU time used by other programs in the last
+	// interval, in per mille.
+	otherLoad int32
+}
+
+func newHashBackoff(cfg config.Wrapper) *hashBackoff {
+	return &hashBackoff{cfg: cfg}
+}
+
+func (b *hashBackoff) Allowed(hashers int) int {
+	load := atomic.LoadInt32(&b.otherLoad)
+	if load >= 500 {
+		// The system is busy with something else, don't compete.
+		return 1
+	}
+	if allowed := hashers * int(1000-2*load) / 1000; allowed > 1 {
+		return allowed
+	}
+	return 1
+}
+
+func (b *hashBackoff) serve(ctx context.Context) error {
+	cpuBusy, err := newCPUBusy()
+	if err != nil {
+		l.Debugln("Adaptive hashing not available:", err)
+		return nil
+	}
+
+	var prevTotal, prevOwn float64
+	var prevTime time.Time
+	ticker := time.NewTicker(hashBackoffInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if !b.cfg.Options().AdaptiveHashing {
+			atomic.StoreInt32(&b.otherLoad, 0)
+			prevTime = time.Time{}
+			continue
+		}
+
+		total, own, err := cpuBusy()
+		if err != nil {
+			l.Debugln("Measuring CPU load:", err)
+			continue
+		}
+		now := time.Now()
+		if !prevTime.IsZero() {
+			available := now.Sub(prevTime).Seconds() * float64(runtime.NumCPU())
+			load := int32(1000 * ((total - prevTotal) - (own - prevOwn)) / available)
+			if load < 0 {
+				load = 0
+			}
+			atomic.StoreInt32(&b.otherLoad, load)
+			l.Debugf("CPU load of other programs: %d per mille", load)
+		}
+		prevTotal, prevOwn, prevTime = total, own, now
+	}
+}
+
+func (b *hashBackoff) String() string {
+	return "hashBackoff"
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build linux windows darwin freebsd
+
+package model
+
+import (
+	"errors"
+	"os"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+var errNoCPUTimes = errors.New("no CPU times")
+
+// newCPUBusy returns a function returning the CPU seconds spent, in total
+// and by us.
+func newCPUBusy() (func() (float64, float64, error), error) {
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return nil, err
+	}
+	return func() (float64, float64, error) {
+		times, err := cpu.Times(false)
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(times) == 0 {
+			return 0, 0, errNoCPUTimes
+		}
+		own, err := proc.Times()
+		if err != nil {
+			return 0, 0, err
+		}
+		total := times[0].Total() - times[0].Idle - times[0].Iowait
+		return total, own.User + own.System, nil
+	}, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+)
+
+func TestHasherWeights(t *testing.T) {
+	cfgs := map[string]config.FolderConfiguration{
+		"low":    {ID: "low", Priority: -1},
+		"normal": {ID: "normal"},
+		"high":   {ID: "high", Priority: 1},
+	}
+	for folder, expected := range map[string]int{"low": 1, "normal": 2, "high": 3} {
+		weight, total := hasherWeights(cfgs, folder)
+		if weight != expected || total != 6 {
+			t.Errorf("%s: expected weight %d of 6, got %d of %d", folder, expected, weight, total)
+		}
+	}
+}
+
+func TestHashBackoffAllowed(t *testing.T) {
+	b := newHashBackoff(nil)
+	for _, tc := range []struct {
+		load     int32
+		hashers  int
+		expected int
+	}{
+		{0, 8, 8},
+		{250, 8, 4},
+		{400, 8, 1},
+		{800, 8, 1},
+		{0, 1, 1},
+	} {
+		b.otherLoad = tc.load
+		if allowed := b.Allowed(tc.hashers); allowed != tc.expected {
+			t.Errorf("%d hashers at load %d: expected %d allowed, got %d", tc.hashers, tc.load, tc.expected, allowed)
+		}
+	}
+}
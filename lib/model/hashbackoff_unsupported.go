@@ -0,0 +1,17 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// +build !linux,!windows,!darwin,!freebsd
+
+package model
+
+import "errors"
+
+// newCPUBusy fails, as the CPU times of our process can't be measured on
+// this platform. Adaptive hashing then leaves the number of hashers alone.
+func newCPUBusy() (func() (float64, float64, error), error) {
+	return nil, errors.New("not supported on this platform")
+}
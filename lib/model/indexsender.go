@@ -21,10 +21,22 @@ import (
 	"github.com/syncthing/syncthing/lib/svcutil"
 )
 
+const (
+	// A device this many files behind gets its index updates in larger
+	// batches, which compress better and are committed to its database in
+	// fewer transactions.
+	catchUpThreshold   = 10 * maxBatchSizeFiles
+	catchUpBatchFactor = 8
+)
+
 type indexSender struct {
 	conn                     protocol.Connection
 	folder                   string
 	folderIsReceiveEncrypted bool
+	sendXattrs               bool
+	sendOwnership            bool
+	stripEmptyBlocks         bool
+	invalidateBLAKE2b        bool
 	dev                      string
 	fset                     *db.FileSet
 	prevSequence             int64
@@ -115,18 +127,30 @@ func (s *indexSender) pause() {
 	}
 }
 
-// sendIndexTo sends file infos with a sequence number higher than prevSequence and
-// returns the highest sent sequence number.
+// sendIndexTo sends file infos with a sequence number higher than prevSequence,
+// which it advances with every batch sent.
 func (s *indexSender) sendIndexTo(ctx context.Context) error {
 	initial := s.prevSequence == 0
 	batch := newFileInfoBatch(nil)
+	if s.fset.Sequence(protocol.LocalDeviceID)-s.prevSequence > catchUpThreshold {
+		batch.maxFiles *= catchUpBatchFactor
+		batch.maxBytes *= catchUpBatchFactor
+	}
 	batch.flushFn = func(fs []protocol.FileInfo) error {
 		l.Debugf("%v: Sending %d files (<%d bytes)", s, len(batch.infos), batch.size)
+		var err error
 		if initial {
 			initial = false
-			return s.conn.Index(ctx, s.folder, fs)
+			err = s.conn.Index(ctx, s.folder, fs)
+		} else {
+			err = s.conn.IndexUpdate(ctx, s.folder, fs)
 		}
-		return s.conn.IndexUpdate(ctx, s.folder, fs)
+		if err == nil {
+			// Checkpoint, so that being restarted on the same connection
+			// doesn't send the batch again.
+			s.prevSequence = fs[len(fs)-1].Sequence
+		}
+		return err
 	}
 
 	var err error
@@ -187,6 +211,20 @@ func (s *indexSender) sendIndexTo(ctx context.Context) error {
 		// never sent externally
 		f.LocalFlags = 0
 		f.VersionHash = nil
+		if !s.sendXattrs {
+			f.XattrData = nil
+		}
+		if !s.sendOwnership {
+			f.OwnershipData = nil
+		}
+		if s.stripEmptyBlocks {
+			f.StripEmptyBlockHashes()
+		}
+		// A device that can't hash with the algorithm used for the block
+		// list can neither verify nor request the blocks.
+		if s.invalidateBLAKE2b && f.BlockHashAlgorithm == protocol.BlockHashAlgorithmBLAKE2b {
+			f.RawInvalid = true
+		}
 
 		previousWasDelete = f.IsDeleted()
 
@@ -213,26 +251,34 @@ func (s *indexSender) String() string {
 }
 
 type indexSenderRegistry struct {
-	deviceID     protocol.DeviceID
-	sup          *suture.Supervisor
-	evLogger     events.Logger
-	conn         protocol.Connection
-	closed       chan struct{}
-	indexSenders map[string]*indexSender
-	startInfos   map[string]*indexSenderStartInfo
-	mut          sync.Mutex
+	deviceID      protocol.DeviceID
+	sup           *suture.Supervisor
+	evLogger      events.Logger
+	conn          protocol.Connection
+	closed        chan struct{}
+	sendXattrs    bool
+	sendOwnership bool
+	sendSparse    bool
+	sendBLAKE2b   bool
+	indexSenders  map[string]*indexSender
+	startInfos    map[string]*indexSenderStartInfo
+	mut           sync.Mutex
 }
 
-func newIndexSenderRegistry(conn protocol.Connection, closed chan struct{}, sup *suture.Supervisor, evLogger events.Logger) *indexSenderRegistry {
+func newIndexSenderRegistry(conn protocol.Connection, closed chan struct{}, hello protocol.Hello, sup *suture.Supervisor, evLogger events.Logger) *indexSenderRegistry {
 	return &indexSenderRegistry{
-		deviceID:     conn.ID(),
-		conn:         conn,
-		closed:       closed,
-		sup:          sup,
-		evLogger:     evLogger,
-		indexSenders: make(map[string]*indexSender),
-		startInfos:   make(map[string]*indexSenderStartInfo),
-		mut:          sync.Mutex{},
+		deviceID:      conn.ID(),
+		conn:          conn,
+		closed:        closed,
+		sendXattrs:    hello.HasFeature(protocol.FeatureXattrs),
+		sendOwnership: hello.HasFeature(protocol.FeatureOwnership),
+		sendSparse:    hello.HasFeature(protocol.FeatureSparse),
+		sendBLAKE2b:   hello.HasFeature(protocol.FeatureBLAKE2b),
+		sup:           sup,
+		evLogger:      evLogger,
+		indexSenders:  make(map[string]*indexSender),
+		startInfos:    make(map[string]*indexSenderStartInfo),
+		mut:           sync.Mutex{},
 	}
 }
 
@@ -248,6 +294,7 @@ func (r *indexSenderRegistry) addLocked(folder config.FolderConfiguration, fset
 	myIndexID := fset.IndexID(protocol.LocalDeviceID)
 	mySequence := fset.Sequence(protocol.LocalDeviceID)
 	var startSequence int64
+	deltaCompatible := false
 
 	// This is the other side's description of what it knows
 	// about us. Lets check to see if we can start sending index
@@ -269,6 +316,7 @@ func (r *indexSenderRegistry) addLocked(folder config.FolderConfiguration, fset
 		} else {
 			l.Debugf("Device %v folder %s is delta index compatible (mlv=%d)", r.deviceID, folder.Description(), startInfo.local.MaxSequence)
 			startSequence = startInfo.local.MaxSequence
+			deltaCompatible = true
 		}
 	} else if startInfo.local.IndexID != 0 {
 		// They say they've seen an index ID from us, but it's
@@ -308,17 +356,37 @@ func (r *indexSenderRegistry) addLocked(folder config.FolderConfiguration, fset
 	if is, ok := r.indexSenders[folder.ID]; ok {
 		r.sup.RemoveAndWait(is.token, 0)
 		delete(r.indexSenders, folder.ID)
+		// What was already sent on this connection is on its way, even if
+		// the other side hadn't seen it when sending the cluster config.
+		if deltaCompatible && is.prevSequence > startSequence {
+			l.Debugf("Device %v folder %s continues index at %d instead of %d", r.deviceID, folder.Description(), is.prevSequence, startSequence)
+			startSequence = is.prevSequence
+		}
 	}
 	if _, ok := r.startInfos[folder.ID]; ok {
 		delete(r.startInfos, folder.ID)
 	}
 
+	// The encryption of the file infos for untrusted devices needs the
+	// block hashes.
+	dev, _ := folder.Device(r.deviceID)
+	stripEmptyBlocks := r.sendSparse && dev.EncryptionPassword == ""
+	// Untrusted devices only ever see the encrypted block hash tokens.
+	invalidateBLAKE2b := !r.sendBLAKE2b && dev.EncryptionPassword == ""
+	if invalidateBLAKE2b && folder.BlockHashAlgorithm == protocol.BlockHashAlgorithmBLAKE2b {
+		l.Warnf("Device %v does not support BLAKE2b block hashes; files in folder %s will not be synced to it", r.deviceID, folder.Description())
+	}
+
 	is := &indexSender{
 		conn:                     r.conn,
 		connClosed:               r.closed,
 		done:                     make(chan struct{}),
 		folder:                   folder.ID,
 		folderIsReceiveEncrypted: folder.Type == config.FolderTypeReceiveEncrypted,
+		sendXattrs:               r.sendXattrs,
+		sendOwnership:            r.sendOwnership,
+		stripEmptyBlocks:         stripEmptyBlocks,
+		invalidateBLAKE2b:        invalidateBLAKE2b,
 		fset:                     fset,
 		prevSequence:             startSequence,
 		evLogger:                 r.evLogger,
@@ -0,0 +1,140 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// Changes to fewer files than this are never held back, as they can easily
+// be most of a small folder.
+const massChangeMinFiles = 10
+
+// A MassChange is an incoming update that would delete or overwrite a large
+// part of the local files, held back until confirmed.
+type MassChange struct {
+	Deleted int `json:"deleted"`
+	Changed int `json:"changed"`
+	Total   int `json:"total"`
+}
+
+func (c MassChange) percent() int {
+	if c.Total == 0 {
+		return 0
+	}
+	return (c.Deleted + c.Changed) * 100 / c.Total
+}
+
+// holdMassChange returns whether the changes needed in the snapshot must be
+// held back, as they would delete or overwrite more than the configured
+// share of the local files and weren't confirmed.
+func (f *folder) holdMassChange(snap *db.Snapshot) bool {
+	if f.MassChangeThresholdPct <= 0 || f.Type == config.FolderTypeSendOnly {
+		return false
+	}
+
+	f.massChangeMut.Lock()
+	defer f.massChangeMut.Unlock()
+
+	if f.massChangeConfirmed {
+		return false
+	}
+
+	change, hold := f.countMassChange(snap)
+
+	if !hold {
+		if f.heldMassChange != nil {
+			f.log.Infof("No longer holding back changes to %v", f.Description())
+			f.heldMassChange = nil
+			f.setQuarantined(false)
+		}
+		return false
+	}
+
+	if f.heldMassChange == nil {
+		f.log.Warnf("Holding back changes to %v until confirmed, as they would delete or overwrite %d of %d files (%d%%)", f.Description(), change.Deleted+change.Changed, change.Total, change.percent())
+	}
+	f.heldMassChange = &change
+	f.setQuarantined(true)
+	return true
+}
+
+// countMassChange counts the local files that the needed changes would
+// delete or overwrite, and returns whether that exceeds the threshold.
+func (f *folder) countMassChange(snap *db.Snapshot) (MassChange, bool) {
+	local := snap.LocalSize()
+	change := MassChange{Total: local.Files + local.Directories + local.Symlinks}
+	exceeds := func(n int) bool {
+		return n >= massChangeMinFiles && n*100 > f.MassChangeThresholdPct*change.Total
+	}
+
+	// Nothing to look into if there aren't enough changes at all.
+	if !exceeds(snap.NeedSize(protocol.LocalDeviceID).TotalItems()) {
+		return change, false
+	}
+
+	snap.WithNeed(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+		need := intf.(protocol.FileInfo)
+		cur, ok := snap.Get(protocol.LocalDeviceID, need.Name)
+		if ok && (cur.IsDeleted() || cur.IsInvalid()) {
+			// Nothing there to lose.
+			return true
+		}
+		switch {
+		case need.IsDeleted():
+			// Without a local entry there may still be something on
+			// disk that hasn't been scanned yet.
+			if !f.IgnoreDelete {
+				change.Deleted++
+			}
+		case !ok:
+			// A new file.
+		case need.Type != cur.Type:
+			change.Changed++
+		case need.Type == protocol.FileInfoTypeFile && !need.BlocksEqual(cur):
+			change.Changed++
+		}
+		return true
+	})
+
+	return change, exceeds(change.Deleted + change.Changed)
+}
+
+// ConfirmMassChange lets the changes held back be applied. The confirmation
+// lasts until there is nothing left to pull.
+func (f *folder) ConfirmMassChange() {
+	f.massChangeMut.Lock()
+	if f.heldMassChange != nil {
+		f.log.Infof("Applying changes to %v as confirmed", f.Description())
+		f.massChangeConfirmed = true
+		f.heldMassChange = nil
+		f.setQuarantined(false)
+	}
+	f.massChangeMut.Unlock()
+	f.SchedulePull()
+}
+
+// HeldMassChange returns the changes held back, or nil.
+func (f *folder) HeldMassChange() *MassChange {
+	f.massChangeMut.Lock()
+	defer f.massChangeMut.Unlock()
+	if f.heldMassChange == nil {
+		return nil
+	}
+	change := *f.heldMassChange
+	return &change
+}
+
+// resetMassChangeConfirmation ends the confirmation once everything is in
+// sync.
+func (f *folder) resetMassChangeConfirmation() {
+	f.massChangeMut.Lock()
+	f.massChangeConfirmed = false
+	f.massChangeMut.Unlock()
+}
@@ -9,6 +9,7 @@ package model
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,6 +27,7 @@ import (
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/connections"
 	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/ignore"
@@ -35,6 +37,7 @@ import (
 	"github.com/syncthing/syncthing/lib/stats"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/sync"
+	"github.com/syncthing/syncthing/lib/upgrade"
 	"github.com/syncthing/syncthing/lib/ur/contract"
 	"github.com/syncthing/syncthing/lib/versioner"
 )
@@ -48,6 +51,7 @@ const (
 type service interface {
 	suture.Service
 	BringToFront(string)
+	SetPullOrder(order config.PullOrder, patterns []string)
 	Override()
 	Revert()
 	DelayScan(d time.Duration)
@@ -55,9 +59,16 @@ type service interface {
 	Jobs(page, perpage int) ([]string, []string, int) // In progress, Queued, skipped
 	Scan(subs []string) error
 	Errors() []FileError
+	SkipItems(paths []string) error
+	RetryItems(paths []string)
+	SkippedItems() map[string]protocol.Vector
+	Verify(repair bool) (VerifyReport, error)
 	WatchError() error
+	ConflictCount() int
 	ScheduleForceRescan(path string)
 	GetStatistics() (stats.FolderStatistics, error)
+	ConfirmMassChange()
+	HeldMassChange() *MassChange
 
 	getState() (folderState, time.Time, error)
 }
@@ -79,12 +90,18 @@ type Model interface {
 	ScanFolderSubdirs(folder string, subs []string) error
 	State(folder string) (string, time.Time, error)
 	FolderErrors(folder string) ([]FileError, error)
+	Conflicts(folder string) ([]Conflict, error)
+	ConflictCount(folder string) int
+	Tombstones(folder string) (TombstoneReport, error)
 	WatchError(folder string) error
 	Override(folder string)
 	Revert(folder string)
+	ConfirmMassChange(folder string) error
+	HeldMassChange(folder string) *MassChange
 	BringToFront(folder, file string)
 	LoadIgnores(folder string) ([]string, []string, error)
 	CurrentIgnores(folder string) ([]string, []string, error)
+	ExplainIgnore(folder, file string) (ignore.Explanation, error)
 	SetIgnores(folder string, content []string) error
 
 	GetFolderVersions(folder string) (map[string][]versioner.FileVersion, error)
@@ -93,8 +110,16 @@ type Model interface {
 	DBSnapshot(folder string) (*db.Snapshot, error)
 	NeedFolderFiles(folder string, page, perpage int) ([]db.FileInfoTruncated, []db.FileInfoTruncated, []db.FileInfoTruncated, error)
 	RemoteNeedFolderFiles(folder string, device protocol.DeviceID, page, perpage int) ([]db.FileInfoTruncated, error)
+	OutOfSyncItems(folder string, device protocol.DeviceID, page, perpage int) ([]OutOfSyncItem, error)
+	SkipItems(folder string, paths []string) error
+	RetryItems(folder string, paths []string) error
+	VerifyFolder(folder string, repair bool) (VerifyReport, error)
 	LocalChangedFolderFiles(folder string, page, perpage int) ([]db.FileInfoTruncated, error)
 	FolderProgressBytesCompleted(folder string) int64
+	CompactDatabase() error
+	ReadDatabase(fn func(backend.Reader) error) error
+	CheckDatabase(folder string) (map[string]db.CheckResult, error)
+	DatabaseSize() (DatabaseSize, error)
 
 	CurrentFolderFile(folder string, file string) (protocol.FileInfo, bool)
 	CurrentGlobalFile(folder string, file string) (protocol.FileInfo, bool)
@@ -109,6 +134,12 @@ type Model interface {
 	PendingDevices() (map[protocol.DeviceID]db.ObservedDevice, error)
 	PendingFolders(device protocol.DeviceID) (map[string]db.PendingFolder, error)
 
+	PushConfig(device protocol.DeviceID, fragment []byte) error
+	RequestUpgrades(ctx context.Context, devices []protocol.DeviceID, version string, checkOnly bool) map[protocol.DeviceID]UpgradeResult
+	Benchmark(ctx context.Context, device protocol.DeviceID, duration time.Duration) (DeviceBenchmark, error)
+
+	SetNextCertificate(next, cert tls.Certificate) error
+
 	StartDeadlockDetector(timeout time.Duration)
 	GlobalDirectoryTree(folder, prefix string, levels int, dirsOnly bool) ([]*TreeEntry, error)
 }
@@ -134,9 +165,16 @@ type model struct {
 	globalRequestLimiter *byteSemaphore
 	// folderIOLimiter limits the number of concurrent I/O heavy operations,
 	// such as scans and pulls.
-	folderIOLimiter *byteSemaphore
-	fatalChan       chan error
-	started         chan struct{}
+	folderIOLimiter  *byteSemaphore
+	hashBackoff      *hashBackoff
+	lowImpactScans   *lowImpactScans
+	completionRates  *completionRates
+	removableStorage *removableStorage
+	fatalChan        chan error
+	started          chan struct{}
+	// upgradeToken is held while handling an upgrade request, so that
+	// only one is acted on at a time.
+	upgradeToken chan struct{}
 
 	// fields protected by fmut
 	fmut                           sync.RWMutex
@@ -154,12 +192,20 @@ type model struct {
 	// fields protected by pmut
 	pmut                sync.RWMutex
 	conn                map[protocol.DeviceID]protocol.Connection
+	links               map[protocol.DeviceID][]*link // primary connection first, then secondaries
 	connRequestLimiters map[protocol.DeviceID]*byteSemaphore
+	// outRequestLimiters limit our requests to each device, letting the
+	// folders with the highest priority go first.
+	outRequestLimiters  map[protocol.DeviceID]*byteSemaphore
 	closed              map[protocol.DeviceID]chan struct{}
 	helloMessages       map[protocol.DeviceID]protocol.Hello
 	deviceDownloads     map[protocol.DeviceID]*deviceDownloadState
 	remotePausedFolders map[protocol.DeviceID]map[string]struct{} // deviceID -> folders
 	indexSenders        map[protocol.DeviceID]*indexSenderRegistry
+	// the certificate we are rotating to and its signature by our current
+	// one, announced in our Hello messages
+	nextCert    []byte
+	nextCertSig []byte
 
 	// for testing only
 	foldersRunning int32
@@ -179,8 +225,10 @@ var (
 	ErrFolderPaused      = errors.New("folder is paused")
 	errFolderNotRunning  = errors.New("folder is not running")
 	errFolderMissing     = errors.New("no such folder")
+	errNoSuchItem        = errors.New("no such item")
 	errNetworkNotAllowed = errors.New("network not allowed")
 	errNoVersioner       = errors.New("folder has no versioner")
+	errMaintenanceFreeze = errors.New("maintenance freeze is active")
 	// errors about why a connection is closed
 	errIgnoredFolderRemoved            = errors.New("folder no longer ignored")
 	errReplacingConnection             = errors.New("replacing connection")
@@ -195,6 +243,11 @@ var (
 	errMissingRemoteInClusterConfig    = errors.New("remote device missing in cluster config")
 	errMissingLocalInClusterConfig     = errors.New("local device missing in cluster config")
 	errConnLimitReached                = errors.New("connection limit reached")
+	errDeviceNotConnected              = errors.New("device is not connected")
+	errConfigPushUnsupported           = errors.New("device does not support configuration pushes")
+	errUpgradeRequestsUnsupported      = errors.New("device does not support upgrade requests")
+	errUpgradeNotAllowed               = errors.New("device is not allowed to manage our upgrades")
+	errUpgradedOnRequest               = errors.New("upgraded on request of another device")
 )
 
 // NewModel creates and starts a new model. The model starts in read-only mode,
@@ -220,8 +273,12 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, clientName, clientVersio
 		shortID:              id.Short(),
 		globalRequestLimiter: newByteSemaphore(1024 * cfg.Options().MaxConcurrentIncomingRequestKiB()),
 		folderIOLimiter:      newByteSemaphore(cfg.Options().MaxFolderConcurrency()),
+		hashBackoff:          newHashBackoff(cfg),
+		completionRates:      newCompletionRates(),
+		removableStorage:     newRemovableStorage(cfg, ldb),
 		fatalChan:            make(chan error),
 		started:              make(chan struct{}),
+		upgradeToken:         make(chan struct{}, 1),
 
 		// fields protected by fmut
 		fmut:                           sync.NewRWMutex(),
@@ -238,17 +295,22 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, clientName, clientVersio
 		// fields protected by pmut
 		pmut:                sync.NewRWMutex(),
 		conn:                make(map[protocol.DeviceID]protocol.Connection),
+		links:               make(map[protocol.DeviceID][]*link),
 		connRequestLimiters: make(map[protocol.DeviceID]*byteSemaphore),
+		outRequestLimiters:  make(map[protocol.DeviceID]*byteSemaphore),
 		closed:              make(map[protocol.DeviceID]chan struct{}),
 		helloMessages:       make(map[protocol.DeviceID]protocol.Hello),
 		deviceDownloads:     make(map[protocol.DeviceID]*deviceDownloadState),
 		remotePausedFolders: make(map[protocol.DeviceID]map[string]struct{}),
 		indexSenders:        make(map[protocol.DeviceID]*indexSenderRegistry),
 	}
+	m.lowImpactScans = newLowImpactScans(cfg.Options().LowImpactScans, m.hashBackoff)
 	for devID := range cfg.Devices() {
 		m.deviceStatRefs[devID] = stats.NewDeviceStatisticsReference(m.db, devID)
 	}
 	m.Add(m.progressEmitter)
+	m.Add(svcutil.AsService(m.hashBackoff.serve, m.hashBackoff.String()))
+	m.Add(svcutil.AsService(m.removableStorage.serve, m.removableStorage.String()))
 	m.Add(svcutil.AsService(m.serve, m.String()))
 
 	return m
@@ -279,7 +341,11 @@ func (m *model) initFolders(cfg config.Configuration) error {
 	clusterConfigDevices := make(deviceIDSet, len(cfg.Devices))
 	for _, folderCfg := range cfg.Folders {
 		if folderCfg.Paused {
-			folderCfg.CreateRoot()
+			if !folderCfg.RemovableStorage {
+				// Where removable storage is mounted is none of our
+				// business while it isn't.
+				folderCfg.CreateRoot()
+			}
 			continue
 		}
 		err := m.newFolder(folderCfg, cfg.Options.CacheIgnoredFiles)
@@ -374,7 +440,11 @@ func (m *model) addAndStartFolderLockedWithIgnores(cfg config.FolderConfiguratio
 		// if these things don't work, we still want to start the folder and
 		// it'll show up as errored later.
 
-		if err := cfg.CreateRoot(); err != nil {
+		// The root of removable storage is there when the storage is, and
+		// creating it otherwise would put the data in the wrong place.
+		if cfg.RemovableStorage && cfg.CheckPath() == config.ErrPathMissing {
+			l.Debugln("Not creating root of folder on removable storage", cfg.Description())
+		} else if err := cfg.CreateRoot(); err != nil {
 			l.Warnln("Failed to create folder root directory", err)
 		} else if err = cfg.CreateMarker(); err != nil {
 			l.Warnln("Failed to create folder marker:", err)
@@ -495,6 +565,7 @@ func (m *model) cleanupFolderLocked(cfg config.FolderConfiguration) {
 	delete(m.folderRunners, cfg.ID)
 	delete(m.folderRunnerToken, cfg.ID)
 	delete(m.folderVersioners, cfg.ID)
+	m.completionRates.forgetFolder(cfg.ID)
 }
 
 func (m *model) restartFolder(from, to config.FolderConfiguration, cacheIgnoredFiles bool) error {
@@ -707,7 +778,13 @@ type ConnectionInfo struct {
 	Address       string
 	ClientVersion string
 	Type          string
+	Transport     string
+	Priority      int
 	Crypto        string
+	// "IPv4" or "IPv6", empty if the remote address is neither
+	AddressFamily string
+	// Addresses of the secondary connections used for block transfers
+	SecondaryAddresses []string
 }
 
 func (info ConnectionInfo) MarshalJSON() ([]byte, error) {
@@ -715,15 +792,93 @@ func (info ConnectionInfo) MarshalJSON() ([]byte, error) {
 		"at":            info.At,
 		"inBytesTotal":  info.InBytesTotal,
 		"outBytesTotal": info.OutBytesTotal,
+		"rttMs":         info.RTT.Seconds() * 1000,
+		"jitterMs":      info.Jitter.Seconds() * 1000,
 		"connected":     info.Connected,
 		"paused":        info.Paused,
 		"address":       info.Address,
 		"clientVersion": info.ClientVersion,
 		"type":          info.Type,
+		"transport":     info.Transport,
+		"priority":      info.Priority,
 		"crypto":        info.Crypto,
+		"addressFamily": info.AddressFamily,
+
+		"secondaryAddresses": info.SecondaryAddresses,
+	})
+}
+
+// addressFamily returns "IPv4" or "IPv6" for IP based addresses, and an
+// empty string otherwise.
+func addressFamily(addr net.Addr) string {
+	var ip net.IP
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		ip = addr.IP
+	case *net.UDPAddr:
+		ip = addr.IP
+	default:
+		return ""
+	}
+	if ip.To4() != nil {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// DeviceBenchmark is the result of benchmarking the connection to a device,
+// along with what kind of connection it is.
+type DeviceBenchmark struct {
+	protocol.BenchmarkResult
+	Address   string
+	Type      string
+	Transport string
+	// Whether the data went through a relay rather than directly to the
+	// device.
+	Relayed bool
+}
+
+func (b DeviceBenchmark) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"durationS":      b.Duration.Seconds(),
+		"bytes":          b.Bytes,
+		"bytesPerSecond": b.BytesPerSecond(),
+		"rttMs":          b.RTT.Seconds() * 1000,
+		"address":        b.Address,
+		"type":           b.Type,
+		"transport":      b.Transport,
+		"relayed":        b.Relayed,
 	})
 }
 
+// Benchmark measures the throughput and round trip time of the connection
+// to the device, by sending it synthetic data for the given duration.
+func (m *model) Benchmark(ctx context.Context, device protocol.DeviceID, duration time.Duration) (DeviceBenchmark, error) {
+	m.pmut.RLock()
+	conn, ok := m.conn[device]
+	m.pmut.RUnlock()
+	if !ok {
+		return DeviceBenchmark{}, errDeviceNotConnected
+	}
+
+	l.Infof("Benchmarking connection to %v for %v", device, duration)
+	res, err := conn.Benchmark(ctx, duration)
+	if err != nil {
+		return DeviceBenchmark{}, err
+	}
+	bench := DeviceBenchmark{
+		BenchmarkResult: res,
+		Type:            conn.Type(),
+		Transport:       conn.Transport(),
+		Relayed:         strings.HasPrefix(conn.Type(), "relay"),
+	}
+	if addr := conn.RemoteAddr(); addr != nil {
+		bench.Address = addr.String()
+	}
+	l.Infof("Connection to %v (%s, %s): %.1f MiB/s, round trip time %v", device, bench.Type, bench.Address, bench.BytesPerSecond()/(1<<20), bench.RTT)
+	return bench, nil
+}
+
 // NumConnections returns the current number of active connected devices.
 func (m *model) NumConnections() int {
 	m.pmut.RLock()
@@ -751,11 +906,20 @@ func (m *model) ConnectionStats() map[string]interface{} {
 		}
 		if conn, ok := m.conn[device]; ok {
 			ci.Type = conn.Type()
+			ci.Transport = conn.Transport()
+			ci.Priority = conn.Priority()
 			ci.Crypto = conn.Crypto()
 			ci.Connected = ok
 			ci.Statistics = conn.Statistics()
 			if addr := conn.RemoteAddr(); addr != nil {
 				ci.Address = addr.String()
+				ci.AddressFamily = addressFamily(addr)
+			}
+			ci.SecondaryAddresses = []string{}
+			for _, ln := range m.links[device][1:] {
+				if addr := ln.RemoteAddr(); addr != nil {
+					ci.SecondaryAddresses = append(ci.SecondaryAddresses, addr.String())
+				}
 			}
 		}
 
@@ -814,6 +978,12 @@ type FolderCompletion struct {
 	NeedItems     int
 	NeedDeletes   int
 	Sequence      int64
+	// The moving average of the transfer rate, the time it will take to
+	// transfer the needed data at that rate, or -1 when that is unknown,
+	// and how the rate developed recently.
+	BytesPerSecond float64
+	Remaining      time.Duration
+	RateHistory    []RateSample
 }
 
 func newFolderCompletion(global, need db.Counts, sequence int64) FolderCompletion {
@@ -857,14 +1027,21 @@ func (comp *FolderCompletion) setComplectionPct() {
 
 // Map returns the members as a map, e.g. used in api to serialize as Json.
 func (comp FolderCompletion) Map() map[string]interface{} {
+	var remaining interface{} // null when unknown
+	if comp.Remaining >= 0 {
+		remaining = comp.Remaining.Seconds()
+	}
 	return map[string]interface{}{
-		"completion":  comp.CompletionPct,
-		"globalBytes": comp.GlobalBytes,
-		"needBytes":   comp.NeedBytes,
-		"globalItems": comp.GlobalItems,
-		"needItems":   comp.NeedItems,
-		"needDeletes": comp.NeedDeletes,
-		"sequence":    comp.Sequence,
+		"completion":       comp.CompletionPct,
+		"globalBytes":      comp.GlobalBytes,
+		"needBytes":        comp.NeedBytes,
+		"globalItems":      comp.GlobalItems,
+		"needItems":        comp.NeedItems,
+		"needDeletes":      comp.NeedDeletes,
+		"sequence":         comp.Sequence,
+		"bytesPerSecond":   comp.BytesPerSecond,
+		"remainingSeconds": remaining,
+		"rateHistory":      comp.RateHistory,
 	}
 }
 
@@ -872,7 +1049,8 @@ func (comp FolderCompletion) Map() map[string]interface{} {
 // for the given device and folder. The device can be any known device ID
 // (including the local device) or explicitly protocol.LocalDeviceID. An
 // empty folder string means the aggregate of all folders shared with the
-// given device.
+// given device. The transfer rate and remaining time are estimated from
+// how the completion changed over the previous calls.
 func (m *model) Completion(device protocol.DeviceID, folder string) FolderCompletion {
 	// The user specifically asked for our own device ID. Internally that is
 	// known as protocol.LocalDeviceID so translate.
@@ -892,6 +1070,7 @@ func (m *model) Completion(device protocol.DeviceID, folder string) FolderComple
 			comp.add(m.folderCompletion(device, fcfg.ID))
 		}
 	}
+	m.completionRates.update(device, "", &comp, time.Now())
 	return comp
 }
 
@@ -918,6 +1097,7 @@ func (m *model) folderCompletion(device protocol.DeviceID, folder string) Folder
 	}
 
 	comp := newFolderCompletion(snap.GlobalSize(), need, snap.Sequence(device))
+	m.completionRates.update(device, folder, &comp, time.Now())
 
 	l.Debugf("%v Completion(%s, %q): %v", m, device, folder, comp.Map())
 	return comp
@@ -935,6 +1115,44 @@ func (m *model) DBSnapshot(folder string) (*db.Snapshot, error) {
 	return rf.Snapshot(), nil
 }
 
+// TombstoneReport describes the deleted items in the index of a folder.
+type TombstoneReport struct {
+	db.TombstoneCounts
+	RetentionDays int `json:"retentionDays"`
+	// Devices sharing the folder whose index we haven't got, which keeps
+	// any deletions from being forgotten.
+	MissingIndexes []protocol.DeviceID `json:"missingIndexes"`
+}
+
+// Tombstones returns how many deleted items there are in the index of the
+// folder, and how many of those may be forgotten.
+func (m *model) Tombstones(folder string) (TombstoneReport, error) {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	cfg := m.folderCfgs[folder]
+	rf := m.folderFiles[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return TombstoneReport{}, err
+	}
+
+	snap := rf.Snapshot()
+	defer snap.Release()
+	cutoff, missing := tombstoneCutoff(cfg, m.id, snap)
+	report := TombstoneReport{
+		TombstoneCounts: snap.Tombstones(cutoff),
+		RetentionDays:   cfg.TombstoneRetentionDays,
+		MissingIndexes:  missing,
+	}
+	if report.MissingIndexes == nil {
+		report.MissingIndexes = []protocol.DeviceID{}
+	}
+	if len(missing) > 0 {
+		report.Forgettable = 0
+	}
+	return report, nil
+}
+
 func (m *model) FolderProgressBytesCompleted(folder string) int64 {
 	return m.progressEmitter.BytesCompleted(folder)
 }
@@ -1034,6 +1252,121 @@ func (m *model) RemoteNeedFolderFiles(folder string, device protocol.DeviceID, p
 	return files, nil
 }
 
+// OutOfSyncItem is an item that a device needs for a folder. For the local
+// device, it also says why pulling it failed, if it did, and whether it is
+// skipped.
+type OutOfSyncItem struct {
+	db.FileInfoTruncated
+	Error    string
+	Failures int
+	Skipped  bool
+}
+
+// OutOfSyncItems returns the items the device needs for the folder. The
+// device can be the local one, either by its ID or protocol.LocalDeviceID.
+func (m *model) OutOfSyncItems(folder string, device protocol.DeviceID, page, perpage int) ([]OutOfSyncItem, error) {
+	if device == m.id {
+		device = protocol.LocalDeviceID
+	}
+
+	m.fmut.RLock()
+	rf, ok := m.folderFiles[folder]
+	runner := m.folderRunners[folder]
+	m.fmut.RUnlock()
+
+	if !ok {
+		return nil, errFolderMissing
+	}
+
+	// We only know about our own failures.
+	var errs map[string]FileError
+	var skipped map[string]protocol.Vector
+	if device == protocol.LocalDeviceID && runner != nil {
+		errs = make(map[string]FileError)
+		for _, fe := range runner.Errors() {
+			// Pull errors are the more relevant ones for needed items.
+			if _, ok := errs[fe.Path]; !ok || fe.Failures > 0 {
+				errs[fe.Path] = fe
+			}
+		}
+		skipped = runner.SkippedItems()
+	}
+
+	snap := rf.Snapshot()
+	defer snap.Release()
+
+	items := make([]OutOfSyncItem, 0, perpage)
+	p := newPager(page, perpage)
+	snap.WithNeedTruncated(device, func(f protocol.FileIntf) bool {
+		if p.skip() {
+			return true
+		}
+		item := OutOfSyncItem{FileInfoTruncated: f.(db.FileInfoTruncated)}
+		if fe, ok := errs[item.Name]; ok {
+			item.Error = fe.Err
+			item.Failures = fe.Failures
+		}
+		if version, ok := skipped[item.Name]; ok {
+			item.Skipped = version.Equal(item.Version)
+		}
+		items = append(items, item)
+		return !p.done()
+	})
+	return items, nil
+}
+
+// SkipItems makes the folder leave the given items alone until they change
+// again, or RetryItems is called for them.
+func (m *model) SkipItems(folder string, paths []string) error {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	runner := m.folderRunners[folder]
+	cfg := m.folderCfgs[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return err
+	}
+	if cfg.Type == config.FolderTypeSendOnly {
+		return errors.New("send only folders don't pull items")
+	}
+	return runner.SkipItems(paths)
+}
+
+// RetryItems pulls the folder right away, including the given items if they
+// were skipped, or all skipped items if none are given.
+func (m *model) RetryItems(folder string, paths []string) error {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	runner := m.folderRunners[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return err
+	}
+	runner.RetryItems(paths)
+	return nil
+}
+
+// VerifyFolder re-hashes the local files of the folder, to find the ones
+// that don't match the index although a scan wouldn't notice. With repair,
+// corrupted files are pulled again where other devices have good copies.
+func (m *model) VerifyFolder(folder string, repair bool) (VerifyReport, error) {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	runner := m.folderRunners[folder]
+	cfg := m.folderCfgs[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return VerifyReport{}, err
+	}
+	if cfg.Type == config.FolderTypeReceiveEncrypted {
+		return VerifyReport{}, errors.New("receive encrypted folders can't be verified")
+	}
+	if repair && cfg.Type == config.FolderTypeSendOnly {
+		return VerifyReport{}, errors.New("send only folders don't pull items")
+	}
+	return runner.Verify(repair)
+}
+
 func (m *model) LocalChangedFolderFiles(folder string, page, perpage int) ([]db.FileInfoTruncated, error) {
 	m.fmut.RLock()
 	rf, ok := m.folderFiles[folder]
@@ -1151,6 +1484,7 @@ func (m *model) handleIndex(deviceID protocol.DeviceID, folder string, fs []prot
 		// Make sure they look like they weren't.
 		fs[i].LocalFlags = 0
 		fs[i].VersionHash = nil
+		fs[i].RestoreEmptyBlockHashes()
 	}
 	files.Update(deviceID, fs)
 
@@ -1213,12 +1547,16 @@ func (m *model) ClusterConfig(deviceID protocol.DeviceID, cm protocol.ClusterCon
 
 	// Needs to happen outside of the fmut, as can cause CommitConfiguration
 	if deviceCfg.AutoAcceptFolders {
-		w, _ := m.cfg.Modify(func(cfg *config.Configuration) {
+		w, _ := m.cfg.As(config.ActorDevice(deviceID)).Modify(func(cfg *config.Configuration) {
+			basePath := deviceCfg.AutoAcceptPath
+			if basePath == "" {
+				basePath = cfg.Defaults.Folder.Path
+			}
 			changedFcfg := make(map[string]config.FolderConfiguration)
 			haveFcfg := cfg.FolderMap()
 			for _, folder := range cm.Folders {
 				from, ok := haveFcfg[folder.ID]
-				if to, changed := m.handleAutoAccepts(deviceID, folder, ccDeviceInfos[folder.ID], from, ok, cfg.Defaults.Folder.Path); changed {
+				if to, changed := m.handleAutoAccepts(deviceID, folder, ccDeviceInfos[folder.ID], from, ok, basePath); changed {
 					changedFcfg[folder.ID] = to
 				}
 			}
@@ -1263,7 +1601,7 @@ func (m *model) ClusterConfig(deviceID protocol.DeviceID, cm protocol.ClusterCon
 	}
 
 	if deviceCfg.Introducer {
-		m.cfg.Modify(func(cfg *config.Configuration) {
+		m.cfg.As(config.ActorDevice(deviceID)).Modify(func(cfg *config.Configuration) {
 			folders, devices, foldersDevices, introduced := m.handleIntroductions(deviceCfg, cm, cfg.FolderMap(), cfg.DeviceMap())
 			folders, devices, deintroduced := m.handleDeintroductions(deviceCfg, foldersDevices, folders, devices)
 			if !introduced && !deintroduced {
@@ -1273,7 +1611,7 @@ func (m *model) ClusterConfig(deviceID protocol.DeviceID, cm protocol.ClusterCon
 			for _, fcfg := range folders {
 				cfg.Folders = append(cfg.Folders, fcfg)
 			}
-			cfg.Devices = make([]config.DeviceConfiguration, len(devices))
+			cfg.Devices = make([]config.DeviceConfiguration, 0, len(devices))
 			for _, dcfg := range devices {
 				cfg.Devices = append(cfg.Devices, dcfg)
 			}
@@ -1547,10 +1885,15 @@ func (m *model) handleIntroductions(introducerCfg config.DeviceConfiguration, cm
 
 			foldersDevices.set(device.ID, folder.ID)
 
-			if _, ok := devices[device.ID]; !ok {
+			if devCfg, ok := devices[device.ID]; !ok {
 				// The device is currently unknown. Add it to the config.
 				devices[device.ID] = m.introduceDevice(device, introducerCfg)
-			} else if fcfg.SharedWith(device.ID) {
+			} else if devCfg, ok := updateIntroducedDevice(devCfg, device, introducerCfg); ok {
+				devices[device.ID] = devCfg
+				changed = true
+			}
+
+			if fcfg.SharedWith(device.ID) {
 				// We already share the folder with this device, so
 				// nothing to do.
 				continue
@@ -1690,18 +2033,14 @@ func (m *model) newFolderConfiguration(id, label string, fsType fs.FilesystemTyp
 }
 
 func (m *model) introduceDevice(device protocol.Device, introducerCfg config.DeviceConfiguration) config.DeviceConfiguration {
-	addresses := []string{"dynamic"}
-	for _, addr := range device.Addresses {
-		if addr != "dynamic" {
-			addresses = append(addresses, addr)
-		}
-	}
+	addresses := introducedAddresses(device)
 
 	l.Infof("Adding device %v to config (vouched for by introducer %v)", device.ID, introducerCfg.DeviceID)
+	// Settings such as compression come from the device defaults, like for
+	// devices added by hand.
 	newDeviceCfg := m.cfg.DefaultDevice()
 	newDeviceCfg.DeviceID = device.ID
 	newDeviceCfg.Name = device.Name
-	newDeviceCfg.Compression = introducerCfg.Compression
 	newDeviceCfg.Addresses = addresses
 	newDeviceCfg.CertName = device.CertName
 	newDeviceCfg.IntroducedBy = introducerCfg.DeviceID
@@ -1716,6 +2055,59 @@ func (m *model) introduceDevice(device protocol.Device, introducerCfg config.Dev
 	return newDeviceCfg
 }
 
+// updateIntroducedDevice brings the config of a device introduced by the
+// given introducer in line with what the introducer says about it, as far
+// as the introducer has the authority to do so. The second return value is
+// true if anything changed.
+func updateIntroducedDevice(deviceCfg config.DeviceConfiguration, device protocol.Device, introducerCfg config.DeviceConfiguration) (config.DeviceConfiguration, bool) {
+	if deviceCfg.IntroducedBy != introducerCfg.DeviceID || introducerCfg.IntroducerAuthority == config.IntroducerAuthorityMembership {
+		return deviceCfg, false
+	}
+
+	changed := false
+
+	if addresses := introducedAddresses(device); !reflect.DeepEqual(addresses, deviceCfg.Addresses) {
+		l.Infof("Updating addresses of device %v to %v (vouched for by introducer %v)", device.ID, addresses, introducerCfg.DeviceID)
+		deviceCfg.Addresses = addresses
+		changed = true
+	}
+
+	if introducerCfg.IntroducerAuthority != config.IntroducerAuthorityFull {
+		return deviceCfg, changed
+	}
+
+	// An empty name means the introducer uses the name the device
+	// advertises, which we'll pick up ourselves.
+	if device.Name != "" && device.Name != deviceCfg.Name {
+		l.Infof("Renaming device %v from %q to %q (vouched for by introducer %v)", device.ID, deviceCfg.Name, device.Name, introducerCfg.DeviceID)
+		deviceCfg.Name = device.Name
+		changed = true
+	}
+	if device.Introducer != deviceCfg.Introducer {
+		if device.Introducer {
+			l.Infof("Device %v is now also an introducer (vouched for by introducer %v)", device.ID, introducerCfg.DeviceID)
+		} else {
+			l.Infof("Device %v is no longer an introducer (vouched for by introducer %v)", device.ID, introducerCfg.DeviceID)
+		}
+		deviceCfg.Introducer = device.Introducer
+		changed = true
+	}
+
+	return deviceCfg, changed
+}
+
+// introducedAddresses returns the addresses for a device as advertised by
+// an introducer, always including dynamic discovery.
+func introducedAddresses(device protocol.Device) []string {
+	addresses := []string{"dynamic"}
+	for _, addr := range device.Addresses {
+		if addr != "dynamic" {
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses
+}
+
 // Closed is called when a connection has been closed
 func (m *model) Closed(conn protocol.Connection, err error) {
 	device := conn.ID()
@@ -1728,7 +2120,10 @@ func (m *model) Closed(conn protocol.Connection, err error) {
 	}
 
 	delete(m.conn, device)
+	secondaries := m.links[device][1:]
+	delete(m.links, device)
 	delete(m.connRequestLimiters, device)
+	delete(m.outRequestLimiters, device)
 	delete(m.helloMessages, device)
 	delete(m.deviceDownloads, device)
 	delete(m.remotePausedFolders, device)
@@ -1737,10 +2132,16 @@ func (m *model) Closed(conn protocol.Connection, err error) {
 	delete(m.indexSenders, device)
 	m.pmut.Unlock()
 
+	m.completionRates.forgetDevice(device)
+
+	for _, ln := range secondaries {
+		ln.Close(err)
+	}
+
 	m.progressEmitter.temporaryIndexUnsubscribe(conn)
 	m.deviceDidClose(device, time.Since(conn.EstablishedAt()))
 
-	l.Infof("Connection to %s at %s closed: %v", device, conn, err)
+	l.With("device", device.String()).Infof("Connection to %s at %s closed: %v", device, conn, err)
 	m.evLogger.Log(events.DeviceDisconnected, map[string]string{
 		"id":    device.String(),
 		"error": err.Error(),
@@ -1751,6 +2152,7 @@ func (m *model) Closed(conn protocol.Connection, err error) {
 // Implements protocol.RequestResponse
 type requestResponse struct {
 	data   []byte
+	hash   []byte
 	closed chan struct{}
 	once   stdsync.Once
 }
@@ -1777,9 +2179,13 @@ func (r *requestResponse) Wait() {
 	<-r.closed
 }
 
+func (r *requestResponse) Hash() []byte {
+	return r.hash
+}
+
 // Request returns the specified data segment by reading it from local disk.
 // Implements the protocol.Model interface.
-func (m *model) Request(deviceID protocol.DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, weakHash uint32, fromTemporary bool) (out protocol.RequestResponse, err error) {
+func (m *model) Request(deviceID protocol.DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, hashAlgo protocol.BlockHashAlgorithm, weakHash uint32, fromTemporary bool) (out protocol.RequestResponse, err error) {
 	if size < 0 || offset < 0 {
 		return nil, protocol.ErrInvalid
 	}
@@ -1846,7 +2252,9 @@ func (m *model) Request(deviceID protocol.DeviceID, folder, name string, blockNo
 
 	folderFs := folderCfg.Filesystem()
 
-	if err := osutil.TraversesSymlink(folderFs, filepath.Dir(name)); err != nil {
+	// Symlinks are only traversed if they are followed, in which case all
+	// of them are the user's.
+	if err := osutil.TraversesSymlink(folderFs, filepath.Dir(name)); err != nil && folderCfg.SymlinkPolicy != config.SymlinkPolicyFollow {
 		l.Debugf("%v REQ(in) traversal check: %s - %s: %q / %q o=%d s=%d", m, err, deviceID, folder, name, offset, size)
 		return nil, protocol.ErrNoSuchFile
 	}
@@ -1863,7 +2271,7 @@ func (m *model) Request(deviceID protocol.DeviceID, folder, name string, blockNo
 			return nil, protocol.ErrNoSuchFile
 		}
 		_, err := readOffsetIntoBuf(folderFs, tempFn, offset, res.data)
-		if err == nil && scanner.Validate(res.data, hash, weakHash) {
+		if err == nil && scanner.Validate(res.data, hash, hashAlgo, weakHash) {
 			return res, nil
 		}
 		// Fall through to reading from a non-temp file, just incase the temp
@@ -1892,7 +2300,8 @@ func (m *model) Request(deviceID protocol.DeviceID, folder, name string, blockNo
 		return nil, protocol.ErrGeneric
 	}
 
-	if len(hash) > 0 && !scanner.Validate(res.data[:n], hash, weakHash) {
+	res.hash = m.verifiedBlockHash(folder, name, offset, res.data[:n], hash)
+	if len(hash) > 0 && res.hash == nil && !scanner.Validate(res.data[:n], hash, hashAlgo, weakHash) {
 		m.recheckFile(deviceID, folder, name, offset, hash, weakHash)
 		l.Debugf("%v REQ(in) failed validating data: %s: %q / %q o=%d s=%d", m, deviceID, folder, name, offset, size)
 		return nil, protocol.ErrNoSuchFile
@@ -1927,6 +2336,31 @@ func newLimitedRequestResponse(size int, limiters ...*byteSemaphore) *requestRes
 	return res
 }
 
+// verifiedBlockHash returns the hash of the block at the offset in our
+// index, if it's the requested one and the data matches it exactly. The
+// weak hash isn't considered, as that doesn't identify the data.
+func (m *model) verifiedBlockHash(folder, name string, offset int64, data, hash []byte) []byte {
+	if len(hash) == 0 {
+		return nil
+	}
+	cf, ok := m.CurrentFolderFile(folder, name)
+	if !ok || cf.IsDeleted() || cf.IsInvalid() || cf.Type != protocol.FileInfoTypeFile {
+		return nil
+	}
+	blockIndex := cf.BlockIndex(offset)
+	if blockIndex < 0 {
+		return nil
+	}
+	block := cf.Blocks[blockIndex]
+	if !bytes.Equal(block.Hash, hash) || int(block.Size) != len(data) {
+		return nil
+	}
+	if !bytes.Equal(scanner.HashBlock(cf.BlockHashAlgorithm, data), block.Hash) {
+		return nil
+	}
+	return block.Hash
+}
+
 func (m *model) recheckFile(deviceID protocol.DeviceID, folder, name string, offset int64, hash []byte, weakHash uint32) {
 	cf, ok := m.CurrentFolderFile(folder, name)
 	if !ok {
@@ -1939,9 +2373,9 @@ func (m *model) recheckFile(deviceID protocol.DeviceID, folder, name string, off
 		return
 	}
 
-	blockIndex := int(offset / int64(cf.BlockSize()))
-	if blockIndex >= len(cf.Blocks) {
-		l.Debugf("%v recheckFile: %s: %q / %q i=%d: block index too far", m, deviceID, folder, name, blockIndex)
+	blockIndex := cf.BlockIndex(offset)
+	if blockIndex < 0 {
+		l.Debugf("%v recheckFile: %s: %q / %q: no block at offset %d", m, deviceID, folder, name, offset)
 		return
 	}
 
@@ -2012,6 +2446,32 @@ func (m *model) Connection(deviceID protocol.DeviceID) (protocol.Connection, boo
 // LoadIgnores loads or refreshes the ignore patterns from disk, if the
 // folder is healthy, and returns the refreshed lines and patterns.
 func (m *model) LoadIgnores(folder string) ([]string, []string, error) {
+	ignores, err := m.loadIgnores(folder)
+	if ignores == nil {
+		return nil, nil, err
+	}
+
+	// Return lines and patterns, which may have some meaning even when err
+	// != nil, depending on the specific error.
+	return ignores.Lines(), ignores.Patterns(), err
+}
+
+// ExplainIgnore loads or refreshes the ignore patterns from disk like
+// LoadIgnores, and returns whether the file is ignored and why.
+func (m *model) ExplainIgnore(folder, file string) (ignore.Explanation, error) {
+	ignores, err := m.loadIgnores(folder)
+	if ignores == nil {
+		if err != nil {
+			return ignore.Explanation{}, err
+		}
+		ignores = ignore.New(nil)
+	}
+	return ignores.Explain(file), err
+}
+
+// loadIgnores returns the refreshed ignore matcher of the folder, or nil
+// if there are no ignore patterns.
+func (m *model) loadIgnores(folder string) (*ignore.Matcher, error) {
 	m.fmut.RLock()
 	cfg, cfgOk := m.folderCfgs[folder]
 	ignores, ignoresOk := m.folderIgnores[folder]
@@ -2020,17 +2480,17 @@ func (m *model) LoadIgnores(folder string) ([]string, []string, error) {
 	if !cfgOk {
 		cfg, cfgOk = m.cfg.Folder(folder)
 		if !cfgOk {
-			return nil, nil, fmt.Errorf("folder %s does not exist", folder)
+			return nil, fmt.Errorf("folder %s does not exist", folder)
 		}
 	}
 
 	if cfg.Type == config.FolderTypeReceiveEncrypted {
-		return nil, nil, nil
+		return nil, nil
 	}
 
 	// On creation a new folder with ignore patterns validly has no marker yet.
 	if err := cfg.CheckPath(); err != nil && err != config.ErrMarkerMissing {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if !ignoresOk {
@@ -2040,12 +2500,9 @@ func (m *model) LoadIgnores(folder string) ([]string, []string, error) {
 	err := ignores.Load(".stignore")
 	if fs.IsNotExist(err) {
 		// Having no ignores is not an error.
-		return nil, nil, nil
+		return nil, nil
 	}
-
-	// Return lines and patterns, which may have some meaning even when err
-	// != nil, depending on the specific error.
-	return ignores.Lines(), ignores.Patterns(), err
+	return ignores, err
 }
 
 // CurrentIgnores returns the currently loaded set of ignore patterns,
@@ -2109,6 +2566,11 @@ func (m *model) OnHello(remoteID protocol.DeviceID, addr net.Addr, hello protoco
 	}
 
 	cfg, ok := m.cfg.Device(remoteID)
+	if !ok {
+		if prev, rotating := m.deviceRotatingTo(remoteID); rotating {
+			cfg, ok = m.completeRotation(prev.DeviceID, remoteID)
+		}
+	}
 	if !ok {
 		if err := m.db.AddOrUpdatePendingDevice(remoteID, hello.DeviceName, addr.String()); err != nil {
 			l.Warnf("Failed to persist pending device entry to database: %v", err)
@@ -2143,6 +2605,66 @@ func (m *model) OnHello(remoteID protocol.DeviceID, addr net.Addr, hello protoco
 		return errConnLimitReached
 	}
 
+	var nextID protocol.DeviceID
+	if len(hello.NextCertificate) > 0 {
+		nextID = protocol.NewDeviceID(hello.NextCertificate)
+	}
+	if nextID != cfg.NextDeviceID && nextID != m.id {
+		if nextID != protocol.EmptyDeviceID {
+			l.Infof("Device %v is rotating its certificate to %v", remoteID, nextID)
+		}
+		waiter, err := m.cfg.Modify(func(cfg *config.Configuration) {
+			if dev, _, ok := cfg.Device(remoteID); ok {
+				dev.NextDeviceID = nextID
+				cfg.SetDevice(dev)
+			}
+		})
+		if err != nil {
+			l.Warnf("Failed to record certificate rotation of %v: %v", remoteID, err)
+		} else {
+			waiter.Wait()
+		}
+	}
+
+	return nil
+}
+
+// deviceRotatingTo returns the device that announced it is rotating its
+// certificate to the given device ID.
+func (m *model) deviceRotatingTo(id protocol.DeviceID) (config.DeviceConfiguration, bool) {
+	for _, dev := range m.cfg.DeviceList() {
+		if dev.NextDeviceID == id {
+			return dev, true
+		}
+	}
+	return config.DeviceConfiguration{}, false
+}
+
+// completeRotation replaces the device from by to in the configuration, as
+// from connected with the certificate it announced it was rotating to.
+func (m *model) completeRotation(from, to protocol.DeviceID) (config.DeviceConfiguration, bool) {
+	l.Infof("Device %v completed its certificate rotation and is now %v", from, to)
+	waiter, err := m.cfg.Modify(func(cfg *config.Configuration) {
+		cfg.ReplaceDeviceID(from, to)
+	})
+	if err != nil {
+		l.Warnf("Failed to replace device %v by %v: %v", from, to, err)
+		return config.DeviceConfiguration{}, false
+	}
+	waiter.Wait()
+	return m.cfg.Device(to)
+}
+
+// SetNextCertificate makes us announce next as the certificate we are
+// rotating to, vouched for by our current certificate cert.
+func (m *model) SetNextCertificate(next, cert tls.Certificate) error {
+	var hello protocol.Hello
+	if err := hello.SetNextCertificate(next, cert); err != nil {
+		return err
+	}
+	m.pmut.Lock()
+	m.nextCert, m.nextCertSig = hello.NextCertificate, hello.NextCertificateSignature
+	m.pmut.Unlock()
 	return nil
 }
 
@@ -2155,10 +2677,23 @@ func (m *model) GetHello(id protocol.DeviceID) protocol.HelloIntf {
 			name = myCfg.Name
 		}
 	}
+	m.pmut.RLock()
+	secondary := m.wantsSecondaryLocked(id)
+	nextCert, nextCertSig := m.nextCert, m.nextCertSig
+	m.pmut.RUnlock()
+	features := []string{protocol.FeatureXattrs, protocol.FeatureOwnership, protocol.FeatureSparse, protocol.FeatureConfigPush, protocol.FeatureUpgradeRequests, protocol.FeatureBLAKE2b}
+	if protocol.ZstdSupported {
+		features = append(features, protocol.FeatureZstd)
+	}
 	return &protocol.Hello{
 		DeviceName:    name,
 		ClientName:    m.clientName,
 		ClientVersion: m.clientVersion,
+		Secondary:     secondary,
+		Features:      features,
+
+		NextCertificate:          nextCert,
+		NextCertificateSignature: nextCertSig,
 	}
 }
 
@@ -2175,7 +2710,7 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 
 	m.pmut.Lock()
 	if oldConn, ok := m.conn[deviceID]; ok {
-		l.Infoln("Replacing old connection", oldConn, "with", conn, "for", deviceID)
+		l.With("device", deviceID.String()).Infoln("Replacing old connection", oldConn, "with", conn, "for", deviceID)
 		// There is an existing connection to this device that we are
 		// replacing. We must close the existing connection and wait for the
 		// close to complete before adding the new connection. We do the
@@ -2189,10 +2724,11 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 	}
 
 	m.conn[deviceID] = conn
+	m.links[deviceID] = []*link{{Connection: conn}}
 	closed := make(chan struct{})
 	m.closed[deviceID] = closed
 	m.deviceDownloads[deviceID] = newDeviceDownloadState()
-	m.indexSenders[deviceID] = newIndexSenderRegistry(conn, closed, m.Supervisor, m.evLogger)
+	m.indexSenders[deviceID] = newIndexSenderRegistry(conn, closed, hello, m.Supervisor, m.evLogger)
 	// 0: default, <0: no limiting
 	switch {
 	case device.MaxRequestKiB > 0:
@@ -2200,6 +2736,9 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 	case device.MaxRequestKiB == 0:
 		m.connRequestLimiters[deviceID] = newByteSemaphore(1024 * defaultPullerPendingKiB)
 	}
+	// The other device most likely doesn't serve more than this at once
+	// either, so there's nothing to gain by sending more.
+	m.outRequestLimiters[deviceID] = newByteSemaphore(1024 * defaultPullerPendingKiB)
 
 	m.helloMessages[deviceID] = hello
 
@@ -2218,7 +2757,7 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 
 	m.evLogger.Log(events.DeviceConnected, event)
 
-	l.Infof(`Device %s client is "%s %s" named "%s" at %s`, deviceID, hello.ClientName, hello.ClientVersion, hello.DeviceName, conn)
+	l.With("device", deviceID.String()).Infof(`Device %s client is "%s %s" named "%s" at %s`, deviceID, hello.ClientName, hello.ClientVersion, hello.DeviceName, conn)
 
 	conn.Start()
 	m.pmut.Unlock()
@@ -2228,7 +2767,7 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 	conn.ClusterConfig(cm)
 
 	if (device.Name == "" || m.cfg.Options().OverwriteRemoteDevNames) && hello.DeviceName != "" {
-		m.cfg.Modify(func(cfg *config.Configuration) {
+		m.cfg.As(config.ActorDevice(deviceID)).Modify(func(cfg *config.Configuration) {
 			for i := range cfg.Devices {
 				if cfg.Devices[i].DeviceID == deviceID {
 					if cfg.Devices[i].Name == "" || cfg.Options.OverwriteRemoteDevNames {
@@ -2267,6 +2806,210 @@ func (m *model) DownloadProgress(device protocol.DeviceID, folder string, update
 	return nil
 }
 
+// ConfigPush applies a configuration fragment pushed by the device, if we
+// accept configuration from it. Anything else is only logged, as the
+// device can't act on it anyway.
+func (m *model) ConfigPush(deviceID protocol.DeviceID, push protocol.ConfigPush) error {
+	deviceCfg, ok := m.cfg.Device(deviceID)
+	if !ok || !deviceCfg.ConfigManager {
+		l.Warnf("Ignoring configuration pushed by %v, as it is not allowed to manage our configuration", deviceID)
+		return nil
+	}
+
+	frag, err := config.ParseFragment(push.Fragment)
+	if err != nil {
+		l.Warnf("Ignoring configuration pushed by %v: %v", deviceID, err)
+		return nil
+	}
+	var applyErr error
+	_, err = m.cfg.As(config.ActorDevice(deviceID)).Modify(func(cfg *config.Configuration) {
+		// Either all of it or nothing.
+		to := cfg.Copy()
+		if applyErr = frag.Apply(&to); applyErr == nil {
+			*cfg = to
+		}
+	})
+	if applyErr != nil {
+		l.Warnf("Rejected configuration pushed by %v: %v", deviceID, applyErr)
+		return nil
+	} else if err != nil {
+		l.Warnf("Failed to apply configuration pushed by %v: %v", deviceID, err)
+		return nil
+	}
+	l.Infof("Applied configuration pushed by %v", deviceID)
+	return nil
+}
+
+// PushConfig sends a configuration fragment for the device to apply. Whether
+// it does is up to the device.
+func (m *model) PushConfig(device protocol.DeviceID, fragment []byte) error {
+	if _, err := config.ParseFragment(fragment); err != nil {
+		return err
+	}
+
+	m.pmut.RLock()
+	conn, ok := m.conn[device]
+	hello := m.helloMessages[device]
+	m.pmut.RUnlock()
+	if !ok {
+		return errDeviceNotConnected
+	}
+	if !hello.HasFeature(protocol.FeatureConfigPush) {
+		return errConfigPushUnsupported
+	}
+
+	return conn.ConfigPush(context.Background(), protocol.ConfigPush{Fragment: fragment})
+}
+
+// UpgradeResult is the answer of a device to an upgrade request.
+type UpgradeResult struct {
+	Running  string `json:"running,omitempty"`
+	Latest   string `json:"latest,omitempty"`
+	Upgraded bool   `json:"upgraded"`
+	Error    string `json:"error,omitempty"`
+}
+
+// RequestUpgrades asks the given devices, or all connected ones if none are
+// given, to check for an upgrade to the given version, or the latest one if
+// empty, and to perform it unless checkOnly is set. The devices are asked in
+// parallel and the answers returned once all of them answered or the context
+// is cancelled. Whether a device acts on the request is up to it.
+func (m *model) RequestUpgrades(ctx context.Context, devices []protocol.DeviceID, version string, checkOnly bool) map[protocol.DeviceID]UpgradeResult {
+	m.pmut.RLock()
+	if len(devices) == 0 {
+		for id := range m.conn {
+			devices = append(devices, id)
+		}
+	}
+	conns := make(map[protocol.DeviceID]protocol.Connection, len(devices))
+	results := make(map[protocol.DeviceID]UpgradeResult, len(devices))
+	for _, id := range devices {
+		conn, ok := m.conn[id]
+		switch {
+		case !ok:
+			results[id] = UpgradeResult{Error: errDeviceNotConnected.Error()}
+		case !m.helloMessages[id].HasFeature(protocol.FeatureUpgradeRequests):
+			results[id] = UpgradeResult{Error: errUpgradeRequestsUnsupported.Error()}
+		default:
+			conns[id] = conn
+		}
+	}
+	m.pmut.RUnlock()
+
+	mut := sync.NewMutex()
+	wg := sync.NewWaitGroup()
+	for id, conn := range conns {
+		wg.Add(1)
+		go func(id protocol.DeviceID, conn protocol.Connection) {
+			defer wg.Done()
+			resp, err := conn.UpgradeRequest(ctx, version, checkOnly)
+			res := UpgradeResult{
+				Running:  resp.Running,
+				Latest:   resp.Latest,
+				Upgraded: resp.Upgraded,
+				Error:    resp.Error,
+			}
+			if err != nil {
+				res.Error = err.Error()
+			}
+			mut.Lock()
+			results[id] = res
+			mut.Unlock()
+		}(id, conn)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// UpgradeRequest handles an upgrade request by the device in the
+// background, as checking for and downloading a release takes a while.
+func (m *model) UpgradeRequest(deviceID protocol.DeviceID, req protocol.UpgradeRequest) error {
+	m.pmut.RLock()
+	conn, ok := m.conn[deviceID]
+	m.pmut.RUnlock()
+	if !ok {
+		return nil
+	}
+	go m.handleUpgradeRequest(conn, req)
+	return nil
+}
+
+func (m *model) handleUpgradeRequest(conn protocol.Connection, req protocol.UpgradeRequest) {
+	resp := protocol.UpgradeResponse{ID: req.ID, Running: m.clientVersion}
+	if err := m.upgradeOnRequest(conn.ID(), req, &resp); err != nil {
+		l.Infof("Not upgrading on request of %v: %v", conn.ID(), err)
+		resp.Error = err.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := conn.UpgradeResponse(ctx, resp); err != nil {
+		l.Debugf("Failed to answer upgrade request of %v: %v", conn.ID(), err)
+	}
+
+	if resp.Upgraded {
+		l.Infof("Upgraded to %s on request of %v, restarting", resp.Latest, conn.ID())
+		m.fatal(&svcutil.FatalErr{
+			Err:    errUpgradedOnRequest,
+			Status: svcutil.ExitUpgrade,
+		})
+	}
+}
+
+// upgradeOnRequest checks for the requested release, or the latest one if
+// none is given, and upgrades to it unless only a check was requested,
+// provided the device is allowed to manage our upgrades. Requests arriving
+// while another one is being handled are refused.
+func (m *model) upgradeOnRequest(deviceID protocol.DeviceID, req protocol.UpgradeRequest, resp *protocol.UpgradeResponse) error {
+	deviceCfg, ok := m.cfg.Device(deviceID)
+	if !ok || !deviceCfg.UpgradeManager {
+		return errUpgradeNotAllowed
+	}
+	if upgrade.DisabledByCompilation {
+		return upgrade.ErrUpgradeUnsupported
+	}
+
+	select {
+	case m.upgradeToken <- struct{}{}:
+	default:
+		return upgrade.ErrUpgradeInProgress
+	}
+	defer func() {
+		// After upgrading we're about to restart; keep refusing.
+		if !resp.Upgraded {
+			<-m.upgradeToken
+		}
+	}()
+
+	opts := m.cfg.Options()
+	var rel upgrade.Release
+	var err error
+	if req.Version != "" {
+		rel, err = upgrade.ReleaseByTag(opts.ReleasesURL, m.clientVersion, req.Version)
+		if err == upgrade.ErrNoVersionToSelect {
+			err = fmt.Errorf("requested version %s is not available", req.Version)
+		}
+	} else {
+		rel, err = upgrade.LatestRelease(opts.ReleasesURL, m.clientVersion, opts.UpgradeToPreReleases)
+	}
+	if err != nil {
+		return err
+	}
+	resp.Latest = rel.Tag
+	if req.CheckOnly {
+		return nil
+	}
+	if upgrade.CompareVersions(rel.Tag, m.clientVersion) < upgrade.Newer {
+		return upgrade.ErrNoUpgradeAvailable
+	}
+	if err := upgrade.To(rel); err != nil {
+		return err
+	}
+	resp.Upgraded = true
+	return nil
+}
+
 func (m *model) deviceWasSeen(deviceID protocol.DeviceID) {
 	m.fmut.RLock()
 	sr, ok := m.deviceStatRefs[deviceID]
@@ -2285,20 +3028,6 @@ func (m *model) deviceDidClose(deviceID protocol.DeviceID, duration time.Duratio
 	}
 }
 
-func (m *model) requestGlobal(ctx context.Context, deviceID protocol.DeviceID, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error) {
-	m.pmut.RLock()
-	nc, ok := m.conn[deviceID]
-	m.pmut.RUnlock()
-
-	if !ok {
-		return nil, fmt.Errorf("requestGlobal: no such device: %s", deviceID)
-	}
-
-	l.Debugf("%v REQ(out): %s: %q / %q b=%d o=%d s=%d h=%x wh=%x ft=%t", m, deviceID, folder, name, blockNo, offset, size, hash, weakHash, fromTemporary)
-
-	return nc.Request(ctx, folder, name, blockNo, offset, size, hash, weakHash, fromTemporary)
-}
-
 func (m *model) ScanFolders() map[string]error {
 	m.fmut.RLock()
 	folders := make([]string, 0, len(m.folderCfgs))
@@ -2356,13 +3085,19 @@ func (m *model) DelayScan(folder string, next time.Duration) {
 }
 
 // numHashers returns the number of hasher routines to use for a given folder,
-// taking into account configuration and available CPU cores.
+// taking into account configuration, the memory budget, folder priorities and
+// available CPU cores.
 func (m *model) numHashers(folder string) int {
 	m.fmut.RLock()
 	folderCfg := m.folderCfgs[folder]
-	numFolders := len(m.folderCfgs)
+	weight, totalWeight := hasherWeights(m.folderCfgs, folder)
 	m.fmut.RUnlock()
 
+	if m.cfg.Options().LowMemory() {
+		// Every hasher holds a block in memory, and more.
+		return 1
+	}
+
 	if folderCfg.Hashers > 0 {
 		// Specific value set in the config, use that.
 		return folderCfg.Hashers
@@ -2376,14 +3111,34 @@ func (m *model) numHashers(folder string) int {
 
 	// For other operating systems and architectures, lets try to get some
 	// work done... Divide the available CPU cores among the configured
-	// folders.
-	if perFolder := runtime.GOMAXPROCS(-1) / numFolders; perFolder > 0 {
+	// folders, according to their priorities.
+	if perFolder := runtime.GOMAXPROCS(-1) * weight / totalWeight; perFolder > 0 {
 		return perFolder
 	}
 
 	return 1
 }
 
+// hasherWeights returns the weight of the folder when dividing the CPU
+// cores, and the total weight of all folders. Folders with the lowest
+// priority weigh one, and each step up in priority adds one.
+func hasherWeights(folderCfgs map[string]config.FolderConfiguration, folder string) (int, int) {
+	if len(folderCfgs) == 0 {
+		return 1, 1
+	}
+	minPriority := folderCfgs[folder].Priority
+	for _, cfg := range folderCfgs {
+		if cfg.Priority < minPriority {
+			minPriority = cfg.Priority
+		}
+	}
+	total := 0
+	for _, cfg := range folderCfgs {
+		total += cfg.Priority - minPriority + 1
+	}
+	return folderCfgs[folder].Priority - minPriority + 1, total
+}
+
 // generateClusterConfig returns a ClusterConfigMessage that is correct for
 // the given peer device
 func (m *model) generateClusterConfig(device protocol.DeviceID) protocol.ClusterConfig {
@@ -2498,6 +3253,30 @@ func (m *model) WatchError(folder string) error {
 	return runner.WatchError()
 }
 
+// ConfirmMassChange applies the changes held back in the folder, as they
+// would delete or overwrite much of it.
+func (m *model) ConfirmMassChange(folder string) error {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	runner := m.folderRunners[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return err
+	}
+	runner.ConfirmMassChange()
+	return nil
+}
+
+func (m *model) HeldMassChange(folder string) *MassChange {
+	m.fmut.RLock()
+	runner, ok := m.folderRunners[folder]
+	m.fmut.RUnlock()
+	if !ok {
+		return nil
+	}
+	return runner.HeldMassChange()
+}
+
 func (m *model) Override(folder string) {
 	// Grab the runner and the file set.
 
@@ -2642,6 +3421,9 @@ func (m *model) RestoreFolderVersions(folder string, versions map[string]time.Ti
 	if ver == nil {
 		return nil, errNoVersioner
 	}
+	if m.cfg.Options().MaintenanceFreeze {
+		return nil, errMaintenanceFreeze
+	}
 
 	restoreErrors := make(map[string]error)
 
@@ -2778,6 +3560,13 @@ func (m *model) CommitConfiguration(from, to config.Configuration) bool {
 			}
 			clusterConfigDevices.add(fromCfg.DeviceIDs())
 			clusterConfigDevices.add(toCfg.DeviceIDs())
+		} else if fromCfg.Order != toCfg.Order || !reflect.DeepEqual(fromCfg.PullOrderPatterns, toCfg.PullOrderPatterns) {
+			m.fmut.RLock()
+			runner, ok := m.folderRunners[toCfg.ID]
+			m.fmut.RUnlock()
+			if ok {
+				runner.SetPullOrder(toCfg.Order, toCfg.PullOrderPatterns)
+			}
 		}
 
 		// Emit the folder pause/resume event
@@ -2860,6 +3649,23 @@ func (m *model) CommitConfiguration(from, to config.Configuration) bool {
 	m.globalRequestLimiter.setCapacity(1024 * to.Options.MaxConcurrentIncomingRequestKiB())
 	m.folderIOLimiter.setCapacity(to.Options.MaxFolderConcurrency())
 
+	// Pulls are skipped during a maintenance freeze, so catch up on
+	// whatever happened in the meantime once it's lifted.
+	if from.Options.MaintenanceFreeze && !to.Options.MaintenanceFreeze {
+		l.Infoln("Maintenance freeze lifted, resuming changes to folders")
+		m.fmut.RLock()
+		for _, runner := range m.folderRunners {
+			runner.SchedulePull()
+		}
+		m.fmut.RUnlock()
+	} else if !from.Options.MaintenanceFreeze && to.Options.MaintenanceFreeze {
+		l.Infoln("Maintenance freeze active, no changes will be made to folders")
+	}
+
+	if from.Options.LowImpactScans != to.Options.LowImpactScans {
+		m.lowImpactScans.set(to.Options.LowImpactScans)
+	}
+
 	// Some options don't require restart as those components handle it fine
 	// by themselves. Compare the options structs containing only the
 	// attributes that require restart and act apprioriately.
@@ -3068,15 +3874,19 @@ func (s folderDeviceSet) hasDevice(dev protocol.DeviceID) bool {
 }
 
 type fileInfoBatch struct {
-	infos   []protocol.FileInfo
-	size    int
-	flushFn func([]protocol.FileInfo) error
+	infos    []protocol.FileInfo
+	size     int
+	maxFiles int
+	maxBytes int
+	flushFn  func([]protocol.FileInfo) error
 }
 
 func newFileInfoBatch(fn func([]protocol.FileInfo) error) *fileInfoBatch {
 	return &fileInfoBatch{
-		infos:   make([]protocol.FileInfo, 0, maxBatchSizeFiles),
-		flushFn: fn,
+		infos:    make([]protocol.FileInfo, 0, maxBatchSizeFiles),
+		maxFiles: maxBatchSizeFiles,
+		maxBytes: maxBatchSizeBytes,
+		flushFn:  fn,
 	}
 }
 
@@ -3086,7 +3896,7 @@ func (b *fileInfoBatch) append(f protocol.FileInfo) {
 }
 
 func (b *fileInfoBatch) full() bool {
-	return len(b.infos) >= maxBatchSizeFiles || b.size >= maxBatchSizeBytes
+	return len(b.infos) >= b.maxFiles || b.size >= b.maxBytes
 }
 
 func (b *fileInfoBatch) flushIfFull() error {
@@ -11,9 +11,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/adler32"
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -37,6 +39,8 @@ import (
 	"github.com/syncthing/syncthing/lib/protocol"
 	srand "github.com/syncthing/syncthing/lib/rand"
 	"github.com/syncthing/syncthing/lib/testutils"
+	"github.com/syncthing/syncthing/lib/tlsutil"
+	"github.com/syncthing/syncthing/lib/upgrade"
 	"github.com/syncthing/syncthing/lib/versioner"
 )
 
@@ -150,7 +154,7 @@ func TestRequest(t *testing.T) {
 	defer cleanupModel(m)
 
 	// Existing, shared file
-	res, err := m.Request(device1, "default", "foo", 0, 6, 0, nil, 0, false)
+	res, err := m.Request(device1, "default", "foo", 0, 6, 0, nil, 0, 0, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -160,40 +164,73 @@ func TestRequest(t *testing.T) {
 	}
 
 	// Existing, nonshared file
-	_, err = m.Request(device2, "default", "foo", 0, 6, 0, nil, 0, false)
+	_, err = m.Request(device2, "default", "foo", 0, 6, 0, nil, 0, 0, false)
 	if err == nil {
 		t.Error("Unexpected nil error on insecure file read")
 	}
 
 	// Nonexistent file
-	_, err = m.Request(device1, "default", "nonexistent", 0, 6, 0, nil, 0, false)
+	_, err = m.Request(device1, "default", "nonexistent", 0, 6, 0, nil, 0, 0, false)
 	if err == nil {
 		t.Error("Unexpected nil error on insecure file read")
 	}
 
 	// Shared folder, but disallowed file name
-	_, err = m.Request(device1, "default", "../walk.go", 0, 6, 0, nil, 0, false)
+	_, err = m.Request(device1, "default", "../walk.go", 0, 6, 0, nil, 0, 0, false)
 	if err == nil {
 		t.Error("Unexpected nil error on insecure file read")
 	}
 
 	// Negative offset
-	_, err = m.Request(device1, "default", "foo", 0, -4, 0, nil, 0, false)
+	_, err = m.Request(device1, "default", "foo", 0, -4, 0, nil, 0, 0, false)
 	if err == nil {
 		t.Error("Unexpected nil error on insecure file read")
 	}
 
 	// Larger block than available
-	_, err = m.Request(device1, "default", "foo", 0, 42, 0, []byte("hash necessary but not checked"), 0, false)
+	_, err = m.Request(device1, "default", "foo", 0, 42, 0, []byte("hash necessary but not checked"), 0, 0, false)
 	if err == nil {
 		t.Error("Unexpected nil error on read past end of file")
 	}
-	_, err = m.Request(device1, "default", "foo", 0, 42, 0, nil, 0, false)
+	_, err = m.Request(device1, "default", "foo", 0, 42, 0, nil, 0, 0, false)
 	if err != nil {
 		t.Error("Unexpected error when large read should be permitted")
 	}
 }
 
+func TestRequestVerifiedHash(t *testing.T) {
+	m := setupModel(t, defaultCfgWrapper)
+	defer cleanupModel(m)
+
+	cf, ok := m.CurrentFolderFile("default", "foo")
+	if !ok {
+		t.Fatal("foo not in index")
+	}
+	block := cf.Blocks[0]
+
+	// The hash from the index is attested once the data matched it.
+	res, err := m.Request(device1, "default", "foo", 0, int32(block.Size), 0, block.Hash, 0, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Hash(), block.Hash) {
+		t.Errorf("Expected verified hash %x, got %x", block.Hash, res.Hash())
+	}
+	weakHash := adler32.Checksum(res.Data())
+	res.Close()
+
+	// Matching only the weak hash is good enough to send the data, but
+	// doesn't verify it.
+	res, err = m.Request(device1, "default", "foo", 0, int32(block.Size), 0, []byte("some other hash"), 0, weakHash, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Hash() != nil {
+		t.Errorf("Data matching only the weak hash was verified as %x", res.Hash())
+	}
+	res.Close()
+}
+
 func genFiles(n int) []protocol.FileInfo {
 	files := make([]protocol.FileInfo, n)
 	t := time.Now().Unix()
@@ -276,7 +313,7 @@ func BenchmarkRequestOut(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		data, err := m.requestGlobal(context.Background(), device1, "default", files[i%n].Name, 0, 0, 32, nil, 0, false)
+		data, _, err := m.requestGlobal(context.Background(), device1, "default", files[i%n].Name, 0, 0, 32, nil, 0, 0, false)
 		if err != nil {
 			b.Error(err)
 		}
@@ -300,7 +337,7 @@ func BenchmarkRequestInSingleFile(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		if _, err := m.Request(device1, "default", "request/for/a/file/in/a/couple/of/dirs/128k", 0, 128<<10, 0, nil, 0, false); err != nil {
+		if _, err := m.Request(device1, "default", "request/for/a/file/in/a/couple/of/dirs/128k", 0, 128<<10, 0, nil, 0, 0, false); err != nil {
 			b.Error(err)
 		}
 	}
@@ -830,6 +867,233 @@ func TestIntroducer(t *testing.T) {
 	}
 }
 
+func TestIntroducerAuthority(t *testing.T) {
+	addresses := []string{"dynamic", "tcp://192.0.2.42:22000"}
+
+	cases := []struct {
+		authority    config.IntroducerAuthority
+		introducedBy protocol.DeviceID
+		addresses    []string
+		name         string
+		introducer   bool
+	}{
+		{config.IntroducerAuthorityMembership, device1, []string{"dynamic"}, "old", false},
+		{config.IntroducerAuthorityAddresses, device1, addresses, "old", false},
+		{config.IntroducerAuthorityFull, device1, addresses, "new", true},
+		// Devices we added ourselves are left alone.
+		{config.IntroducerAuthorityFull, protocol.EmptyDeviceID, []string{"dynamic"}, "old", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.authority.String(), func(t *testing.T) {
+			m, cancel := newState(t, config.Configuration{
+				Version: config.CurrentVersion,
+				Devices: []config.DeviceConfiguration{
+					{
+						DeviceID:            device1,
+						Introducer:          true,
+						IntroducerAuthority: tc.authority,
+					},
+					{
+						DeviceID:     device2,
+						Name:         "old",
+						Addresses:    []string{"dynamic"},
+						IntroducedBy: tc.introducedBy,
+					},
+				},
+				Folders: []config.FolderConfiguration{
+					{
+						ID:   "folder1",
+						Path: "testdata",
+						Devices: []config.FolderDeviceConfiguration{
+							{DeviceID: device1},
+							{DeviceID: device2, IntroducedBy: tc.introducedBy},
+						},
+					},
+				},
+			})
+			defer cleanupModel(m)
+			defer cancel()
+
+			cc := basicClusterConfig(myID, device1, "folder1")
+			cc.Folders[0].Devices = append(cc.Folders[0].Devices, protocol.Device{
+				ID:         device2,
+				Name:       "new",
+				Addresses:  []string{"tcp://192.0.2.42:22000", "dynamic"},
+				Introducer: true,
+			})
+			m.ClusterConfig(device1, cc)
+
+			dev, ok := m.cfg.Device(device2)
+			if !ok {
+				t.Fatal("device 2 missing")
+			}
+			if !equalStrings(dev.Addresses, tc.addresses) {
+				t.Errorf("addresses %v, expected %v", dev.Addresses, tc.addresses)
+			}
+			if dev.Name != tc.name {
+				t.Errorf("name %q, expected %q", dev.Name, tc.name)
+			}
+			if dev.Introducer != tc.introducer {
+				t.Errorf("introducer %v, expected %v", dev.Introducer, tc.introducer)
+			}
+		})
+	}
+}
+
+func TestConfigPush(t *testing.T) {
+	wcfg, cancel := createTmpWrapper(config.Configuration{
+		Version: config.CurrentVersion,
+		Devices: []config.DeviceConfiguration{
+			{DeviceID: device1, ConfigManager: true},
+			{DeviceID: device2},
+		},
+	})
+	defer cancel()
+	m := setupModel(t, wcfg)
+	defer cleanupModel(m)
+
+	fc1 := &fakeConnection{id: device1, model: m}
+	m.AddConnection(fc1, protocol.Hello{Features: []string{protocol.FeatureConfigPush}})
+	m.AddConnection(&fakeConnection{id: device2, model: m}, protocol.Hello{})
+
+	fragment := []byte(`{"options": {"relaysEnabled": true}}`)
+
+	if err := m.PushConfig(device1, []byte(`{}`)); err == nil {
+		t.Error("pushed an empty fragment")
+	}
+	if err := m.PushConfig(device2, fragment); err != errConfigPushUnsupported {
+		t.Error("expected unsupported error, got", err)
+	}
+	if err := m.PushConfig(protocol.LocalDeviceID, fragment); err != errDeviceNotConnected {
+		t.Error("expected not connected error, got", err)
+	}
+	if err := m.PushConfig(device1, fragment); err != nil {
+		t.Fatal(err)
+	}
+	fc1.mut.Lock()
+	if len(fc1.configPushes) != 1 || string(fc1.configPushes[0].Fragment) != string(fragment) {
+		t.Errorf("unexpected pushes %v", fc1.configPushes)
+	}
+	fc1.mut.Unlock()
+
+	// Only devices trusted to manage the configuration get to change it.
+	must(t, m.ConfigPush(device2, protocol.ConfigPush{Fragment: fragment}))
+	if m.cfg.Options().RelaysEnabled {
+		t.Error("applied configuration from device 2")
+	}
+	must(t, m.ConfigPush(device1, protocol.ConfigPush{Fragment: fragment}))
+	if !m.cfg.Options().RelaysEnabled {
+		t.Error("didn't apply configuration from device 1")
+	}
+
+	// A fragment that can't be applied to our configuration is rejected
+	// as a whole.
+	waiter, err := wcfg.Modify(func(cfg *config.Configuration) {
+		fcfg := cfg.Defaults.Folder.Copy()
+		fcfg.ID = "hooked"
+		fcfg.Path = "hooked"
+		fcfg.Paused = true
+		fcfg.Hooks = []config.FolderHookConfiguration{{Event: "folderIdle", Command: "true"}}
+		cfg.SetFolder(fcfg)
+	})
+	must(t, err)
+	waiter.Wait()
+	invalid := []byte(`{"folders": [{"id": "hooked", "hooks": []}], "options": {"relaysEnabled": false}}`)
+	if _, err := config.ParseFragment(invalid); err != nil {
+		t.Fatal("fragment should be valid by itself:", err)
+	}
+	must(t, m.ConfigPush(device1, protocol.ConfigPush{Fragment: invalid}))
+	if !m.cfg.Options().RelaysEnabled {
+		t.Error("applied part of a rejected fragment")
+	}
+	if fcfg, ok := m.cfg.Folder("hooked"); !ok || len(fcfg.Hooks) != 1 {
+		t.Error("folder changed by a rejected fragment")
+	}
+}
+
+func TestRequestUpgrades(t *testing.T) {
+	wcfg, cancel := createTmpWrapper(config.Configuration{
+		Version: config.CurrentVersion,
+		Devices: []config.DeviceConfiguration{
+			{DeviceID: device1},
+			{DeviceID: device2},
+		},
+	})
+	defer cancel()
+	m := setupModel(t, wcfg)
+	defer cleanupModel(m)
+
+	fc1 := &fakeConnection{id: device1, model: m}
+	m.AddConnection(fc1, protocol.Hello{Features: []string{protocol.FeatureUpgradeRequests}})
+	m.AddConnection(&fakeConnection{id: device2, model: m}, protocol.Hello{})
+
+	res := m.RequestUpgrades(context.Background(), []protocol.DeviceID{device1, device2, protocol.LocalDeviceID}, "v1.1.0", false)
+	if r := res[device1]; r.Error != "" || r.Running != "v1.0.0" || r.Latest != "v1.1.0" || !r.Upgraded {
+		t.Errorf("unexpected result for device 1: %+v", r)
+	}
+	if r := res[device2]; r.Error != errUpgradeRequestsUnsupported.Error() {
+		t.Errorf("unexpected result for device 2: %+v", r)
+	}
+	if r := res[protocol.LocalDeviceID]; r.Error != errDeviceNotConnected.Error() {
+		t.Errorf("unexpected result for unconnected device: %+v", r)
+	}
+	if res := m.RequestUpgrades(context.Background(), nil, "", true); len(res) != 2 {
+		t.Errorf("expected results for both connected devices, got %v", res)
+	}
+
+	response := func(id int) protocol.UpgradeResponse {
+		t.Helper()
+		must(t, m.UpgradeRequest(device1, protocol.UpgradeRequest{ID: id}))
+		for i := 0; i < 100; i++ {
+			fc1.mut.Lock()
+			for _, resp := range fc1.upgradeResponses {
+				if resp.ID == id {
+					fc1.mut.Unlock()
+					return resp
+				}
+			}
+			fc1.mut.Unlock()
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("no response to upgrade request", id)
+		return protocol.UpgradeResponse{}
+	}
+
+	// Devices not trusted to manage our upgrades are refused.
+	if resp := response(42); resp.Upgraded || resp.Error != errUpgradeNotAllowed.Error() {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	// Trusted ones too, while another upgrade request is being handled.
+	setDevice(t, wcfg, config.DeviceConfiguration{DeviceID: device1, UpgradeManager: true})
+	m.upgradeToken <- struct{}{}
+	if resp := response(43); resp.Upgraded || resp.Error != upgrade.ErrUpgradeInProgress.Error() {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestBenchmark(t *testing.T) {
+	m, _, fcfg, wcfgCancel := setupModelWithConnection(t)
+	defer wcfgCancel()
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem().URI())
+
+	if _, err := m.Benchmark(context.Background(), device2, time.Second); err != errDeviceNotConnected {
+		t.Error("expected not connected error, got", err)
+	}
+
+	res, err := m.Benchmark(context.Background(), device1, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Duration != 2*time.Second || res.BytesPerSecond() != 10<<20 || res.RTT != time.Millisecond {
+		t.Errorf("unexpected result %+v", res.BenchmarkResult)
+	}
+	if res.Type != "fake" || res.Relayed {
+		t.Errorf("unexpected connection info %+v", res)
+	}
+}
+
 func TestIssue4897(t *testing.T) {
 	m, cancel := newState(t, config.Configuration{
 		Devices: []config.DeviceConfiguration{
@@ -945,6 +1209,23 @@ func TestAutoAcceptNewFolder(t *testing.T) {
 	}
 }
 
+func TestAutoAcceptDevicePath(t *testing.T) {
+	tmpDir := createTmpDir()
+	defer os.RemoveAll(tmpDir)
+	modifiedCfg := defaultAutoAcceptCfg.Copy()
+	modifiedCfg.Devices[1].AutoAcceptPath = tmpDir
+	m, cancel := newState(t, modifiedCfg)
+	defer cleanupModel(m)
+	defer cancel()
+	id := srand.String(8)
+	m.ClusterConfig(device1, createClusterConfig(device1, id))
+	if fcfg, ok := m.cfg.Folder(id); !ok || !fcfg.SharedWith(device1) {
+		t.Error("expected shared", id)
+	} else if fcfg.Path != filepath.Join(tmpDir, id) {
+		t.Errorf("expected path %v, got %v", filepath.Join(tmpDir, id), fcfg.Path)
+	}
+}
+
 func TestAutoAcceptNewFolderFromTwoDevices(t *testing.T) {
 	m, cancel := newState(t, defaultAutoAcceptCfg)
 	defer cleanupModel(m)
@@ -3127,14 +3408,14 @@ func TestRequestLimit(t *testing.T) {
 
 	file := "tmpfile"
 	befReq := time.Now()
-	first, err := m.Request(device1, "default", file, 0, 2000, 0, nil, 0, false)
+	first, err := m.Request(device1, "default", file, 0, 2000, 0, nil, 0, 0, false)
 	if err != nil {
 		t.Fatalf("First request failed: %v", err)
 	}
 	reqDur := time.Since(befReq)
 	returned := make(chan struct{})
 	go func() {
-		second, err := m.Request(device1, "default", file, 0, 2000, 0, nil, 0, false)
+		second, err := m.Request(device1, "default", file, 0, 2000, 0, nil, 0, 0, false)
 		if err != nil {
 			t.Errorf("Second request failed: %v", err)
 		}
@@ -3172,7 +3453,7 @@ func TestConnCloseOnRestart(t *testing.T) {
 
 	br := &testutils.BlockingRW{}
 	nw := &testutils.NoopRW{}
-	m.AddConnection(protocol.NewConnection(device1, br, nw, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"fc"}, protocol.CompressionNever), protocol.Hello{})
+	m.AddConnection(protocol.NewConnection(device1, br, nw, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"fc"}, protocol.CompressionNever, 0, protocol.Keepalive{}), protocol.Hello{})
 	m.pmut.RLock()
 	if len(m.closed) != 1 {
 		t.Fatalf("Expected just one conn (len(m.conn) == %v)", len(m.conn))
@@ -4247,3 +4528,116 @@ func equalStringsInAnyOrder(a, b []string) bool {
 	}
 	return true
 }
+
+func TestDatabaseMaintenance(t *testing.T) {
+	m, _, fcfg, wcfgCancel := setupModelWithConnection(t)
+	defer wcfgCancel()
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem().URI())
+
+	results, err := m.CheckDatabase("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, ok := results[fcfg.ID]; !ok {
+		t.Errorf("No check result for folder %v", fcfg.ID)
+	} else if res.Repaired() != 0 {
+		t.Errorf("Unexpected repairs %+v", res)
+	}
+	if _, err := m.CheckDatabase("nonexistent"); err != errFolderMissing {
+		t.Errorf("Expected %v, got %v", errFolderMissing, err)
+	}
+
+	size, err := m.DatabaseSize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := size.Folders[fcfg.ID]; !ok {
+		t.Errorf("No size for folder %v", fcfg.ID)
+	}
+
+	if err := m.CompactDatabase(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullOrderChangeWithoutRestart(t *testing.T) {
+	wcfg, fcfg, wcfgCancel := tmpDefaultWrapper()
+	defer wcfgCancel()
+	m := setupModel(t, wcfg)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem().URI())
+
+	m.fmut.RLock()
+	before := m.folderRunners[fcfg.ID]
+	m.fmut.RUnlock()
+
+	waiter, err := wcfg.Modify(func(cfg *config.Configuration) {
+		fcfg.Order = config.PullOrderNewestFirst
+		fcfg.PullOrderPatterns = []string{"*.xmp"}
+		cfg.SetFolder(fcfg)
+	})
+	must(t, err)
+	waiter.Wait()
+
+	m.fmut.RLock()
+	after := m.folderRunners[fcfg.ID]
+	m.fmut.RUnlock()
+	if after != before {
+		t.Fatal("Folder was restarted")
+	}
+	f := after.(*sendReceiveFolder)
+	f.pullOrderMut.Lock()
+	defer f.pullOrderMut.Unlock()
+	if f.pullOrder != config.PullOrderNewestFirst || len(f.pullOrderPatterns) != 1 {
+		t.Errorf("Pull order not changed, got %v %v", f.pullOrder, f.pullOrderPatterns)
+	}
+}
+
+func TestCertificateRotation(t *testing.T) {
+	w, fcfg, wCancel := tmpDefaultWrapper()
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem().URI())
+
+	dir := t.TempDir()
+	cert, err := tlsutil.NewCertificate(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), "syncthing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := tlsutil.NewCertificate(filepath.Join(dir, "cert-next.pem"), filepath.Join(dir, "key-next.pem"), "syncthing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nextID := protocol.NewDeviceID(next.Certificate[0])
+	addr := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 22000}
+
+	// Our own rotation is announced in our Hello messages.
+	if err := m.SetNextCertificate(next, cert); err != nil {
+		t.Fatal(err)
+	}
+	if hello := m.GetHello(device1).(*protocol.Hello); !bytes.Equal(hello.NextCertificate, next.Certificate[0]) || len(hello.NextCertificateSignature) == 0 {
+		t.Error("next certificate not announced")
+	}
+
+	// A device announcing its rotation gets its next ID recorded.
+	hello := protocol.Hello{NextCertificate: next.Certificate[0]}
+	if err := m.OnHello(device1, addr, hello); err != nil {
+		t.Fatal(err)
+	}
+	if dev, _ := w.Device(device1); dev.NextDeviceID != nextID {
+		t.Fatalf("expected next device ID %v, got %v", nextID, dev.NextDeviceID)
+	}
+
+	// Once it connects with the new ID, that replaces the old one.
+	if err := m.OnHello(nextID, addr, protocol.Hello{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := w.Device(device1); ok {
+		t.Error("old device ID still configured")
+	}
+	if _, ok := w.Device(nextID); !ok {
+		t.Error("new device ID not configured")
+	}
+	if folder, _ := w.Folder(fcfg.ID); !folder.SharedWith(nextID) || folder.SharedWith(device1) {
+		t.Errorf("folder not shared with the new device ID: %v", folder.Devices)
+	}
+}
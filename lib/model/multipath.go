@@ -0,0 +1,170 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+var errNoPrimaryConnection = errors.New("no primary connection")
+
+// A link is a connection to a device that blocks can be requested over,
+// along with the number of requests currently outstanding on it. The first
+// link to a device is always the primary connection, any others are
+// secondary connections used only for block transfers.
+type link struct {
+	protocol.Connection
+	pending int32 // atomic
+}
+
+// cost estimates how long a new request on the link would take to be
+// answered, based on the requests already outstanding and the measured
+// round trip time.
+func (ln *link) cost() time.Duration {
+	rtt := ln.Statistics().RTT
+	if rtt <= 0 {
+		// Not measured yet; treat all such links as equally fast.
+		rtt = time.Millisecond
+	}
+	return time.Duration(atomic.LoadInt32(&ln.pending)+1) * rtt
+}
+
+// AddSecondaryConnection adds an additional connection to an already
+// connected device, which is then used to request blocks alongside the
+// primary connection. The connection must have been created with a
+// protocol.RequestsOnly receiver calling SecondaryClosed.
+func (m *model) AddSecondaryConnection(conn protocol.Connection) {
+	deviceID := conn.ID()
+
+	m.pmut.Lock()
+	if _, ok := m.conn[deviceID]; !ok {
+		m.pmut.Unlock()
+		conn.Close(errNoPrimaryConnection)
+		return
+	}
+	m.links[deviceID] = append(m.links[deviceID], &link{Connection: conn})
+	l.Infof("Established secondary connection to %s at %s", deviceID, conn)
+	conn.Start()
+	m.pmut.Unlock()
+
+	// The other side won't accept requests before it has seen a cluster
+	// config, even though it's ignored on secondary connections.
+	conn.ClusterConfig(protocol.ClusterConfig{})
+}
+
+// SecondaryClosed is called when a secondary connection is closed.
+func (m *model) SecondaryClosed(conn protocol.Connection, err error) {
+	deviceID := conn.ID()
+
+	m.pmut.Lock()
+	m.pruneLinksLocked(deviceID)
+	m.pmut.Unlock()
+
+	l.Infof("Secondary connection to %s at %s closed: %v", deviceID, conn, err)
+}
+
+// pruneLinksLocked removes the closed secondary connections of the device.
+func (m *model) pruneLinksLocked(deviceID protocol.DeviceID) {
+	links, ok := m.links[deviceID]
+	if !ok {
+		return
+	}
+	kept := links[:1]
+	for _, ln := range links[1:] {
+		if !ln.Closed() {
+			kept = append(kept, ln)
+		}
+	}
+	m.links[deviceID] = kept
+}
+
+// DeviceConnections returns all current connections to the device, the
+// primary one first.
+func (m *model) DeviceConnections(deviceID protocol.DeviceID) []protocol.Connection {
+	m.pmut.RLock()
+	defer m.pmut.RUnlock()
+	links := m.links[deviceID]
+	conns := make([]protocol.Connection, len(links))
+	for i, ln := range links {
+		conns[i] = ln.Connection
+	}
+	return conns
+}
+
+// wantsSecondaryLocked returns whether we'd like another connection to the
+// device, in addition to the ones we already have.
+func (m *model) wantsSecondaryLocked(deviceID protocol.DeviceID) bool {
+	links, ok := m.links[deviceID]
+	if !ok {
+		return false
+	}
+	device, ok := m.cfg.Device(deviceID)
+	return ok && int(device.NumConnections) > len(links)
+}
+
+// selectLink returns the link to the device that is expected to answer a
+// request the soonest, skipping the ones in tried.
+func (m *model) selectLink(deviceID protocol.DeviceID, tried map[*link]struct{}) (*link, bool) {
+	m.pmut.RLock()
+	defer m.pmut.RUnlock()
+	var best *link
+	for _, ln := range m.links[deviceID] {
+		if _, ok := tried[ln]; ok {
+			continue
+		}
+		if best == nil || ln.cost() < best.cost() {
+			best = ln
+		}
+	}
+	return best, best != nil
+}
+
+// requestGlobal requests a block from the device, striping requests over
+// all connections to it. A request failing because its connection closed
+// is retried over the remaining ones. Requests for folders with a higher
+// priority are sent first when there are many outstanding.
+func (m *model) requestGlobal(ctx context.Context, deviceID protocol.DeviceID, folder, name string, blockNo int, offset int64, size int, hash []byte, hashAlgo protocol.BlockHashAlgorithm, weakHash uint32, fromTemporary bool) ([]byte, bool, error) {
+	m.fmut.RLock()
+	priority := m.folderCfgs[folder].Priority
+	m.fmut.RUnlock()
+	m.pmut.RLock()
+	limiter, ok := m.outRequestLimiters[deviceID]
+	m.pmut.RUnlock()
+	if ok {
+		if err := limiter.takeWithPriority(ctx, size, priority); err != nil {
+			return nil, false, err
+		}
+		defer limiter.give(size)
+	}
+
+	tried := make(map[*link]struct{})
+	err := fmt.Errorf("requestGlobal: no such device: %s", deviceID)
+	for {
+		ln, ok := m.selectLink(deviceID, tried)
+		if !ok {
+			return nil, false, err
+		}
+		tried[ln] = struct{}{}
+
+		l.Debugf("%v REQ(out): %s: %q / %q b=%d o=%d s=%d h=%x wh=%x ft=%t via %s", m, deviceID, folder, name, blockNo, offset, size, hash, weakHash, fromTemporary, ln)
+
+		atomic.AddInt32(&ln.pending, 1)
+		var data []byte
+		var verified bool
+		data, verified, err = ln.Request(ctx, folder, name, blockNo, offset, size, hash, hashAlgo, weakHash, fromTemporary)
+		atomic.AddInt32(&ln.pending, -1)
+		if err != protocol.ErrClosed {
+			return data, verified, err
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestSecondaryConnections(t *testing.T) {
+	m := setupModel(t, defaultCfgWrapper)
+	defer cleanupModel(m)
+
+	primary := &fakeConnection{id: device1, model: m}
+	primary.addFile("file", 0644, protocol.FileInfoTypeFile, []byte("primary"))
+	m.AddConnection(primary, protocol.Hello{})
+
+	secondary := &fakeConnection{id: device1, model: m}
+	secondary.addFile("file", 0644, protocol.FileInfoTypeFile, []byte("secondary"))
+	secondary.closeFn = func(error) { secondary.closed = true }
+	m.AddSecondaryConnection(secondary)
+
+	if l := len(m.DeviceConnections(device1)); l != 2 {
+		t.Fatalf("expected 2 connections, got %d", l)
+	}
+
+	request := func() string {
+		t.Helper()
+		data, _, err := m.requestGlobal(context.Background(), device1, "default", "file", 0, 0, 32, nil, 0, 0, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// While the primary connection is busy, requests go to the secondary.
+
+	release := make(chan struct{})
+	primary.requestFn = func(context.Context, string, string, int64, int, []byte, bool) ([]byte, error) {
+		<-release
+		return []byte("primary"), nil
+	}
+	done := make(chan string)
+	go func() {
+		data, _, _ := m.requestGlobal(context.Background(), device1, "default", "file", 0, 0, 32, nil, 0, 0, false)
+		done <- string(data)
+	}()
+	for {
+		m.pmut.RLock()
+		pending := atomic.LoadInt32(&m.links[device1][0].pending)
+		m.pmut.RUnlock()
+		if pending > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if data := request(); data != "secondary" {
+		t.Errorf("expected request over secondary connection, got %q", data)
+	}
+	close(release)
+	if data := <-done; data != "primary" {
+		t.Errorf("expected request over primary connection, got %q", data)
+	}
+
+	// Requests failing because a connection closed are retried on the
+	// other one.
+
+	primary.requestFn = func(context.Context, string, string, int64, int, []byte, bool) ([]byte, error) {
+		return nil, protocol.ErrClosed
+	}
+	if data := request(); data != "secondary" {
+		t.Errorf("expected failover to secondary connection, got %q", data)
+	}
+	primary.requestFn = nil
+
+	closeErr := errors.New("closed")
+	secondary.Close(closeErr)
+	m.SecondaryClosed(secondary, closeErr)
+	if l := len(m.DeviceConnections(device1)); l != 1 {
+		t.Fatalf("expected 1 connection, got %d", l)
+	}
+	if data := request(); data != "primary" {
+		t.Errorf("expected request over primary connection, got %q", data)
+	}
+
+	// Closing the primary connection takes the secondaries with it.
+
+	secondary = &fakeConnection{id: device1, model: m}
+	m.AddSecondaryConnection(secondary)
+	primary.Close(closeErr)
+	if !secondary.Closed() {
+		t.Error("expected secondary connection to be closed along with the primary")
+	}
+	if l := len(m.DeviceConnections(device1)); l != 0 {
+		t.Errorf("expected no connections, got %d", l)
+	}
+}
+
+func TestSecondaryConnectionWithoutPrimary(t *testing.T) {
+	m := setupModel(t, defaultCfgWrapper)
+	defer cleanupModel(m)
+
+	secondary := &fakeConnection{id: device1, model: m}
+	secondary.closeFn = func(error) { secondary.closed = true }
+	m.AddSecondaryConnection(secondary)
+
+	if !secondary.Closed() {
+		t.Error("expected secondary connection without primary to be closed")
+	}
+	if l := len(m.DeviceConnections(device1)); l != 0 {
+		t.Errorf("expected no connections, got %d", l)
+	}
+}
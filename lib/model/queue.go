@@ -7,7 +7,10 @@
 package model
 
 import (
+	"path"
+	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/rand"
@@ -17,7 +20,11 @@ import (
 type jobQueue struct {
 	progress []string
 	queued   []jobQueueEntry
-	mut      sync.Mutex
+	// Files someone is waiting for, and the generation (number of resets)
+	// when they were prioritized.
+	prioritized map[string]int
+	gen         int
+	mut         sync.Mutex
 }
 
 type jobQueueEntry struct {
@@ -28,7 +35,8 @@ type jobQueueEntry struct {
 
 func newJobQueue() *jobQueue {
 	return &jobQueue{
-		mut: sync.NewMutex(),
+		prioritized: make(map[string]int),
+		mut:         sync.NewMutex(),
 	}
 }
 
@@ -57,6 +65,47 @@ func (q *jobQueue) BringToFront(filename string) {
 	q.mut.Lock()
 	defer q.mut.Unlock()
 
+	q.bringToFrontLocked(filename)
+}
+
+// Prioritize brings the file to the front and marks it as prioritized, so
+// that it stays ahead of other files when the queue is sorted, and its
+// blocks are requested with priority once it is being pulled. If the file
+// isn't queued yet, that happens when it is, during the current or next
+// pull.
+func (q *jobQueue) Prioritize(filename string) {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	q.prioritized[filename] = q.gen
+	q.bringToFrontLocked(filename)
+}
+
+func (q *jobQueue) Prioritized(filename string) bool {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	_, ok := q.prioritized[filename]
+	return ok
+}
+
+// SortPrioritized moves the prioritized files to the front, keeping the
+// order otherwise.
+func (q *jobQueue) SortPrioritized() {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	if len(q.prioritized) == 0 {
+		return
+	}
+	sort.SliceStable(q.queued, func(a, b int) bool {
+		_, aPrio := q.prioritized[q.queued[a].name]
+		_, bPrio := q.prioritized[q.queued[b].name]
+		return aPrio && !bPrio
+	})
+}
+
+func (q *jobQueue) bringToFrontLocked(filename string) {
 	for i, cur := range q.queued {
 		if cur.name == filename {
 			if i > 0 {
@@ -75,6 +124,8 @@ func (q *jobQueue) Done(file string) {
 	q.mut.Lock()
 	defer q.mut.Unlock()
 
+	delete(q.prioritized, file)
+
 	for i := range q.progress {
 		if q.progress[i] == file {
 			copy(q.progress[i:], q.progress[i+1:])
@@ -138,6 +189,14 @@ func (q *jobQueue) Reset() {
 	defer q.mut.Unlock()
 	q.progress = nil
 	q.queued = nil
+	// Files prioritized during this pull may not have been part of it, but
+	// those that weren't part of the next one either aren't needed.
+	for file, gen := range q.prioritized {
+		if gen < q.gen {
+			delete(q.prioritized, file)
+		}
+	}
+	q.gen++
 }
 
 func (q *jobQueue) lenQueued() int {
@@ -152,6 +211,15 @@ func (q *jobQueue) lenProgress() int {
 	return len(q.progress)
 }
 
+func (q *jobQueue) SortAlphabetic() {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	sort.Slice(q.queued, func(a, b int) bool {
+		return q.queued[a].name < q.queued[b].name
+	})
+}
+
 func (q *jobQueue) SortSmallestFirst() {
 	q.mut.Lock()
 	defer q.mut.Unlock()
@@ -180,6 +248,44 @@ func (q *jobQueue) SortNewestFirst() {
 	sort.Sort(sort.Reverse(oldestFirst(q.queued)))
 }
 
+// SortByPatterns moves the files matching the glob patterns to the front,
+// those matching the first pattern first and so on, keeping the order
+// within each group. Patterns without a slash match the file name, others
+// the whole path.
+func (q *jobQueue) SortByPatterns(patterns []string) {
+	if len(patterns) == 0 {
+		return
+	}
+
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	ranks := make(map[string]int, len(q.queued))
+	for _, e := range q.queued {
+		ranks[e.name] = patternRank(e.name, patterns)
+	}
+	sort.SliceStable(q.queued, func(a, b int) bool {
+		return ranks[q.queued[a].name] < ranks[q.queued[b].name]
+	})
+}
+
+// patternRank returns the index of the first pattern matching the file, or
+// the number of patterns if none does.
+func patternRank(name string, patterns []string) int {
+	name = filepath.ToSlash(name)
+	base := path.Base(name)
+	for i, pattern := range patterns {
+		subject := name
+		if !strings.Contains(pattern, "/") {
+			subject = base
+		}
+		if ok, _ := path.Match(pattern, subject); ok {
+			return i
+		}
+	}
+	return len(patterns)
+}
+
 // The usual sort.Interface boilerplate
 
 type smallestFirst []jobQueueEntry
@@ -8,6 +8,7 @@ package model
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -161,6 +162,45 @@ func TestBringToFront(t *testing.T) {
 	}
 }
 
+func TestPrioritize(t *testing.T) {
+	q := newJobQueue()
+	q.Push("f1", 0, time.Time{})
+	q.Push("f2", 0, time.Time{})
+	q.Push("f3", 0, time.Time{})
+
+	q.Prioritize("f3")
+	q.Prioritize("f5") // not queued yet
+	q.Push("f4", 0, time.Time{})
+	q.Push("f5", 0, time.Time{})
+
+	// Prioritized files stay in front when sorting.
+	q.SortAlphabetic()
+	q.SortPrioritized()
+	_, queued, _ := q.Jobs(1, 100)
+	if diff, equal := messagediff.PrettyDiff([]string{"f3", "f5", "f1", "f2", "f4"}, queued); !equal {
+		t.Errorf("Order does not match. Diff:\n%s", diff)
+	}
+
+	n, _ := q.Pop()
+	if !q.Prioritized(n) {
+		t.Errorf("%s should be prioritized", n)
+	}
+	q.Done(n)
+	if q.Prioritized(n) {
+		t.Errorf("%s should no longer be prioritized when done", n)
+	}
+
+	// Files that aren't pulled expire after the next pull.
+	q.Reset()
+	if !q.Prioritized("f5") {
+		t.Error("f5 should still be prioritized")
+	}
+	q.Reset()
+	if q.Prioritized("f5") {
+		t.Error("f5 should no longer be prioritized")
+	}
+}
+
 func TestShuffle(t *testing.T) {
 	q := newJobQueue()
 	q.Push("f1", 0, time.Time{})
@@ -281,6 +321,32 @@ func BenchmarkJobQueuePushPopDone10k(b *testing.B) {
 
 }
 
+func TestSortByPatterns(t *testing.T) {
+	q := newJobQueue()
+	q.Push("b.raw", 0, time.Unix(10, 0))
+	q.Push(filepath.Join("dcim", "a.raw"), 0, time.Unix(30, 0))
+	q.Push(filepath.Join("dcim", "b.xmp"), 0, time.Unix(20, 0))
+	q.Push("c.xmp", 0, time.Unix(40, 0))
+	q.Push("d.txt", 0, time.Unix(50, 0))
+
+	q.SortNewestFirst()
+	q.SortByPatterns([]string{"*.xmp", "dcim/*"})
+
+	_, actual, _ := q.Jobs(1, 100)
+	expected := []string{"c.xmp", filepath.Join("dcim", "b.xmp"), filepath.Join("dcim", "a.raw"), "d.txt", "b.raw"}
+	if diff, equal := messagediff.PrettyDiff(expected, actual); !equal {
+		t.Errorf("SortByPatterns() diff:\n%s", diff)
+	}
+
+	q.SortAlphabetic()
+
+	_, actual, _ = q.Jobs(1, 100)
+	expected = []string{"b.raw", "c.xmp", "d.txt", filepath.Join("dcim", "a.raw"), filepath.Join("dcim", "b.xmp")}
+	if diff, equal := messagediff.PrettyDiff(expected, actual); !equal {
+		t.Errorf("SortAlphabetic() diff:\n%s", diff)
+	}
+}
+
 func TestQueuePagination(t *testing.T) {
 	q := newJobQueue()
 	// Ten random actions
@@ -0,0 +1,122 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+)
+
+const removableStorageInterval = 10 * time.Second
+
+// removableStorage pauses the folders on removable storage while it's gone,
+// as told by their path or marker missing, and resumes them once it's back.
+// Which folders it paused is kept in the database, so that it doesn't
+// resume folders paused by the user, also across restarts.
+type removableStorage struct {
+	cfg  config.Wrapper
+	misc *db.NamespacedKV
+}
+
+func newRemovableStorage(cfg config.Wrapper, ldb *db.Lowlevel) *removableStorage {
+	return &removableStorage{
+		cfg:  cfg,
+		misc: db.NewMiscDataNamespace(ldb),
+	}
+}
+
+func (r *removableStorage) String() string {
+	return "removableStorage"
+}
+
+func (r *removableStorage) serve(ctx context.Context) error {
+	ticker := time.NewTicker(removableStorageInterval)
+	defer ticker.Stop()
+	for {
+		r.check()
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *removableStorage) check() {
+	pause := make(map[string]bool)
+	for _, fcfg := range r.cfg.FolderList() {
+		key := removableStorageKey(fcfg.ID)
+		paused, _, err := r.misc.Bool(key)
+		if err != nil {
+			l.Debugln("Checking removable storage:", err)
+			continue
+		}
+		if paused && (!fcfg.Paused || !fcfg.RemovableStorage) {
+			// Resumed, or no longer on removable storage, in the meantime.
+			r.misc.Delete(key)
+			paused = false
+		}
+		if !fcfg.RemovableStorage {
+			continue
+		}
+
+		unavailable := isStorageUnavailable(fcfg)
+		switch {
+		case unavailable && !fcfg.Paused:
+			l.Infof("Pausing folder %s while its storage is unavailable", fcfg.Description())
+			pause[fcfg.ID] = true
+		case !unavailable && paused:
+			l.Infof("Resuming folder %s as its storage is available again", fcfg.Description())
+			pause[fcfg.ID] = false
+		}
+	}
+	if len(pause) == 0 {
+		return
+	}
+
+	// Remembering what we pause comes first, so that it's resumed even
+	// if we don't get around to it now. A pause that fails to happen
+	// is forgotten on the next check.
+	for id, p := range pause {
+		if p {
+			r.misc.PutBool(removableStorageKey(id), true)
+		}
+	}
+
+	waiter, err := r.cfg.Modify(func(cfg *config.Configuration) {
+		for i := range cfg.Folders {
+			if p, ok := pause[cfg.Folders[i].ID]; ok {
+				cfg.Folders[i].Paused = p
+			}
+		}
+	})
+	if err != nil {
+		l.Warnln("Pausing or resuming folders on removable storage:", err)
+		return
+	}
+	waiter.Wait()
+
+	for id, p := range pause {
+		if !p {
+			r.misc.Delete(removableStorageKey(id))
+		}
+	}
+}
+
+// isStorageUnavailable returns whether the folder is missing its path or
+// marker, i.e. the storage is unplugged or not mounted.
+func isStorageUnavailable(fcfg config.FolderConfiguration) bool {
+	err := fcfg.CheckPath()
+	return err == config.ErrPathMissing || err == config.ErrMarkerMissing
+}
+
+func removableStorageKey(folder string) string {
+	return "removableStoragePaused-" + folder
+}
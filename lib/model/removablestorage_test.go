@@ -0,0 +1,75 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+)
+
+func TestRemovableStorage(t *testing.T) {
+	fcfg := testFolderConfigTmp()
+	defer os.RemoveAll(fcfg.Path)
+	fcfg.RemovableStorage = true
+	must(t, fcfg.CreateMarker())
+
+	w, cancel := createTmpWrapper(config.Configuration{
+		Version: config.CurrentVersion,
+		Folders: []config.FolderConfiguration{fcfg},
+	})
+	defer cancel()
+	defer os.Remove(w.ConfigPath())
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	must(t, err)
+	defer ldb.Close()
+	r := newRemovableStorage(w, ldb)
+
+	paused := func() bool {
+		t.Helper()
+		cfg, ok := w.Folder(fcfg.ID)
+		if !ok {
+			t.Fatal("folder is gone")
+		}
+		return cfg.Paused
+	}
+	marker := filepath.Join(fcfg.Path, config.DefaultMarkerName)
+
+	r.check()
+	if paused() {
+		t.Fatal("paused while the storage is there")
+	}
+
+	// Unplugged.
+	must(t, os.RemoveAll(marker))
+	r.check()
+	if !paused() {
+		t.Fatal("not paused while the storage is gone")
+	}
+
+	// Plugged in again.
+	must(t, fcfg.CreateMarker())
+	r.check()
+	if paused() {
+		t.Fatal("not resumed once the storage is back")
+	}
+
+	// Folders paused by the user stay so.
+	_, err = w.Modify(func(cfg *config.Configuration) {
+		cfg.Folders[0].Paused = true
+	})
+	must(t, err)
+	r.check()
+	if !paused() {
+		t.Fatal("resumed a folder paused by the user")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -104,7 +105,7 @@ func TestSymlinkTraversalRead(t *testing.T) {
 	<-done
 
 	// Request a file by traversing the symlink
-	res, err := m.Request(device1, "default", "symlink/requests_test.go", 0, 10, 0, nil, 0, false)
+	res, err := m.Request(device1, "default", "symlink/requests_test.go", 0, 10, 0, nil, 0, 0, false)
 	if err == nil || res != nil {
 		t.Error("Managed to traverse symlink")
 	}
@@ -518,7 +519,7 @@ func TestRescanIfHaveInvalidContent(t *testing.T) {
 		t.Fatalf("unexpected weak hash: %d != 103547413", f.Blocks[0].WeakHash)
 	}
 
-	res, err := m.Request(device1, "default", "foo", 0, int32(len(payload)), 0, f.Blocks[0].Hash, f.Blocks[0].WeakHash, false)
+	res, err := m.Request(device1, "default", "foo", 0, int32(len(payload)), 0, f.Blocks[0].Hash, f.BlockHashAlgorithm, f.Blocks[0].WeakHash, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -532,7 +533,7 @@ func TestRescanIfHaveInvalidContent(t *testing.T) {
 
 	must(t, writeFile(tfs, "foo", payload, 0777))
 
-	_, err = m.Request(device1, "default", "foo", 0, int32(len(payload)), 0, f.Blocks[0].Hash, f.Blocks[0].WeakHash, false)
+	_, err = m.Request(device1, "default", "foo", 0, int32(len(payload)), 0, f.Blocks[0].Hash, f.BlockHashAlgorithm, f.Blocks[0].WeakHash, false)
 	if err == nil {
 		t.Fatalf("expected failure")
 	}
@@ -1296,6 +1297,60 @@ func TestRequestIndexSenderPause(t *testing.T) {
 	}
 }
 
+func TestRequestIndexSenderContinuesOnClusterConfig(t *testing.T) {
+	// A cluster config with an outdated sequence for us must not make the
+	// index sender repeat what it already sent on the same connection.
+
+	done := make(chan struct{})
+	defer close(done)
+
+	m, fc, fcfg, wcfgCancel := setupModelWithConnection(t)
+	defer wcfgCancel()
+	tfs := fcfg.Filesystem()
+	defer cleanupModelAndRemoveDir(m, tfs.URI())
+
+	indexChan := make(chan []protocol.FileInfo)
+	fc.mut.Lock()
+	fc.indexFn = func(ctx context.Context, folder string, fs []protocol.FileInfo) {
+		select {
+		case indexChan <- fs:
+		case <-done:
+		case <-ctx.Done():
+		}
+	}
+	fc.mut.Unlock()
+
+	files := genFiles(3)
+	localIndexUpdate(m, fcfg.ID, files)
+	for received := 0; received < len(files); {
+		select {
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out before receiving index")
+		case fs := <-indexChan:
+			received += len(fs)
+		}
+	}
+
+	// The remote announces our index ID, but hasn't seen anything yet.
+	m.fmut.RLock()
+	fset := m.folderFiles[fcfg.ID]
+	m.fmut.RUnlock()
+	cc := basicClusterConfig(device1, myID, fcfg.ID)
+	cc.Folders[0].Devices[1].IndexID = fset.IndexID(protocol.LocalDeviceID)
+	m.ClusterConfig(device1, cc)
+
+	newFile := genFiles(4)[3]
+	localIndexUpdate(m, fcfg.ID, []protocol.FileInfo{newFile})
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out before receiving index")
+	case fs := <-indexChan:
+		if len(fs) != 1 || fs[0].Name != newFile.Name {
+			t.Errorf("Expected index update with only %v, got %v", newFile.Name, fs)
+		}
+	}
+}
+
 func TestRequestIndexSenderClusterConfigBeforeStart(t *testing.T) {
 	w, fcfg, wCancel := tmpDefaultWrapper()
 	defer wCancel()
@@ -1426,3 +1481,141 @@ func TestRequestReceiveEncryptedLocalNoSend(t *testing.T) {
 		t.Fatal("timed out before receiving index")
 	}
 }
+
+func TestBLAKE2bIndexInvalid(t *testing.T) {
+	// Files hashed with BLAKE2b are announced as invalid to devices that
+	// don't support it, as they couldn't verify the blocks.
+
+	for _, features := range [][]string{nil, {protocol.FeatureBLAKE2b}} {
+		w, fcfg, wCancel := tmpDefaultWrapper()
+		tfs := fcfg.Filesystem()
+		fcfg.BlockHashAlgorithm = protocol.BlockHashAlgorithmBLAKE2b
+		setFolder(t, w, fcfg)
+		must(t, writeFile(tfs, "file", []byte("some contents"), 0644))
+
+		m := setupModel(t, w)
+
+		indexChan := make(chan []protocol.FileInfo, 1)
+		done := make(chan struct{})
+		fc := &fakeConnection{
+			id:    device1,
+			model: m,
+			indexFn: func(_ context.Context, _ string, fs []protocol.FileInfo) {
+				select {
+				case indexChan <- fs:
+				case <-done:
+				}
+			},
+		}
+		m.AddConnection(fc, protocol.Hello{Features: features})
+		m.ClusterConfig(device1, protocol.ClusterConfig{
+			Folders: []protocol.Folder{
+				{
+					ID: "default",
+					Devices: []protocol.Device{
+						{ID: myID},
+						{ID: device1},
+					},
+				},
+			},
+		})
+
+		select {
+		case fs := <-indexChan:
+			if len(fs) != 1 {
+				t.Fatal("Expected index with one file, got", fs)
+			}
+			if fs[0].BlockHashAlgorithm != protocol.BlockHashAlgorithmBLAKE2b {
+				t.Errorf("Expected a BLAKE2b block list, got %v", fs[0].BlockHashAlgorithm)
+			}
+			if invalid := features == nil; fs[0].IsInvalid() != invalid {
+				t.Errorf("Expected invalid to be %v with features %v", invalid, features)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out before receiving index")
+		}
+
+		close(done)
+		cleanupModelAndRemoveDir(m, tfs.URI())
+		wCancel()
+	}
+}
+
+func TestRequestMassDeletionHeldBack(t *testing.T) {
+	w, fcfg, wCancel := tmpDefaultWrapper()
+	defer wCancel()
+	tfs := fcfg.Filesystem()
+	fcfg.MassChangeThresholdPct = 50
+	setFolder(t, w, fcfg)
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModelAndRemoveDir(m, tfs.URI())
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		for timeout := time.After(10 * time.Second); !cond(); {
+			select {
+			case <-time.After(10 * time.Millisecond):
+			case <-timeout:
+				t.Fatal("timed out waiting for", what)
+			}
+		}
+	}
+	exists := func(name string) bool {
+		_, err := tfs.Lstat(name)
+		return err == nil
+	}
+
+	var names []string
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("file%d", i)
+		names = append(names, name)
+		fc.addFile(name, 0644, protocol.FileInfoTypeFile, []byte(name))
+	}
+	fc.sendIndexUpdate()
+	// The files must be in the database, not just on disk, for the
+	// numbers below to be exact.
+	waitFor("files to be pulled", func() bool {
+		for _, name := range names {
+			if fi, ok := m.CurrentFolderFile("default", name); !ok || fi.IsDeleted() || !exists(name) {
+				return false
+			}
+		}
+		return true
+	})
+
+	// Deleting three quarters of the files is held back.
+	for _, name := range names[:15] {
+		fc.deleteFile(name)
+	}
+	fc.sendIndexUpdate()
+	waitFor("folder to hold back changes", func() bool {
+		state, _, _ := m.State("default")
+		return state == FolderQuarantined.String()
+	})
+	for _, name := range names {
+		if !exists(name) {
+			t.Errorf("%v was deleted before confirmation", name)
+		}
+	}
+	if mc := m.HeldMassChange("default"); mc == nil || *mc != (MassChange{Deleted: 15, Total: 20}) {
+		t.Errorf("Unexpected held change %+v", mc)
+	}
+
+	must(t, m.ConfirmMassChange("default"))
+	waitFor("files to be deleted", func() bool {
+		for _, name := range names[:15] {
+			if exists(name) {
+				return false
+			}
+		}
+		return true
+	})
+	if mc := m.HeldMassChange("default"); mc != nil {
+		t.Errorf("Unexpected held change %+v after confirmation", mc)
+	}
+	for _, name := range names[15:] {
+		if !exists(name) {
+			t.Errorf("%v was deleted", name)
+		}
+	}
+}
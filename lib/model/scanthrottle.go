@@ -0,0 +1,114 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/scanner"
+)
+
+const (
+	// The combined rate at which folders are read while scanning with the
+	// low impact profile.
+	lowImpactScanBytesPerSecond = 10 << 20
+	scanThrottleBurstSize       = 4 * 128 << 10
+)
+
+// lowImpactScans is the low impact profile for scans, which can be switched
+// on and off at any time. As a scanner.Backoff it lets only one hasher per
+// folder run while enabled, and otherwise defers to the given backoff.
+type lowImpactScans struct {
+	enabled int32 // accessed atomically
+	limiter *rate.Limiter
+	backoff scanner.Backoff
+}
+
+func newLowImpactScans(enabled bool, backoff scanner.Backoff) *lowImpactScans {
+	s := &lowImpactScans{
+		limiter: rate.NewLimiter(lowImpactScanBytesPerSecond, scanThrottleBurstSize),
+		backoff: backoff,
+	}
+	s.set(enabled)
+	return s
+}
+
+func (s *lowImpactScans) set(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&s.enabled, v)
+}
+
+func (s *lowImpactScans) Enabled() bool {
+	return atomic.LoadInt32(&s.enabled) == 1
+}
+
+func (s *lowImpactScans) Allowed(hashers int) int {
+	if s.Enabled() {
+		return 1
+	}
+	return s.backoff.Allowed(hashers)
+}
+
+// scanThrottle implements scanner.Throttle with the limits of a folder, and
+// those of the low impact profile while that is enabled.
+type scanThrottle struct {
+	bytes     *rate.Limiter // nil when unlimited
+	ops       *rate.Limiter // nil when unlimited
+	lowImpact *lowImpactScans
+}
+
+func newScanThrottle(cfg config.FolderConfiguration, lowImpact *lowImpactScans) *scanThrottle {
+	t := &scanThrottle{lowImpact: lowImpact}
+	if cfg.ScanMaxKbps > 0 {
+		t.bytes = rate.NewLimiter(rate.Limit(cfg.ScanMaxKbps)*1024, scanThrottleBurstSize)
+	}
+	if cfg.ScanMaxIOPS > 0 {
+		t.ops = rate.NewLimiter(rate.Limit(cfg.ScanMaxIOPS), 1)
+	}
+	return t
+}
+
+func (t *scanThrottle) Wait(ctx context.Context, n int) error {
+	if t.ops != nil {
+		if err := t.ops.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := waitBytes(ctx, t.bytes, n); err != nil {
+		return err
+	}
+	if t.lowImpact.Enabled() {
+		return waitBytes(ctx, t.lowImpact.limiter, n)
+	}
+	return nil
+}
+
+// waitBytes waits for the limiter to allow n bytes, in parts no larger than
+// its burst size as reads can be larger than that.
+func waitBytes(ctx context.Context, lim *rate.Limiter, n int) error {
+	if lim == nil {
+		return nil
+	}
+	for n > 0 {
+		part := n
+		if burst := lim.Burst(); part > burst {
+			part = burst
+		}
+		if err := lim.WaitN(ctx, part); err != nil {
+			return err
+		}
+		n -= part
+	}
+	return nil
+}
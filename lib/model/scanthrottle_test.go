@@ -0,0 +1,58 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+)
+
+type fixedBackoff int
+
+func (b fixedBackoff) Allowed(int) int {
+	return int(b)
+}
+
+func TestScanThrottle(t *testing.T) {
+	lowImpact := newLowImpactScans(false, fixedBackoff(4))
+	throttle := newScanThrottle(config.FolderConfiguration{ScanMaxKbps: 1 << 10}, lowImpact)
+
+	if allowed := lowImpact.Allowed(8); allowed != 4 {
+		t.Errorf("expected the other backoff to decide, got %d hashers", allowed)
+	}
+
+	// Reads larger than the burst size are fine, and take the time
+	// they should at 1 MiB/s, after the first burst.
+	ctx := context.Background()
+	t0 := time.Now()
+	if err := throttle.Wait(ctx, scanThrottleBurstSize+128<<10); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(t0); d < 100*time.Millisecond {
+		t.Errorf("read of more than the burst size went through in %v", d)
+	}
+
+	lowImpact.set(true)
+	if allowed := lowImpact.Allowed(8); allowed != 1 {
+		t.Errorf("expected one hasher with low impact scans, got %d", allowed)
+	}
+
+	// The low impact limit applies on top of the folder's.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	unlimited := newScanThrottle(config.FolderConfiguration{}, lowImpact)
+	if err := unlimited.Wait(cancelled, 2*lowImpactScanBytesPerSecond); err == nil {
+		t.Error("expected waiting for the low impact limit to fail when cancelled")
+	}
+	lowImpact.set(false)
+	if err := unlimited.Wait(cancelled, 2*lowImpactScanBytesPerSecond); err != nil {
+		t.Error("expected no limit, got", err)
+	}
+}
@@ -46,30 +46,37 @@ type sharedPullerState struct {
 	updated           time.Time       // Time when any of the counters above were last updated
 	closed            bool            // True if the file has been finalClosed.
 	available         []int           // Indexes of the blocks that are available in the temporary file
+	announceAvailable bool            // Whether to track available blocks, which other devices look up by offset / block size
 	availableUpdated  time.Time       // Time when list of available blocks was last updated
 	mut               sync.RWMutex    // Protects the above
 }
 
 func newSharedPullerState(file protocol.FileInfo, fs fs.Filesystem, folderID, tempName string, blocks []protocol.BlockInfo, reused []int, ignorePerms, hasCurFile bool, curFile protocol.FileInfo, sparse bool, fsync bool) *sharedPullerState {
+	announceAvailable := file.HasFixedSizeBlocks()
+	var available []int
+	if announceAvailable {
+		available = reused
+	}
 	return &sharedPullerState{
-		file:             file,
-		fs:               fs,
-		folder:           folderID,
-		tempName:         tempName,
-		realName:         file.Name,
-		copyTotal:        len(blocks),
-		copyNeeded:       len(blocks),
-		reused:           len(reused),
-		updated:          time.Now(),
-		available:        reused,
-		availableUpdated: time.Now(),
-		ignorePerms:      ignorePerms,
-		hasCurFile:       hasCurFile,
-		curFile:          curFile,
-		mut:              sync.NewRWMutex(),
-		sparse:           sparse,
-		fsync:            fsync,
-		created:          time.Now(),
+		file:              file,
+		fs:                fs,
+		folder:            folderID,
+		tempName:          tempName,
+		realName:          file.Name,
+		copyTotal:         len(blocks),
+		copyNeeded:        len(blocks),
+		reused:            len(reused),
+		updated:           time.Now(),
+		available:         available,
+		announceAvailable: announceAvailable,
+		availableUpdated:  time.Now(),
+		ignorePerms:       ignorePerms,
+		hasCurFile:        hasCurFile,
+		curFile:           curFile,
+		mut:               sync.NewRWMutex(),
+		sparse:            sparse,
+		fsync:             fsync,
+		created:           time.Now(),
 	}
 }
 
@@ -101,6 +108,14 @@ func (w *lockedWriterAt) WriteAt(p []byte, off int64) (n int, err error) {
 	return w.fd.WriteAt(p, off)
 }
 
+// PunchHole zeroes the given range, deallocating it, under the same lock as
+// WriteAt.
+func (w *lockedWriterAt) PunchHole(offset, length int64) error {
+	w.mut.RLock()
+	defer w.mut.RUnlock()
+	return fs.PunchHole(w.fd, offset, length)
+}
+
 // SyncClose ensures that no more writes are happening before going ahead and
 // syncing and closing the fd, thus needs to acquire a write-lock.
 func (w *lockedWriterAt) SyncClose(fsync bool) error {
@@ -254,8 +269,10 @@ func (s *sharedPullerState) copyDone(block protocol.BlockInfo) {
 	s.mut.Lock()
 	s.copyNeeded--
 	s.updated = time.Now()
-	s.available = append(s.available, int(block.Offset/int64(s.file.BlockSize())))
-	s.availableUpdated = time.Now()
+	if s.announceAvailable {
+		s.available = append(s.available, int(block.Offset/int64(s.file.BlockSize())))
+		s.availableUpdated = time.Now()
+	}
 	l.Debugln("sharedPullerState", s.folder, s.file.Name, "copyNeeded ->", s.copyNeeded)
 	s.mut.Unlock()
 }
@@ -290,8 +307,10 @@ func (s *sharedPullerState) pullDone(block protocol.BlockInfo) {
 	s.mut.Lock()
 	s.pullNeeded--
 	s.updated = time.Now()
-	s.available = append(s.available, int(block.Offset/int64(s.file.BlockSize())))
-	s.availableUpdated = time.Now()
+	if s.announceAvailable {
+		s.available = append(s.available, int(block.Offset/int64(s.file.BlockSize())))
+		s.availableUpdated = time.Now()
+	}
 	l.Debugln("sharedPullerState", s.folder, s.file.Name, "pullNeeded done ->", s.pullNeeded)
 	s.mut.Unlock()
 }
@@ -0,0 +1,184 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/scanner"
+)
+
+// The problems verification finds with local files.
+const (
+	// The contents don't match the index, while size and modification
+	// time do. This is what a scan can't notice, e.g. bit rot.
+	VerifyCorrupted = "corrupted"
+	// The file changed since the last scan, which the next one will pick
+	// up.
+	VerifyModified = "modified"
+	VerifyMissing  = "missing"
+	VerifyError    = "error"
+)
+
+// VerifyReport is the outcome of verifying the local data of a folder.
+type VerifyReport struct {
+	Files    int           `json:"files"`
+	Bytes    int64         `json:"bytes"`
+	Problems []VerifyIssue `json:"problems"`
+}
+
+// VerifyIssue is a local file that doesn't match the index.
+type VerifyIssue struct {
+	Name    string `json:"name"`
+	Problem string `json:"problem"`
+	// Indexes of the blocks that don't match, when corrupted.
+	CorruptBlocks []int  `json:"corruptBlocks,omitempty"`
+	Error         string `json:"error,omitempty"`
+	// Whether the corrupted blocks are pulled again from other devices.
+	Repairing bool `json:"repairing"`
+}
+
+// Verify reads and hashes all local files, and compares them to the index.
+// With repair, corrupted files that other devices have the same version of
+// are pulled again, which only fetches the corrupted blocks as the copier
+// verifies those taken from the local file.
+func (f *folder) Verify(repair bool) (VerifyReport, error) {
+	<-f.initialScanFinished
+	var report VerifyReport
+	err := f.doInSync(func() error {
+		var err error
+		report, err = f.verify(repair)
+		return err
+	})
+	return report, err
+}
+
+func (f *folder) verify(repair bool) (VerifyReport, error) {
+	report := VerifyReport{Problems: []VerifyIssue{}}
+	if err := f.getHealthErrorWithoutIgnores(); err != nil {
+		return report, err
+	}
+
+	f.setState(FolderScanWaiting)
+	defer f.setState(FolderIdle)
+
+	if err := f.ioLimiter.takeWithPriority(f.ctx, 1, f.Priority); err != nil {
+		return report, err
+	}
+	defer f.ioLimiter.give(1)
+
+	f.setState(FolderScanning)
+	f.log.Infof("Verifying local data of folder %v", f.Description())
+
+	snap := f.fset.Snapshot()
+	defer snap.Release()
+
+	repairs := 0
+	snap.WithHave(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+		if f.ctx.Err() != nil {
+			return false
+		}
+		fi := intf.(protocol.FileInfo)
+		if fi.Type != protocol.FileInfoTypeFile || fi.IsDeleted() || fi.IsInvalid() {
+			return true
+		}
+		report.Files++
+		report.Bytes += fi.Size
+
+		issue := f.verifyFile(fi)
+		if issue == nil {
+			return true
+		}
+		if issue.Problem == VerifyCorrupted {
+			f.log.Warnf("Verifying %v: %s is corrupted in %d blocks", f.Description(), fi.Name, len(issue.CorruptBlocks))
+			if repair && f.canRepair(fi, snap) {
+				f.repairs[fi.Name] = fi.Sequence
+				repairs++
+				issue.Repairing = true
+			}
+		}
+		report.Problems = append(report.Problems, *issue)
+		return true
+	})
+	if err := f.ctx.Err(); err != nil {
+		return report, err
+	}
+
+	if repairs > 0 {
+		f.SchedulePull()
+	}
+	f.log.Infof("Verified %d files of folder %v, %d problems found", report.Files, f.Description(), len(report.Problems))
+	return report, nil
+}
+
+// verifyFile returns what is wrong with the local file, if anything.
+func (f *folder) verifyFile(fi protocol.FileInfo) *VerifyIssue {
+	stat, err := f.mtimefs.Lstat(fi.Name)
+	if fs.IsNotExist(err) {
+		return &VerifyIssue{Name: fi.Name, Problem: VerifyMissing}
+	} else if err != nil {
+		return &VerifyIssue{Name: fi.Name, Problem: VerifyError, Error: err.Error()}
+	}
+	statItem, err := scanner.CreateFileInfo(stat, fi.Name, f.mtimefs)
+	if err != nil {
+		return &VerifyIssue{Name: fi.Name, Problem: VerifyError, Error: err.Error()}
+	}
+	if !statItem.IsEquivalentOptional(fi, f.modTimeWindow, f.IgnorePerms, true, protocol.LocalAllFlags) {
+		return &VerifyIssue{Name: fi.Name, Problem: VerifyModified}
+	}
+
+	fd, err := f.mtimefs.Open(fi.Name)
+	if err != nil {
+		return &VerifyIssue{Name: fi.Name, Problem: VerifyError, Error: err.Error()}
+	}
+	defer fd.Close()
+
+	var corrupt []int
+	for i, block := range fi.Blocks {
+		if err := f.scanThrottle.Wait(f.ctx, int(block.Size)); err != nil {
+			return &VerifyIssue{Name: fi.Name, Problem: VerifyError, Error: err.Error()}
+		}
+		buf := protocol.BufferPool.Get(int(block.Size))
+		n, err := fd.ReadAt(buf, block.Offset)
+		if err != nil && err != io.EOF {
+			protocol.BufferPool.Put(buf)
+			return &VerifyIssue{Name: fi.Name, Problem: VerifyError, Error: err.Error()}
+		}
+		hash := scanner.HashBlock(fi.BlockHashAlgorithm, buf[:n])
+		protocol.BufferPool.Put(buf)
+		if !bytes.Equal(hash, block.Hash) {
+			corrupt = append(corrupt, i)
+		}
+	}
+	if len(corrupt) == 0 {
+		return nil
+	}
+	return &VerifyIssue{Name: fi.Name, Problem: VerifyCorrupted, CorruptBlocks: corrupt}
+}
+
+// canRepair returns whether the folder pulls, and another device has the
+// same version of the file to pull it from.
+func (f *folder) canRepair(fi protocol.FileInfo, snap *db.Snapshot) bool {
+	if f.Type == config.FolderTypeSendOnly {
+		return false
+	}
+	global, ok := snap.GetGlobal(fi.Name)
+	if !ok || !global.Version.Equal(fi.Version) {
+		return false
+	}
+	for _, dev := range snap.Availability(fi.Name) {
+		if dev != protocol.LocalDeviceID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io/ioutil"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestVerifyLocalData(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupSRFolder(f, m, wcfgCancel)
+	ffs := f.Filesystem()
+
+	data := make([]byte, 3*protocol.MinBlockSize)
+	_, _ = rand.Read(data)
+	must(t, writeFile(ffs, "a", data, 0644))
+	must(t, f.scanSubdirs(nil))
+
+	snap := f.fset.Snapshot()
+	a, ok := snap.Get(protocol.LocalDeviceID, "a")
+	snap.Release()
+	if !ok || len(a.Blocks) != 3 {
+		t.Fatalf("Expected a scanned in three blocks, got %+v", a)
+	}
+
+	report, err := f.verify(false)
+	must(t, err)
+	if report.Files != 1 || report.Bytes != a.Size || len(report.Problems) != 0 {
+		t.Fatalf("Expected no problems, got %+v", report)
+	}
+
+	// Flip a bit in the second block, behind the scanner's back.
+	fd, err := ffs.OpenFile("a", fs.OptReadWrite, 0644)
+	must(t, err)
+	_, err = fd.WriteAt([]byte{data[protocol.MinBlockSize] ^ 1}, protocol.MinBlockSize)
+	must(t, err)
+	must(t, fd.Close())
+	must(t, f.mtimefs.Chtimes("a", a.ModTime(), a.ModTime()))
+
+	report, err = f.verify(false)
+	must(t, err)
+	if len(report.Problems) != 1 {
+		t.Fatalf("Expected one problem, got %+v", report)
+	}
+	if p := report.Problems[0]; p.Name != "a" || p.Problem != VerifyCorrupted || len(p.CorruptBlocks) != 1 || p.CorruptBlocks[0] != 1 || p.Repairing {
+		t.Fatalf("Unexpected problem %+v", p)
+	}
+
+	// Nobody else has it to repair it from.
+	report, err = f.verify(true)
+	must(t, err)
+	if report.Problems[0].Repairing {
+		t.Fatal("Expected no repair without another device having the file")
+	}
+
+	fc := addFakeConn(m, device1)
+	var requested []int64
+	fc.requestFn = func(_ context.Context, _, _ string, offset int64, size int, _ []byte, _ bool) ([]byte, error) {
+		requested = append(requested, offset)
+		return data[offset : offset+int64(size)], nil
+	}
+	m.Index(device1, f.ID, []protocol.FileInfo{a})
+	report, err = f.verify(true)
+	must(t, err)
+	if !report.Problems[0].Repairing {
+		t.Fatal("Expected a repair with device1 having the file")
+	}
+
+	// Nothing is announced until the file is repaired.
+	sent := func() []protocol.FileInfo {
+		t.Helper()
+		var files []protocol.FileInfo
+		snap := f.fset.Snapshot()
+		defer snap.Release()
+		snap.WithHaveSequence(a.Sequence+1, func(fi protocol.FileIntf) bool {
+			files = append(files, fi.(protocol.FileInfo))
+			return true
+		})
+		return files
+	}
+	if files := sent(); len(files) != 0 {
+		t.Fatalf("Expected nothing to be sent before the repair, got %v", files)
+	}
+
+	// Repairing fetches only the corrupted block, leaving the index as it
+	// was all along.
+	scanChan := make(chan string, 10)
+	if changed := f.pullerIteration(scanChan); changed != 1 {
+		t.Errorf("Expected one change, got %d", changed)
+	}
+	if len(requested) != 1 || requested[0] != protocol.MinBlockSize {
+		t.Errorf("Expected only the second block to be requested, got %v", requested)
+	}
+	if bs, err := ioutil.ReadAll(mustOpen(t, ffs, "a")); err != nil || !bytes.Equal(bs, data) {
+		t.Error("Expected a to be repaired, err:", err)
+	}
+	if files := sent(); len(files) != 0 {
+		t.Errorf("Expected nothing to be sent after the repair, got %v", files)
+	}
+	if cur, ok := m.CurrentFolderFile(f.ID, "a"); !ok || !cur.Version.Equal(a.Version) || !cur.BlocksEqual(a) || cur.IsInvalid() {
+		t.Errorf("Unexpected index entry %v for a", cur)
+	}
+	if changed := f.pullerIteration(scanChan); changed != 0 || len(f.repairs) != 0 {
+		t.Errorf("Expected the repair to be done, got %d changes and %v", changed, f.repairs)
+	}
+
+	// Changes since the last scan aren't corruption.
+	must(t, writeFile(ffs, "a", []byte("changed"), 0644))
+	report, err = f.verify(false)
+	must(t, err)
+	if len(report.Problems) != 1 || report.Problems[0].Problem != VerifyModified {
+		t.Fatalf("Expected a to be modified, got %+v", report)
+	}
+}
@@ -21,6 +21,8 @@ const (
 
 type Device interface {
 	ID() string
+	// Type is the port mapping protocol spoken by the device, e.g. "UPnP".
+	Type() string
 	GetLocalIPAddress() net.IP
 	AddPortMapping(ctx context.Context, protocol Protocol, internalPort, externalPort int, description string, duration time.Duration) (int, error)
 	GetExternalIPAddress(ctx context.Context) (net.IP, error)
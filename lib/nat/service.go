@@ -12,6 +12,7 @@ import (
 	"hash/fnv"
 	"math/rand"
 	"net"
+	"sort"
 	stdsync "sync"
 	"time"
 
@@ -29,7 +30,19 @@ type Service struct {
 
 	mappings []*Mapping
 	enabled  bool
-	mut      sync.RWMutex
+	// The NAT devices found last, and the last error acquiring or
+	// renewing a mapping on each.
+	nats      map[string]Device
+	natErrors map[string]error
+	mut       sync.RWMutex
+}
+
+// DeviceStatus describes a NAT device and the port mappings we hold on it.
+type DeviceStatus struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	Mappings []string `json:"mappings"`
+	Error    *string  `json:"error"`
 }
 
 func NewService(id protocol.DeviceID, cfg config.Wrapper) *Service {
@@ -37,6 +50,7 @@ func NewService(id protocol.DeviceID, cfg config.Wrapper) *Service {
 		id:               id,
 		cfg:              cfg,
 		processScheduled: make(chan struct{}, 1),
+		natErrors:        make(map[string]error),
 
 		mut: sync.NewRWMutex(),
 	}
@@ -58,6 +72,8 @@ func (s *Service) CommitConfiguration(from, to config.Configuration) bool {
 	} else if s.enabled && !to.Options.NATEnabled {
 		l.Debugln("Stopping NAT service")
 		s.enabled = false
+		s.nats = nil
+		s.natErrors = make(map[string]error)
 	}
 	s.mut.Unlock()
 	return true
@@ -144,6 +160,15 @@ func (s *Service) process(ctx context.Context) (int, time.Duration) {
 
 	nats := discoverAll(ctx, time.Duration(s.cfg.Options().NATRenewalM)*time.Minute, time.Duration(s.cfg.Options().NATTimeoutS)*time.Second)
 
+	s.mut.Lock()
+	s.nats = nats
+	for id := range s.natErrors {
+		if _, ok := nats[id]; !ok {
+			delete(s.natErrors, id)
+		}
+	}
+	s.mut.Unlock()
+
 	for _, mapping := range toRenew {
 		s.updateMapping(ctx, mapping, nats, true)
 	}
@@ -254,6 +279,7 @@ func (s *Service) verifyExistingMappings(ctx context.Context, mapping *Mapping,
 			l.Debugf("Renewing %s -> %s mapping on %s", mapping, address, id)
 
 			addr, err := s.tryNATDevice(ctx, nat, mapping.address.Port, address.Port, leaseTime)
+			s.setNATError(id, err)
 			if err != nil {
 				l.Debugf("Failed to renew %s -> mapping on %s", mapping, address, id)
 				mapping.removeAddress(id)
@@ -303,6 +329,7 @@ func (s *Service) acquireNewMappings(ctx context.Context, mapping *Mapping, nats
 		l.Debugf("Acquiring %s mapping on %s", mapping, id)
 
 		addr, err := s.tryNATDevice(ctx, nat, mapping.address.Port, 0, leaseTime)
+		s.setNATError(id, err)
 		if err != nil {
 			l.Debugf("Failed to acquire %s mapping on %s", mapping, id)
 			continue
@@ -371,6 +398,49 @@ findIP:
 	}, nil
 }
 
+func (s *Service) setNATError(id string, err error) {
+	s.mut.Lock()
+	if err != nil {
+		s.natErrors[id] = err
+	} else {
+		delete(s.natErrors, id)
+	}
+	s.mut.Unlock()
+}
+
+// Status returns the NAT devices found, sorted by ID, with the external
+// addresses mapped on each.
+func (s *Service) Status() []DeviceStatus {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	status := make([]DeviceStatus, 0, len(s.nats))
+	for id, natd := range s.nats {
+		st := DeviceStatus{
+			ID:       id,
+			Type:     natd.Type(),
+			Mappings: []string{},
+		}
+		for _, mapping := range s.mappings {
+			mapping.mut.RLock()
+			addr, ok := mapping.extAddresses[id]
+			mapping.mut.RUnlock()
+			if ok {
+				st.Mappings = append(st.Mappings, fmt.Sprintf("%s %s -> %s", mapping.protocol, addr, mapping.address))
+			}
+		}
+		if err, ok := s.natErrors[id]; ok {
+			errStr := err.Error()
+			st.Error = &errStr
+		}
+		status = append(status, st)
+	}
+	sort.Slice(status, func(a, b int) bool {
+		return status[a].ID < status[b].ID
+	})
+	return status
+}
+
 func (s *Service) String() string {
 	return fmt.Sprintf("nat.Service@%p", s)
 }
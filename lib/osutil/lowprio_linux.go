@@ -98,3 +98,20 @@ func SetLowPriority() error {
 	err := ioprioSet(ioprioClassBE, 5)
 	return errors.Wrap(err, "set I/O priority") // wraps nil as nil
 }
+
+// SetThreadLowPriority lowers the CPU and I/O scheduling priority of the
+// calling thread as far as it goes, leaving other threads alone. The
+// calling goroutine is expected to be locked to its thread.
+func SetThreadLowPriority() error {
+	// Linux being what it is (see above), process zero is the calling
+	// thread.
+	const (
+		threadSelf     = 0
+		lowestPriority = 19
+	)
+	if err := syscall.Setpriority(syscall.PRIO_PROCESS, threadSelf, lowestPriority); err != nil {
+		return errors.Wrap(err, "set niceness")
+	}
+	err := ioprioSet(ioprioClassIdle, 0)
+	return errors.Wrap(err, "set I/O priority") // wraps nil as nil
+}
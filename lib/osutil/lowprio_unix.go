@@ -32,3 +32,11 @@ func SetLowPriority() error {
 	err := syscall.Setpriority(syscall.PRIO_PROCESS, pidSelf, wantNiceLevel)
 	return errors.Wrap(err, "set niceness") // wraps nil as nil
 }
+
+// SetThreadLowPriority lowers the CPU and I/O scheduling priority of the
+// calling thread as far as it goes, leaving other threads alone. The
+// calling goroutine is expected to be locked to its thread.
+func SetThreadLowPriority() error {
+	// The priority is per process here.
+	return errors.New("not supported per thread")
+}
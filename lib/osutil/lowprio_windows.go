@@ -22,6 +22,9 @@ const (
 	processModeBackgroundBegin = 0x00100000
 	processModeBackgroundEnd   = 0x00200000
 	realtimePriorityClass      = 0x00000100
+
+	// https://docs.microsoft.com/windows/win32/api/processthreadsapi/nf-processthreadsapi-setthreadpriority
+	threadModeBackgroundBegin = 0x00010000
 )
 
 // SetLowPriority lowers the process CPU scheduling priority, and possibly
@@ -47,3 +50,25 @@ func SetLowPriority() error {
 	}
 	return errors.Wrap(err, "set priority class") // wraps nil as nil
 }
+
+// SetThreadLowPriority lowers the CPU and I/O scheduling priority of the
+// calling thread as far as it goes, leaving other threads alone. The
+// calling goroutine is expected to be locked to its thread.
+func SetThreadLowPriority() error {
+	modkernel32 := syscall.NewLazyDLL("kernel32.dll")
+	getCurrentThread := modkernel32.NewProc("GetCurrentThread")
+	setThreadPriority := modkernel32.NewProc("SetThreadPriority")
+
+	if err := setThreadPriority.Find(); err != nil {
+		return errors.Wrap(err, "find proc")
+	}
+
+	// A pseudo handle, which needn't be closed.
+	handle, _, _ := getCurrentThread.Call()
+	res, _, err := setThreadPriority.Call(handle, threadModeBackgroundBegin)
+	if res != 0 {
+		// "If the function succeeds, the return value is nonzero."
+		return nil
+	}
+	return errors.Wrap(err, "set thread priority") // wraps nil as nil
+}
@@ -8,7 +8,6 @@ package osutil
 
 import (
 	"context"
-	"net/url"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/dialer"
@@ -27,14 +26,3 @@ func TCPPing(ctx context.Context, address string) (time.Duration, error) {
 	}
 	return time.Since(start), err
 }
-
-// GetLatencyForURL parses the given URL, tries opening a TCP connection to it
-// and returns the time it took to establish the connection.
-func GetLatencyForURL(ctx context.Context, addr string) (time.Duration, error) {
-	uri, err := url.Parse(addr)
-	if err != nil {
-		return 0, err
-	}
-
-	return TCPPing(ctx, uri.Host)
-}
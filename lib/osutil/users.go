@@ -0,0 +1,104 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package osutil
+
+import (
+	"os/user"
+	"strconv"
+
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// The lookups can be expensive, e.g. when they go to a directory service,
+// and are done for every scanned or pulled item when syncing ownership, so
+// the results are cached for the life of the process.
+var (
+	userNames  = make(map[int]string)
+	groupNames = make(map[int]string)
+	userIDs    = make(map[string]int)
+	groupIDs   = make(map[string]int)
+	usersMut   = sync.NewMutex()
+)
+
+// UserName returns the name of the user with the given ID, or the empty
+// string if there is none.
+func UserName(uid int) string {
+	return cachedName(userNames, uid, func(id string) (string, error) {
+		u, err := user.LookupId(id)
+		if err != nil {
+			return "", err
+		}
+		return u.Username, nil
+	})
+}
+
+// GroupName returns the name of the group with the given ID, or the empty
+// string if there is none.
+func GroupName(gid int) string {
+	return cachedName(groupNames, gid, func(id string) (string, error) {
+		g, err := user.LookupGroupId(id)
+		if err != nil {
+			return "", err
+		}
+		return g.Name, nil
+	})
+}
+
+// UserID returns the ID of the user with the given name, and whether there
+// is such a user.
+func UserID(name string) (int, bool) {
+	return cachedID(userIDs, name, func(name string) (string, error) {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return "", err
+		}
+		return u.Uid, nil
+	})
+}
+
+// GroupID returns the ID of the group with the given name, and whether
+// there is such a group.
+func GroupID(name string) (int, bool) {
+	return cachedID(groupIDs, name, func(name string) (string, error) {
+		g, err := user.LookupGroup(name)
+		if err != nil {
+			return "", err
+		}
+		return g.Gid, nil
+	})
+}
+
+func cachedName(cache map[int]string, id int, lookup func(string) (string, error)) string {
+	usersMut.Lock()
+	defer usersMut.Unlock()
+	name, ok := cache[id]
+	if !ok {
+		name, _ = lookup(strconv.Itoa(id))
+		cache[id] = name
+	}
+	return name
+}
+
+func cachedID(cache map[string]int, name string, lookup func(string) (string, error)) (int, bool) {
+	if name == "" {
+		return -1, false
+	}
+	usersMut.Lock()
+	defer usersMut.Unlock()
+	id, ok := cache[name]
+	if !ok {
+		id = -1
+		if idStr, err := lookup(name); err == nil {
+			// Not numeric on Windows, where we don't use the IDs anyway.
+			if n, err := strconv.Atoi(idStr); err == nil {
+				id = n
+			}
+		}
+		cache[name] = id
+	}
+	return id, id >= 0
+}
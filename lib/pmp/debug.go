@@ -11,5 +11,5 @@ import (
 )
 
 var (
-	l = logger.DefaultLogger.NewFacility("pmp", "NAT-PMP discovery and port mapping")
+	l = logger.DefaultLogger.NewFacility("pmp", "NAT-PMP and PCP discovery and port mapping")
 )
@@ -0,0 +1,244 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pmp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/nat"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// Port Control Protocol, RFC 6887. PCP servers listen on the same port as
+// NAT-PMP ones and answer NAT-PMP style when they only speak that.
+
+const (
+	pcpPort    = 5351
+	pcpVersion = 2
+
+	pcpOpAnnounce = 0
+	pcpOpMap      = 1
+
+	pcpHeaderLen = 24
+	pcpMapLen    = 36
+	pcpMaxLen    = 1100
+
+	// The first retransmission interval; it doubles for each retry.
+	pcpInitialRetry = time.Second
+)
+
+// pcpResultCodes are the descriptions of the result codes in responses.
+var pcpResultCodes = []string{
+	"success",
+	"unsupported version",
+	"not authorized",
+	"malformed request",
+	"unsupported opcode",
+	"unsupported option",
+	"malformed option",
+	"network failure",
+	"no resources",
+	"unsupported protocol",
+	"user exceeded quota",
+	"cannot provide external",
+	"address mismatch",
+	"excessive remote peers",
+}
+
+var errPCPUnsupported = errors.New("PCP not supported by gateway")
+
+type pcpError uint8
+
+func (e pcpError) Error() string {
+	if int(e) < len(pcpResultCodes) {
+		return "PCP: " + pcpResultCodes[e]
+	}
+	return "PCP: result code " + strconv.Itoa(int(e))
+}
+
+type pcpClient struct {
+	renewal   time.Duration
+	timeout   time.Duration
+	localIP   net.IP
+	gatewayIP net.IP
+	port      int
+	// Identifies our mappings, so that the server lets us renew them.
+	nonce [12]byte
+
+	// The external address of the last successful mapping.
+	externalIP net.IP
+	mut        sync.Mutex
+}
+
+func newPCPClient(gatewayIP, localIP net.IP, renewal, timeout time.Duration) *pcpClient {
+	c := &pcpClient{
+		renewal:   renewal,
+		timeout:   timeout,
+		localIP:   localIP,
+		gatewayIP: gatewayIP,
+		port:      pcpPort,
+		mut:       sync.NewMutex(),
+	}
+	_, _ = rand.Read(c.nonce[:])
+	return c
+}
+
+func (c *pcpClient) ID() string {
+	return fmt.Sprintf("PCP@%s", c.gatewayIP.String())
+}
+
+func (c *pcpClient) Type() string {
+	return "PCP"
+}
+
+func (c *pcpClient) GetLocalIPAddress() net.IP {
+	return c.localIP
+}
+
+// probe checks whether the gateway speaks PCP, returning errPCPUnsupported
+// if it responds as a NAT-PMP only gateway.
+func (c *pcpClient) probe(ctx context.Context) error {
+	_, err := c.request(ctx, pcpOpAnnounce, 0, nil)
+	return err
+}
+
+func (c *pcpClient) AddPortMapping(ctx context.Context, protocol nat.Protocol, internalPort, externalPort int, description string, duration time.Duration) (int, error) {
+	// A lifetime of zero deletes the mapping, so use the renewal interval
+	// instead, as for NAT-PMP.
+	if duration == 0 {
+		duration = c.renewal
+	}
+
+	payload := make([]byte, pcpMapLen)
+	copy(payload, c.nonce[:])
+	switch protocol {
+	case nat.TCP:
+		payload[12] = 6
+	case nat.UDP:
+		payload[12] = 17
+	}
+	binary.BigEndian.PutUint16(payload[16:], uint16(internalPort))
+	binary.BigEndian.PutUint16(payload[18:], uint16(externalPort))
+	// Any external address will do.
+	copy(payload[20:], net.IPv4zero.To16())
+
+	resp, err := c.request(ctx, pcpOpMap, uint32(duration/time.Second), payload)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp) < pcpMapLen {
+		return 0, errors.New("PCP: short MAP response")
+	}
+
+	c.mut.Lock()
+	c.externalIP = net.IP(append([]byte(nil), resp[20:36]...))
+	c.mut.Unlock()
+	return int(binary.BigEndian.Uint16(resp[18:])), nil
+}
+
+func (c *pcpClient) GetExternalIPAddress(_ context.Context) (net.IP, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.externalIP == nil {
+		return net.IPv4zero, errors.New("PCP: no mapping yet")
+	}
+	if ip4 := c.externalIP.To4(); ip4 != nil {
+		return ip4, nil
+	}
+	return c.externalIP, nil
+}
+
+// request sends the request to the gateway, retrying until it responds or
+// the timeout expires, and returns the opcode specific part of the
+// response.
+func (c *pcpClient) request(ctx context.Context, opcode byte, lifetime uint32, payload []byte) ([]byte, error) {
+	req := make([]byte, pcpHeaderLen, pcpHeaderLen+len(payload))
+	req[0] = pcpVersion
+	req[1] = opcode
+	binary.BigEndian.PutUint32(req[4:], lifetime)
+	localIP := c.localIP
+	if localIP == nil {
+		localIP = net.IPv4zero
+	}
+	copy(req[8:], localIP.To16())
+	req = append(req, payload...)
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "udp", net.JoinHostPort(c.gatewayIP.String(), strconv.Itoa(c.port)))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	buf := make([]byte, pcpMaxLen)
+	retry := pcpInitialRetry
+	for {
+		if _, err := conn.Write(req); err != nil {
+			return nil, ctxErr(ctx, err)
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(retry))
+		retry *= 2
+
+		for {
+			n, err := conn.Read(buf)
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() && ctx.Err() == nil {
+				break // retransmit
+			} else if err != nil {
+				return nil, ctxErr(ctx, err)
+			}
+			resp, err := parsePCPResponse(buf[:n], opcode, payload)
+			if err == errPCPIgnore {
+				continue
+			}
+			return resp, err
+		}
+	}
+}
+
+var errPCPIgnore = errors.New("not a response to our request")
+
+// parsePCPResponse checks the response against the request and returns the
+// opcode specific part.
+func parsePCPResponse(resp []byte, opcode byte, payload []byte) ([]byte, error) {
+	if len(resp) >= 4 && resp[0] == 0 {
+		// A NAT-PMP response, most likely telling us it doesn't
+		// understand our version.
+		return nil, errPCPUnsupported
+	}
+	if len(resp) < pcpHeaderLen || resp[0] != pcpVersion || resp[1] != opcode|0x80 {
+		return nil, errPCPIgnore
+	}
+	if code := resp[3]; code != 0 {
+		return nil, pcpError(code)
+	}
+	resp = resp[pcpHeaderLen:]
+	if opcode == pcpOpMap && (len(resp) < pcpMapLen || string(resp[:12]) != string(payload[:12])) {
+		// Responses must echo the nonce.
+		return nil, errPCPIgnore
+	}
+	return resp, nil
+}
+
+// ctxErr returns the context's error, if any, as the cause of err.
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pmp
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/nat"
+)
+
+// fakeGateway answers requests with the response returned by handle.
+func fakeGateway(t *testing.T, handle func(req []byte) []byte) (*pcpClient, func()) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		buf := make([]byte, pcpMaxLen)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := handle(buf[:n]); resp != nil {
+				conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+
+	c := newPCPClient(net.ParseIP("127.0.0.1"), net.ParseIP("127.0.0.1"), time.Minute, 5*time.Second)
+	c.port = conn.LocalAddr().(*net.UDPAddr).Port
+	return c, func() { conn.Close() }
+}
+
+func TestPCPMap(t *testing.T) {
+	external := net.ParseIP("192.0.2.42")
+	c, stop := fakeGateway(t, func(req []byte) []byte {
+		if req[0] != pcpVersion || req[1] != pcpOpMap || len(req) != pcpHeaderLen+pcpMapLen {
+			t.Errorf("Unexpected request %x", req)
+			return nil
+		}
+		if lifetime := binary.BigEndian.Uint32(req[4:]); lifetime != 3600 {
+			t.Errorf("Unexpected lifetime %d", lifetime)
+		}
+		resp := make([]byte, len(req))
+		copy(resp, req)
+		resp[1] |= 0x80
+		copy(resp[8:], make([]byte, 16))
+		payload := resp[pcpHeaderLen:]
+		if payload[12] != 6 || binary.BigEndian.Uint16(payload[16:]) != 22000 {
+			t.Errorf("Unexpected mapping request %x", payload)
+		}
+		// Assign another port than suggested.
+		binary.BigEndian.PutUint16(payload[18:], 23456)
+		copy(payload[20:], external.To16())
+		return resp
+	})
+	defer stop()
+
+	port, err := c.AddPortMapping(context.Background(), nat.TCP, 22000, 12345, "syncthing", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port != 23456 {
+		t.Errorf("Got port %d, expected 23456", port)
+	}
+	ip, err := c.GetExternalIPAddress(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ip.Equal(external) {
+		t.Errorf("Got external address %v, expected %v", ip, external)
+	}
+}
+
+func TestPCPProbe(t *testing.T) {
+	announce := make([]byte, pcpHeaderLen)
+	announce[0] = pcpVersion
+	announce[1] = pcpOpAnnounce | 0x80
+	notAuthorized := append([]byte(nil), announce...)
+	notAuthorized[3] = 2
+
+	cases := []struct {
+		resp     []byte
+		expected error
+	}{
+		{announce, nil},
+		{notAuthorized, pcpError(2)},
+		// A NAT-PMP gateway, not understanding the version.
+		{[]byte{0, 128, 0, 1, 0, 0, 0, 0}, errPCPUnsupported},
+	}
+
+	for _, tc := range cases {
+		c, stop := fakeGateway(t, func([]byte) []byte {
+			return tc.resp
+		})
+		if err := c.probe(context.Background()); err != tc.expected {
+			t.Errorf("Got %v for response %x, expected %v", err, tc.resp, tc.expected)
+		}
+		stop()
+	}
+}
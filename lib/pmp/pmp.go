@@ -42,6 +42,32 @@ func Discover(ctx context.Context, renewal, timeout time.Duration) []nat.Device
 
 	l.Debugln("Discovered gateway at", ip)
 
+	var localIP net.IP
+	// Port comes from the natpmp package
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(timeoutCtx, "udp", net.JoinHostPort(ip.String(), "5351"))
+	if err == nil {
+		conn.Close()
+		localIPAddress, _, err := net.SplitHostPort(conn.LocalAddr().String())
+		if err == nil {
+			localIP = net.ParseIP(localIPAddress)
+		} else {
+			l.Debugln("Failed to lookup local IP", err)
+		}
+	}
+
+	// Prefer PCP, which NAT-PMP gateways tell us they don't speak.
+	pcp := newPCPClient(ip, localIP, renewal, timeout)
+	err = pcp.probe(ctx)
+	if err == nil {
+		l.Debugln("Gateway at", ip, "speaks PCP")
+		return []nat.Device{pcp}
+	} else if errors.Cause(err) == context.Canceled {
+		return nil
+	}
+	l.Debugln("PCP probe of", ip, "failed:", err)
+
 	c := natpmp.NewClientWithTimeout(ip, timeout)
 	// Try contacting the gateway, if it does not respond, assume it does not
 	// speak NAT-PMP.
@@ -59,21 +85,6 @@ func Discover(ctx context.Context, renewal, timeout time.Duration) []nat.Device
 		}
 	}
 
-	var localIP net.IP
-	// Port comes from the natpmp package
-	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-	conn, err := (&net.Dialer{}).DialContext(timeoutCtx, "udp", net.JoinHostPort(ip.String(), "5351"))
-	if err == nil {
-		conn.Close()
-		localIPAddress, _, err := net.SplitHostPort(conn.LocalAddr().String())
-		if err == nil {
-			localIP = net.ParseIP(localIPAddress)
-		} else {
-			l.Debugln("Failed to lookup local IP", err)
-		}
-	}
-
 	return []nat.Device{&wrapper{
 		renewal:   renewal,
 		localIP:   localIP,
@@ -93,6 +104,10 @@ func (w *wrapper) ID() string {
 	return fmt.Sprintf("NAT-PMP@%s", w.gatewayIP.String())
 }
 
+func (w *wrapper) Type() string {
+	return "NAT-PMP"
+}
+
 func (w *wrapper) GetLocalIPAddress() net.IP {
 	return w.localIP
 }
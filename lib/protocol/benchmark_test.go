@@ -60,9 +60,9 @@ func benchmarkRequestsTLS(b *testing.B, conn0, conn1 net.Conn) {
 
 func benchmarkRequestsConnPair(b *testing.B, conn0, conn1 net.Conn) {
 	// Start up Connections on them
-	c0 := NewConnection(LocalDeviceID, conn0, conn0, testutils.NoopCloser{}, new(fakeModel), &testutils.FakeConnectionInfo{"c0"}, CompressionMetadata)
+	c0 := NewConnection(LocalDeviceID, conn0, conn0, testutils.NoopCloser{}, new(fakeModel), &testutils.FakeConnectionInfo{"c0"}, CompressionMetadata, 0, Keepalive{})
 	c0.Start()
-	c1 := NewConnection(LocalDeviceID, conn1, conn1, testutils.NoopCloser{}, new(fakeModel), &testutils.FakeConnectionInfo{"c1"}, CompressionMetadata)
+	c1 := NewConnection(LocalDeviceID, conn1, conn1, testutils.NoopCloser{}, new(fakeModel), &testutils.FakeConnectionInfo{"c1"}, CompressionMetadata, 0, Keepalive{})
 	c1.Start()
 
 	// Satisfy the assertions in the protocol by sending an initial cluster config
@@ -82,9 +82,9 @@ func benchmarkRequestsConnPair(b *testing.B, conn0, conn1 net.Conn) {
 		// Use c0 and c1 for each alternating request, so we get as much
 		// data flowing in both directions.
 		if i%2 == 0 {
-			buf, err = c0.Request(context.Background(), "folder", "file", i, int64(i), 128<<10, nil, 0, false)
+			buf, _, err = c0.Request(context.Background(), "folder", "file", i, int64(i), 128<<10, nil, 0, 0, false)
 		} else {
-			buf, err = c1.Request(context.Background(), "folder", "file", i, int64(i), 128<<10, nil, 0, false)
+			buf, _, err = c1.Request(context.Background(), "folder", "file", i, int64(i), 128<<10, nil, 0, 0, false)
 		}
 
 		if err != nil {
@@ -175,13 +175,13 @@ func (m *fakeModel) IndexUpdate(deviceID DeviceID, folder string, files []FileIn
 	return nil
 }
 
-func (m *fakeModel) Request(deviceID DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, weakHash uint32, fromTemporary bool) (RequestResponse, error) {
+func (m *fakeModel) Request(deviceID DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, hashAlgo BlockHashAlgorithm, weakHash uint32, fromTemporary bool) (RequestResponse, error) {
 	// We write the offset to the end of the buffer, so the receiver
 	// can verify that it did in fact get some data back over the
 	// connection.
 	buf := make([]byte, size)
 	binary.BigEndian.PutUint64(buf[len(buf)-8:], uint64(offset))
-	return &fakeRequestResponse{buf}, nil
+	return &fakeRequestResponse{data: buf}, nil
 }
 
 func (m *fakeModel) ClusterConfig(deviceID DeviceID, config ClusterConfig) error {
@@ -194,3 +194,11 @@ func (m *fakeModel) Closed(conn Connection, err error) {
 func (m *fakeModel) DownloadProgress(deviceID DeviceID, folder string, updates []FileDownloadProgressUpdate) error {
 	return nil
 }
+
+func (m *fakeModel) ConfigPush(deviceID DeviceID, push ConfigPush) error {
+	return nil
+}
+
+func (m *fakeModel) UpgradeRequest(deviceID DeviceID, req UpgradeRequest) error {
+	return nil
+}
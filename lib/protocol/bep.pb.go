@@ -35,17 +35,23 @@ const (
 	MessageTypeDownloadProgress MessageType = 5
 	MessageTypePing             MessageType = 6
 	MessageTypeClose            MessageType = 7
+	MessageTypeConfigPush       MessageType = 8
+	MessageTypeUpgradeRequest   MessageType = 9
+	MessageTypeUpgradeResponse  MessageType = 10
 )
 
 var MessageType_name = map[int32]string{
-	0: "MESSAGE_TYPE_CLUSTER_CONFIG",
-	1: "MESSAGE_TYPE_INDEX",
-	2: "MESSAGE_TYPE_INDEX_UPDATE",
-	3: "MESSAGE_TYPE_REQUEST",
-	4: "MESSAGE_TYPE_RESPONSE",
-	5: "MESSAGE_TYPE_DOWNLOAD_PROGRESS",
-	6: "MESSAGE_TYPE_PING",
-	7: "MESSAGE_TYPE_CLOSE",
+	0:  "MESSAGE_TYPE_CLUSTER_CONFIG",
+	1:  "MESSAGE_TYPE_INDEX",
+	2:  "MESSAGE_TYPE_INDEX_UPDATE",
+	3:  "MESSAGE_TYPE_REQUEST",
+	4:  "MESSAGE_TYPE_RESPONSE",
+	5:  "MESSAGE_TYPE_DOWNLOAD_PROGRESS",
+	6:  "MESSAGE_TYPE_PING",
+	7:  "MESSAGE_TYPE_CLOSE",
+	8:  "MESSAGE_TYPE_CONFIG_PUSH",
+	9:  "MESSAGE_TYPE_UPGRADE_REQUEST",
+	10: "MESSAGE_TYPE_UPGRADE_RESPONSE",
 }
 
 var MessageType_value = map[string]int32{
@@ -57,6 +63,9 @@ var MessageType_value = map[string]int32{
 	"MESSAGE_TYPE_DOWNLOAD_PROGRESS": 5,
 	"MESSAGE_TYPE_PING":              6,
 	"MESSAGE_TYPE_CLOSE":             7,
+	"MESSAGE_TYPE_CONFIG_PUSH":       8,
+	"MESSAGE_TYPE_UPGRADE_REQUEST":   9,
+	"MESSAGE_TYPE_UPGRADE_RESPONSE":  10,
 }
 
 func (x MessageType) String() string {
@@ -72,16 +81,19 @@ type MessageCompression int32
 const (
 	MessageCompressionNone MessageCompression = 0
 	MessageCompressionLZ4  MessageCompression = 1
+	MessageCompressionZstd MessageCompression = 2
 )
 
 var MessageCompression_name = map[int32]string{
 	0: "MESSAGE_COMPRESSION_NONE",
 	1: "MESSAGE_COMPRESSION_LZ4",
+	2: "MESSAGE_COMPRESSION_ZSTD",
 }
 
 var MessageCompression_value = map[string]int32{
 	"MESSAGE_COMPRESSION_NONE": 0,
 	"MESSAGE_COMPRESSION_LZ4":  1,
+	"MESSAGE_COMPRESSION_ZSTD": 2,
 }
 
 func (x MessageCompression) String() string {
@@ -120,6 +132,31 @@ func (Compression) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_311ef540e10d9705, []int{2}
 }
 
+type BlockHashAlgorithm int32
+
+const (
+	BlockHashAlgorithmSHA256  BlockHashAlgorithm = 0
+	BlockHashAlgorithmBLAKE2b BlockHashAlgorithm = 1
+)
+
+var BlockHashAlgorithm_name = map[int32]string{
+	0: "BLOCK_HASH_ALGORITHM_SHA256",
+	1: "BLOCK_HASH_ALGORITHM_BLAKE2B",
+}
+
+var BlockHashAlgorithm_value = map[string]int32{
+	"BLOCK_HASH_ALGORITHM_SHA256":  0,
+	"BLOCK_HASH_ALGORITHM_BLAKE2B": 1,
+}
+
+func (x BlockHashAlgorithm) String() string {
+	return proto.EnumName(BlockHashAlgorithm_name, int32(x))
+}
+
+func (BlockHashAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{3}
+}
+
 type FileInfoType int32
 
 const (
@@ -151,7 +188,7 @@ func (x FileInfoType) String() string {
 }
 
 func (FileInfoType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{3}
+	return fileDescriptor_311ef540e10d9705, []int{4}
 }
 
 type ErrorCode int32
@@ -182,7 +219,7 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{4}
+	return fileDescriptor_311ef540e10d9705, []int{5}
 }
 
 type FileDownloadProgressUpdateType int32
@@ -207,13 +244,25 @@ func (x FileDownloadProgressUpdateType) String() string {
 }
 
 func (FileDownloadProgressUpdateType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{5}
+	return fileDescriptor_311ef540e10d9705, []int{6}
 }
 
 type Hello struct {
 	DeviceName    string `protobuf:"bytes,1,opt,name=device_name,json=deviceName,proto3" json:"deviceName" xml:"deviceName"`
 	ClientName    string `protobuf:"bytes,2,opt,name=client_name,json=clientName,proto3" json:"clientName" xml:"clientName"`
 	ClientVersion string `protobuf:"bytes,3,opt,name=client_version,json=clientVersion,proto3" json:"clientVersion" xml:"clientVersion"`
+	// Set when the sender is already connected to the recipient and would
+	// use this connection as an additional one, for block transfers only.
+	// It becomes such a secondary connection only if both sides set this.
+	Secondary bool `protobuf:"varint,4,opt,name=secondary,proto3" json:"secondary" xml:"secondary"`
+	// Optional protocol features the sender supports, see the Feature
+	// constants.
+	Features []string `protobuf:"bytes,5,rep,name=features,proto3" json:"features" xml:"feature"`
+	// The certificate the sender is rotating to, if any, in DER form, and
+	// its signature by the key of the current certificate over the
+	// current device ID.
+	NextCertificate          []byte `protobuf:"bytes,6,opt,name=next_certificate,json=nextCertificate,proto3" json:"nextCertificate" xml:"nextCertificate"`
+	NextCertificateSignature []byte `protobuf:"bytes,7,opt,name=next_certificate_signature,json=nextCertificateSignature,proto3" json:"nextCertificateSignature" xml:"nextCertificateSignature"`
 }
 
 func (m *Hello) Reset()         { *m = Hello{} }
@@ -491,20 +540,28 @@ func (m *IndexUpdate) XXX_DiscardUnknown() {
 var xxx_messageInfo_IndexUpdate proto.InternalMessageInfo
 
 type FileInfo struct {
-	Name          string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name" xml:"name"`
-	Size          int64        `protobuf:"varint,3,opt,name=size,proto3" json:"size" xml:"size"`
-	ModifiedS     int64        `protobuf:"varint,5,opt,name=modified_s,json=modifiedS,proto3" json:"modifiedS" xml:"modifiedS"`
-	ModifiedBy    ShortID      `protobuf:"varint,12,opt,name=modified_by,json=modifiedBy,proto3,customtype=ShortID" json:"modifiedBy" xml:"modifiedBy"`
-	Version       Vector       `protobuf:"bytes,9,opt,name=version,proto3" json:"version" xml:"version"`
-	Sequence      int64        `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence" xml:"sequence"`
-	Blocks        []BlockInfo  `protobuf:"bytes,16,rep,name=blocks,proto3" json:"blocks" xml:"block"`
-	SymlinkTarget string       `protobuf:"bytes,17,opt,name=symlink_target,json=symlinkTarget,proto3" json:"symlinkTarget" xml:"symlinkTarget"`
-	BlocksHash    []byte       `protobuf:"bytes,18,opt,name=blocks_hash,json=blocksHash,proto3" json:"blocksHash" xml:"blocksHash"`
-	Encrypted     []byte       `protobuf:"bytes,19,opt,name=encrypted,proto3" json:"encrypted" xml:"encrypted"`
-	Type          FileInfoType `protobuf:"varint,2,opt,name=type,proto3,enum=protocol.FileInfoType" json:"type" xml:"type"`
-	Permissions   uint32       `protobuf:"varint,4,opt,name=permissions,proto3" json:"permissions" xml:"permissions"`
-	ModifiedNs    int          `protobuf:"varint,11,opt,name=modified_ns,json=modifiedNs,proto3,casttype=int" json:"modifiedNs" xml:"modifiedNs"`
-	RawBlockSize  int          `protobuf:"varint,13,opt,name=block_size,json=blockSize,proto3,casttype=int" json:"blockSize" xml:"blockSize"`
+	Name          string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name" xml:"name"`
+	Size          int64       `protobuf:"varint,3,opt,name=size,proto3" json:"size" xml:"size"`
+	ModifiedS     int64       `protobuf:"varint,5,opt,name=modified_s,json=modifiedS,proto3" json:"modifiedS" xml:"modifiedS"`
+	ModifiedBy    ShortID     `protobuf:"varint,12,opt,name=modified_by,json=modifiedBy,proto3,customtype=ShortID" json:"modifiedBy" xml:"modifiedBy"`
+	Version       Vector      `protobuf:"bytes,9,opt,name=version,proto3" json:"version" xml:"version"`
+	Sequence      int64       `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence" xml:"sequence"`
+	Blocks        []BlockInfo `protobuf:"bytes,16,rep,name=blocks,proto3" json:"blocks" xml:"block"`
+	SymlinkTarget string      `protobuf:"bytes,17,opt,name=symlink_target,json=symlinkTarget,proto3" json:"symlinkTarget" xml:"symlinkTarget"`
+	BlocksHash    []byte      `protobuf:"bytes,18,opt,name=blocks_hash,json=blocksHash,proto3" json:"blocksHash" xml:"blocksHash"`
+	Encrypted     []byte      `protobuf:"bytes,19,opt,name=encrypted,proto3" json:"encrypted" xml:"encrypted"`
+	// Set by devices that sync extended attributes for the folder, even if
+	// the item has none.
+	XattrData *XattrData `protobuf:"bytes,20,opt,name=xattr_data,json=xattrData,proto3" json:"xattrData" xml:"xattrData"`
+	// Set by devices that sync ownership for the folder.
+	OwnershipData *OwnershipData `protobuf:"bytes,21,opt,name=ownership_data,json=ownershipData,proto3" json:"ownershipData" xml:"ownershipData"`
+	// The hash function that produced the block hashes. Only sent to
+	// devices announcing support for it.
+	BlockHashAlgorithm BlockHashAlgorithm `protobuf:"varint,22,opt,name=block_hash_algorithm,json=blockHashAlgorithm,proto3,enum=protocol.BlockHashAlgorithm" json:"blockHashAlgorithm" xml:"blockHashAlgorithm"`
+	Type               FileInfoType       `protobuf:"varint,2,opt,name=type,proto3,enum=protocol.FileInfoType" json:"type" xml:"type"`
+	Permissions        uint32             `protobuf:"varint,4,opt,name=permissions,proto3" json:"permissions" xml:"permissions"`
+	ModifiedNs         int                `protobuf:"varint,11,opt,name=modified_ns,json=modifiedNs,proto3,casttype=int" json:"modifiedNs" xml:"modifiedNs"`
+	RawBlockSize       int                `protobuf:"varint,13,opt,name=block_size,json=blockSize,proto3,casttype=int" json:"blockSize" xml:"blockSize"`
 	// The local_flags fields stores flags that are relevant to the local
 	// host only. It is not part of the protocol, doesn't get sent or
 	// received (we make sure to zero it), nonetheless we need it on our
@@ -550,6 +607,123 @@ func (m *FileInfo) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_FileInfo proto.InternalMessageInfo
 
+type XattrData struct {
+	Xattrs []Xattr `protobuf:"bytes,1,rep,name=xattrs,proto3" json:"xattrs" xml:"xattr"`
+}
+
+func (m *XattrData) Reset()         { *m = XattrData{} }
+func (m *XattrData) String() string { return proto.CompactTextString(m) }
+func (*XattrData) ProtoMessage()    {}
+func (*XattrData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{8}
+}
+func (m *XattrData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *XattrData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_XattrData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *XattrData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_XattrData.Merge(m, src)
+}
+func (m *XattrData) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *XattrData) XXX_DiscardUnknown() {
+	xxx_messageInfo_XattrData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_XattrData proto.InternalMessageInfo
+
+type Xattr struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name" xml:"name"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value" xml:"value"`
+}
+
+func (m *Xattr) Reset()         { *m = Xattr{} }
+func (m *Xattr) String() string { return proto.CompactTextString(m) }
+func (*Xattr) ProtoMessage()    {}
+func (*Xattr) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{9}
+}
+func (m *Xattr) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Xattr) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Xattr.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Xattr) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Xattr.Merge(m, src)
+}
+func (m *Xattr) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *Xattr) XXX_DiscardUnknown() {
+	xxx_messageInfo_Xattr.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Xattr proto.InternalMessageInfo
+
+// The owner and group of an item, by name when known, so that they can be
+// mapped to the IDs used by the other device.
+type OwnershipData struct {
+	UserName  string `protobuf:"bytes,1,opt,name=user_name,json=userName,proto3" json:"userName" xml:"userName"`
+	GroupName string `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"groupName" xml:"groupName"`
+	UID       int    `protobuf:"varint,3,opt,name=uid,proto3,casttype=int" json:"uid" xml:"uid"`
+	GID       int    `protobuf:"varint,4,opt,name=gid,proto3,casttype=int" json:"gid" xml:"gid"`
+}
+
+func (m *OwnershipData) Reset()         { *m = OwnershipData{} }
+func (m *OwnershipData) String() string { return proto.CompactTextString(m) }
+func (*OwnershipData) ProtoMessage()    {}
+func (*OwnershipData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{10}
+}
+func (m *OwnershipData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OwnershipData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OwnershipData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OwnershipData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnershipData.Merge(m, src)
+}
+func (m *OwnershipData) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *OwnershipData) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnershipData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnershipData proto.InternalMessageInfo
+
 type BlockInfo struct {
 	Hash     []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash" xml:"hash"`
 	Offset   int64  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset" xml:"offset"`
@@ -560,7 +734,7 @@ type BlockInfo struct {
 func (m *BlockInfo) Reset()      { *m = BlockInfo{} }
 func (*BlockInfo) ProtoMessage() {}
 func (*BlockInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{8}
+	return fileDescriptor_311ef540e10d9705, []int{11}
 }
 func (m *BlockInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -597,7 +771,7 @@ func (m *Vector) Reset()         { *m = Vector{} }
 func (m *Vector) String() string { return proto.CompactTextString(m) }
 func (*Vector) ProtoMessage()    {}
 func (*Vector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{9}
+	return fileDescriptor_311ef540e10d9705, []int{12}
 }
 func (m *Vector) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -635,7 +809,7 @@ func (m *Counter) Reset()         { *m = Counter{} }
 func (m *Counter) String() string { return proto.CompactTextString(m) }
 func (*Counter) ProtoMessage()    {}
 func (*Counter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{10}
+	return fileDescriptor_311ef540e10d9705, []int{13}
 }
 func (m *Counter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -674,13 +848,15 @@ type Request struct {
 	FromTemporary bool   `protobuf:"varint,7,opt,name=from_temporary,json=fromTemporary,proto3" json:"fromTemporary" xml:"fromTemporary"`
 	WeakHash      uint32 `protobuf:"varint,8,opt,name=weak_hash,json=weakHash,proto3" json:"weakHash" xml:"weakHash"`
 	BlockNo       int    `protobuf:"varint,9,opt,name=block_no,json=blockNo,proto3,casttype=int" json:"blockNo" xml:"blockNo"`
+	// The hash function that produced the hash.
+	BlockHashAlgorithm BlockHashAlgorithm `protobuf:"varint,10,opt,name=block_hash_algorithm,json=blockHashAlgorithm,proto3,enum=protocol.BlockHashAlgorithm" json:"blockHashAlgorithm" xml:"blockHashAlgorithm"`
 }
 
 func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{11}
+	return fileDescriptor_311ef540e10d9705, []int{14}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -709,17 +885,21 @@ func (m *Request) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Request proto.InternalMessageInfo
 
+// The hash is set by implementations that verified the data against the
+// strong hash of the block in their own index before sending it, when that
+// is the hash given in the request.
 type Response struct {
 	ID   int       `protobuf:"varint,1,opt,name=id,proto3,casttype=int" json:"id" xml:"id"`
 	Data []byte    `protobuf:"bytes,2,opt,name=data,proto3" json:"data" xml:"data"`
 	Code ErrorCode `protobuf:"varint,3,opt,name=code,proto3,enum=protocol.ErrorCode" json:"code" xml:"code"`
+	Hash []byte    `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash" xml:"hash"`
 }
 
 func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{12}
+	return fileDescriptor_311ef540e10d9705, []int{15}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -757,7 +937,7 @@ func (m *DownloadProgress) Reset()         { *m = DownloadProgress{} }
 func (m *DownloadProgress) String() string { return proto.CompactTextString(m) }
 func (*DownloadProgress) ProtoMessage()    {}
 func (*DownloadProgress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{13}
+	return fileDescriptor_311ef540e10d9705, []int{16}
 }
 func (m *DownloadProgress) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -798,7 +978,7 @@ func (m *FileDownloadProgressUpdate) Reset()         { *m = FileDownloadProgress
 func (m *FileDownloadProgressUpdate) String() string { return proto.CompactTextString(m) }
 func (*FileDownloadProgressUpdate) ProtoMessage()    {}
 func (*FileDownloadProgressUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{14}
+	return fileDescriptor_311ef540e10d9705, []int{17}
 }
 func (m *FileDownloadProgressUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -827,14 +1007,22 @@ func (m *FileDownloadProgressUpdate) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_FileDownloadProgressUpdate proto.InternalMessageInfo
 
+// A Ping with a nonzero ID is answered by the other side with a Ping
+// carrying the same ID and the reply flag set, allowing the round trip time
+// to be measured. Older implementations ignore the fields and don't reply.
+// The payload is ignored and not sent back; it serves to measure
+// throughput.
 type Ping struct {
+	ID      int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id" xml:"id"`
+	Reply   bool   `protobuf:"varint,2,opt,name=reply,proto3" json:"reply" xml:"reply"`
+	Payload []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload" xml:"payload"`
 }
 
 func (m *Ping) Reset()         { *m = Ping{} }
 func (m *Ping) String() string { return proto.CompactTextString(m) }
 func (*Ping) ProtoMessage()    {}
 func (*Ping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{15}
+	return fileDescriptor_311ef540e10d9705, []int{18}
 }
 func (m *Ping) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -871,7 +1059,7 @@ func (m *Close) Reset()         { *m = Close{} }
 func (m *Close) String() string { return proto.CompactTextString(m) }
 func (*Close) ProtoMessage()    {}
 func (*Close) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{16}
+	return fileDescriptor_311ef540e10d9705, []int{19}
 }
 func (m *Close) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -900,10 +1088,138 @@ func (m *Close) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Close proto.InternalMessageInfo
 
+// A configuration fragment, in the JSON form of the configuration, for the
+// recipient to apply if it accepts configuration from the sender. Only sent
+// to devices announcing the config push feature.
+type ConfigPush struct {
+	Fragment []byte `protobuf:"bytes,1,opt,name=fragment,proto3" json:"fragment" xml:"fragment"`
+}
+
+func (m *ConfigPush) Reset()         { *m = ConfigPush{} }
+func (m *ConfigPush) String() string { return proto.CompactTextString(m) }
+func (*ConfigPush) ProtoMessage()    {}
+func (*ConfigPush) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{20}
+}
+func (m *ConfigPush) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConfigPush) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConfigPush.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConfigPush) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigPush.Merge(m, src)
+}
+func (m *ConfigPush) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *ConfigPush) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigPush.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigPush proto.InternalMessageInfo
+
+// Asks the recipient to check for an upgrade and, unless check_only is set,
+// to perform it. The version is the release to upgrade to, or empty for the
+// latest one. Only sent to devices announcing the upgrade requests feature,
+// which act on it only if they accept upgrade requests from the sender, and
+// answer with an UpgradeResponse carrying the same ID.
+type UpgradeRequest struct {
+	ID        int    `protobuf:"varint,1,opt,name=id,proto3,casttype=int" json:"id" xml:"id"`
+	Version   string `protobuf:"bytes,2,opt,name=version,proto3" json:"version" xml:"version"`
+	CheckOnly bool   `protobuf:"varint,3,opt,name=check_only,json=checkOnly,proto3" json:"checkOnly" xml:"checkOnly"`
+}
+
+func (m *UpgradeRequest) Reset()         { *m = UpgradeRequest{} }
+func (m *UpgradeRequest) String() string { return proto.CompactTextString(m) }
+func (*UpgradeRequest) ProtoMessage()    {}
+func (*UpgradeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{21}
+}
+func (m *UpgradeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpgradeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpgradeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpgradeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeRequest.Merge(m, src)
+}
+func (m *UpgradeRequest) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *UpgradeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeRequest proto.InternalMessageInfo
+
+// The outcome of an upgrade request: the version the recipient runs, the
+// release it found, whether it upgraded to it and will restart, or why not.
+type UpgradeResponse struct {
+	ID       int    `protobuf:"varint,1,opt,name=id,proto3,casttype=int" json:"id" xml:"id"`
+	Running  string `protobuf:"bytes,2,opt,name=running,proto3" json:"running" xml:"running"`
+	Latest   string `protobuf:"bytes,3,opt,name=latest,proto3" json:"latest" xml:"latest"`
+	Upgraded bool   `protobuf:"varint,4,opt,name=upgraded,proto3" json:"upgraded" xml:"upgraded"`
+	Error    string `protobuf:"bytes,5,opt,name=error,proto3" json:"error" xml:"error"`
+}
+
+func (m *UpgradeResponse) Reset()         { *m = UpgradeResponse{} }
+func (m *UpgradeResponse) String() string { return proto.CompactTextString(m) }
+func (*UpgradeResponse) ProtoMessage()    {}
+func (*UpgradeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{22}
+}
+func (m *UpgradeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpgradeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpgradeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpgradeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeResponse.Merge(m, src)
+}
+func (m *UpgradeResponse) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *UpgradeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("protocol.MessageType", MessageType_name, MessageType_value)
 	proto.RegisterEnum("protocol.MessageCompression", MessageCompression_name, MessageCompression_value)
 	proto.RegisterEnum("protocol.Compression", Compression_name, Compression_value)
+	proto.RegisterEnum("protocol.BlockHashAlgorithm", BlockHashAlgorithm_name, BlockHashAlgorithm_value)
 	proto.RegisterEnum("protocol.FileInfoType", FileInfoType_name, FileInfoType_value)
 	proto.RegisterEnum("protocol.ErrorCode", ErrorCode_name, ErrorCode_value)
 	proto.RegisterEnum("protocol.FileDownloadProgressUpdateType", FileDownloadProgressUpdateType_name, FileDownloadProgressUpdateType_value)
@@ -915,6 +1231,9 @@ func init() {
 	proto.RegisterType((*Index)(nil), "protocol.Index")
 	proto.RegisterType((*IndexUpdate)(nil), "protocol.IndexUpdate")
 	proto.RegisterType((*FileInfo)(nil), "protocol.FileInfo")
+	proto.RegisterType((*XattrData)(nil), "protocol.XattrData")
+	proto.RegisterType((*Xattr)(nil), "protocol.Xattr")
+	proto.RegisterType((*OwnershipData)(nil), "protocol.OwnershipData")
 	proto.RegisterType((*BlockInfo)(nil), "protocol.BlockInfo")
 	proto.RegisterType((*Vector)(nil), "protocol.Vector")
 	proto.RegisterType((*Counter)(nil), "protocol.Counter")
@@ -924,180 +1243,230 @@ func init() {
 	proto.RegisterType((*FileDownloadProgressUpdate)(nil), "protocol.FileDownloadProgressUpdate")
 	proto.RegisterType((*Ping)(nil), "protocol.Ping")
 	proto.RegisterType((*Close)(nil), "protocol.Close")
+	proto.RegisterType((*ConfigPush)(nil), "protocol.ConfigPush")
+	proto.RegisterType((*UpgradeRequest)(nil), "protocol.UpgradeRequest")
+	proto.RegisterType((*UpgradeResponse)(nil), "protocol.UpgradeResponse")
 }
 
 func init() { proto.RegisterFile("lib/protocol/bep.proto", fileDescriptor_311ef540e10d9705) }
 
 var fileDescriptor_311ef540e10d9705 = []byte{
-	// 2681 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0x4d, 0x6c, 0x1b, 0xc7,
-	0xf5, 0xd7, 0x92, 0x94, 0x44, 0x8d, 0x24, 0x87, 0x1a, 0x7f, 0x6d, 0x68, 0x9b, 0xcb, 0xff, 0xc4,
-	0xf9, 0x57, 0x51, 0x1a, 0x39, 0x51, 0x92, 0x36, 0x4d, 0x52, 0x07, 0xfc, 0x92, 0xc4, 0x44, 0x22,
-	0xd9, 0x21, 0xed, 0xd4, 0x46, 0x0b, 0x62, 0xc5, 0x1d, 0x51, 0x0b, 0x2f, 0x77, 0xd9, 0x5d, 0x4a,
-	0xb6, 0x82, 0x5e, 0xda, 0x5e, 0x02, 0x1e, 0x8a, 0x22, 0xa7, 0xa2, 0x28, 0xd1, 0xa0, 0x97, 0xde,
-	0x0a, 0xf4, 0xd0, 0x4b, 0x4e, 0x3d, 0xe6, 0x68, 0x04, 0x28, 0x50, 0xf4, 0xb0, 0x80, 0xed, 0x4b,
-	0xcb, 0x23, 0x8f, 0x3d, 0x15, 0xf3, 0xb1, 0xbb, 0xb3, 0xa2, 0x14, 0xc8, 0xc9, 0xa1, 0xb7, 0x7d,
-	0xbf, 0xf7, 0x7b, 0x6f, 0x86, 0x6f, 0xde, 0x7b, 0xf3, 0x76, 0x09, 0xae, 0x58, 0xe6, 0xde, 0xad,
-	0xbe, 0xeb, 0x0c, 0x9c, 0x8e, 0x63, 0xdd, 0xda, 0x23, 0xfd, 0x75, 0x26, 0xc0, 0x74, 0x80, 0x65,
-	0x17, 0xc8, 0xa3, 0x01, 0x07, 0xb3, 0x2f, 0xb9, 0xa4, 0xef, 0x78, 0x9c, 0xbe, 0x77, 0xb8, 0x7f,
-	0xab, 0xeb, 0x74, 0x1d, 0x26, 0xb0, 0x27, 0x4e, 0x42, 0x4f, 0x15, 0x30, 0xbb, 0x4d, 0x2c, 0xcb,
-	0x81, 0x25, 0xb0, 0x68, 0x90, 0x23, 0xb3, 0x43, 0xda, 0xb6, 0xde, 0x23, 0xaa, 0x92, 0x57, 0x56,
-	0x17, 0x8a, 0x68, 0xec, 0x6b, 0x80, 0xc3, 0x35, 0xbd, 0x47, 0x26, 0xbe, 0x96, 0x79, 0xd4, 0xb3,
-	0xde, 0x45, 0x11, 0x84, 0xb0, 0xa4, 0xa7, 0x4e, 0x3a, 0x96, 0x49, 0xec, 0x01, 0x77, 0x92, 0x88,
-	0x9c, 0x70, 0x38, 0xe6, 0x24, 0x82, 0x10, 0x96, 0xf4, 0xb0, 0x0e, 0x2e, 0x08, 0x27, 0x47, 0xc4,
-	0xf5, 0x4c, 0xc7, 0x56, 0x93, 0xcc, 0xcf, 0xea, 0xd8, 0xd7, 0x96, 0xb9, 0xe6, 0x2e, 0x57, 0x4c,
-	0x7c, 0xed, 0xa2, 0xe4, 0x4a, 0xa0, 0x08, 0xc7, 0x59, 0xe8, 0x2f, 0x0a, 0x98, 0xdb, 0x26, 0xba,
-	0x41, 0x5c, 0x58, 0x00, 0xa9, 0xc1, 0x71, 0x9f, 0xff, 0xbc, 0x0b, 0x1b, 0x97, 0xd7, 0x83, 0xc0,
-	0xad, 0xef, 0x12, 0xcf, 0xd3, 0xbb, 0xa4, 0x75, 0xdc, 0x27, 0xc5, 0x2b, 0x63, 0x5f, 0x63, 0xb4,
-	0x89, 0xaf, 0x01, 0xe6, 0x9f, 0x0a, 0x08, 0x33, 0x0c, 0x1a, 0x60, 0xb1, 0xe3, 0xf4, 0xfa, 0x2e,
-	0xf1, 0xd8, 0xde, 0x12, 0xcc, 0xd3, 0xf5, 0x29, 0x4f, 0xa5, 0x88, 0x53, 0xbc, 0x39, 0xf6, 0x35,
-	0xd9, 0x68, 0xe2, 0x6b, 0x2b, 0x7c, 0xdf, 0x11, 0x86, 0xb0, 0xcc, 0x40, 0x3f, 0x01, 0xcb, 0x25,
-	0xeb, 0xd0, 0x1b, 0x10, 0xb7, 0xe4, 0xd8, 0xfb, 0x66, 0x17, 0x7e, 0x04, 0xe6, 0xf7, 0x1d, 0xcb,
-	0x20, 0xae, 0xa7, 0x2a, 0xf9, 0xe4, 0xea, 0xe2, 0x46, 0x26, 0x5a, 0x72, 0x93, 0x29, 0x8a, 0xda,
-	0x97, 0xbe, 0x36, 0x33, 0xf6, 0xb5, 0x80, 0x38, 0xf1, 0xb5, 0x25, 0xb6, 0x0c, 0x97, 0x11, 0x0e,
-	0x14, 0xe8, 0x8b, 0x14, 0x98, 0xe3, 0x46, 0x70, 0x1d, 0x24, 0x4c, 0x43, 0x1c, 0x77, 0xee, 0xa9,
-	0xaf, 0x25, 0xaa, 0xe5, 0xb1, 0xaf, 0x25, 0x4c, 0x63, 0xe2, 0x6b, 0x69, 0x66, 0x6d, 0x1a, 0xe8,
-	0xb3, 0xc7, 0x37, 0x13, 0xd5, 0x32, 0x4e, 0x98, 0x06, 0x5c, 0x07, 0xb3, 0x96, 0xbe, 0x47, 0x2c,
-	0x71, 0xb8, 0xea, 0xd8, 0xd7, 0x38, 0x30, 0xf1, 0xb5, 0x45, 0xc6, 0x67, 0x12, 0xc2, 0x1c, 0x85,
-	0xef, 0x81, 0x05, 0x97, 0xe8, 0x46, 0xdb, 0xb1, 0xad, 0x63, 0x76, 0x90, 0xe9, 0x62, 0x6e, 0xec,
-	0x6b, 0x69, 0x0a, 0xd6, 0x6d, 0xeb, 0x78, 0xe2, 0x6b, 0x17, 0x98, 0x59, 0x00, 0x20, 0x1c, 0xea,
-	0x60, 0x1b, 0x40, 0xb3, 0x6b, 0x3b, 0x2e, 0x69, 0xf7, 0x89, 0xdb, 0x33, 0x59, 0x68, 0x3c, 0x35,
-	0xc5, 0xbc, 0xbc, 0x3e, 0xf6, 0xb5, 0x15, 0xae, 0x6d, 0x44, 0xca, 0x89, 0xaf, 0x5d, 0xe5, 0xbb,
-	0x3e, 0xa9, 0x41, 0x78, 0x9a, 0x0d, 0x3f, 0x02, 0xcb, 0x62, 0x01, 0x83, 0x58, 0x64, 0x40, 0xd4,
-	0x59, 0xe6, 0xfb, 0xff, 0xc7, 0xbe, 0xb6, 0xc4, 0x15, 0x65, 0x86, 0x4f, 0x7c, 0x0d, 0x4a, 0x6e,
-	0x39, 0x88, 0x70, 0x8c, 0x03, 0x0d, 0x70, 0xc9, 0x30, 0x3d, 0x7d, 0xcf, 0x22, 0xed, 0x01, 0xe9,
-	0xf5, 0xdb, 0xa6, 0x6d, 0x90, 0x47, 0xc4, 0x53, 0xe7, 0x98, 0xcf, 0x8d, 0xb1, 0xaf, 0x41, 0xa1,
-	0x6f, 0x91, 0x5e, 0xbf, 0xca, 0xb5, 0x13, 0x5f, 0x53, 0x79, 0x4d, 0x4d, 0xa9, 0x10, 0x3e, 0x85,
-	0x0f, 0x37, 0xc0, 0x5c, 0x5f, 0x3f, 0xf4, 0x88, 0xa1, 0xce, 0x33, 0xbf, 0xd9, 0xb1, 0xaf, 0x09,
-	0x24, 0x3c, 0x70, 0x2e, 0x22, 0x2c, 0x70, 0x9a, 0x3c, 0xbc, 0x4a, 0x3d, 0x35, 0x73, 0x32, 0x79,
-	0xca, 0x4c, 0x11, 0x25, 0x8f, 0x20, 0x86, 0xbe, 0xb8, 0x8c, 0x70, 0xa0, 0x40, 0x7f, 0x9b, 0x03,
-	0x73, 0xdc, 0x08, 0x16, 0xc3, 0xe4, 0x59, 0x2a, 0x6e, 0x50, 0x07, 0xff, 0xf4, 0xb5, 0x34, 0xd7,
-	0x55, 0xcb, 0x67, 0x25, 0xd3, 0xa7, 0x8f, 0x6f, 0x2a, 0x52, 0x42, 0xad, 0x81, 0x94, 0xd4, 0x2c,
-	0x58, 0xed, 0xd9, 0xbc, 0x4d, 0xf0, 0xda, 0xb3, 0x59, 0x83, 0x60, 0x18, 0x7c, 0x1f, 0x2c, 0xe8,
-	0x86, 0x41, 0x6b, 0x84, 0x78, 0x6a, 0x32, 0x9f, 0xa4, 0x39, 0x3b, 0xf6, 0xb5, 0x08, 0x9c, 0xf8,
-	0xda, 0x32, 0xb3, 0x12, 0x08, 0xc2, 0x91, 0x0e, 0xfe, 0x34, 0x5e, 0xb9, 0xa9, 0x93, 0x3d, 0xe0,
-	0xdb, 0x95, 0x2c, 0xcd, 0xf4, 0x0e, 0x71, 0x45, 0xeb, 0x9b, 0xe5, 0x05, 0x45, 0x33, 0x9d, 0x82,
-	0xa2, 0xf1, 0xf1, 0x4c, 0x0f, 0x00, 0x84, 0x43, 0x1d, 0xdc, 0x02, 0x4b, 0x3d, 0xfd, 0x51, 0xdb,
-	0x23, 0x3f, 0x3b, 0x24, 0x76, 0x87, 0xb0, 0x9c, 0x49, 0xf2, 0x5d, 0xf4, 0xf4, 0x47, 0x4d, 0x01,
-	0x87, 0xbb, 0x90, 0x30, 0x84, 0x65, 0x06, 0x2c, 0x02, 0x60, 0xda, 0x03, 0xd7, 0x31, 0x0e, 0x3b,
-	0xc4, 0x15, 0x29, 0xc2, 0x3a, 0x70, 0x84, 0x86, 0x1d, 0x38, 0x82, 0x10, 0x96, 0xf4, 0xb0, 0x0b,
-	0xd2, 0x2c, 0x77, 0xdb, 0xa6, 0xa1, 0xa6, 0xf3, 0xca, 0x6a, 0xaa, 0xb8, 0x23, 0x0e, 0x77, 0x9e,
-	0x65, 0x21, 0x3b, 0xdb, 0xe0, 0x91, 0xe6, 0x0c, 0x63, 0x57, 0x8d, 0x30, 0xfa, 0x42, 0xa6, 0x7d,
-	0x23, 0xa0, 0xfd, 0x2e, 0x7a, 0xc4, 0x01, 0x1f, 0xfe, 0x1c, 0x64, 0xbd, 0x07, 0x26, 0xad, 0x14,
-	0xbe, 0xf6, 0xc0, 0x74, 0xec, 0xb6, 0x4b, 0x7a, 0xce, 0x91, 0x6e, 0x79, 0xea, 0x02, 0xdb, 0xfc,
-	0xed, 0xb1, 0xaf, 0xa9, 0x94, 0x55, 0x95, 0x48, 0x58, 0x70, 0x26, 0xbe, 0x96, 0x63, 0x2b, 0x9e,
-	0x45, 0x40, 0xf8, 0x4c, 0x5b, 0xf8, 0x08, 0xbc, 0x48, 0xec, 0x8e, 0x7b, 0xdc, 0x67, 0xcb, 0xf6,
-	0x75, 0xcf, 0x7b, 0xe8, 0xb8, 0x46, 0x7b, 0xe0, 0x3c, 0x20, 0xb6, 0x0a, 0x58, 0x52, 0xbf, 0x3f,
-	0xf6, 0xb5, 0xab, 0x11, 0xa9, 0x21, 0x38, 0x2d, 0x4a, 0x99, 0xf8, 0xda, 0x0d, 0xb6, 0xf6, 0x19,
-	0x7a, 0x84, 0xcf, 0xb2, 0x44, 0xbf, 0x54, 0xc0, 0x2c, 0x0b, 0x06, 0xad, 0x66, 0xde, 0x94, 0x45,
-	0x0b, 0x66, 0xd5, 0xcc, 0x91, 0xa9, 0xf6, 0x2d, 0x70, 0x58, 0x01, 0xb3, 0xfb, 0xa6, 0x45, 0x3c,
-	0x35, 0xc1, 0x6a, 0x19, 0x4a, 0x17, 0x81, 0x69, 0x91, 0xaa, 0xbd, 0xef, 0x14, 0xaf, 0x89, 0x6a,
-	0xe6, 0xc4, 0xb0, 0x96, 0xa8, 0x84, 0x30, 0x07, 0xd1, 0xa7, 0x0a, 0x58, 0x64, 0x9b, 0xb8, 0xd3,
-	0x37, 0xf4, 0x01, 0xf9, 0x5f, 0x6e, 0xe5, 0x09, 0x00, 0xe9, 0xc0, 0x20, 0x6c, 0x08, 0xca, 0x39,
-	0x1a, 0xc2, 0x1a, 0x48, 0x79, 0xe6, 0x27, 0x84, 0x5d, 0x2c, 0x49, 0xce, 0xa5, 0x72, 0xc8, 0xa5,
-	0x02, 0xc2, 0x0c, 0x83, 0x1f, 0x00, 0xd0, 0x73, 0x0c, 0x73, 0xdf, 0x24, 0x46, 0xdb, 0x63, 0x05,
-	0x9a, 0x2c, 0xe6, 0x69, 0xf7, 0x08, 0xd0, 0xe6, 0xc4, 0xd7, 0x5e, 0xe0, 0xe5, 0x15, 0x20, 0x08,
-	0x47, 0x5a, 0xda, 0x3f, 0x42, 0x07, 0x7b, 0xc7, 0xea, 0x12, 0xab, 0x8c, 0xf7, 0x83, 0xca, 0x68,
-	0x1e, 0x38, 0xee, 0x80, 0x95, 0x43, 0xb8, 0x4c, 0xf1, 0x38, 0x2c, 0xb5, 0x08, 0x42, 0xb4, 0x12,
-	0x04, 0x19, 0x4b, 0x54, 0xb8, 0x03, 0xe6, 0x83, 0x81, 0x87, 0x66, 0x7e, 0xac, 0x49, 0xdf, 0x25,
-	0x9d, 0x81, 0xe3, 0x16, 0xf3, 0x41, 0x93, 0x3e, 0x0a, 0x07, 0x20, 0x5e, 0x70, 0x47, 0xc1, 0xe8,
-	0x13, 0x68, 0xe0, 0xbb, 0x20, 0x1d, 0x36, 0x13, 0xc0, 0x7e, 0x2b, 0x6b, 0x46, 0x5e, 0xd4, 0x49,
-	0x78, 0x33, 0xf2, 0xc2, 0x36, 0x12, 0xea, 0xe0, 0x87, 0x60, 0x6e, 0xcf, 0x72, 0x3a, 0x0f, 0x82,
-	0xdb, 0xe2, 0x62, 0xb4, 0x91, 0x22, 0xc5, 0xd9, 0xb9, 0xde, 0x10, 0x7b, 0x11, 0xd4, 0xf0, 0xfa,
-	0x67, 0x22, 0xc2, 0x02, 0xa6, 0xd3, 0x9c, 0x77, 0xdc, 0xb3, 0x4c, 0xfb, 0x41, 0x7b, 0xa0, 0xbb,
-	0x5d, 0x32, 0x50, 0x57, 0xa2, 0x69, 0x4e, 0x68, 0x5a, 0x4c, 0x11, 0x4e, 0x73, 0x31, 0x14, 0xe1,
-	0x38, 0x8b, 0xce, 0x98, 0xdc, 0x75, 0xfb, 0x40, 0xf7, 0x0e, 0x54, 0xc8, 0xea, 0x94, 0x75, 0x38,
-	0x0e, 0x6f, 0xeb, 0xde, 0x41, 0x18, 0xf6, 0x08, 0x42, 0x58, 0xd2, 0xc3, 0xdb, 0x60, 0x41, 0xd4,
-	0x26, 0x31, 0xd4, 0x8b, 0xcc, 0x05, 0x4b, 0x85, 0x10, 0x0c, 0x53, 0x21, 0x44, 0x10, 0x8e, 0xb4,
-	0xb0, 0x28, 0xe6, 0x48, 0x3e, 0xfd, 0x5d, 0x99, 0x4e, 0xfb, 0x73, 0x0c, 0x92, 0x9b, 0x60, 0xf1,
-	0xe4, 0x54, 0xb3, 0xcc, 0x3b, 0x7e, 0x3f, 0x36, 0xcf, 0xf0, 0x8e, 0xdf, 0x97, 0x27, 0x19, 0x99,
-	0x01, 0x3f, 0x94, 0xd2, 0xd2, 0xf6, 0xd4, 0xc5, 0xbc, 0xb2, 0x3a, 0x5b, 0x7c, 0x45, 0xce, 0xc3,
-	0x9a, 0x37, 0x95, 0x87, 0x35, 0x0f, 0xfd, 0xc7, 0xd7, 0x92, 0xa6, 0x3d, 0xc0, 0x12, 0x0d, 0xee,
-	0x03, 0x1e, 0xa5, 0x36, 0xab, 0xaa, 0x65, 0xe6, 0x6a, 0xeb, 0xa9, 0xaf, 0x2d, 0x61, 0xfd, 0x21,
-	0x3b, 0xfa, 0xa6, 0xf9, 0x09, 0xa1, 0x81, 0xda, 0x0b, 0x84, 0x30, 0x50, 0x21, 0x12, 0x38, 0xfe,
-	0xec, 0xf1, 0xcd, 0x98, 0x19, 0x8e, 0x8c, 0x60, 0x19, 0x2c, 0x5a, 0x4e, 0x47, 0xb7, 0xda, 0xfb,
-	0x96, 0xde, 0xf5, 0xd4, 0x7f, 0xcd, 0xb3, 0x1f, 0xcf, 0x4e, 0x91, 0xe1, 0x9b, 0x14, 0x0e, 0x37,
-	0x1d, 0x41, 0x08, 0x4b, 0x7a, 0xb8, 0x0d, 0x96, 0x44, 0xba, 0xf3, 0x5c, 0xf8, 0xf7, 0x3c, 0x3b,
-	0x49, 0x16, 0x43, 0xa1, 0x10, 0xd9, 0xb0, 0x22, 0x57, 0x09, 0x4f, 0x07, 0x99, 0x01, 0xbf, 0x47,
-	0x07, 0x24, 0x3a, 0xc4, 0x19, 0x62, 0x5a, 0xbb, 0xce, 0x47, 0x21, 0x06, 0x85, 0x55, 0x26, 0x64,
-	0x36, 0x0b, 0xb1, 0x27, 0x88, 0xc1, 0xbc, 0x69, 0x1f, 0xe9, 0x96, 0x19, 0x4c, 0x63, 0xef, 0x3c,
-	0xf5, 0x35, 0x80, 0xf5, 0x87, 0x55, 0x8e, 0xf2, 0xcb, 0x91, 0x3d, 0x4a, 0x97, 0x23, 0x93, 0xe9,
-	0xe5, 0x28, 0x31, 0x71, 0xc0, 0xa3, 0x15, 0x63, 0x3b, 0xb1, 0x81, 0x37, 0xcd, 0x5c, 0xb3, 0x8a,
-	0xb1, 0x9d, 0xf8, 0xb0, 0xcb, 0x2b, 0x26, 0x86, 0x22, 0x1c, 0x67, 0xbd, 0x9b, 0xfa, 0xed, 0xe7,
-	0xda, 0x0c, 0x7a, 0xa2, 0x80, 0x85, 0xb0, 0x7a, 0x69, 0xe3, 0x64, 0x21, 0x4b, 0xb2, 0x88, 0xb1,
-	0x44, 0x3d, 0xe0, 0xa1, 0xe2, 0x89, 0x7a, 0xc0, 0x62, 0xc4, 0x30, 0x7a, 0x31, 0x38, 0xfb, 0xfb,
-	0x1e, 0x19, 0xb0, 0x96, 0x9c, 0xe4, 0x17, 0x03, 0x47, 0xc2, 0x8b, 0x81, 0x8b, 0x08, 0x0b, 0x1c,
-	0xbe, 0x21, 0x1a, 0x73, 0x82, 0xa5, 0xd0, 0x8d, 0xd3, 0x1b, 0x73, 0x90, 0x81, 0xbc, 0x3f, 0xbf,
-	0x07, 0x16, 0x1e, 0x12, 0xfd, 0x01, 0x3f, 0x4a, 0x5e, 0x0d, 0xac, 0x65, 0x51, 0x50, 0x1c, 0x23,
-	0x6f, 0x59, 0x01, 0x80, 0x70, 0xa8, 0x13, 0xbf, 0xf1, 0x3e, 0x98, 0xe3, 0x9d, 0x12, 0x36, 0x40,
-	0xba, 0xe3, 0x1c, 0xda, 0x83, 0xe8, 0x7d, 0x69, 0x45, 0x1e, 0xf4, 0x98, 0xa6, 0xf8, 0x7f, 0xa2,
-	0x85, 0x85, 0xd4, 0xf0, 0x8c, 0x04, 0x40, 0x27, 0x34, 0xa1, 0x42, 0xbf, 0x52, 0xc0, 0xbc, 0x30,
-	0x84, 0xdb, 0xe1, 0xdc, 0x9b, 0x2a, 0xbe, 0x73, 0xe2, 0x02, 0xf8, 0xfa, 0x77, 0x28, 0xb9, 0xf9,
-	0x8b, 0xd7, 0xa9, 0x23, 0xdd, 0x3a, 0xe4, 0x81, 0x4a, 0xf1, 0xd7, 0x29, 0x06, 0x84, 0xfd, 0x94,
-	0x49, 0x08, 0x73, 0x14, 0xfd, 0x22, 0x05, 0xe6, 0x31, 0xed, 0xd3, 0xde, 0x00, 0xbe, 0x1d, 0xee,
-	0x62, 0xb6, 0xf8, 0xf2, 0x59, 0xcb, 0x46, 0xc5, 0x18, 0x0c, 0xdc, 0xd1, 0x3d, 0x9f, 0x38, 0xf7,
-	0x3d, 0x1f, 0xdc, 0xc9, 0xc9, 0x73, 0xdc, 0xc9, 0x51, 0xba, 0xa4, 0x9e, 0x3b, 0x5d, 0x66, 0xcf,
-	0x9f, 0x2e, 0x41, 0x06, 0xcf, 0x9d, 0x23, 0x83, 0xeb, 0xe0, 0xc2, 0xbe, 0xeb, 0xf4, 0xd8, 0x6b,
-	0x99, 0xe3, 0xea, 0xee, 0xb1, 0xa8, 0x56, 0x56, 0x52, 0x54, 0xd3, 0x0a, 0x14, 0x61, 0x49, 0xc5,
-	0x50, 0x84, 0xe3, 0xac, 0x78, 0xae, 0xa6, 0x9f, 0x2f, 0x57, 0xe1, 0x6d, 0x90, 0xe6, 0x4d, 0xd6,
-	0x76, 0xd8, 0x4d, 0x3f, 0x5b, 0x7c, 0x89, 0xf6, 0x09, 0x86, 0xd5, 0x9c, 0x30, 0x07, 0x85, 0x1c,
-	0xfe, 0xec, 0x80, 0x80, 0xfe, 0xac, 0x80, 0x34, 0x26, 0x5e, 0xdf, 0xb1, 0x3d, 0xf2, 0x4d, 0x93,
-	0x60, 0x0d, 0xa4, 0x0c, 0x7d, 0xa0, 0xb3, 0x14, 0x10, 0xd1, 0xa3, 0x72, 0x18, 0x3d, 0x2a, 0x20,
-	0xcc, 0x30, 0xf8, 0x01, 0x48, 0x75, 0x1c, 0x83, 0x1f, 0xfe, 0x05, 0x79, 0x18, 0xa8, 0xb8, 0xae,
-	0xe3, 0x96, 0x1c, 0x43, 0xdc, 0x74, 0x94, 0x14, 0x3a, 0xa0, 0x02, 0xc2, 0x0c, 0x43, 0x7f, 0x52,
-	0x40, 0xa6, 0xec, 0x3c, 0xb4, 0x2d, 0x47, 0x37, 0x1a, 0xae, 0xd3, 0xa5, 0x6f, 0x4c, 0xdf, 0x68,
-	0xdc, 0x6c, 0x83, 0xf9, 0x43, 0x36, 0xac, 0x06, 0x03, 0xe7, 0xcd, 0xf8, 0xcd, 0x7b, 0x72, 0x11,
-	0x3e, 0xd9, 0x46, 0xef, 0xb6, 0xc2, 0x38, 0xf4, 0xcf, 0x65, 0x84, 0x03, 0x05, 0xfa, 0x63, 0x12,
-	0x64, 0xcf, 0x76, 0x04, 0x7b, 0x60, 0x91, 0x33, 0xdb, 0xd2, 0x57, 0xa4, 0xd5, 0xf3, 0xec, 0x81,
-	0xcd, 0x03, 0xec, 0x7e, 0x3b, 0x0c, 0xe5, 0xf0, 0x7e, 0x8b, 0x20, 0x84, 0x25, 0xfd, 0x73, 0xbd,
-	0x1a, 0x4b, 0xd3, 0x63, 0xf2, 0xdb, 0x4f, 0x8f, 0x4d, 0xb0, 0xcc, 0x53, 0x34, 0xf8, 0x86, 0x91,
-	0xca, 0x27, 0x57, 0x67, 0x8b, 0xeb, 0x63, 0x5f, 0x5b, 0xda, 0xe3, 0x97, 0x48, 0xf0, 0xf5, 0x62,
-	0x25, 0x4a, 0x56, 0x0e, 0x06, 0xd9, 0x96, 0x99, 0xc1, 0x31, 0x2e, 0xdc, 0x8c, 0x0d, 0x17, 0xbc,
-	0xd4, 0xbf, 0x73, 0xce, 0x61, 0x42, 0x1a, 0x1e, 0xd0, 0x1c, 0x48, 0x35, 0x4c, 0xbb, 0x8b, 0xde,
-	0x03, 0xb3, 0x25, 0xcb, 0xf1, 0x58, 0xc7, 0x71, 0x89, 0xee, 0x39, 0xb6, 0x9c, 0x4a, 0x1c, 0x09,
-	0x8f, 0x9a, 0x8b, 0x08, 0x0b, 0x7c, 0xed, 0x8b, 0x24, 0x58, 0x94, 0x3e, 0xfa, 0xc1, 0x1f, 0x82,
-	0x6b, 0xbb, 0x95, 0x66, 0xb3, 0xb0, 0x55, 0x69, 0xb7, 0xee, 0x35, 0x2a, 0xed, 0xd2, 0xce, 0x9d,
-	0x66, 0xab, 0x82, 0xdb, 0xa5, 0x7a, 0x6d, 0xb3, 0xba, 0x95, 0x99, 0xc9, 0x5e, 0x1f, 0x8e, 0xf2,
-	0xaa, 0x64, 0x11, 0xff, 0x3c, 0xf7, 0x5d, 0x00, 0x63, 0xe6, 0xd5, 0x5a, 0xb9, 0xf2, 0xe3, 0x8c,
-	0x92, 0xbd, 0x34, 0x1c, 0xe5, 0x33, 0x92, 0x15, 0x7f, 0xeb, 0xfb, 0x01, 0x78, 0x71, 0x9a, 0xdd,
-	0xbe, 0xd3, 0x28, 0x17, 0x5a, 0x95, 0x4c, 0x22, 0x9b, 0x1d, 0x8e, 0xf2, 0x57, 0x4e, 0x1a, 0x89,
-	0x14, 0x7c, 0x1d, 0x5c, 0x8a, 0x99, 0xe2, 0xca, 0x8f, 0xee, 0x54, 0x9a, 0xad, 0x4c, 0x32, 0x7b,
-	0x65, 0x38, 0xca, 0x43, 0xc9, 0x2a, 0xb8, 0x26, 0x36, 0xc0, 0xe5, 0x13, 0x16, 0xcd, 0x46, 0xbd,
-	0xd6, 0xac, 0x64, 0x52, 0xd9, 0xab, 0xc3, 0x51, 0xfe, 0x62, 0xcc, 0x44, 0x74, 0x95, 0x12, 0xc8,
-	0xc5, 0x6c, 0xca, 0xf5, 0x8f, 0x6b, 0x3b, 0xf5, 0x42, 0xb9, 0xdd, 0xc0, 0xf5, 0x2d, 0x5c, 0x69,
-	0x36, 0x33, 0xb3, 0x59, 0x6d, 0x38, 0xca, 0x5f, 0x93, 0x8c, 0xa7, 0x2a, 0x7c, 0x0d, 0xac, 0xc4,
-	0x9c, 0x34, 0xaa, 0xb5, 0xad, 0xcc, 0x5c, 0xf6, 0xe2, 0x70, 0x94, 0x7f, 0x41, 0xb2, 0xa3, 0x67,
-	0x39, 0x15, 0xbf, 0xd2, 0x4e, 0xbd, 0x59, 0xc9, 0xcc, 0x4f, 0xc5, 0x8f, 0x1d, 0xf8, 0xda, 0x1f,
-	0x14, 0x00, 0xa7, 0xbf, 0xb3, 0xc2, 0x77, 0x80, 0x1a, 0x38, 0x29, 0xd5, 0x77, 0x1b, 0x74, 0x9f,
-	0xd5, 0x7a, 0xad, 0x5d, 0xab, 0xd7, 0x2a, 0x99, 0x99, 0x58, 0x54, 0x25, 0xab, 0x9a, 0x63, 0x13,
-	0x58, 0x07, 0x57, 0x4f, 0xb3, 0xdc, 0xb9, 0xff, 0x56, 0x46, 0xc9, 0x6e, 0x0c, 0x47, 0xf9, 0xcb,
-	0xd3, 0x86, 0x3b, 0xf7, 0xdf, 0xfa, 0xea, 0xd7, 0x2f, 0x9f, 0xae, 0x58, 0xfb, 0xbd, 0x02, 0x16,
-	0xe5, 0xad, 0xbd, 0x01, 0x2e, 0xc9, 0x8e, 0x77, 0x2b, 0xad, 0x42, 0xb9, 0xd0, 0x2a, 0x64, 0x66,
-	0xf8, 0x19, 0x48, 0xd4, 0x5d, 0x32, 0xd0, 0x59, 0xdb, 0x7d, 0x15, 0xac, 0xc4, 0x7e, 0x45, 0xe5,
-	0x6e, 0x05, 0x07, 0x19, 0x25, 0xef, 0x9f, 0x1c, 0x11, 0x17, 0xbe, 0x06, 0xa0, 0x4c, 0x2e, 0xec,
-	0x7c, 0x5c, 0xb8, 0xd7, 0xcc, 0x24, 0xb2, 0x97, 0x87, 0xa3, 0xfc, 0x8a, 0xc4, 0x2e, 0x58, 0x0f,
-	0xf5, 0x63, 0x6f, 0xed, 0xaf, 0x09, 0xb0, 0x24, 0xbf, 0xaa, 0xc0, 0xd7, 0xc0, 0xc5, 0xcd, 0xea,
-	0x0e, 0xcd, 0xc4, 0xcd, 0x3a, 0x3f, 0x01, 0x2a, 0x66, 0x66, 0xf8, 0x72, 0x32, 0x95, 0x3e, 0xc3,
-	0xef, 0x03, 0xf5, 0x04, 0xbd, 0x5c, 0xc5, 0x95, 0x52, 0xab, 0x8e, 0xef, 0x65, 0x94, 0xec, 0x8b,
-	0x34, 0x60, 0xb2, 0x4d, 0xd9, 0x74, 0x59, 0x0b, 0x3a, 0x86, 0xb7, 0xc1, 0xb5, 0x13, 0x86, 0xcd,
-	0x7b, 0xbb, 0x3b, 0xd5, 0xda, 0x47, 0x7c, 0xbd, 0x44, 0xf6, 0xc6, 0x70, 0x94, 0xbf, 0x2a, 0xdb,
-	0x36, 0xf9, 0xdb, 0x1f, 0x85, 0xd2, 0x0a, 0xdc, 0x06, 0xf9, 0x33, 0xec, 0xa3, 0x0d, 0x24, 0xb3,
-	0x68, 0x38, 0xca, 0x5f, 0x3f, 0xc5, 0x49, 0xb8, 0x8f, 0xb4, 0x02, 0xdf, 0x04, 0x57, 0x4e, 0xf7,
-	0x14, 0xd4, 0xc5, 0x29, 0xf6, 0x6b, 0x7f, 0x57, 0xc0, 0x42, 0x78, 0xeb, 0xd1, 0xa0, 0x55, 0x30,
-	0xae, 0xd3, 0x26, 0x51, 0xae, 0xb4, 0x6b, 0xf5, 0x36, 0x93, 0x82, 0xa0, 0x85, 0xbc, 0x9a, 0xc3,
-	0x1e, 0x69, 0x8e, 0x4b, 0xf4, 0xad, 0x4a, 0xad, 0x82, 0xab, 0xa5, 0xe0, 0x44, 0x43, 0xf6, 0x16,
-	0xb1, 0x89, 0x6b, 0x76, 0xe0, 0x5b, 0xe0, 0x6a, 0xdc, 0x79, 0xf3, 0x4e, 0x69, 0x3b, 0x88, 0x12,
-	0xdb, 0xa0, 0xb4, 0x40, 0xf3, 0xb0, 0x73, 0xc0, 0x0e, 0xe6, 0xed, 0x98, 0x55, 0xb5, 0x76, 0xb7,
-	0xb0, 0x53, 0x2d, 0x73, 0xab, 0x64, 0x56, 0x1d, 0x8e, 0xf2, 0x97, 0x42, 0x2b, 0xf1, 0xe2, 0x41,
-	0xcd, 0xd6, 0xbe, 0x52, 0x40, 0xee, 0xeb, 0x2f, 0x2f, 0xf8, 0x31, 0x78, 0x85, 0xc5, 0x6b, 0xaa,
-	0x15, 0x88, 0xbe, 0xc5, 0x63, 0x58, 0x68, 0x34, 0x2a, 0xb5, 0x72, 0x66, 0x26, 0xbb, 0x3a, 0x1c,
-	0xe5, 0x6f, 0x7e, 0xbd, 0xcb, 0x42, 0xbf, 0x4f, 0x6c, 0xe3, 0x9c, 0x8e, 0x37, 0xeb, 0x78, 0xab,
-	0xd2, 0xca, 0x28, 0xe7, 0x71, 0xbc, 0xe9, 0xb8, 0x5d, 0x32, 0x28, 0xee, 0x7e, 0xf9, 0x24, 0x37,
-	0xf3, 0xf8, 0x49, 0x6e, 0xe6, 0xcb, 0xa7, 0x39, 0xe5, 0xf1, 0xd3, 0x9c, 0xf2, 0x9b, 0x67, 0xb9,
-	0x99, 0xcf, 0x9f, 0xe5, 0x94, 0xc7, 0xcf, 0x72, 0x33, 0xff, 0x78, 0x96, 0x9b, 0xb9, 0xff, 0x6a,
-	0xd7, 0x1c, 0x1c, 0x1c, 0xee, 0xad, 0x77, 0x9c, 0xde, 0x2d, 0xef, 0xd8, 0xee, 0x0c, 0x0e, 0x4c,
-	0xbb, 0x2b, 0x3d, 0xc9, 0xff, 0xb7, 0xed, 0xcd, 0xb1, 0xa7, 0x37, 0xff, 0x1b, 0x00, 0x00, 0xff,
-	0xff, 0xf7, 0xe3, 0xa9, 0xd7, 0x86, 0x1b, 0x00, 0x00,
+	// 3437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5a, 0x4d, 0x6c, 0x23, 0x47,
+	0x76, 0x56, 0xf3, 0x47, 0xa2, 0x4a, 0xd2, 0x0c, 0xa7, 0xe6, 0x8f, 0xe6, 0xcc, 0xa8, 0x99, 0xda,
+	0x71, 0x22, 0x6b, 0xbd, 0xe3, 0x5d, 0xad, 0xed, 0x78, 0x6d, 0xc7, 0x06, 0x29, 0x52, 0x12, 0x77,
+	0x34, 0xa4, 0x52, 0x94, 0x6c, 0xcf, 0x60, 0x83, 0x46, 0x8b, 0x5d, 0xa2, 0x1a, 0x43, 0x76, 0x33,
+	0xdd, 0xe4, 0x8c, 0xb4, 0xc8, 0x69, 0x03, 0x24, 0x0b, 0x1d, 0x8c, 0x60, 0x4f, 0x41, 0x10, 0x05,
+	0x46, 0x2e, 0xb9, 0x05, 0x08, 0x90, 0x5c, 0x72, 0xda, 0xa3, 0x2f, 0x01, 0x06, 0x06, 0x02, 0x24,
+	0x7b, 0x68, 0xc0, 0xe3, 0x4b, 0xa2, 0xa3, 0x2e, 0x01, 0x72, 0x0a, 0xea, 0x55, 0x75, 0x75, 0x35,
+	0x25, 0x79, 0x65, 0x1b, 0xc8, 0xde, 0xba, 0xbe, 0xf7, 0x53, 0xd5, 0xaf, 0xde, 0x7b, 0xf5, 0x75,
+	0x91, 0xe8, 0x56, 0xdf, 0xdd, 0x7d, 0x63, 0x18, 0xf8, 0x23, 0xbf, 0xeb, 0xf7, 0xdf, 0xd8, 0x65,
+	0xc3, 0x07, 0x30, 0xc0, 0x85, 0x18, 0x2b, 0xcf, 0xb2, 0x83, 0x91, 0x00, 0xcb, 0xdf, 0x0b, 0xd8,
+	0xd0, 0x0f, 0x85, 0xfa, 0xee, 0x78, 0xef, 0x8d, 0x9e, 0xdf, 0xf3, 0x61, 0x00, 0x4f, 0x42, 0x89,
+	0xfc, 0x73, 0x0e, 0xe5, 0x37, 0x58, 0xbf, 0xef, 0xe3, 0x55, 0x34, 0xe7, 0xb0, 0x67, 0x6e, 0x97,
+	0x59, 0x9e, 0x3d, 0x60, 0x25, 0xa3, 0x62, 0x2c, 0xcd, 0xd6, 0xc8, 0x49, 0x64, 0x22, 0x01, 0xb7,
+	0xec, 0x01, 0x3b, 0x8d, 0xcc, 0xe2, 0xc1, 0xa0, 0xff, 0x2e, 0x49, 0x20, 0x42, 0x35, 0x39, 0x77,
+	0xd2, 0xed, 0xbb, 0xcc, 0x1b, 0x09, 0x27, 0x99, 0xc4, 0x89, 0x80, 0x53, 0x4e, 0x12, 0x88, 0x50,
+	0x4d, 0x8e, 0xdb, 0xe8, 0x8a, 0x74, 0xf2, 0x8c, 0x05, 0xa1, 0xeb, 0x7b, 0xa5, 0x2c, 0xf8, 0x59,
+	0x3a, 0x89, 0xcc, 0x05, 0x21, 0xf9, 0x48, 0x08, 0x4e, 0x23, 0xf3, 0xba, 0xe6, 0x4a, 0xa2, 0x84,
+	0xa6, 0xb5, 0xf0, 0x07, 0x68, 0x36, 0x64, 0x5d, 0xdf, 0x73, 0xec, 0xe0, 0xb0, 0x94, 0xab, 0x18,
+	0x4b, 0x85, 0x5a, 0xe5, 0x24, 0x32, 0x13, 0xf0, 0x34, 0x32, 0xaf, 0x82, 0x1f, 0x85, 0x10, 0x9a,
+	0x48, 0xf1, 0x4f, 0x50, 0x61, 0x8f, 0xd9, 0xa3, 0x71, 0xc0, 0xc2, 0x52, 0xbe, 0x92, 0x5d, 0x9a,
+	0xad, 0xdd, 0x3b, 0x89, 0x4c, 0x85, 0x9d, 0x46, 0xe6, 0x02, 0x58, 0x4b, 0x80, 0x50, 0x25, 0xc2,
+	0x1f, 0xa3, 0xa2, 0xc7, 0x0e, 0x46, 0x56, 0x97, 0x05, 0x23, 0x77, 0xcf, 0xed, 0xda, 0x23, 0x56,
+	0x9a, 0xae, 0x18, 0x4b, 0xf3, 0xb5, 0xd7, 0x4f, 0x22, 0xf3, 0x2a, 0x97, 0xad, 0x26, 0xa2, 0xd3,
+	0xc8, 0xbc, 0x09, 0x9e, 0x26, 0x70, 0x42, 0x27, 0x35, 0xf1, 0x9f, 0xa1, 0xf2, 0xa4, 0x63, 0x2b,
+	0x74, 0x7b, 0x1e, 0xcc, 0x5b, 0x9a, 0x81, 0x29, 0x3e, 0x38, 0x89, 0xcc, 0xd2, 0x84, 0x61, 0x27,
+	0xd6, 0x39, 0x8d, 0xcc, 0xc5, 0xf3, 0xe6, 0x52, 0x0a, 0x84, 0x5e, 0x68, 0x4b, 0xfe, 0xc9, 0x40,
+	0xd3, 0x1b, 0xcc, 0x76, 0x58, 0x80, 0xab, 0x28, 0x37, 0x3a, 0x1c, 0x8a, 0x84, 0xb9, 0xb2, 0x72,
+	0xf3, 0x41, 0x9c, 0x8a, 0x0f, 0x1e, 0xb1, 0x30, 0xb4, 0x7b, 0x6c, 0xfb, 0x70, 0xc8, 0x6a, 0xb7,
+	0x4e, 0x22, 0x13, 0xd4, 0x4e, 0x23, 0x13, 0xc1, 0xac, 0x7c, 0x40, 0x28, 0x60, 0xd8, 0x41, 0x73,
+	0x5d, 0x7f, 0x30, 0x0c, 0x58, 0x08, 0xbb, 0x9d, 0x01, 0x4f, 0x77, 0xcf, 0x78, 0x5a, 0x4d, 0x74,
+	0x6a, 0xf7, 0x4f, 0x22, 0x53, 0x37, 0x3a, 0x8d, 0xcc, 0x6b, 0x22, 0x13, 0x12, 0x8c, 0x50, 0x5d,
+	0x83, 0xfc, 0x0c, 0x2d, 0xac, 0xf6, 0xc7, 0xe1, 0x88, 0x05, 0xab, 0xbe, 0xb7, 0xe7, 0xf6, 0xf0,
+	0x43, 0x34, 0xb3, 0xe7, 0xf7, 0x1d, 0x16, 0x84, 0x25, 0xa3, 0x92, 0x5d, 0x9a, 0x5b, 0x29, 0x26,
+	0x53, 0xae, 0x81, 0xa0, 0x66, 0x7e, 0x1e, 0x99, 0x53, 0x27, 0x91, 0x19, 0x2b, 0x9e, 0x46, 0xe6,
+	0xbc, 0xd8, 0x6a, 0x18, 0x13, 0x1a, 0x0b, 0xc8, 0xbf, 0xe6, 0xd0, 0xb4, 0x30, 0xc2, 0x0f, 0x50,
+	0xc6, 0x75, 0x64, 0x01, 0x2d, 0xbe, 0x8c, 0xcc, 0x4c, 0xb3, 0x7e, 0x12, 0x99, 0x19, 0xd7, 0x39,
+	0x8d, 0xcc, 0x02, 0x58, 0xbb, 0x0e, 0xf9, 0xd5, 0x8b, 0xfb, 0x99, 0x66, 0x9d, 0x66, 0x5c, 0x07,
+	0x3f, 0x40, 0xf9, 0xbe, 0xbd, 0xcb, 0xfa, 0xb2, 0x5c, 0x4a, 0x27, 0x91, 0x29, 0x80, 0xd3, 0xc8,
+	0x9c, 0x03, 0x7d, 0x18, 0x11, 0x2a, 0x50, 0xfc, 0x1e, 0x9a, 0x0d, 0x98, 0xed, 0x58, 0xbe, 0xd7,
+	0x3f, 0x84, 0xd2, 0x28, 0xd4, 0x16, 0x79, 0x3e, 0x72, 0xb0, 0xed, 0xf5, 0x79, 0x36, 0x5f, 0x01,
+	0xb3, 0x18, 0x20, 0x54, 0xc9, 0xb0, 0x85, 0xb0, 0xdb, 0xf3, 0xfc, 0x80, 0x59, 0x43, 0x16, 0x0c,
+	0x5c, 0x08, 0x4d, 0x28, 0x8b, 0xe2, 0x87, 0x27, 0x91, 0x79, 0x4d, 0x48, 0xb7, 0x12, 0xe1, 0x69,
+	0x64, 0xde, 0x16, 0xab, 0x9e, 0x94, 0x10, 0x7a, 0x56, 0x1b, 0x3f, 0x44, 0x0b, 0x72, 0x02, 0x87,
+	0xf5, 0xd9, 0x88, 0x95, 0xf2, 0xe0, 0xfb, 0xf7, 0x4f, 0x22, 0x73, 0x5e, 0x08, 0xea, 0x80, 0x9f,
+	0x46, 0x26, 0xd6, 0xdc, 0x0a, 0x90, 0xd0, 0x94, 0x0e, 0x76, 0xd0, 0x0d, 0xc7, 0x0d, 0xed, 0xdd,
+	0x3e, 0xb3, 0x46, 0x6c, 0x30, 0xb4, 0x5c, 0xcf, 0x61, 0x07, 0x2c, 0x84, 0x12, 0x2a, 0xd4, 0x56,
+	0x4e, 0x22, 0x13, 0x4b, 0xf9, 0x36, 0x1b, 0x0c, 0x9b, 0x42, 0x7a, 0x1a, 0x99, 0x25, 0xd1, 0xa5,
+	0xce, 0x88, 0x08, 0x3d, 0x47, 0x1f, 0xaf, 0xa0, 0xe9, 0xa1, 0x3d, 0x0e, 0x99, 0x03, 0x75, 0x53,
+	0xa8, 0x95, 0x4f, 0x22, 0x53, 0x22, 0x6a, 0xc3, 0xc5, 0x90, 0x50, 0x89, 0xf3, 0xe4, 0x11, 0x7d,
+	0x2f, 0x2c, 0x15, 0x27, 0x93, 0xa7, 0x0e, 0x82, 0x24, 0x79, 0xa4, 0xa2, 0xf2, 0x25, 0xc6, 0x84,
+	0xc6, 0x02, 0xf2, 0xeb, 0x69, 0x34, 0x2d, 0x8c, 0x70, 0x4d, 0x25, 0xcf, 0x7c, 0x6d, 0x85, 0x3b,
+	0xf8, 0x4d, 0x64, 0x16, 0x84, 0xac, 0x59, 0xbf, 0x28, 0x99, 0x7e, 0xf9, 0xe2, 0xbe, 0xa1, 0x25,
+	0xd4, 0x32, 0xca, 0x69, 0xed, 0x17, 0x6a, 0xcf, 0xb3, 0x07, 0x49, 0xed, 0x79, 0xd0, 0x72, 0x01,
+	0xc3, 0xef, 0xa3, 0x59, 0xdb, 0x71, 0x78, 0x8d, 0xb0, 0xb0, 0x94, 0x85, 0xe6, 0xc6, 0x93, 0x29,
+	0x01, 0x55, 0x77, 0x93, 0x08, 0xa1, 0x89, 0x0c, 0xff, 0x49, 0xba, 0x72, 0x73, 0x93, 0x3d, 0xe0,
+	0xbb, 0x95, 0x2c, 0xcf, 0x74, 0xde, 0xdf, 0xc4, 0x61, 0x92, 0x17, 0x05, 0xc5, 0x33, 0x9d, 0x83,
+	0xf2, 0x28, 0x11, 0x99, 0x1e, 0x03, 0x84, 0x2a, 0x19, 0x5e, 0x47, 0xf3, 0x03, 0xfb, 0xc0, 0x0a,
+	0xd9, 0x9f, 0x8e, 0x99, 0xd7, 0x15, 0x6d, 0x37, 0x2b, 0x56, 0x31, 0xb0, 0x0f, 0x3a, 0x12, 0x56,
+	0xab, 0xd0, 0x30, 0x42, 0x75, 0x0d, 0x5c, 0x43, 0xc8, 0xf5, 0x46, 0x81, 0xef, 0x8c, 0xbb, 0x2c,
+	0x90, 0x29, 0x02, 0x67, 0x5a, 0x82, 0xaa, 0x33, 0x2d, 0x81, 0x08, 0xd5, 0xe4, 0xb8, 0x87, 0x0a,
+	0x90, 0xbb, 0x96, 0xeb, 0x94, 0x0a, 0x15, 0x63, 0x29, 0x57, 0xdb, 0x94, 0x9b, 0x3b, 0x03, 0x59,
+	0x08, 0x7b, 0x1b, 0x3f, 0xf2, 0x9c, 0x01, 0xed, 0xa6, 0xa3, 0xa2, 0x2f, 0xc7, 0xbc, 0x6f, 0xc4,
+	0x6a, 0x7f, 0x93, 0x3c, 0xd2, 0x58, 0x9f, 0x9f, 0x0b, 0xe1, 0x53, 0x77, 0x68, 0xc5, 0x73, 0x8f,
+	0x5c, 0xdf, 0xb3, 0x02, 0x36, 0xf0, 0x9f, 0xd9, 0xfd, 0xb0, 0x34, 0x0b, 0x8b, 0x87, 0x73, 0x81,
+	0x6b, 0x35, 0x35, 0x25, 0x2a, 0x75, 0xd4, 0xb9, 0x70, 0x91, 0x02, 0xa1, 0x17, 0xda, 0xe2, 0x03,
+	0xf4, 0x0a, 0xf3, 0xba, 0xc1, 0xe1, 0x10, 0xa6, 0x1d, 0xda, 0x61, 0xf8, 0xdc, 0x0f, 0x1c, 0x6b,
+	0xe4, 0x3f, 0x65, 0x5e, 0x09, 0x41, 0x52, 0xbf, 0x7f, 0x12, 0x99, 0xb7, 0x13, 0xa5, 0x2d, 0xa9,
+	0xb3, 0xcd, 0x55, 0x4e, 0x23, 0xf3, 0x1e, 0xcc, 0x7d, 0x81, 0x9c, 0xd0, 0x8b, 0x2c, 0xc9, 0x2f,
+	0x0c, 0x94, 0x87, 0x60, 0xf0, 0x6a, 0x16, 0x4d, 0x59, 0xb6, 0x60, 0xa8, 0x66, 0x81, 0x9c, 0x69,
+	0xdf, 0x12, 0xc7, 0x0d, 0x94, 0xdf, 0x73, 0xfb, 0x2c, 0x2c, 0x65, 0xa0, 0x96, 0xb1, 0x76, 0x10,
+	0xb8, 0x7d, 0xd6, 0xf4, 0xf6, 0xfc, 0xda, 0x1d, 0x59, 0xcd, 0x42, 0x51, 0xd5, 0x12, 0x1f, 0x11,
+	0x2a, 0x40, 0xf2, 0x4b, 0x03, 0xcd, 0xc1, 0x22, 0x76, 0x86, 0x0e, 0x3f, 0xa4, 0x7f, 0x87, 0x4b,
+	0xf9, 0xc7, 0x05, 0x54, 0x88, 0x0d, 0x54, 0x43, 0x30, 0x2e, 0xd1, 0x10, 0x96, 0x51, 0x2e, 0x74,
+	0x7f, 0xce, 0xe0, 0x60, 0xc9, 0x0a, 0x5d, 0x3e, 0x56, 0xba, 0x7c, 0x40, 0x28, 0x60, 0xf8, 0x43,
+	0x84, 0x06, 0xbe, 0xe3, 0xee, 0xb9, 0xcc, 0xb1, 0x42, 0x28, 0xd0, 0xac, 0x60, 0x56, 0x31, 0xda,
+	0x51, 0xcc, 0x4a, 0x21, 0x84, 0x26, 0x52, 0xde, 0x3f, 0x94, 0x83, 0xdd, 0xc3, 0xd2, 0x3c, 0x54,
+	0xc6, 0xfb, 0x71, 0x65, 0x74, 0xf6, 0xfd, 0x60, 0x04, 0xe5, 0xa0, 0xa6, 0xa9, 0x1d, 0xaa, 0x52,
+	0x4b, 0x20, 0xc2, 0x2b, 0x41, 0x2a, 0x53, 0x4d, 0x15, 0x6f, 0xa2, 0x99, 0x98, 0x42, 0xf2, 0xcc,
+	0x4f, 0x35, 0xe9, 0x8f, 0x58, 0x77, 0xe4, 0x07, 0xb5, 0x4a, 0xdc, 0xa4, 0x9f, 0x29, 0x4a, 0x29,
+	0x0a, 0xee, 0x59, 0x4c, 0x26, 0x63, 0x09, 0x7e, 0x17, 0x15, 0x54, 0x33, 0x41, 0xf0, 0xae, 0xd0,
+	0x8c, 0xc2, 0xa4, 0x93, 0x5c, 0x91, 0x24, 0x32, 0x6e, 0x23, 0x4a, 0x86, 0x7f, 0x8a, 0xa6, 0x77,
+	0xfb, 0x7e, 0xf7, 0x69, 0x7c, 0x5a, 0x5c, 0x4f, 0x16, 0x52, 0xe3, 0x38, 0xec, 0xeb, 0x3d, 0xb9,
+	0x16, 0xa9, 0xaa, 0x8e, 0x7f, 0x18, 0x12, 0x2a, 0x61, 0xce, 0x8f, 0xc3, 0xc3, 0x41, 0xdf, 0xf5,
+	0x9e, 0x5a, 0x23, 0x3b, 0xe8, 0xb1, 0x51, 0xe9, 0x5a, 0xc2, 0x8f, 0xa5, 0x64, 0x1b, 0x04, 0x8a,
+	0x1f, 0xa7, 0x50, 0x42, 0xd3, 0x5a, 0x9c, 0xb5, 0x0b, 0xd7, 0xd6, 0xbe, 0x1d, 0xee, 0x97, 0x30,
+	0xd4, 0x29, 0x74, 0x38, 0x01, 0x6f, 0xd8, 0xe1, 0xbe, 0x0a, 0x7b, 0x02, 0x11, 0xaa, 0xc9, 0x39,
+	0xc9, 0x96, 0xb5, 0xc9, 0x9c, 0xd2, 0x75, 0x70, 0x01, 0xa9, 0xa0, 0x40, 0x95, 0x0a, 0x0a, 0x21,
+	0x34, 0x91, 0xe2, 0x4f, 0x10, 0x3a, 0xb0, 0x47, 0xa3, 0xc0, 0x72, 0xec, 0x91, 0x5d, 0xba, 0x51,
+	0x31, 0xd2, 0x51, 0xfa, 0x84, 0xcb, 0xea, 0xf6, 0xc8, 0xae, 0xdd, 0xff, 0x3c, 0x32, 0x0d, 0xee,
+	0xf9, 0x20, 0x86, 0x94, 0x67, 0x85, 0x10, 0x9a, 0x48, 0x71, 0x1f, 0x5d, 0xf1, 0x9f, 0x7b, 0x2c,
+	0x08, 0xf7, 0xdd, 0xa1, 0xf0, 0x7e, 0x13, 0xbc, 0xdf, 0x4e, 0xbc, 0xb7, 0x63, 0x39, 0xcc, 0xf0,
+	0xba, 0x9c, 0x61, 0xc1, 0xd7, 0x61, 0x15, 0xcc, 0x14, 0x4a, 0x68, 0x5a, 0x0b, 0xff, 0xc2, 0x40,
+	0x37, 0x20, 0x2c, 0x10, 0x4c, 0xcb, 0xee, 0xf7, 0xfc, 0xc0, 0x1d, 0xed, 0x0f, 0x4a, 0xb7, 0x26,
+	0x69, 0x2d, 0x6c, 0x3c, 0x8f, 0x5d, 0x35, 0xd6, 0x11, 0x8c, 0x66, 0xf7, 0x0c, 0xae, 0x18, 0xcd,
+	0x59, 0x11, 0xa1, 0xe7, 0xe8, 0xe3, 0x9a, 0x24, 0xe5, 0x82, 0x4a, 0xdf, 0x3a, 0xdb, 0x43, 0x2e,
+	0xc1, 0xca, 0xd7, 0xd0, 0xdc, 0x24, 0x45, 0x5c, 0x10, 0xc7, 0xe7, 0x30, 0x45, 0x0e, 0xc5, 0xf1,
+	0x39, 0xd4, 0x69, 0xa1, 0xae, 0x81, 0x7f, 0xaa, 0xd5, 0xb8, 0x17, 0x96, 0xe6, 0x2a, 0xc6, 0x52,
+	0xbe, 0xf6, 0x9a, 0x5e, 0xd4, 0xad, 0xf0, 0x4c, 0x51, 0xb7, 0x42, 0xf2, 0xbf, 0x91, 0x99, 0x75,
+	0xbd, 0x11, 0xd5, 0xd4, 0xf0, 0x1e, 0x12, 0x29, 0x67, 0x41, 0x8b, 0x5a, 0x00, 0x57, 0xeb, 0x2f,
+	0x23, 0x73, 0x9e, 0xda, 0xcf, 0x21, 0x9c, 0x1d, 0xf7, 0xe7, 0x8c, 0xe7, 0xc6, 0x6e, 0x3c, 0x50,
+	0xb9, 0xa1, 0x90, 0xd8, 0xf1, 0xaf, 0x5e, 0xdc, 0x4f, 0x99, 0xd1, 0xc4, 0x08, 0xd7, 0xd1, 0x5c,
+	0xdf, 0xef, 0xda, 0x7d, 0x6b, 0xaf, 0x6f, 0xf7, 0xc2, 0xd2, 0x7f, 0xcd, 0xc0, 0xcb, 0x43, 0x49,
+	0x00, 0xbe, 0xc6, 0x61, 0xb5, 0xe8, 0x04, 0x22, 0x54, 0x93, 0xe3, 0x0d, 0x34, 0x2f, 0x7b, 0x87,
+	0x28, 0xac, 0xff, 0x16, 0x9f, 0x65, 0x10, 0x43, 0x29, 0x90, 0xa5, 0x75, 0x4d, 0x6f, 0x39, 0xa2,
+	0xb6, 0x74, 0x0d, 0xfc, 0x36, 0x67, 0x9b, 0x9c, 0x11, 0x3b, 0x92, 0xfa, 0xde, 0x15, 0xbc, 0x12,
+	0x20, 0xd5, 0xb2, 0xe4, 0x18, 0x88, 0x25, 0x3c, 0x61, 0x8a, 0x66, 0x5c, 0xef, 0x99, 0xdd, 0x77,
+	0x63, 0x6a, 0xfb, 0xce, 0xcb, 0xc8, 0x44, 0xd4, 0x7e, 0xde, 0x14, 0xa8, 0x60, 0x1a, 0xf0, 0xa8,
+	0x31, 0x0d, 0x18, 0x73, 0xa6, 0xa1, 0x69, 0xd2, 0x58, 0x8f, 0xb7, 0x1f, 0xcf, 0x4f, 0x7d, 0x3d,
+	0x14, 0xc0, 0x35, 0xb4, 0x1f, 0xcf, 0x4f, 0x7f, 0x39, 0x88, 0x8a, 0x49, 0xa1, 0x84, 0xa6, 0xb5,
+	0xde, 0xcd, 0xfd, 0xf5, 0x67, 0xe6, 0x14, 0xe9, 0xa0, 0x59, 0x55, 0xe3, 0x78, 0x0d, 0x4d, 0x43,
+	0xfd, 0xc6, 0x5f, 0x66, 0x57, 0x27, 0x1a, 0x41, 0xd2, 0x2a, 0x85, 0x9a, 0x6a, 0x95, 0x30, 0x24,
+	0x54, 0xc2, 0xa4, 0x8b, 0xf2, 0xa0, 0xff, 0x8d, 0x4e, 0xc0, 0x07, 0x28, 0xff, 0xcc, 0xee, 0x8f,
+	0x45, 0xf5, 0xcc, 0x8b, 0xef, 0x31, 0x00, 0xd4, 0x2c, 0x30, 0x22, 0x54, 0xa0, 0xe4, 0x2f, 0x33,
+	0x68, 0x21, 0xd5, 0x40, 0x38, 0x6f, 0x1d, 0x87, 0x2c, 0xd0, 0x6f, 0x52, 0xe0, 0xa8, 0xe0, 0x60,
+	0x8a, 0xb7, 0xc6, 0x00, 0xa1, 0x4a, 0xc6, 0x0f, 0xd5, 0x5e, 0xe0, 0x8f, 0x87, 0xfa, 0x15, 0x0a,
+	0x74, 0x52, 0x40, 0xa5, 0xb9, 0xc8, 0x69, 0x85, 0x10, 0x9a, 0x48, 0xf1, 0x7b, 0x28, 0x3b, 0x76,
+	0x1d, 0x38, 0xc0, 0xf3, 0xb5, 0xd7, 0x5e, 0x46, 0x66, 0x76, 0x07, 0x0e, 0x51, 0x8e, 0x9e, 0xf2,
+	0xbe, 0x09, 0x33, 0xbb, 0x8e, 0x56, 0x08, 0x5c, 0x83, 0x72, 0x39, 0x37, 0xee, 0xb9, 0x4e, 0x29,
+	0x97, 0x18, 0xaf, 0x0b, 0xe3, 0x9e, 0x66, 0xdc, 0x4b, 0x1b, 0xaf, 0x73, 0x63, 0x8e, 0x7d, 0x69,
+	0xa0, 0x59, 0x75, 0x9c, 0xf1, 0x98, 0x43, 0xda, 0x67, 0x21, 0x8c, 0x10, 0xf3, 0x7d, 0x91, 0xee,
+	0x22, 0xe6, 0xfb, 0x90, 0xe7, 0x80, 0x71, 0xa6, 0xe4, 0xef, 0xed, 0x85, 0x6c, 0x04, 0xe1, 0xca,
+	0x0a, 0xa6, 0x24, 0x10, 0xc5, 0x94, 0xc4, 0x90, 0x50, 0x89, 0xe3, 0x1f, 0x49, 0xa6, 0x92, 0x81,
+	0xb5, 0xde, 0x3b, 0x9f, 0xa9, 0xc4, 0x5d, 0x04, 0x44, 0x7c, 0x63, 0x9e, 0x33, 0x5b, 0xb4, 0x66,
+	0xd9, 0xd1, 0x60, 0x63, 0x38, 0x28, 0x4b, 0x51, 0x6c, 0x4c, 0x0c, 0x10, 0xaa, 0x64, 0x32, 0x4f,
+	0x9f, 0xa0, 0x69, 0x41, 0x1d, 0xf0, 0x16, 0x2a, 0x74, 0xfd, 0xb1, 0x37, 0x4a, 0x2e, 0x10, 0xae,
+	0xe9, 0x5f, 0x3e, 0x20, 0xa9, 0xfd, 0x9e, 0x4c, 0x54, 0xa5, 0xaa, 0xea, 0x4c, 0x02, 0xfc, 0x93,
+	0x45, 0x8a, 0xc8, 0x9f, 0x1b, 0x68, 0x46, 0x1a, 0xe2, 0x0d, 0xf5, 0x21, 0x98, 0xab, 0xbd, 0x33,
+	0xc1, 0x88, 0xbe, 0xfe, 0x52, 0x41, 0x67, 0x43, 0xf2, 0x7e, 0x21, 0xc9, 0xe7, 0xdc, 0x6f, 0xcf,
+	0xe7, 0xbf, 0xc8, 0xa3, 0x19, 0xca, 0x89, 0x4b, 0x38, 0xc2, 0x6f, 0xa9, 0x55, 0xe4, 0x6b, 0xaf,
+	0x5e, 0x34, 0x6d, 0x92, 0x0a, 0xf1, 0x17, 0x68, 0x42, 0x7c, 0x33, 0x97, 0x26, 0xbe, 0x71, 0x89,
+	0x66, 0x2f, 0x51, 0xa2, 0x49, 0xba, 0xe4, 0xbe, 0x71, 0xba, 0xe4, 0x2f, 0x9f, 0x2e, 0x71, 0x06,
+	0x4f, 0x5f, 0x22, 0x83, 0xdb, 0xe8, 0xca, 0x5e, 0xe0, 0x0f, 0xe0, 0x9e, 0xc2, 0x0f, 0xf8, 0x4d,
+	0xe3, 0x4c, 0xd2, 0x16, 0xb9, 0x64, 0x3b, 0x16, 0xa8, 0xb6, 0x98, 0x42, 0x09, 0x4d, 0x6b, 0xa5,
+	0x73, 0xb5, 0xf0, 0xcd, 0x72, 0x15, 0x7f, 0x80, 0x0a, 0xe2, 0xa0, 0xf4, 0x7c, 0xa0, 0xbe, 0xf9,
+	0xda, 0xf7, 0x78, 0xaf, 0x07, 0xac, 0xe5, 0xab, 0x1c, 0x94, 0x63, 0xf5, 0xda, 0xb1, 0xc2, 0xc5,
+	0x2c, 0x06, 0xfd, 0xff, 0xb1, 0x18, 0xf2, 0x1b, 0x03, 0x15, 0x28, 0x0b, 0x87, 0xbe, 0x17, 0xb2,
+	0x6f, 0x9b, 0x89, 0xcb, 0x28, 0x07, 0x94, 0x2f, 0x93, 0x6c, 0xa1, 0x23, 0xc8, 0x9c, 0xd8, 0x42,
+	0x07, 0x38, 0x1c, 0x60, 0xf8, 0x43, 0x94, 0xeb, 0xfa, 0x8e, 0xc8, 0xc0, 0x2b, 0x3a, 0xf9, 0x6c,
+	0x04, 0x81, 0x1f, 0xac, 0xfa, 0x8e, 0xa4, 0x4c, 0x5c, 0x49, 0x39, 0xe0, 0x03, 0x42, 0x01, 0x53,
+	0xf9, 0x92, 0xfb, 0xed, 0xf9, 0x42, 0xfe, 0xc1, 0x40, 0xc5, 0xba, 0xff, 0xdc, 0xeb, 0xfb, 0xb6,
+	0xb3, 0x15, 0xf8, 0x3d, 0x7e, 0xe7, 0xf1, 0xad, 0x3e, 0x18, 0x2d, 0x34, 0x33, 0x86, 0xcf, 0xcd,
+	0xf8, 0x93, 0xf1, 0x7e, 0x9a, 0xee, 0x4d, 0x4e, 0x22, 0xbe, 0x4d, 0x93, 0xdb, 0x29, 0x69, 0xac,
+	0xfc, 0x8b, 0x31, 0xa1, 0xb1, 0x80, 0xfc, 0x7d, 0x16, 0x95, 0x2f, 0x76, 0x84, 0x07, 0x68, 0x4e,
+	0x68, 0x5a, 0xda, 0x3d, 0xf0, 0xd2, 0x65, 0xd6, 0x00, 0x24, 0x14, 0x48, 0xd5, 0x58, 0x8d, 0x15,
+	0xa9, 0x4a, 0x20, 0x42, 0x35, 0xf9, 0x37, 0xba, 0xdc, 0xd2, 0xbe, 0xff, 0xb2, 0xdf, 0xfd, 0xfb,
+	0xaf, 0x83, 0x16, 0x44, 0x49, 0xc4, 0xb7, 0x90, 0xb9, 0x4a, 0x76, 0x29, 0x5f, 0x7b, 0xc0, 0x6f,
+	0x36, 0x77, 0xc5, 0xa9, 0x17, 0xdf, 0x3f, 0x5e, 0x4b, 0xf2, 0x5c, 0x80, 0x71, 0x66, 0x16, 0xa7,
+	0x68, 0x4a, 0x17, 0xaf, 0xa5, 0x18, 0xad, 0xe8, 0x4d, 0x7f, 0x70, 0x49, 0x06, 0xab, 0x31, 0x56,
+	0xf2, 0x77, 0x06, 0xca, 0x6d, 0xb9, 0x5e, 0x4f, 0xbb, 0x7d, 0xce, 0x5e, 0xf6, 0xf6, 0x39, 0x60,
+	0xc3, 0xfe, 0x21, 0x04, 0xb4, 0x20, 0x4e, 0x07, 0x00, 0xd4, 0xe9, 0x00, 0x23, 0x42, 0x05, 0xca,
+	0xa9, 0xe8, 0xd0, 0x3e, 0xe4, 0x9b, 0x29, 0x0f, 0x76, 0xa0, 0xa2, 0x12, 0x52, 0xd1, 0x93, 0x63,
+	0x42, 0x63, 0x09, 0x79, 0x0f, 0xe5, 0x57, 0xfb, 0x7e, 0x08, 0xbd, 0x3b, 0x60, 0x76, 0xe8, 0x7b,
+	0x7a, 0x8e, 0x0b, 0x44, 0xe5, 0xa0, 0x18, 0x12, 0x2a, 0x71, 0xb2, 0x81, 0x90, 0xb8, 0xb4, 0xdf,
+	0x1a, 0x87, 0xfb, 0xfc, 0x43, 0x7c, 0x2f, 0xb0, 0x7b, 0x03, 0xe6, 0x8d, 0xe4, 0x4d, 0x29, 0x34,
+	0xc6, 0x18, 0x53, 0x8d, 0x31, 0x06, 0xf8, 0x0f, 0x32, 0xf1, 0xe3, 0xaf, 0x0d, 0x74, 0x65, 0x67,
+	0xd8, 0x0b, 0x6c, 0x87, 0x7d, 0xc7, 0x33, 0xee, 0xed, 0x24, 0xb9, 0x44, 0x2e, 0xde, 0xbd, 0x5c,
+	0x1a, 0x7d, 0x88, 0x50, 0x77, 0x9f, 0x75, 0x9f, 0xea, 0xf7, 0xf7, 0xc0, 0xef, 0x00, 0x95, 0x17,
+	0xf8, 0x62, 0xc7, 0x15, 0x42, 0x68, 0x22, 0x25, 0x9f, 0x65, 0xd0, 0x55, 0xf5, 0x0a, 0xdf, 0xad,
+	0x3b, 0xbe, 0x8d, 0x66, 0x82, 0xb1, 0xe7, 0xb9, 0x5e, 0x4f, 0x7f, 0x07, 0x09, 0xa9, 0x77, 0x90,
+	0x63, 0x42, 0x63, 0x09, 0xdf, 0xc3, 0x3e, 0xef, 0x0d, 0xa3, 0x52, 0x36, 0xd9, 0x43, 0x81, 0xa8,
+	0x3d, 0x14, 0x43, 0x42, 0x25, 0xce, 0x77, 0x6d, 0x2c, 0x56, 0xed, 0xc8, 0xdf, 0x1b, 0x04, 0x27,
+	0x96, 0x58, 0xc2, 0x89, 0x25, 0xc0, 0x39, 0xb1, 0x7c, 0xe4, 0x49, 0xca, 0x78, 0x0f, 0x96, 0x97,
+	0xc0, 0x90, 0xa4, 0x00, 0xa8, 0x24, 0x85, 0x11, 0xa1, 0x02, 0x5d, 0xfe, 0x9f, 0x1c, 0x9a, 0xd3,
+	0x7e, 0x7f, 0xc2, 0x7f, 0x84, 0xee, 0x3c, 0x6a, 0x74, 0x3a, 0xd5, 0xf5, 0x86, 0xb5, 0xfd, 0x78,
+	0xab, 0x61, 0xad, 0x6e, 0xee, 0x74, 0xb6, 0x1b, 0xd4, 0x5a, 0x6d, 0xb7, 0xd6, 0x9a, 0xeb, 0xc5,
+	0xa9, 0xf2, 0xdd, 0xa3, 0xe3, 0x4a, 0x49, 0xb3, 0x48, 0xff, 0x52, 0xf4, 0x3a, 0xc2, 0x29, 0xf3,
+	0x66, 0xab, 0xde, 0xf8, 0xa4, 0x68, 0x94, 0x6f, 0x1c, 0x1d, 0x57, 0x8a, 0x9a, 0x95, 0xb8, 0x80,
+	0xfc, 0x09, 0x7a, 0xe5, 0xac, 0xb6, 0xb5, 0xb3, 0x55, 0xaf, 0x6e, 0x37, 0x8a, 0x99, 0x72, 0xf9,
+	0xe8, 0xb8, 0x72, 0x6b, 0xd2, 0x48, 0xf6, 0xd2, 0x1f, 0xa2, 0x1b, 0x29, 0x53, 0xda, 0xf8, 0xe3,
+	0x9d, 0x46, 0x67, 0xbb, 0x98, 0x2d, 0xdf, 0x3a, 0x3a, 0xae, 0x60, 0xcd, 0x2a, 0x4e, 0xde, 0x15,
+	0x74, 0x73, 0xc2, 0xa2, 0xb3, 0xd5, 0x6e, 0x75, 0x1a, 0xc5, 0x5c, 0xf9, 0xf6, 0xd1, 0x71, 0xe5,
+	0x7a, 0xca, 0x44, 0x26, 0xcb, 0x2a, 0x5a, 0x4c, 0xd9, 0xd4, 0xdb, 0x1f, 0xb7, 0x36, 0xdb, 0xd5,
+	0xba, 0xb5, 0x45, 0xdb, 0xeb, 0xb4, 0xd1, 0xe9, 0x14, 0xf3, 0x65, 0xf3, 0xe8, 0xb8, 0x72, 0x47,
+	0x33, 0x3e, 0x73, 0x54, 0x2d, 0xa3, 0x6b, 0x29, 0x27, 0x5b, 0xcd, 0xd6, 0x7a, 0x71, 0xba, 0x7c,
+	0xfd, 0xe8, 0xb8, 0x72, 0x55, 0xb3, 0x83, 0x9e, 0x34, 0x19, 0xbf, 0xd5, 0xcd, 0x76, 0xa7, 0x51,
+	0x9c, 0x39, 0x13, 0x3f, 0xd1, 0x20, 0xfe, 0x10, 0x95, 0xd2, 0xda, 0xb0, 0x49, 0xd6, 0xd6, 0x4e,
+	0x67, 0xa3, 0x58, 0x28, 0xbf, 0x72, 0x74, 0x5c, 0xb9, 0xa9, 0xdb, 0x24, 0x7d, 0xe1, 0x43, 0x74,
+	0x37, 0x65, 0xb8, 0xb3, 0xb5, 0x4e, 0xab, 0xf5, 0x24, 0x8a, 0xb3, 0xe5, 0x7b, 0x47, 0xc7, 0x95,
+	0x57, 0x34, 0xe3, 0x89, 0x4e, 0x50, 0x45, 0xf7, 0x2e, 0x70, 0x20, 0x83, 0x8a, 0xca, 0x8b, 0x47,
+	0xc7, 0x95, 0xf2, 0x79, 0x1e, 0x44, 0x6c, 0x97, 0xff, 0xd3, 0x40, 0xf8, 0xec, 0xef, 0x95, 0xf8,
+	0x9d, 0xe4, 0x9d, 0x56, 0xdb, 0x8f, 0xb6, 0x78, 0x90, 0x9b, 0xed, 0x96, 0xd5, 0x6a, 0xb7, 0x1a,
+	0xc5, 0xa9, 0x54, 0x4a, 0x68, 0x56, 0x2d, 0xdf, 0xe3, 0xbf, 0x86, 0xdf, 0x3e, 0xcf, 0x72, 0xf3,
+	0xc9, 0x9b, 0x45, 0xa3, 0xbc, 0xa2, 0x05, 0x43, 0x33, 0xdc, 0x7c, 0xf2, 0xe6, 0x17, 0x9f, 0xbe,
+	0x7a, 0xbe, 0xe0, 0xa2, 0xa5, 0x3c, 0xe9, 0x6c, 0xd7, 0x27, 0xb2, 0x53, 0x33, 0x7c, 0x12, 0x8e,
+	0x9c, 0xe5, 0xbf, 0x35, 0xd0, 0x9c, 0xfe, 0x52, 0x3f, 0x42, 0x37, 0x74, 0x0f, 0x8f, 0x1a, 0xdb,
+	0xd5, 0x7a, 0x75, 0xbb, 0x5a, 0x9c, 0x12, 0xa9, 0xa7, 0xa9, 0x3e, 0x62, 0x23, 0x1b, 0x28, 0xd6,
+	0xf7, 0xd1, 0xb5, 0xd4, 0xfb, 0x37, 0x3e, 0x6a, 0xd0, 0xb8, 0x90, 0xf4, 0x37, 0x67, 0xcf, 0x58,
+	0x80, 0x7f, 0x80, 0xb0, 0xae, 0x5c, 0xdd, 0xfc, 0xb8, 0xfa, 0xb8, 0x53, 0xcc, 0x94, 0x6f, 0x1e,
+	0x1d, 0x57, 0xae, 0x69, 0xda, 0xd5, 0xfe, 0x73, 0xfb, 0x30, 0x5c, 0xfe, 0x37, 0x03, 0xe1, 0xb3,
+	0x6c, 0x14, 0x3f, 0x46, 0x77, 0x6a, 0x9b, 0xed, 0xd5, 0x87, 0xd6, 0x46, 0xb5, 0xb3, 0x61, 0x55,
+	0x37, 0xd7, 0xdb, 0xb4, 0xb9, 0xbd, 0xf1, 0xc8, 0xea, 0x6c, 0x54, 0x57, 0xde, 0x7a, 0xbb, 0x38,
+	0x55, 0x7e, 0x87, 0xd7, 0xfe, 0x59, 0x43, 0x21, 0xff, 0xe2, 0xd3, 0x57, 0x2f, 0x94, 0xe1, 0x9f,
+	0xa1, 0xbb, 0xe7, 0xba, 0xae, 0x6d, 0x56, 0x1f, 0x36, 0x56, 0x6a, 0x45, 0xa3, 0xfc, 0x2e, 0x4f,
+	0xb8, 0xb3, 0xf6, 0x42, 0x61, 0xf7, 0x8b, 0x4f, 0x5f, 0xbd, 0x58, 0xb8, 0xfc, 0x2f, 0x19, 0x34,
+	0xaf, 0xdf, 0xd7, 0xe1, 0x1f, 0xa0, 0xeb, 0x6b, 0xcd, 0x4d, 0xde, 0x50, 0xd6, 0xda, 0x22, 0x41,
+	0xf9, 0xb0, 0x38, 0x25, 0xc2, 0xa7, 0xab, 0xf2, 0x67, 0x5e, 0x47, 0x13, 0xea, 0xf5, 0x26, 0x6d,
+	0xac, 0x6e, 0xb7, 0xe9, 0xe3, 0xa2, 0x21, 0xea, 0x48, 0xb7, 0xa9, 0xbb, 0x01, 0x50, 0xa2, 0x43,
+	0xfc, 0x01, 0xba, 0x33, 0x61, 0xd8, 0x79, 0xfc, 0x68, 0xb3, 0xd9, 0x7a, 0x28, 0xe6, 0xcb, 0x40,
+	0x19, 0xdd, 0xd6, 0x6d, 0x3b, 0xe2, 0x3e, 0x99, 0x43, 0x05, 0x03, 0x6f, 0xa0, 0xca, 0x05, 0xf6,
+	0xc9, 0x02, 0xb2, 0x65, 0x72, 0x74, 0x5c, 0xb9, 0x7b, 0x8e, 0x13, 0xb5, 0x8e, 0x82, 0x81, 0x7f,
+	0x8c, 0x6e, 0x9d, 0xef, 0x29, 0x6e, 0x6f, 0xe7, 0xd8, 0x2f, 0xff, 0xbb, 0x81, 0x66, 0x15, 0x63,
+	0xe7, 0x41, 0x6b, 0x50, 0xda, 0xe6, 0xbd, 0xbe, 0xde, 0xb0, 0x5a, 0x6d, 0x0b, 0x46, 0x71, 0xd0,
+	0x94, 0x5e, 0xcb, 0x87, 0x47, 0xde, 0xaa, 0x34, 0xf5, 0xf5, 0x46, 0xab, 0x41, 0x9b, 0xab, 0x71,
+	0x86, 0x2a, 0xed, 0x75, 0xe6, 0xb1, 0xc0, 0xed, 0xe2, 0x37, 0xd1, 0xed, 0xb4, 0xf3, 0xce, 0xce,
+	0xea, 0x46, 0x1c, 0x25, 0x58, 0xa0, 0x36, 0x41, 0x67, 0xdc, 0xdd, 0x87, 0x8d, 0x79, 0x2b, 0x65,
+	0xd5, 0x6c, 0x7d, 0x54, 0xdd, 0x6c, 0xd6, 0x85, 0x55, 0xb6, 0x5c, 0x3a, 0x3a, 0xae, 0xdc, 0x50,
+	0x56, 0xf2, 0xf6, 0x8d, 0x9b, 0x2d, 0x7f, 0x61, 0xa0, 0xc5, 0xaf, 0x27, 0xd3, 0xf8, 0x63, 0xf4,
+	0x1a, 0xc4, 0xeb, 0x4c, 0x47, 0x97, 0xc7, 0x8f, 0x88, 0x61, 0x75, 0x6b, 0xab, 0xd1, 0xaa, 0x17,
+	0xa7, 0xca, 0x4b, 0x47, 0xc7, 0x95, 0xfb, 0x5f, 0xef, 0xb2, 0x3a, 0x1c, 0x32, 0xcf, 0xb9, 0xa4,
+	0xe3, 0xb5, 0x36, 0x5d, 0x6f, 0x6c, 0x17, 0x8d, 0xcb, 0x38, 0x5e, 0xf3, 0xf9, 0x6f, 0x0f, 0xb5,
+	0x47, 0x9f, 0x7f, 0xb9, 0x38, 0xf5, 0xe2, 0xcb, 0xc5, 0xa9, 0xcf, 0x5f, 0x2e, 0x1a, 0x2f, 0x5e,
+	0x2e, 0x1a, 0x7f, 0xf5, 0xd5, 0xe2, 0xd4, 0x67, 0x5f, 0x2d, 0x1a, 0x2f, 0xbe, 0x5a, 0x9c, 0xfa,
+	0x8f, 0xaf, 0x16, 0xa7, 0x9e, 0x7c, 0xbf, 0xe7, 0x8e, 0xf6, 0xc7, 0xbb, 0x0f, 0xba, 0xfe, 0xe0,
+	0x8d, 0xf0, 0xd0, 0xeb, 0x8e, 0xf6, 0x5d, 0xaf, 0xa7, 0x3d, 0xe9, 0xff, 0x89, 0xda, 0x9d, 0x86,
+	0xa7, 0x1f, 0xff, 0xdf, 0x00, 0xf9, 0x1d, 0xd9, 0x8f, 0x2a, 0x25, 0x00, 0x00,
 }
 
 func (m *Hello) Marshal() (dAtA []byte, err error) {
@@ -1120,6 +1489,39 @@ func (m *Hello) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.NextCertificateSignature) > 0 {
+		i -= len(m.NextCertificateSignature)
+		copy(dAtA[i:], m.NextCertificateSignature)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.NextCertificateSignature)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.NextCertificate) > 0 {
+		i -= len(m.NextCertificate)
+		copy(dAtA[i:], m.NextCertificate)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.NextCertificate)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintBep(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Secondary {
+		i--
+		if m.Secondary {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.ClientVersion) > 0 {
 		i -= len(m.ClientVersion)
 		copy(dAtA[i:], m.ClientVersion)
@@ -1539,6 +1941,41 @@ func (m *FileInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.BlockHashAlgorithm != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.BlockHashAlgorithm))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.OwnershipData != nil {
+		{
+			size, err := m.OwnershipData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBep(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
+	}
+	if m.XattrData != nil {
+		{
+			size, err := m.XattrData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBep(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
 	if len(m.Encrypted) > 0 {
 		i -= len(m.Encrypted)
 		copy(dAtA[i:], m.Encrypted)
@@ -1672,52 +2109,7 @@ func (m *FileInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *BlockInfo) Marshal() (dAtA []byte, err error) {
-	size := m.ProtoSize()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *BlockInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.ProtoSize()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *BlockInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.WeakHash != 0 {
-		i = encodeVarintBep(dAtA, i, uint64(m.WeakHash))
-		i--
-		dAtA[i] = 0x20
-	}
-	if len(m.Hash) > 0 {
-		i -= len(m.Hash)
-		copy(dAtA[i:], m.Hash)
-		i = encodeVarintBep(dAtA, i, uint64(len(m.Hash)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Size != 0 {
-		i = encodeVarintBep(dAtA, i, uint64(m.Size))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Offset != 0 {
-		i = encodeVarintBep(dAtA, i, uint64(m.Offset))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Vector) Marshal() (dAtA []byte, err error) {
+func (m *XattrData) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1727,20 +2119,20 @@ func (m *Vector) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Vector) MarshalTo(dAtA []byte) (int, error) {
+func (m *XattrData) MarshalTo(dAtA []byte) (int, error) {
 	size := m.ProtoSize()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Vector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *XattrData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Counters) > 0 {
-		for iNdEx := len(m.Counters) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Xattrs) > 0 {
+		for iNdEx := len(m.Xattrs) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Counters[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Xattrs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -1754,7 +2146,7 @@ func (m *Vector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Counter) Marshal() (dAtA []byte, err error) {
+func (m *Xattr) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1764,22 +2156,188 @@ func (m *Counter) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Counter) MarshalTo(dAtA []byte) (int, error) {
+func (m *Xattr) MarshalTo(dAtA []byte) (int, error) {
 	size := m.ProtoSize()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Counter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Xattr) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Value != 0 {
-		i = encodeVarintBep(dAtA, i, uint64(m.Value))
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Value)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x12
 	}
-	if m.ID != 0 {
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OwnershipData) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OwnershipData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OwnershipData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GID != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.GID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.UID != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.UID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.GroupName) > 0 {
+		i -= len(m.GroupName)
+		copy(dAtA[i:], m.GroupName)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.GroupName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UserName) > 0 {
+		i -= len(m.UserName)
+		copy(dAtA[i:], m.UserName)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.UserName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockInfo) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.WeakHash != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.WeakHash))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Size != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.Size))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Offset != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Vector) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Vector) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Vector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Counters) > 0 {
+		for iNdEx := len(m.Counters) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Counters[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintBep(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Counter) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Counter) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Counter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.Value))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ID != 0 {
 		i = encodeVarintBep(dAtA, i, uint64(m.ID))
 		i--
 		dAtA[i] = 0x8
@@ -1807,6 +2365,11 @@ func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BlockHashAlgorithm != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.BlockHashAlgorithm))
+		i--
+		dAtA[i] = 0x50
+	}
 	if m.BlockNo != 0 {
 		i = encodeVarintBep(dAtA, i, uint64(m.BlockNo))
 		i--
@@ -1886,6 +2449,13 @@ func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.Code != 0 {
 		i = encodeVarintBep(dAtA, i, uint64(m.Code))
 		i--
@@ -2027,6 +2597,28 @@ func (m *Ping) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Reply {
+		i--
+		if m.Reply {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ID != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -2060,75 +2652,226 @@ func (m *Close) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintBep(dAtA []byte, offset int, v uint64) int {
-	offset -= sovBep(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ConfigPush) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Hello) ProtoSize() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.DeviceName)
-	if l > 0 {
-		n += 1 + l + sovBep(uint64(l))
-	}
-	l = len(m.ClientName)
-	if l > 0 {
-		n += 1 + l + sovBep(uint64(l))
-	}
-	l = len(m.ClientVersion)
-	if l > 0 {
-		n += 1 + l + sovBep(uint64(l))
-	}
-	return n
+
+func (m *ConfigPush) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Header) ProtoSize() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ConfigPush) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Type != 0 {
-		n += 1 + sovBep(uint64(m.Type))
-	}
-	if m.Compression != 0 {
-		n += 1 + sovBep(uint64(m.Compression))
+	if len(m.Fragment) > 0 {
+		i -= len(m.Fragment)
+		copy(dAtA[i:], m.Fragment)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Fragment)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ClusterConfig) ProtoSize() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Folders) > 0 {
-		for _, e := range m.Folders {
-			l = e.ProtoSize()
-			n += 1 + l + sovBep(uint64(l))
-		}
+func (m *UpgradeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Folder) ProtoSize() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *UpgradeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpgradeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ID)
+	if m.CheckOnly {
+		i--
+		if m.CheckOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpgradeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpgradeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpgradeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Upgraded {
+		i--
+		if m.Upgraded {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Latest) > 0 {
+		i -= len(m.Latest)
+		copy(dAtA[i:], m.Latest)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Latest)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Running) > 0 {
+		i -= len(m.Running)
+		copy(dAtA[i:], m.Running)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Running)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintBep(dAtA []byte, offset int, v uint64) int {
+	offset -= sovBep(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Hello) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DeviceName)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.ClientName)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.ClientVersion)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	if m.Secondary {
+		n += 2
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovBep(uint64(l))
+		}
+	}
+	l = len(m.NextCertificate)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.NextCertificateSignature)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	return n
+}
+
+func (m *Header) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovBep(uint64(m.Type))
+	}
+	if m.Compression != 0 {
+		n += 1 + sovBep(uint64(m.Compression))
+	}
+	return n
+}
+
+func (m *ClusterConfig) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Folders) > 0 {
+		for _, e := range m.Folders {
+			l = e.ProtoSize()
+			n += 1 + l + sovBep(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Folder) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
 	if l > 0 {
 		n += 1 + l + sovBep(uint64(l))
 	}
@@ -2305,6 +3048,17 @@ func (m *FileInfo) ProtoSize() (n int) {
 	if l > 0 {
 		n += 2 + l + sovBep(uint64(l))
 	}
+	if m.XattrData != nil {
+		l = m.XattrData.ProtoSize()
+		n += 2 + l + sovBep(uint64(l))
+	}
+	if m.OwnershipData != nil {
+		l = m.OwnershipData.ProtoSize()
+		n += 2 + l + sovBep(uint64(l))
+	}
+	if m.BlockHashAlgorithm != 0 {
+		n += 2 + sovBep(uint64(m.BlockHashAlgorithm))
+	}
 	if m.LocalFlags != 0 {
 		n += 2 + sovBep(uint64(m.LocalFlags))
 	}
@@ -2315,6 +3069,61 @@ func (m *FileInfo) ProtoSize() (n int) {
 	return n
 }
 
+func (m *XattrData) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Xattrs) > 0 {
+		for _, e := range m.Xattrs {
+			l = e.ProtoSize()
+			n += 1 + l + sovBep(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Xattr) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	return n
+}
+
+func (m *OwnershipData) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserName)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.GroupName)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	if m.UID != 0 {
+		n += 1 + sovBep(uint64(m.UID))
+	}
+	if m.GID != 0 {
+		n += 1 + sovBep(uint64(m.GID))
+	}
+	return n
+}
+
 func (m *BlockInfo) ProtoSize() (n int) {
 	if m == nil {
 		return 0
@@ -2403,6 +3212,9 @@ func (m *Request) ProtoSize() (n int) {
 	if m.BlockNo != 0 {
 		n += 1 + sovBep(uint64(m.BlockNo))
 	}
+	if m.BlockHashAlgorithm != 0 {
+		n += 1 + sovBep(uint64(m.BlockHashAlgorithm))
+	}
 	return n
 }
 
@@ -2422,6 +3234,10 @@ func (m *Response) ProtoSize() (n int) {
 	if m.Code != 0 {
 		n += 1 + sovBep(uint64(m.Code))
 	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
 	return n
 }
 
@@ -2476,6 +3292,16 @@ func (m *Ping) ProtoSize() (n int) {
 	}
 	var l int
 	_ = l
+	if m.ID != 0 {
+		n += 1 + sovBep(uint64(m.ID))
+	}
+	if m.Reply {
+		n += 2
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
 	return n
 }
 
@@ -2492,6 +3318,65 @@ func (m *Close) ProtoSize() (n int) {
 	return n
 }
 
+func (m *ConfigPush) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Fragment)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	return n
+}
+
+func (m *UpgradeRequest) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovBep(uint64(m.ID))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	if m.CheckOnly {
+		n += 2
+	}
+	return n
+}
+
+func (m *UpgradeResponse) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovBep(uint64(m.ID))
+	}
+	l = len(m.Running)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.Latest)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	if m.Upgraded {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	return n
+}
+
 func sovBep(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2623,10 +3508,130 @@ func (m *Hello) Unmarshal(dAtA []byte) error {
 			}
 			m.ClientVersion = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipBep(dAtA[iNdEx:])
-			if err != nil {
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secondary", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Secondary = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCertificate", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextCertificate = append(m.NextCertificate[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextCertificate == nil {
+				m.NextCertificate = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCertificateSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextCertificateSignature = append(m.NextCertificateSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextCertificateSignature == nil {
+				m.NextCertificateSignature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
 			if skippy < 0 {
@@ -4067,11 +5072,11 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				m.Encrypted = []byte{}
 			}
 			iNdEx = postIndex
-		case 1000:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LocalFlags", wireType)
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field XattrData", wireType)
 			}
-			m.LocalFlags = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4081,16 +5086,33 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LocalFlags |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 1001:
+			if msglen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.XattrData == nil {
+				m.XattrData = &XattrData{}
+			}
+			if err := m.XattrData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VersionHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OwnershipData", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4100,84 +5122,33 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthBep
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthBep
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.VersionHash = append(m.VersionHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.VersionHash == nil {
-				m.VersionHash = []byte{}
+			if m.OwnershipData == nil {
+				m.OwnershipData = &OwnershipData{}
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipBep(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.OwnershipData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthBep
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthBep
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *BlockInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowBep
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BlockInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 22:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHashAlgorithm", wireType)
 			}
-			m.Offset = 0
+			m.BlockHashAlgorithm = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4187,16 +5158,16 @@ func (m *BlockInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Offset |= int64(b&0x7F) << shift
+				m.BlockHashAlgorithm |= BlockHashAlgorithm(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 1000:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalFlags", wireType)
 			}
-			m.Size = 0
+			m.LocalFlags = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4206,14 +5177,14 @@ func (m *BlockInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Size |= int(b&0x7F) << shift
+				m.LocalFlags |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 1001:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VersionHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4240,30 +5211,11 @@ func (m *BlockInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
+			m.VersionHash = append(m.VersionHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.VersionHash == nil {
+				m.VersionHash = []byte{}
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WeakHash", wireType)
-			}
-			m.WeakHash = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowBep
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.WeakHash |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBep(dAtA[iNdEx:])
@@ -4288,7 +5240,7 @@ func (m *BlockInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Vector) Unmarshal(dAtA []byte) error {
+func (m *XattrData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4311,15 +5263,15 @@ func (m *Vector) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Vector: wiretype end group for non-group")
+			return fmt.Errorf("proto: XattrData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Vector: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: XattrData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Counters", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Xattrs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4346,8 +5298,8 @@ func (m *Vector) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Counters = append(m.Counters, Counter{})
-			if err := m.Counters[len(m.Counters)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Xattrs = append(m.Xattrs, Xattr{})
+			if err := m.Xattrs[len(m.Xattrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4375,7 +5327,7 @@ func (m *Vector) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Counter) Unmarshal(dAtA []byte) error {
+func (m *Xattr) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4398,17 +5350,17 @@ func (m *Counter) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Counter: wiretype end group for non-group")
+			return fmt.Errorf("proto: Xattr: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Counter: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Xattr: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			m.ID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4418,16 +5370,29 @@ func (m *Counter) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= ShortID(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
+			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			m.Value = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4437,11 +5402,26 @@ func (m *Counter) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Value |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBep(dAtA[iNdEx:])
@@ -4466,7 +5446,7 @@ func (m *Counter) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *OwnershipData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4489,34 +5469,15 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: OwnershipData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OwnershipData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			m.ID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowBep
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ID |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Folder", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4544,11 +5505,11 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Folder = string(dAtA[iNdEx:postIndex])
+			m.UserName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4576,105 +5537,13 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
-			}
-			m.Offset = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowBep
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Offset |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size", wireType)
-			}
-			m.Size = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowBep
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Size |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowBep
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthBep
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthBep
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
-			}
+			m.GroupName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FromTemporary", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowBep
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.FromTemporary = bool(v != 0)
-		case 8:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WeakHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
-			m.WeakHash = 0
+			m.UID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4684,16 +5553,16 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.WeakHash |= uint32(b&0x7F) << shift
+				m.UID |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockNo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
 			}
-			m.BlockNo = 0
+			m.GID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4703,7 +5572,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockNo |= int(b&0x7F) << shift
+				m.GID |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4732,7 +5601,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *BlockInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4755,17 +5624,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			m.ID = 0
+			m.Offset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4775,14 +5644,33 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= int(b&0x7F) << shift
+				m.Offset |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size", wireType)
+			}
+			m.Size = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4809,16 +5697,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field WeakHash", wireType)
 			}
-			m.Code = 0
+			m.WeakHash = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -4828,7 +5716,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= ErrorCode(b&0x7F) << shift
+				m.WeakHash |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4857,7 +5745,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DownloadProgress) Unmarshal(dAtA []byte) error {
+func (m *Vector) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4880,47 +5768,15 @@ func (m *DownloadProgress) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DownloadProgress: wiretype end group for non-group")
+			return fmt.Errorf("proto: Vector: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DownloadProgress: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Vector: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Folder", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowBep
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthBep
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthBep
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Folder = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Updates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Counters", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4947,8 +5803,8 @@ func (m *DownloadProgress) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Updates = append(m.Updates, FileDownloadProgressUpdate{})
-			if err := m.Updates[len(m.Updates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Counters = append(m.Counters, Counter{})
+			if err := m.Counters[len(m.Counters)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4976,7 +5832,661 @@ func (m *DownloadProgress) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FileDownloadProgressUpdate) Unmarshal(dAtA []byte) error {
+func (m *Counter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Counter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Counter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= ShortID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Request) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Folder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Folder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size", wireType)
+			}
+			m.Size = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromTemporary", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FromTemporary = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WeakHash", wireType)
+			}
+			m.WeakHash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WeakHash |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockNo", wireType)
+			}
+			m.BlockNo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockNo |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHashAlgorithm", wireType)
+			}
+			m.BlockHashAlgorithm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHashAlgorithm |= BlockHashAlgorithm(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= ErrorCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DownloadProgress) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DownloadProgress: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DownloadProgress: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Folder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Folder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Updates = append(m.Updates, FileDownloadProgressUpdate{})
+			if err := m.Updates[len(m.Updates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FileDownloadProgressUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5002,14 +6512,544 @@ func (m *FileDownloadProgressUpdate) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: FileDownloadProgressUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FileDownloadProgressUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FileDownloadProgressUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateType", wireType)
+			}
+			m.UpdateType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdateType |= FileDownloadProgressUpdateType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType == 0 {
+				var v int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowBep
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.BlockIndexes = append(m.BlockIndexes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowBep
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthBep
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthBep
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.BlockIndexes) == 0 {
+					m.BlockIndexes = make([]int, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowBep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.BlockIndexes = append(m.BlockIndexes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockIndexes", wireType)
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockSize", wireType)
+			}
+			m.BlockSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockSize |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Ping) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Ping: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Ping: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reply", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reply = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Close) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Close: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Close: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigPush) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigPush: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigPush: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fragment", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fragment = append(m.Fragment[:0], dAtA[iNdEx:postIndex]...)
+			if m.Fragment == nil {
+				m.Fragment = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpgradeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpgradeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpgradeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdateType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			m.UpdateType = 0
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -5019,14 +7059,14 @@ func (m *FileDownloadProgressUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.UpdateType |= FileDownloadProgressUpdateType(b&0x7F) << shift
+				m.ID |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5054,122 +7094,13 @@ func (m *FileDownloadProgressUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowBep
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthBep
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthBep
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType == 0 {
-				var v int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowBep
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.BlockIndexes = append(m.BlockIndexes, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowBep
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthBep
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthBep
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.BlockIndexes) == 0 {
-					m.BlockIndexes = make([]int, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowBep
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.BlockIndexes = append(m.BlockIndexes, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockIndexes", wireType)
-			}
-		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockSize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckOnly", wireType)
 			}
-			m.BlockSize = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowBep
@@ -5179,11 +7110,12 @@ func (m *FileDownloadProgressUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockSize |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.CheckOnly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBep(dAtA[iNdEx:])
@@ -5208,7 +7140,7 @@ func (m *FileDownloadProgressUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Ping) Unmarshal(dAtA []byte) error {
+func (m *UpgradeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5231,68 +7163,118 @@ func (m *Ping) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Ping: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpgradeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Ping: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpgradeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipBep(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			if skippy < 0 {
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Running", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthBep
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthBep
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Close) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowBep
+			m.Running = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Latest", wireType)
 			}
-			if iNdEx >= l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Latest = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upgraded", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Close: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Close: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Upgraded = bool(v != 0)
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5320,7 +7302,7 @@ func (m *Close) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Reason = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"sort"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/rand"
@@ -128,6 +129,34 @@ func (f FileInfo) BlockSize() int {
 	return int(f.RawBlockSize)
 }
 
+// HasFixedSizeBlocks returns whether all blocks but the last are of the
+// file's block size, i.e. the file was not split into blocks at content
+// defined boundaries and the index of a block follows from its offset.
+func (f FileInfo) HasFixedSizeBlocks() bool {
+	blockSize := f.BlockSize()
+	for i := 0; i < len(f.Blocks)-1; i++ {
+		if f.Blocks[i].Size != blockSize {
+			return false
+		}
+	}
+	return true
+}
+
+// BlockIndex returns the index of the block starting at the given offset,
+// or -1 if there is none.
+func (f FileInfo) BlockIndex(offset int64) int {
+	if i := int(offset / int64(f.BlockSize())); i < len(f.Blocks) && f.Blocks[i].Offset == offset {
+		return i
+	}
+	i := sort.Search(len(f.Blocks), func(i int) bool {
+		return f.Blocks[i].Offset >= offset
+	})
+	if i < len(f.Blocks) && f.Blocks[i].Offset == offset {
+		return i
+	}
+	return -1
+}
+
 func (f FileInfo) FileName() string {
 	return f.Name
 }
@@ -206,6 +235,8 @@ func (f FileInfo) IsEquivalentOptional(other FileInfo, modTimeWindow time.Durati
 //  - deleted flag
 //  - invalid flag
 //  - permissions, unless they are ignored
+//  - extended attributes, if both have them
+//  - ownership, if both have it
 // A file is not "equivalent", if it has different
 //  - modification time (difference bigger than modTimeWindow)
 //  - size
@@ -233,6 +264,16 @@ func (f FileInfo) isEquivalent(other FileInfo, modTimeWindow time.Duration, igno
 		return false
 	}
 
+	// Extended attributes are only compared if both sides have them, i.e.
+	// sync them.
+	if f.XattrData != nil && other.XattrData != nil && !f.XattrData.Equal(other.XattrData) {
+		return false
+	}
+	// Likewise ownership.
+	if f.OwnershipData != nil && other.OwnershipData != nil && !f.OwnershipData.Equal(other.OwnershipData) {
+		return false
+	}
+
 	switch f.Type {
 	case FileInfoTypeFile:
 		return f.Size == other.Size && ModTimeEqual(f.ModTime(), other.ModTime(), modTimeWindow) && (ignoreBlocks || f.BlocksEqual(other))
@@ -297,6 +338,32 @@ func blocksEqual(a, b []BlockInfo) bool {
 	return true
 }
 
+// Equal returns true when both have the same owner and group. They are
+// compared by name where both sides know it, as the IDs may differ between
+// devices, and otherwise by ID, which is also what is applied when a name
+// doesn't exist locally.
+func (o *OwnershipData) Equal(other *OwnershipData) bool {
+	return sameOwner(o.UserName, other.UserName, o.UID, other.UID) && sameOwner(o.GroupName, other.GroupName, o.GID, other.GID)
+}
+
+func sameOwner(name, otherName string, id, otherID int) bool {
+	return (name != "" && name == otherName) || id == otherID
+}
+
+// Equal returns true when both have the same extended attributes, which are
+// expected to be sorted by name.
+func (x *XattrData) Equal(other *XattrData) bool {
+	if len(x.Xattrs) != len(other.Xattrs) {
+		return false
+	}
+	for i, xa := range x.Xattrs {
+		if xa.Name != other.Xattrs[i].Name || !bytes.Equal(xa.Value, other.Xattrs[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
 func (f *FileInfo) SetMustRescan() {
 	f.setLocalFlags(FlagLocalMustRescan)
 }
@@ -341,6 +408,42 @@ func (b BlockInfo) IsEmpty() bool {
 	return false
 }
 
+// EmptyBlockHash returns the hash of a full block of zeroes of the given
+// size, which must be one of the block sizes.
+func EmptyBlockHash(size int) ([]byte, bool) {
+	v, ok := sha256OfEmptyBlock[size]
+	return v[:], ok
+}
+
+// StripEmptyBlockHashes leaves out the hashes of the full blocks of zeroes,
+// which a device announcing FeatureSparse restores on reception.
+func (f *FileInfo) StripEmptyBlockHashes() {
+	stripped := false
+	for i, b := range f.Blocks {
+		if !b.IsEmpty() {
+			continue
+		}
+		if !stripped {
+			// The blocks may be shared with other file infos.
+			f.Blocks = append([]BlockInfo(nil), f.Blocks...)
+			stripped = true
+		}
+		f.Blocks[i].Hash = nil
+	}
+}
+
+// RestoreEmptyBlockHashes undoes StripEmptyBlockHashes.
+func (f *FileInfo) RestoreEmptyBlockHashes() {
+	for i, b := range f.Blocks {
+		if len(b.Hash) > 0 {
+			continue
+		}
+		if hash, ok := EmptyBlockHash(int(b.Size)); ok {
+			f.Blocks[i].Hash = hash
+		}
+	}
+}
+
 type IndexID uint64
 
 func (i IndexID) String() string {
@@ -0,0 +1,28 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+package protocol
+
+import "fmt"
+
+var blockHashAlgorithmMarshal = map[BlockHashAlgorithm]string{
+	BlockHashAlgorithmSHA256:  "sha256",
+	BlockHashAlgorithmBLAKE2b: "blake2b",
+}
+
+var blockHashAlgorithmUnmarshal = map[string]BlockHashAlgorithm{
+	"sha256":  BlockHashAlgorithmSHA256,
+	"blake2b": BlockHashAlgorithmBLAKE2b,
+}
+
+func (a BlockHashAlgorithm) GoString() string {
+	return fmt.Sprintf("%q", a.String())
+}
+
+func (a BlockHashAlgorithm) MarshalText() ([]byte, error) {
+	return []byte(blockHashAlgorithmMarshal[a]), nil
+}
+
+func (a *BlockHashAlgorithm) UnmarshalText(bs []byte) error {
+	*a = blockHashAlgorithmUnmarshal[string(bs)]
+	return nil
+}
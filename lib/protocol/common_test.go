@@ -13,8 +13,11 @@ type TestModel struct {
 	hash          []byte
 	weakHash      uint32
 	fromTemporary bool
+	verifiedHash  []byte
 	indexFn       func(DeviceID, string, []FileInfo)
 	ccFn          func(DeviceID, ClusterConfig)
+	pushFn        func(DeviceID, ConfigPush)
+	upgradeFn     func(DeviceID, UpgradeRequest)
 	closedCh      chan struct{}
 	closedErr     error
 }
@@ -36,7 +39,7 @@ func (t *TestModel) IndexUpdate(deviceID DeviceID, folder string, files []FileIn
 	return nil
 }
 
-func (t *TestModel) Request(deviceID DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, weakHash uint32, fromTemporary bool) (RequestResponse, error) {
+func (t *TestModel) Request(deviceID DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, hashAlgo BlockHashAlgorithm, weakHash uint32, fromTemporary bool) (RequestResponse, error) {
 	t.folder = folder
 	t.name = name
 	t.offset = offset
@@ -46,7 +49,7 @@ func (t *TestModel) Request(deviceID DeviceID, folder, name string, blockNo, siz
 	t.fromTemporary = fromTemporary
 	buf := make([]byte, len(t.data))
 	copy(buf, t.data)
-	return &fakeRequestResponse{buf}, nil
+	return &fakeRequestResponse{data: buf, hash: t.verifiedHash}, nil
 }
 
 func (t *TestModel) Closed(conn Connection, err error) {
@@ -65,6 +68,20 @@ func (t *TestModel) DownloadProgress(DeviceID, string, []FileDownloadProgressUpd
 	return nil
 }
 
+func (t *TestModel) ConfigPush(deviceID DeviceID, push ConfigPush) error {
+	if t.pushFn != nil {
+		t.pushFn(deviceID, push)
+	}
+	return nil
+}
+
+func (t *TestModel) UpgradeRequest(deviceID DeviceID, req UpgradeRequest) error {
+	if t.upgradeFn != nil {
+		t.upgradeFn(deviceID, req)
+	}
+	return nil
+}
+
 func (t *TestModel) closedError() error {
 	select {
 	case <-t.closedCh:
@@ -76,6 +93,7 @@ func (t *TestModel) closedError() error {
 
 type fakeRequestResponse struct {
 	data []byte
+	hash []byte
 }
 
 func (r *fakeRequestResponse) Data() []byte {
@@ -85,3 +103,7 @@ func (r *fakeRequestResponse) Data() []byte {
 func (r *fakeRequestResponse) Close() {}
 
 func (r *fakeRequestResponse) Wait() {}
+
+func (r *fakeRequestResponse) Hash() []byte {
+	return r.hash
+}
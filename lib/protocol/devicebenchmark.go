@@ -0,0 +1,159 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+package protocol
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// Benchmark data is sent in pings carrying this much payload, with at
+	// most benchmarkWindow of them unanswered at a time.
+	benchmarkChunkSize = 128 << 10
+	benchmarkWindow    = 64
+	// The round trip time is the best of this many pings without payload.
+	benchmarkRTTSamples = 5
+	// Giving up when the other side doesn't answer for this long.
+	benchmarkTimeout = 30 * time.Second
+)
+
+var (
+	errBenchmarkRunning = errors.New("a benchmark is already running on the connection")
+	errBenchmarkTimeout = errors.New("timed out waiting for the other side to answer")
+)
+
+// BenchmarkResult is the outcome of a benchmark, see Connection.Benchmark.
+type BenchmarkResult struct {
+	// The time it took to send the data and have all of it acknowledged.
+	Duration time.Duration
+	Bytes    int64
+	// The best round trip time before sending data, i.e. on an otherwise
+	// quiet connection.
+	RTT time.Duration
+}
+
+// BytesPerSecond returns the measured throughput.
+func (r BenchmarkResult) BytesPerSecond() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Bytes) / r.Duration.Seconds()
+}
+
+// benchmarker passes the answers to benchmark pings, which have negative
+// IDs so as not to be mistaken for the regular ones, to the running
+// benchmark.
+type benchmarker struct {
+	mut  sync.Mutex
+	acks chan struct{}
+}
+
+func (b *benchmarker) start() (chan struct{}, bool) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	if b.acks != nil {
+		return nil, false
+	}
+	b.acks = make(chan struct{}, benchmarkWindow)
+	return b.acks, true
+}
+
+func (b *benchmarker) stop() {
+	b.mut.Lock()
+	b.acks = nil
+	b.mut.Unlock()
+}
+
+func (b *benchmarker) reply() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	if b.acks != nil {
+		select {
+		case b.acks <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Benchmark sends synthetic data to the other side for the given duration,
+// as fast as the connection allows, and measures the throughput and round
+// trip time. It works with any device that answers pings.
+func (c *rawConnection) Benchmark(ctx context.Context, duration time.Duration) (BenchmarkResult, error) {
+	acks, ok := c.bench.start()
+	if !ok {
+		return BenchmarkResult{}, errBenchmarkRunning
+	}
+	defer c.bench.stop()
+
+	var res BenchmarkResult
+	var id int64
+	send := func(payload []byte) error {
+		id--
+		if !c.send(ctx, &Ping{ID: id, Payload: payload}, nil) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return ErrClosed
+		}
+		return nil
+	}
+	wait := func() error {
+		select {
+		case <-acks:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.closed:
+			return ErrClosed
+		case <-time.After(benchmarkTimeout):
+			return errBenchmarkTimeout
+		}
+	}
+
+	for i := 0; i < benchmarkRTTSamples; i++ {
+		sent := time.Now()
+		if err := send(nil); err != nil {
+			return res, err
+		}
+		if err := wait(); err != nil {
+			return res, err
+		}
+		if rtt := time.Since(sent); res.RTT == 0 || rtt < res.RTT {
+			res.RTT = rtt
+		}
+	}
+
+	// Random, so that compression doesn't make the connection look faster
+	// than it is.
+	payload := make([]byte, benchmarkChunkSize)
+	if _, err := rand.Read(payload); err != nil {
+		return res, err
+	}
+
+	start := time.Now()
+	deadline := start.Add(duration)
+	outstanding := 0
+	for {
+		for outstanding < benchmarkWindow && time.Now().Before(deadline) {
+			if err := send(payload); err != nil {
+				return res, err
+			}
+			outstanding++
+		}
+		if outstanding == 0 {
+			break
+		}
+		if err := wait(); err != nil {
+			return res, err
+		}
+		outstanding--
+		res.Bytes += benchmarkChunkSize
+	}
+	res.Duration = time.Since(start)
+
+	return res, nil
+}
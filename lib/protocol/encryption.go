@@ -64,10 +64,10 @@ func (e encryptedModel) IndexUpdate(deviceID DeviceID, folder string, files []Fi
 	return e.model.IndexUpdate(deviceID, folder, files)
 }
 
-func (e encryptedModel) Request(deviceID DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, weakHash uint32, fromTemporary bool) (RequestResponse, error) {
+func (e encryptedModel) Request(deviceID DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, hashAlgo BlockHashAlgorithm, weakHash uint32, fromTemporary bool) (RequestResponse, error) {
 	folderKey, ok := e.folderKeys[folder]
 	if !ok {
-		return e.model.Request(deviceID, folder, name, blockNo, size, offset, hash, weakHash, fromTemporary)
+		return e.model.Request(deviceID, folder, name, blockNo, size, offset, hash, hashAlgo, weakHash, fromTemporary)
 	}
 
 	// Figure out the real file name, offset and size from the encrypted /
@@ -100,7 +100,7 @@ func (e encryptedModel) Request(deviceID DeviceID, folder, name string, blockNo,
 
 	// Perform that request and grab the data.
 
-	resp, err := e.model.Request(deviceID, folder, realName, blockNo, realSize, realOffset, realHash, 0, false)
+	resp, err := e.model.Request(deviceID, folder, realName, blockNo, realSize, realOffset, realHash, BlockHashAlgorithmSHA256, 0, false)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +131,14 @@ func (e encryptedModel) DownloadProgress(deviceID DeviceID, folder string, updat
 	return nil
 }
 
+func (e encryptedModel) ConfigPush(deviceID DeviceID, push ConfigPush) error {
+	return e.model.ConfigPush(deviceID, push)
+}
+
+func (e encryptedModel) UpgradeRequest(deviceID DeviceID, req UpgradeRequest) error {
+	return e.model.UpgradeRequest(deviceID, req)
+}
+
 func (e encryptedModel) ClusterConfig(deviceID DeviceID, config ClusterConfig) error {
 	return e.model.ClusterConfig(deviceID, config)
 }
@@ -169,10 +177,10 @@ func (e encryptedConnection) IndexUpdate(ctx context.Context, folder string, fil
 	return e.conn.IndexUpdate(ctx, folder, files)
 }
 
-func (e encryptedConnection) Request(ctx context.Context, folder string, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error) {
+func (e encryptedConnection) Request(ctx context.Context, folder string, name string, blockNo int, offset int64, size int, hash []byte, hashAlgo BlockHashAlgorithm, weakHash uint32, fromTemporary bool) ([]byte, bool, error) {
 	folderKey, ok := e.folderKeys[folder]
 	if !ok {
-		return e.conn.Request(ctx, folder, name, blockNo, offset, size, hash, weakHash, fromTemporary)
+		return e.conn.Request(ctx, folder, name, blockNo, offset, size, hash, hashAlgo, weakHash, fromTemporary)
 	}
 
 	// Encrypt / adjust the request parameters.
@@ -189,9 +197,9 @@ func (e encryptedConnection) Request(ctx context.Context, folder string, name st
 
 	// Perform that request, getting back and encrypted block.
 
-	bs, err := e.conn.Request(ctx, folder, encName, blockNo, encOffset, encSize, nil, 0, false)
+	bs, _, err := e.conn.Request(ctx, folder, encName, blockNo, encOffset, encSize, nil, 0, 0, false)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// Return the decrypted block (or an error if it fails decryption)
@@ -199,9 +207,9 @@ func (e encryptedConnection) Request(ctx context.Context, folder string, name st
 	fileKey := FileKey(name, folderKey)
 	bs, err = DecryptBytes(bs, fileKey)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return bs[:origSize], nil
+	return bs[:origSize], false, nil
 }
 
 func (e encryptedConnection) DownloadProgress(ctx context.Context, folder string, updates []FileDownloadProgressUpdate) {
@@ -212,6 +220,22 @@ func (e encryptedConnection) DownloadProgress(ctx context.Context, folder string
 	// No need to send these
 }
 
+func (e encryptedConnection) ConfigPush(ctx context.Context, push ConfigPush) error {
+	return e.conn.ConfigPush(ctx, push)
+}
+
+func (e encryptedConnection) UpgradeRequest(ctx context.Context, version string, checkOnly bool) (UpgradeResponse, error) {
+	return e.conn.UpgradeRequest(ctx, version, checkOnly)
+}
+
+func (e encryptedConnection) UpgradeResponse(ctx context.Context, resp UpgradeResponse) error {
+	return e.conn.UpgradeResponse(ctx, resp)
+}
+
+func (e encryptedConnection) Benchmark(ctx context.Context, duration time.Duration) (BenchmarkResult, error) {
+	return e.conn.Benchmark(ctx, duration)
+}
+
 func (e encryptedConnection) ClusterConfig(config ClusterConfig) {
 	e.conn.ClusterConfig(config)
 }
@@ -537,8 +561,9 @@ func (r rawResponse) Data() []byte {
 	return r.data
 }
 
-func (r rawResponse) Close() {}
-func (r rawResponse) Wait()  {}
+func (r rawResponse) Close()       {}
+func (r rawResponse) Wait()        {}
+func (r rawResponse) Hash() []byte { return nil }
 
 // IsEncryptedPath returns true if the path points at encrypted data. This is
 // determined by checking for a sentinel string in the path.
@@ -25,6 +25,38 @@ var (
 	ErrUnknownMagic = errors.New("the remote device speaks an unknown (newer?) version of the protocol")
 )
 
+// Optional features announced in the Hello message. A feature is only used
+// on a connection if both sides announce it.
+const (
+	// Extended attributes are sent as part of the file info.
+	FeatureXattrs = "xattrs"
+	// Full blocks of zeroes are sent without their hashes.
+	FeatureSparse = "sparse"
+	// Messages may be compressed with zstd instead of LZ4.
+	FeatureZstd = "zstd"
+	// ConfigPush messages are understood, though only applied if the
+	// sender is trusted to manage the configuration.
+	FeatureConfigPush = "configPush"
+	// The owner and group are sent as part of the file info.
+	FeatureOwnership = "ownership"
+	// UpgradeRequest messages are understood and answered, though only
+	// acted upon if the sender is trusted to manage upgrades.
+	FeatureUpgradeRequests = "upgradeRequests"
+	// Block hashes may be BLAKE2b instead of SHA-256, as given by the file
+	// info and request.
+	FeatureBLAKE2b = "blake2b"
+)
+
+// HasFeature returns true if the other side announced the given feature.
+func (m Hello) HasFeature(feature string) bool {
+	for _, f := range m.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
 func ExchangeHello(c io.ReadWriter, h HelloIntf) (Hello, error) {
 	if err := writeHello(c, h); err != nil {
 		return Hello{}, err
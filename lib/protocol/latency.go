@@ -0,0 +1,74 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+package protocol
+
+import (
+	"sync"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// pingRTTTimer aggregates the round trip times measured on all connections.
+var pingRTTTimer = metrics.GetOrRegisterTimer("bep ping rtt", nil)
+
+// latencyTracker measures the round trip time of a connection by keeping
+// track of the outstanding ping and the time it was sent. The smoothed round
+// trip time follows RFC 6298 and the jitter, the mean deviation between
+// consecutive samples, follows RFC 3550.
+type latencyTracker struct {
+	mut      sync.Mutex
+	lastID   int64
+	sentAt   time.Time
+	previous time.Duration
+	srtt     time.Duration
+	jitter   time.Duration
+	samples  int
+}
+
+// next returns the ID to use for the next ping, and notes that it is being
+// sent now.
+func (t *latencyTracker) next() int64 {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	t.lastID++
+	t.sentAt = time.Now()
+	return t.lastID
+}
+
+// reply records a sample for the ping reply with the given ID. Replies that
+// don't match the most recently sent ping are ignored.
+func (t *latencyTracker) reply(id int64) (time.Duration, bool) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if id != t.lastID || t.sentAt.IsZero() {
+		return 0, false
+	}
+	rtt := time.Since(t.sentAt)
+	t.sentAt = time.Time{}
+	t.add(rtt)
+	return rtt, true
+}
+
+func (t *latencyTracker) add(rtt time.Duration) {
+	if t.samples == 0 {
+		t.srtt = rtt
+	} else {
+		t.srtt += (rtt - t.srtt) / 8
+		d := rtt - t.previous
+		if d < 0 {
+			d = -d
+		}
+		t.jitter += (d - t.jitter) / 16
+	}
+	t.previous = rtt
+	t.samples++
+}
+
+// stats returns the smoothed round trip time and jitter, or zeroes if no
+// sample has been recorded yet.
+func (t *latencyTracker) stats() (rtt, jitter time.Duration) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	return t.srtt, t.jitter
+}
@@ -3,6 +3,7 @@
 package protocol
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
@@ -108,6 +109,7 @@ var (
 	errDeletedHasBlocks   = errors.New("deleted file with non-empty block list")
 	errDirectoryHasBlocks = errors.New("directory with non-empty block list")
 	errFileHasNoBlocks    = errors.New("file with empty block list")
+	errResponseHash       = errors.New("response hash does not match request")
 )
 
 type Model interface {
@@ -115,20 +117,29 @@ type Model interface {
 	Index(deviceID DeviceID, folder string, files []FileInfo) error
 	// An index update was received from the peer device
 	IndexUpdate(deviceID DeviceID, folder string, files []FileInfo) error
-	// A request was made by the peer device
-	Request(deviceID DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, weakHash uint32, fromTemporary bool) (RequestResponse, error)
+	// A request was made by the peer device. If a hash is given, the
+	// returned data must have been verified to match it.
+	Request(deviceID DeviceID, folder, name string, blockNo, size int32, offset int64, hash []byte, hashAlgo BlockHashAlgorithm, weakHash uint32, fromTemporary bool) (RequestResponse, error)
 	// A cluster configuration message was received
 	ClusterConfig(deviceID DeviceID, config ClusterConfig) error
 	// The peer device closed the connection
 	Closed(conn Connection, err error)
 	// The peer device sent progress updates for the files it is currently downloading
 	DownloadProgress(deviceID DeviceID, folder string, updates []FileDownloadProgressUpdate) error
+	// The peer device sent a configuration fragment to apply
+	ConfigPush(deviceID DeviceID, push ConfigPush) error
+	// The peer device asked us to upgrade. The answer is sent separately
+	// using Connection.UpgradeResponse.
+	UpgradeRequest(deviceID DeviceID, req UpgradeRequest) error
 }
 
 type RequestResponse interface {
 	Data() []byte
 	Close() // Must always be called once the byte slice is no longer in use
 	Wait()  // Blocks until Close is called
+	// Hash returns the strong hash of the block in the local index that
+	// the data was verified against, or nil if it wasn't.
+	Hash() []byte
 }
 
 type Connection interface {
@@ -137,9 +148,19 @@ type Connection interface {
 	ID() DeviceID
 	Index(ctx context.Context, folder string, files []FileInfo) error
 	IndexUpdate(ctx context.Context, folder string, files []FileInfo) error
-	Request(ctx context.Context, folder string, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error)
+	// Request returns the requested data, and whether the other side
+	// verified it against the given hash before sending it.
+	Request(ctx context.Context, folder string, name string, blockNo int, offset int64, size int, hash []byte, hashAlgo BlockHashAlgorithm, weakHash uint32, fromTemporary bool) ([]byte, bool, error)
 	ClusterConfig(config ClusterConfig)
 	DownloadProgress(ctx context.Context, folder string, updates []FileDownloadProgressUpdate)
+	// ConfigPush must only be used when the other side announced
+	// FeatureConfigPush.
+	ConfigPush(ctx context.Context, push ConfigPush) error
+	// UpgradeRequest must only be used when the other side announced
+	// FeatureUpgradeRequests.
+	UpgradeRequest(ctx context.Context, version string, checkOnly bool) (UpgradeResponse, error)
+	UpgradeResponse(ctx context.Context, resp UpgradeResponse) error
+	Benchmark(ctx context.Context, duration time.Duration) (BenchmarkResult, error)
 	Statistics() Statistics
 	Closed() bool
 	ConnectionInfo
@@ -174,6 +195,10 @@ type rawConnection struct {
 	nextID    int
 	nextIDMut sync.Mutex
 
+	latency  latencyTracker
+	bench    benchmarker
+	upgrades upgradeTracker
+
 	inbox                 chan message
 	outbox                chan asyncMessage
 	closeBox              chan asyncMessage
@@ -183,13 +208,16 @@ type rawConnection struct {
 	closeOnce             sync.Once
 	sendCloseOnce         sync.Once
 	compression           Compression
+	zstdLevel             int
+	keepalive             Keepalive
 
 	loopWG sync.WaitGroup // Need to ensure no leftover routines in testing
 }
 
 type asyncResult struct {
-	val []byte
-	err error
+	val  []byte
+	hash []byte
+	err  error
 }
 
 type message interface {
@@ -213,18 +241,44 @@ const (
 	ReceiveTimeout = 300 * time.Second
 )
 
+// Keepalive is how often a connection makes sure to send a message, and how
+// long it waits for one from the other side before closing. Zero values mean
+// PingSendInterval and ReceiveTimeout respectively.
+type Keepalive struct {
+	PingInterval   time.Duration
+	ReceiveTimeout time.Duration
+}
+
+// WithDefaults returns the keepalive with the defaults filled in, and the
+// receive timeout long enough to cover a few ping intervals.
+func (k Keepalive) WithDefaults() Keepalive {
+	if k.PingInterval <= 0 {
+		k.PingInterval = PingSendInterval
+	}
+	if k.ReceiveTimeout <= 0 {
+		k.ReceiveTimeout = ReceiveTimeout
+	}
+	if k.ReceiveTimeout < 2*k.PingInterval {
+		k.ReceiveTimeout = 2 * k.PingInterval
+	}
+	return k
+}
+
 // CloseTimeout is the longest we'll wait when trying to send the close
 // message before just closing the connection.
 // Should not be modified in production code, just for testing.
 var CloseTimeout = 10 * time.Second
 
-func NewConnection(deviceID DeviceID, reader io.Reader, writer io.Writer, closer io.Closer, receiver Model, connInfo ConnectionInfo, compress Compression) Connection {
+// NewConnection returns a connection compressing messages according to
+// compress, with zstd at the given level if it's positive, LZ4 otherwise.
+// Zstd must only be used when the other side announced FeatureZstd.
+func NewConnection(deviceID DeviceID, reader io.Reader, writer io.Writer, closer io.Closer, receiver Model, connInfo ConnectionInfo, compress Compression, zstdLevel int, keepalive Keepalive) Connection {
 	receiver = nativeModel{receiver}
-	rc := newRawConnection(deviceID, reader, writer, closer, receiver, connInfo, compress)
+	rc := newRawConnection(deviceID, reader, writer, closer, receiver, connInfo, compress, zstdLevel, keepalive)
 	return wireFormatConnection{rc}
 }
 
-func NewEncryptedConnection(passwords map[string]string, deviceID DeviceID, reader io.Reader, writer io.Writer, closer io.Closer, receiver Model, connInfo ConnectionInfo, compress Compression) Connection {
+func NewEncryptedConnection(passwords map[string]string, deviceID DeviceID, reader io.Reader, writer io.Writer, closer io.Closer, receiver Model, connInfo ConnectionInfo, compress Compression, zstdLevel int, keepalive Keepalive) Connection {
 	keys := keysFromPasswords(passwords)
 
 	// Encryption / decryption is first (outermost) before conversion to
@@ -234,14 +288,14 @@ func NewEncryptedConnection(passwords map[string]string, deviceID DeviceID, read
 
 	// We do the wire format conversion first (outermost) so that the
 	// metadata is in wire format when it reaches the encryption step.
-	rc := newRawConnection(deviceID, reader, writer, closer, em, connInfo, compress)
+	rc := newRawConnection(deviceID, reader, writer, closer, em, connInfo, compress, zstdLevel, keepalive)
 	ec := encryptedConnection{ConnectionInfo: rc, conn: rc, folderKeys: keys}
 	wc := wireFormatConnection{ec}
 
 	return wc
 }
 
-func newRawConnection(deviceID DeviceID, reader io.Reader, writer io.Writer, closer io.Closer, receiver Model, connInfo ConnectionInfo, compress Compression) *rawConnection {
+func newRawConnection(deviceID DeviceID, reader io.Reader, writer io.Writer, closer io.Closer, receiver Model, connInfo ConnectionInfo, compress Compression, zstdLevel int, keepalive Keepalive) *rawConnection {
 	cr := &countingReader{Reader: reader}
 	cw := &countingWriter{Writer: writer}
 
@@ -260,6 +314,8 @@ func newRawConnection(deviceID DeviceID, reader io.Reader, writer io.Writer, clo
 		dispatcherLoopStopped: make(chan struct{}),
 		closed:                make(chan struct{}),
 		compression:           compress,
+		zstdLevel:             zstdLevel,
+		keepalive:             keepalive.WithDefaults(),
 		loopWG:                sync.WaitGroup{},
 	}
 }
@@ -328,8 +384,9 @@ func (c *rawConnection) IndexUpdate(ctx context.Context, folder string, idx []Fi
 	return nil
 }
 
-// Request returns the bytes for the specified block after fetching them from the connected peer.
-func (c *rawConnection) Request(ctx context.Context, folder string, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error) {
+// Request returns the bytes for the specified block after fetching them from
+// the connected peer, and whether the peer verified them against the hash.
+func (c *rawConnection) Request(ctx context.Context, folder string, name string, blockNo int, offset int64, size int, hash []byte, hashAlgo BlockHashAlgorithm, weakHash uint32, fromTemporary bool) ([]byte, bool, error) {
 	c.nextIDMut.Lock()
 	id := c.nextID
 	c.nextID++
@@ -345,28 +402,35 @@ func (c *rawConnection) Request(ctx context.Context, folder string, name string,
 	c.awaitingMut.Unlock()
 
 	ok := c.send(ctx, &Request{
-		ID:            id,
-		Folder:        folder,
-		Name:          name,
-		Offset:        offset,
-		Size:          size,
-		BlockNo:       blockNo,
-		Hash:          hash,
-		WeakHash:      weakHash,
-		FromTemporary: fromTemporary,
+		ID:                 id,
+		Folder:             folder,
+		Name:               name,
+		Offset:             offset,
+		Size:               size,
+		BlockNo:            blockNo,
+		Hash:               hash,
+		BlockHashAlgorithm: hashAlgo,
+		WeakHash:           weakHash,
+		FromTemporary:      fromTemporary,
 	}, nil)
 	if !ok {
-		return nil, ErrClosed
+		return nil, false, ErrClosed
 	}
 
 	select {
 	case res, ok := <-rc:
 		if !ok {
-			return nil, ErrClosed
+			return nil, false, ErrClosed
 		}
-		return res.val, res.err
+		if res.err != nil || len(hash) == 0 || len(res.hash) == 0 {
+			return res.val, false, res.err
+		}
+		if !bytes.Equal(res.hash, hash) {
+			return nil, false, errResponseHash
+		}
+		return res.val, true, nil
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, false, ctx.Err()
 	}
 }
 
@@ -395,8 +459,19 @@ func (c *rawConnection) DownloadProgress(ctx context.Context, folder string, upd
 	}, nil)
 }
 
+// ConfigPush sends a configuration fragment for the other side to apply.
+func (c *rawConnection) ConfigPush(ctx context.Context, push ConfigPush) error {
+	if !c.send(ctx, &push, nil) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return ErrClosed
+	}
+	return nil
+}
+
 func (c *rawConnection) ping() bool {
-	return c.send(context.Background(), &Ping{}, nil)
+	return c.send(context.Background(), &Ping{ID: c.latency.next()}, nil)
 }
 
 func (c *rawConnection) readerLoop() {
@@ -497,7 +572,32 @@ func (c *rawConnection) dispatcherLoop() (err error) {
 			if state != stateReady {
 				return fmt.Errorf("protocol error: ping message in state %d", state)
 			}
-			// Nothing
+			c.handlePing(*msg)
+
+		case *ConfigPush:
+			l.Debugln("read ConfigPush message")
+			if state != stateReady {
+				return fmt.Errorf("protocol error: config push message in state %d", state)
+			}
+			if err := c.receiver.ConfigPush(c.id, *msg); err != nil {
+				return fmt.Errorf("receiving config push: %w", err)
+			}
+
+		case *UpgradeRequest:
+			l.Debugln("read UpgradeRequest message")
+			if state != stateReady {
+				return fmt.Errorf("protocol error: upgrade request message in state %d", state)
+			}
+			if err := c.receiver.UpgradeRequest(c.id, *msg); err != nil {
+				return fmt.Errorf("receiving upgrade request: %w", err)
+			}
+
+		case *UpgradeResponse:
+			l.Debugln("read UpgradeResponse message")
+			if state != stateReady {
+				return fmt.Errorf("protocol error: upgrade response message in state %d", state)
+			}
+			c.upgrades.deliver(*msg)
 
 		case *Close:
 			l.Debugln("read Close message")
@@ -553,6 +653,14 @@ func (c *rawConnection) readMessageAfterHeader(hdr Header, fourByteBuf []byte) (
 		}
 		buf = decomp
 
+	case MessageCompressionZstd:
+		decomp, err := zstdDecompress(buf)
+		BufferPool.Put(buf)
+		if err != nil {
+			return nil, errors.Wrap(err, "decompressing message")
+		}
+		buf = decomp
+
 	default:
 		return nil, fmt.Errorf("unknown message compression %d", hdr.Compression)
 	}
@@ -671,7 +779,7 @@ func checkFilename(name string) error {
 }
 
 func (c *rawConnection) handleRequest(req Request) {
-	res, err := c.receiver.Request(c.id, req.Folder, req.Name, int32(req.BlockNo), int32(req.Size), req.Offset, req.Hash, req.WeakHash, req.FromTemporary)
+	res, err := c.receiver.Request(c.id, req.Folder, req.Name, int32(req.BlockNo), int32(req.Size), req.Offset, req.Hash, req.BlockHashAlgorithm, req.WeakHash, req.FromTemporary)
 	if err != nil {
 		c.send(context.Background(), &Response{
 			ID:   req.ID,
@@ -684,6 +792,7 @@ func (c *rawConnection) handleRequest(req Request) {
 		ID:   req.ID,
 		Data: res.Data(),
 		Code: errorToCode(nil),
+		Hash: res.Hash(),
 	}, done)
 	<-done
 	res.Close()
@@ -693,7 +802,7 @@ func (c *rawConnection) handleResponse(resp Response) {
 	c.awaitingMut.Lock()
 	if rc := c.awaiting[resp.ID]; rc != nil {
 		delete(c.awaiting, resp.ID)
-		rc <- asyncResult{resp.Data, codeToError(resp.Code)}
+		rc <- asyncResult{resp.Data, resp.Hash, codeToError(resp.Code)}
 		close(rc)
 	}
 	c.awaitingMut.Unlock()
@@ -770,15 +879,22 @@ func (c *rawConnection) writeCompressedMessage(msg message) error {
 		return errors.Wrap(err, "marshalling message")
 	}
 
-	compressed, err := c.lz4Compress(buf)
-	if err != nil {
-		return errors.Wrap(err, "compressing message")
-	}
-
 	hdr := Header{
 		Type:        c.typeOf(msg),
 		Compression: MessageCompressionLZ4,
 	}
+	var compressed []byte
+	var err error
+	if c.zstdLevel > 0 {
+		hdr.Compression = MessageCompressionZstd
+		compressed, err = zstdCompress(buf, c.zstdLevel)
+	} else {
+		compressed, err = c.lz4Compress(buf)
+	}
+	if err != nil {
+		return errors.Wrap(err, "compressing message")
+	}
+
 	hdrSize := hdr.ProtoSize()
 	if hdrSize > 1<<16-1 {
 		panic("impossibly large header")
@@ -864,6 +980,12 @@ func (c *rawConnection) typeOf(msg message) MessageType {
 		return MessageTypePing
 	case *Close:
 		return MessageTypeClose
+	case *ConfigPush:
+		return MessageTypeConfigPush
+	case *UpgradeRequest:
+		return MessageTypeUpgradeRequest
+	case *UpgradeResponse:
+		return MessageTypeUpgradeResponse
 	default:
 		panic("bug: unknown message type")
 	}
@@ -887,6 +1009,12 @@ func (c *rawConnection) newMessage(t MessageType) (message, error) {
 		return new(Ping), nil
 	case MessageTypeClose:
 		return new(Close), nil
+	case MessageTypeConfigPush:
+		return new(ConfigPush), nil
+	case MessageTypeUpgradeRequest:
+		return new(UpgradeRequest), nil
+	case MessageTypeUpgradeResponse:
+		return new(UpgradeResponse), nil
 	default:
 		return nil, errUnknownMessage
 	}
@@ -961,25 +1089,18 @@ func (c *rawConnection) internalClose(err error) {
 	})
 }
 
-// The pingSender makes sure that we've sent a message within the last
-// PingSendInterval. If we already have something sent in the last
-// PingSendInterval/2, we do nothing. Otherwise we send a ping message. This
-// results in an effecting ping interval of somewhere between
-// PingSendInterval/2 and PingSendInterval.
+// The pingSender sends a ping message every PingInterval/2. Besides
+// making sure that we've sent a message within the last PingInterval, the
+// replies to these pings are used to measure the round trip time of the
+// connection.
 func (c *rawConnection) pingSender() {
-	ticker := time.NewTicker(PingSendInterval / 2)
+	ticker := time.NewTicker(c.keepalive.PingInterval / 2)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			d := time.Since(c.cw.Last())
-			if d < PingSendInterval/2 {
-				l.Debugln(c.id, "ping skipped after wr", d)
-				continue
-			}
-
-			l.Debugln(c.id, "ping -> after", d)
+			l.Debugln(c.id, "ping -> after", time.Since(c.cw.Last()))
 			c.ping()
 
 		case <-c.closed:
@@ -990,21 +1111,30 @@ func (c *rawConnection) pingSender() {
 
 // The pingReceiver checks that we've received a message (any message will do,
 // but we expect pings in the absence of other messages) within the last
-// ReceiveTimeout. If not, we close the connection with an ErrTimeout.
+// receive timeout. If not, we close the connection with an ErrTimeout.
 func (c *rawConnection) pingReceiver() {
-	ticker := time.NewTicker(ReceiveTimeout / 2)
-	defer ticker.Stop()
+	timer := time.NewTimer(c.keepalive.ReceiveTimeout)
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
+			timeout := c.receiveTimeout()
 			d := time.Since(c.cr.Last())
-			if d > ReceiveTimeout {
+			if d > timeout {
 				l.Debugln(c.id, "ping timeout", d)
 				c.internalClose(ErrTimeout)
+				return
 			}
 
 			l.Debugln(c.id, "last read within", d)
+			// Check again right when the timeout would pass, or sooner in
+			// case the configured timeout starts to apply.
+			next := timeout - d
+			if next > c.keepalive.ReceiveTimeout {
+				next = c.keepalive.ReceiveTimeout
+			}
+			timer.Reset(next)
 
 		case <-c.closed:
 			return
@@ -1012,19 +1142,53 @@ func (c *rawConnection) pingReceiver() {
 	}
 }
 
+// receiveTimeout is the configured receive timeout. Until the other side has
+// replied to a ping, it's no shorter than the default though: devices that
+// don't reply only send their own pings, at the default interval.
+func (c *rawConnection) receiveTimeout() time.Duration {
+	if rtt, _ := c.latency.stats(); rtt == 0 && c.keepalive.ReceiveTimeout < ReceiveTimeout {
+		return ReceiveTimeout
+	}
+	return c.keepalive.ReceiveTimeout
+}
+
+// handlePing answers pings that ask for it, records the round trip time of
+// replies to our own pings and passes on replies to benchmark pings.
+func (c *rawConnection) handlePing(ping Ping) {
+	switch {
+	case ping.Reply && ping.ID < 0:
+		c.bench.reply()
+	case ping.Reply:
+		if rtt, ok := c.latency.reply(ping.ID); ok {
+			l.Debugln(c.id, "ping rtt", rtt)
+			pingRTTTimer.Update(rtt)
+		}
+	case ping.ID != 0:
+		go c.send(context.Background(), &Ping{ID: ping.ID, Reply: true}, nil)
+	}
+}
+
 type Statistics struct {
 	At            time.Time
 	InBytesTotal  int64
 	OutBytesTotal int64
 	StartedAt     time.Time
+	// The smoothed round trip time and jitter as measured by pings, zero
+	// until the first reply has been received or if the other side
+	// doesn't reply to pings.
+	RTT    time.Duration
+	Jitter time.Duration
 }
 
 func (c *rawConnection) Statistics() Statistics {
+	rtt, jitter := c.latency.stats()
 	return Statistics{
 		At:            time.Now(),
 		InBytesTotal:  c.cr.Tot(),
 		OutBytesTotal: c.cw.Tot(),
 		StartedAt:     c.startTime,
+		RTT:           rtt,
+		Jitter:        jitter,
 	}
 }
 
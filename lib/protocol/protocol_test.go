@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -13,6 +14,7 @@ import (
 	"io/ioutil"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"testing/quick"
 	"time"
@@ -31,10 +33,10 @@ func TestPing(t *testing.T) {
 	ar, aw := io.Pipe()
 	br, bw := io.Pipe()
 
-	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{"name"}, CompressionAlways).(wireFormatConnection).Connection.(*rawConnection)
+	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	c0.Start()
 	defer closeAndWait(c0, ar, bw)
-	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{"name"}, CompressionAlways).(wireFormatConnection).Connection.(*rawConnection)
+	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	c1.Start()
 	defer closeAndWait(c1, ar, bw)
 	c0.ClusterConfig(ClusterConfig{})
@@ -48,8 +50,285 @@ func TestPing(t *testing.T) {
 	}
 }
 
+func TestPingRTT(t *testing.T) {
+	ar, aw := io.Pipe()
+	br, bw := io.Pipe()
+
+	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	c0.Start()
+	defer closeAndWait(c0, ar, bw)
+	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	c1.Start()
+	defer closeAndWait(c1, ar, bw)
+	c0.ClusterConfig(ClusterConfig{})
+	c1.ClusterConfig(ClusterConfig{})
+
+	if ok := c0.ping(); !ok {
+		t.Fatal("c0 ping failed")
+	}
+
+	timeout := time.After(5 * time.Second)
+	for c0.Statistics().RTT == 0 {
+		select {
+		case <-timeout:
+			t.Fatal("timed out waiting for ping reply")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if rtt := c1.Statistics().RTT; rtt != 0 {
+		t.Error("c1 sent no ping, but has rtt", rtt)
+	}
+}
+
+func TestConfigPush(t *testing.T) {
+	ar, aw := io.Pipe()
+	br, bw := io.Pipe()
+
+	m1 := newTestModel()
+	received := make(chan ConfigPush, 1)
+	m1.pushFn = func(id DeviceID, push ConfigPush) {
+		if id != c0ID {
+			t.Error("push from unexpected device", id)
+		}
+		received <- push
+	}
+
+	c0 := NewConnection(c1ID, ar, bw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	c0.Start()
+	defer closeAndWait(c0, ar, bw)
+	c1 := NewConnection(c0ID, br, aw, testutils.NoopCloser{}, m1, &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	c1.Start()
+	defer closeAndWait(c1, ar, bw)
+	c0.ClusterConfig(ClusterConfig{})
+	c1.ClusterConfig(ClusterConfig{})
+
+	fragment := []byte(`{"options":{"relaysEnabled":false}}`)
+	if err := c0.ConfigPush(context.Background(), ConfigPush{Fragment: fragment}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case push := <-received:
+		if !bytes.Equal(push.Fragment, fragment) {
+			t.Errorf("received %q, expected %q", push.Fragment, fragment)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for config push")
+	}
+}
+
+func TestUpgradeRequest(t *testing.T) {
+	ar, aw := io.Pipe()
+	br, bw := io.Pipe()
+
+	m1 := newTestModel()
+	c0 := NewConnection(c1ID, ar, bw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	c0.Start()
+	defer closeAndWait(c0, ar, bw)
+	c1 := NewConnection(c0ID, br, aw, testutils.NoopCloser{}, m1, &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	m1.upgradeFn = func(id DeviceID, req UpgradeRequest) {
+		if id != c0ID {
+			t.Error("upgrade request from unexpected device", id)
+		}
+		go c1.UpgradeResponse(context.Background(), UpgradeResponse{
+			ID:       req.ID,
+			Running:  "v1.0.0",
+			Latest:   req.Version,
+			Upgraded: !req.CheckOnly,
+		})
+	}
+	c1.Start()
+	defer closeAndWait(c1, ar, bw)
+	c0.ClusterConfig(ClusterConfig{})
+	c1.ClusterConfig(ClusterConfig{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, checkOnly := range []bool{true, false} {
+		resp, err := c0.UpgradeRequest(ctx, "v1.1.0", checkOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Running != "v1.0.0" || resp.Latest != "v1.1.0" || resp.Upgraded == checkOnly {
+			t.Errorf("unexpected response %+v to check only %v", resp, checkOnly)
+		}
+	}
+}
+
+func TestBenchmark(t *testing.T) {
+	ar, aw := io.Pipe()
+	br, bw := io.Pipe()
+
+	c0 := NewConnection(c1ID, ar, bw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	c0.Start()
+	defer closeAndWait(c0, ar, bw)
+	c1 := NewConnection(c0ID, br, aw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	c1.Start()
+	defer closeAndWait(c1, ar, bw)
+	c0.ClusterConfig(ClusterConfig{})
+	c1.ClusterConfig(ClusterConfig{})
+
+	res, err := c0.Benchmark(context.Background(), 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RTT <= 0 || res.Bytes <= 0 || res.Bytes%benchmarkChunkSize != 0 || res.Duration < 100*time.Millisecond || res.BytesPerSecond() <= 0 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if rtt := c0.Statistics().RTT; rtt != 0 {
+		t.Error("benchmark pings were taken as regular ones, rtt", rtt)
+	}
+}
+
+func TestLatencyTracker(t *testing.T) {
+	var lt latencyTracker
+
+	id := lt.next()
+	if _, ok := lt.reply(id + 1); ok {
+		t.Error("reply to unknown ping should be ignored")
+	}
+	if _, ok := lt.reply(id); !ok {
+		t.Error("reply to outstanding ping should be recorded")
+	}
+	if _, ok := lt.reply(id); ok {
+		t.Error("duplicate reply should be ignored")
+	}
+
+	lt = latencyTracker{}
+	for i := 0; i < 100; i++ {
+		lt.add(100 * time.Millisecond)
+	}
+	if rtt, jitter := lt.stats(); rtt != 100*time.Millisecond || jitter != 0 {
+		t.Errorf("steady samples should give exact rtt and no jitter, got %v, %v", rtt, jitter)
+	}
+	for i := 0; i < 100; i++ {
+		lt.add(time.Duration(50+100*(i%2)) * time.Millisecond)
+	}
+	if _, jitter := lt.stats(); jitter < 90*time.Millisecond || jitter > 100*time.Millisecond {
+		t.Error("alternating samples should give about 100ms jitter, got", jitter)
+	}
+}
+
+func TestRequestVerified(t *testing.T) {
+	m1 := newTestModel()
+	m1.data = []byte("hello")
+
+	ar, aw := io.Pipe()
+	br, bw := io.Pipe()
+
+	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{})
+	c0.Start()
+	defer closeAndWait(c0, ar, bw)
+	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, m1, &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 0, Keepalive{})
+	c1.Start()
+	defer closeAndWait(c1, ar, bw)
+	c0.ClusterConfig(ClusterConfig{})
+	c1.ClusterConfig(ClusterConfig{})
+
+	ctx := context.Background()
+	hash := []byte("hash")
+
+	// The requested hash isn't attested unless the model verified it.
+	if _, verified, err := c0.Request(ctx, "default", "foo", 0, 0, 5, hash, 0, 0, false); err != nil {
+		t.Fatal(err)
+	} else if verified {
+		t.Error("data not verified by the model was attested")
+	}
+
+	m1.verifiedHash = hash
+	data, verified, err := c0.Request(ctx, "default", "foo", 0, 0, 5, hash, 0, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello" || !verified {
+		t.Errorf("expected verified data, got %q, %v", data, verified)
+	}
+
+	m1.verifiedHash = []byte("other")
+	if _, _, err := c0.Request(ctx, "default", "foo", 0, 0, 5, hash, 0, 0, false); err != errResponseHash {
+		t.Errorf("expected %v for data verified against another hash, got %v", errResponseHash, err)
+	}
+	m1.verifiedHash = nil
+
+	if _, verified, err := c0.Request(ctx, "default", "foo", 0, 0, 5, nil, 0, 0, false); err != nil {
+		t.Fatal(err)
+	} else if verified {
+		t.Error("data requested without hash cannot be verified")
+	}
+}
+
 var errManual = errors.New("manual close")
 
+func TestKeepalive(t *testing.T) {
+	k := Keepalive{}.WithDefaults()
+	if k.PingInterval != PingSendInterval || k.ReceiveTimeout != ReceiveTimeout {
+		t.Errorf("Unexpected defaults %+v", k)
+	}
+	if k := (Keepalive{PingInterval: 10 * time.Second, ReceiveTimeout: 5 * time.Second}).WithDefaults(); k.ReceiveTimeout != 20*time.Second {
+		t.Errorf("Expected the timeout to cover two ping intervals, got %v", k.ReceiveTimeout)
+	}
+
+	ar, aw := io.Pipe()
+	br, bw := io.Pipe()
+
+	// c1 can be made to stop sending, like a device that went away
+	// without closing the connection.
+	stalled := &stallingWriter{Writer: aw, resume: make(chan struct{})}
+	m0 := newTestModel()
+	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, m0, &testutils.FakeConnectionInfo{Name: "c0"}, CompressionAlways, 0, Keepalive{PingInterval: 50 * time.Millisecond, ReceiveTimeout: 200 * time.Millisecond}).(wireFormatConnection).Connection.(*rawConnection)
+	c0.Start()
+	defer closeAndWait(c0, ar, bw)
+	c1 := NewConnection(c1ID, br, stalled, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "c1"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
+	c1.Start()
+	defer closeAndWait(c1, br, aw)
+	defer close(stalled.resume)
+	c0.ClusterConfig(ClusterConfig{})
+	c1.ClusterConfig(ClusterConfig{})
+
+	// Until c1 has replied to a ping, the default timeout applies.
+	if d := c0.receiveTimeout(); d != ReceiveTimeout {
+		t.Errorf("Expected the default timeout before any replies, got %v", d)
+	}
+	timeout := time.After(5 * time.Second)
+	for c0.Statistics().RTT == 0 {
+		select {
+		case <-timeout:
+			t.Fatal("Timed out waiting for a ping reply")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if d := c0.receiveTimeout(); d != 200*time.Millisecond {
+		t.Errorf("Expected the configured timeout after replies, got %v", d)
+	}
+
+	stalled.stall()
+	select {
+	case <-m0.closedCh:
+		if m0.closedErr != ErrTimeout {
+			t.Error("Expected a timeout, got", m0.closedErr)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Connection to the stalled device not closed")
+	}
+}
+
+type stallingWriter struct {
+	io.Writer
+	stalled int32
+	resume  chan struct{}
+}
+
+func (w *stallingWriter) stall() {
+	atomic.StoreInt32(&w.stalled, 1)
+}
+
+func (w *stallingWriter) Write(bs []byte) (int, error) {
+	if atomic.LoadInt32(&w.stalled) == 1 {
+		<-w.resume
+		return 0, io.ErrClosedPipe
+	}
+	return w.Writer.Write(bs)
+}
+
 func TestClose(t *testing.T) {
 	m0 := newTestModel()
 	m1 := newTestModel()
@@ -57,10 +336,10 @@ func TestClose(t *testing.T) {
 	ar, aw := io.Pipe()
 	br, bw := io.Pipe()
 
-	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, m0, &testutils.FakeConnectionInfo{"name"}, CompressionAlways).(wireFormatConnection).Connection.(*rawConnection)
+	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, m0, &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	c0.Start()
 	defer closeAndWait(c0, ar, bw)
-	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, m1, &testutils.FakeConnectionInfo{"name"}, CompressionAlways)
+	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, m1, &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{})
 	c1.Start()
 	defer closeAndWait(c1, ar, bw)
 	c0.ClusterConfig(ClusterConfig{})
@@ -84,7 +363,7 @@ func TestClose(t *testing.T) {
 	c0.Index(ctx, "default", nil)
 	c0.Index(ctx, "default", nil)
 
-	if _, err := c0.Request(ctx, "default", "foo", 0, 0, 0, nil, 0, false); err == nil {
+	if _, _, err := c0.Request(ctx, "default", "foo", 0, 0, 0, nil, 0, 0, false); err == nil {
 		t.Error("Request should return an error")
 	}
 }
@@ -102,7 +381,7 @@ func TestCloseOnBlockingSend(t *testing.T) {
 	m := newTestModel()
 
 	rw := testutils.NewBlockingRW()
-	c := NewConnection(c0ID, rw, rw, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways).(wireFormatConnection).Connection.(*rawConnection)
+	c := NewConnection(c0ID, rw, rw, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	c.Start()
 	defer closeAndWait(c, rw)
 
@@ -153,10 +432,10 @@ func TestCloseRace(t *testing.T) {
 	ar, aw := io.Pipe()
 	br, bw := io.Pipe()
 
-	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, m0, &testutils.FakeConnectionInfo{"c0"}, CompressionNever).(wireFormatConnection).Connection.(*rawConnection)
+	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, m0, &testutils.FakeConnectionInfo{"c0"}, CompressionNever, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	c0.Start()
 	defer closeAndWait(c0, ar, bw)
-	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, m1, &testutils.FakeConnectionInfo{"c1"}, CompressionNever)
+	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, m1, &testutils.FakeConnectionInfo{"c1"}, CompressionNever, 0, Keepalive{})
 	c1.Start()
 	defer closeAndWait(c1, ar, bw)
 	c0.ClusterConfig(ClusterConfig{})
@@ -193,7 +472,7 @@ func TestClusterConfigFirst(t *testing.T) {
 	m := newTestModel()
 
 	rw := testutils.NewBlockingRW()
-	c := NewConnection(c0ID, rw, &testutils.NoopRW{}, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways).(wireFormatConnection).Connection.(*rawConnection)
+	c := NewConnection(c0ID, rw, &testutils.NoopRW{}, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	c.Start()
 	defer closeAndWait(c, rw)
 
@@ -245,7 +524,7 @@ func TestCloseTimeout(t *testing.T) {
 	m := newTestModel()
 
 	rw := testutils.NewBlockingRW()
-	c := NewConnection(c0ID, rw, rw, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways).(wireFormatConnection).Connection.(*rawConnection)
+	c := NewConnection(c0ID, rw, rw, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	c.Start()
 	defer closeAndWait(c, rw)
 
@@ -330,6 +609,9 @@ func TestMarshalResponseMessage(t *testing.T) {
 		if len(m1.Data) == 0 {
 			m1.Data = nil
 		}
+		if len(m1.Hash) == 0 {
+			m1.Hash = nil
+		}
 		return testMarshal(t, "response", &m1, &Response{})
 	}
 
@@ -470,6 +752,63 @@ func TestLZ4Compression(t *testing.T) {
 	}
 }
 
+func TestZstdCompression(t *testing.T) {
+	if !ZstdSupported {
+		t.Skip("zstd not supported in this build")
+	}
+
+	data := bytes.Repeat([]byte("compressible "), 1000)
+	comp, err := zstdCompress(data, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comp) >= len(data) {
+		t.Errorf("Expected compression, got %d bytes from %d", len(comp), len(data))
+	}
+	res, err := zstdDecompress(comp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, res) {
+		t.Error("Incorrect decompressed data")
+	}
+
+	// A size exceeding the maximum message length is rejected.
+	binary.BigEndian.PutUint32(comp, MaxMessageLen+1)
+	if _, err := zstdDecompress(comp); err == nil {
+		t.Error("Expected an error for an excessive size")
+	}
+}
+
+func TestZstdConnection(t *testing.T) {
+	if !ZstdSupported {
+		t.Skip("zstd not supported in this build")
+	}
+
+	m1 := newTestModel()
+	m1.data = bytes.Repeat([]byte("hello "), 100)
+
+	ar, aw := io.Pipe()
+	br, bw := io.Pipe()
+
+	c0 := NewConnection(c0ID, ar, bw, testutils.NoopCloser{}, newTestModel(), &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 3, Keepalive{})
+	c0.Start()
+	defer closeAndWait(c0, ar, bw)
+	c1 := NewConnection(c1ID, br, aw, testutils.NoopCloser{}, m1, &testutils.FakeConnectionInfo{Name: "name"}, CompressionAlways, 3, Keepalive{})
+	c1.Start()
+	defer closeAndWait(c1, ar, bw)
+	c0.ClusterConfig(ClusterConfig{})
+	c1.ClusterConfig(ClusterConfig{})
+
+	data, _, err := c0.Request(context.Background(), "default", "foo", 0, 0, len(m1.data), nil, 0, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, m1.data) {
+		t.Errorf("Unexpected data %q", data)
+	}
+}
+
 func TestStressLZ4CompressGrows(t *testing.T) {
 	c := new(rawConnection)
 	success := 0
@@ -865,7 +1204,7 @@ func TestClusterConfigAfterClose(t *testing.T) {
 	m := newTestModel()
 
 	rw := testutils.NewBlockingRW()
-	c := NewConnection(c0ID, rw, rw, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways).(wireFormatConnection).Connection.(*rawConnection)
+	c := NewConnection(c0ID, rw, rw, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	c.Start()
 	defer closeAndWait(c, rw)
 
@@ -889,7 +1228,7 @@ func TestDispatcherToCloseDeadlock(t *testing.T) {
 	// the model callbacks (ClusterConfig).
 	m := newTestModel()
 	rw := testutils.NewBlockingRW()
-	c := NewConnection(c0ID, rw, &testutils.NoopRW{}, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways).(wireFormatConnection).Connection.(*rawConnection)
+	c := NewConnection(c0ID, rw, &testutils.NoopRW{}, testutils.NoopCloser{}, m, &testutils.FakeConnectionInfo{"name"}, CompressionAlways, 0, Keepalive{}).(wireFormatConnection).Connection.(*rawConnection)
 	m.ccFn = func(devID DeviceID, cc ClusterConfig) {
 		c.Close(errManual)
 	}
@@ -954,6 +1293,34 @@ func TestBlocksEqual(t *testing.T) {
 	}
 }
 
+func TestStripEmptyBlockHashes(t *testing.T) {
+	emptyHash, _ := EmptyBlockHash(MinBlockSize)
+	f := FileInfo{
+		Blocks: []BlockInfo{
+			{Size: MinBlockSize, Hash: []byte("some other hash")},
+			{Offset: MinBlockSize, Size: MinBlockSize, Hash: emptyHash},
+			{Offset: 2 * MinBlockSize, Size: 42, Hash: []byte("short block")},
+		},
+	}
+	orig := f.Blocks
+
+	f.StripEmptyBlockHashes()
+	if f.Blocks[1].Hash != nil {
+		t.Error("Expected the empty block hash to be stripped")
+	}
+	if f.Blocks[0].Hash == nil || f.Blocks[2].Hash == nil {
+		t.Error("Expected the other hashes to remain")
+	}
+	if orig[1].Hash == nil {
+		t.Error("Stripping modified the original blocks")
+	}
+
+	f.RestoreEmptyBlockHashes()
+	if !f.BlocksEqual(FileInfo{Blocks: orig}) {
+		t.Errorf("Restored blocks differ: %v", f.Blocks)
+	}
+}
+
 func TestIndexIDString(t *testing.T) {
 	// Index ID is a 64 bit, zero padded hex integer.
 	var i IndexID = 42
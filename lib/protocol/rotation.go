@@ -0,0 +1,60 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+package protocol
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	"github.com/syncthing/syncthing/lib/tlsutil"
+)
+
+var errNoCertificate = errors.New("no certificate")
+
+// rotationMessage is what the current certificate signs to vouch for the
+// next one. Binding it to the current device ID keeps the signature from
+// being replayed on behalf of another device.
+func rotationMessage(next []byte, current DeviceID) []byte {
+	msg := []byte("syncthing certificate rotation\x00")
+	msg = append(msg, current[:]...)
+	return append(msg, next...)
+}
+
+// SetNextCertificate announces next as the certificate the sender is
+// rotating to, signed with the key of cert, the current certificate.
+func (h *Hello) SetNextCertificate(next, cert tls.Certificate) error {
+	if len(next.Certificate) == 0 || len(cert.Certificate) == 0 {
+		return errNoCertificate
+	}
+	current := NewDeviceID(cert.Certificate[0])
+	sig, err := tlsutil.Sign(cert.PrivateKey, rotationMessage(next.Certificate[0], current))
+	if err != nil {
+		return err
+	}
+	h.NextCertificate = next.Certificate[0]
+	h.NextCertificateSignature = sig
+	return nil
+}
+
+// VerifiedNextDeviceID returns the device ID the sender is rotating to,
+// after checking that the announcement is signed by the certificate of
+// the connection it was received over. It returns EmptyDeviceID if there
+// is no such announcement.
+func (h Hello) VerifiedNextDeviceID(cert *x509.Certificate) (DeviceID, error) {
+	if len(h.NextCertificate) == 0 {
+		return EmptyDeviceID, nil
+	}
+	if cert == nil {
+		return EmptyDeviceID, errNoCertificate
+	}
+	next, err := x509.ParseCertificate(h.NextCertificate)
+	if err != nil {
+		return EmptyDeviceID, err
+	}
+	current := NewDeviceID(cert.Raw)
+	if err := tlsutil.VerifySignature(cert, rotationMessage(next.Raw, current), h.NextCertificateSignature); err != nil {
+		return EmptyDeviceID, err
+	}
+	return NewDeviceID(next.Raw), nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+package protocol
+
+import (
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/tlsutil"
+)
+
+func TestNextCertificate(t *testing.T) {
+	dir := t.TempDir()
+	cur, err := tlsutil.NewCertificate(filepath.Join(dir, "cur.pem"), filepath.Join(dir, "cur-key.pem"), "syncthing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := tlsutil.NewCertificate(filepath.Join(dir, "next.pem"), filepath.Join(dir, "next-key.pem"), "syncthing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := tlsutil.NewCertificate(filepath.Join(dir, "other.pem"), filepath.Join(dir, "other-key.pem"), "syncthing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	curX509, _ := x509.ParseCertificate(cur.Certificate[0])
+	otherX509, _ := x509.ParseCertificate(other.Certificate[0])
+
+	var h Hello
+	if id, err := h.VerifiedNextDeviceID(curX509); err != nil || id != EmptyDeviceID {
+		t.Fatalf("expected no announcement, got %v, %v", id, err)
+	}
+
+	if err := h.SetNextCertificate(next, cur); err != nil {
+		t.Fatal(err)
+	}
+
+	// The announcement survives the wire format.
+	bs, err := h.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received Hello
+	if err := received.Unmarshal(bs); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := received.VerifiedNextDeviceID(curX509)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := NewDeviceID(next.Certificate[0]); id != expected {
+		t.Errorf("got next device ID %v, expected %v", id, expected)
+	}
+
+	// Received over a connection with another certificate, it must not
+	// be trusted.
+	if _, err := received.VerifiedNextDeviceID(otherX509); err == nil {
+		t.Error("announcement verified with the wrong certificate")
+	}
+
+	// Neither may a tampered announcement.
+	received.NextCertificate = other.Certificate[0]
+	if _, err := received.VerifiedNextDeviceID(curX509); err == nil {
+		t.Error("tampered announcement verified")
+	}
+}
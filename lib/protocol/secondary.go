@@ -0,0 +1,46 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+package protocol
+
+// requestsOnlyModel is the Model for secondary connections, which carry
+// only block requests and their responses. Everything else concerns the
+// primary connection to the device and is ignored.
+type requestsOnlyModel struct {
+	Model
+	closed func(Connection, error)
+}
+
+// RequestsOnly returns a Model for a secondary connection that passes on
+// requests to the given model, and calls closed instead of the model's
+// Closed method when the connection closes.
+func RequestsOnly(model Model, closed func(Connection, error)) Model {
+	return requestsOnlyModel{Model: model, closed: closed}
+}
+
+func (requestsOnlyModel) Index(DeviceID, string, []FileInfo) error {
+	return nil
+}
+
+func (requestsOnlyModel) IndexUpdate(DeviceID, string, []FileInfo) error {
+	return nil
+}
+
+func (requestsOnlyModel) ClusterConfig(DeviceID, ClusterConfig) error {
+	return nil
+}
+
+func (requestsOnlyModel) DownloadProgress(DeviceID, string, []FileDownloadProgressUpdate) error {
+	return nil
+}
+
+func (requestsOnlyModel) ConfigPush(DeviceID, ConfigPush) error {
+	return nil
+}
+
+func (requestsOnlyModel) UpgradeRequest(DeviceID, UpgradeRequest) error {
+	return nil
+}
+
+func (m requestsOnlyModel) Closed(conn Connection, err error) {
+	m.closed(conn, err)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+package protocol
+
+import (
+	"context"
+	"sync"
+)
+
+// upgradeTracker passes upgrade responses to the requests waiting for them,
+// matched by their ID.
+type upgradeTracker struct {
+	mut     sync.Mutex
+	lastID  int
+	waiting map[int]chan UpgradeResponse
+}
+
+func (t *upgradeTracker) register() (int, chan UpgradeResponse) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if t.waiting == nil {
+		t.waiting = make(map[int]chan UpgradeResponse)
+	}
+	t.lastID++
+	rc := make(chan UpgradeResponse, 1)
+	t.waiting[t.lastID] = rc
+	return t.lastID, rc
+}
+
+func (t *upgradeTracker) cancel(id int) {
+	t.mut.Lock()
+	delete(t.waiting, id)
+	t.mut.Unlock()
+}
+
+// deliver passes the response to the waiting request, if any. Responses
+// nobody waits for anymore are dropped.
+func (t *upgradeTracker) deliver(resp UpgradeResponse) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if rc, ok := t.waiting[resp.ID]; ok {
+		rc <- resp
+		delete(t.waiting, resp.ID)
+	}
+}
+
+// UpgradeRequest asks the other side to check for an upgrade to the given
+// version, or the latest one if empty, and to perform it unless checkOnly is
+// set. It waits for the answer until the context is cancelled, as upgrading
+// includes downloading the release and may take a while.
+func (c *rawConnection) UpgradeRequest(ctx context.Context, version string, checkOnly bool) (UpgradeResponse, error) {
+	id, rc := c.upgrades.register()
+	defer c.upgrades.cancel(id)
+
+	if !c.send(ctx, &UpgradeRequest{ID: id, Version: version, CheckOnly: checkOnly}, nil) {
+		if err := ctx.Err(); err != nil {
+			return UpgradeResponse{}, err
+		}
+		return UpgradeResponse{}, ErrClosed
+	}
+
+	select {
+	case resp := <-rc:
+		return resp, nil
+	case <-ctx.Done():
+		return UpgradeResponse{}, ctx.Err()
+	case <-c.closed:
+		return UpgradeResponse{}, ErrClosed
+	}
+}
+
+// UpgradeResponse sends the answer to an upgrade request and returns once it
+// has been written, so that the caller may restart afterwards.
+func (c *rawConnection) UpgradeResponse(ctx context.Context, resp UpgradeResponse) error {
+	done := make(chan struct{})
+	if !c.send(ctx, &resp, done) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return ErrClosed
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.closed:
+		return ErrClosed
+	}
+}
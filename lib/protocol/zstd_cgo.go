@@ -0,0 +1,53 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+// +build cgo
+
+package protocol
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/DataDog/zstd"
+)
+
+// ZstdSupported is true when messages can be compressed with zstd, which
+// requires cgo.
+const ZstdSupported = true
+
+// zstdCompress compresses src into a buffer from the pool, prefixed by its
+// uncompressed size like the LZ4 format.
+func zstdCompress(src []byte, level int) ([]byte, error) {
+	buf := BufferPool.Get(4 + zstd.CompressBound(len(src)))
+	compressed, err := zstd.CompressLevel(buf[4:], src, level)
+	if err != nil {
+		BufferPool.Put(buf)
+		return nil, err
+	}
+	if &compressed[0] != &buf[4] {
+		panic("bug: zstd.CompressLevel allocated, which it must not (should use buffer pool)")
+	}
+	binary.BigEndian.PutUint32(buf, uint32(len(src)))
+	return buf[:4+len(compressed)], nil
+}
+
+func zstdDecompress(src []byte) ([]byte, error) {
+	if len(src) < 4 {
+		return nil, fmt.Errorf("zstd message too short")
+	}
+	size := binary.BigEndian.Uint32(src)
+	if size > MaxMessageLen {
+		return nil, fmt.Errorf("decompressed message length %d exceeds maximum %d", size, MaxMessageLen)
+	}
+	buf := BufferPool.Get(int(size))
+	decoded, err := zstd.Decompress(buf, src[4:])
+	if err != nil {
+		BufferPool.Put(buf)
+		return nil, err
+	}
+	if len(decoded) != int(size) {
+		BufferPool.Put(buf)
+		return nil, fmt.Errorf("zstd message decompressed to %d bytes instead of %d", len(decoded), size)
+	}
+	return decoded, nil
+}
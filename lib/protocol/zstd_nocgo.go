@@ -0,0 +1,21 @@
+// Copyright (C) 2021 The Protocol Authors.
+
+// +build !cgo
+
+package protocol
+
+import "errors"
+
+// ZstdSupported is true when messages can be compressed with zstd, which
+// requires cgo.
+const ZstdSupported = false
+
+var errZstdNotSupported = errors.New("zstd compression not supported in this build")
+
+func zstdCompress(src []byte, level int) ([]byte, error) {
+	return nil, errZstdNotSupported
+}
+
+func zstdDecompress(src []byte) ([]byte, error) {
+	return nil, errZstdNotSupported
+}
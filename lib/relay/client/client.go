@@ -35,6 +35,14 @@ type RelayClient interface {
 	URI() *url.URL
 }
 
+// A ProbingClient is a RelayClient that picks a relay from a pool of relays
+// by probing them.
+type ProbingClient interface {
+	RelayClient
+	SetPreferences(preferences []string)
+	ProbeResults() []ProbeResult
+}
+
 func NewClient(uri *url.URL, certs []tls.Certificate, invitations chan protocol.SessionInvitation, timeout time.Duration) (RelayClient, error) {
 	factory, ok := supportedSchemes[uri.Scheme]
 	if !ok {
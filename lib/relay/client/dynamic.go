@@ -10,11 +10,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"sort"
 	"time"
 
-	"github.com/syncthing/syncthing/lib/osutil"
-	"github.com/syncthing/syncthing/lib/rand"
 	"github.com/syncthing/syncthing/lib/relay/protocol"
 )
 
@@ -25,9 +22,15 @@ type dynamicClient struct {
 	certs    []tls.Certificate
 	timeout  time.Duration
 
-	client RelayClient
+	client      RelayClient
+	preferences []string
+	results     []ProbeResult
 }
 
+// How often the relays of the pool are probed again while connected to one
+// of them, to see if there is a better one by now.
+const relayReprobeInterval = 30 * time.Minute
+
 func newDynamicClient(uri *url.URL, certs []tls.Certificate, invitations chan protocol.SessionInvitation, timeout time.Duration) RelayClient {
 	c := &dynamicClient{
 		pooladdr: uri,
@@ -39,6 +42,105 @@ func newDynamicClient(uri *url.URL, certs []tls.Certificate, invitations chan pr
 }
 
 func (c *dynamicClient) serve(ctx context.Context) error {
+	ann, err := c.lookup(ctx)
+	if err != nil {
+		return err
+	}
+	results := c.probe(ctx, ann)
+
+	for {
+		var reprobed []ProbeResult
+		for _, res := range results {
+			select {
+			case <-ctx.Done():
+				l.Debugln(c, "stopping")
+				return nil
+			default:
+			}
+			ruri, err := url.Parse(res.URL)
+			if err != nil {
+				l.Debugln(c, "skipping relay", res.URL, err)
+				continue
+			}
+			if reprobed = c.serveRelay(ctx, ruri, res); reprobed != nil {
+				break
+			}
+		}
+		if reprobed == nil {
+			l.Debugln(c, "could not find a connectable relay")
+			return errors.New("could not find a connectable relay")
+		}
+		results = reprobed
+	}
+}
+
+// serveRelay stays connected to the given relay until the connection fails
+// or probing the pool again turns up a better relay, in which case the new
+// probe results are returned.
+func (c *dynamicClient) serveRelay(ctx context.Context, uri *url.URL, current ProbeResult) []ProbeResult {
+	client := newStaticClient(uri, c.certs, c.invitations, c.timeout)
+	c.mut.Lock()
+	c.client = client
+	c.mut.Unlock()
+
+	relayCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	better := make(chan []ProbeResult, 1)
+	go c.reprobe(relayCtx, current, better, cancel)
+
+	client.Serve(relayCtx)
+
+	c.mut.Lock()
+	c.client = nil
+	c.mut.Unlock()
+
+	select {
+	case results := <-better:
+		return results
+	default:
+		return nil
+	}
+}
+
+// reprobe periodically looks up and probes the relays of the pool, and
+// cancels the connection to the current relay when a better one is found.
+func (c *dynamicClient) reprobe(ctx context.Context, current ProbeResult, better chan<- []ProbeResult, cancel context.CancelFunc) {
+	ticker := time.NewTicker(relayReprobeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		ann, err := c.lookup(ctx)
+		if err != nil {
+			continue
+		}
+		results := c.probe(ctx, ann)
+		if len(results) == 0 {
+			continue
+		}
+		for _, res := range results {
+			if res.URL == current.URL {
+				current = res
+				break
+			}
+		}
+		if results[0].URL == current.URL || !results[0].better(current) {
+			continue
+		}
+
+		l.Infof("Switching from relay %s to better relay %s", current.URL, results[0].URL)
+		better <- results
+		cancel()
+		return
+	}
+}
+
+// lookup fetches the list of relays from the pool.
+func (c *dynamicClient) lookup(ctx context.Context) (dynamicAnnouncement, error) {
 	uri := *c.pooladdr
 
 	// Trim off the `dynamic+` prefix
@@ -46,62 +148,63 @@ func (c *dynamicClient) serve(ctx context.Context) error {
 
 	l.Debugln(c, "looking up dynamic relays")
 
+	var ann dynamicAnnouncement
 	req, err := http.NewRequest("GET", uri.String(), nil)
 	if err != nil {
 		l.Debugln(c, "failed to lookup dynamic relays", err)
-		return err
+		return ann, err
 	}
 	req.Cancel = ctx.Done()
 	data, err := http.DefaultClient.Do(req)
 	if err != nil {
 		l.Debugln(c, "failed to lookup dynamic relays", err)
-		return err
+		return ann, err
 	}
 
-	var ann dynamicAnnouncement
 	err = json.NewDecoder(data.Body).Decode(&ann)
 	data.Body.Close()
 	if err != nil {
 		l.Debugln(c, "failed to lookup dynamic relays", err)
-		return err
+		return ann, err
 	}
 
-	var addrs []string
 	for _, relayAnn := range ann.Relays {
-		ruri, err := url.Parse(relayAnn.URL)
-		if err != nil {
-			l.Debugln(c, "failed to parse dynamic relay address", relayAnn.URL, err)
-			continue
-		}
-		l.Debugln(c, "found", ruri)
-		addrs = append(addrs, ruri.String())
+		l.Debugln(c, "found", relayAnn.URL)
 	}
+	return ann, nil
+}
 
-	for _, addr := range relayAddressesOrder(ctx, addrs) {
-		select {
-		case <-ctx.Done():
-			l.Debugln(c, "stopping")
-			return nil
-		default:
-			ruri, err := url.Parse(addr)
-			if err != nil {
-				l.Debugln(c, "skipping relay", addr, err)
-				continue
-			}
-			client := newStaticClient(ruri, c.certs, c.invitations, c.timeout)
-			c.mut.Lock()
-			c.client = client
-			c.mut.Unlock()
+// probe probes the relays of the announcement and keeps the results for
+// ProbeResults.
+func (c *dynamicClient) probe(ctx context.Context, ann dynamicAnnouncement) []ProbeResult {
+	c.mut.RLock()
+	preferences := c.preferences
+	c.mut.RUnlock()
 
-			c.client.Serve(ctx)
+	results := probeRelays(ctx, ann, preferences)
 
-			c.mut.Lock()
-			c.client = nil
-			c.mut.Unlock()
-		}
-	}
-	l.Debugln(c, "could not find a connectable relay")
-	return errors.New("could not find a connectable relay")
+	c.mut.Lock()
+	c.results = results
+	c.mut.Unlock()
+	return results
+}
+
+// SetPreferences sets the relays to prefer, as two letter country codes or
+// relay addresses. They take effect the next time the pool is probed.
+func (c *dynamicClient) SetPreferences(preferences []string) {
+	c.mut.Lock()
+	c.preferences = preferences
+	c.mut.Unlock()
+}
+
+// ProbeResults returns the results of the most recent probe of the pool,
+// best relay first.
+func (c *dynamicClient) ProbeResults() []ProbeResult {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+	results := make([]ProbeResult, len(c.results))
+	copy(results, c.results)
+	return results
 }
 
 func (c *dynamicClient) Error() error {
@@ -136,49 +239,12 @@ func (c *dynamicClient) URI() *url.URL {
 }
 
 // This is the announcement received from the relay server;
-// {"relays": [{"url": "relay://10.20.30.40:5060"}, ...]}
+// {"relays": [{"url": "relay://10.20.30.40:5060", "location": {"country": "DE", ...}}, ...]}
 type dynamicAnnouncement struct {
 	Relays []struct {
-		URL string
-	}
-}
-
-// relayAddressesOrder checks the latency to each relay, rounds latency down to
-// the closest 50ms, and puts them in buckets of 50ms latency ranges. Then
-// shuffles each bucket, and returns all addresses starting with the ones from
-// the lowest latency bucket, ending with the highest latency buceket.
-func relayAddressesOrder(ctx context.Context, input []string) []string {
-	buckets := make(map[int][]string)
-
-	for _, relay := range input {
-		latency, err := osutil.GetLatencyForURL(ctx, relay)
-		if err != nil {
-			latency = time.Hour
-		}
-
-		id := int(latency/time.Millisecond) / 50
-
-		buckets[id] = append(buckets[id], relay)
-
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
+		URL      string
+		Location struct {
+			Country string
 		}
 	}
-
-	var ids []int
-	for id, bucket := range buckets {
-		rand.Shuffle(bucket)
-		ids = append(ids, id)
-	}
-
-	sort.Ints(ids)
-
-	addresses := make([]string, 0, len(input))
-	for _, id := range ids {
-		addresses = append(addresses, buckets[id]...)
-	}
-
-	return addresses
 }
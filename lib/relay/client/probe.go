@@ -0,0 +1,239 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math/bits"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/rand"
+)
+
+const (
+	// Number of TCP connects used to determine the latency to a relay.
+	probePings = 3
+	// Number of relays probed at the same time.
+	probeConcurrency = 4
+	// Latencies within the same bucket are considered equal.
+	latencyBucket = 50 * time.Millisecond
+	// Throughputs within a factor of two are considered equal.
+	throughputBucket = 64 << 10 // bytes/s
+	// Timeout for the throughput test.
+	throughputTimeout = 5 * time.Second
+	// Maximum amount of data read during the throughput test.
+	maxThroughputBytes = 1 << 20
+)
+
+// ProbeResult is the outcome of probing a relay from a relay pool.
+type ProbeResult struct {
+	URL        string
+	Country    string
+	Preferred  bool
+	Latency    time.Duration
+	Throughput float64 // bytes/s, zero if unknown
+	Error      string
+	ProbedAt   time.Time
+}
+
+func (r ProbeResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"url":        r.URL,
+		"country":    r.Country,
+		"preferred":  r.Preferred,
+		"latencyMs":  r.Latency.Seconds() * 1000,
+		"throughput": r.Throughput,
+		"error":      r.Error,
+		"probedAt":   r.ProbedAt,
+	})
+}
+
+func (r ProbeResult) latencyBucket() int {
+	return int(r.Latency / latencyBucket)
+}
+
+func (r ProbeResult) throughputBucket() int {
+	return bits.Len64(uint64(r.Throughput / throughputBucket))
+}
+
+// better returns whether r is enough of an improvement over other to make
+// switching relays worthwhile.
+func (r ProbeResult) better(other ProbeResult) bool {
+	switch {
+	case r.Error != "":
+		return false
+	case other.Error != "":
+		return true
+	case r.Preferred != other.Preferred:
+		return r.Preferred
+	}
+	return r.latencyBucket() < other.latencyBucket()-1
+}
+
+// probeRelays probes all relays of the announcement, a few at a time,
+// returning the results in order of preference.
+func probeRelays(ctx context.Context, ann dynamicAnnouncement, preferences []string) []ProbeResult {
+	results := make([]ProbeResult, len(ann.Relays))
+	limiter := make(chan struct{}, probeConcurrency)
+	var wg sync.WaitGroup
+	for i, relay := range ann.Relays {
+		select {
+		case limiter <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		}
+		wg.Add(1)
+		go func(i int, addr, country string) {
+			defer wg.Done()
+			results[i] = probeRelay(ctx, addr, country, preferences)
+			<-limiter
+		}(i, relay.URL, relay.Location.Country)
+	}
+	wg.Wait()
+	orderProbeResults(results)
+	return results
+}
+
+// probeRelay measures the latency to the relay as the best of a few TCP
+// connects, and estimates the throughput by downloading the relay status.
+// The latter is a rough estimate at best, but it is capped by the per
+// session rate limit the relay announces, which is what usually limits
+// relayed connections.
+func probeRelay(ctx context.Context, addr, country string, preferences []string) ProbeResult {
+	res := ProbeResult{
+		URL:      addr,
+		Country:  country,
+		Latency:  time.Hour,
+		ProbedAt: time.Now(),
+	}
+
+	uri, err := url.Parse(addr)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Preferred = isPreferredRelay(uri, country, preferences)
+
+	for i := 0; i < probePings; i++ {
+		latency, err := osutil.TCPPing(ctx, uri.Host)
+		if err != nil {
+			res.Error = err.Error()
+			continue
+		}
+		res.Error = ""
+		if latency < res.Latency {
+			res.Latency = latency
+		}
+	}
+	if res.Error != "" {
+		l.Debugln("relay", addr, "probe failed:", res.Error)
+		return res
+	}
+
+	res.Throughput, err = probeThroughput(ctx, uri)
+	if err != nil {
+		l.Debugln("relay", addr, "throughput test failed:", err)
+	}
+	if limit, _ := strconv.ParseFloat(uri.Query().Get("sessionLimitBps"), 64); limit > 0 && (res.Throughput == 0 || limit < res.Throughput) {
+		res.Throughput = limit
+	}
+
+	l.Debugf("relay %s probed: latency %v, throughput %.0f B/s, preferred %v", addr, res.Latency, res.Throughput, res.Preferred)
+	return res
+}
+
+// probeThroughput downloads from the relay status service and returns the
+// rate at which the data was received.
+func probeThroughput(ctx context.Context, uri *url.URL) (float64, error) {
+	statusAddr := uri.Query().Get("statusAddr")
+	if statusAddr == "" {
+		statusAddr = ":22070"
+	}
+	statusHost, statusPort, err := net.SplitHostPort(statusAddr)
+	if err != nil {
+		return 0, err
+	}
+	if statusHost == "" {
+		statusHost, _, err = net.SplitHostPort(uri.Host)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, throughputTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+net.JoinHostPort(statusHost, statusPort)+"/status", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	t0 := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	n, err := io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxThroughputBytes))
+	if err != nil {
+		return 0, err
+	}
+	return float64(n) / time.Since(t0).Seconds(), nil
+}
+
+// isPreferredRelay returns whether the relay matches any of the preferences,
+// which are either two letter country codes or relay addresses.
+func isPreferredRelay(uri *url.URL, country string, preferences []string) bool {
+	for _, pref := range preferences {
+		if len(pref) == 2 {
+			if strings.EqualFold(pref, country) {
+				return true
+			}
+			continue
+		}
+		host := pref
+		if pu, err := url.Parse(pref); err == nil && pu.Host != "" {
+			host = pu.Host
+		}
+		if strings.EqualFold(host, uri.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderProbeResults sorts the results with preferred and reachable relays
+// first, then by latency, rounded down to the closest 50ms, and throughput,
+// rounded down to the closest power of two. Relays that compare equal are
+// shuffled to spread the load between them.
+func orderProbeResults(results []ProbeResult) {
+	rand.Shuffle(results)
+	sort.SliceStable(results, func(a, b int) bool {
+		ra, rb := results[a], results[b]
+		if (ra.Error == "") != (rb.Error == "") {
+			return ra.Error == ""
+		}
+		if ra.Preferred != rb.Preferred {
+			return ra.Preferred
+		}
+		if ra.latencyBucket() != rb.latencyBucket() {
+			return ra.latencyBucket() < rb.latencyBucket()
+		}
+		return ra.throughputBucket() > rb.throughputBucket()
+	})
+}
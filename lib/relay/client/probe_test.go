@@ -0,0 +1,128 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsPreferredRelay(t *testing.T) {
+	uri, _ := url.Parse("relay://192.0.2.1:22067/?id=abc")
+	cases := []struct {
+		preferences []string
+		country     string
+		preferred   bool
+	}{
+		{nil, "DE", false},
+		{[]string{"de"}, "DE", true},
+		{[]string{"SE"}, "DE", false},
+		{[]string{"SE"}, "", false},
+		{[]string{"192.0.2.1:22067"}, "", true},
+		{[]string{"relay://192.0.2.1:22067"}, "", true},
+		{[]string{"relay://192.0.2.2:22067"}, "DE", false},
+		{[]string{"SE", "relay://192.0.2.1:22067/?id=abc"}, "DE", true},
+	}
+	for _, tc := range cases {
+		if res := isPreferredRelay(uri, tc.country, tc.preferences); res != tc.preferred {
+			t.Errorf("isPreferredRelay(%v, %q) => %v, expected %v", tc.preferences, tc.country, res, tc.preferred)
+		}
+	}
+}
+
+func TestOrderProbeResults(t *testing.T) {
+	results := []ProbeResult{
+		{URL: "failed", Preferred: true, Latency: time.Millisecond, Error: "connection refused"},
+		{URL: "slow", Latency: 300 * time.Millisecond},
+		{URL: "fast-narrow", Latency: 20 * time.Millisecond, Throughput: 100 << 10},
+		{URL: "fast-wide", Latency: 30 * time.Millisecond, Throughput: 10 << 20},
+		{URL: "preferred", Preferred: true, Latency: 200 * time.Millisecond},
+	}
+	orderProbeResults(results)
+
+	expected := []string{"preferred", "fast-wide", "fast-narrow", "slow", "failed"}
+	for i, res := range results {
+		if res.URL != expected[i] {
+			t.Errorf("position %d: got %s, expected %s", i, res.URL, expected[i])
+		}
+	}
+}
+
+func TestProbeResultBetter(t *testing.T) {
+	current := ProbeResult{Latency: 120 * time.Millisecond}
+
+	cases := []struct {
+		res    ProbeResult
+		better bool
+	}{
+		{ProbeResult{Latency: 110 * time.Millisecond}, false},
+		{ProbeResult{Latency: 60 * time.Millisecond}, false},
+		{ProbeResult{Latency: 10 * time.Millisecond}, true},
+		{ProbeResult{Latency: 10 * time.Millisecond, Error: "failed"}, false},
+		{ProbeResult{Latency: 500 * time.Millisecond, Preferred: true}, true},
+	}
+	for i, tc := range cases {
+		if better := tc.res.better(current); better != tc.better {
+			t.Errorf("case %d: better => %v, expected %v", i, better, tc.better)
+		}
+	}
+
+	failed := ProbeResult{Error: "failed"}
+	if !current.better(failed) {
+		t.Error("a reachable relay should be better than a failed one")
+	}
+}
+
+func TestProbeRelay(t *testing.T) {
+	relay, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer relay.Close()
+	go func() {
+		for {
+			conn, err := relay.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	status := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", 64<<10)))
+	}))
+	defer status.Close()
+	statusURL, _ := url.Parse(status.URL)
+
+	addr := "relay://" + relay.Addr().String() + "/?statusAddr=" + statusURL.Host + "&sessionLimitBps=1000"
+	res := probeRelay(context.Background(), addr, "DE", []string{"DE"})
+	if res.Error != "" {
+		t.Fatal(res.Error)
+	}
+	if !res.Preferred {
+		t.Error("expected relay to be preferred")
+	}
+	if res.Latency <= 0 || res.Latency >= time.Second {
+		t.Errorf("unexpected latency %v", res.Latency)
+	}
+	if res.Throughput != 1000 {
+		t.Errorf("expected throughput capped to session limit, got %v", res.Throughput)
+	}
+
+	relay.Close()
+	res = probeRelay(context.Background(), addr, "DE", nil)
+	if res.Error == "" {
+		t.Error("expected probing a closed relay to fail")
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fake is just enough of an S3 service, keeping one bucket in memory, to
+// test the users of this package against.
+type Fake struct {
+	bucket    string
+	accessKey string
+	mut       sync.Mutex
+	objects   map[string]fakeObject
+}
+
+type fakeObject struct {
+	data     []byte
+	meta     http.Header
+	modified time.Time
+}
+
+// NewFake returns a fake service for the given bucket, accepting requests
+// signed with the given access key. Signatures aren't verified.
+func NewFake(bucket, accessKey string) *Fake {
+	return &Fake{
+		bucket:    bucket,
+		accessKey: accessKey,
+		objects:   make(map[string]fakeObject),
+	}
+}
+
+// Keys returns the sorted keys of all objects in the bucket.
+func (f *Fake) Keys() []string {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	keys := make([]string, 0, len(f.objects))
+	for k := range f.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *Fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+f.accessKey+"/") {
+		f.error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/"+f.bucket)
+	if key == r.URL.Path || (key != "" && key[0] != '/') {
+		f.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	key = strings.TrimPrefix(key, "/")
+
+	switch {
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		src, _ := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		obj, ok := f.objects[strings.TrimPrefix(src, "/"+f.bucket+"/")]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		if r.Header.Get("X-Amz-Metadata-Directive") == "REPLACE" {
+			obj.meta = fakeMeta(r.Header)
+		}
+		obj.modified = time.Now()
+		f.objects[key] = obj
+		w.Write([]byte("<CopyObjectResult></CopyObjectResult>"))
+
+	case r.Method == http.MethodPut:
+		bs, _ := ioutil.ReadAll(r.Body)
+		f.objects[key] = fakeObject{data: bs, meta: fakeMeta(r.Header), modified: time.Now()}
+
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodGet && key == "":
+		f.list(w, r.URL.Query())
+
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := f.objects[key]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		for k, vs := range obj.meta {
+			w.Header()[k] = vs
+		}
+		http.ServeContent(w, r, "", obj.modified, bytes.NewReader(obj.data))
+
+	default:
+		f.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (f *Fake) list(w http.ResponseWriter, query url.Values) {
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+
+	var res ListResult
+	seen := make(map[string]bool)
+	var keys []string
+	for k := range f.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(k[len(prefix):], delimiter); i >= 0 {
+				common := k[:len(prefix)+i+len(delimiter)]
+				if !seen[common] {
+					seen[common] = true
+					res.CommonPrefixes = append(res.CommonPrefixes, struct{ Prefix string }{common})
+				}
+				continue
+			}
+		}
+		res.Contents = append(res.Contents, Object{k, f.objects[k].modified, int64(len(f.objects[k].data))})
+	}
+
+	bs, _ := xml.Marshal(struct {
+		XMLName xml.Name `xml:"ListBucketResult"`
+		ListResult
+	}{ListResult: res})
+	w.Write(bs)
+}
+
+func (f *Fake) error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	bs, _ := xml.Marshal(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: http.StatusText(status)})
+	w.Write(bs)
+}
+
+func fakeMeta(header http.Header) http.Header {
+	meta := http.Header{}
+	for k, vs := range header {
+		if strings.HasPrefix(k, "X-Amz-Meta-") {
+			meta[k] = vs
+		}
+	}
+	return meta
+}
@@ -0,0 +1,349 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package s3 implements the small part of the S3 API that Syncthing needs
+// to keep files in S3 compatible object storage.
+package s3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/dialer"
+)
+
+const (
+	// Objects are uploaded in a single request, which S3 limits to 5 GiB.
+	MaxObjectSize = 5 << 30
+	// MtimeHeader holds the modification time of the file an object was
+	// uploaded from, as the last modified time of objects is the upload
+	// time.
+	MtimeHeader   = "X-Amz-Meta-Syncthing-Mtime"
+	DefaultRegion = "us-east-1"
+
+	requestTimeout  = time.Hour
+	service         = "s3"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// A Client makes requests for the objects in one bucket.
+type Client struct {
+	endpoint  string
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// NewClient returns a client for the bucket at the given endpoint, which
+// is the http or https URL of the service. An empty region means the
+// default region.
+func NewClient(endpoint, bucket, region, accessKey, secretKey string) *Client {
+	if region == "" {
+		region = DefaultRegion
+	}
+	return &Client{
+		endpoint:  endpoint,
+		bucket:    bucket,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: dialer.DialContext,
+				Proxy:       http.ProxyFromEnvironment,
+			},
+		},
+	}
+}
+
+func (c *Client) String() string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(c.endpoint, "/"), c.bucket)
+}
+
+// Error is an unsuccessful response from the service.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("unexpected status %s", http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// IsNotFound returns true if the error is a not found response.
+func IsNotFound(err error) bool {
+	var serr *Error
+	return errors.As(err, &serr) && serr.StatusCode == http.StatusNotFound
+}
+
+// Request makes a signed request for the object with the given key, or for
+// the bucket if the key is empty. Unsuccessful responses are returned as an
+// *Error.
+func (c *Client) Request(method, key string, query url.Values, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	if c.endpoint == "" || c.bucket == "" {
+		return nil, errors.New("endpoint and bucket must be set")
+	}
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported endpoint URL scheme %q", u.Scheme)
+	}
+	// Path style addressing, as supported by all S3 compatible services.
+	// Joining would drop the trailing slash of directory marker keys.
+	u.Path = path.Join("/", u.Path, c.bucket)
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawPath = Escape(u.Path, false)
+	u.RawQuery = canonicalQuery(query)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+		if size == 0 {
+			// Otherwise it's taken as unknown and sent chunked.
+			req.Body = http.NoBody
+		}
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	c.Sign(req, time.Now())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer cancel()
+		defer resp.Body.Close()
+		serr := &Error{StatusCode: resp.StatusCode}
+		bs, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		_ = xml.Unmarshal(bs, serr)
+		return nil, serr
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// Do makes a request like Request, for when only the response headers are
+// of interest.
+func (c *Client) Do(method, key string, query url.Values, header http.Header) (http.Header, error) {
+	resp, err := c.Request(method, key, query, nil, 0, header)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp.Header, nil
+}
+
+// Copy copies the object at src to dst, replacing its metadata with the
+// given headers unless they are nil.
+func (c *Client) Copy(src, dst string, meta http.Header) error {
+	header := http.Header{}
+	header.Set("X-Amz-Copy-Source", Escape("/"+c.bucket+"/"+src, false))
+	if meta != nil {
+		header.Set("X-Amz-Metadata-Directive", "REPLACE")
+		for k, vs := range meta {
+			header[k] = vs
+		}
+	}
+	// Copies can fail after the status has been sent, with the error in
+	// the body instead.
+	resp, err := c.Request(http.MethodPut, dst, nil, nil, 0, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	bs, err := ioutil.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return err
+	}
+	serr := &Error{StatusCode: resp.StatusCode}
+	if xml.Unmarshal(bs, serr) == nil && serr.Code != "" {
+		return serr
+	}
+	return nil
+}
+
+// An Object is an entry in a listing.
+type Object struct {
+	Key          string
+	LastModified time.Time
+	Size         int64
+}
+
+// ListResult is the response to a ListObjectsV2 request.
+type ListResult struct {
+	Contents       []Object
+	CommonPrefixes []struct {
+		Prefix string
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// List lists the objects with keys starting with the given prefix. With a
+// delimiter, keys containing it after the prefix are rolled up into common
+// prefixes, which are returned separately. At most max objects and common
+// prefixes are returned, or all if max is zero.
+func (c *Client) List(prefix, delimiter string, max int) ([]Object, []string, error) {
+	var objects []Object
+	var prefixes []string
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	if delimiter != "" {
+		query.Set("delimiter", delimiter)
+	}
+	if max > 0 {
+		query.Set("max-keys", fmt.Sprint(max))
+	}
+	for {
+		resp, err := c.Request(http.MethodGet, "", query, nil, 0, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		var res ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&res)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		objects = append(objects, res.Contents...)
+		for _, p := range res.CommonPrefixes {
+			prefixes = append(prefixes, p.Prefix)
+		}
+
+		if !res.IsTruncated || res.NextContinuationToken == "" || (max > 0 && len(objects)+len(prefixes) >= max) {
+			break
+		}
+		query.Set("continuation-token", res.NextContinuationToken)
+	}
+	return objects, prefixes, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// Sign adds the AWS signature version 4 authorization header to the
+// request, signing the host and all headers already set.
+func (c *Client) Sign(req *http.Request, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, vs := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(vs, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := amzDate[:8] + "/" + c.region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + c.secretKey)
+	for _, part := range []string{amzDate[:8], c.region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s", c.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery returns the query string with sorted parameters and
+// encoded as required for signing.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, val := range query[k] {
+			parts = append(parts, Escape(k, true)+"="+Escape(val, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// Escape percent encodes everything but the unreserved characters, and
+// slashes unless told otherwise.
+func Escape(s string, escapeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !escapeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,20 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package scanner
+
+import "time"
+
+// How often a paused hasher checks whether it may run again.
+const backoffInterval = time.Second
+
+// A Backoff limits the number of hashers running at the moment, for
+// example depending on the system load.
+type Backoff interface {
+	// Allowed returns how many of the given number of hashers may run, at
+	// least one.
+	Allowed(hashers int) int
+}
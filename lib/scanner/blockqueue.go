@@ -9,15 +9,22 @@ package scanner
 import (
 	"context"
 	"errors"
+	"runtime"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/sync"
 )
 
 // HashFile hashes the files and returns a list of blocks representing the file.
-func HashFile(ctx context.Context, fs fs.Filesystem, path string, blockSize int, counter Counter, useWeakHashes bool) ([]protocol.BlockInfo, error) {
-	fd, err := fs.Open(path)
+func HashFile(ctx context.Context, fs fs.Filesystem, path string, blockSize int, counter Counter, hashAlgo protocol.BlockHashAlgorithm, useWeakHashes bool) ([]protocol.BlockInfo, error) {
+	return hashFile(ctx, fs, path, blockSize, counter, nil, hashAlgo, useWeakHashes, false)
+}
+
+func hashFile(ctx context.Context, filesystem fs.Filesystem, path string, blockSize int, counter Counter, throttle Throttle, hashAlgo protocol.BlockHashAlgorithm, useWeakHashes, contentDefined bool) ([]protocol.BlockInfo, error) {
+	fd, err := filesystem.Open(path)
 	if err != nil {
 		l.Debugln("open:", err)
 		return nil, err
@@ -36,7 +43,26 @@ func HashFile(ctx context.Context, fs fs.Filesystem, path string, blockSize int,
 
 	// Hash the file. This may take a while for large files.
 
-	blocks, err := Blocks(ctx, fd, blockSize, size, counter, useWeakHashes)
+	var r readerReaderAt = fd
+	if throttle != nil {
+		r = &throttledReader{ctx: ctx, r: fd, throttle: throttle}
+	}
+	var blocks []protocol.BlockInfo
+	if contentDefined {
+		blocks, err = ContentBlocks(ctx, r, blockSize, size, counter, hashAlgo, useWeakHashes)
+	} else {
+		// Holes in sparse files are known to be zeroes, so reading them
+		// can be skipped.
+		holes, herr := fs.Holes(fd, size)
+		if herr != nil {
+			l.Debugln("holes:", herr)
+		}
+		if len(holes) > 0 {
+			blocks, err = sparseBlocks(ctx, r, holes, blockSize, size, counter, hashAlgo, useWeakHashes)
+		} else {
+			blocks, err = Blocks(ctx, r, blockSize, size, counter, hashAlgo, useWeakHashes)
+		}
+	}
 	if err != nil {
 		l.Debugln("blocks:", err)
 		return nil, err
@@ -62,36 +88,62 @@ func HashFile(ctx context.Context, fs fs.Filesystem, path string, blockSize int,
 // workers are used in parallel. The outbox will become closed when the inbox
 // is closed and all items handled.
 type parallelHasher struct {
-	fs      fs.Filesystem
-	outbox  chan<- ScanResult
-	inbox   <-chan protocol.FileInfo
-	counter Counter
-	done    chan<- struct{}
-	wg      sync.WaitGroup
+	fs             fs.Filesystem
+	workers        int
+	backoff        Backoff
+	throttle       Throttle
+	lowPriority    bool
+	outbox         chan<- ScanResult
+	inbox          <-chan protocol.FileInfo
+	counter        Counter
+	done           chan<- struct{}
+	contentDefined bool
+	hashAlgo       protocol.BlockHashAlgorithm
+	wg             sync.WaitGroup
 }
 
-func newParallelHasher(ctx context.Context, fs fs.Filesystem, workers int, outbox chan<- ScanResult, inbox <-chan protocol.FileInfo, counter Counter, done chan<- struct{}) {
+func newParallelHasher(ctx context.Context, fs fs.Filesystem, workers int, backoff Backoff, throttle Throttle, lowPriority bool, outbox chan<- ScanResult, inbox <-chan protocol.FileInfo, counter Counter, done chan<- struct{}, contentDefined bool, hashAlgo protocol.BlockHashAlgorithm) {
 	ph := &parallelHasher{
-		fs:      fs,
-		outbox:  outbox,
-		inbox:   inbox,
-		counter: counter,
-		done:    done,
-		wg:      sync.NewWaitGroup(),
+		fs:             fs,
+		workers:        workers,
+		backoff:        backoff,
+		throttle:       throttle,
+		lowPriority:    lowPriority,
+		outbox:         outbox,
+		inbox:          inbox,
+		counter:        counter,
+		done:           done,
+		contentDefined: contentDefined,
+		hashAlgo:       hashAlgo,
+		wg:             sync.NewWaitGroup(),
 	}
 
 	ph.wg.Add(workers)
 	for i := 0; i < workers; i++ {
-		go ph.hashFiles(ctx)
+		go ph.hashFiles(ctx, i)
 	}
 
 	go ph.closeWhenDone()
 }
 
-func (ph *parallelHasher) hashFiles(ctx context.Context) {
+func (ph *parallelHasher) hashFiles(ctx context.Context, worker int) {
 	defer ph.wg.Done()
 
+	if ph.lowPriority {
+		// The priority is that of the thread, which is never unlocked, so
+		// that it goes away along with the goroutine instead of running
+		// other goroutines at low priority.
+		runtime.LockOSThread()
+		if err := osutil.SetThreadLowPriority(); err != nil {
+			l.Debugln("Lowering hasher priority:", err)
+		}
+	}
+
 	for {
+		if !ph.waitForTurn(ctx, worker) {
+			return
+		}
+
 		select {
 		case f, ok := <-ph.inbox:
 			if !ok {
@@ -102,7 +154,7 @@ func (ph *parallelHasher) hashFiles(ctx context.Context) {
 				panic("Bug. Asked to hash a directory or a deleted file.")
 			}
 
-			blocks, err := HashFile(ctx, ph.fs, f.Name, f.BlockSize(), ph.counter, true)
+			blocks, err := hashFile(ctx, ph.fs, f.Name, f.BlockSize(), ph.counter, ph.throttle, ph.hashAlgo, true, ph.contentDefined)
 			if err != nil {
 				handleError(ctx, "hashing", f.Name, err, ph.outbox)
 				continue
@@ -110,6 +162,7 @@ func (ph *parallelHasher) hashFiles(ctx context.Context) {
 
 			f.Blocks = blocks
 			f.BlocksHash = protocol.BlocksHash(blocks)
+			f.BlockHashAlgorithm = ph.hashAlgo
 
 			// The size we saw when initially deciding to hash the file
 			// might not have been the size it actually had when we hashed
@@ -132,6 +185,22 @@ func (ph *parallelHasher) hashFiles(ctx context.Context) {
 	}
 }
 
+// waitForTurn blocks while the backoff doesn't allow the given worker to
+// run, returning false if the context is cancelled meanwhile.
+func (ph *parallelHasher) waitForTurn(ctx context.Context, worker int) bool {
+	if ph.backoff == nil {
+		return true
+	}
+	for worker >= ph.backoff.Allowed(ph.workers) {
+		select {
+		case <-time.After(backoffInterval):
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
 func (ph *parallelHasher) closeWhenDone() {
 	ph.wg.Wait()
 	// In case the hasher aborted on context, wait for filesystem
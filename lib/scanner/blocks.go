@@ -13,8 +13,10 @@ import (
 	"hash/adler32"
 	"io"
 
+	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/sha256"
+	"golang.org/x/crypto/blake2b"
 )
 
 var SHA256OfNothing = []uint8{0xe3, 0xb0, 0xc4, 0x42, 0x98, 0xfc, 0x1c, 0x14, 0x9a, 0xfb, 0xf4, 0xc8, 0x99, 0x6f, 0xb9, 0x24, 0x27, 0xae, 0x41, 0xe4, 0x64, 0x9b, 0x93, 0x4c, 0xa4, 0x95, 0x99, 0x1b, 0x78, 0x52, 0xb8, 0x55}
@@ -23,14 +25,35 @@ type Counter interface {
 	Update(bytes int64)
 }
 
+// newBlockHash returns the hash function for blocks hashed with the given
+// algorithm.
+func newBlockHash(hashAlgo protocol.BlockHashAlgorithm) hash.Hash {
+	if hashAlgo == protocol.BlockHashAlgorithmBLAKE2b {
+		hf, _ := blake2b.New256(nil) // only fails with a key that is too long
+		return hf
+	}
+	return sha256.New()
+}
+
+// HashBlock returns the hash of the data with the given block hash
+// algorithm.
+func HashBlock(hashAlgo protocol.BlockHashAlgorithm, data []byte) []byte {
+	if hashAlgo == protocol.BlockHashAlgorithmBLAKE2b {
+		hash := blake2b.Sum256(data)
+		return hash[:]
+	}
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
+
 // Blocks returns the blockwise hash of the reader.
-func Blocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, counter Counter, useWeakHashes bool) ([]protocol.BlockInfo, error) {
+func Blocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, counter Counter, hashAlgo protocol.BlockHashAlgorithm, useWeakHashes bool) ([]protocol.BlockInfo, error) {
 	if counter == nil {
 		counter = &noopCounter{}
 	}
 
-	hf := sha256.New()
-	const hashLength = sha256.Size
+	hf := newBlockHash(hashAlgo)
+	hashLength := hf.Size()
 
 	var weakHf hash.Hash32 = noopHash{}
 	var multiHf io.Writer = hf
@@ -54,7 +77,7 @@ func Blocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, cou
 			numBlocks++
 		}
 		blocks = make([]protocol.BlockInfo, 0, numBlocks)
-		hashes = make([]byte, 0, hashLength*numBlocks)
+		hashes = make([]byte, 0, int64(hashLength)*numBlocks)
 	}
 
 	// A 32k buffer is used for copying into the hash function.
@@ -105,24 +128,91 @@ func Blocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, cou
 		blocks = append(blocks, protocol.BlockInfo{
 			Offset: 0,
 			Size:   0,
-			Hash:   SHA256OfNothing,
+			Hash:   HashBlock(hashAlgo, nil),
 		})
 	}
 
 	return blocks, nil
 }
 
+// sparseBlocks returns the blockwise hash of the sparse file, like Blocks,
+// without reading the full blocks that lie within one of the holes.
+func sparseBlocks(ctx context.Context, r io.ReaderAt, holes []fs.Hole, blocksize int, size int64, counter Counter, hashAlgo protocol.BlockHashAlgorithm, useWeakHashes bool) ([]protocol.BlockInfo, error) {
+	if counter == nil {
+		counter = &noopCounter{}
+	}
+	// Only the SHA-256 hashes of empty blocks are known.
+	emptyHash, ok := protocol.EmptyBlockHash(blocksize)
+	if !ok || hashAlgo != protocol.BlockHashAlgorithmSHA256 {
+		return Blocks(ctx, io.NewSectionReader(r, 0, size), blocksize, size, counter, hashAlgo, useWeakHashes)
+	}
+	var emptyWeakHash uint32
+	if useWeakHashes {
+		// The Adler-32 checksum of blocksize zeroes.
+		emptyWeakHash = uint32(blocksize%65521)<<16 | 1
+	}
+
+	inHole := func(offset int64) bool {
+		for len(holes) > 0 && holes[0].Offset+holes[0].Length < offset+int64(blocksize) {
+			if holes[0].Offset+holes[0].Length > offset {
+				return false
+			}
+			holes = holes[1:]
+		}
+		return len(holes) > 0 && holes[0].Offset <= offset
+	}
+
+	var blocks []protocol.BlockInfo
+	// The offset where the current run of blocks to read starts.
+	dataStart := int64(0)
+	hashData := func(end int64) error {
+		if end == dataStart {
+			return nil
+		}
+		data, err := Blocks(ctx, io.NewSectionReader(r, dataStart, end-dataStart), blocksize, end-dataStart, counter, hashAlgo, useWeakHashes)
+		if err != nil {
+			return err
+		}
+		for _, b := range data {
+			b.Offset += dataStart
+			blocks = append(blocks, b)
+		}
+		return nil
+	}
+
+	for offset := int64(0); offset+int64(blocksize) <= size; offset += int64(blocksize) {
+		if !inHole(offset) {
+			continue
+		}
+		if err := hashData(offset); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, protocol.BlockInfo{
+			Offset:   offset,
+			Size:     blocksize,
+			Hash:     emptyHash,
+			WeakHash: emptyWeakHash,
+		})
+		counter.Update(int64(blocksize))
+		dataStart = offset + int64(blocksize)
+	}
+	if err := hashData(size); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
 // Validate quickly validates buf against the 32-bit weakHash, if not zero,
-// else against the cryptohash hash, if len(hash)>0. It is satisfied if
-// either hash matches or neither hash is given.
-func Validate(buf, hash []byte, weakHash uint32) bool {
+// else against the cryptohash hash of the given algorithm, if len(hash)>0.
+// It is satisfied if either hash matches or neither hash is given.
+func Validate(buf, hash []byte, hashAlgo protocol.BlockHashAlgorithm, weakHash uint32) bool {
 	if weakHash != 0 && adler32.Checksum(buf) == weakHash {
 		return true
 	}
 
 	if len(hash) > 0 {
-		hbuf := sha256.Sum256(buf)
-		return bytes.Equal(hbuf[:], hash)
+		return bytes.Equal(HashBlock(hashAlgo, buf), hash)
 	}
 
 	return true
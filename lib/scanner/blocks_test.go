@@ -13,12 +13,15 @@ import (
 	"fmt"
 	origAdler32 "hash/adler32"
 	mrand "math/rand"
+	"reflect"
 	"testing"
 	"testing/quick"
 
 	rollingAdler32 "github.com/chmduquesne/rollinghash/adler32"
+	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/sha256"
+	"golang.org/x/crypto/blake2b"
 )
 
 var blocksTestData = []struct {
@@ -71,7 +74,7 @@ var blocksTestData = []struct {
 func TestBlocks(t *testing.T) {
 	for testNo, test := range blocksTestData {
 		buf := bytes.NewBuffer(test.data)
-		blocks, err := Blocks(context.TODO(), buf, test.blocksize, -1, nil, true)
+		blocks, err := Blocks(context.TODO(), buf, test.blocksize, -1, nil, protocol.BlockHashAlgorithmSHA256, true)
 
 		if err != nil {
 			t.Fatal(err)
@@ -106,6 +109,129 @@ func TestBlocks(t *testing.T) {
 	}
 }
 
+func TestBlocksBLAKE2b(t *testing.T) {
+	bs := protocol.MinBlockSize
+	data := make([]byte, 2*bs+bs/2)
+	rand.Read(data)
+
+	blocks, err := Blocks(context.TODO(), bytes.NewReader(data), bs, int64(len(data)), nil, protocol.BlockHashAlgorithmBLAKE2b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("Incorrect number of blocks %d != 3", len(blocks))
+	}
+	for i, b := range blocks {
+		block := data[b.Offset : b.Offset+int64(b.Size)]
+		expected := blake2b.Sum256(block)
+		if !bytes.Equal(b.Hash, expected[:]) {
+			t.Errorf("%d: Incorrect block hash %x != %x", i, b.Hash, expected)
+		}
+		if !Validate(block, b.Hash, protocol.BlockHashAlgorithmBLAKE2b, 0) {
+			t.Errorf("%d: Block should validate with BLAKE2b", i)
+		}
+		if Validate(block, b.Hash, protocol.BlockHashAlgorithmSHA256, 0) {
+			t.Errorf("%d: Block should not validate with SHA-256", i)
+		}
+	}
+
+	blocks, err = Blocks(context.TODO(), bytes.NewReader(nil), bs, 0, nil, protocol.BlockHashAlgorithmBLAKE2b, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := blake2b.Sum256(nil); len(blocks) != 1 || !bytes.Equal(blocks[0].Hash, expected[:]) {
+		t.Errorf("Incorrect hash for an empty file: %v", blocks)
+	}
+}
+
+func TestSparseBlocks(t *testing.T) {
+	bs := protocol.MinBlockSize
+	data := make([]byte, 3*bs+bs/2)
+	rand.Read(data[:bs/2])
+	rand.Read(data[3*bs:])
+
+	// The hole starts within the first block, so only the next two are
+	// left unread.
+	holes := []fs.Hole{{Offset: int64(bs / 2), Length: int64(2*bs + bs/2)}}
+	blocks, err := sparseBlocks(context.TODO(), bytes.NewReader(data), holes, bs, int64(len(data)), nil, protocol.BlockHashAlgorithmSHA256, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := Blocks(context.TODO(), bytes.NewReader(data), bs, int64(len(data)), nil, protocol.BlockHashAlgorithmSHA256, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("Sparse blocks differ:\n%v\n%v", blocks, expected)
+	}
+	if !blocks[1].IsEmpty() || !blocks[2].IsEmpty() {
+		t.Error("Expected the blocks in the hole to be empty")
+	}
+}
+
+func TestContentBlocks(t *testing.T) {
+	const blocksize = 16 << 10
+	data := make([]byte, 1<<20)
+	mrand.New(mrand.NewSource(42)).Read(data)
+
+	blocks, err := ContentBlocks(context.TODO(), bytes.NewReader(data), blocksize, -1, nil, protocol.BlockHashAlgorithmSHA256, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var offset int64
+	for i, b := range blocks {
+		if b.Offset != offset {
+			t.Fatalf("block %d: offset %d != expected %d", i, b.Offset, offset)
+		}
+		if b.Size > 2*blocksize || b.Size < blocksize/2 && i != len(blocks)-1 {
+			t.Errorf("block %d: size %d out of bounds", i, b.Size)
+		}
+		if hash := sha256.Sum256(data[offset : offset+int64(b.Size)]); !bytes.Equal(b.Hash, hash[:]) {
+			t.Errorf("block %d: hash mismatch", i)
+		}
+		if weak := origAdler32.Checksum(data[offset : offset+int64(b.Size)]); b.WeakHash != weak {
+			t.Errorf("block %d: weak hash mismatch", i)
+		}
+		offset += int64(b.Size)
+	}
+	if offset != int64(len(data)) {
+		t.Errorf("blocks cover %d bytes, expected %d", offset, len(data))
+	}
+	if n := len(blocks); n < len(data)/blocksize/2 || n > len(data)/blocksize*2 {
+		t.Errorf("unexpected number of blocks %d", n)
+	}
+
+	// Inserting data should only change the blocks around the insertion.
+	inserted := append(append(append([]byte{}, data[:len(data)/2]...), "some inserted data"...), data[len(data)/2:]...)
+	insBlocks, err := ContentBlocks(context.TODO(), bytes.NewReader(inserted), blocksize, int64(len(inserted)), nil, protocol.BlockHashAlgorithmSHA256, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes := make(map[string]bool, len(blocks))
+	for _, b := range blocks {
+		hashes[string(b.Hash)] = true
+	}
+	changed := 0
+	for _, b := range insBlocks {
+		if !hashes[string(b.Hash)] {
+			changed++
+		}
+	}
+	if changed > 2 {
+		t.Errorf("%d of %d blocks changed by an insertion", changed, len(insBlocks))
+	}
+
+	// Empty files result in a single empty block, as with Blocks.
+	blocks, err = ContentBlocks(context.TODO(), bytes.NewReader(nil), blocksize, 0, nil, protocol.BlockHashAlgorithmSHA256, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 || blocks[0].Size != 0 || !bytes.Equal(blocks[0].Hash, SHA256OfNothing) {
+		t.Errorf("unexpected blocks for empty file: %v", blocks)
+	}
+}
+
 func TestAdler32Variants(t *testing.T) {
 	// Verify that the two adler32 functions give matching results for a few
 	// different blocks of data.
@@ -125,7 +251,7 @@ func TestAdler32Variants(t *testing.T) {
 
 		// Make sure whatever we use in Validate matches too resp. this
 		// tests gets adjusted if we ever switch the weak hash algo.
-		return sum1 == sum2 && Validate(data, nil, sum1)
+		return sum1 == sum2 && Validate(data, nil, protocol.BlockHashAlgorithmSHA256, sum1)
 	}
 
 	// protocol block sized data
@@ -172,7 +298,7 @@ func TestAdler32Variants(t *testing.T) {
 				t.Errorf("Mismatch after roll; i=%d, sum1=%08x, sum3=%08x", i, sum1, sum3)
 				break
 			}
-			if !Validate(window, nil, sum1) {
+			if !Validate(window, nil, protocol.BlockHashAlgorithmSHA256, sum1) {
 				t.Errorf("Validation failure after roll; i=%d", i)
 			}
 		}
@@ -215,7 +341,7 @@ func BenchmarkValidate(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		for _, b := range blocks {
-			Validate(b.data[:], b.hash[:], b.weakhash)
+			Validate(b.data[:], b.hash[:], protocol.BlockHashAlgorithmSHA256, b.weakhash)
 		}
 	}
 }
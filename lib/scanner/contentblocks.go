@@ -0,0 +1,131 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package scanner
+
+import (
+	"context"
+	"hash"
+	"hash/adler32"
+	"io"
+	"math/bits"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// The gear table maps bytes to the random values rolled into the chunking
+// hash. It must be the same everywhere, for identical content to be split
+// into identical blocks on all devices.
+var gear [256]uint64
+
+func init() {
+	// splitmix64 with a fixed seed
+	x := uint64(0x5379_6e63_7468_696e)
+	for i := range gear {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		gear[i] = z ^ (z >> 31)
+	}
+}
+
+// ContentBlocks returns the blockwise hash of the reader, like Blocks, but
+// with block boundaries determined by the content instead of at fixed
+// offsets. Inserting or removing data thus only changes the blocks around
+// the change, instead of all blocks following it. Blocks are between half
+// and twice the given block size, except for the last one, and average
+// about the block size.
+func ContentBlocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, counter Counter, hashAlgo protocol.BlockHashAlgorithm, useWeakHashes bool) ([]protocol.BlockInfo, error) {
+	if counter == nil {
+		counter = &noopCounter{}
+	}
+
+	minSize := blocksize / 2
+	maxSize := 2 * blocksize
+	if maxSize > protocol.MaxBlockSize {
+		maxSize = protocol.MaxBlockSize
+	}
+	// A boundary is placed where the low bits of the hash are all zero,
+	// which on average happens every minSize bytes after the minimum size.
+	mask := uint64(1)<<uint(bits.Len(uint(minSize))-1) - 1
+
+	hf := newBlockHash(hashAlgo)
+	var weakHf hash.Hash32 = noopHash{}
+	if useWeakHashes {
+		weakHf = adler32.New()
+	}
+
+	var blocks []protocol.BlockInfo
+	if sizehint >= 0 {
+		r = io.LimitReader(r, sizehint)
+		blocks = make([]protocol.BlockInfo, 0, sizehint/int64(blocksize)+1)
+	}
+
+	buf := make([]byte, maxSize)
+	var offset int64
+	var n int
+	eof := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if !eof {
+			read, err := io.ReadFull(r, buf[n:])
+			n += read
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				eof = true
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		if n == 0 {
+			break
+		}
+
+		size := n
+		if n > minSize {
+			var h uint64
+			for i := minSize; i < n; i++ {
+				h = h<<1 + gear[buf[i]]
+				if h&mask == 0 {
+					size = i + 1
+					break
+				}
+			}
+		}
+
+		block := buf[:size]
+		hf.Write(block)
+		weakHf.Write(block)
+		blocks = append(blocks, protocol.BlockInfo{
+			Size:     size,
+			Offset:   offset,
+			Hash:     hf.Sum(nil),
+			WeakHash: weakHf.Sum32(),
+		})
+		hf.Reset()
+		weakHf.Reset()
+		counter.Update(int64(size))
+
+		offset += int64(size)
+		n = copy(buf, buf[size:n])
+	}
+
+	if len(blocks) == 0 {
+		// Empty file
+		blocks = append(blocks, protocol.BlockInfo{
+			Offset: 0,
+			Size:   0,
+			Hash:   HashBlock(hashAlgo, nil),
+		})
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package scanner
+
+import (
+	"context"
+	"io"
+)
+
+// A Throttle limits how fast files are read while hashing, for example in
+// bytes or operations per second.
+type Throttle interface {
+	// Wait blocks until n bytes may be read in one operation, returning
+	// an error if the context is cancelled meanwhile.
+	Wait(ctx context.Context, n int) error
+}
+
+type readerReaderAt interface {
+	io.Reader
+	io.ReaderAt
+}
+
+// throttledReader waits for its throttle before each read.
+type throttledReader struct {
+	ctx      context.Context
+	r        readerReaderAt
+	throttle Throttle
+}
+
+func (t *throttledReader) Read(bs []byte) (int, error) {
+	if err := t.throttle.Wait(t.ctx, len(bs)); err != nil {
+		return 0, err
+	}
+	return t.r.Read(bs)
+}
+
+func (t *throttledReader) ReadAt(bs []byte, off int64) (int, error) {
+	if err := t.throttle.Wait(t.ctx, len(bs)); err != nil {
+		return 0, err
+	}
+	return t.r.ReadAt(bs, off)
+}
@@ -59,6 +59,31 @@ type Config struct {
 	ModTimeWindow time.Duration
 	// Event logger to which the scan progress events are sent
 	EventLogger events.Logger
+	// If ContentDefinedBlocks is true, files are split into blocks at
+	// content defined boundaries instead of at fixed offsets.
+	ContentDefinedBlocks bool
+	// The hash function for the blocks of the files that are hashed.
+	BlockHashAlgorithm protocol.BlockHashAlgorithm
+	// If SyncXattrs is true, the extended attributes of files and
+	// directories are included in the file infos.
+	SyncXattrs bool
+	// If SyncOwnership is true, the owner and group of files and
+	// directories are included in the file infos.
+	SyncOwnership bool
+	// If FollowSymlinks is true, symlinks are scanned as the items they
+	// point to. If SkipSymlinks is true, they aren't scanned at all.
+	FollowSymlinks bool
+	SkipSymlinks   bool
+	// Optional function returning the target a symlink is synced with,
+	// given its target on disk.
+	RewriteSymlinkTarget func(target string) string
+	// Optional limit on the number of hashers running at the moment.
+	HasherBackoff Backoff
+	// Optional limit on how fast files are read while hashing.
+	Throttle Throttle
+	// If LowPriority is true, hashing runs at the lowest CPU and I/O
+	// priority the platform allows.
+	LowPriority bool
 }
 
 type CurrentFiler interface {
@@ -121,7 +146,7 @@ func (w *walker) walk(ctx context.Context) chan ScanResult {
 	// We're not required to emit scan progress events, just kick off hashers,
 	// and feed inputs directly from the walker.
 	if w.ProgressTickIntervalS < 0 {
-		newParallelHasher(ctx, w.Filesystem, w.Hashers, finishedChan, toHashChan, nil, nil)
+		newParallelHasher(ctx, w.Filesystem, w.Hashers, w.HasherBackoff, w.Throttle, w.LowPriority, finishedChan, toHashChan, nil, nil, w.ContentDefinedBlocks, w.BlockHashAlgorithm)
 		return finishedChan
 	}
 
@@ -152,7 +177,7 @@ func (w *walker) walk(ctx context.Context) chan ScanResult {
 		done := make(chan struct{})
 		progress := newByteCounter()
 
-		newParallelHasher(ctx, w.Filesystem, w.Hashers, finishedChan, realToHashChan, progress, done)
+		newParallelHasher(ctx, w.Filesystem, w.Hashers, w.HasherBackoff, w.Throttle, w.LowPriority, finishedChan, realToHashChan, progress, done, w.ContentDefinedBlocks, w.BlockHashAlgorithm)
 
 		// A routine which actually emits the FolderScanProgress events
 		// every w.ProgressTicker ticks, until the hasher routines terminate.
@@ -257,6 +282,11 @@ func (w *walker) walkAndHashFiles(ctx context.Context, toHashChan chan<- protoco
 			return skip
 		}
 
+		if err == nil && info.IsSymlink() && w.FollowSymlinks {
+			// We get called again with the item it points to.
+			return fs.FollowSymlink
+		}
+
 		if fs.IsTemporary(path) {
 			l.Debugln("temporary:", path, "err:", err)
 			if err == nil && info.IsRegular() && info.ModTime().Add(w.TempLifetime).Before(now) {
@@ -313,7 +343,7 @@ func (w *walker) walkAndHashFiles(ctx context.Context, toHashChan chan<- protoco
 		// appended in the first iteration.
 		for _, name := range append([]string{""}, strings.Split(rel, string(fs.PathSeparator))...) {
 			ignoredParent = filepath.Join(ignoredParent, name)
-			info, err = w.Filesystem.Lstat(ignoredParent)
+			info, err = w.lstat(ignoredParent)
 			// An error here would be weird as we've already gotten to this point, but act on it nonetheless
 			if err != nil {
 				handleError(ctx, "scan", ignoredParent, err, finishedChan)
@@ -329,6 +359,16 @@ func (w *walker) walkAndHashFiles(ctx context.Context, toHashChan chan<- protoco
 	}
 }
 
+// lstat returns the info of the item, or of the item it points to if it is
+// a symlink that is followed.
+func (w *walker) lstat(path string) (fs.FileInfo, error) {
+	info, err := w.Filesystem.Lstat(path)
+	if err == nil && info.IsSymlink() && w.FollowSymlinks {
+		return w.Filesystem.Stat(path)
+	}
+	return info, err
+}
+
 func (w *walker) handleItem(ctx context.Context, path string, info fs.FileInfo, toHashChan chan<- protocol.FileInfo, finishedChan chan<- ScanResult, skip error) error {
 	oldPath := path
 	path, err := w.normalizePath(path, info)
@@ -352,13 +392,13 @@ func (w *walker) handleItem(ctx context.Context, path string, info fs.FileInfo,
 		err = w.walkDir(ctx, path, info, finishedChan)
 
 	case info.IsRegular():
-		err = w.walkRegular(ctx, path, info, toHashChan)
+		err = w.walkRegular(ctx, path, info, toHashChan, finishedChan)
 	}
 
 	return err
 }
 
-func (w *walker) walkRegular(ctx context.Context, relPath string, info fs.FileInfo, toHashChan chan<- protocol.FileInfo) error {
+func (w *walker) walkRegular(ctx context.Context, relPath string, info fs.FileInfo, toHashChan chan<- protocol.FileInfo, finishedChan chan<- ScanResult) error {
 	curFile, hasCurFile := w.CurrentFiler.CurrentFile(relPath)
 
 	blockSize := protocol.BlockSize(info.Size())
@@ -381,9 +421,13 @@ func (w *walker) walkRegular(ctx context.Context, relPath string, info fs.FileIn
 	f = w.updateFileInfo(f, curFile)
 	f.NoPermissions = w.IgnorePerms
 	f.RawBlockSize = blockSize
+	if !w.updateXattrs(ctx, &f, finishedChan) {
+		return nil
+	}
+	w.updateOwnership(&f, info)
 
 	if hasCurFile {
-		if curFile.IsEquivalentOptional(f, w.ModTimeWindow, w.IgnorePerms, true, w.LocalFlags) {
+		if xattrsUnchanged(curFile, f) && ownershipUnchanged(curFile, f) && curFile.IsEquivalentOptional(f, w.ModTimeWindow, w.IgnorePerms, true, w.LocalFlags) {
 			return nil
 		}
 		if curFile.ShouldConflict() {
@@ -414,9 +458,13 @@ func (w *walker) walkDir(ctx context.Context, relPath string, info fs.FileInfo,
 	f, _ := CreateFileInfo(info, relPath, nil)
 	f = w.updateFileInfo(f, curFile)
 	f.NoPermissions = w.IgnorePerms
+	if !w.updateXattrs(ctx, &f, finishedChan) {
+		return nil
+	}
+	w.updateOwnership(&f, info)
 
 	if hasCurFile {
-		if curFile.IsEquivalentOptional(f, w.ModTimeWindow, w.IgnorePerms, true, w.LocalFlags) {
+		if xattrsUnchanged(curFile, f) && ownershipUnchanged(curFile, f) && curFile.IsEquivalentOptional(f, w.ModTimeWindow, w.IgnorePerms, true, w.LocalFlags) {
 			return nil
 		}
 		if curFile.ShouldConflict() {
@@ -445,7 +493,7 @@ func (w *walker) walkDir(ctx context.Context, relPath string, info fs.FileInfo,
 func (w *walker) walkSymlink(ctx context.Context, relPath string, info fs.FileInfo, finishedChan chan<- ScanResult) error {
 	// Symlinks are not supported on Windows. We ignore instead of returning
 	// an error.
-	if runtime.GOOS == "windows" {
+	if runtime.GOOS == "windows" || w.SkipSymlinks {
 		return nil
 	}
 
@@ -454,6 +502,9 @@ func (w *walker) walkSymlink(ctx context.Context, relPath string, info fs.FileIn
 		handleError(ctx, "reading link:", relPath, err, finishedChan)
 		return nil
 	}
+	if w.RewriteSymlinkTarget != nil {
+		f.SymlinkTarget = w.RewriteSymlinkTarget(f.SymlinkTarget)
+	}
 
 	curFile, hasCurFile := w.CurrentFiler.CurrentFile(relPath)
 
@@ -556,6 +607,52 @@ func (w *walker) updateFileInfo(file, curFile protocol.FileInfo) protocol.FileIn
 	return file
 }
 
+// updateXattrs reads the extended attributes into the file info, if they
+// are synced. It returns false if that failed and the error was reported.
+func (w *walker) updateXattrs(ctx context.Context, f *protocol.FileInfo, finishedChan chan<- ScanResult) bool {
+	if !w.SyncXattrs {
+		return true
+	}
+	xattrs, err := w.Filesystem.GetXattr(f.Name)
+	if errors.Is(err, fs.ErrXattrsNotSupported) {
+		return true
+	} else if err != nil {
+		handleError(ctx, "reading extended attributes:", f.Name, err, finishedChan)
+		return false
+	}
+	f.XattrData = &protocol.XattrData{Xattrs: make([]protocol.Xattr, len(xattrs))}
+	for i, xa := range xattrs {
+		f.XattrData.Xattrs[i] = protocol.Xattr{Name: xa.Name, Value: xa.Value}
+	}
+	return true
+}
+
+// updateOwnership sets the owner and group in the file info, if they are
+// synced and the platform has them.
+func (w *walker) updateOwnership(f *protocol.FileInfo, info fs.FileInfo) {
+	if !w.SyncOwnership || info.Owner() < 0 || info.Group() < 0 {
+		return
+	}
+	f.OwnershipData = &protocol.OwnershipData{
+		UserName:  osutil.UserName(info.Owner()),
+		GroupName: osutil.GroupName(info.Group()),
+		UID:       info.Owner(),
+		GID:       info.Group(),
+	}
+}
+
+// ownershipUnchanged is like xattrsUnchanged, for the ownership.
+func ownershipUnchanged(curFile, f protocol.FileInfo) bool {
+	return curFile.OwnershipData != nil || f.OwnershipData == nil
+}
+
+// xattrsUnchanged returns false if the current file lacks the extended
+// attributes the new one has, which isn't considered a difference when
+// comparing file infos.
+func xattrsUnchanged(curFile, f protocol.FileInfo) bool {
+	return curFile.XattrData != nil || f.XattrData == nil
+}
+
 func handleError(ctx context.Context, context, path string, err error, finishedChan chan<- ScanResult) {
 	// Ignore missing items, as deletions are not handled by the scanner.
 	if fs.IsNotExist(err) {
@@ -19,6 +19,7 @@ import (
 	"runtime"
 	rdebug "runtime/debug"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 
@@ -132,7 +133,7 @@ func TestVerify(t *testing.T) {
 	progress := newByteCounter()
 	defer progress.Close()
 
-	blocks, err := Blocks(context.TODO(), buf, blocksize, -1, progress, false)
+	blocks, err := Blocks(context.TODO(), buf, blocksize, -1, progress, protocol.BlockHashAlgorithmSHA256, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -291,6 +292,71 @@ func TestWalkSymlinkUnix(t *testing.T) {
 	}
 }
 
+func TestWalkSymlinkPolicies(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping unsupported symlink test")
+	}
+
+	dir, err := ioutil.TempDir("", "syncthing-symlinks-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"target/dir", "folder"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "target/file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for name, target := range map[string]string{
+		"file":     filepath.Join(dir, "target/file"),
+		"dir":      filepath.Join(dir, "target/dir"),
+		"absolute": "/mnt/data/file",
+	} {
+		if err := os.Symlink(target, filepath.Join(dir, "folder", name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scan := func(cfg Config) map[string]protocol.FileInfo {
+		t.Helper()
+		cfg.Filesystem = fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(dir, "folder"))
+		cfg.CurrentFiler = make(fakeCurrentFiler)
+		files := make(map[string]protocol.FileInfo)
+		for res := range Walk(context.TODO(), cfg) {
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			files[res.File.Name] = res.File
+		}
+		return files
+	}
+
+	cfg, cancel := testConfig()
+	defer cancel()
+	cfg.RewriteSymlinkTarget = func(target string) string {
+		return strings.Replace(target, "/mnt/data/", "/data/", 1)
+	}
+	files := scan(cfg)
+	if len(files) != 3 || !files["dir"].IsSymlink() || files["absolute"].SymlinkTarget != "/data/file" {
+		t.Errorf("Expected three symlinks with rewritten absolute target, got %v", files)
+	}
+
+	cfg.FollowSymlinks = true
+	files = scan(cfg)
+	if len(files) != 2 || files["file"].Type != protocol.FileInfoTypeFile || files["file"].Size != 4 || files["dir"].Type != protocol.FileInfoTypeDirectory {
+		t.Errorf("Expected the file and directory pointed to, got %v", files)
+	}
+
+	cfg.FollowSymlinks = false
+	cfg.SkipSymlinks = true
+	if files := scan(cfg); len(files) != 0 {
+		t.Errorf("Expected symlinks to be skipped, got %v", files)
+	}
+}
+
 func TestWalkSymlinkWindows(t *testing.T) {
 	if runtime.GOOS != "windows" {
 		t.Skip("skipping unsupported symlink test")
@@ -560,7 +626,7 @@ func BenchmarkHashFile(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		if _, err := HashFile(context.TODO(), fs.NewFilesystem(testFsType, ""), testdataName, protocol.MinBlockSize, nil, true); err != nil {
+		if _, err := HashFile(context.TODO(), fs.NewFilesystem(testFsType, ""), testdataName, protocol.MinBlockSize, nil, protocol.BlockHashAlgorithmSHA256, true); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -858,6 +924,141 @@ func TestIncludedSubdir(t *testing.T) {
 	}
 }
 
+func TestWalkXattrs(t *testing.T) {
+	fss := fs.NewFilesystem(fs.FilesystemTypeFake, t.Name())
+
+	if err := fss.Mkdir("dir", 0777); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := fss.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+	xattrs := []fs.Xattr{{Name: "user.a", Value: []byte("a")}}
+	for _, name := range []string{"dir", "file"} {
+		if err := fss.SetXattr(name, xattrs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, cancel := testConfig()
+	defer cancel()
+	cfg.Filesystem = fss
+	cfg.SyncXattrs = true
+	current := make(fakeCurrentFiler)
+	cfg.CurrentFiler = current
+	scan := func() []protocol.FileInfo {
+		t.Helper()
+		var files []protocol.FileInfo
+		for res := range Walk(context.TODO(), cfg) {
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			files = append(files, res.File)
+		}
+		return files
+	}
+
+	files := scan()
+	if len(files) != 2 {
+		t.Fatalf("Expected two items, got %d", len(files))
+	}
+	expected := &protocol.XattrData{Xattrs: []protocol.Xattr{{Name: "user.a", Value: []byte("a")}}}
+	for _, f := range files {
+		if f.XattrData == nil || !f.XattrData.Equal(expected) {
+			t.Errorf("Unexpected extended attributes on %s: %v", f.Name, f.XattrData)
+		}
+		current[f.Name] = f
+	}
+
+	// Unchanged items aren't rescanned.
+	if files := scan(); len(files) != 0 {
+		t.Fatalf("Expected no changes, got %v", files)
+	}
+
+	// Neither are those that lack the attributes in the index, as long as
+	// they aren't synced.
+	for name, f := range current {
+		f.XattrData = nil
+		current[name] = f
+	}
+	cfg.SyncXattrs = false
+	if files := scan(); len(files) != 0 {
+		t.Fatalf("Expected no changes, got %v", files)
+	}
+	cfg.SyncXattrs = true
+	if files := scan(); len(files) != 2 {
+		t.Fatalf("Expected two changes, got %d", len(files))
+	}
+
+	// Changed attributes are picked up.
+	for _, f := range files {
+		current[f.Name] = f
+	}
+	if err := fss.SetXattr("file", append(xattrs, fs.Xattr{Name: "user.b"})); err != nil {
+		t.Fatal(err)
+	}
+	files = scan()
+	if len(files) != 1 || files[0].Name != "file" || len(files[0].XattrData.Xattrs) != 2 {
+		t.Fatalf("Expected file to have changed, got %v", files)
+	}
+}
+
+func TestWalkOwnership(t *testing.T) {
+	fss := fs.NewFilesystem(fs.FilesystemTypeFake, t.Name())
+
+	fd, err := fss.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+	if err := fss.Lchown("file", 1234, 5678); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, cancel := testConfig()
+	defer cancel()
+	cfg.Filesystem = fss
+	cfg.SyncOwnership = true
+	current := make(fakeCurrentFiler)
+	cfg.CurrentFiler = current
+	scan := func() []protocol.FileInfo {
+		t.Helper()
+		var files []protocol.FileInfo
+		for res := range Walk(context.TODO(), cfg) {
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			files = append(files, res.File)
+		}
+		return files
+	}
+
+	files := scan()
+	if len(files) != 1 {
+		t.Fatalf("Expected one item, got %d", len(files))
+	}
+	if od := files[0].OwnershipData; od == nil || od.UID != 1234 || od.GID != 5678 {
+		t.Fatalf("Unexpected ownership %v", od)
+	}
+	current["file"] = files[0]
+
+	// Unchanged items aren't rescanned.
+	if files := scan(); len(files) != 0 {
+		t.Fatalf("Expected no changes, got %v", files)
+	}
+
+	// Changed ownership is picked up.
+	if err := fss.Lchown("file", 1234, 8765); err != nil {
+		t.Fatal(err)
+	}
+	files = scan()
+	if len(files) != 1 || files[0].OwnershipData.GID != 8765 {
+		t.Fatalf("Expected file to have changed, got %v", files)
+	}
+}
+
 // Verify returns nil or an error describing the mismatch between the block
 // list and actual reader contents
 func verify(r io.Reader, blocksize int, blocks []protocol.BlockInfo) error {
@@ -897,6 +1098,46 @@ func (fcf fakeCurrentFiler) CurrentFile(name string) (protocol.FileInfo, bool) {
 	return f, ok
 }
 
+type countingThrottle struct {
+	mut   sync.Mutex
+	bytes int
+	ops   int
+}
+
+func (t *countingThrottle) Wait(_ context.Context, n int) error {
+	t.mut.Lock()
+	t.bytes += n
+	t.ops++
+	t.mut.Unlock()
+	return nil
+}
+
+func TestWalkThrottle(t *testing.T) {
+	throttle := &countingThrottle{}
+	cfg, cancel := testConfig()
+	defer cancel()
+	cfg.Throttle = throttle
+	cfg.LowPriority = true
+
+	var size int64
+	for f := range Walk(context.TODO(), cfg) {
+		if f.Err != nil {
+			t.Errorf("Error while scanning %v: %v", f.Path, f.Err)
+			continue
+		}
+		if f.File.Type == protocol.FileInfoTypeFile {
+			size += f.File.Size
+		}
+	}
+
+	if size == 0 {
+		t.Fatal("nothing was hashed")
+	}
+	if throttle.ops == 0 || int64(throttle.bytes) < size {
+		t.Errorf("throttle saw %d bytes in %d reads, expected at least %d bytes", throttle.bytes, throttle.ops, size)
+	}
+}
+
 func testConfig() (Config, context.CancelFunc) {
 	evLogger := events.NewLogger()
 	ctx, cancel := context.WithCancel(context.Background())
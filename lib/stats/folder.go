@@ -13,8 +13,9 @@ import (
 )
 
 type FolderStatistics struct {
-	LastFile LastFile  `json:"lastFile"`
-	LastScan time.Time `json:"lastScan"`
+	LastFile          LastFile  `json:"lastFile"`
+	LastScan          time.Time `json:"lastScan"`
+	LastScanDurationS float64   `json:"lastScanDurationS"`
 }
 
 type FolderStatisticsReference struct {
@@ -73,8 +74,11 @@ func (s *FolderStatisticsReference) ReceivedFile(file string, deleted bool) erro
 	return nil
 }
 
-func (s *FolderStatisticsReference) ScanCompleted() error {
-	return s.ns.PutTime("lastScan", time.Now())
+func (s *FolderStatisticsReference) ScanCompleted(duration time.Duration) error {
+	if err := s.ns.PutTime("lastScan", time.Now()); err != nil {
+		return err
+	}
+	return s.ns.PutInt64("lastScanDuration", duration.Nanoseconds())
 }
 
 func (s *FolderStatisticsReference) GetLastScanTime() (time.Time, error) {
@@ -87,6 +91,16 @@ func (s *FolderStatisticsReference) GetLastScanTime() (time.Time, error) {
 	return lastScan, nil
 }
 
+func (s *FolderStatisticsReference) GetLastScanDuration() (time.Duration, error) {
+	d, ok, err := s.ns.Int64("lastScanDuration")
+	if err != nil {
+		return 0, err
+	} else if !ok {
+		return 0, nil
+	}
+	return time.Duration(d), nil
+}
+
 func (s *FolderStatisticsReference) GetStatistics() (FolderStatistics, error) {
 	lastFile, err := s.GetLastFile()
 	if err != nil {
@@ -96,8 +110,13 @@ func (s *FolderStatisticsReference) GetStatistics() (FolderStatistics, error) {
 	if err != nil {
 		return FolderStatistics{}, err
 	}
+	lastScanDuration, err := s.GetLastScanDuration()
+	if err != nil {
+		return FolderStatistics{}, err
+	}
 	return FolderStatistics{
-		LastFile: lastFile,
-		LastScan: lastScanTime,
+		LastFile:          lastFile,
+		LastScan:          lastScanTime,
+		LastScanDurationS: lastScanDuration.Seconds(),
 	}, nil
 }
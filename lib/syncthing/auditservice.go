@@ -20,18 +20,20 @@ import (
 type auditService struct {
 	w        io.Writer // audit destination
 	evLogger events.Logger
+	mask     events.EventType
 }
 
-func newAuditService(w io.Writer, evLogger events.Logger) *auditService {
+func newAuditService(w io.Writer, evLogger events.Logger, mask events.EventType) *auditService {
 	return &auditService{
 		w:        w,
 		evLogger: evLogger,
+		mask:     mask,
 	}
 }
 
 // serve runs the audit service.
 func (s *auditService) Serve(ctx context.Context) error {
-	sub := s.evLogger.Subscribe(events.AllEvents)
+	sub := s.evLogger.Subscribe(s.mask)
 	defer sub.Unsubscribe()
 
 	enc := json.NewEncoder(s.w)
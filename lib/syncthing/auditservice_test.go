@@ -31,7 +31,7 @@ func TestAuditService(t *testing.T) {
 	<-sub.C()
 
 	auditCtx, auditCancel := context.WithCancel(context.Background())
-	service := newAuditService(buf, evLogger)
+	service := newAuditService(buf, evLogger, events.AllEvents)
 	done := make(chan struct{})
 	go func() {
 		service.Serve(auditCtx)
@@ -0,0 +1,75 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package syncthing
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+const (
+	memoryCheckInterval = 10 * time.Second
+	// Garbage is collected twice as often as by default while within a
+	// memory budget, unless GOGC says otherwise.
+	lowMemoryGCPercent = 50
+)
+
+// The memory service keeps memory usage within the configured budget, by
+// collecting garbage more eagerly and returning freed memory to the
+// operating system once usage reaches the budget.
+type memoryService struct {
+	budget uint64 // bytes
+	warned bool
+}
+
+func newMemoryService(budgetMiB int) *memoryService {
+	return &memoryService{
+		budget: uint64(budgetMiB) << 20,
+	}
+}
+
+func (s *memoryService) Serve(ctx context.Context) error {
+	if os.Getenv("GOGC") == "" {
+		debug.SetGCPercent(lowMemoryGCPercent)
+	}
+
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.check()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *memoryService) check() {
+	if memoryInUse() < s.budget {
+		s.warned = false
+		return
+	}
+
+	debug.FreeOSMemory()
+
+	if inUse := memoryInUse(); inUse >= s.budget && !s.warned {
+		l.Warnf("Memory usage of %d MiB exceeds the budget of %d MiB", inUse>>20, s.budget>>20)
+		s.warned = true
+	}
+}
+
+// memoryInUse returns the amount of memory obtained from the operating
+// system and not yet returned to it, in bytes.
+func memoryInUse() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.Sys - m.HeapReleased
+}
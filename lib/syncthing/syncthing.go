@@ -30,6 +30,7 @@ import (
 	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/discover"
 	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/hooks"
 	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/logger"
 	"github.com/syncthing/syncthing/lib/model"
@@ -41,6 +42,7 @@ import (
 	"github.com/syncthing/syncthing/lib/tlsutil"
 	"github.com/syncthing/syncthing/lib/upgrade"
 	"github.com/syncthing/syncthing/lib/ur"
+	"github.com/syncthing/syncthing/lib/webhook"
 )
 
 const (
@@ -53,16 +55,20 @@ const (
 )
 
 type Options struct {
-	AssetDir         string
-	AuditWriter      io.Writer
-	DeadlockTimeoutS int
-	NoUpgrade        bool
-	ProfilerAddr     string
-	ResetDeltaIdxs   bool
-	Verbose          bool
+	AssetDir          string
+	AuditWriter       io.Writer
+	ConfigAuditWriter io.Writer // receives only the ConfigChanged events
+	DeadlockTimeoutS  int
+	NoUpgrade         bool
+	ProfilerAddr      string
+	ResetDeltaIdxs    bool
+	Verbose           bool
 	// null duration means use default value
 	DBRecheckInterval    time.Duration
 	DBIndirectGCInterval time.Duration
+	// The locations of this instance's files; the process wide default
+	// locations if nil.
+	Locations *locations.Set
 }
 
 type App struct {
@@ -93,6 +99,9 @@ func New(cfg config.Wrapper, dbBackend backend.Backend, evLogger events.Logger,
 		cert:     cert,
 		stopped:  make(chan struct{}),
 	}
+	if a.opts.Locations == nil {
+		a.opts.Locations = locations.Default()
+	}
 	close(a.stopped) // Hasn't been started, so shouldn't block on Wait.
 	return a, nil
 }
@@ -123,16 +132,27 @@ func (a *App) Start() error {
 func (a *App) startup() error {
 	a.mainService.Add(ur.NewFailureHandler(a.cfg, a.evLogger))
 
+	a.mainService.Add(webhook.New(a.cfg, a.evLogger))
+	a.mainService.Add(hooks.New(a.cfg, a.evLogger))
+
 	a.mainService.Add(a.ll)
 
 	if a.opts.AuditWriter != nil {
-		a.mainService.Add(newAuditService(a.opts.AuditWriter, a.evLogger))
+		a.mainService.Add(newAuditService(a.opts.AuditWriter, a.evLogger, events.AllEvents))
+	}
+
+	if a.opts.ConfigAuditWriter != nil {
+		a.mainService.Add(newAuditService(a.opts.ConfigAuditWriter, a.evLogger, events.ConfigChanged))
 	}
 
 	if a.opts.Verbose {
 		a.mainService.Add(newVerboseService(a.evLogger))
 	}
 
+	if opts := a.cfg.Options(); opts.LowMemory() {
+		a.mainService.Add(newMemoryService(opts.MemoryBudgetMiB))
+	}
+
 	errors := logger.NewRecorder(l, logger.LevelWarn, maxSystemErrors, 0)
 	systemLog := logger.NewRecorder(l, logger.LevelDebug, maxSystemLog, initialSystemLog)
 
@@ -160,7 +180,7 @@ func (a *App) startup() error {
 	// Emit the Starting event, now that we know who we are.
 
 	a.evLogger.Log(events.Starting, map[string]string{
-		"home": locations.GetBaseDir(locations.ConfigBaseDir),
+		"home": a.opts.Locations.GetBaseDir(locations.ConfigBaseDir),
 		"myID": a.myID.String(),
 	})
 
@@ -195,10 +215,10 @@ func (a *App) startup() error {
 	}
 
 	protectedFiles := []string{
-		locations.Get(locations.Database),
-		locations.Get(locations.ConfigFile),
-		locations.Get(locations.CertFile),
-		locations.Get(locations.KeyFile),
+		a.opts.Locations.Get(locations.Database),
+		a.opts.Locations.Get(locations.ConfigFile),
+		a.opts.Locations.Get(locations.CertFile),
+		a.opts.Locations.Get(locations.KeyFile),
 	}
 
 	// Remove database entries for folders that no longer exist in the config
@@ -244,6 +264,14 @@ func (a *App) startup() error {
 
 	m := model.NewModel(a.cfg, a.myID, "syncthing", build.Version, a.ll, protectedFiles, a.evLogger)
 
+	// Keep announcing the certificate we are rotating to, if any, until it
+	// replaces the current one.
+	if next, err := tls.LoadX509KeyPair(a.opts.Locations.Get(locations.CertNextFile), a.opts.Locations.Get(locations.KeyNextFile)); err == nil {
+		if err := m.SetNextCertificate(next, a.cert); err != nil {
+			l.Warnln("Announcing next certificate:", err)
+		}
+	}
+
 	if a.opts.DeadlockTimeoutS > 0 {
 		m.StartDeadlockDetector(time.Duration(a.opts.DeadlockTimeoutS) * time.Second)
 	} else if !build.IsRelease || build.IsBeta {
@@ -414,7 +442,7 @@ func (a *App) setupGUI(m model.Model, defaultSub, diskSub events.BufferedSubscri
 	summaryService := model.NewFolderSummaryService(a.cfg, m, a.myID, a.evLogger)
 	a.mainService.Add(summaryService)
 
-	apiSvc := api.New(a.myID, a.cfg, a.opts.AssetDir, tlsDefaultCommonName, m, defaultSub, diskSub, a.evLogger, discoverer, connectionsService, urService, summaryService, errors, systemLog, a.opts.NoUpgrade)
+	apiSvc := api.New(a.myID, a.cfg, a.opts.Locations, a.opts.AssetDir, tlsDefaultCommonName, m, defaultSub, diskSub, a.evLogger, discoverer, connectionsService, urService, summaryService, errors, systemLog, a.opts.NoUpgrade)
 	a.mainService.Add(apiSvc)
 
 	if err := apiSvc.WaitForStart(); err != nil {
@@ -7,6 +7,7 @@
 package syncthing
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -16,6 +17,7 @@ import (
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/tlsutil"
@@ -117,3 +119,61 @@ func TestStartupFail(t *testing.T) {
 		t.Error("Expected error due to db being closed, got", err)
 	}
 }
+
+func TestPromoteNextCertificate(t *testing.T) {
+	dir := t.TempDir()
+	locs, err := locations.NewSet(dir, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := tlsutil.NewCertificate(locs.Get(locations.CertFile), locs.Get(locations.KeyFile), "syncthing", 365)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := protocol.NewDeviceID(cert.Certificate[0])
+
+	// Without a next certificate nothing changes.
+	if got, err := PromoteNextCertificate(cert, locs); err != nil || !bytes.Equal(got.Certificate[0], cert.Certificate[0]) {
+		t.Fatal("certificate changed without rotation:", err)
+	}
+
+	next, err := tlsutil.NewCertificate(locs.Get(locations.CertNextFile), locs.Get(locations.KeyNextFile), "syncthing", 365)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nextID := protocol.NewDeviceID(next.Certificate[0])
+	cfg := config.New(id)
+	if err := config.Wrap(locs.Get(locations.ConfigFile), cfg, id, events.NoopLogger).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Within the overlap period the current certificate stays.
+	if got, err := PromoteNextCertificate(cert, locs); err != nil || !bytes.Equal(got.Certificate[0], cert.Certificate[0]) {
+		t.Fatal("certificate changed during the overlap period:", err)
+	}
+
+	cfg.Options.CertificateRotationOverlapDays = 0
+	if err := config.Wrap(locs.Get(locations.ConfigFile), cfg, id, events.NoopLogger).Save(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := PromoteNextCertificate(cert, locs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Certificate[0], next.Certificate[0]) {
+		t.Fatal("next certificate not promoted")
+	}
+	if _, err := os.Stat(locs.Get(locations.CertNextFile)); !os.IsNotExist(err) {
+		t.Error("next certificate still present:", err)
+	}
+	if _, err := os.Stat(locs.Get(locations.CertFile) + ".old"); err != nil {
+		t.Error("old certificate not kept:", err)
+	}
+	loaded, _, err := config.Load(locs.Get(locations.ConfigFile), nextID, events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded.Device(id); ok {
+		t.Error("old device ID still in config")
+	}
+}
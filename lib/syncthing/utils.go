@@ -8,10 +8,12 @@ package syncthing
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -25,15 +27,12 @@ import (
 )
 
 func LoadOrGenerateCertificate(certFile, keyFile string) (tls.Certificate, error) {
-	cert, err := tls.LoadX509KeyPair(
-		locations.Get(locations.CertFile),
-		locations.Get(locations.KeyFile),
-	)
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
 	if err != nil {
 		l.Infof("Generating ECDSA key and certificate for %s...", tlsDefaultCommonName)
 		return tlsutil.NewCertificate(
-			locations.Get(locations.CertFile),
-			locations.Get(locations.KeyFile),
+			certFile,
+			keyFile,
 			tlsDefaultCommonName,
 			deviceCertLifetimeDays,
 		)
@@ -41,6 +40,56 @@ func LoadOrGenerateCertificate(certFile, keyFile string) (tls.Certificate, error
 	return cert, nil
 }
 
+// PromoteNextCertificate replaces the certificate cert with the one we are
+// rotating to, if there is one and it has been announced to our peers for
+// the configured overlap period. Our own entry in the configuration is moved
+// to the new device ID and the old certificate and key are kept with an
+// ".old" suffix. It returns the certificate to use.
+func PromoteNextCertificate(cert tls.Certificate, locs *locations.Set) (tls.Certificate, error) {
+	cfgPath := locs.Get(locations.ConfigFile)
+	certFile, keyFile := locs.Get(locations.CertFile), locs.Get(locations.KeyFile)
+	nextCertFile, nextKeyFile := locs.Get(locations.CertNextFile), locs.Get(locations.KeyNextFile)
+
+	next, err := tls.LoadX509KeyPair(nextCertFile, nextKeyFile)
+	if err != nil {
+		// No rotation in progress.
+		return cert, nil
+	}
+	nextX509, err := x509.ParseCertificate(next.Certificate[0])
+	if err != nil {
+		return cert, errors.Wrap(err, "parse next certificate")
+	}
+
+	myID := protocol.NewDeviceID(cert.Certificate[0])
+	nextID := protocol.NewDeviceID(next.Certificate[0])
+	cfg, _, err := config.Load(cfgPath, myID, events.NoopLogger)
+	if fs.IsNotExist(err) {
+		return cert, nil
+	} else if err != nil {
+		return cert, errors.Wrap(err, "load config")
+	}
+	overlap := time.Duration(cfg.Options().CertificateRotationOverlapDays) * 24 * time.Hour
+	if promoteAt := nextX509.NotBefore.Add(overlap); time.Now().Before(promoteAt) {
+		l.Infof("Rotating device certificate to %v, which will replace %v after %s", nextID, myID, promoteAt.Format(time.RFC3339))
+		return cert, nil
+	}
+
+	raw := cfg.RawCopy()
+	raw.ReplaceDeviceID(myID, nextID)
+	if err := config.Wrap(cfgPath, raw, nextID, events.NoopLogger).Save(); err != nil {
+		return cert, errors.Wrap(err, "save config")
+	}
+
+	for _, f := range [][2]string{{certFile, certFile + ".old"}, {keyFile, keyFile + ".old"}, {nextCertFile, certFile}, {nextKeyFile, keyFile}} {
+		if err := os.Rename(f[0], f[1]); err != nil {
+			return cert, errors.Wrap(err, "move certificate")
+		}
+	}
+
+	l.Infof("Rotated device certificate: device ID changed from %v to %v", myID, nextID)
+	return next, nil
+}
+
 func DefaultConfig(path string, myID protocol.DeviceID, evLogger events.Logger, noDefaultFolder bool) (config.Wrapper, error) {
 	newCfg, err := config.NewWithFreePorts(myID)
 	if err != nil {
@@ -128,6 +177,12 @@ func copyFile(src, dst string) error {
 	return nil
 }
 
-func OpenDBBackend(path string, tuning config.Tuning) (backend.Backend, error) {
-	return backend.Open(path, backend.Tuning(tuning))
+func OpenDBBackend(path string, typ config.DatabaseBackend, tuning config.Tuning) (backend.Backend, error) {
+	return backend.Open(path, backend.Type(typ), backend.Tuning(tuning))
+}
+
+// DetectDBBackend returns the type of the existing database at path, for
+// when the configured type isn't known.
+func DetectDBBackend(path string) config.DatabaseBackend {
+	return config.DatabaseBackend(backend.Detect(path))
 }
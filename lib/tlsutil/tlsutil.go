@@ -7,9 +7,12 @@
 package tlsutil
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -26,6 +29,7 @@ import (
 
 var (
 	ErrIdentificationFailed = errors.New("failed to identify socket type")
+	ErrBadSignature         = errors.New("signature verification failed")
 )
 
 var (
@@ -158,6 +162,44 @@ func NewCertificate(certFile, keyFile, commonName string, lifetimeDays int) (tls
 	return tls.LoadX509KeyPair(certFile, keyFile)
 }
 
+// Sign returns a signature over data made with the given private key, which
+// must be an ECDSA, RSA or Ed25519 key such as those of certificates
+// returned by NewCertificate.
+func Sign(key crypto.PrivateKey, data []byte) ([]byte, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("key cannot sign")
+	}
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return signer.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+	hash := sha256.Sum256(data)
+	return signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+}
+
+// VerifySignature checks that sig is a signature over data, as returned by
+// Sign, made with the private key of the given certificate.
+func VerifySignature(cert *x509.Certificate, data, sig []byte) error {
+	hash := sha256.Sum256(data)
+	switch pub := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, hash[:], sig) {
+			return ErrBadSignature
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], sig); err != nil {
+			return ErrBadSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, data, sig) {
+			return ErrBadSignature
+		}
+	default:
+		return errors.New("unsupported public key type")
+	}
+	return nil
+}
+
 type DowngradingListener struct {
 	net.Listener
 	TLSConfig *tls.Config
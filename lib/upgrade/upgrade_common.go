@@ -25,6 +25,19 @@ type Release struct {
 	// The HTML URL is needed for human readable links in the output created
 	// by cmd/stupgrades.
 	HTMLURL string `json:"html_url"`
+
+	// MinPeerVersion is the oldest version of other devices the release
+	// still works with, if it doesn't work with all of them.
+	MinPeerVersion string `json:"min_peer_version,omitempty"`
+	// ConfigSchemaChange is set when the release migrates the configuration
+	// to a format that older versions can't use.
+	ConfigSchemaChange bool `json:"config_schema_change,omitempty"`
+}
+
+// IncompatiblePeer returns true if a device running the given version
+// won't work with the release.
+func (r Release) IncompatiblePeer(version string) bool {
+	return r.MinPeerVersion != "" && CompareVersions(version, r.MinPeerVersion) < Equal
 }
 
 type Asset struct {
@@ -39,6 +52,7 @@ type Asset struct {
 var (
 	ErrNoReleaseDownload  = errors.New("couldn't find a release to download")
 	ErrNoVersionToSelect  = errors.New("no version to select")
+	ErrNoUpgradeAvailable = errors.New("no upgrade available")
 	ErrUpgradeUnsupported = errors.New("upgrade unsupported")
 	ErrUpgradeInProgress  = errors.New("upgrade already in progress")
 	upgradeUnlocked       = make(chan bool, 1)
@@ -48,7 +62,43 @@ func init() {
 	upgradeUnlocked <- true
 }
 
+// A Program is a binary that is released as signed archives, which it can
+// upgrade itself from.
+type Program struct {
+	// Name is both the name of the binary in the release archives and the
+	// start of the names of the archives, as in
+	// "syncthing-linux-amd64-v1.18.0.tar.gz".
+	Name string
+	// SigningKey is the public key that release signatures are verified
+	// with.
+	SigningKey []byte
+}
+
+// Syncthing is the program that the package level functions upgrade.
+var Syncthing = Program{
+	Name:       "syncthing",
+	SigningKey: SigningKey,
+}
+
 func To(rel Release) error {
+	return Syncthing.To(rel)
+}
+
+func ToURL(url string) error {
+	return Syncthing.ToURL(url)
+}
+
+func LatestRelease(releasesURL, current string, upgradeToPreReleases bool) (Release, error) {
+	return Syncthing.LatestRelease(releasesURL, current, upgradeToPreReleases)
+}
+
+func ReleaseByTag(releasesURL, current, tag string) (Release, error) {
+	return Syncthing.ReleaseByTag(releasesURL, current, tag)
+}
+
+// To upgrades the running binary to the given release, saving the previous
+// binary with a ".old" extension.
+func (p Program) To(rel Release) error {
 	select {
 	case <-upgradeUnlocked:
 		path, err := os.Executable()
@@ -56,7 +106,7 @@ func To(rel Release) error {
 			upgradeUnlocked <- true
 			return err
 		}
-		err = upgradeTo(path, rel)
+		err = upgradeTo(p, path, rel)
 		// If we've failed to upgrade, unlock so that another attempt could be made
 		if err != nil {
 			upgradeUnlocked <- true
@@ -67,7 +117,8 @@ func To(rel Release) error {
 	}
 }
 
-func ToURL(url string) error {
+// ToURL upgrades the running binary to the release archive at url.
+func (p Program) ToURL(url string) error {
 	select {
 	case <-upgradeUnlocked:
 		binary, err := os.Executable()
@@ -75,7 +126,7 @@ func ToURL(url string) error {
 			upgradeUnlocked <- true
 			return err
 		}
-		err = upgradeToURL(path.Base(url), binary, url)
+		err = upgradeToURL(p, path.Base(url), binary, url)
 		// If we've failed to upgrade, unlock so that another attempt could be made
 		if err != nil {
 			upgradeUnlocked <- true
@@ -86,6 +137,24 @@ func ToURL(url string) error {
 	}
 }
 
+// ToLatest upgrades the running binary to the latest release newer than
+// current, and returns that release. Major upgrades, which may be
+// incompatible, are skipped. ErrNoUpgradeAvailable is returned when there
+// is nothing to upgrade to.
+func (p Program) ToLatest(releasesURL, current string, upgradeToPreReleases bool) (Release, error) {
+	rel, err := p.LatestRelease(releasesURL, current, upgradeToPreReleases)
+	if err != nil {
+		return Release{}, err
+	}
+	if CompareVersions(rel.Tag, current) != Newer {
+		return Release{}, ErrNoUpgradeAvailable
+	}
+	if err := p.To(rel); err != nil {
+		return Release{}, err
+	}
+	return rel, nil
+}
+
 type Relation int
 
 const (
@@ -231,20 +300,21 @@ func versionParts(v string) ([]int, []interface{}) {
 	return release, prerelease
 }
 
-func releaseNames(tag string) []string {
+func (p Program) releaseNames(tag string) []string {
 	// We must ensure that the release asset matches the expected naming
-	// standard, containing both the architecture/OS and the tag name we
-	// expect. This protects against malformed release data potentially
-	// tricking us into doing a downgrade.
+	// standard, containing the program, the architecture/OS and the tag
+	// name we expect. This protects against malformed release data
+	// potentially tricking us into doing a downgrade, or into installing
+	// another program.
 	switch runtime.GOOS {
 	case "darwin":
 		return []string{
-			fmt.Sprintf("syncthing-macos-%s-%s.", runtime.GOARCH, tag),
-			fmt.Sprintf("syncthing-macosx-%s-%s.", runtime.GOARCH, tag),
+			fmt.Sprintf("%s-macos-%s-%s.", p.Name, runtime.GOARCH, tag),
+			fmt.Sprintf("%s-macosx-%s-%s.", p.Name, runtime.GOARCH, tag),
 		}
 	default:
 		return []string{
-			fmt.Sprintf("syncthing-%s-%s-%s.", runtime.GOOS, runtime.GOARCH, tag),
+			fmt.Sprintf("%s-%s-%s-%s.", p.Name, runtime.GOOS, runtime.GOARCH, tag),
 		}
 	}
 }
@@ -75,20 +75,24 @@ var insecureHTTP = &http.Client{
 	},
 }
 
-func insecureGet(url, version string) (*http.Response, error) {
+func insecureGet(url, name, version string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("User-Agent", fmt.Sprintf(`syncthing %s (%s %s-%s)`, version, runtime.Version(), runtime.GOOS, runtime.GOARCH))
+	req.Header.Set("User-Agent", fmt.Sprintf(`%s %s (%s %s-%s)`, name, version, runtime.Version(), runtime.GOOS, runtime.GOARCH))
 	return insecureHTTP.Do(req)
 }
 
 // FetchLatestReleases returns the latest releases. The "current" parameter
 // is used for setting the User-Agent only.
 func FetchLatestReleases(releasesURL, current string) []Release {
-	resp, err := insecureGet(releasesURL, current)
+	return fetchLatestReleases(releasesURL, Syncthing.Name, current)
+}
+
+func fetchLatestReleases(releasesURL, name, current string) []Release {
+	resp, err := insecureGet(releasesURL, name, current)
 	if err != nil {
 		l.Infoln("Couldn't fetch release information:", err)
 		return nil
@@ -120,12 +124,21 @@ func (s SortByRelease) Less(i, j int) bool {
 	return CompareVersions(s[i].Tag, s[j].Tag) > 0
 }
 
-func LatestRelease(releasesURL, current string, upgradeToPreReleases bool) (Release, error) {
-	rels := FetchLatestReleases(releasesURL, current)
-	return SelectLatestRelease(rels, current, upgradeToPreReleases)
+// LatestRelease returns the latest release of the program that is
+// acceptable as an upgrade from current.
+func (p Program) LatestRelease(releasesURL, current string, upgradeToPreReleases bool) (Release, error) {
+	rels := fetchLatestReleases(releasesURL, p.Name, current)
+	return p.SelectLatestRelease(rels, current, upgradeToPreReleases)
 }
 
 func SelectLatestRelease(rels []Release, current string, upgradeToPreReleases bool) (Release, error) {
+	return Syncthing.SelectLatestRelease(rels, current, upgradeToPreReleases)
+}
+
+// SelectLatestRelease returns the latest of the given releases that has an
+// archive of the program for this platform. Its MinPeerVersion and
+// ConfigSchemaChange include those of the releases skipped over.
+func (p Program) SelectLatestRelease(rels []Release, current string, upgradeToPreReleases bool) (Release, error) {
 	if len(rels) == 0 {
 		return Release{}, ErrNoVersionToSelect
 	}
@@ -140,7 +153,7 @@ func SelectLatestRelease(rels []Release, current string, upgradeToPreReleases bo
 			// already found a minor upgrade that is acceptable we should go
 			// with that one first and then revisit in the future.
 			if selected.Tag != "" && CompareVersions(selected.Tag, current) == Newer {
-				return selected, nil
+				break
 			}
 		}
 
@@ -149,18 +162,9 @@ func SelectLatestRelease(rels []Release, current string, upgradeToPreReleases bo
 			continue
 		}
 
-		expectedReleases := releaseNames(rel.Tag)
-	nextAsset:
-		for _, asset := range rel.Assets {
-			assetName := path.Base(asset.Name)
-			// Check for the architecture
-			for _, expRel := range expectedReleases {
-				if strings.HasPrefix(assetName, expRel) {
-					l.Debugln("selected", rel.Tag)
-					selected = rel
-					break nextAsset
-				}
-			}
+		if p.hasAsset(rel) {
+			l.Debugln("selected", rel.Tag)
+			selected = rel
 		}
 	}
 
@@ -168,19 +172,78 @@ func SelectLatestRelease(rels []Release, current string, upgradeToPreReleases bo
 		return Release{}, ErrNoReleaseDownload
 	}
 
-	return selected, nil
+	return p.withSkippedRequirements(selected, rels, current), nil
+}
+
+// ReleaseByTag returns the release of the program with the given tag, which must
+// have an archive for this platform.
+func (p Program) ReleaseByTag(releasesURL, current, tag string) (Release, error) {
+	rels := fetchLatestReleases(releasesURL, p.Name, current)
+	return p.SelectRelease(rels, current, tag)
+}
+
+func SelectRelease(rels []Release, current, tag string) (Release, error) {
+	return Syncthing.SelectRelease(rels, current, tag)
+}
+
+// SelectRelease returns the release among the given ones with the given tag,
+// if it has an archive of the program for this platform. Its MinPeerVersion
+// and ConfigSchemaChange include those of the releases skipped over.
+func (p Program) SelectRelease(rels []Release, current, tag string) (Release, error) {
+	for _, rel := range rels {
+		if rel.Tag != tag {
+			continue
+		}
+		if !p.hasAsset(rel) {
+			return Release{}, ErrNoReleaseDownload
+		}
+		return p.withSkippedRequirements(rel, rels, current), nil
+	}
+	return Release{}, ErrNoVersionToSelect
+}
+
+// hasAsset returns whether the release has an archive of the program for
+// this platform.
+func (p Program) hasAsset(rel Release) bool {
+	expectedReleases := p.releaseNames(rel.Tag)
+	for _, asset := range rel.Assets {
+		assetName := path.Base(asset.Name)
+		// Check for the architecture
+		for _, expRel := range expectedReleases {
+			if strings.HasPrefix(assetName, expRel) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withSkippedRequirements returns the selected release with the
+// requirements of the releases between current and it added, as upgrading
+// skips those releases but not their requirements.
+func (p Program) withSkippedRequirements(selected Release, rels []Release, current string) Release {
+	for _, rel := range rels {
+		if CompareVersions(rel.Tag, current) <= Equal || CompareVersions(rel.Tag, selected.Tag) >= Equal {
+			continue
+		}
+		if rel.MinPeerVersion != "" && (selected.MinPeerVersion == "" || CompareVersions(rel.MinPeerVersion, selected.MinPeerVersion) > Equal) {
+			selected.MinPeerVersion = rel.MinPeerVersion
+		}
+		selected.ConfigSchemaChange = selected.ConfigSchemaChange || rel.ConfigSchemaChange
+	}
+	return selected
 }
 
 // Upgrade to the given release, saving the previous binary with a ".old" extension.
-func upgradeTo(binary string, rel Release) error {
-	expectedReleases := releaseNames(rel.Tag)
+func upgradeTo(p Program, binary string, rel Release) error {
+	expectedReleases := p.releaseNames(rel.Tag)
 	for _, asset := range rel.Assets {
 		assetName := path.Base(asset.Name)
 		l.Debugln("considering release", assetName)
 
 		for _, expRel := range expectedReleases {
 			if strings.HasPrefix(assetName, expRel) {
-				return upgradeToURL(assetName, binary, asset.URL)
+				return upgradeToURL(p, assetName, binary, asset.URL)
 			}
 		}
 	}
@@ -189,8 +252,8 @@ func upgradeTo(binary string, rel Release) error {
 }
 
 // Upgrade to the given release, saving the previous binary with a ".old" extension.
-func upgradeToURL(archiveName, binary string, url string) error {
-	fname, err := readRelease(archiveName, filepath.Dir(binary), url)
+func upgradeToURL(p Program, archiveName, binary string, url string) error {
+	fname, err := readRelease(p, archiveName, filepath.Dir(binary), url)
 	if err != nil {
 		return err
 	}
@@ -209,7 +272,7 @@ func upgradeToURL(archiveName, binary string, url string) error {
 	return nil
 }
 
-func readRelease(archiveName, dir, url string) (string, error) {
+func readRelease(p Program, archiveName, dir, url string) (string, error) {
 	l.Debugf("loading %q", url)
 
 	req, err := http.NewRequest("GET", url, nil)
@@ -226,13 +289,13 @@ func readRelease(archiveName, dir, url string) (string, error) {
 
 	switch path.Ext(archiveName) {
 	case ".zip":
-		return readZip(archiveName, dir, io.LimitReader(resp.Body, maxArchiveSize))
+		return readZip(p, archiveName, dir, io.LimitReader(resp.Body, maxArchiveSize))
 	default:
-		return readTarGz(archiveName, dir, io.LimitReader(resp.Body, maxArchiveSize))
+		return readTarGz(p, archiveName, dir, io.LimitReader(resp.Body, maxArchiveSize))
 	}
 }
 
-func readTarGz(archiveName, dir string, r io.Reader) (string, error) {
+func readTarGz(p Program, archiveName, dir string, r io.Reader) (string, error) {
 	gr, err := gzip.NewReader(r)
 	if err != nil {
 		return "", err
@@ -265,7 +328,7 @@ func readTarGz(archiveName, dir string, r io.Reader) (string, error) {
 			break
 		}
 
-		err = archiveFileVisitor(dir, &tempName, &sig, hdr.Name, tr)
+		err = archiveFileVisitor(p, dir, &tempName, &sig, hdr.Name, tr)
 		if err != nil {
 			return "", err
 		}
@@ -275,14 +338,14 @@ func readTarGz(archiveName, dir string, r io.Reader) (string, error) {
 		}
 	}
 
-	if err := verifyUpgrade(archiveName, tempName, sig); err != nil {
+	if err := verifyUpgrade(p.SigningKey, archiveName, tempName, sig); err != nil {
 		return "", err
 	}
 
 	return tempName, nil
 }
 
-func readZip(archiveName, dir string, r io.Reader) (string, error) {
+func readZip(p Program, archiveName, dir string, r io.Reader) (string, error) {
 	body, err := ioutil.ReadAll(r)
 	if err != nil {
 		return "", err
@@ -315,7 +378,7 @@ func readZip(archiveName, dir string, r io.Reader) (string, error) {
 			return "", err
 		}
 
-		err = archiveFileVisitor(dir, &tempName, &sig, file.Name, inFile)
+		err = archiveFileVisitor(p, dir, &tempName, &sig, file.Name, inFile)
 		inFile.Close()
 		if err != nil {
 			return "", err
@@ -326,7 +389,7 @@ func readZip(archiveName, dir string, r io.Reader) (string, error) {
 		}
 	}
 
-	if err := verifyUpgrade(archiveName, tempName, sig); err != nil {
+	if err := verifyUpgrade(p.SigningKey, archiveName, tempName, sig); err != nil {
 		return "", err
 	}
 
@@ -335,21 +398,21 @@ func readZip(archiveName, dir string, r io.Reader) (string, error) {
 
 // archiveFileVisitor is called for each file in an archive. It may set
 // tempFile and signature.
-func archiveFileVisitor(dir string, tempFile *string, signature *[]byte, archivePath string, filedata io.Reader) error {
+func archiveFileVisitor(p Program, dir string, tempFile *string, signature *[]byte, archivePath string, filedata io.Reader) error {
 	var err error
 	filename := path.Base(archivePath)
 	archiveDir := path.Dir(archivePath)
 	l.Debugf("considering file %s", archivePath)
 	switch filename {
-	case "syncthing", "syncthing.exe":
+	case p.Name, p.Name + ".exe":
 		archiveDirs := strings.Split(archiveDir, "/")
 		if len(archiveDirs) > 1 {
-			// Don't consider "syncthing" files found too deeply, as they may be
+			// Don't consider binaries found too deeply, as they may be
 			// other things.
 			return nil
 		}
 		l.Debugf("found upgrade binary %s", archivePath)
-		*tempFile, err = writeBinary(dir, io.LimitReader(filedata, maxBinarySize))
+		*tempFile, err = writeBinary(dir, p.Name, io.LimitReader(filedata, maxBinarySize))
 		if err != nil {
 			return err
 		}
@@ -365,7 +428,7 @@ func archiveFileVisitor(dir string, tempFile *string, signature *[]byte, archive
 	return nil
 }
 
-func verifyUpgrade(archiveName, tempName string, sig []byte) error {
+func verifyUpgrade(key []byte, archiveName, tempName string, sig []byte) error {
 	if tempName == "" {
 		return errors.New("no upgrade found")
 	}
@@ -388,11 +451,12 @@ func verifyUpgrade(archiveName, tempName string, sig []byte) error {
 	// - the temp file contents
 	//
 	// We then verify the release signature against the contents of this
-	// multireader. This ensures that it is not only a bonafide syncthing
-	// binary, but it is also of exactly the platform and version we expect.
+	// multireader. This ensures that it is not only a bonafide binary of
+	// the program, but it is also of exactly the platform and version we
+	// expect.
 
 	mr := io.MultiReader(bytes.NewBufferString(archiveName+"\n"), fd)
-	err = signature.Verify(SigningKey, sig, mr)
+	err = signature.Verify(key, sig, mr)
 	fd.Close()
 
 	if err != nil {
@@ -403,10 +467,10 @@ func verifyUpgrade(archiveName, tempName string, sig []byte) error {
 	return nil
 }
 
-func writeBinary(dir string, inFile io.Reader) (filename string, err error) {
+func writeBinary(dir, name string, inFile io.Reader) (filename string, err error) {
 	// Write the binary to a temporary file.
 
-	outFile, err := ioutil.TempFile(dir, "syncthing")
+	outFile, err := ioutil.TempFile(dir, name)
 	if err != nil {
 		return "", err
 	}
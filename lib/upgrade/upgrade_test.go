@@ -102,7 +102,7 @@ func TestSelectedRelease(t *testing.T) {
 				Prerelease: strings.Contains(c, "-"),
 				Assets: []Asset{
 					// There must be a matching asset or it will not get selected
-					{Name: releaseNames(c)[0]},
+					{Name: Syncthing.releaseNames(c)[0]},
 				},
 			})
 		}
@@ -154,3 +154,106 @@ func TestSelectedReleaseMacOS(t *testing.T) {
 		}
 	}
 }
+
+func TestSelectedReleaseProgram(t *testing.T) {
+	relay := Program{Name: "strelaysrv", SigningKey: SigningKey}
+
+	rels := []Release{
+		{
+			Tag: "v1.18.1",
+			Assets: []Asset{
+				{Name: Syncthing.releaseNames("v1.18.1")[0] + "tar.gz"},
+			},
+		},
+		{
+			Tag: "v1.18.0",
+			Assets: []Asset{
+				{Name: relay.releaseNames("v1.18.0")[0] + "tar.gz"},
+			},
+		},
+	}
+
+	// Only releases with an archive of the program itself are considered.
+	sel, err := relay.SelectLatestRelease(rels, "v1.17.0", false)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if sel.Tag != "v1.18.0" {
+		t.Error("wrong tag selected:", sel.Tag)
+	}
+
+	sel, err = SelectLatestRelease(rels, "v1.17.0", false)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if sel.Tag != "v1.18.1" {
+		t.Error("wrong tag selected:", sel.Tag)
+	}
+}
+
+func TestSelectedReleaseRequirements(t *testing.T) {
+	release := func(tag, minPeer string, schemaChange bool) Release {
+		return Release{
+			Tag:                tag,
+			Assets:             []Asset{{Name: Syncthing.releaseNames(tag)[0] + "tar.gz"}},
+			MinPeerVersion:     minPeer,
+			ConfigSchemaChange: schemaChange,
+		}
+	}
+	rels := []Release{
+		release("v1.17.0", "v1.0.0", true),
+		release("v1.18.0", "v1.12.0", true),
+		release("v1.19.0", "v1.10.0", false),
+		release("v1.20.0", "", false),
+	}
+
+	// The requirements of the releases skipped over are kept, but not
+	// those of the running one or older.
+	sel, err := SelectLatestRelease(rels, "v1.17.0", false)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if sel.Tag != "v1.20.0" || sel.MinPeerVersion != "v1.12.0" || !sel.ConfigSchemaChange {
+		t.Errorf("unexpected selection %+v", sel)
+	}
+	if !sel.IncompatiblePeer("v1.11.2") || sel.IncompatiblePeer("v1.12.0") {
+		t.Error("wrong peer compatibility")
+	}
+
+	sel, err = SelectLatestRelease(rels, "v1.18.0", false)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if sel.MinPeerVersion != "v1.10.0" || sel.ConfigSchemaChange {
+		t.Errorf("unexpected selection %+v", sel)
+	}
+	if (Release{}).IncompatiblePeer("v0.14.0") {
+		t.Error("release without requirements is incompatible")
+	}
+}
+
+func TestSelectReleaseByTag(t *testing.T) {
+	relay := Program{Name: "strelaysrv", SigningKey: SigningKey}
+	rels := []Release{
+		{Tag: "v1.19.0", Assets: []Asset{{Name: Syncthing.releaseNames("v1.19.0")[0] + "tar.gz"}}},
+		{Tag: "v1.18.0", Assets: []Asset{{Name: Syncthing.releaseNames("v1.18.0")[0] + "tar.gz"}}, MinPeerVersion: "v1.12.0"},
+		{Tag: "v1.17.0", Assets: []Asset{{Name: Syncthing.releaseNames("v1.17.0")[0] + "tar.gz"}}},
+	}
+
+	// An older release than the latest one can be selected, keeping the
+	// requirements of those skipped over.
+	sel, err := SelectRelease(rels, "v1.16.0", "v1.18.0")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if sel.Tag != "v1.18.0" || sel.MinPeerVersion != "v1.12.0" {
+		t.Errorf("unexpected selection %+v", sel)
+	}
+
+	if _, err := SelectRelease(rels, "v1.16.0", "v1.20.0"); err != ErrNoVersionToSelect {
+		t.Error("expected no version error, got", err)
+	}
+	if _, err := relay.SelectRelease(rels, "v1.16.0", "v1.18.0"); err != ErrNoReleaseDownload {
+		t.Error("expected no download error, got", err)
+	}
+}
@@ -10,14 +10,18 @@ package upgrade
 
 const DisabledByCompilation = true
 
-func upgradeTo(binary string, rel Release) error {
+func upgradeTo(p Program, binary string, rel Release) error {
 	return ErrUpgradeUnsupported
 }
 
-func upgradeToURL(archiveName, binary, url string) error {
+func upgradeToURL(p Program, archiveName, binary, url string) error {
 	return ErrUpgradeUnsupported
 }
 
-func LatestRelease(releasesURL, current string, upgradeToPreRelease bool) (Release, error) {
+func (p Program) LatestRelease(releasesURL, current string, upgradeToPreRelease bool) (Release, error) {
+	return Release{}, ErrUpgradeUnsupported
+}
+
+func (p Program) ReleaseByTag(releasesURL, current, tag string) (Release, error) {
 	return Release{}, ErrUpgradeUnsupported
 }
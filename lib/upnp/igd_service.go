@@ -128,3 +128,8 @@ func (s *IGDService) GetLocalIPAddress() net.IP {
 func (s *IGDService) ID() string {
 	return s.UUID + "/" + s.Device.FriendlyName + "/" + s.ServiceID + "/" + s.URN + "/" + s.URL
 }
+
+// Type returns the port mapping protocol spoken by the service
+func (s *IGDService) Type() string {
+	return "UPnP"
+}
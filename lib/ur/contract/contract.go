@@ -29,21 +29,21 @@ type Report struct {
 	Version        string  `json:"version,omitempty" since:"1"`
 	LongVersion    string  `json:"longVersion,omitempty" since:"1"`
 	Platform       string  `json:"platform,omitempty" since:"1"`
-	NumFolders     int     `json:"numFolders,omitempty" since:"1"`
-	NumDevices     int     `json:"numDevices,omitempty" since:"1"`
-	TotFiles       int     `json:"totFiles,omitempty" since:"1"`
-	FolderMaxFiles int     `json:"folderMaxFiles,omitempty" since:"1"`
-	TotMiB         int     `json:"totMiB,omitempty" since:"1"`
-	FolderMaxMiB   int     `json:"folderMaxMiB,omitempty" since:"1"`
-	MemoryUsageMiB int     `json:"memoryUsageMiB,omitempty" since:"1"`
-	SHA256Perf     float64 `json:"sha256Perf,omitempty" since:"1"`
-	HashPerf       float64 `json:"hashPerf,omitempty" since:"1"` // Was previously not stored server-side
-	MemorySize     int     `json:"memorySize,omitempty" since:"1"`
+	NumFolders     int     `json:"numFolders,omitempty" since:"1" category:"folders"`
+	NumDevices     int     `json:"numDevices,omitempty" since:"1" category:"devices"`
+	TotFiles       int     `json:"totFiles,omitempty" since:"1" category:"folders"`
+	FolderMaxFiles int     `json:"folderMaxFiles,omitempty" since:"1" category:"folders"`
+	TotMiB         int     `json:"totMiB,omitempty" since:"1" category:"folders"`
+	FolderMaxMiB   int     `json:"folderMaxMiB,omitempty" since:"1" category:"folders"`
+	MemoryUsageMiB int     `json:"memoryUsageMiB,omitempty" since:"1" category:"performance"`
+	SHA256Perf     float64 `json:"sha256Perf,omitempty" since:"1" category:"performance"`
+	HashPerf       float64 `json:"hashPerf,omitempty" since:"1" category:"performance"` // Was previously not stored server-side
+	MemorySize     int     `json:"memorySize,omitempty" since:"1" category:"performance"`
 
 	// v2 fields
 
 	URVersion  int `json:"urVersion,omitempty" since:"2"`
-	NumCPU     int `json:"numCPU,omitempty" since:"2"`
+	NumCPU     int `json:"numCPU,omitempty" since:"2" category:"performance"`
 	FolderUses struct {
 		SendOnly            int `json:"sendonly,omitempty" since:"2"`
 		SendReceive         int `json:"sendreceive,omitempty" since:"2"` // Was previously not stored server-side
@@ -55,7 +55,7 @@ type Report struct {
 		ExternalVersioning  int `json:"externalVersioning,omitempty" since:"2"`
 		StaggeredVersioning int `json:"staggeredVersioning,omitempty" since:"2"`
 		TrashcanVersioning  int `json:"trashcanVersioning,omitempty" since:"2"`
-	} `json:"folderUses,omitempty" since:"2"`
+	} `json:"folderUses,omitempty" since:"2" category:"folders"`
 
 	DeviceUses struct {
 		Introducer       int `json:"introducer,omitempty" since:"2"`
@@ -65,7 +65,7 @@ type Report struct {
 		CompressNever    int `json:"compressNever,omitempty" since:"2"`
 		DynamicAddr      int `json:"dynamicAddr,omitempty" since:"2"`
 		StaticAddr       int `json:"staticAddr,omitempty" since:"2"`
-	} `json:"deviceUses,omitempty" since:"2"`
+	} `json:"deviceUses,omitempty" since:"2" category:"devices"`
 
 	Announce struct {
 		GlobalEnabled     bool `json:"globalEnabled,omitempty" since:"2"`
@@ -73,40 +73,40 @@ type Report struct {
 		DefaultServersDNS int  `json:"defaultServersDNS,omitempty" since:"2"`
 		DefaultServersIP  int  `json:"defaultServersIP,omitempty" since:"2"` // Deprecated and not provided client-side anymore
 		OtherServers      int  `json:"otherServers,omitempty" since:"2"`
-	} `json:"announce,omitempty" since:"2"`
+	} `json:"announce,omitempty" since:"2" category:"network"`
 
 	Relays struct {
 		Enabled        bool `json:"enabled,omitempty" since:"2"`
 		DefaultServers int  `json:"defaultServers,omitempty" since:"2"`
 		OtherServers   int  `json:"otherServers,omitempty" since:"2"`
-	} `json:"relays,omitempty" since:"2"`
+	} `json:"relays,omitempty" since:"2" category:"network"`
 
-	UsesRateLimit        bool `json:"usesRateLimit,omitempty" since:"2"`
+	UsesRateLimit        bool `json:"usesRateLimit,omitempty" since:"2" category:"network"`
 	UpgradeAllowedManual bool `json:"upgradeAllowedManual,omitempty" since:"2"`
 	UpgradeAllowedAuto   bool `json:"upgradeAllowedAuto,omitempty" since:"2"`
 
 	// V2.5 fields (fields that were in v2 but never added to the database
 	UpgradeAllowedPre bool  `json:"upgradeAllowedPre,omitempty" since:"2"`
-	RescanIntvs       []int `json:"rescanIntvs,omitempty" since:"2"`
+	RescanIntvs       []int `json:"rescanIntvs,omitempty" since:"2" category:"folders"`
 
 	// v3 fields
 
-	Uptime                     int    `json:"uptime,omitempty" since:"3"`
-	NATType                    string `json:"natType,omitempty" since:"3"`
-	AlwaysLocalNets            bool   `json:"alwaysLocalNets,omitempty" since:"3"`
+	Uptime                     int    `json:"uptime,omitempty" since:"3" category:"performance"`
+	NATType                    string `json:"natType,omitempty" since:"3" category:"network"`
+	AlwaysLocalNets            bool   `json:"alwaysLocalNets,omitempty" since:"3" category:"network"`
 	CacheIgnoredFiles          bool   `json:"cacheIgnoredFiles,omitempty" since:"3"`
 	OverwriteRemoteDeviceNames bool   `json:"overwriteRemoteDeviceNames,omitempty" since:"3"`
 	ProgressEmitterEnabled     bool   `json:"progressEmitterEnabled,omitempty" since:"3"`
 	CustomDefaultFolderPath    bool   `json:"customDefaultFolderPath,omitempty" since:"3"`
 	WeakHashSelection          string `json:"weakHashSelection,omitempty" since:"3"` // Deprecated and not provided client-side anymore
-	CustomTrafficClass         bool   `json:"customTrafficClass,omitempty" since:"3"`
+	CustomTrafficClass         bool   `json:"customTrafficClass,omitempty" since:"3" category:"network"`
 	CustomTempIndexMinBlocks   bool   `json:"customTempIndexMinBlocks,omitempty" since:"3"`
 	TemporariesDisabled        bool   `json:"temporariesDisabled,omitempty" since:"3"`
 	TemporariesCustom          bool   `json:"temporariesCustom,omitempty" since:"3"`
-	LimitBandwidthInLan        bool   `json:"limitBandwidthInLan,omitempty" since:"3"`
+	LimitBandwidthInLan        bool   `json:"limitBandwidthInLan,omitempty" since:"3" category:"network"`
 	CustomReleaseURL           bool   `json:"customReleaseURL,omitempty" since:"3"`
 	RestartOnWakeup            bool   `json:"restartOnWakeup,omitempty" since:"3"`
-	CustomStunServers          bool   `json:"customStunServers,omitempty" since:"3"`
+	CustomStunServers          bool   `json:"customStunServers,omitempty" since:"3" category:"network"`
 
 	FolderUsesV3 struct {
 		ScanProgressDisabled    int            `json:"scanProgressDisabled,omitempty" since:"3"`
@@ -129,11 +129,11 @@ type Report struct {
 		BlockPullOrder          map[string]int `json:"blockPullOrder,omitempty" since:"3"`
 		CopyRangeMethod         map[string]int `json:"copyRangeMethod,omitempty" since:"3"`
 		CaseSensitiveFS         int            `json:"caseSensitiveFS,omitempty" since:"3"`
-	} `json:"folderUsesV3,omitempty" since:"3"`
+	} `json:"folderUsesV3,omitempty" since:"3" category:"folders"`
 
 	DeviceUsesV3 struct {
 		Untrusted int `json:"untrusted,omitempty" since:"3"`
-	} `json:"deviceUsesV3,omitempty" since:"3"`
+	} `json:"deviceUsesV3,omitempty" since:"3" category:"devices"`
 
 	GUIStats struct {
 		Enabled                   int            `json:"enabled,omitempty" since:"3"`
@@ -146,7 +146,7 @@ type Report struct {
 		ListenLocal               int            `json:"listenLocal,omitempty" since:"3"`
 		ListenUnspecified         int            `json:"listenUnspecified,omitempty" since:"3"`
 		Theme                     map[string]int `json:"theme,omitempty" since:"3"`
-	} `json:"guiStats,omitempty" since:"3"`
+	} `json:"guiStats,omitempty" since:"3" category:"gui"`
 
 	BlockStats struct {
 		Total             int `json:"total,omitempty" since:"3"`
@@ -156,9 +156,9 @@ type Report struct {
 		CopyOrigin        int `json:"copyOrigin,omitempty" since:"3"`
 		CopyOriginShifted int `json:"copyOriginShifted,omitempty" since:"3"`
 		CopyElsewhere     int `json:"copyElsewhere,omitempty" since:"3"`
-	} `json:"blockStats,omitempty" since:"3"`
+	} `json:"blockStats,omitempty" since:"3" category:"performance"`
 
-	TransportStats map[string]int `json:"transportStats,omitempty" since:"3"`
+	TransportStats map[string]int `json:"transportStats,omitempty" since:"3" category:"network"`
 
 	IgnoreStats struct {
 		Lines           int `json:"lines,omitempty" since:"3"`
@@ -170,7 +170,7 @@ type Report struct {
 		EscapedIncludes int `json:"escapedIncludes,omitempty" since:"3"`
 		DoubleStars     int `json:"doubleStars,omitempty" since:"3"`
 		Stars           int `json:"stars,omitempty" since:"3"`
-	} `json:"ignoreStats,omitempty" since:"3"`
+	} `json:"ignoreStats,omitempty" since:"3" category:"folders"`
 
 	// V3 fields added late in the RC
 	WeakHashEnabled bool `json:"weakHashEnabled,omitempty" since:"3"` // Deprecated and not provided client-side anymore
@@ -205,6 +205,30 @@ func (r *Report) ClearForVersion(version int) error {
 	return clear(r, version)
 }
 
+// Categories are the categories of usage data users may leave out of their
+// reports, as set in the category tags of the Report fields. Fields
+// without a category are always sent.
+var Categories = []string{"performance", "folders", "devices", "network", "gui"}
+
+// ClearCategories zeroes the fields in the given categories.
+func (r *Report) ClearCategories(categories []string) {
+	if len(categories) == 0 {
+		return
+	}
+	clearCategories := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		clearCategories[c] = true
+	}
+	s := reflect.ValueOf(r).Elem()
+	t := s.Type()
+	for i := 0; i < s.NumField(); i++ {
+		if clearCategories[t.Field(i).Tag.Get("category")] {
+			f := s.Field(i)
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+}
+
 func (r *Report) FieldPointers() []interface{} {
 	// All the fields of the Report, in the same order as the database fields.
 	return []interface{}{
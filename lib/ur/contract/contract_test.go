@@ -152,3 +152,39 @@ func TestMarshallingBehaviour(t *testing.T) {
 		t.Errorf("%d != 0", r.FolderUses.SendOnly)
 	}
 }
+
+func TestClearCategories(t *testing.T) {
+	// Every category is in use, and no field has an unknown one.
+	used := make(map[string]bool)
+	rt := reflect.TypeOf(Report{})
+	for i := 0; i < rt.NumField(); i++ {
+		if c := rt.Field(i).Tag.Get("category"); c != "" {
+			used[c] = true
+		}
+	}
+	for _, c := range Categories {
+		if !used[c] {
+			t.Errorf("category %q is not used", c)
+		}
+		delete(used, c)
+	}
+	for c := range used {
+		t.Errorf("unknown category %q", c)
+	}
+
+	r := New()
+	r.UniqueID = "abc"
+	r.NumFolders = 3
+	r.NumDevices = 2
+	r.SHA256Perf = 1.5
+	r.FolderUses.SendOnly = 1
+	r.GUIStats.Theme["dark"] = 1
+	r.ClearCategories([]string{"folders", "gui"})
+
+	if r.UniqueID != "abc" || r.NumDevices != 2 || r.SHA256Perf != 1.5 {
+		t.Error("cleared fields outside the categories")
+	}
+	if r.NumFolders != 0 || r.FolderUses.SendOnly != 0 || r.GUIStats.Theme != nil {
+		t.Error("didn't clear the fields in the categories")
+	}
+}
@@ -69,9 +69,35 @@ func (s *Service) ReportDataPreview(ctx context.Context, urVersion int) (*contra
 	return s.reportData(ctx, urVersion, true)
 }
 
+// Payload returns exactly what would be sent as the usage report right now,
+// or nil if usage reporting isn't enabled.
+func (s *Service) Payload(ctx context.Context) ([]byte, error) {
+	return s.payload(ctx, true)
+}
+
+func (s *Service) payload(ctx context.Context, preview bool) ([]byte, error) {
+	urVersion := s.cfg.Options().URAccepted
+	if urVersion < 2 {
+		return nil, nil
+	}
+	d, err := s.reportData(ctx, urVersion, preview)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := json.NewEncoder(&b).Encode(d); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
 func (s *Service) reportData(ctx context.Context, urVersion int, preview bool) (*contract.Report, error) {
 	opts := s.cfg.Options()
 	defaultFolder := s.cfg.DefaultFolder()
+	excluded := make(map[string]bool, len(opts.URExcludedCategories))
+	for _, c := range opts.URExcludedCategories {
+		excluded[c] = true
+	}
 
 	var totFiles, maxFiles int
 	var totBytes, maxBytes int64
@@ -109,8 +135,11 @@ func (s *Service) reportData(ctx context.Context, urVersion int, preview bool) (
 	report.TotMiB = int(totBytes / 1024 / 1024)
 	report.FolderMaxMiB = int(maxBytes / 1024 / 1024)
 	report.MemoryUsageMiB = int((mem.Sys - mem.HeapReleased) / 1024 / 1024)
-	report.SHA256Perf = CpuBench(ctx, 5, 125*time.Millisecond, false)
-	report.HashPerf = CpuBench(ctx, 5, 125*time.Millisecond, true)
+	if !excluded["performance"] {
+		// Takes a while, so don't bother if it won't be sent.
+		report.SHA256Perf = CpuBench(ctx, 5, 125*time.Millisecond, false)
+		report.HashPerf = CpuBench(ctx, 5, 125*time.Millisecond, true)
+	}
 	report.MemorySize = int(memorySize() / 1024 / 1024)
 	report.NumCPU = runtime.NumCPU()
 
@@ -317,6 +346,7 @@ func (s *Service) reportData(ctx context.Context, urVersion int, preview bool) (
 	if err := report.ClearForVersion(urVersion); err != nil {
 		return nil, err
 	}
+	report.ClearCategories(opts.URExcludedCategories)
 
 	return report, nil
 }
@@ -326,12 +356,8 @@ func (s *Service) UptimeS() int {
 }
 
 func (s *Service) sendUsageReport(ctx context.Context) error {
-	d, err := s.ReportData(ctx)
-	if err != nil {
-		return err
-	}
-	var b bytes.Buffer
-	if err := json.NewEncoder(&b).Encode(d); err != nil {
+	bs, err := s.payload(ctx, false)
+	if err != nil || bs == nil {
 		return err
 	}
 
@@ -344,7 +370,7 @@ func (s *Service) sendUsageReport(ctx context.Context) error {
 			},
 		},
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", s.cfg.Options().URURL, &b)
+	req, err := http.NewRequestWithContext(ctx, "POST", s.cfg.Options().URURL, bytes.NewReader(bs))
 	if err != nil {
 		return err
 	}
@@ -432,7 +458,7 @@ func cpuBenchOnce(ctx context.Context, duration time.Duration, useWeakHash bool,
 	var err error
 	for time.Since(t0) < duration {
 		r := bytes.NewReader(bs)
-		blocksResult, err = scanner.Blocks(ctx, r, protocol.MinBlockSize, int64(len(bs)), nil, useWeakHash)
+		blocksResult, err = scanner.Blocks(ctx, r, protocol.MinBlockSize, int64(len(bs)), nil, protocol.BlockHashAlgorithmSHA256, useWeakHash)
 		if err != nil {
 			return 0 // Context done
 		}
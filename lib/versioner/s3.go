@@ -0,0 +1,205 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package versioner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/osutil"
+	s3client "github.com/syncthing/syncthing/lib/s3"
+)
+
+func init() {
+	// Register the constructor for this type of versioner with the name "s3"
+	factories["s3"] = newS3
+}
+
+// The s3 versioner uploads versions to a bucket in S3 compatible object
+// storage, named like those of the simple versioner, instead of keeping
+// them on disk. Expiring old versions is left to the lifecycle rules of
+// the bucket. The credentials are taken from the AWS_ACCESS_KEY_ID and
+// AWS_SECRET_ACCESS_KEY environment variables, so that they don't end up in
+// the configuration.
+type s3 struct {
+	folderFs fs.Filesystem
+	prefix   string
+	client   *s3client.Client
+}
+
+func newS3(cfg config.FolderConfiguration) Versioner {
+	params := cfg.Versioning.Params
+	s := &s3{
+		folderFs: cfg.Filesystem(),
+		prefix:   strings.Trim(params["prefix"], "/"),
+		client:   s3client.NewClient(params["endpoint"], params["bucket"], params["region"], os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")),
+	}
+
+	l.Debugf("instantiated s3 versioner for %s", s.client)
+	return s
+}
+
+// Archive uploads the named file as a new version and removes it from the
+// folder. If this function returns nil, the named file does not exist any
+// more (has been archived).
+func (v *s3) Archive(filePath string) error {
+	filePath = osutil.NativeFilename(filePath)
+	info, err := v.folderFs.Lstat(filePath)
+	if fs.IsNotExist(err) {
+		l.Debugln("not archiving nonexistent file", filePath)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if info.IsSymlink() {
+		panic("bug: attempting to version a symlink")
+	}
+	if info.Size() > s3client.MaxObjectSize {
+		return fmt.Errorf("%s is larger than the maximum object size of 5 GiB", filePath)
+	}
+
+	fd, err := v.folderFs.Open(filePath)
+	if err != nil {
+		return err
+	}
+	key := v.objectKey(TagFilename(filePath, time.Now().Format(TimeFormat)))
+	l.Debugln("archiving", filePath, "to", key)
+	header := http.Header{}
+	header.Set(s3client.MtimeHeader, info.ModTime().UTC().Format(time.RFC3339Nano))
+	resp, err := v.client.Request(http.MethodPut, key, nil, fd, info.Size(), header)
+	fd.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return v.folderFs.Remove(filePath)
+}
+
+// GetVersions lists the versions in the bucket. As the modification times
+// of the files aren't part of the listing, the upload times are used.
+func (v *s3) GetVersions() (map[string][]FileVersion, error) {
+	prefix := ""
+	if v.prefix != "" {
+		prefix = v.prefix + "/"
+	}
+
+	objects, _, err := v.client.List(prefix, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]FileVersion)
+	for _, obj := range objects {
+		name, tag := UntagFilename(strings.TrimPrefix(obj.Key, prefix))
+		if name == "" || tag == "" {
+			continue
+		}
+		versionTime, err := time.ParseInLocation(TimeFormat, tag, time.Local)
+		if err != nil {
+			continue
+		}
+		files[name] = append(files[name], FileVersion{
+			VersionTime: versionTime,
+			ModTime:     obj.LastModified.Truncate(time.Second),
+			Size:        obj.Size,
+		})
+	}
+
+	for _, versions := range files {
+		sort.Slice(versions, func(a, b int) bool {
+			return versions[a].VersionTime.Before(versions[b].VersionTime)
+		})
+	}
+	return files, nil
+}
+
+// Restore downloads the given version into the folder, archiving the file
+// currently there, if any.
+func (v *s3) Restore(filePath string, versionTime time.Time) error {
+	filePath = osutil.NativeFilename(filePath)
+	tag := versionTime.In(time.Local).Truncate(time.Second).Format(TimeFormat)
+	key := v.objectKey(TagFilename(filePath, tag))
+
+	// Fetch the version first, so that nothing changes in the folder if it
+	// doesn't exist.
+	resp, err := v.client.Request(http.MethodGet, key, nil, nil, 0, nil)
+	if s3client.IsNotFound(err) {
+		return errNotFound
+	} else if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if info, err := v.folderFs.Lstat(filePath); err == nil {
+		switch {
+		case info.IsDir():
+			return ErrDirectory
+		case info.IsSymlink():
+			// Remove existing symlinks (as we don't want to archive them)
+			if err := v.folderFs.Remove(filePath); err != nil {
+				return fmt.Errorf("removing existing symlink: %w", err)
+			}
+		case info.IsRegular():
+			if err := v.Archive(filePath); err != nil {
+				return fmt.Errorf("archiving existing file: %w", err)
+			}
+		default:
+			panic("bug: unknown item type")
+		}
+	} else if !fs.IsNotExist(err) {
+		return err
+	}
+
+	// Download to a temporary file next to the target, so that a failed
+	// download doesn't leave a partial file.
+	dir := filepath.Dir(filePath)
+	_ = v.folderFs.MkdirAll(dir, 0755)
+	tempName := filepath.Join(dir, fs.TempName(filepath.Base(filePath)))
+	fd, err := v.folderFs.Create(tempName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fd, resp.Body)
+	if cerr := fd.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = v.folderFs.Remove(tempName)
+		return err
+	}
+
+	mtime := versionTime
+	if t, err := time.Parse(time.RFC3339Nano, resp.Header.Get(s3client.MtimeHeader)); err == nil {
+		mtime = t
+	}
+	_ = v.folderFs.Chtimes(tempName, mtime, mtime)
+	return v.folderFs.Rename(tempName, filePath)
+}
+
+func (v *s3) Clean(_ context.Context) error {
+	return nil
+}
+
+func (v *s3) String() string {
+	return fmt.Sprintf("s3@%p", v)
+}
+
+// objectKey returns the key of the object for the given version name.
+func (v *s3) objectKey(name string) string {
+	return path.Join(v.prefix, filepath.ToSlash(osutil.NormalizedFilename(name)))
+}
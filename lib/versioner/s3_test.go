@@ -0,0 +1,119 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package versioner
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	s3client "github.com/syncthing/syncthing/lib/s3"
+)
+
+func TestS3ArchiveRestore(t *testing.T) {
+	srv := s3client.NewFake("bucket", "key")
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	setenv(t, "AWS_ACCESS_KEY_ID", "key")
+	setenv(t, "AWS_SECRET_ACCESS_KEY", "secret")
+
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           tmpDir,
+		Versioning: config.VersioningConfiguration{
+			Type: "s3",
+			Params: map[string]string{
+				"endpoint": ts.URL,
+				"bucket":   "bucket",
+				"prefix":   "/versions/",
+			},
+		},
+	}
+	folderFs := cfg.Filesystem()
+	versioner := newS3(cfg)
+
+	if err := folderFs.MkdirAll("dir", 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, folderFs, "dir/file.txt", "A")
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := folderFs.Chtimes("dir/file.txt", mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := versioner.Archive("dir/file.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := folderFs.Lstat("dir/file.txt"); !fs.IsNotExist(err) {
+		t.Fatal("expected the file to be removed, got", err)
+	}
+
+	versions, err := versioner.GetVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 || len(versions["dir/file.txt"]) != 1 {
+		t.Fatalf("unexpected versions %v", versions)
+	}
+	version := versions["dir/file.txt"][0]
+	if version.Size != 1 {
+		t.Errorf("unexpected size %d", version.Size)
+	}
+	for _, key := range srv.Keys() {
+		if !strings.HasPrefix(key, "versions/dir/file~") || !strings.HasSuffix(key, ".txt") {
+			t.Errorf("unexpected object key %q", key)
+		}
+	}
+
+	if err := versioner.Restore("dir/file.txt", version.VersionTime.Add(time.Hour)); err != errNotFound {
+		t.Errorf("expected %v, got %v", errNotFound, err)
+	}
+
+	// Restoring archives the file that's there now.
+	writeFile(t, folderFs, "dir/file.txt", "B")
+	time.Sleep(time.Second)
+	if err := versioner.Restore("dir/file.txt", version.VersionTime); err != nil {
+		t.Fatal(err)
+	}
+	if content := readFile(t, folderFs, "dir/file.txt"); content != "A" {
+		t.Errorf("expected A got %s", content)
+	}
+	if info, err := folderFs.Lstat("dir/file.txt"); err != nil {
+		t.Fatal(err)
+	} else if !info.ModTime().Equal(mtime) {
+		t.Errorf("expected mtime %v, got %v", mtime, info.ModTime())
+	}
+	if len(srv.Keys()) != 2 {
+		t.Errorf("expected two versions, got %d", len(srv.Keys()))
+	}
+}
+
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+	old, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
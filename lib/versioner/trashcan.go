@@ -25,17 +25,21 @@ type trashcan struct {
 	folderFs        fs.Filesystem
 	versionsFs      fs.Filesystem
 	cleanoutDays    int
+	maxSize         int64
 	copyRangeMethod fs.CopyRangeMethod
 }
 
 func newTrashcan(cfg config.FolderConfiguration) Versioner {
 	cleanoutDays, _ := strconv.Atoi(cfg.Versioning.Params["cleanoutDays"])
 	// On error we default to 0, "do not clean out the trash can"
+	maxSizeMiB, _ := strconv.ParseInt(cfg.Versioning.Params["maxSizeMiB"], 10, 64)
+	// Likewise 0, "no limit on the size of the trash can"
 
 	s := &trashcan{
 		folderFs:        cfg.Filesystem(),
 		versionsFs:      versionerFsFromFolderCfg(cfg),
 		cleanoutDays:    cleanoutDays,
+		maxSize:         maxSizeMiB << 20,
 		copyRangeMethod: cfg.CopyRangeMethod,
 	}
 
@@ -56,7 +60,10 @@ func (t *trashcan) String() string {
 }
 
 func (t *trashcan) Clean(ctx context.Context) error {
-	return cleanByDay(ctx, t.versionsFs, t.cleanoutDays)
+	if err := cleanByDay(ctx, t.versionsFs, t.cleanoutDays); err != nil {
+		return err
+	}
+	return cleanBySize(ctx, t.versionsFs, t.maxSize)
 }
 
 func (t *trashcan) GetVersions() (map[string][]FileVersion, error) {
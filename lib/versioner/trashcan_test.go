@@ -7,7 +7,10 @@
 package versioner
 
 import (
+	"context"
 	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -98,6 +101,61 @@ func TestTrashcanArchiveRestoreSwitcharoo(t *testing.T) {
 	}
 }
 
+func TestTrashcanCleanBySize(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           tmpDir,
+		Versioning: config.VersioningConfiguration{
+			Params: map[string]string{"maxSizeMiB": "2"},
+		},
+	}
+	versioner := newTrashcan(cfg).(*trashcan)
+	if versioner.maxSize != 2<<20 {
+		t.Fatalf("unexpected max size %d", versioner.maxSize)
+	}
+	versionsFs := versioner.versionsFs
+
+	// Three versions of a MiB each, archived a minute apart, oldest first.
+	now := time.Now()
+	for i, name := range []string{"a", "dir/b", "c"} {
+		if err := versionsFs.MkdirAll("dir", 0755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, versionsFs, name, strings.Repeat("x", 1<<20))
+		mtime := now.Add(time.Duration(i-3) * time.Minute)
+		if err := versionsFs.Chtimes(name, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := versioner.Clean(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := versioner.GetVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 || len(versions["dir/b"]) != 1 || len(versions["c"]) != 1 {
+		t.Errorf("expected the oldest version to be removed, got %v", versions)
+	}
+
+	// Removing b as well removes the then empty directory.
+	versioner.maxSize = 1 << 20
+	if err := versioner.Clean(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := versionsFs.Lstat("dir"); !fs.IsNotExist(err) {
+		t.Errorf("expected the empty directory to be removed, got %v", err)
+	}
+}
+
 func readFile(t *testing.T, filesystem fs.Filesystem, name string) string {
 	t.Helper()
 	fd, err := filesystem.Open(name)
@@ -334,3 +334,68 @@ func cleanByDay(ctx context.Context, versionsFs fs.Filesystem, cleanoutDays int)
 
 	return nil
 }
+
+// cleanBySize removes the oldest files from the versions directory until
+// the remaining ones take up at most maxSize bytes.
+func cleanBySize(ctx context.Context, versionsFs fs.Filesystem, maxSize int64) error {
+	if maxSize <= 0 {
+		return nil
+	}
+
+	if _, err := versionsFs.Lstat("."); fs.IsNotExist(err) {
+		return nil
+	}
+
+	type version struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var versions []version
+	var total int64
+	dirTracker := make(emptyDirTracker)
+
+	walkFn := func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if info.IsDir() && !info.IsSymlink() {
+			dirTracker.addDir(path)
+			return nil
+		}
+
+		versions = append(versions, version{path, info.Size(), info.ModTime()})
+		total += info.Size()
+		return nil
+	}
+
+	if err := versionsFs.Walk(".", walkFn); err != nil {
+		return err
+	}
+
+	// The modification time of versions is when they were archived.
+	sort.Slice(versions, func(a, b int) bool {
+		return versions[a].modTime.Before(versions[b].modTime)
+	})
+	for _, v := range versions {
+		if total <= maxSize {
+			dirTracker.addFile(v.path)
+			continue
+		}
+		if err := versionsFs.Remove(v.path); err != nil {
+			return err
+		}
+		total -= v.size
+	}
+
+	dirTracker.deleteEmptyDirs(versionsFs)
+
+	return nil
+}
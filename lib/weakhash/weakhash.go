@@ -87,6 +87,29 @@ func NewFinder(ctx context.Context, ir io.ReadSeeker, size int, hashesToFind []u
 	}, nil
 }
 
+// NewFinderAt is like NewFinder, but only looks for blocks in the part of
+// the reader following the start offset.
+func NewFinderAt(ctx context.Context, ir io.ReadSeeker, start int64, size int, hashesToFind []uint32) (*Finder, error) {
+	if _, err := ir.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	offsets, err := Find(ctx, ir, hashesToFind, size)
+	if err != nil {
+		return nil, err
+	}
+	for hash, hashOffsets := range offsets {
+		for i := range hashOffsets {
+			offsets[hash][i] += start
+		}
+	}
+
+	return &Finder{
+		reader:  ir,
+		size:    size,
+		offsets: offsets,
+	}, nil
+}
+
 type Finder struct {
 	reader  io.ReadSeeker
 	size    int
@@ -67,3 +67,29 @@ func TestFinder(t *testing.T) {
 		t.Errorf("Not equal: %#v != %#v", actual, expected)
 	}
 }
+
+func TestFinderAt(t *testing.T) {
+	hashes := []uint32{65143183, 65798547}
+	finder, err := NewFinderAt(context.Background(), bytes.NewReader(payload), 30, 4, hashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[uint32][]int64{
+		65143183: {53, 79},
+		65798547: {54, 80},
+	}
+	if !reflect.DeepEqual(finder.offsets, expected) {
+		t.Errorf("Not equal: %#v != %#v", finder.offsets, expected)
+	}
+
+	b := make([]byte, 4)
+	if _, err := finder.Iterate(hashes[0], b, func(offset int64) bool {
+		if !bytes.Equal(b, payload[offset:offset+4]) {
+			t.Errorf("Not equal at %d: %s != %s", offset, string(b), string(payload[offset:offset+4]))
+		}
+		return true
+	}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package webhook posts selected events to the HTTP endpoints configured
+// as webhooks.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/thejerf/suture/v4"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/dialer"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// DefaultEvents are the events posted by webhooks that don't select any
+// event types themselves.
+const DefaultEvents = events.FolderCompletion | events.DeviceConnected | events.FolderErrors | events.UpgradeAvailable
+
+const (
+	// Number of events queued per webhook while earlier ones are being
+	// delivered; further events are dropped.
+	queueSize   = 64
+	maxAttempts = 5
+	sendTimeout = 30 * time.Second
+)
+
+var (
+	// The wait before retrying a failed delivery, doubled for every
+	// further attempt.
+	initialBackoff = time.Second
+	maxBackoff     = time.Minute
+)
+
+// The Payload is posted as JSON to the webhook URL. The Text summarizes the
+// event, making the payload directly usable with chat services such as
+// Slack or Matrix hookshot.
+type Payload struct {
+	ID       int         `json:"id"`
+	DeviceID string      `json:"deviceID"`
+	Time     time.Time   `json:"time"`
+	Type     string      `json:"type"`
+	Data     interface{} `json:"data"`
+	Text     string      `json:"text"`
+}
+
+type Service interface {
+	suture.Service
+	config.Committer
+}
+
+func New(cfg config.Wrapper, evLogger events.Logger) Service {
+	return &service{
+		cfg:      cfg,
+		evLogger: evLogger,
+		hookChan: make(chan []config.WebhookConfiguration),
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: dialer.DialContext,
+				Proxy:       http.ProxyFromEnvironment,
+			},
+		},
+		completed: make(map[string]bool),
+	}
+}
+
+type service struct {
+	cfg      config.Wrapper
+	evLogger events.Logger
+	hookChan chan []config.WebhookConfiguration
+	client   *http.Client
+
+	// Only touched by the Serve loop.
+	completed     map[string]bool
+	latestUpgrade string
+}
+
+// A hook delivers the events queued for a single webhook.
+type hook struct {
+	cfg   config.WebhookConfiguration
+	mask  events.EventType
+	queue chan Payload
+}
+
+func (s *service) Serve(ctx context.Context) error {
+	cfg := s.cfg.Subscribe(s)
+	defer s.cfg.Unsubscribe(s)
+
+	var sub events.Subscription
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+	}()
+
+	var hooks []*hook
+	var evChan <-chan events.Event
+	var hooksCancel context.CancelFunc = func() {}
+	defer func() { hooksCancel() }()
+
+	apply := func(confs []config.WebhookConfiguration) {
+		hooksCancel()
+		var hooksCtx context.Context
+		hooksCtx, hooksCancel = context.WithCancel(ctx)
+
+		hooks = hooks[:0]
+		var mask events.EventType
+		for _, conf := range confs {
+			h, err := newHook(conf)
+			if err != nil {
+				l.Warnf("Webhook %s: %v", conf.ID, err)
+				continue
+			}
+			if h == nil {
+				continue
+			}
+			hooks = append(hooks, h)
+			mask |= h.mask
+			go s.deliver(hooksCtx, h)
+		}
+
+		if sub != nil && sub.Mask() == mask {
+			return
+		}
+		if sub != nil {
+			sub.Unsubscribe()
+			sub, evChan = nil, nil
+		}
+		if mask != 0 {
+			sub = s.evLogger.Subscribe(mask)
+			evChan = sub.C()
+		}
+	}
+	apply(cfg.Webhooks)
+
+	for {
+		select {
+		case confs := <-s.hookChan:
+			apply(confs)
+		case ev, ok := <-evChan:
+			if !ok {
+				evChan = nil
+				continue
+			}
+			if !s.shouldPost(ev) {
+				continue
+			}
+			payload := s.newPayload(ev)
+			for _, h := range hooks {
+				if h.mask&ev.Type == 0 {
+					continue
+				}
+				select {
+				case h.queue <- payload:
+				default:
+					l.Debugf("Webhook %s: queue full, dropping %s event", h.cfg.ID, ev.Type)
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// newHook returns the hook for the given configuration, or nil if the
+// webhook is disabled.
+func newHook(conf config.WebhookConfiguration) (*hook, error) {
+	if !conf.Enabled || conf.URL == "" {
+		return nil, nil
+	}
+	if req, err := http.NewRequest(http.MethodPost, conf.URL, nil); err != nil {
+		return nil, err
+	} else if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme %q", req.URL.Scheme)
+	}
+
+	mask := DefaultEvents
+	if len(conf.Events) > 0 {
+		mask = 0
+		for _, name := range conf.Events {
+			evType := events.UnmarshalEventType(name)
+			if evType == 0 {
+				return nil, fmt.Errorf("unknown event type %q", name)
+			}
+			mask |= evType
+		}
+	}
+
+	return &hook{
+		cfg:   conf,
+		mask:  mask,
+		queue: make(chan Payload, queueSize),
+	}, nil
+}
+
+// shouldPost filters out events that would be noise when posted: folder
+// completion is only of interest once a folder becomes fully synced, and
+// the same upgrade may be seen by several upgrade checks.
+func (s *service) shouldPost(ev events.Event) bool {
+	switch ev.Type {
+	case events.FolderCompletion:
+		key := fmt.Sprint(field(ev.Data, "folder"), "/", field(ev.Data, "device"))
+		pct, _ := field(ev.Data, "completion").(float64)
+		wasCompleted, known := s.completed[key]
+		s.completed[key] = pct >= 100
+		return known && !wasCompleted && pct >= 100
+	case events.UpgradeAvailable:
+		latest := fmt.Sprint(field(ev.Data, "latest"))
+		if latest == s.latestUpgrade {
+			return false
+		}
+		s.latestUpgrade = latest
+	}
+	return true
+}
+
+func (s *service) newPayload(ev events.Event) Payload {
+	return Payload{
+		ID:       ev.GlobalID,
+		DeviceID: s.cfg.MyID().String(),
+		Time:     ev.Time,
+		Type:     ev.Type.String(),
+		Data:     ev.Data,
+		Text:     s.eventText(ev),
+	}
+}
+
+func (s *service) eventText(ev events.Event) string {
+	switch ev.Type {
+	case events.FolderCompletion:
+		return fmt.Sprintf("Folder %s is up to date on device %s", s.folderName(field(ev.Data, "folder")), s.deviceName(field(ev.Data, "device")))
+	case events.DeviceConnected:
+		return fmt.Sprintf("Device %s connected from %v", s.deviceName(field(ev.Data, "id")), field(ev.Data, "addr"))
+	case events.FolderErrors:
+		n := 0
+		if errs := reflect.ValueOf(field(ev.Data, "errors")); errs.Kind() == reflect.Slice {
+			n = errs.Len()
+		}
+		return fmt.Sprintf("Folder %s failed to sync %d items", s.folderName(field(ev.Data, "folder")), n)
+	case events.UpgradeAvailable:
+		return fmt.Sprintf("Syncthing %v is available (running %v)", field(ev.Data, "latest"), field(ev.Data, "running"))
+	}
+	return fmt.Sprintf("Syncthing event %s", ev.Type)
+}
+
+func (s *service) folderName(id interface{}) string {
+	if folder, ok := s.cfg.Folder(fmt.Sprint(id)); ok {
+		return folder.Description()
+	}
+	return fmt.Sprintf("%q", id)
+}
+
+func (s *service) deviceName(id interface{}) string {
+	devID, err := protocol.DeviceIDFromString(fmt.Sprint(id))
+	if err != nil {
+		return fmt.Sprint(id)
+	}
+	if dev, ok := s.cfg.Device(devID); ok && dev.Name != "" {
+		return fmt.Sprintf("%q (%s)", dev.Name, devID.Short())
+	}
+	return devID.Short().String()
+}
+
+// field returns the value of the given key in the event data, or nil.
+func field(data interface{}, key string) interface{} {
+	switch data := data.(type) {
+	case map[string]interface{}:
+		return data[key]
+	case map[string]string:
+		if v, ok := data[key]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// deliver posts the queued events to the webhook, in order, retrying
+// failed deliveries with exponential backoff.
+func (s *service) deliver(ctx context.Context, h *hook) {
+	for {
+		select {
+		case payload := <-h.queue:
+			s.deliverPayload(ctx, h, payload)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *service) deliverPayload(ctx context.Context, h *hook, payload Payload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		l.Warnf("Webhook %s: %v", h.cfg.ID, err)
+		return
+	}
+
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(ctx, h.cfg, payload, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt == maxAttempts {
+			l.Infof("Webhook %s: failed to post %s event: %v", h.cfg.ID, payload.Type, err)
+			return
+		}
+		l.Debugf("Webhook %s: attempt %d to post %s event failed, retrying in %v: %v", h.cfg.ID, attempt, payload.Type, backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// post makes a single delivery attempt, returning whether a failure is
+// worth retrying.
+func (s *service) post(ctx context.Context, conf config.WebhookConfiguration, payload Payload, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, conf.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "syncthing/"+build.Version)
+	req.Header.Set("X-Syncthing-Event", payload.Type)
+	req.Header.Set("X-Syncthing-Delivery", strconv.Itoa(payload.ID))
+	if conf.Secret != "" {
+		req.Header.Set("X-Syncthing-Signature", "sha256="+Sign(conf.Secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body with the secret, as
+// sent in the X-Syncthing-Signature header.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *service) VerifyConfiguration(_, _ config.Configuration) error {
+	return nil
+}
+
+func (s *service) CommitConfiguration(from, to config.Configuration) bool {
+	if !reflect.DeepEqual(from.Webhooks, to.Webhooks) {
+		s.hookChan <- to.Webhooks
+	}
+	return true
+}
+
+func (s *service) String() string {
+	return "webhook.Service"
+}
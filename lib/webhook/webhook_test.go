@@ -0,0 +1,140 @@
+// Copyright (C) 2021 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestDelivery(t *testing.T) {
+	initialBackoff = time.Millisecond
+
+	var received []Payload
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// The first attempt fails and should be retried.
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if sig := r.Header.Get("X-Syncthing-Signature"); sig != "sha256="+Sign("secret", body) {
+			t.Errorf("bad signature %q", sig)
+		}
+		if ev := r.Header.Get("X-Syncthing-Event"); ev != "UpgradeAvailable" {
+			t.Errorf("unexpected event header %q", ev)
+		}
+		var payload Payload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Error(err)
+		}
+		received = append(received, payload)
+	}))
+	defer srv.Close()
+
+	w := config.Wrap("", config.New(protocol.LocalDeviceID), protocol.LocalDeviceID, events.NoopLogger)
+	svc := New(w, events.NoopLogger).(*service)
+
+	h, err := newHook(config.WebhookConfiguration{ID: "test", URL: srv.URL, Enabled: true, Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.deliverPayload(context.Background(), h, svc.newPayload(events.Event{
+		GlobalID: 42,
+		Time:     time.Now(),
+		Type:     events.UpgradeAvailable,
+		Data:     map[string]string{"running": "v1.0.0", "latest": "v1.1.0"},
+	}))
+
+	if attempts != 2 {
+		t.Errorf("expected two attempts, got %d", attempts)
+	}
+	if len(received) != 1 {
+		t.Fatalf("expected one payload, got %d", len(received))
+	}
+	if p := received[0]; p.ID != 42 || p.Type != "UpgradeAvailable" || p.Text != "Syncthing v1.1.0 is available (running v1.0.0)" {
+		t.Errorf("unexpected payload %+v", p)
+	}
+}
+
+func TestNewHook(t *testing.T) {
+	cases := []struct {
+		conf config.WebhookConfiguration
+		mask events.EventType
+		err  bool
+	}{
+		{config.WebhookConfiguration{URL: "https://example.com/hook", Enabled: true}, DefaultEvents, false},
+		{config.WebhookConfiguration{URL: "https://example.com/hook", Enabled: true, Events: []string{"DeviceDisconnected", "FolderErrors"}}, events.DeviceDisconnected | events.FolderErrors, false},
+		{config.WebhookConfiguration{URL: "https://example.com/hook", Enabled: false}, 0, false},
+		{config.WebhookConfiguration{URL: "https://example.com/hook", Enabled: true, Events: []string{"NoSuchEvent"}}, 0, true},
+		{config.WebhookConfiguration{URL: "ftp://example.com/hook", Enabled: true}, 0, true},
+	}
+	for _, tc := range cases {
+		h, err := newHook(tc.conf)
+		if (err != nil) != tc.err {
+			t.Errorf("%+v: unexpected error %v", tc.conf, err)
+			continue
+		}
+		var mask events.EventType
+		if h != nil {
+			mask = h.mask
+		}
+		if mask != tc.mask {
+			t.Errorf("%+v: mask %v != expected %v", tc.conf, mask, tc.mask)
+		}
+	}
+}
+
+func TestShouldPost(t *testing.T) {
+	svc := New(nil, events.NoopLogger).(*service)
+
+	completion := func(pct float64) events.Event {
+		return events.Event{Type: events.FolderCompletion, Data: map[string]interface{}{
+			"folder":     "default",
+			"device":     "device",
+			"completion": pct,
+		}}
+	}
+	for i, tc := range []struct {
+		pct  float64
+		post bool
+	}{
+		{100, false}, // initial state
+		{50, false},
+		{75, false},
+		{100, true}, // became complete
+		{100, false},
+	} {
+		if post := svc.shouldPost(completion(tc.pct)); post != tc.post {
+			t.Errorf("%d: completion %v: post %v != expected %v", i, tc.pct, post, tc.post)
+		}
+	}
+
+	upgrade := func(latest string) events.Event {
+		return events.Event{Type: events.UpgradeAvailable, Data: map[string]string{"latest": latest}}
+	}
+	if !svc.shouldPost(upgrade("v1.1.0")) {
+		t.Error("first upgrade should be posted")
+	}
+	if svc.shouldPost(upgrade("v1.1.0")) {
+		t.Error("same upgrade should not be posted again")
+	}
+	if !svc.shouldPost(upgrade("v1.2.0")) {
+		t.Error("newer upgrade should be posted")
+	}
+}